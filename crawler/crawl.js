@@ -19,9 +19,12 @@
  *   --include        Glob pattern for URLs to include (can specify multiple)
  *   --exclude        Glob pattern for URLs to exclude (can specify multiple)
  *   --rag-url        RAG service URL (default: http://localhost:8080)
+ *   --api-key        Tenant API key sent as X-API-Key (default: $RAG_API_KEY)
  *   --delay          Delay between requests in ms (default: 1000)
  *   --dry-run        Don't actually ingest, just print URLs
  *   --debug          Show converted markdown for debugging
+ *   --cache          File remembering each page's ETag/Last-Modified (default: .crawl-cache.json)
+ *   --refresh        Re-ingest every page, even if unchanged since the last crawl
  *
  * Examples:
  *   node crawl.js --tenant-id abc123 --url https://docs.example.com \
@@ -30,6 +33,7 @@
 
 import { PlaywrightCrawler, Configuration } from 'crawlee';
 import { parseArgs } from 'node:util';
+import { existsSync, readFileSync, writeFileSync } from 'node:fs';
 import { htmlToMarkdown, extractMetadata, findMainContent } from './html-to-markdown.js';
 
 // Parse command line arguments
@@ -42,9 +46,12 @@ const { values: args } = parseArgs({
     'include': { type: 'string', multiple: true, default: [] },
     'exclude': { type: 'string', multiple: true, default: [] },
     'rag-url': { type: 'string', default: 'http://localhost:8080' },
+    'api-key': { type: 'string', default: process.env.RAG_API_KEY || '' },
     'delay': { type: 'string', default: '1000' },
     'dry-run': { type: 'boolean', default: false },
     'debug': { type: 'boolean', default: false },
+    'cache': { type: 'string', default: '.crawl-cache.json' },
+    'refresh': { type: 'boolean', default: false },
     'help': { type: 'boolean', default: false },
   },
 });
@@ -70,9 +77,12 @@ Options:
   --include        Glob pattern for URLs to include (can specify multiple)
   --exclude        Glob pattern for URLs to exclude (can specify multiple)
   --rag-url        RAG service URL (default: http://localhost:8080)
+  --api-key        Tenant API key sent as X-API-Key (default: $RAG_API_KEY)
   --delay          Delay between requests in ms (default: 1000)
   --dry-run        Don't actually ingest, just print URLs and markdown
   --debug          Show converted markdown for debugging
+  --cache          File remembering each page's ETag/Last-Modified (default: .crawl-cache.json)
+  --refresh        Re-ingest every page, even if unchanged since the last crawl
   --help           Show this help message
 
 Examples:
@@ -97,9 +107,12 @@ const config = {
   includePatterns: args['include'],
   excludePatterns: args['exclude'],
   ragUrl: args['rag-url'],
+  apiKey: args['api-key'],
   delayMs: parseInt(args['delay'], 10),
   dryRun: args['dry-run'],
   debug: args['debug'],
+  cacheFile: args['cache'],
+  refresh: args['refresh'],
 };
 
 // Extract domain from start URL for domain restriction
@@ -175,8 +188,48 @@ const stats = {
   ingested: 0,
   failed: 0,
   skipped: 0,
+  unchanged: 0,
 };
 
+// Validator cache: per tenant and URL, the ETag and Last-Modified of the
+// last ingested version of a page and the links found on it, so a re-crawl
+// skips unchanged pages but still follows their links
+function loadCache() {
+  if (!existsSync(config.cacheFile)) return {};
+  try {
+    return JSON.parse(readFileSync(config.cacheFile, 'utf8'));
+  } catch (error) {
+    console.warn(`Ignoring unreadable cache ${config.cacheFile}: ${error.message}`);
+    return {};
+  }
+}
+
+const cache = loadCache();
+const pageCache = cache[config.tenantId] || (cache[config.tenantId] = {});
+
+function saveCache() {
+  if (config.dryRun) return;
+  writeFileSync(config.cacheFile, JSON.stringify(cache, null, 2));
+}
+
+// Ask the server whether a page changed since it was cached; a 304 means
+// it did not, anything else (including errors) means crawl it
+async function isUnchanged(url) {
+  const cached = pageCache[url];
+  if (config.refresh || !cached || (!cached.etag && !cached.lastModified)) return false;
+
+  const headers = {};
+  if (cached.etag) headers['If-None-Match'] = cached.etag;
+  if (cached.lastModified) headers['If-Modified-Since'] = cached.lastModified;
+  try {
+    const response = await fetch(url, { headers, redirect: 'manual' });
+    await response.body?.cancel();
+    return response.status === 304;
+  } catch {
+    return false;
+  }
+}
+
 // Ingest document to RAG service
 async function ingestDocument(url, title, content, metadata = {}) {
   if (config.dryRun) {
@@ -197,6 +250,7 @@ async function ingestDocument(url, title, content, metadata = {}) {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
+        ...(config.apiKey && { 'X-API-Key': config.apiKey }),
       },
       body: JSON.stringify({
         tenant_id: config.tenantId,
@@ -211,6 +265,7 @@ async function ingestDocument(url, title, content, metadata = {}) {
       const result = await response.json();
       console.log(`✓ Ingested: ${url} (doc: ${result.documentId || result.document_id || 'unknown'})`);
       stats.ingested++;
+      return true;
     } else {
       const error = await response.text();
       console.error(`✗ Failed to ingest ${url}: ${response.status} - ${error}`);
@@ -220,6 +275,7 @@ async function ingestDocument(url, title, content, metadata = {}) {
     console.error(`✗ Error ingesting ${url}: ${error.message}`);
     stats.failed++;
   }
+  return false;
 }
 
 // Main crawler
@@ -232,9 +288,11 @@ async function main() {
   console.log(`Include patterns: ${config.includePatterns.length > 0 ? config.includePatterns.join(', ') : '(all)'}`);
   console.log(`Exclude patterns: ${config.excludePatterns.length > 0 ? config.excludePatterns.join(', ') : '(defaults only)'}`);
   console.log(`RAG URL: ${config.ragUrl}`);
+  console.log(`API key: ${config.apiKey ? '(set)' : '(none)'}`);
   console.log(`Delay: ${config.delayMs}ms`);
   console.log(`Dry run: ${config.dryRun}`);
   console.log(`Debug: ${config.debug}`);
+  console.log(`Cache: ${config.cacheFile}${config.refresh ? ' (refreshing all pages)' : ''}`);
   console.log('');
 
   const includeGlobs = buildGlobs();
@@ -289,20 +347,21 @@ async function main() {
           });
         });
       },
+      // Skip rendering pages unchanged since the last crawl
+      async ({ request }) => {
+        if (await isUnchanged(request.url)) {
+          request.skipNavigation = true;
+          request.userData.unchanged = true;
+        }
+      },
     ],
 
     // Request handler
-    async requestHandler({ request, page, enqueueLinks, log }) {
+    async requestHandler({ request, response, page, enqueueLinks, log }) {
       const url = request.url;
       stats.crawled++;
 
-      log.info(`Crawling [${stats.crawled}/${config.maxPages}]: ${url}`);
-
-      // Wait for page to be fully loaded
-      await page.waitForLoadState('networkidle', { timeout: 30000 }).catch(() => {});
-
-      // IMPORTANT: Enqueue links BEFORE modifying the DOM (content extraction removes nav elements)
-      await enqueueLinks({
+      const linkOptions = {
         globs: includeGlobs,
         exclude: excludeGlobs,
         transformRequestFunction: (req) => {
@@ -313,7 +372,24 @@ async function main() {
           req.userData.depth = (request.userData.depth || 0) + 1;
           return req;
         },
-      });
+      };
+
+      // An unchanged page was not rendered; follow the links it had last time
+      if (request.userData.unchanged) {
+        log.info(`Unchanged [${stats.crawled}/${config.maxPages}]: ${url}`);
+        stats.unchanged++;
+        await enqueueLinks({ ...linkOptions, urls: pageCache[url].links || [] });
+        return;
+      }
+
+      log.info(`Crawling [${stats.crawled}/${config.maxPages}]: ${url}`);
+
+      // Wait for page to be fully loaded
+      await page.waitForLoadState('networkidle', { timeout: 30000 }).catch(() => {});
+
+      // IMPORTANT: Enqueue links BEFORE modifying the DOM (content extraction removes nav elements)
+      await enqueueLinks(linkOptions);
+      const links = await page.$$eval('a[href]', anchors => anchors.map(a => a.href)).catch(() => []);
 
       // Extract content using smart HTML-to-Markdown conversion
       const result = await page.evaluate(({ htmlToMarkdownCode, extractMetadataCode, findMainContentCode }) => {
@@ -376,7 +452,10 @@ async function main() {
           'nav, footer, header, aside, ' +
           '[role="navigation"], [role="banner"], [role="contentinfo"], ' +
           '.sidebar, .nav, .navigation, .menu, .footer, .header, ' +
-          '.advertisement, .ad, .ads, .social-share, .comments'
+          '.advertisement, .ad, .ads, .social-share, .comments, ' +
+          '[role="dialog"], [aria-hidden="true"], ' +
+          '[id*="cookie" i], [class*="cookie" i], [id*="consent" i], [class*="consent" i], ' +
+          '.newsletter, .related, .breadcrumb, .breadcrumbs, .pagination'
         );
         elementsToRemove.forEach(el => el.remove());
 
@@ -677,7 +756,10 @@ async function main() {
           'nav, footer, header, aside, ' +
           '[role="navigation"], [role="banner"], [role="contentinfo"], ' +
           '.sidebar, .nav, .navigation, .menu, .footer, .header, ' +
-          '.advertisement, .ad, .ads, .social-share, .comments'
+          '.advertisement, .ad, .ads, .social-share, .comments, ' +
+          '[role="dialog"], [aria-hidden="true"], ' +
+          '[id*="cookie" i], [class*="cookie" i], [id*="consent" i], [class*="consent" i], ' +
+          '.newsletter, .related, .breadcrumb, .breadcrumbs, .pagination'
         );
         elementsToRemove.forEach(el => el.remove());
 
@@ -700,7 +782,61 @@ async function main() {
             }
           }
         }
-        mainContent = mainContent || document.body;
+        let extractionMethod = mainContent ? 'selector' : 'readability';
+
+        // No semantic container: score paragraphs readability-style and pick
+        // the element with the most (non-link) text
+        if (!mainContent) {
+          const positive = /article|body|content|entry|main|post|text|blog|story|documentation/i;
+          const negative = /comment|foot|footer|meta|promo|related|share|sidebar|sponsor|widget|advert/i;
+          const scores = new Map();
+
+          const linkDensity = (el) => {
+            const total = (el.textContent || '').trim().length || 1;
+            let links = 0;
+            el.querySelectorAll('a').forEach(a => { links += (a.textContent || '').trim().length; });
+            return links / total;
+          };
+          const initialScore = (el) => {
+            let score = { DIV: 5, ARTICLE: 10, SECTION: 3, PRE: 3, TD: 3, BLOCKQUOTE: 3 }[el.tagName] || 0;
+            const hint = `${el.className || ''} ${el.id || ''}`;
+            if (positive.test(hint)) score += 25;
+            if (negative.test(hint)) score -= 25;
+            return score;
+          };
+
+          document.body.querySelectorAll('p, pre, td, blockquote').forEach(p => {
+            const text = (p.textContent || '').trim();
+            if (text.length < 25) return;
+            // One point per paragraph and per comma, plus up to 3 for length
+            const score = text.split(',').length + Math.min(Math.floor(text.length / 100), 3);
+            [p.parentElement, p.parentElement?.parentElement].forEach((el, level) => {
+              if (!el) return;
+              if (!scores.has(el)) scores.set(el, initialScore(el));
+              scores.set(el, scores.get(el) + (level === 0 ? score : score / 2));
+            });
+          });
+
+          let bestScore = 0;
+          for (const [el, score] of scores) {
+            const final = score * (1 - linkDensity(el));
+            if (final > bestScore) {
+              bestScore = final;
+              mainContent = el;
+            }
+          }
+        }
+
+        if (!mainContent || (mainContent.textContent || '').trim().length < 250) {
+          mainContent = document.body;
+          extractionMethod = 'body';
+        }
+
+        const pageTextLength = (document.body.textContent || '').trim().length || 1;
+        const extraction = {
+          method: extractionMethod,
+          textRatio: ((mainContent.textContent || '').trim().length / pageTextLength).toFixed(2),
+        };
 
         // Get title
         const title = document.querySelector('title')?.textContent?.trim() ||
@@ -717,11 +853,13 @@ async function main() {
           title,
           description,
           markdown,
+          extraction,
           wordCount: markdown.split(/\s+/).length,
         };
       });
 
       // Skip if content is too small
+      let processed = true;
       if (markdown.wordCount < 30) {
         log.info(`Skipping ${url} - content too small (${markdown.wordCount} words)`);
         stats.skipped++;
@@ -735,12 +873,22 @@ async function main() {
         }
 
         // Ingest the document with metadata
-        await ingestDocument(url, markdown.title, markdown.markdown, {
+        processed = await ingestDocument(url, markdown.title, markdown.markdown, {
           description: markdown.description,
           word_count: String(markdown.wordCount),
+          extraction_method: markdown.extraction.method,
+          extraction_text_ratio: markdown.extraction.textRatio,
         });
       }
 
+      // Remember the page's validators once it is ingested
+      const headers = response?.headers() || {};
+      if (processed && (headers['etag'] || headers['last-modified'])) {
+        pageCache[url] = { etag: headers['etag'], lastModified: headers['last-modified'], links };
+      } else {
+        delete pageCache[url];
+      }
+
       // Add delay between requests
       await new Promise(resolve => setTimeout(resolve, config.delayMs));
     },
@@ -757,6 +905,7 @@ async function main() {
     url: config.startUrl,
     userData: { depth: 0 },
   }]);
+  saveCache();
 
   // Print summary
   console.log('');
@@ -765,6 +914,7 @@ async function main() {
   console.log(`Documents ingested: ${stats.ingested}`);
   console.log(`Failed: ${stats.failed}`);
   console.log(`Skipped (too small): ${stats.skipped}`);
+  console.log(`Unchanged since last crawl: ${stats.unchanged}`);
 }
 
 main().catch(error => {
@@ -27,6 +27,13 @@ TENANT_ID="00000000-0000-0000-0000-000000000001"
 RAG_URL="http://localhost:8080"
 SAMPLE_SITE_PORT="8000"
 
+# Admin key the RAG service is started with; the tenant calls use the
+# tenant's own API key, set by create_tenant
+export ADMIN_API_KEY="${ADMIN_API_KEY:-demo-admin-key}"
+# Signs the service's bearer tokens; a fixed value is fine for the local demo
+export JWT_SECRET="${JWT_SECRET:-demo-only-jwt-secret-do-not-use-in-prod}"
+TENANT_API_KEY=""
+
 log() {
     echo -e "${BLUE}[DEMO]${NC} $1"
 }
@@ -137,7 +144,7 @@ start_services() {
     log "Waiting for services to start..."
     sleep 3
 
-    until curl -s "$RAG_URL/v1/tenants" >/dev/null 2>&1; do
+    until curl -s "$RAG_URL/v1/tenants" -H "X-API-Key: $ADMIN_API_KEY" >/dev/null 2>&1; do
         log "Waiting for RAG service..."
         sleep 2
     done
@@ -149,10 +156,10 @@ cleanup_existing_data() {
     log "Cleaning up existing demo data..."
 
     # Delete tenant if it exists (this removes all documents and vectors)
-    existing=$(curl -s "$RAG_URL/v1/tenants/$TENANT_ID" 2>&1)
+    existing=$(curl -s "$RAG_URL/v1/tenants/$TENANT_ID" -H "X-API-Key: $ADMIN_API_KEY" 2>&1)
     if echo "$existing" | grep -q "api_key"; then
         log "Deleting existing tenant and all its data..."
-        curl -s -X DELETE "$RAG_URL/v1/tenants/$TENANT_ID" >/dev/null 2>&1
+        curl -s -X DELETE "$RAG_URL/v1/tenants/$TENANT_ID" -H "X-API-Key: $ADMIN_API_KEY" >/dev/null 2>&1
         sleep 2
         success "Existing data cleaned up"
     else
@@ -166,6 +173,7 @@ create_tenant() {
     # Create tenant (always fresh since we cleaned up first)
     result=$(curl -s -X POST "$RAG_URL/v1/tenants" \
         -H "Content-Type: application/json" \
+        -H "X-API-Key: $ADMIN_API_KEY" \
         -d "{
             \"name\": \"Demo Cloud\",
             \"id\": \"$TENANT_ID\"
@@ -177,6 +185,7 @@ create_tenant() {
         # If custom ID not supported, create without it
         result=$(curl -s -X POST "$RAG_URL/v1/tenants" \
             -H "Content-Type: application/json" \
+            -H "X-API-Key: $ADMIN_API_KEY" \
             -d '{"name": "Demo Cloud"}')
 
         NEW_ID=$(echo "$result" | grep -o '"id":"[^"]*"' | cut -d'"' -f4)
@@ -187,6 +196,9 @@ create_tenant() {
             error "Failed to create tenant"
         fi
     fi
+
+    TENANT_API_KEY=$(echo "$result" | grep -o '"api_key":"[^"]*"' | cut -d'"' -f4)
+    [ -n "$TENANT_API_KEY" ] || error "Tenant created without an API key"
 }
 
 build_client_sdk() {
@@ -204,6 +216,12 @@ build_client_sdk() {
     success "Client SDK built"
 }
 
+write_widget_config() {
+    # The chat widget on the sample pages authenticates with the tenant's key
+    echo "window.RAG_API_KEY = '$TENANT_API_KEY';" > "$SCRIPT_DIR/rag-config.js"
+    success "Chat widget configured"
+}
+
 start_sample_site() {
     log "Starting sample website..."
     cd "$SCRIPT_DIR"
@@ -248,6 +266,7 @@ run_crawler() {
 
     node crawl.js \
         --tenant-id "$TENANT_ID" \
+        --api-key "$TENANT_API_KEY" \
         --url "http://localhost:$SAMPLE_SITE_PORT" \
         --include "/docs/**" \
         --include "/pricing.html" \
@@ -261,10 +280,10 @@ run_crawler() {
 verify_ingestion() {
     log "Verifying ingestion..."
 
-    doc_count=$(curl -s "$RAG_URL/v1/documents?tenant_id=$TENANT_ID" | grep -o '"documents":\[' | wc -l)
+    doc_count=$(curl -s "$RAG_URL/v1/documents?tenant_id=$TENANT_ID" -H "X-API-Key: $TENANT_API_KEY" | grep -o '"documents":\[' | wc -l)
 
     # Get actual count
-    response=$(curl -s "$RAG_URL/v1/documents?tenant_id=$TENANT_ID")
+    response=$(curl -s "$RAG_URL/v1/documents?tenant_id=$TENANT_ID" -H "X-API-Key: $TENANT_API_KEY")
     if command -v jq >/dev/null 2>&1; then
         doc_count=$(echo "$response" | jq '.documents | length')
     else
@@ -290,6 +309,7 @@ run_sample_queries() {
 
         response=$(curl -s -X POST "$RAG_URL/v1/query" \
             -H "Content-Type: application/json" \
+            -H "X-API-Key: $TENANT_API_KEY" \
             -d "{
                 \"tenant_id\": \"$TENANT_ID\",
                 \"query\": \"$query\"
@@ -320,17 +340,20 @@ print_summary() {
     echo "  - Crawler Service: localhost:9091 (gRPC)"
     echo "  - Sample Site:     http://localhost:$SAMPLE_SITE_PORT/"
     echo ""
-    echo "Tenant ID: $TENANT_ID"
+    echo "Tenant ID:      $TENANT_ID"
+    echo "Tenant API key: $TENANT_API_KEY"
+    echo "Admin API key:  $ADMIN_API_KEY"
     echo ""
     echo "Try these commands:"
     echo ""
     echo "  # Query the RAG service"
     echo "  curl -X POST $RAG_URL/v1/query \\"
     echo "    -H 'Content-Type: application/json' \\"
+    echo "    -H 'X-API-Key: $TENANT_API_KEY' \\"
     echo "    -d '{\"tenant_id\": \"$TENANT_ID\", \"query\": \"How do I create a database?\"}' | jq"
     echo ""
     echo "  # List documents"
-    echo "  curl '$RAG_URL/v1/documents?tenant_id=$TENANT_ID' | jq"
+    echo "  curl '$RAG_URL/v1/documents?tenant_id=$TENANT_ID' -H 'X-API-Key: $TENANT_API_KEY' | jq"
     echo ""
     echo "  # View logs"
     echo "  tail -f /tmp/ragd.log"
@@ -356,6 +379,7 @@ main() {
     cleanup_existing_data
     create_tenant
     build_client_sdk
+    write_widget_config
     start_sample_site
     setup_crawler
     run_crawler
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/knoguchi/rag/internal/config"
+)
+
+// configCommand checks configuration without starting the service:
+//
+//	ragd config validate [-file PATH] [-show]
+//
+// validate loads the config file (CONFIG_FILE unless -file names another)
+// and environment as the service does and reports every problem; -show
+// prints the effective configuration with secrets redacted.
+func configCommand(args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		return errors.New("usage: ragd config validate [-file PATH] [-show]")
+	}
+
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	file := fs.String("file", "", "config file to validate (default $CONFIG_FILE)")
+	show := fs.Bool("show", false, "print the effective configuration")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var cfg *config.Config
+	var err error
+	if *file != "" {
+		cfg, err = config.LoadFile(*file)
+	} else {
+		cfg, err = config.Load()
+	}
+	if err != nil {
+		return err
+	}
+
+	if *show {
+		settings := cfg.Redacted()
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(os.Stdout, "%s=%s\n", key, settings[key])
+		}
+	}
+	fmt.Fprintln(os.Stdout, "configuration is valid")
+	return nil
+}
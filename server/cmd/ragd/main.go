@@ -2,33 +2,67 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/knoguchi/rag/internal/auth"
 	"github.com/knoguchi/rag/internal/config"
+	"github.com/knoguchi/rag/internal/crawl"
 	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/ingestion"
 	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/logging"
+	"github.com/knoguchi/rag/internal/memory"
+	"github.com/knoguchi/rag/internal/monitor"
+	"github.com/knoguchi/rag/internal/ocr"
+	"github.com/knoguchi/rag/internal/ratelimit"
+	"github.com/knoguchi/rag/internal/render"
 	"github.com/knoguchi/rag/internal/repository"
 	"github.com/knoguchi/rag/internal/repository/postgres"
+	"github.com/knoguchi/rag/internal/reranker"
 	"github.com/knoguchi/rag/internal/server"
 	"github.com/knoguchi/rag/internal/service"
 	"github.com/knoguchi/rag/internal/vectorstore"
 )
 
 func main() {
-	// Set up structured logging
-	logLevel := slog.LevelInfo
-	if os.Getenv("LOG_LEVEL") == "debug" {
-		logLevel = slog.LevelDebug
-	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: logLevel,
-	}))
+	// Set up structured logging; run reconfigures it from the loaded config
+	logLevel, err := logging.ParseLevel(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		logLevel = slog.LevelInfo
+	}
+	logger, _ := logging.New(os.Stdout, logging.Config{Level: logLevel, Redact: true})
 	slog.SetDefault(logger)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrateSchema(os.Args[2:]); err != nil {
+			slog.Error("migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		// Problems are printed as they are, one per line, for the operator
+		if err := configCommand(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate-shared-collection" {
+		if err := migrateSharedCollection(os.Args[2:]); err != nil {
+			slog.Error("migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		slog.Error("failed to run server", "error", err)
 		os.Exit(1)
@@ -44,6 +78,13 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	slog.Info("loaded configuration", "file", os.Getenv(config.FileEnv), "config", cfg.Redacted())
+	configs := config.NewBus(cfg, os.Getenv(config.FileEnv))
 
 	slog.Info("starting RAG service",
 		"grpc_port", cfg.GRPCPort,
@@ -59,24 +100,78 @@ func run() error {
 	defer db.Close()
 	slog.Info("connected to PostgreSQL")
 
+	if cfg.DBAutoMigrate {
+		applied, err := db.MigrateUp(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+		for _, m := range applied {
+			slog.Info("applied migration", "version", m.Version, "name", m.Name)
+		}
+	} else if status, err := db.MigrationStatus(ctx); err == nil && len(status.Pending) > 0 {
+		slog.Warn("database has pending migrations; run `ragd migrate up` or set DB_AUTO_MIGRATE=true",
+			"version", status.Version, "pending", len(status.Pending))
+	}
+
 	// Initialize repositories
 	tenantRepo := postgres.NewTenantRepo(db)
 	documentRepo := postgres.NewDocumentRepo(db)
+	reindexJobRepo := postgres.NewReindexJobRepo(db)
+	feedRepo := postgres.NewFeedRepo(db)
+	crawlJobRepo := postgres.NewCrawlJobRepo(db)
+	collectionRepo := postgres.NewCollectionRepo(db)
+	promptRepo := postgres.NewPromptTemplateRepo(db)
+	synonymRepo := postgres.NewSynonymRepo(db)
+	queryUsageRepo := postgres.NewQueryUsageRepo(db)
+	apiKeyRepo := postgres.NewAPIKeyRepo(db)
+	projectRepo := postgres.NewProjectRepo(db)
+	graphRepo := postgres.NewGraphRepo(db)
+
+	// Jobs cannot survive a restart; their partial collections are abandoned
+	if err := reindexJobRepo.FailInterrupted(ctx); err != nil {
+		return fmt.Errorf("failed to reset reindex jobs: %w", err)
+	}
 
 	// Initialize Qdrant vector store
-	vectorStore, err := vectorstore.NewQdrantStore(ctx, cfg.QdrantGRPCURL)
+	vectorStore, err := newVectorStore(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Qdrant: %w", err)
 	}
 	defer vectorStore.Close()
-	slog.Info("connected to Qdrant")
+	slog.Info("connected to Qdrant", "shared_collection", cfg.QdrantSharedCollection)
 
-	// Initialize Ollama embedder
-	embed := embedder.NewOllamaEmbedder(embedder.OllamaConfig{
-		BaseURL: cfg.OllamaURL,
-		Model:   cfg.OllamaEmbeddingModel,
-	})
-	slog.Info("initialized Ollama embedder", "model", cfg.OllamaEmbeddingModel)
+	// Initialize the embedder; model references may name a hosted provider
+	newEmbedder := func(ref string) (embedder.Embedder, error) {
+		kind, model := embedder.ParseModelRef(ref)
+		pc := embedder.ProviderConfig{
+			Kind:  kind,
+			Model: model,
+			Retry: embedder.RetryConfig{
+				MaxAttempts:    cfg.EmbeddingMaxAttempts,
+				InitialBackoff: cfg.EmbeddingRetryBackoff,
+				MaxBackoff:     cfg.EmbeddingRetryMaxDelay,
+				RequestTimeout: cfg.EmbeddingRequestTimeout,
+			},
+		}
+		switch kind {
+		case "cohere":
+			pc.BaseURL, pc.APIKey = cfg.CohereBaseURL, cfg.CohereAPIKey
+		case "voyage":
+			pc.BaseURL, pc.APIKey = cfg.VoyageBaseURL, cfg.VoyageAPIKey
+		default:
+			pc.BaseURL, pc.MaxBatchSize = cfg.OllamaURL, cfg.EmbeddingBatchSize
+			pc.DisableTaskPrefixes = !cfg.EmbeddingTaskPrefixes
+		}
+		return embedder.NewProvider(pc)
+	}
+	embed, err := newEmbedder(cfg.OllamaEmbeddingModel)
+	if err != nil {
+		return fmt.Errorf("failed to create embedder: %w", err)
+	}
+	slog.Info("initialized embedder", "model", embed.ModelName())
+
+	// Tenants on other embedding models get their own embedder on first use
+	embedders := embedder.NewPool(embed, newEmbedder)
 
 	// Initialize Ollama LLM
 	llmClient := llm.NewOllamaClient(
@@ -85,19 +180,268 @@ func run() error {
 	)
 	slog.Info("initialized Ollama LLM", "model", cfg.OllamaLLMModel)
 
+	// Route generation across providers, failing over to tenant fallback models
+	llmRegistry := llm.NewRegistry(llm.BreakerConfig{
+		FailureThreshold: cfg.LLMBreakerThreshold,
+		OpenTimeout:      cfg.LLMBreakerTimeout,
+	})
+	llmRegistry.Register("ollama", llmClient)
+	var providers []llm.ProviderConfig
+	if cfg.AnthropicAPIKey != "" {
+		providers = append(providers, llm.ProviderConfig{Kind: "anthropic", BaseURL: cfg.AnthropicBaseURL, APIKey: cfg.AnthropicAPIKey})
+	}
+	if cfg.OpenAIAPIKey != "" {
+		providers = append(providers, llm.ProviderConfig{Kind: "openai", BaseURL: cfg.OpenAIBaseURL, APIKey: cfg.OpenAIAPIKey})
+	}
+	if cfg.VLLMURL != "" {
+		providers = append(providers, llm.ProviderConfig{
+			Kind:     "vllm",
+			BaseURL:  cfg.VLLMURL,
+			APIKey:   cfg.VLLMAPIKey,
+			Model:    cfg.VLLMModel,
+			Endpoint: cfg.VLLMEndpoint,
+		})
+	}
+	for _, pc := range providers {
+		provider, err := llm.NewProvider(pc)
+		if err != nil {
+			return fmt.Errorf("failed to create %s provider: %w", pc.Kind, err)
+		}
+		llmRegistry.Register(pc.Kind, provider)
+		slog.Info("initialized LLM provider", "provider", pc.Kind)
+	}
+	if err := llmRegistry.SetDefault(cfg.LLMDefaultProvider); err != nil {
+		return err
+	}
+
+	// Fail now, rather than at the first query, when Ollama lacks a model
+	if cfg.OllamaModelCheck {
+		if err := ensureOllamaModels(ctx, cfg, llmClient, llmRegistry); err != nil {
+			return err
+		}
+	}
+
+	// Rerankers tenants choose with rerank.provider
+	rerankModel := cfg.RerankLLMModel
+	if rerankModel == "" {
+		rerankModel = "ollama/" + cfg.OllamaLLMModel
+	}
+	rerankers := reranker.NewRegistry()
+	rerankers.Register(reranker.ProviderLLM, reranker.NewLLMReranker(llmRegistry,
+		reranker.WithModel(rerankModel),
+		reranker.WithBatchSize(cfg.RerankLLMBatchSize),
+		reranker.WithConcurrency(cfg.RerankLLMConcurrency),
+		reranker.WithTimeout(cfg.RerankTimeout),
+	))
+	if cfg.CohereAPIKey != "" {
+		rerankers.Register(reranker.ProviderCohere, reranker.NewCohereReranker(reranker.CohereConfig{
+			BaseURL: cfg.CohereBaseURL,
+			APIKey:  cfg.CohereAPIKey,
+			Model:   cfg.CohereRerankModel,
+			Timeout: cfg.RerankTimeout,
+		}))
+	}
+	if err := rerankers.SetDefault(cfg.RerankerDefaultProvider); err != nil {
+		return err
+	}
+	slog.Info("initialized rerankers", "providers", rerankers.Names(), "default", cfg.RerankerDefaultProvider)
+
+	// File extractors, with OCR for scanned PDFs and images if configured
+	extractors := ingestion.DefaultExtractors()
+	switch cfg.OCREngine {
+	case "":
+	case "tesseract":
+		ingestion.RegisterOCR(extractors, ocr.NewTesseractEngine(ocr.TesseractConfig{
+			Path:           cfg.OCRTesseractPath,
+			RasterizerPath: cfg.OCRRasterizerPath,
+			Languages:      cfg.OCRLanguages,
+		}))
+		slog.Info("enabled OCR", "engine", cfg.OCREngine)
+	case "http":
+		if cfg.OCRURL == "" {
+			return fmt.Errorf("OCR_URL is required for the http OCR engine")
+		}
+		ingestion.RegisterOCR(extractors, ocr.NewHTTPEngine(ocr.HTTPConfig{
+			URL:    cfg.OCRURL,
+			APIKey: cfg.OCRAPIKey,
+		}))
+		slog.Info("enabled OCR", "engine", cfg.OCREngine, "url", cfg.OCRURL)
+	default:
+		return fmt.Errorf("unknown OCR engine: %s", cfg.OCREngine)
+	}
+
+	documentOpts := []service.DocumentServiceOption{
+		service.WithDocumentEmbedderPool(embedders),
+		service.WithDocumentProjects(projectRepo),
+		service.WithExtractors(extractors),
+		service.WithUnitOfWork(db),
+		service.WithContentStorage(cfg.StoreDocumentContent),
+		service.WithCheckpointing(cfg.IngestionCheckpointChunks),
+		service.WithEmbedBatching(cfg.EmbedBatchSize, cfg.EmbedConcurrency),
+		service.WithMaxDownloadSize(cfg.MaxDownloadSize),
+		service.WithFetchLimits(crawl.SchedulerConfig{
+			MaxConcurrency:  cfg.CrawlMaxConcurrency,
+			HostConcurrency: cfg.CrawlHostConcurrency,
+			Delay:           cfg.CrawlDelay,
+			Jitter:          cfg.CrawlDelayJitter,
+			MaxBackoff:      cfg.CrawlMaxBackoff,
+			MaxRetries:      cfg.CrawlMaxRetries,
+		}),
+	}
+	if cfg.RespectRobotsTxt {
+		documentOpts = append(documentOpts, service.WithRobotsTxt(cfg.RobotsTxtCacheTTL))
+	}
+	if cfg.RendererURL != "" {
+		documentOpts = append(documentOpts, service.WithRenderer(render.NewHTTPRenderer(render.HTTPConfig{
+			URL:    cfg.RendererURL,
+			APIKey: cfg.RendererAPIKey,
+		})))
+		slog.Info("enabled headless rendering", "url", cfg.RendererURL)
+	}
+	if cfg.EnrichmentEnabled {
+		documentOpts = append(documentOpts, service.WithEnricher(ingestion.HeuristicEnricher{MaxEntities: cfg.EnrichmentMaxEntities}))
+	}
+	if cfg.ImageCaptionModel != "" {
+		documentOpts = append(documentOpts, service.WithImageCaptioner(ingestion.NewImageCaptioner(llmClient, ingestion.CaptionConfig{
+			Model:     cfg.ImageCaptionModel,
+			MaxImages: cfg.ImageCaptionMaxImages,
+		})))
+		slog.Info("enabled image captioning", "model", cfg.ImageCaptionModel)
+	}
+	if cfg.GraphExtractionModel != "" {
+		documentOpts = append(documentOpts, service.WithGraphExtraction(ingestion.NewGraphExtractor(llmRegistry, ingestion.GraphConfig{
+			Model:       cfg.GraphExtractionModel,
+			Concurrency: cfg.GraphExtractionConcurrency,
+		}), graphRepo))
+		slog.Info("enabled knowledge graph extraction", "model", cfg.GraphExtractionModel)
+	}
+	if cfg.SummaryIndexModel != "" {
+		documentOpts = append(documentOpts, service.WithSummaryIndex(ingestion.NewSummaryTreeBuilder(llmRegistry, ingestion.SummaryTreeConfig{
+			Model:       cfg.SummaryIndexModel,
+			ClusterSize: cfg.SummaryIndexClusterSize,
+			MaxLevels:   cfg.SummaryIndexMaxLevels,
+		})))
+		slog.Info("enabled summary index", "model", cfg.SummaryIndexModel)
+	}
+
 	// Initialize services
-	tenantSvc := service.NewTenantService(tenantRepo, vectorStore, cfg)
-	documentSvc := service.NewDocumentService(documentRepo, tenantRepo, embed, vectorStore)
-	ragSvc := service.NewRAGService(tenantRepo, documentRepo, embed, vectorStore, llmClient)
+	tenantSvc := service.NewTenantService(tenantRepo, vectorStore, cfg,
+		service.WithReindexing(documentRepo, reindexJobRepo, embedders),
+		service.WithAPIKeys(apiKeyRepo),
+		service.WithAPIKeyProjects(projectRepo),
+	)
+	documentSvc := service.NewDocumentService(documentRepo, tenantRepo, embed, vectorStore, documentOpts...)
+	if err := documentSvc.ResumeIngestion(ctx); err != nil {
+		slog.Warn("failed to resume interrupted ingestion", "error", err)
+	}
+	collectionSvc := service.NewCollectionService(collectionRepo, documentRepo, vectorStore, db)
+	promptSvc := service.NewPromptService(promptRepo, tenantRepo)
+	synonymSvc := service.NewSynonymService(synonymRepo, tenantRepo)
+	projectSvc := service.NewProjectService(projectRepo, tenantRepo)
+	sessions := memory.DefaultStore()
+	sessionSvc := service.NewSessionService(sessions, tenantRepo)
+	queryCounter := service.NewQueryCounter(queryUsageRepo, cfg.QueryCountRetentionMonths)
+	ragSvc := service.NewRAGService(tenantRepo, documentRepo, embed, vectorStore, llmRegistry,
+		service.WithEmbedderPool(embedders),
+		service.WithPromptTemplates(promptRepo),
+		service.WithMemoryStore(sessions),
+		service.WithRerankers(rerankers),
+		service.WithKnowledgeGraph(graphRepo),
+		service.WithSynonyms(synonymRepo),
+		service.WithQueryCounter(queryCounter),
+		service.WithProjects(projectRepo),
+	)
+
+	feedSvc := service.NewFeedService(feedRepo, tenantRepo, documentSvc, cfg.FeedDefaultPollInterval)
+	crawlSvc := service.NewCrawlService(crawlJobRepo, tenantRepo, documentSvc)
+	if err := crawlSvc.ResumeCrawls(ctx); err != nil {
+		slog.Warn("failed to resume interrupted crawls", "error", err)
+	}
+
+	// Failed and slow requests are kept in memory for the admin dashboard
+	recorder := monitor.NewRecorder(cfg.AdminRecentRequests, cfg.AdminSlowQueryThreshold)
+	adminSvc := service.NewAdminService(tenantRepo, documentRepo, reindexJobRepo, vectorStore, embed, llmRegistry, recorder, queryCounter, configs, cfg.AdminAPIKey)
+
+	// Tenants exchange their API key for a JWT at /v1/auth/token
+	jwtConfig := auth.DefaultJWTConfig(cfg.JWTSecret)
+	jwtConfig.Expiry = cfg.JWTExpiry
+	jwtConfig.RefreshWindow = cfg.JWTRefreshWindow
+	jwtManager := auth.NewJWTManager(jwtConfig)
+	authSvc := service.NewAuthService(tenantRepo, apiKeyRepo, jwtManager)
+
+	// Authenticate API keys and bearer tokens; services take the tenant from them
+	var authInterceptor *auth.APIKeyInterceptor
+	if cfg.AuthEnabled {
+		authInterceptor = auth.NewAPIKeyInterceptor(tenantRepo, cfg.AdminAPIKey).
+			WithJWT(jwtManager).
+			WithScopedKeys(apiKeyRepo)
+		if cfg.OIDCIssuerURL != "" {
+			verifier, err := auth.NewOIDCVerifier(auth.OIDCConfig{
+				IssuerURL:   cfg.OIDCIssuerURL,
+				ClientID:    cfg.OIDCClientID,
+				Audience:    cfg.OIDCAudience,
+				AdminGroups: cfg.OIDCAdminGroups,
+				GroupsClaim: cfg.OIDCGroupsClaim,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to configure OIDC: %w", err)
+			}
+			authInterceptor.WithOIDC(verifier)
+			slog.Info("OIDC enabled for admin methods", "issuer", cfg.OIDCIssuerURL, "admin_groups", cfg.OIDCAdminGroups)
+			if len(cfg.OIDCAdminGroups) == 0 {
+				slog.Warn("OIDC_ADMIN_GROUPS is not set; every user of the identity provider with the right audience is an admin")
+			}
+		} else if cfg.AdminAPIKey == "" {
+			slog.Warn("ADMIN_API_KEY is not set; admin methods such as CreateTenant will be rejected")
+		}
+	} else {
+		slog.Warn("authentication is disabled; every endpoint is open")
+	}
+
+	rateLimiter, err := newRateLimiter(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Create gRPC server
+	transport := server.TransportConfig{
+		MaxRecvMsgSize:               cfg.GRPCMaxRecvMsgSize,
+		MaxSendMsgSize:               cfg.GRPCMaxSendMsgSize,
+		KeepaliveTime:                cfg.GRPCKeepaliveTime,
+		KeepaliveTimeout:             cfg.GRPCKeepaliveTimeout,
+		KeepaliveMinTime:             cfg.GRPCKeepaliveMinTime,
+		PermitKeepaliveWithoutStream: cfg.GRPCKeepalivePermitWithoutStream,
+		MaxConnectionIdle:            cfg.GRPCMaxConnectionIdle,
+		Compression:                  cfg.GRPCCompression,
+	}
 	grpcServer, err := server.NewGRPCServer(server.GRPCServerConfig{
-		Port:   cfg.GRPCPort,
-		Logger: slog.Default(),
+		Port:      cfg.GRPCPort,
+		Logger:    logging.Component(slog.Default(), "grpc"),
+		Recorder:  recorder,
+		Auth:      authInterceptor,
+		RateLimit: rateLimiter,
+		TLS: server.TLSConfig{
+			CertFile:       cfg.GRPCTLSCertFile,
+			KeyFile:        cfg.GRPCTLSKeyFile,
+			ClientCAFile:   cfg.GRPCTLSClientCAFile,
+			ReloadInterval: cfg.TLSReloadInterval,
+		},
+		Transport: transport,
+
+		RequestLogSampleRate: cfg.LogRequestSampleRate,
 	}, server.Services{
-		TenantService:   tenantSvc,
-		DocumentService: documentSvc,
-		RAGService:      ragSvc,
+		TenantService:     tenantSvc,
+		DocumentService:   documentSvc,
+		CollectionService: collectionSvc,
+		PromptService:     promptSvc,
+		SynonymService:    synonymSvc,
+		ProjectService:    projectSvc,
+		SessionService:    sessionSvc,
+		RAGService:        ragSvc,
+		AdminService:      adminSvc,
+		FeedService:       feedSvc,
+		CrawlService:      crawlSvc,
+		AuthService:       authSvc,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC server: %w", err)
@@ -105,15 +449,53 @@ func run() error {
 
 	// Create HTTP server with grpc-gateway
 	httpServer, err := server.NewHTTPServer(server.HTTPServerConfig{
-		Port:           cfg.HTTPPort,
-		GRPCAddr:       fmt.Sprintf("localhost:%d", cfg.GRPCPort),
-		Logger:         slog.Default(),
-		AllowedOrigins: []string{"*"}, // Configure in production
+		Port:     cfg.HTTPPort,
+		GRPCAddr: fmt.Sprintf("localhost:%d", cfg.GRPCPort),
+		Logger:   logging.Component(slog.Default(), "http"),
+		CORS: server.CORSConfig{
+			AllowedOrigins:   cfg.CORSAllowedOrigins,
+			AllowedHeaders:   cfg.CORSAllowedHeaders,
+			ExposedHeaders:   cfg.CORSExposedHeaders,
+			AllowCredentials: cfg.CORSAllowCredentials,
+			MaxAge:           cfg.CORSMaxAge,
+		},
+		TLS: server.TLSConfig{
+			CertFile:       cfg.HTTPTLSCertFile,
+			KeyFile:        cfg.HTTPTLSKeyFile,
+			ClientCAFile:   cfg.HTTPTLSClientCAFile,
+			ReloadInterval: cfg.TLSReloadInterval,
+		},
+		GRPCCredentials: grpcServer.GatewayCredentials(),
+		GRPCTransport:   transport,
+
+		RequestLogSampleRate: cfg.LogRequestSampleRate,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP server: %w", err)
 	}
 
+	// Apply reloaded settings that can change without a restart
+	configs.Subscribe(func(cfg *config.Config) {
+		if logCfg, err := loggingConfig(cfg); err == nil {
+			_ = logging.SetLevels(logger, logCfg)
+		}
+		tenantSvc.SetConfig(cfg)
+		httpServer.SetCORSOrigins(cfg.CORSAllowedOrigins)
+		rateLimiter.SetRates(
+			ratelimit.Rate{RPS: cfg.RateLimitRPS, Burst: cfg.RateLimitBurst},
+			ratelimit.Rate{RPS: cfg.RateLimitTenantRPS, Burst: cfg.RateLimitTenantBurst},
+		)
+	})
+	go configs.Watch(ctx, cfg.ConfigReloadInterval)
+
+	// Write query counts in batches, and expire old months
+	go queryCounter.Run(ctx, cfg.QueryCountFlushInterval)
+
+	// Poll subscribed feeds in the background
+	if cfg.FeedPollerEnabled {
+		go feedSvc.Run(ctx, cfg.FeedPollInterval)
+	}
+
 	// Start servers
 	errCh := make(chan error, 2)
 
@@ -136,20 +518,28 @@ func run() error {
 		}
 	}()
 
-	// Wait for shutdown signal
+	// Wait for shutdown signal; SIGHUP reloads the configuration
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
-	select {
-	case err := <-errCh:
-		return err
-	case sig := <-sigCh:
-		slog.Info("received shutdown signal", "signal", sig)
+wait:
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case sig := <-sigCh:
+			if sig == syscall.SIGHUP {
+				_, _ = configs.Reload()
+				continue
+			}
+			slog.Info("received shutdown signal", "signal", sig)
+			break wait
+		}
 	}
 
 	// Graceful shutdown
 	slog.Info("shutting down servers...")
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer shutdownCancel()
 
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
@@ -159,6 +549,16 @@ func run() error {
 		slog.Error("failed to shutdown gRPC server", "error", err)
 	}
 
+	// No new documents arrive once the servers stop
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.IngestDrainTimeout)
+	defer drainCancel()
+	if err := documentSvc.Drain(drainCtx); err != nil {
+		slog.Warn("interrupted in-flight ingestions", "error", err)
+	}
+	if err := queryCounter.Flush(shutdownCtx); err != nil {
+		slog.Warn("failed to flush query counts", "error", err)
+	}
+
 	slog.Info("servers stopped")
 	return nil
 }
@@ -170,4 +570,127 @@ var (
 	_ vectorstore.VectorStore       = (*vectorstore.QdrantStore)(nil)
 	_ embedder.Embedder             = (*embedder.OllamaEmbedder)(nil)
 	_ llm.LLM                       = (*llm.OllamaClient)(nil)
+	_ llm.LLM                       = (*llm.Registry)(nil)
 )
+
+// newVectorStore connects to Qdrant in the configured collection mode
+func newVectorStore(ctx context.Context, cfg *config.Config) (*vectorstore.QdrantStore, error) {
+	opts := []vectorstore.QdrantOption{
+		vectorstore.WithRetry(vectorstore.RetryConfig{
+			MaxAttempts:    cfg.QdrantMaxAttempts,
+			InitialBackoff: cfg.QdrantRetryBackoff,
+			MaxBackoff:     cfg.QdrantRetryMaxDelay,
+		}),
+		vectorstore.WithConnection(vectorstore.ConnectionConfig{
+			PoolSize:         cfg.QdrantPoolSize,
+			KeepAliveTime:    cfg.QdrantKeepAlive,
+			KeepAliveTimeout: cfg.QdrantKeepAliveTimeout,
+		}),
+	}
+	if cfg.QdrantTLS || cfg.QdrantCAFile != "" {
+		opts = append(opts, vectorstore.WithTLS(vectorstore.TLSConfig{
+			CAFile:             cfg.QdrantCAFile,
+			ServerName:         cfg.QdrantTLSServerName,
+			InsecureSkipVerify: cfg.QdrantTLSInsecureSkipVerify,
+		}))
+	}
+	if cfg.QdrantAPIKey != "" {
+		opts = append(opts, vectorstore.WithAPIKey(cfg.QdrantAPIKey))
+	}
+	if cfg.QdrantSharedCollection != "" {
+		opts = append(opts, vectorstore.WithSharedCollection(cfg.QdrantSharedCollection, vectorstore.StorageConfig{
+			Quantization:  cfg.QdrantSharedQuantization,
+			OnDiskVectors: cfg.QdrantSharedOnDisk,
+			OnDiskPayload: cfg.QdrantSharedOnDisk,
+		}))
+	}
+	return vectorstore.NewQdrantStore(ctx, cfg.QdrantGRPCURL, opts...)
+}
+
+// ensureOllamaModels checks that Ollama has the embedding model and the LLMs
+// routed to it, pulling missing ones when OLLAMA_AUTO_PULL is set
+func ensureOllamaModels(ctx context.Context, cfg *config.Config, client *llm.OllamaClient, registry *llm.Registry) error {
+	var models []string
+	if kind, model := embedder.ParseModelRef(cfg.OllamaEmbeddingModel); kind == "ollama" {
+		models = append(models, model)
+	}
+	for _, ref := range []string{cfg.OllamaLLMModel, cfg.RerankLLMModel, cfg.ImageCaptionModel, cfg.GraphExtractionModel, cfg.SummaryIndexModel} {
+		if ref == "" {
+			continue
+		}
+		if provider, model, err := registry.Route(ref); err == nil && provider == "ollama" && model != "" {
+			models = append(models, model)
+		}
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	pullCtx, cancel := context.WithTimeout(ctx, cfg.OllamaPullTimeout)
+	defer cancel()
+	if err := client.EnsureModels(pullCtx, models, cfg.OllamaAutoPull); err != nil {
+		var missing *llm.MissingModelsError
+		if errors.As(err, &missing) {
+			return fmt.Errorf("%w, or set OLLAMA_AUTO_PULL=true to pull them at startup (OLLAMA_MODEL_CHECK=false skips this check)", err)
+		}
+		return err
+	}
+	slog.Info("ollama has the configured models", "models", models)
+	return nil
+}
+
+// newLogger builds the logger the config describes
+func newLogger(cfg *config.Config) (*slog.Logger, error) {
+	logCfg, err := loggingConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return logging.New(os.Stdout, logCfg)
+}
+
+// loggingConfig returns the logger settings of the config
+func loggingConfig(cfg *config.Config) (logging.Config, error) {
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return logging.Config{}, err
+	}
+	components, err := logging.ParseComponentLevels(cfg.LogLevels)
+	if err != nil {
+		return logging.Config{}, err
+	}
+	return logging.Config{
+		Format:          cfg.LogFormat,
+		Level:           level,
+		ComponentLevels: components,
+		DebugTenants:    cfg.LogDebugTenants,
+		Redact:          cfg.LogRedact,
+	}, nil
+}
+
+// newRateLimiter builds the request rate limiter. It is built even when no
+// limit is configured, so reloaded limits can take effect.
+func newRateLimiter(cfg *config.Config) (*ratelimit.Limiter, error) {
+	rlCfg := ratelimit.Config{
+		PerKey:    ratelimit.Rate{RPS: cfg.RateLimitRPS, Burst: cfg.RateLimitBurst},
+		PerTenant: ratelimit.Rate{RPS: cfg.RateLimitTenantRPS, Burst: cfg.RateLimitTenantBurst},
+		Logger:    logging.Component(slog.Default(), "ratelimit"),
+	}
+
+	backend := "memory"
+	if cfg.RateLimitRedisURL != "" {
+		store, err := ratelimit.NewRedisStore(cfg.RateLimitRedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure rate limit store: %w", err)
+		}
+		rlCfg.Store = store
+		backend = "redis"
+	}
+
+	if rlCfg.PerKey.Enabled() || rlCfg.PerTenant.Enabled() {
+		slog.Info("rate limiting enabled",
+			"per_key_rps", cfg.RateLimitRPS, "per_key_burst", cfg.RateLimitBurst,
+			"per_tenant_rps", cfg.RateLimitTenantRPS, "per_tenant_burst", cfg.RateLimitTenantBurst,
+			"backend", backend)
+	}
+	return ratelimit.New(rlCfg), nil
+}
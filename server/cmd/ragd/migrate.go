@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/knoguchi/rag/internal/config"
+	"github.com/knoguchi/rag/internal/repository/postgres"
+)
+
+// migrateSharedCollection copies every tenant's collection into the shared
+// collection named by QDRANT_SHARED_COLLECTION. It is safe to rerun.
+func migrateSharedCollection(args []string) error {
+	fs := flag.NewFlagSet("migrate-shared-collection", flag.ContinueOnError)
+	deleteSource := fs.Bool("delete-source", false, "delete each per-tenant collection after copying it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if cfg.QdrantSharedCollection == "" {
+		return fmt.Errorf("QDRANT_SHARED_COLLECTION is not set")
+	}
+
+	db, err := postgres.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+	tenantRepo := postgres.NewTenantRepo(db)
+
+	vectorStore, err := newVectorStore(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Qdrant: %w", err)
+	}
+	defer vectorStore.Close()
+
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		tenants, total, err := tenantRepo.List(ctx, pageSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list tenants: %w", err)
+		}
+
+		for _, tenant := range tenants {
+			copied, err := vectorStore.MigrateToShared(ctx, tenant.ID.String(), *deleteSource)
+			if err != nil {
+				return fmt.Errorf("tenant %s: %w", tenant.ID, err)
+			}
+			slog.Info("migrated tenant", "tenant_id", tenant.ID, "points", copied)
+		}
+
+		if offset+pageSize >= total {
+			return nil
+		}
+	}
+}
+
+// migrateSchema applies or reverts the embedded database migrations:
+//
+//	ragd migrate up
+//	ragd migrate down [-steps N | -all]
+//	ragd migrate status
+//	ragd migrate force VERSION
+func migrateSchema(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: ragd migrate up|down|status|force")
+	}
+	command, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to revert (down only)")
+	all := fs.Bool("all", false, "revert every migration (down only)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	db, err := postgres.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	switch command {
+	case "up":
+		applied, err := db.MigrateUp(ctx)
+		for _, m := range applied {
+			slog.Info("applied migration", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			slog.Info("database schema is up to date")
+		}
+		return nil
+
+	case "down":
+		n := *steps
+		if *all {
+			migrations, err := postgres.Migrations()
+			if err != nil {
+				return err
+			}
+			n = len(migrations)
+		}
+		if n < 1 {
+			return errors.New("-steps must be at least 1")
+		}
+		reverted, err := db.MigrateDown(ctx, n)
+		for _, m := range reverted {
+			slog.Info("reverted migration", "version", m.Version, "name", m.Name)
+		}
+		return err
+
+	case "status":
+		status, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "version: %d\ndirty: %t\n", status.Version, status.Dirty)
+		for _, m := range status.Applied {
+			fmt.Fprintf(os.Stdout, "applied  %03d_%s\n", m.Version, m.Name)
+		}
+		for _, m := range status.Pending {
+			fmt.Fprintf(os.Stdout, "pending  %03d_%s\n", m.Version, m.Name)
+		}
+		return nil
+
+	case "force":
+		if fs.NArg() != 1 {
+			return errors.New("usage: ragd migrate force VERSION")
+		}
+		version, err := strconv.ParseUint(fs.Arg(0), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", fs.Arg(0))
+		}
+		if err := db.ForceMigrationVersion(ctx, uint(version)); err != nil {
+			return err
+		}
+		slog.Info("forced schema version", "version", version)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q; use up, down, status or force", command)
+	}
+}
@@ -0,0 +1,982 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/admin.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSystemStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Months of query counts returned, up to the current one (default 6)
+	QueryMonths   int32 `protobuf:"varint,1,opt,name=query_months,json=queryMonths,proto3" json:"query_months,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemStatsRequest) Reset() {
+	*x = GetSystemStatsRequest{}
+	mi := &file_rag_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemStatsRequest) ProtoMessage() {}
+
+func (x *GetSystemStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemStatsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetSystemStatsRequest) GetQueryMonths() int32 {
+	if x != nil {
+		return x.QueryMonths
+	}
+	return 0
+}
+
+type SystemStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantCount   int32                  `protobuf:"varint,1,opt,name=tenant_count,json=tenantCount,proto3" json:"tenant_count,omitempty"`
+	DocumentCount int32                  `protobuf:"varint,2,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
+	ChunkCount    int32                  `protobuf:"varint,3,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	// Document counts keyed by status (PENDING, PROCESSING, READY, FAILED)
+	DocumentsByStatus map[string]int32 `protobuf:"bytes,4,rep,name=documents_by_status,json=documentsByStatus,proto3" json:"documents_by_status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Total points across all tenant collections, as reported by Qdrant
+	VectorCount int64                `protobuf:"varint,5,opt,name=vector_count,json=vectorCount,proto3" json:"vector_count,omitempty"`
+	Tenants     []*TenantVectorStats `protobuf:"bytes,6,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	// Documents waiting or being processed, plus active reindex jobs
+	QueueDepth        int32                  `protobuf:"varint,7,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	ActiveReindexJobs int32                  `protobuf:"varint,8,opt,name=active_reindex_jobs,json=activeReindexJobs,proto3" json:"active_reindex_jobs,omitempty"`
+	Models            []*ModelHealth         `protobuf:"bytes,9,rep,name=models,proto3" json:"models,omitempty"`
+	GeneratedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// Queries of all tenants per month, oldest first
+	QueryCounts   []*MonthlyQueryCount `protobuf:"bytes,11,rep,name=query_counts,json=queryCounts,proto3" json:"query_counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemStats) Reset() {
+	*x = SystemStats{}
+	mi := &file_rag_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStats) ProtoMessage() {}
+
+func (x *SystemStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStats.ProtoReflect.Descriptor instead.
+func (*SystemStats) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SystemStats) GetTenantCount() int32 {
+	if x != nil {
+		return x.TenantCount
+	}
+	return 0
+}
+
+func (x *SystemStats) GetDocumentCount() int32 {
+	if x != nil {
+		return x.DocumentCount
+	}
+	return 0
+}
+
+func (x *SystemStats) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *SystemStats) GetDocumentsByStatus() map[string]int32 {
+	if x != nil {
+		return x.DocumentsByStatus
+	}
+	return nil
+}
+
+func (x *SystemStats) GetVectorCount() int64 {
+	if x != nil {
+		return x.VectorCount
+	}
+	return 0
+}
+
+func (x *SystemStats) GetTenants() []*TenantVectorStats {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *SystemStats) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *SystemStats) GetActiveReindexJobs() int32 {
+	if x != nil {
+		return x.ActiveReindexJobs
+	}
+	return 0
+}
+
+func (x *SystemStats) GetModels() []*ModelHealth {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *SystemStats) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *SystemStats) GetQueryCounts() []*MonthlyQueryCount {
+	if x != nil {
+		return x.QueryCounts
+	}
+	return nil
+}
+
+type TenantVectorStats struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TenantId    string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	VectorCount int64                  `protobuf:"varint,3,opt,name=vector_count,json=vectorCount,proto3" json:"vector_count,omitempty"`
+	Error       string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Set if the collection could not be counted
+	// The tenant's queries per month, oldest first
+	QueryCounts   []*MonthlyQueryCount `protobuf:"bytes,5,rep,name=query_counts,json=queryCounts,proto3" json:"query_counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantVectorStats) Reset() {
+	*x = TenantVectorStats{}
+	mi := &file_rag_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantVectorStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantVectorStats) ProtoMessage() {}
+
+func (x *TenantVectorStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantVectorStats.ProtoReflect.Descriptor instead.
+func (*TenantVectorStats) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TenantVectorStats) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *TenantVectorStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TenantVectorStats) GetVectorCount() int64 {
+	if x != nil {
+		return x.VectorCount
+	}
+	return 0
+}
+
+func (x *TenantVectorStats) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TenantVectorStats) GetQueryCounts() []*MonthlyQueryCount {
+	if x != nil {
+		return x.QueryCounts
+	}
+	return nil
+}
+
+// MonthlyQueryCount is the number of Query, QueryStream and Retrieve requests
+// in a calendar month (UTC)
+type MonthlyQueryCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM
+	QueryCount    int64                  `protobuf:"varint,2,opt,name=query_count,json=queryCount,proto3" json:"query_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlyQueryCount) Reset() {
+	*x = MonthlyQueryCount{}
+	mi := &file_rag_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyQueryCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyQueryCount) ProtoMessage() {}
+
+func (x *MonthlyQueryCount) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyQueryCount.ProtoReflect.Descriptor instead.
+func (*MonthlyQueryCount) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MonthlyQueryCount) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *MonthlyQueryCount) GetQueryCount() int64 {
+	if x != nil {
+		return x.QueryCount
+	}
+	return 0
+}
+
+type ModelHealth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // "embedding" or "llm"
+	Healthy       bool                   `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,5,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelHealth) Reset() {
+	*x = ModelHealth{}
+	mi := &file_rag_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelHealth) ProtoMessage() {}
+
+func (x *ModelHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelHealth.ProtoReflect.Descriptor instead.
+func (*ModelHealth) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ModelHealth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelHealth) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ModelHealth) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ModelHealth) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ModelHealth) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// RequestRecord describes a single recorded RPC
+type RequestRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	TenantId      string                 `protobuf:"bytes,5,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Query         string                 `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"` // Query text for RAG requests (truncated)
+	Time          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestRecord) Reset() {
+	*x = RequestRecord{}
+	mi := &file_rag_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestRecord) ProtoMessage() {}
+
+func (x *RequestRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestRecord.ProtoReflect.Descriptor instead.
+func (*RequestRecord) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RequestRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RequestRecord) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RequestRecord) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *RequestRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RequestRecord) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RequestRecord) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RequestRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ListRecentErrorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentErrorsRequest) Reset() {
+	*x = ListRecentErrorsRequest{}
+	mi := &file_rag_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentErrorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentErrorsRequest) ProtoMessage() {}
+
+func (x *ListRecentErrorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentErrorsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentErrorsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRecentErrorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRecentErrorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []*RequestRecord       `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentErrorsResponse) Reset() {
+	*x = ListRecentErrorsResponse{}
+	mi := &file_rag_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentErrorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentErrorsResponse) ProtoMessage() {}
+
+func (x *ListRecentErrorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentErrorsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecentErrorsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRecentErrorsResponse) GetErrors() []*RequestRecord {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ListSlowQueriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSlowQueriesRequest) Reset() {
+	*x = ListSlowQueriesRequest{}
+	mi := &file_rag_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlowQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlowQueriesRequest) ProtoMessage() {}
+
+func (x *ListSlowQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlowQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSlowQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSlowQueriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSlowQueriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*RequestRecord       `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSlowQueriesResponse) Reset() {
+	*x = ListSlowQueriesResponse{}
+	mi := &file_rag_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSlowQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSlowQueriesResponse) ProtoMessage() {}
+
+func (x *ListSlowQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSlowQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSlowQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSlowQueriesResponse) GetQueries() []*RequestRecord {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_rag_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+type ReloadConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*ConfigChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	mi := &file_rag_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReloadConfigResponse) GetChanges() []*ConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// ConfigChange is a setting that changed, named by its environment variable.
+// Secrets' values are redacted.
+type ConfigChange struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Key      string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	OldValue string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	// False when the change takes effect at the next restart
+	Applied       bool `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_rag_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_rag_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConfigChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+var File_rag_v1_admin_proto protoreflect.FileDescriptor
+
+const file_rag_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x12rag/v1/admin.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\":\n" +
+	"\x15GetSystemStatsRequest\x12!\n" +
+	"\fquery_months\x18\x01 \x01(\x05R\vqueryMonths\"\xed\x04\n" +
+	"\vSystemStats\x12!\n" +
+	"\ftenant_count\x18\x01 \x01(\x05R\vtenantCount\x12%\n" +
+	"\x0edocument_count\x18\x02 \x01(\x05R\rdocumentCount\x12\x1f\n" +
+	"\vchunk_count\x18\x03 \x01(\x05R\n" +
+	"chunkCount\x12Z\n" +
+	"\x13documents_by_status\x18\x04 \x03(\v2*.rag.v1.SystemStats.DocumentsByStatusEntryR\x11documentsByStatus\x12!\n" +
+	"\fvector_count\x18\x05 \x01(\x03R\vvectorCount\x123\n" +
+	"\atenants\x18\x06 \x03(\v2\x19.rag.v1.TenantVectorStatsR\atenants\x12\x1f\n" +
+	"\vqueue_depth\x18\a \x01(\x05R\n" +
+	"queueDepth\x12.\n" +
+	"\x13active_reindex_jobs\x18\b \x01(\x05R\x11activeReindexJobs\x12+\n" +
+	"\x06models\x18\t \x03(\v2\x13.rag.v1.ModelHealthR\x06models\x12=\n" +
+	"\fgenerated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12<\n" +
+	"\fquery_counts\x18\v \x03(\v2\x19.rag.v1.MonthlyQueryCountR\vqueryCounts\x1aD\n" +
+	"\x16DocumentsByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xbb\x01\n" +
+	"\x11TenantVectorStats\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fvector_count\x18\x03 \x01(\x03R\vvectorCount\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12<\n" +
+	"\fquery_counts\x18\x05 \x03(\v2\x19.rag.v1.MonthlyQueryCountR\vqueryCounts\"J\n" +
+	"\x11MonthlyQueryCount\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x1f\n" +
+	"\vquery_count\x18\x02 \x01(\x03R\n" +
+	"queryCount\"\x84\x01\n" +
+	"\vModelHealth\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\ahealthy\x18\x03 \x01(\bR\ahealthy\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x05 \x01(\x03R\tlatencyMs\"\xd5\x01\n" +
+	"\rRequestRecord\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1b\n" +
+	"\ttenant_id\x18\x05 \x01(\tR\btenantId\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05query\x12.\n" +
+	"\x04time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"/\n" +
+	"\x17ListRecentErrorsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"I\n" +
+	"\x18ListRecentErrorsResponse\x12-\n" +
+	"\x06errors\x18\x01 \x03(\v2\x15.rag.v1.RequestRecordR\x06errors\".\n" +
+	"\x16ListSlowQueriesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"J\n" +
+	"\x17ListSlowQueriesResponse\x12/\n" +
+	"\aqueries\x18\x01 \x03(\v2\x15.rag.v1.RequestRecordR\aqueries\"\x15\n" +
+	"\x13ReloadConfigRequest\"F\n" +
+	"\x14ReloadConfigResponse\x12.\n" +
+	"\achanges\x18\x01 \x03(\v2\x14.rag.v1.ConfigChangeR\achanges\"t\n" +
+	"\fConfigChange\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied2\xc1\x03\n" +
+	"\fAdminService\x12]\n" +
+	"\x0eGetSystemStats\x12\x1d.rag.v1.GetSystemStatsRequest\x1a\x13.rag.v1.SystemStats\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/admin/stats\x12o\n" +
+	"\x10ListRecentErrors\x12\x1f.rag.v1.ListRecentErrorsRequest\x1a .rag.v1.ListRecentErrorsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/admin/errors\x12r\n" +
+	"\x0fListSlowQueries\x12\x1e.rag.v1.ListSlowQueriesRequest\x1a\x1f.rag.v1.ListSlowQueriesResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/admin/slow-queries\x12m\n" +
+	"\fReloadConfig\x12\x1b.rag.v1.ReloadConfigRequest\x1a\x1c.rag.v1.ReloadConfigResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/admin/config/reloadB\xeb\x01\x92Am\x12C\n" +
+	"\rRAG Admin API\x12-Multi-tenant RAG service - Operator dashboard2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\n" +
+	"AdminProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_admin_proto_rawDescOnce sync.Once
+	file_rag_v1_admin_proto_rawDescData []byte
+)
+
+func file_rag_v1_admin_proto_rawDescGZIP() []byte {
+	file_rag_v1_admin_proto_rawDescOnce.Do(func() {
+		file_rag_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_admin_proto_rawDesc), len(file_rag_v1_admin_proto_rawDesc)))
+	})
+	return file_rag_v1_admin_proto_rawDescData
+}
+
+var file_rag_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_rag_v1_admin_proto_goTypes = []any{
+	(*GetSystemStatsRequest)(nil),    // 0: rag.v1.GetSystemStatsRequest
+	(*SystemStats)(nil),              // 1: rag.v1.SystemStats
+	(*TenantVectorStats)(nil),        // 2: rag.v1.TenantVectorStats
+	(*MonthlyQueryCount)(nil),        // 3: rag.v1.MonthlyQueryCount
+	(*ModelHealth)(nil),              // 4: rag.v1.ModelHealth
+	(*RequestRecord)(nil),            // 5: rag.v1.RequestRecord
+	(*ListRecentErrorsRequest)(nil),  // 6: rag.v1.ListRecentErrorsRequest
+	(*ListRecentErrorsResponse)(nil), // 7: rag.v1.ListRecentErrorsResponse
+	(*ListSlowQueriesRequest)(nil),   // 8: rag.v1.ListSlowQueriesRequest
+	(*ListSlowQueriesResponse)(nil),  // 9: rag.v1.ListSlowQueriesResponse
+	(*ReloadConfigRequest)(nil),      // 10: rag.v1.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),     // 11: rag.v1.ReloadConfigResponse
+	(*ConfigChange)(nil),             // 12: rag.v1.ConfigChange
+	nil,                              // 13: rag.v1.SystemStats.DocumentsByStatusEntry
+	(*timestamppb.Timestamp)(nil),    // 14: google.protobuf.Timestamp
+}
+var file_rag_v1_admin_proto_depIdxs = []int32{
+	13, // 0: rag.v1.SystemStats.documents_by_status:type_name -> rag.v1.SystemStats.DocumentsByStatusEntry
+	2,  // 1: rag.v1.SystemStats.tenants:type_name -> rag.v1.TenantVectorStats
+	4,  // 2: rag.v1.SystemStats.models:type_name -> rag.v1.ModelHealth
+	14, // 3: rag.v1.SystemStats.generated_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: rag.v1.SystemStats.query_counts:type_name -> rag.v1.MonthlyQueryCount
+	3,  // 5: rag.v1.TenantVectorStats.query_counts:type_name -> rag.v1.MonthlyQueryCount
+	14, // 6: rag.v1.RequestRecord.time:type_name -> google.protobuf.Timestamp
+	5,  // 7: rag.v1.ListRecentErrorsResponse.errors:type_name -> rag.v1.RequestRecord
+	5,  // 8: rag.v1.ListSlowQueriesResponse.queries:type_name -> rag.v1.RequestRecord
+	12, // 9: rag.v1.ReloadConfigResponse.changes:type_name -> rag.v1.ConfigChange
+	0,  // 10: rag.v1.AdminService.GetSystemStats:input_type -> rag.v1.GetSystemStatsRequest
+	6,  // 11: rag.v1.AdminService.ListRecentErrors:input_type -> rag.v1.ListRecentErrorsRequest
+	8,  // 12: rag.v1.AdminService.ListSlowQueries:input_type -> rag.v1.ListSlowQueriesRequest
+	10, // 13: rag.v1.AdminService.ReloadConfig:input_type -> rag.v1.ReloadConfigRequest
+	1,  // 14: rag.v1.AdminService.GetSystemStats:output_type -> rag.v1.SystemStats
+	7,  // 15: rag.v1.AdminService.ListRecentErrors:output_type -> rag.v1.ListRecentErrorsResponse
+	9,  // 16: rag.v1.AdminService.ListSlowQueries:output_type -> rag.v1.ListSlowQueriesResponse
+	11, // 17: rag.v1.AdminService.ReloadConfig:output_type -> rag.v1.ReloadConfigResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_admin_proto_init() }
+func file_rag_v1_admin_proto_init() {
+	if File_rag_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_admin_proto_rawDesc), len(file_rag_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_admin_proto_goTypes,
+		DependencyIndexes: file_rag_v1_admin_proto_depIdxs,
+		MessageInfos:      file_rag_v1_admin_proto_msgTypes,
+	}.Build()
+	File_rag_v1_admin_proto = out.File
+	file_rag_v1_admin_proto_goTypes = nil
+	file_rag_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,686 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/crawl.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CrawlStatus int32
+
+const (
+	CrawlStatus_CRAWL_STATUS_UNSPECIFIED CrawlStatus = 0
+	CrawlStatus_CRAWL_STATUS_PENDING     CrawlStatus = 1
+	CrawlStatus_CRAWL_STATUS_RUNNING     CrawlStatus = 2
+	CrawlStatus_CRAWL_STATUS_COMPLETED   CrawlStatus = 3
+	CrawlStatus_CRAWL_STATUS_FAILED      CrawlStatus = 4
+)
+
+// Enum value maps for CrawlStatus.
+var (
+	CrawlStatus_name = map[int32]string{
+		0: "CRAWL_STATUS_UNSPECIFIED",
+		1: "CRAWL_STATUS_PENDING",
+		2: "CRAWL_STATUS_RUNNING",
+		3: "CRAWL_STATUS_COMPLETED",
+		4: "CRAWL_STATUS_FAILED",
+	}
+	CrawlStatus_value = map[string]int32{
+		"CRAWL_STATUS_UNSPECIFIED": 0,
+		"CRAWL_STATUS_PENDING":     1,
+		"CRAWL_STATUS_RUNNING":     2,
+		"CRAWL_STATUS_COMPLETED":   3,
+		"CRAWL_STATUS_FAILED":      4,
+	}
+)
+
+func (x CrawlStatus) Enum() *CrawlStatus {
+	p := new(CrawlStatus)
+	*p = x
+	return p
+}
+
+func (x CrawlStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CrawlStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_crawl_proto_enumTypes[0].Descriptor()
+}
+
+func (CrawlStatus) Type() protoreflect.EnumType {
+	return &file_rag_v1_crawl_proto_enumTypes[0]
+}
+
+func (x CrawlStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CrawlStatus.Descriptor instead.
+func (CrawlStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{0}
+}
+
+// CrawlConfig limits which pages a crawl follows. Links are only followed
+// on the root URL's host.
+type CrawlConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxDepth        int32                  `protobuf:"varint,1,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`                     // Link hops from the root URL (default 3)
+	MaxPages        int32                  `protobuf:"varint,2,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`                     // Pages crawled before the job stops (default 100)
+	IncludePatterns []string               `protobuf:"bytes,3,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"` // URL path globs to follow, e.g. "/docs/*" (default all)
+	ExcludePatterns []string               `protobuf:"bytes,4,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"` // URL path globs never followed
+	UseHeadless     bool                   `protobuf:"varint,5,opt,name=use_headless,json=useHeadless,proto3" json:"use_headless,omitempty"`            // Render pages in the headless browser
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CrawlConfig) Reset() {
+	*x = CrawlConfig{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlConfig) ProtoMessage() {}
+
+func (x *CrawlConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlConfig.ProtoReflect.Descriptor instead.
+func (*CrawlConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CrawlConfig) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *CrawlConfig) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+func (x *CrawlConfig) GetIncludePatterns() []string {
+	if x != nil {
+		return x.IncludePatterns
+	}
+	return nil
+}
+
+func (x *CrawlConfig) GetExcludePatterns() []string {
+	if x != nil {
+		return x.ExcludePatterns
+	}
+	return nil
+}
+
+func (x *CrawlConfig) GetUseHeadless() bool {
+	if x != nil {
+		return x.UseHeadless
+	}
+	return false
+}
+
+// CrawlJob is a crawl and its progress
+type CrawlJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	RootUrl       string                 `protobuf:"bytes,3,opt,name=root_url,json=rootUrl,proto3" json:"root_url,omitempty"`
+	Status        CrawlStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=rag.v1.CrawlStatus" json:"status,omitempty"`
+	Config        *CrawlConfig           `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	PagesCrawled  int32                  `protobuf:"varint,6,opt,name=pages_crawled,json=pagesCrawled,proto3" json:"pages_crawled,omitempty"`
+	PagesFailed   int32                  `protobuf:"varint,7,opt,name=pages_failed,json=pagesFailed,proto3" json:"pages_failed,omitempty"`
+	PagesTotal    int32                  `protobuf:"varint,8,opt,name=pages_total,json=pagesTotal,proto3" json:"pages_total,omitempty"`       // URLs discovered so far
+	FrontierSize  int32                  `protobuf:"varint,9,opt,name=frontier_size,json=frontierSize,proto3" json:"frontier_size,omitempty"` // URLs discovered but not yet crawled
+	ErrorMessage  string                 `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,14,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Project pages are ingested into; empty for the whole tenant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlJob) Reset() {
+	*x = CrawlJob{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlJob) ProtoMessage() {}
+
+func (x *CrawlJob) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlJob.ProtoReflect.Descriptor instead.
+func (*CrawlJob) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CrawlJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CrawlJob) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CrawlJob) GetRootUrl() string {
+	if x != nil {
+		return x.RootUrl
+	}
+	return ""
+}
+
+func (x *CrawlJob) GetStatus() CrawlStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CrawlStatus_CRAWL_STATUS_UNSPECIFIED
+}
+
+func (x *CrawlJob) GetConfig() *CrawlConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CrawlJob) GetPagesCrawled() int32 {
+	if x != nil {
+		return x.PagesCrawled
+	}
+	return 0
+}
+
+func (x *CrawlJob) GetPagesFailed() int32 {
+	if x != nil {
+		return x.PagesFailed
+	}
+	return 0
+}
+
+func (x *CrawlJob) GetPagesTotal() int32 {
+	if x != nil {
+		return x.PagesTotal
+	}
+	return 0
+}
+
+func (x *CrawlJob) GetFrontierSize() int32 {
+	if x != nil {
+		return x.FrontierSize
+	}
+	return 0
+}
+
+func (x *CrawlJob) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *CrawlJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CrawlJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *CrawlJob) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *CrawlJob) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type StartCrawlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Config        *CrawlConfig           `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Optional project to ingest into; defaults to the API key's
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartCrawlRequest) Reset() {
+	*x = StartCrawlRequest{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartCrawlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartCrawlRequest) ProtoMessage() {}
+
+func (x *StartCrawlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartCrawlRequest.ProtoReflect.Descriptor instead.
+func (*StartCrawlRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StartCrawlRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *StartCrawlRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *StartCrawlRequest) GetConfig() *CrawlConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *StartCrawlRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type GetCrawlJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCrawlJobRequest) Reset() {
+	*x = GetCrawlJobRequest{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCrawlJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCrawlJobRequest) ProtoMessage() {}
+
+func (x *GetCrawlJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCrawlJobRequest.ProtoReflect.Descriptor instead.
+func (*GetCrawlJobRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCrawlJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCrawlJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Status        CrawlStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=rag.v1.CrawlStatus" json:"status,omitempty"` // Optional filter
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Only this project's jobs; defaults to the API key's
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCrawlJobsRequest) Reset() {
+	*x = ListCrawlJobsRequest{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCrawlJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCrawlJobsRequest) ProtoMessage() {}
+
+func (x *ListCrawlJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCrawlJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListCrawlJobsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCrawlJobsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListCrawlJobsRequest) GetStatus() CrawlStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CrawlStatus_CRAWL_STATUS_UNSPECIFIED
+}
+
+func (x *ListCrawlJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCrawlJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCrawlJobsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListCrawlJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*CrawlJob            `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCrawlJobsResponse) Reset() {
+	*x = ListCrawlJobsResponse{}
+	mi := &file_rag_v1_crawl_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCrawlJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCrawlJobsResponse) ProtoMessage() {}
+
+func (x *ListCrawlJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_crawl_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCrawlJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListCrawlJobsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_crawl_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCrawlJobsResponse) GetJobs() []*CrawlJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListCrawlJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_rag_v1_crawl_proto protoreflect.FileDescriptor
+
+const file_rag_v1_crawl_proto_rawDesc = "" +
+	"\n" +
+	"\x12rag/v1/crawl.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc0\x01\n" +
+	"\vCrawlConfig\x12\x1b\n" +
+	"\tmax_depth\x18\x01 \x01(\x05R\bmaxDepth\x12\x1b\n" +
+	"\tmax_pages\x18\x02 \x01(\x05R\bmaxPages\x12)\n" +
+	"\x10include_patterns\x18\x03 \x03(\tR\x0fincludePatterns\x12)\n" +
+	"\x10exclude_patterns\x18\x04 \x03(\tR\x0fexcludePatterns\x12!\n" +
+	"\fuse_headless\x18\x05 \x01(\bR\vuseHeadless\"\xb3\x04\n" +
+	"\bCrawlJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x19\n" +
+	"\broot_url\x18\x03 \x01(\tR\arootUrl\x12+\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x13.rag.v1.CrawlStatusR\x06status\x12+\n" +
+	"\x06config\x18\x05 \x01(\v2\x13.rag.v1.CrawlConfigR\x06config\x12#\n" +
+	"\rpages_crawled\x18\x06 \x01(\x05R\fpagesCrawled\x12!\n" +
+	"\fpages_failed\x18\a \x01(\x05R\vpagesFailed\x12\x1f\n" +
+	"\vpages_total\x18\b \x01(\x05R\n" +
+	"pagesTotal\x12#\n" +
+	"\rfrontier_size\x18\t \x01(\x05R\ffrontierSize\x12#\n" +
+	"\rerror_message\x18\n" +
+	" \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x0e \x01(\tR\tprojectId\"\x8e\x01\n" +
+	"\x11StartCrawlRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12+\n" +
+	"\x06config\x18\x03 \x01(\v2\x13.rag.v1.CrawlConfigR\x06config\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\"$\n" +
+	"\x12GetCrawlJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbb\x01\n" +
+	"\x14ListCrawlJobsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.rag.v1.CrawlStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tR\tprojectId\"e\n" +
+	"\x15ListCrawlJobsResponse\x12$\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x10.rag.v1.CrawlJobR\x04jobs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\x94\x01\n" +
+	"\vCrawlStatus\x12\x1c\n" +
+	"\x18CRAWL_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CRAWL_STATUS_PENDING\x10\x01\x12\x18\n" +
+	"\x14CRAWL_STATUS_RUNNING\x10\x02\x12\x1a\n" +
+	"\x16CRAWL_STATUS_COMPLETED\x10\x03\x12\x17\n" +
+	"\x13CRAWL_STATUS_FAILED\x10\x042\x98\x02\n" +
+	"\fCrawlService\x12P\n" +
+	"\n" +
+	"StartCrawl\x12\x19.rag.v1.StartCrawlRequest\x1a\x10.rag.v1.CrawlJob\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/crawls\x12T\n" +
+	"\vGetCrawlJob\x12\x1a.rag.v1.GetCrawlJobRequest\x1a\x10.rag.v1.CrawlJob\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/crawls/{id}\x12`\n" +
+	"\rListCrawlJobs\x12\x1c.rag.v1.ListCrawlJobsRequest\x1a\x1d.rag.v1.ListCrawlJobsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/crawlsB\xe8\x01\x92Aj\x12@\n" +
+	"\rRAG Crawl API\x12*Multi-tenant RAG service - website crawler2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\n" +
+	"CrawlProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_crawl_proto_rawDescOnce sync.Once
+	file_rag_v1_crawl_proto_rawDescData []byte
+)
+
+func file_rag_v1_crawl_proto_rawDescGZIP() []byte {
+	file_rag_v1_crawl_proto_rawDescOnce.Do(func() {
+		file_rag_v1_crawl_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_crawl_proto_rawDesc), len(file_rag_v1_crawl_proto_rawDesc)))
+	})
+	return file_rag_v1_crawl_proto_rawDescData
+}
+
+var file_rag_v1_crawl_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rag_v1_crawl_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rag_v1_crawl_proto_goTypes = []any{
+	(CrawlStatus)(0),              // 0: rag.v1.CrawlStatus
+	(*CrawlConfig)(nil),           // 1: rag.v1.CrawlConfig
+	(*CrawlJob)(nil),              // 2: rag.v1.CrawlJob
+	(*StartCrawlRequest)(nil),     // 3: rag.v1.StartCrawlRequest
+	(*GetCrawlJobRequest)(nil),    // 4: rag.v1.GetCrawlJobRequest
+	(*ListCrawlJobsRequest)(nil),  // 5: rag.v1.ListCrawlJobsRequest
+	(*ListCrawlJobsResponse)(nil), // 6: rag.v1.ListCrawlJobsResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_rag_v1_crawl_proto_depIdxs = []int32{
+	0,  // 0: rag.v1.CrawlJob.status:type_name -> rag.v1.CrawlStatus
+	1,  // 1: rag.v1.CrawlJob.config:type_name -> rag.v1.CrawlConfig
+	7,  // 2: rag.v1.CrawlJob.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 3: rag.v1.CrawlJob.started_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: rag.v1.CrawlJob.completed_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: rag.v1.StartCrawlRequest.config:type_name -> rag.v1.CrawlConfig
+	0,  // 6: rag.v1.ListCrawlJobsRequest.status:type_name -> rag.v1.CrawlStatus
+	2,  // 7: rag.v1.ListCrawlJobsResponse.jobs:type_name -> rag.v1.CrawlJob
+	3,  // 8: rag.v1.CrawlService.StartCrawl:input_type -> rag.v1.StartCrawlRequest
+	4,  // 9: rag.v1.CrawlService.GetCrawlJob:input_type -> rag.v1.GetCrawlJobRequest
+	5,  // 10: rag.v1.CrawlService.ListCrawlJobs:input_type -> rag.v1.ListCrawlJobsRequest
+	2,  // 11: rag.v1.CrawlService.StartCrawl:output_type -> rag.v1.CrawlJob
+	2,  // 12: rag.v1.CrawlService.GetCrawlJob:output_type -> rag.v1.CrawlJob
+	6,  // 13: rag.v1.CrawlService.ListCrawlJobs:output_type -> rag.v1.ListCrawlJobsResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_crawl_proto_init() }
+func file_rag_v1_crawl_proto_init() {
+	if File_rag_v1_crawl_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_crawl_proto_rawDesc), len(file_rag_v1_crawl_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_crawl_proto_goTypes,
+		DependencyIndexes: file_rag_v1_crawl_proto_depIdxs,
+		EnumInfos:         file_rag_v1_crawl_proto_enumTypes,
+		MessageInfos:      file_rag_v1_crawl_proto_msgTypes,
+	}.Build()
+	File_rag_v1_crawl_proto = out.File
+	file_rag_v1_crawl_proto_goTypes = nil
+	file_rag_v1_crawl_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: rag/v1/crawl.proto
+
+package ragv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CrawlService_StartCrawl_FullMethodName    = "/rag.v1.CrawlService/StartCrawl"
+	CrawlService_GetCrawlJob_FullMethodName   = "/rag.v1.CrawlService/GetCrawlJob"
+	CrawlService_ListCrawlJobs_FullMethodName = "/rag.v1.CrawlService/ListCrawlJobs"
+)
+
+// CrawlServiceClient is the client API for CrawlService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CrawlService crawls websites from a root URL, ingesting each page as a document.
+// A job's frontier is persisted, so jobs interrupted by a restart resume where they stopped.
+// Each page's document records how it was found in the metadata keys crawl_depth,
+// crawl_parent_url and crawl_anchor_text, which retrieved chunks carry.
+type CrawlServiceClient interface {
+	// StartCrawl starts crawling a site in the background
+	StartCrawl(ctx context.Context, in *StartCrawlRequest, opts ...grpc.CallOption) (*CrawlJob, error)
+	// GetCrawlJob returns a crawl job's progress
+	GetCrawlJob(ctx context.Context, in *GetCrawlJobRequest, opts ...grpc.CallOption) (*CrawlJob, error)
+	// ListCrawlJobs lists crawl jobs for a tenant, newest first
+	ListCrawlJobs(ctx context.Context, in *ListCrawlJobsRequest, opts ...grpc.CallOption) (*ListCrawlJobsResponse, error)
+}
+
+type crawlServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCrawlServiceClient(cc grpc.ClientConnInterface) CrawlServiceClient {
+	return &crawlServiceClient{cc}
+}
+
+func (c *crawlServiceClient) StartCrawl(ctx context.Context, in *StartCrawlRequest, opts ...grpc.CallOption) (*CrawlJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrawlJob)
+	err := c.cc.Invoke(ctx, CrawlService_StartCrawl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlServiceClient) GetCrawlJob(ctx context.Context, in *GetCrawlJobRequest, opts ...grpc.CallOption) (*CrawlJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrawlJob)
+	err := c.cc.Invoke(ctx, CrawlService_GetCrawlJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlServiceClient) ListCrawlJobs(ctx context.Context, in *ListCrawlJobsRequest, opts ...grpc.CallOption) (*ListCrawlJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCrawlJobsResponse)
+	err := c.cc.Invoke(ctx, CrawlService_ListCrawlJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrawlServiceServer is the server API for CrawlService service.
+// All implementations must embed UnimplementedCrawlServiceServer
+// for forward compatibility.
+//
+// CrawlService crawls websites from a root URL, ingesting each page as a document.
+// A job's frontier is persisted, so jobs interrupted by a restart resume where they stopped.
+// Each page's document records how it was found in the metadata keys crawl_depth,
+// crawl_parent_url and crawl_anchor_text, which retrieved chunks carry.
+type CrawlServiceServer interface {
+	// StartCrawl starts crawling a site in the background
+	StartCrawl(context.Context, *StartCrawlRequest) (*CrawlJob, error)
+	// GetCrawlJob returns a crawl job's progress
+	GetCrawlJob(context.Context, *GetCrawlJobRequest) (*CrawlJob, error)
+	// ListCrawlJobs lists crawl jobs for a tenant, newest first
+	ListCrawlJobs(context.Context, *ListCrawlJobsRequest) (*ListCrawlJobsResponse, error)
+	mustEmbedUnimplementedCrawlServiceServer()
+}
+
+// UnimplementedCrawlServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCrawlServiceServer struct{}
+
+func (UnimplementedCrawlServiceServer) StartCrawl(context.Context, *StartCrawlRequest) (*CrawlJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartCrawl not implemented")
+}
+func (UnimplementedCrawlServiceServer) GetCrawlJob(context.Context, *GetCrawlJobRequest) (*CrawlJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCrawlJob not implemented")
+}
+func (UnimplementedCrawlServiceServer) ListCrawlJobs(context.Context, *ListCrawlJobsRequest) (*ListCrawlJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCrawlJobs not implemented")
+}
+func (UnimplementedCrawlServiceServer) mustEmbedUnimplementedCrawlServiceServer() {}
+func (UnimplementedCrawlServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeCrawlServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CrawlServiceServer will
+// result in compilation errors.
+type UnsafeCrawlServiceServer interface {
+	mustEmbedUnimplementedCrawlServiceServer()
+}
+
+func RegisterCrawlServiceServer(s grpc.ServiceRegistrar, srv CrawlServiceServer) {
+	// If the following call panics, it indicates UnimplementedCrawlServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CrawlService_ServiceDesc, srv)
+}
+
+func _CrawlService_StartCrawl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartCrawlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServiceServer).StartCrawl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlService_StartCrawl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServiceServer).StartCrawl(ctx, req.(*StartCrawlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlService_GetCrawlJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCrawlJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServiceServer).GetCrawlJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlService_GetCrawlJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServiceServer).GetCrawlJob(ctx, req.(*GetCrawlJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlService_ListCrawlJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCrawlJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlServiceServer).ListCrawlJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlService_ListCrawlJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlServiceServer).ListCrawlJobs(ctx, req.(*ListCrawlJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CrawlService_ServiceDesc is the grpc.ServiceDesc for CrawlService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CrawlService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rag.v1.CrawlService",
+	HandlerType: (*CrawlServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartCrawl",
+			Handler:    _CrawlService_StartCrawl_Handler,
+		},
+		{
+			MethodName: "GetCrawlJob",
+			Handler:    _CrawlService_GetCrawlJob_Handler,
+		},
+		{
+			MethodName: "ListCrawlJobs",
+			Handler:    _CrawlService_ListCrawlJobs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rag/v1/crawl.proto",
+}
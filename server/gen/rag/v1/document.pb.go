@@ -80,22 +80,147 @@ func (DocumentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_rag_v1_document_proto_rawDescGZIP(), []int{0}
 }
 
+// DocumentStage is a step of document processing
+type DocumentStage int32
+
+const (
+	DocumentStage_DOCUMENT_STAGE_UNSPECIFIED DocumentStage = 0
+	// Fetching the URL (IngestURL only)
+	DocumentStage_DOCUMENT_STAGE_FETCHING DocumentStage = 1
+	// Splitting the content into chunks
+	DocumentStage_DOCUMENT_STAGE_CHUNKING DocumentStage = 2
+	// Chunks created and stored
+	DocumentStage_DOCUMENT_STAGE_CHUNKED DocumentStage = 3
+	// Embedding chunks; chunks_embedded counts up to chunks_total
+	DocumentStage_DOCUMENT_STAGE_EMBEDDING DocumentStage = 4
+	// Vectors written to the vector store
+	DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED DocumentStage = 5
+)
+
+// Enum value maps for DocumentStage.
+var (
+	DocumentStage_name = map[int32]string{
+		0: "DOCUMENT_STAGE_UNSPECIFIED",
+		1: "DOCUMENT_STAGE_FETCHING",
+		2: "DOCUMENT_STAGE_CHUNKING",
+		3: "DOCUMENT_STAGE_CHUNKED",
+		4: "DOCUMENT_STAGE_EMBEDDING",
+		5: "DOCUMENT_STAGE_VECTORS_UPSERTED",
+	}
+	DocumentStage_value = map[string]int32{
+		"DOCUMENT_STAGE_UNSPECIFIED":      0,
+		"DOCUMENT_STAGE_FETCHING":         1,
+		"DOCUMENT_STAGE_CHUNKING":         2,
+		"DOCUMENT_STAGE_CHUNKED":          3,
+		"DOCUMENT_STAGE_EMBEDDING":        4,
+		"DOCUMENT_STAGE_VECTORS_UPSERTED": 5,
+	}
+)
+
+func (x DocumentStage) Enum() *DocumentStage {
+	p := new(DocumentStage)
+	*p = x
+	return p
+}
+
+func (x DocumentStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_document_proto_enumTypes[1].Descriptor()
+}
+
+func (DocumentStage) Type() protoreflect.EnumType {
+	return &file_rag_v1_document_proto_enumTypes[1]
+}
+
+func (x DocumentStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentStage.Descriptor instead.
+func (DocumentStage) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{1}
+}
+
+// DocumentSortField is the field documents are listed by
+type DocumentSortField int32
+
+const (
+	DocumentSortField_DOCUMENT_SORT_FIELD_UNSPECIFIED DocumentSortField = 0
+	DocumentSortField_DOCUMENT_SORT_FIELD_CREATED_AT  DocumentSortField = 1
+	DocumentSortField_DOCUMENT_SORT_FIELD_UPDATED_AT  DocumentSortField = 2
+	DocumentSortField_DOCUMENT_SORT_FIELD_TITLE       DocumentSortField = 3
+	DocumentSortField_DOCUMENT_SORT_FIELD_SOURCE      DocumentSortField = 4
+)
+
+// Enum value maps for DocumentSortField.
+var (
+	DocumentSortField_name = map[int32]string{
+		0: "DOCUMENT_SORT_FIELD_UNSPECIFIED",
+		1: "DOCUMENT_SORT_FIELD_CREATED_AT",
+		2: "DOCUMENT_SORT_FIELD_UPDATED_AT",
+		3: "DOCUMENT_SORT_FIELD_TITLE",
+		4: "DOCUMENT_SORT_FIELD_SOURCE",
+	}
+	DocumentSortField_value = map[string]int32{
+		"DOCUMENT_SORT_FIELD_UNSPECIFIED": 0,
+		"DOCUMENT_SORT_FIELD_CREATED_AT":  1,
+		"DOCUMENT_SORT_FIELD_UPDATED_AT":  2,
+		"DOCUMENT_SORT_FIELD_TITLE":       3,
+		"DOCUMENT_SORT_FIELD_SOURCE":      4,
+	}
+)
+
+func (x DocumentSortField) Enum() *DocumentSortField {
+	p := new(DocumentSortField)
+	*p = x
+	return p
+}
+
+func (x DocumentSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_document_proto_enumTypes[2].Descriptor()
+}
+
+func (DocumentSortField) Type() protoreflect.EnumType {
+	return &file_rag_v1_document_proto_enumTypes[2]
+}
+
+func (x DocumentSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentSortField.Descriptor instead.
+func (DocumentSortField) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{2}
+}
+
 // Document represents an ingested document
 type Document struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"` // URL or filename
-	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
-	ContentHash   string                 `protobuf:"bytes,5,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // SHA256 hash for deduplication
-	ChunkCount    int32                  `protobuf:"varint,6,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
-	Status        DocumentStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=rag.v1.DocumentStatus" json:"status,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Error details if status is FAILED
-	Metadata      map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId        string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Source          string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"` // URL or filename
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	ContentHash     string                 `protobuf:"bytes,5,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // SHA256 hash for deduplication
+	ChunkCount      int32                  `protobuf:"varint,6,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	Status          DocumentStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=rag.v1.DocumentStatus" json:"status,omitempty"`
+	ErrorMessage    string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Error details if status is FAILED
+	Metadata        map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tags            []string               `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	CollectionIds   []string               `protobuf:"bytes,13,rep,name=collection_ids,json=collectionIds,proto3" json:"collection_ids,omitempty"`
+	Chunker         *ChunkerConfig         `protobuf:"bytes,14,opt,name=chunker,proto3" json:"chunker,omitempty"`                                          // Chunker override given at ingestion, if any
+	NearDuplicateOf string                 `protobuf:"bytes,15,opt,name=near_duplicate_of,json=nearDuplicateOf,proto3" json:"near_duplicate_of,omitempty"` // Earlier document this one nearly duplicates, when flagged
+	ProjectId       string                 `protobuf:"bytes,16,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                     // Project the document belongs to; empty for the whole tenant
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Document) Reset() {
@@ -205,6 +330,41 @@ func (x *Document) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Document) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Document) GetCollectionIds() []string {
+	if x != nil {
+		return x.CollectionIds
+	}
+	return nil
+}
+
+func (x *Document) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+func (x *Document) GetNearDuplicateOf() string {
+	if x != nil {
+		return x.NearDuplicateOf
+	}
+	return ""
+}
+
+func (x *Document) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
 // DocumentChunk represents a chunk of a document
 type DocumentChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -297,6 +457,8 @@ type IngestDocumentRequest struct {
 	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`     // Optional title
 	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`   // Optional source identifier
 	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,6,opt,name=chunker,proto3" json:"chunker,omitempty"`                      // Optional; set fields override the tenant's chunker config
+	ProjectId     string                 `protobuf:"bytes,7,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Optional project to add the document to; defaults to the API key's
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -366,12 +528,28 @@ func (x *IngestDocumentRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *IngestDocumentRequest) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+func (x *IngestDocumentRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
 type IngestURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	UseHeadless   bool                   `protobuf:"varint,3,opt,name=use_headless,json=useHeadless,proto3" json:"use_headless,omitempty"` // Use headless browser for JS-heavy sites
+	UseHeadless   bool                   `protobuf:"varint,3,opt,name=use_headless,json=useHeadless,proto3" json:"use_headless,omitempty"` // Render in a headless browser, for JS-heavy sites; needs TenantConfig.headless
 	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,5,opt,name=chunker,proto3" json:"chunker,omitempty"`                      // Optional; set fields override the tenant's chunker config
+	ProjectId     string                 `protobuf:"bytes,6,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Optional project to add the document to; defaults to the API key's
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -434,6 +612,120 @@ func (x *IngestURLRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *IngestURLRequest) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+func (x *IngestURLRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type UploadDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // Used as the source and to detect the format
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Optional MIME type; detected from filename/content if empty
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                  // File contents (base64 in JSON)
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`                                // Optional title; defaults to the file's title or filename
+	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,7,opt,name=chunker,proto3" json:"chunker,omitempty"`                      // Optional; set fields override the tenant's chunker config
+	ProjectId     string                 `protobuf:"bytes,8,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Optional project to add the document to; defaults to the API key's
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDocumentRequest) Reset() {
+	*x = UploadDocumentRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDocumentRequest) ProtoMessage() {}
+
+func (x *UploadDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDocumentRequest.ProtoReflect.Descriptor instead.
+func (*UploadDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadDocumentRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UploadDocumentRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadDocumentRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadDocumentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadDocumentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UploadDocumentRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadDocumentRequest) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+func (x *UploadDocumentRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
 type IngestDocumentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
@@ -444,7 +736,7 @@ type IngestDocumentResponse struct {
 
 func (x *IngestDocumentResponse) Reset() {
 	*x = IngestDocumentResponse{}
-	mi := &file_rag_v1_document_proto_msgTypes[4]
+	mi := &file_rag_v1_document_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -456,7 +748,7 @@ func (x *IngestDocumentResponse) String() string {
 func (*IngestDocumentResponse) ProtoMessage() {}
 
 func (x *IngestDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[4]
+	mi := &file_rag_v1_document_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,7 +761,7 @@ func (x *IngestDocumentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngestDocumentResponse.ProtoReflect.Descriptor instead.
 func (*IngestDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{4}
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *IngestDocumentResponse) GetDocumentId() string {
@@ -495,7 +787,7 @@ type GetDocumentRequest struct {
 
 func (x *GetDocumentRequest) Reset() {
 	*x = GetDocumentRequest{}
-	mi := &file_rag_v1_document_proto_msgTypes[5]
+	mi := &file_rag_v1_document_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -507,7 +799,7 @@ func (x *GetDocumentRequest) String() string {
 func (*GetDocumentRequest) ProtoMessage() {}
 
 func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[5]
+	mi := &file_rag_v1_document_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +812,7 @@ func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
 func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{5}
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetDocumentRequest) GetId() string {
@@ -530,31 +822,28 @@ func (x *GetDocumentRequest) GetId() string {
 	return ""
 }
 
-type ListDocumentsRequest struct {
+type WatchDocumentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	StatusFilter  DocumentStatus         `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=rag.v1.DocumentStatus" json:"status_filter,omitempty"` // Optional filter by status
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDocumentsRequest) Reset() {
-	*x = ListDocumentsRequest{}
-	mi := &file_rag_v1_document_proto_msgTypes[6]
+func (x *WatchDocumentRequest) Reset() {
+	*x = WatchDocumentRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsRequest) String() string {
+func (x *WatchDocumentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsRequest) ProtoMessage() {}
+func (*WatchDocumentRequest) ProtoMessage() {}
 
-func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[6]
+func (x *WatchDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,63 +854,50 @@ func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
-func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ListDocumentsRequest) GetTenantId() string {
-	if x != nil {
-		return x.TenantId
-	}
-	return ""
-}
-
-func (x *ListDocumentsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+// Deprecated: Use WatchDocumentRequest.ProtoReflect.Descriptor instead.
+func (*WatchDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListDocumentsRequest) GetPageToken() string {
+func (x *WatchDocumentRequest) GetId() string {
 	if x != nil {
-		return x.PageToken
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListDocumentsRequest) GetStatusFilter() DocumentStatus {
-	if x != nil {
-		return x.StatusFilter
-	}
-	return DocumentStatus_DOCUMENT_STATUS_UNSPECIFIED
-}
-
-type ListDocumentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+// DocumentProgress reports a document's status and how far processing got
+type DocumentProgress struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Status     DocumentStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=rag.v1.DocumentStatus" json:"status,omitempty"`
+	// Last stage completed or started while processing
+	Stage DocumentStage `protobuf:"varint,3,opt,name=stage,proto3,enum=rag.v1.DocumentStage" json:"stage,omitempty"`
+	// Chunks the document was split into, and how many are embedded so far
+	ChunksTotal    int32 `protobuf:"varint,4,opt,name=chunks_total,json=chunksTotal,proto3" json:"chunks_total,omitempty"`
+	ChunksEmbedded int32 `protobuf:"varint,5,opt,name=chunks_embedded,json=chunksEmbedded,proto3" json:"chunks_embedded,omitempty"`
+	// Error details if status is FAILED
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDocumentsResponse) Reset() {
-	*x = ListDocumentsResponse{}
-	mi := &file_rag_v1_document_proto_msgTypes[7]
+func (x *DocumentProgress) Reset() {
+	*x = DocumentProgress{}
+	mi := &file_rag_v1_document_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDocumentsResponse) String() string {
+func (x *DocumentProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDocumentsResponse) ProtoMessage() {}
+func (*DocumentProgress) ProtoMessage() {}
 
-func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[7]
+func (x *DocumentProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,54 +908,824 @@ func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
-func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DocumentProgress.ProtoReflect.Descriptor instead.
+func (*DocumentProgress) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListDocumentsResponse) GetDocuments() []*Document {
+func (x *DocumentProgress) GetDocumentId() string {
 	if x != nil {
-		return x.Documents
+		return x.DocumentId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListDocumentsResponse) GetNextPageToken() string {
+func (x *DocumentProgress) GetStatus() DocumentStatus {
 	if x != nil {
-		return x.NextPageToken
+		return x.Status
 	}
-	return ""
+	return DocumentStatus_DOCUMENT_STATUS_UNSPECIFIED
 }
 
-func (x *ListDocumentsResponse) GetTotalCount() int32 {
+func (x *DocumentProgress) GetStage() DocumentStage {
+	if x != nil {
+		return x.Stage
+	}
+	return DocumentStage_DOCUMENT_STAGE_UNSPECIFIED
+}
+
+func (x *DocumentProgress) GetChunksTotal() int32 {
+	if x != nil {
+		return x.ChunksTotal
+	}
+	return 0
+}
+
+func (x *DocumentProgress) GetChunksEmbedded() int32 {
+	if x != nil {
+		return x.ChunksEmbedded
+	}
+	return 0
+}
+
+func (x *DocumentProgress) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DocumentProgress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListDocumentsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TenantId     string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	PageSize     int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken    string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	StatusFilter DocumentStatus         `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=rag.v1.DocumentStatus" json:"status_filter,omitempty"` // Optional filter by status
+	// Optional filters; all given filters must match
+	SourcePrefix  string                 `protobuf:"bytes,5,opt,name=source_prefix,json=sourcePrefix,proto3" json:"source_prefix,omitempty"`                                                // Source starts with this
+	SourceGlob    string                 `protobuf:"bytes,6,opt,name=source_glob,json=sourceGlob,proto3" json:"source_glob,omitempty"`                                                      // Source matches a glob; * matches any run, ? one character
+	TitleContains string                 `protobuf:"bytes,7,opt,name=title_contains,json=titleContains,proto3" json:"title_contains,omitempty"`                                             // Case-insensitive substring of the title
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`                                                // Inclusive
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`                                             // Exclusive
+	Metadata      map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata has all of these key/value pairs
+	Tags          []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`                                                                                   // Has at least one of these tags
+	CollectionId  string                 `protobuf:"bytes,14,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`                                               // Is in this collection
+	ProjectId     string                 `protobuf:"bytes,15,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                                                        // Belongs to this project; defaults to the API key's
+	SortBy        DocumentSortField      `protobuf:"varint,11,opt,name=sort_by,json=sortBy,proto3,enum=rag.v1.DocumentSortField" json:"sort_by,omitempty"`                                  // Default created_at
+	Ascending     bool                   `protobuf:"varint,12,opt,name=ascending,proto3" json:"ascending,omitempty"`                                                                        // Default newest/last first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDocumentsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDocumentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetStatusFilter() DocumentStatus {
+	if x != nil {
+		return x.StatusFilter
+	}
+	return DocumentStatus_DOCUMENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListDocumentsRequest) GetSourcePrefix() string {
+	if x != nil {
+		return x.SourcePrefix
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetSourceGlob() string {
+	if x != nil {
+		return x.SourceGlob
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetTitleContains() string {
+	if x != nil {
+		return x.TitleContains
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListDocumentsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListDocumentsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListDocumentsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListDocumentsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetSortBy() DocumentSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return DocumentSortField_DOCUMENT_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListDocumentsRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+type ListDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     []*Document            `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_rag_v1_document_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListDocumentsResponse) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *ListDocumentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListDocumentsResponse) GetTotalCount() int32 {
 	if x != nil {
 		return x.TotalCount
 	}
-	return 0
+	return 0
+}
+
+type DeleteDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentRequest) Reset() {
+	*x = DeleteDocumentRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentRequest) ProtoMessage() {}
+
+func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteDocumentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentResponse) Reset() {
+	*x = DeleteDocumentResponse{}
+	mi := &file_rag_v1_document_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentResponse) ProtoMessage() {}
+
+func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteDocumentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RechunkDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,2,opt,name=chunker,proto3" json:"chunker,omitempty"` // Optional; replaces the document's chunker override when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RechunkDocumentRequest) Reset() {
+	*x = RechunkDocumentRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RechunkDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RechunkDocumentRequest) ProtoMessage() {}
+
+func (x *RechunkDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RechunkDocumentRequest.ProtoReflect.Descriptor instead.
+func (*RechunkDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RechunkDocumentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RechunkDocumentRequest) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+type GetDocumentContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentContentRequest) Reset() {
+	*x = GetDocumentContentRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentContentRequest) ProtoMessage() {}
+
+func (x *GetDocumentContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentContentRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentContentRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDocumentContentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DocumentContentChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentType   string                 `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // First message only
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // First message only; set for uploaded files
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                                 // First message only; total bytes
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentContentChunk) Reset() {
+	*x = DocumentContentChunk{}
+	mi := &file_rag_v1_document_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentContentChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentContentChunk) ProtoMessage() {}
+
+func (x *DocumentContentChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentContentChunk.ProtoReflect.Descriptor instead.
+func (*DocumentContentChunk) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DocumentContentChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DocumentContentChunk) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *DocumentContentChunk) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DocumentContentChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetDocumentChunksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentChunksRequest) Reset() {
+	*x = GetDocumentChunksRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentChunksRequest) ProtoMessage() {}
+
+func (x *GetDocumentChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentChunksRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentChunksRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetDocumentChunksRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *GetDocumentChunksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDocumentChunksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetDocumentChunksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        []*DocumentChunk       `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentChunksResponse) Reset() {
+	*x = GetDocumentChunksResponse{}
+	mi := &file_rag_v1_document_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentChunksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentChunksResponse) ProtoMessage() {}
+
+func (x *GetDocumentChunksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentChunksResponse.ProtoReflect.Descriptor instead.
+func (*GetDocumentChunksResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDocumentChunksResponse) GetChunks() []*DocumentChunk {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *GetDocumentChunksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddTagsRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoveTagsRequest) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type DocumentTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // All of the document's tags after the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentTagsResponse) Reset() {
+	*x = DocumentTagsResponse{}
+	mi := &file_rag_v1_document_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentTagsResponse) ProtoMessage() {}
+
+func (x *DocumentTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type DeleteDocumentRequest struct {
+// Deprecated: Use DocumentTagsResponse.ProtoReflect.Descriptor instead.
+func (*DocumentTagsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DocumentTagsResponse) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *DocumentTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type PreviewChunksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // Raw text content
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`     // Optional title, as in IngestDocumentRequest
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`   // Optional source identifier
+	Metadata      map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,6,opt,name=chunker,proto3" json:"chunker,omitempty"` // Optional; set fields override the tenant's chunker config
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteDocumentRequest) Reset() {
-	*x = DeleteDocumentRequest{}
-	mi := &file_rag_v1_document_proto_msgTypes[8]
+func (x *PreviewChunksRequest) Reset() {
+	*x = PreviewChunksRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteDocumentRequest) String() string {
+func (x *PreviewChunksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDocumentRequest) ProtoMessage() {}
+func (*PreviewChunksRequest) ProtoMessage() {}
 
-func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[8]
+func (x *PreviewChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,40 +1736,81 @@ func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use PreviewChunksRequest.ProtoReflect.Descriptor instead.
+func (*PreviewChunksRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *DeleteDocumentRequest) GetId() string {
+func (x *PreviewChunksRequest) GetTenantId() string {
 	if x != nil {
-		return x.Id
+		return x.TenantId
 	}
 	return ""
 }
 
-type DeleteDocumentResponse struct {
+func (x *PreviewChunksRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PreviewChunksRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PreviewChunksRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PreviewChunksRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PreviewChunksRequest) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+type PreviewChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ChunkIndex    int32                  `protobuf:"varint,1,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	WordCount     int32                  `protobuf:"varint,4,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	TokenCount    int32                  `protobuf:"varint,5,opt,name=token_count,json=tokenCount,proto3" json:"token_count,omitempty"`    // Estimated tokens
+	ParentStart   int32                  `protobuf:"varint,6,opt,name=parent_start,json=parentStart,proto3" json:"parent_start,omitempty"` // First chunk index of the chunk's section
+	ParentEnd     int32                  `protobuf:"varint,7,opt,name=parent_end,json=parentEnd,proto3" json:"parent_end,omitempty"`       // Last chunk index of the chunk's section
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteDocumentResponse) Reset() {
-	*x = DeleteDocumentResponse{}
-	mi := &file_rag_v1_document_proto_msgTypes[9]
+func (x *PreviewChunk) Reset() {
+	*x = PreviewChunk{}
+	mi := &file_rag_v1_document_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteDocumentResponse) String() string {
+func (x *PreviewChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteDocumentResponse) ProtoMessage() {}
+func (*PreviewChunk) ProtoMessage() {}
 
-func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[9]
+func (x *PreviewChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -734,42 +1821,87 @@ func (x *DeleteDocumentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteDocumentResponse.ProtoReflect.Descriptor instead.
-func (*DeleteDocumentResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use PreviewChunk.ProtoReflect.Descriptor instead.
+func (*PreviewChunk) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *DeleteDocumentResponse) GetSuccess() bool {
+func (x *PreviewChunk) GetChunkIndex() int32 {
 	if x != nil {
-		return x.Success
+		return x.ChunkIndex
 	}
-	return false
+	return 0
 }
 
-type GetDocumentChunksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PreviewChunk) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
 }
 
-func (x *GetDocumentChunksRequest) Reset() {
-	*x = GetDocumentChunksRequest{}
-	mi := &file_rag_v1_document_proto_msgTypes[10]
+func (x *PreviewChunk) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PreviewChunk) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *PreviewChunk) GetTokenCount() int32 {
+	if x != nil {
+		return x.TokenCount
+	}
+	return 0
+}
+
+func (x *PreviewChunk) GetParentStart() int32 {
+	if x != nil {
+		return x.ParentStart
+	}
+	return 0
+}
+
+func (x *PreviewChunk) GetParentEnd() int32 {
+	if x != nil {
+		return x.ParentEnd
+	}
+	return 0
+}
+
+type ChunkingStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OriginalLength    int32                  `protobuf:"varint,1,opt,name=original_length,json=originalLength,proto3" json:"original_length,omitempty"` // Characters of the content
+	OriginalWordCount int32                  `protobuf:"varint,2,opt,name=original_word_count,json=originalWordCount,proto3" json:"original_word_count,omitempty"`
+	ChunkCount        int32                  `protobuf:"varint,3,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	TotalChunkWords   int32                  `protobuf:"varint,4,opt,name=total_chunk_words,json=totalChunkWords,proto3" json:"total_chunk_words,omitempty"` // Words across all chunks, overlap included
+	AvgChunkWords     int32                  `protobuf:"varint,5,opt,name=avg_chunk_words,json=avgChunkWords,proto3" json:"avg_chunk_words,omitempty"`
+	ProcessingTimeMs  int64                  `protobuf:"varint,6,opt,name=processing_time_ms,json=processingTimeMs,proto3" json:"processing_time_ms,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ChunkingStats) Reset() {
+	*x = ChunkingStats{}
+	mi := &file_rag_v1_document_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDocumentChunksRequest) String() string {
+func (x *ChunkingStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDocumentChunksRequest) ProtoMessage() {}
+func (*ChunkingStats) ProtoMessage() {}
 
-func (x *GetDocumentChunksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[10]
+func (x *ChunkingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,55 +1912,77 @@ func (x *GetDocumentChunksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDocumentChunksRequest.ProtoReflect.Descriptor instead.
-func (*GetDocumentChunksRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ChunkingStats.ProtoReflect.Descriptor instead.
+func (*ChunkingStats) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *GetDocumentChunksRequest) GetDocumentId() string {
+func (x *ChunkingStats) GetOriginalLength() int32 {
 	if x != nil {
-		return x.DocumentId
+		return x.OriginalLength
 	}
-	return ""
+	return 0
 }
 
-func (x *GetDocumentChunksRequest) GetPageSize() int32 {
+func (x *ChunkingStats) GetOriginalWordCount() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.OriginalWordCount
 	}
 	return 0
 }
 
-func (x *GetDocumentChunksRequest) GetPageToken() string {
+func (x *ChunkingStats) GetChunkCount() int32 {
 	if x != nil {
-		return x.PageToken
+		return x.ChunkCount
 	}
-	return ""
+	return 0
 }
 
-type GetDocumentChunksResponse struct {
+func (x *ChunkingStats) GetTotalChunkWords() int32 {
+	if x != nil {
+		return x.TotalChunkWords
+	}
+	return 0
+}
+
+func (x *ChunkingStats) GetAvgChunkWords() int32 {
+	if x != nil {
+		return x.AvgChunkWords
+	}
+	return 0
+}
+
+func (x *ChunkingStats) GetProcessingTimeMs() int64 {
+	if x != nil {
+		return x.ProcessingTimeMs
+	}
+	return 0
+}
+
+type PreviewChunksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunks        []*DocumentChunk       `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Chunks        []*PreviewChunk        `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Stats         *ChunkingStats         `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	Chunker       *ChunkerConfig         `protobuf:"bytes,3,opt,name=chunker,proto3" json:"chunker,omitempty"` // The chunker config the content was chunked with
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDocumentChunksResponse) Reset() {
-	*x = GetDocumentChunksResponse{}
-	mi := &file_rag_v1_document_proto_msgTypes[11]
+func (x *PreviewChunksResponse) Reset() {
+	*x = PreviewChunksResponse{}
+	mi := &file_rag_v1_document_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDocumentChunksResponse) String() string {
+func (x *PreviewChunksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDocumentChunksResponse) ProtoMessage() {}
+func (*PreviewChunksResponse) ProtoMessage() {}
 
-func (x *GetDocumentChunksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_document_proto_msgTypes[11]
+func (x *PreviewChunksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,21 +1993,96 @@ func (x *GetDocumentChunksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDocumentChunksResponse.ProtoReflect.Descriptor instead.
-func (*GetDocumentChunksResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_document_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use PreviewChunksResponse.ProtoReflect.Descriptor instead.
+func (*PreviewChunksResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetDocumentChunksResponse) GetChunks() []*DocumentChunk {
+func (x *PreviewChunksResponse) GetChunks() []*PreviewChunk {
 	if x != nil {
 		return x.Chunks
 	}
 	return nil
 }
 
-func (x *GetDocumentChunksResponse) GetNextPageToken() string {
+func (x *PreviewChunksResponse) GetStats() *ChunkingStats {
 	if x != nil {
-		return x.NextPageToken
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PreviewChunksResponse) GetChunker() *ChunkerConfig {
+	if x != nil {
+		return x.Chunker
+	}
+	return nil
+}
+
+type ListDocumentsByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsByTagRequest) Reset() {
+	*x = ListDocumentsByTagRequest{}
+	mi := &file_rag_v1_document_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsByTagRequest) ProtoMessage() {}
+
+func (x *ListDocumentsByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_document_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsByTagRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_document_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListDocumentsByTagRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListDocumentsByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListDocumentsByTagRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDocumentsByTagRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
@@ -862,7 +2091,7 @@ var File_rag_v1_document_proto protoreflect.FileDescriptor
 
 const file_rag_v1_document_proto_rawDesc = "" +
 	"\n" +
-	"\x15rag/v1/document.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xed\x03\n" +
+	"\x15rag/v1/document.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x13rag/v1/tenant.proto\"\xa4\x05\n" +
 	"\bDocument\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x16\n" +
@@ -878,7 +2107,13 @@ const file_rag_v1_document_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a;\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tags\x12%\n" +
+	"\x0ecollection_ids\x18\r \x03(\tR\rcollectionIds\x12/\n" +
+	"\achunker\x18\x0e \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\x12*\n" +
+	"\x11near_duplicate_of\x18\x0f \x01(\tR\x0fnearDuplicateOf\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x10 \x01(\tR\tprojectId\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x02\n" +
@@ -894,21 +2129,40 @@ const file_rag_v1_document_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd2\x02\n" +
 	"\x15IngestDocumentRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
 	"\x06source\x18\x04 \x01(\tR\x06source\x12G\n" +
-	"\bmetadata\x18\x05 \x03(\v2+.rag.v1.IngestDocumentRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x05 \x03(\v2+.rag.v1.IngestDocumentRequest.MetadataEntryR\bmetadata\x12/\n" +
+	"\achunker\x18\x06 \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\a \x01(\tR\tprojectId\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe5\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb5\x02\n" +
 	"\x10IngestURLRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12!\n" +
 	"\fuse_headless\x18\x03 \x01(\bR\vuseHeadless\x12B\n" +
-	"\bmetadata\x18\x04 \x03(\v2&.rag.v1.IngestURLRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x04 \x03(\v2&.rag.v1.IngestURLRequest.MetadataEntryR\bmetadata\x12/\n" +
+	"\achunker\x18\x05 \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\tR\tprojectId\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf3\x02\n" +
+	"\x15UploadDocumentRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12G\n" +
+	"\bmetadata\x18\x06 \x03(\v2+.rag.v1.UploadDocumentRequest.MetadataEntryR\bmetadata\x12/\n" +
+	"\achunker\x18\a \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\b \x01(\tR\tprojectId\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
@@ -917,13 +2171,42 @@ const file_rag_v1_document_proto_rawDesc = "" +
 	"documentId\x12.\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x16.rag.v1.DocumentStatusR\x06status\"$\n" +
 	"\x12GetDocumentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xac\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14WatchDocumentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbc\x02\n" +
+	"\x10DocumentProgress\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.rag.v1.DocumentStatusR\x06status\x12+\n" +
+	"\x05stage\x18\x03 \x01(\x0e2\x15.rag.v1.DocumentStageR\x05stage\x12!\n" +
+	"\fchunks_total\x18\x04 \x01(\x05R\vchunksTotal\x12'\n" +
+	"\x0fchunks_embedded\x18\x05 \x01(\x05R\x0echunksEmbedded\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcc\x05\n" +
 	"\x14ListDocumentsRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x03 \x01(\tR\tpageToken\x12;\n" +
-	"\rstatus_filter\x18\x04 \x01(\x0e2\x16.rag.v1.DocumentStatusR\fstatusFilter\"\x90\x01\n" +
+	"\rstatus_filter\x18\x04 \x01(\x0e2\x16.rag.v1.DocumentStatusR\fstatusFilter\x12#\n" +
+	"\rsource_prefix\x18\x05 \x01(\tR\fsourcePrefix\x12\x1f\n" +
+	"\vsource_glob\x18\x06 \x01(\tR\n" +
+	"sourceGlob\x12%\n" +
+	"\x0etitle_contains\x18\a \x01(\tR\rtitleContains\x12?\n" +
+	"\rcreated_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12F\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2*.rag.v1.ListDocumentsRequest.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12#\n" +
+	"\rcollection_id\x18\x0e \x01(\tR\fcollectionId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x0f \x01(\tR\tprojectId\x122\n" +
+	"\asort_by\x18\v \x01(\x0e2\x19.rag.v1.DocumentSortFieldR\x06sortBy\x12\x1c\n" +
+	"\tascending\x18\f \x01(\bR\tascending\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x01\n" +
 	"\x15ListDocumentsResponse\x12.\n" +
 	"\tdocuments\x18\x01 \x03(\v2\x10.rag.v1.DocumentR\tdocuments\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
@@ -932,7 +2215,17 @@ const file_rag_v1_document_proto_rawDesc = "" +
 	"\x15DeleteDocumentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
 	"\x16DeleteDocumentResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"w\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Y\n" +
+	"\x16RechunkDocumentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\achunker\x18\x02 \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\"+\n" +
+	"\x19GetDocumentContentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"}\n" +
+	"\x14DocumentContentChunk\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\"w\n" +
 	"\x18GetDocumentChunksRequest\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12\x1b\n" +
@@ -941,20 +2234,97 @@ const file_rag_v1_document_proto_rawDesc = "" +
 	"page_token\x18\x03 \x01(\tR\tpageToken\"r\n" +
 	"\x19GetDocumentChunksResponse\x12-\n" +
 	"\x06chunks\x18\x01 \x03(\v2\x15.rag.v1.DocumentChunkR\x06chunks\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xa5\x01\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"E\n" +
+	"\x0eAddTagsRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"H\n" +
+	"\x11RemoveTagsRequest\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"K\n" +
+	"\x14DocumentTagsResponse\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"\xb1\x02\n" +
+	"\x14PreviewChunksRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12F\n" +
+	"\bmetadata\x18\x05 \x03(\v2*.rag.v1.PreviewChunksRequest.MetadataEntryR\bmetadata\x12/\n" +
+	"\achunker\x18\x06 \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
+	"\fPreviewChunk\x12\x1f\n" +
+	"\vchunk_index\x18\x01 \x01(\x05R\n" +
+	"chunkIndex\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12>\n" +
+	"\bmetadata\x18\x03 \x03(\v2\".rag.v1.PreviewChunk.MetadataEntryR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x04 \x01(\x05R\twordCount\x12\x1f\n" +
+	"\vtoken_count\x18\x05 \x01(\x05R\n" +
+	"tokenCount\x12!\n" +
+	"\fparent_start\x18\x06 \x01(\x05R\vparentStart\x12\x1d\n" +
+	"\n" +
+	"parent_end\x18\a \x01(\x05R\tparentEnd\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8b\x02\n" +
+	"\rChunkingStats\x12'\n" +
+	"\x0foriginal_length\x18\x01 \x01(\x05R\x0eoriginalLength\x12.\n" +
+	"\x13original_word_count\x18\x02 \x01(\x05R\x11originalWordCount\x12\x1f\n" +
+	"\vchunk_count\x18\x03 \x01(\x05R\n" +
+	"chunkCount\x12*\n" +
+	"\x11total_chunk_words\x18\x04 \x01(\x05R\x0ftotalChunkWords\x12&\n" +
+	"\x0favg_chunk_words\x18\x05 \x01(\x05R\ravgChunkWords\x12,\n" +
+	"\x12processing_time_ms\x18\x06 \x01(\x03R\x10processingTimeMs\"\xa3\x01\n" +
+	"\x15PreviewChunksResponse\x12,\n" +
+	"\x06chunks\x18\x01 \x03(\v2\x14.rag.v1.PreviewChunkR\x06chunks\x12+\n" +
+	"\x05stats\x18\x02 \x01(\v2\x15.rag.v1.ChunkingStatsR\x05stats\x12/\n" +
+	"\achunker\x18\x03 \x01(\v2\x15.rag.v1.ChunkerConfigR\achunker\"\x86\x01\n" +
+	"\x19ListDocumentsByTagRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken*\xa5\x01\n" +
 	"\x0eDocumentStatus\x12\x1f\n" +
 	"\x1bDOCUMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17DOCUMENT_STATUS_PENDING\x10\x01\x12\x1e\n" +
 	"\x1aDOCUMENT_STATUS_PROCESSING\x10\x02\x12\x19\n" +
 	"\x15DOCUMENT_STATUS_READY\x10\x03\x12\x1a\n" +
-	"\x16DOCUMENT_STATUS_FAILED\x10\x042\xa1\x05\n" +
+	"\x16DOCUMENT_STATUS_FAILED\x10\x04*\xc8\x01\n" +
+	"\rDocumentStage\x12\x1e\n" +
+	"\x1aDOCUMENT_STAGE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DOCUMENT_STAGE_FETCHING\x10\x01\x12\x1b\n" +
+	"\x17DOCUMENT_STAGE_CHUNKING\x10\x02\x12\x1a\n" +
+	"\x16DOCUMENT_STAGE_CHUNKED\x10\x03\x12\x1c\n" +
+	"\x18DOCUMENT_STAGE_EMBEDDING\x10\x04\x12#\n" +
+	"\x1fDOCUMENT_STAGE_VECTORS_UPSERTED\x10\x05*\xbf\x01\n" +
+	"\x11DocumentSortField\x12#\n" +
+	"\x1fDOCUMENT_SORT_FIELD_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eDOCUMENT_SORT_FIELD_CREATED_AT\x10\x01\x12\"\n" +
+	"\x1eDOCUMENT_SORT_FIELD_UPDATED_AT\x10\x02\x12\x1d\n" +
+	"\x19DOCUMENT_SORT_FIELD_TITLE\x10\x03\x12\x1e\n" +
+	"\x1aDOCUMENT_SORT_FIELD_SOURCE\x10\x042\xd1\f\n" +
 	"\x0fDocumentService\x12p\n" +
 	"\x0eIngestDocument\x12\x1d.rag.v1.IngestDocumentRequest\x1a\x1e.rag.v1.IngestDocumentResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/documents/ingest\x12j\n" +
-	"\tIngestURL\x12\x18.rag.v1.IngestURLRequest\x1a\x1e.rag.v1.IngestDocumentResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/documents/ingest-url\x12W\n" +
+	"\tIngestURL\x12\x18.rag.v1.IngestURLRequest\x1a\x1e.rag.v1.IngestDocumentResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/documents/ingest-url\x12p\n" +
+	"\x0eUploadDocument\x12\x1d.rag.v1.UploadDocumentRequest\x1a\x1e.rag.v1.IngestDocumentResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/documents/upload\x12W\n" +
 	"\vGetDocument\x12\x1a.rag.v1.GetDocumentRequest\x1a\x10.rag.v1.Document\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/documents/{id}\x12c\n" +
 	"\rListDocuments\x12\x1c.rag.v1.ListDocumentsRequest\x1a\x1d.rag.v1.ListDocumentsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/documents\x12k\n" +
-	"\x0eDeleteDocument\x12\x1d.rag.v1.DeleteDocumentRequest\x1a\x1e.rag.v1.DeleteDocumentResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/documents/{id}\x12\x84\x01\n" +
-	"\x11GetDocumentChunks\x12 .rag.v1.GetDocumentChunksRequest\x1a!.rag.v1.GetDocumentChunksResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/documents/{document_id}/chunksB\xf2\x01\x92Aq\x12G\n" +
+	"\x0eDeleteDocument\x12\x1d.rag.v1.DeleteDocumentRequest\x1a\x1e.rag.v1.DeleteDocumentResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/v1/documents/{id}\x12x\n" +
+	"\x0fRechunkDocument\x12\x1e.rag.v1.RechunkDocumentRequest\x1a\x1e.rag.v1.IngestDocumentResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/documents/{id}/rechunk\x12{\n" +
+	"\x12GetDocumentContent\x12!.rag.v1.GetDocumentContentRequest\x1a\x1c.rag.v1.DocumentContentChunk\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/documents/{id}/content0\x01\x12k\n" +
+	"\rWatchDocument\x12\x1c.rag.v1.WatchDocumentRequest\x1a\x18.rag.v1.DocumentProgress\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/documents/{id}/watch0\x01\x12\x84\x01\n" +
+	"\x11GetDocumentChunks\x12 .rag.v1.GetDocumentChunksRequest\x1a!.rag.v1.GetDocumentChunksResponse\"*\x82\xd3\xe4\x93\x02$\x12\"/v1/documents/{document_id}/chunks\x12l\n" +
+	"\aAddTags\x12\x16.rag.v1.AddTagsRequest\x1a\x1c.rag.v1.DocumentTagsResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/documents/{document_id}/tags\x12y\n" +
+	"\n" +
+	"RemoveTags\x12\x19.rag.v1.RemoveTagsRequest\x1a\x1c.rag.v1.DocumentTagsResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/documents/{document_id}/tags/remove\x12x\n" +
+	"\x12ListDocumentsByTag\x12!.rag.v1.ListDocumentsByTagRequest\x1a\x1d.rag.v1.ListDocumentsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/tags/{tag}/documents\x12u\n" +
+	"\rPreviewChunks\x12\x1c.rag.v1.PreviewChunksRequest\x1a\x1d.rag.v1.PreviewChunksResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/documents/preview-chunksB\xf2\x01\x92Aq\x12G\n" +
 	"\x10RAG Document API\x12.Multi-tenant RAG service - Document management2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
 	"\n" +
 	"com.rag.v1B\rDocumentProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
@@ -971,58 +2341,114 @@ func file_rag_v1_document_proto_rawDescGZIP() []byte {
 	return file_rag_v1_document_proto_rawDescData
 }
 
-var file_rag_v1_document_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rag_v1_document_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_rag_v1_document_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_rag_v1_document_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_rag_v1_document_proto_goTypes = []any{
 	(DocumentStatus)(0),               // 0: rag.v1.DocumentStatus
-	(*Document)(nil),                  // 1: rag.v1.Document
-	(*DocumentChunk)(nil),             // 2: rag.v1.DocumentChunk
-	(*IngestDocumentRequest)(nil),     // 3: rag.v1.IngestDocumentRequest
-	(*IngestURLRequest)(nil),          // 4: rag.v1.IngestURLRequest
-	(*IngestDocumentResponse)(nil),    // 5: rag.v1.IngestDocumentResponse
-	(*GetDocumentRequest)(nil),        // 6: rag.v1.GetDocumentRequest
-	(*ListDocumentsRequest)(nil),      // 7: rag.v1.ListDocumentsRequest
-	(*ListDocumentsResponse)(nil),     // 8: rag.v1.ListDocumentsResponse
-	(*DeleteDocumentRequest)(nil),     // 9: rag.v1.DeleteDocumentRequest
-	(*DeleteDocumentResponse)(nil),    // 10: rag.v1.DeleteDocumentResponse
-	(*GetDocumentChunksRequest)(nil),  // 11: rag.v1.GetDocumentChunksRequest
-	(*GetDocumentChunksResponse)(nil), // 12: rag.v1.GetDocumentChunksResponse
-	nil,                               // 13: rag.v1.Document.MetadataEntry
-	nil,                               // 14: rag.v1.DocumentChunk.MetadataEntry
-	nil,                               // 15: rag.v1.IngestDocumentRequest.MetadataEntry
-	nil,                               // 16: rag.v1.IngestURLRequest.MetadataEntry
-	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
+	(DocumentStage)(0),                // 1: rag.v1.DocumentStage
+	(DocumentSortField)(0),            // 2: rag.v1.DocumentSortField
+	(*Document)(nil),                  // 3: rag.v1.Document
+	(*DocumentChunk)(nil),             // 4: rag.v1.DocumentChunk
+	(*IngestDocumentRequest)(nil),     // 5: rag.v1.IngestDocumentRequest
+	(*IngestURLRequest)(nil),          // 6: rag.v1.IngestURLRequest
+	(*UploadDocumentRequest)(nil),     // 7: rag.v1.UploadDocumentRequest
+	(*IngestDocumentResponse)(nil),    // 8: rag.v1.IngestDocumentResponse
+	(*GetDocumentRequest)(nil),        // 9: rag.v1.GetDocumentRequest
+	(*WatchDocumentRequest)(nil),      // 10: rag.v1.WatchDocumentRequest
+	(*DocumentProgress)(nil),          // 11: rag.v1.DocumentProgress
+	(*ListDocumentsRequest)(nil),      // 12: rag.v1.ListDocumentsRequest
+	(*ListDocumentsResponse)(nil),     // 13: rag.v1.ListDocumentsResponse
+	(*DeleteDocumentRequest)(nil),     // 14: rag.v1.DeleteDocumentRequest
+	(*DeleteDocumentResponse)(nil),    // 15: rag.v1.DeleteDocumentResponse
+	(*RechunkDocumentRequest)(nil),    // 16: rag.v1.RechunkDocumentRequest
+	(*GetDocumentContentRequest)(nil), // 17: rag.v1.GetDocumentContentRequest
+	(*DocumentContentChunk)(nil),      // 18: rag.v1.DocumentContentChunk
+	(*GetDocumentChunksRequest)(nil),  // 19: rag.v1.GetDocumentChunksRequest
+	(*GetDocumentChunksResponse)(nil), // 20: rag.v1.GetDocumentChunksResponse
+	(*AddTagsRequest)(nil),            // 21: rag.v1.AddTagsRequest
+	(*RemoveTagsRequest)(nil),         // 22: rag.v1.RemoveTagsRequest
+	(*DocumentTagsResponse)(nil),      // 23: rag.v1.DocumentTagsResponse
+	(*PreviewChunksRequest)(nil),      // 24: rag.v1.PreviewChunksRequest
+	(*PreviewChunk)(nil),              // 25: rag.v1.PreviewChunk
+	(*ChunkingStats)(nil),             // 26: rag.v1.ChunkingStats
+	(*PreviewChunksResponse)(nil),     // 27: rag.v1.PreviewChunksResponse
+	(*ListDocumentsByTagRequest)(nil), // 28: rag.v1.ListDocumentsByTagRequest
+	nil,                               // 29: rag.v1.Document.MetadataEntry
+	nil,                               // 30: rag.v1.DocumentChunk.MetadataEntry
+	nil,                               // 31: rag.v1.IngestDocumentRequest.MetadataEntry
+	nil,                               // 32: rag.v1.IngestURLRequest.MetadataEntry
+	nil,                               // 33: rag.v1.UploadDocumentRequest.MetadataEntry
+	nil,                               // 34: rag.v1.ListDocumentsRequest.MetadataEntry
+	nil,                               // 35: rag.v1.PreviewChunksRequest.MetadataEntry
+	nil,                               // 36: rag.v1.PreviewChunk.MetadataEntry
+	(*timestamppb.Timestamp)(nil),     // 37: google.protobuf.Timestamp
+	(*ChunkerConfig)(nil),             // 38: rag.v1.ChunkerConfig
 }
 var file_rag_v1_document_proto_depIdxs = []int32{
 	0,  // 0: rag.v1.Document.status:type_name -> rag.v1.DocumentStatus
-	13, // 1: rag.v1.Document.metadata:type_name -> rag.v1.Document.MetadataEntry
-	17, // 2: rag.v1.Document.created_at:type_name -> google.protobuf.Timestamp
-	17, // 3: rag.v1.Document.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 4: rag.v1.DocumentChunk.metadata:type_name -> rag.v1.DocumentChunk.MetadataEntry
-	17, // 5: rag.v1.DocumentChunk.created_at:type_name -> google.protobuf.Timestamp
-	15, // 6: rag.v1.IngestDocumentRequest.metadata:type_name -> rag.v1.IngestDocumentRequest.MetadataEntry
-	16, // 7: rag.v1.IngestURLRequest.metadata:type_name -> rag.v1.IngestURLRequest.MetadataEntry
-	0,  // 8: rag.v1.IngestDocumentResponse.status:type_name -> rag.v1.DocumentStatus
-	0,  // 9: rag.v1.ListDocumentsRequest.status_filter:type_name -> rag.v1.DocumentStatus
-	1,  // 10: rag.v1.ListDocumentsResponse.documents:type_name -> rag.v1.Document
-	2,  // 11: rag.v1.GetDocumentChunksResponse.chunks:type_name -> rag.v1.DocumentChunk
-	3,  // 12: rag.v1.DocumentService.IngestDocument:input_type -> rag.v1.IngestDocumentRequest
-	4,  // 13: rag.v1.DocumentService.IngestURL:input_type -> rag.v1.IngestURLRequest
-	6,  // 14: rag.v1.DocumentService.GetDocument:input_type -> rag.v1.GetDocumentRequest
-	7,  // 15: rag.v1.DocumentService.ListDocuments:input_type -> rag.v1.ListDocumentsRequest
-	9,  // 16: rag.v1.DocumentService.DeleteDocument:input_type -> rag.v1.DeleteDocumentRequest
-	11, // 17: rag.v1.DocumentService.GetDocumentChunks:input_type -> rag.v1.GetDocumentChunksRequest
-	5,  // 18: rag.v1.DocumentService.IngestDocument:output_type -> rag.v1.IngestDocumentResponse
-	5,  // 19: rag.v1.DocumentService.IngestURL:output_type -> rag.v1.IngestDocumentResponse
-	1,  // 20: rag.v1.DocumentService.GetDocument:output_type -> rag.v1.Document
-	8,  // 21: rag.v1.DocumentService.ListDocuments:output_type -> rag.v1.ListDocumentsResponse
-	10, // 22: rag.v1.DocumentService.DeleteDocument:output_type -> rag.v1.DeleteDocumentResponse
-	12, // 23: rag.v1.DocumentService.GetDocumentChunks:output_type -> rag.v1.GetDocumentChunksResponse
-	18, // [18:24] is the sub-list for method output_type
-	12, // [12:18] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	29, // 1: rag.v1.Document.metadata:type_name -> rag.v1.Document.MetadataEntry
+	37, // 2: rag.v1.Document.created_at:type_name -> google.protobuf.Timestamp
+	37, // 3: rag.v1.Document.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 4: rag.v1.Document.chunker:type_name -> rag.v1.ChunkerConfig
+	30, // 5: rag.v1.DocumentChunk.metadata:type_name -> rag.v1.DocumentChunk.MetadataEntry
+	37, // 6: rag.v1.DocumentChunk.created_at:type_name -> google.protobuf.Timestamp
+	31, // 7: rag.v1.IngestDocumentRequest.metadata:type_name -> rag.v1.IngestDocumentRequest.MetadataEntry
+	38, // 8: rag.v1.IngestDocumentRequest.chunker:type_name -> rag.v1.ChunkerConfig
+	32, // 9: rag.v1.IngestURLRequest.metadata:type_name -> rag.v1.IngestURLRequest.MetadataEntry
+	38, // 10: rag.v1.IngestURLRequest.chunker:type_name -> rag.v1.ChunkerConfig
+	33, // 11: rag.v1.UploadDocumentRequest.metadata:type_name -> rag.v1.UploadDocumentRequest.MetadataEntry
+	38, // 12: rag.v1.UploadDocumentRequest.chunker:type_name -> rag.v1.ChunkerConfig
+	0,  // 13: rag.v1.IngestDocumentResponse.status:type_name -> rag.v1.DocumentStatus
+	0,  // 14: rag.v1.DocumentProgress.status:type_name -> rag.v1.DocumentStatus
+	1,  // 15: rag.v1.DocumentProgress.stage:type_name -> rag.v1.DocumentStage
+	37, // 16: rag.v1.DocumentProgress.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 17: rag.v1.ListDocumentsRequest.status_filter:type_name -> rag.v1.DocumentStatus
+	37, // 18: rag.v1.ListDocumentsRequest.created_after:type_name -> google.protobuf.Timestamp
+	37, // 19: rag.v1.ListDocumentsRequest.created_before:type_name -> google.protobuf.Timestamp
+	34, // 20: rag.v1.ListDocumentsRequest.metadata:type_name -> rag.v1.ListDocumentsRequest.MetadataEntry
+	2,  // 21: rag.v1.ListDocumentsRequest.sort_by:type_name -> rag.v1.DocumentSortField
+	3,  // 22: rag.v1.ListDocumentsResponse.documents:type_name -> rag.v1.Document
+	38, // 23: rag.v1.RechunkDocumentRequest.chunker:type_name -> rag.v1.ChunkerConfig
+	4,  // 24: rag.v1.GetDocumentChunksResponse.chunks:type_name -> rag.v1.DocumentChunk
+	35, // 25: rag.v1.PreviewChunksRequest.metadata:type_name -> rag.v1.PreviewChunksRequest.MetadataEntry
+	38, // 26: rag.v1.PreviewChunksRequest.chunker:type_name -> rag.v1.ChunkerConfig
+	36, // 27: rag.v1.PreviewChunk.metadata:type_name -> rag.v1.PreviewChunk.MetadataEntry
+	25, // 28: rag.v1.PreviewChunksResponse.chunks:type_name -> rag.v1.PreviewChunk
+	26, // 29: rag.v1.PreviewChunksResponse.stats:type_name -> rag.v1.ChunkingStats
+	38, // 30: rag.v1.PreviewChunksResponse.chunker:type_name -> rag.v1.ChunkerConfig
+	5,  // 31: rag.v1.DocumentService.IngestDocument:input_type -> rag.v1.IngestDocumentRequest
+	6,  // 32: rag.v1.DocumentService.IngestURL:input_type -> rag.v1.IngestURLRequest
+	7,  // 33: rag.v1.DocumentService.UploadDocument:input_type -> rag.v1.UploadDocumentRequest
+	9,  // 34: rag.v1.DocumentService.GetDocument:input_type -> rag.v1.GetDocumentRequest
+	12, // 35: rag.v1.DocumentService.ListDocuments:input_type -> rag.v1.ListDocumentsRequest
+	14, // 36: rag.v1.DocumentService.DeleteDocument:input_type -> rag.v1.DeleteDocumentRequest
+	16, // 37: rag.v1.DocumentService.RechunkDocument:input_type -> rag.v1.RechunkDocumentRequest
+	17, // 38: rag.v1.DocumentService.GetDocumentContent:input_type -> rag.v1.GetDocumentContentRequest
+	10, // 39: rag.v1.DocumentService.WatchDocument:input_type -> rag.v1.WatchDocumentRequest
+	19, // 40: rag.v1.DocumentService.GetDocumentChunks:input_type -> rag.v1.GetDocumentChunksRequest
+	21, // 41: rag.v1.DocumentService.AddTags:input_type -> rag.v1.AddTagsRequest
+	22, // 42: rag.v1.DocumentService.RemoveTags:input_type -> rag.v1.RemoveTagsRequest
+	28, // 43: rag.v1.DocumentService.ListDocumentsByTag:input_type -> rag.v1.ListDocumentsByTagRequest
+	24, // 44: rag.v1.DocumentService.PreviewChunks:input_type -> rag.v1.PreviewChunksRequest
+	8,  // 45: rag.v1.DocumentService.IngestDocument:output_type -> rag.v1.IngestDocumentResponse
+	8,  // 46: rag.v1.DocumentService.IngestURL:output_type -> rag.v1.IngestDocumentResponse
+	8,  // 47: rag.v1.DocumentService.UploadDocument:output_type -> rag.v1.IngestDocumentResponse
+	3,  // 48: rag.v1.DocumentService.GetDocument:output_type -> rag.v1.Document
+	13, // 49: rag.v1.DocumentService.ListDocuments:output_type -> rag.v1.ListDocumentsResponse
+	15, // 50: rag.v1.DocumentService.DeleteDocument:output_type -> rag.v1.DeleteDocumentResponse
+	8,  // 51: rag.v1.DocumentService.RechunkDocument:output_type -> rag.v1.IngestDocumentResponse
+	18, // 52: rag.v1.DocumentService.GetDocumentContent:output_type -> rag.v1.DocumentContentChunk
+	11, // 53: rag.v1.DocumentService.WatchDocument:output_type -> rag.v1.DocumentProgress
+	20, // 54: rag.v1.DocumentService.GetDocumentChunks:output_type -> rag.v1.GetDocumentChunksResponse
+	23, // 55: rag.v1.DocumentService.AddTags:output_type -> rag.v1.DocumentTagsResponse
+	23, // 56: rag.v1.DocumentService.RemoveTags:output_type -> rag.v1.DocumentTagsResponse
+	13, // 57: rag.v1.DocumentService.ListDocumentsByTag:output_type -> rag.v1.ListDocumentsResponse
+	27, // 58: rag.v1.DocumentService.PreviewChunks:output_type -> rag.v1.PreviewChunksResponse
+	45, // [45:59] is the sub-list for method output_type
+	31, // [31:45] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_rag_v1_document_proto_init() }
@@ -1030,13 +2456,14 @@ func file_rag_v1_document_proto_init() {
 	if File_rag_v1_document_proto != nil {
 		return
 	}
+	file_rag_v1_tenant_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_document_proto_rawDesc), len(file_rag_v1_document_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   16,
+			NumEnums:      3,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
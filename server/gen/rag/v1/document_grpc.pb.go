@@ -19,12 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DocumentService_IngestDocument_FullMethodName    = "/rag.v1.DocumentService/IngestDocument"
-	DocumentService_IngestURL_FullMethodName         = "/rag.v1.DocumentService/IngestURL"
-	DocumentService_GetDocument_FullMethodName       = "/rag.v1.DocumentService/GetDocument"
-	DocumentService_ListDocuments_FullMethodName     = "/rag.v1.DocumentService/ListDocuments"
-	DocumentService_DeleteDocument_FullMethodName    = "/rag.v1.DocumentService/DeleteDocument"
-	DocumentService_GetDocumentChunks_FullMethodName = "/rag.v1.DocumentService/GetDocumentChunks"
+	DocumentService_IngestDocument_FullMethodName     = "/rag.v1.DocumentService/IngestDocument"
+	DocumentService_IngestURL_FullMethodName          = "/rag.v1.DocumentService/IngestURL"
+	DocumentService_UploadDocument_FullMethodName     = "/rag.v1.DocumentService/UploadDocument"
+	DocumentService_GetDocument_FullMethodName        = "/rag.v1.DocumentService/GetDocument"
+	DocumentService_ListDocuments_FullMethodName      = "/rag.v1.DocumentService/ListDocuments"
+	DocumentService_DeleteDocument_FullMethodName     = "/rag.v1.DocumentService/DeleteDocument"
+	DocumentService_RechunkDocument_FullMethodName    = "/rag.v1.DocumentService/RechunkDocument"
+	DocumentService_GetDocumentContent_FullMethodName = "/rag.v1.DocumentService/GetDocumentContent"
+	DocumentService_WatchDocument_FullMethodName      = "/rag.v1.DocumentService/WatchDocument"
+	DocumentService_GetDocumentChunks_FullMethodName  = "/rag.v1.DocumentService/GetDocumentChunks"
+	DocumentService_AddTags_FullMethodName            = "/rag.v1.DocumentService/AddTags"
+	DocumentService_RemoveTags_FullMethodName         = "/rag.v1.DocumentService/RemoveTags"
+	DocumentService_ListDocumentsByTag_FullMethodName = "/rag.v1.DocumentService/ListDocumentsByTag"
+	DocumentService_PreviewChunks_FullMethodName      = "/rag.v1.DocumentService/PreviewChunks"
 )
 
 // DocumentServiceClient is the client API for DocumentService service.
@@ -35,16 +43,48 @@ const (
 type DocumentServiceClient interface {
 	// IngestDocument ingests raw text content
 	IngestDocument(ctx context.Context, in *IngestDocumentRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error)
-	// IngestURL fetches and ingests content from a URL
+	// IngestURL fetches and ingests content from a URL. The URL is
+	// canonicalized (scheme and host lowercased, tracking parameters and
+	// trailing slash dropped) and the page's canonical link is honored, so
+	// re-ingesting a page under another address re-fetches its existing
+	// document instead of creating another. The re-fetch is conditional on the
+	// page's ETag and Last-Modified, and an unchanged page is not reprocessed.
+	// Content that is not HTML, such as a PDF, is extracted as an uploaded file
+	// of its type would be; types no extractor supports fail the document.
 	IngestURL(ctx context.Context, in *IngestURLRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error)
+	// UploadDocument ingests a file (DOCX, PPTX, HTML, Markdown or text),
+	// extracting its text according to the content type or file extension
+	UploadDocument(ctx context.Context, in *UploadDocumentRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error)
 	// GetDocument retrieves a document by ID
 	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error)
 	// ListDocuments lists documents for a tenant
 	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
 	// DeleteDocument deletes a document and its chunks
 	DeleteDocument(ctx context.Context, in *DeleteDocumentRequest, opts ...grpc.CallOption) (*DeleteDocumentResponse, error)
+	// RechunkDocument re-chunks and re-embeds a document from its stored
+	// original content, optionally with a new chunker override
+	RechunkDocument(ctx context.Context, in *RechunkDocumentRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error)
+	// GetDocumentContent streams back the original content a document was
+	// ingested from. The first message carries the content type and size.
+	GetDocumentContent(ctx context.Context, in *GetDocumentContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentContentChunk], error)
+	// WatchDocument streams a document's processing progress, starting with
+	// its current state, until it is ready or has failed. Browsers can use
+	// GET /v1/documents/{id}/events instead, which sends the same messages as
+	// server-sent "progress" events. EventSource cannot set headers, so that
+	// endpoint also takes a token from IssueToken with a ttl of at most 15
+	// minutes in its access_token query parameter.
+	WatchDocument(ctx context.Context, in *WatchDocumentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentProgress], error)
 	// GetDocumentChunks retrieves chunks for a document
 	GetDocumentChunks(ctx context.Context, in *GetDocumentChunksRequest, opts ...grpc.CallOption) (*GetDocumentChunksResponse, error)
+	// AddTags tags a document; tags are lowercased and existing tags are kept
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*DocumentTagsResponse, error)
+	// RemoveTags removes tags from a document
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*DocumentTagsResponse, error)
+	// ListDocumentsByTag lists a tenant's documents with a tag
+	ListDocumentsByTag(ctx context.Context, in *ListDocumentsByTagRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
+	// PreviewChunks chunks content as IngestDocument would, without storing or
+	// embedding anything, so chunker settings can be tuned before an ingest
+	PreviewChunks(ctx context.Context, in *PreviewChunksRequest, opts ...grpc.CallOption) (*PreviewChunksResponse, error)
 }
 
 type documentServiceClient struct {
@@ -75,6 +115,16 @@ func (c *documentServiceClient) IngestURL(ctx context.Context, in *IngestURLRequ
 	return out, nil
 }
 
+func (c *documentServiceClient) UploadDocument(ctx context.Context, in *UploadDocumentRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestDocumentResponse)
+	err := c.cc.Invoke(ctx, DocumentService_UploadDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *documentServiceClient) GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Document)
@@ -105,6 +155,54 @@ func (c *documentServiceClient) DeleteDocument(ctx context.Context, in *DeleteDo
 	return out, nil
 }
 
+func (c *documentServiceClient) RechunkDocument(ctx context.Context, in *RechunkDocumentRequest, opts ...grpc.CallOption) (*IngestDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestDocumentResponse)
+	err := c.cc.Invoke(ctx, DocumentService_RechunkDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) GetDocumentContent(ctx context.Context, in *GetDocumentContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentContentChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DocumentService_ServiceDesc.Streams[0], DocumentService_GetDocumentContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetDocumentContentRequest, DocumentContentChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_GetDocumentContentClient = grpc.ServerStreamingClient[DocumentContentChunk]
+
+func (c *documentServiceClient) WatchDocument(ctx context.Context, in *WatchDocumentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DocumentProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DocumentService_ServiceDesc.Streams[1], DocumentService_WatchDocument_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDocumentRequest, DocumentProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_WatchDocumentClient = grpc.ServerStreamingClient[DocumentProgress]
+
 func (c *documentServiceClient) GetDocumentChunks(ctx context.Context, in *GetDocumentChunksRequest, opts ...grpc.CallOption) (*GetDocumentChunksResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetDocumentChunksResponse)
@@ -115,6 +213,46 @@ func (c *documentServiceClient) GetDocumentChunks(ctx context.Context, in *GetDo
 	return out, nil
 }
 
+func (c *documentServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*DocumentTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentTagsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*DocumentTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentTagsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) ListDocumentsByTag(ctx context.Context, in *ListDocumentsByTagRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDocumentsResponse)
+	err := c.cc.Invoke(ctx, DocumentService_ListDocumentsByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentServiceClient) PreviewChunks(ctx context.Context, in *PreviewChunksRequest, opts ...grpc.CallOption) (*PreviewChunksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewChunksResponse)
+	err := c.cc.Invoke(ctx, DocumentService_PreviewChunks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocumentServiceServer is the server API for DocumentService service.
 // All implementations must embed UnimplementedDocumentServiceServer
 // for forward compatibility.
@@ -123,16 +261,48 @@ func (c *documentServiceClient) GetDocumentChunks(ctx context.Context, in *GetDo
 type DocumentServiceServer interface {
 	// IngestDocument ingests raw text content
 	IngestDocument(context.Context, *IngestDocumentRequest) (*IngestDocumentResponse, error)
-	// IngestURL fetches and ingests content from a URL
+	// IngestURL fetches and ingests content from a URL. The URL is
+	// canonicalized (scheme and host lowercased, tracking parameters and
+	// trailing slash dropped) and the page's canonical link is honored, so
+	// re-ingesting a page under another address re-fetches its existing
+	// document instead of creating another. The re-fetch is conditional on the
+	// page's ETag and Last-Modified, and an unchanged page is not reprocessed.
+	// Content that is not HTML, such as a PDF, is extracted as an uploaded file
+	// of its type would be; types no extractor supports fail the document.
 	IngestURL(context.Context, *IngestURLRequest) (*IngestDocumentResponse, error)
+	// UploadDocument ingests a file (DOCX, PPTX, HTML, Markdown or text),
+	// extracting its text according to the content type or file extension
+	UploadDocument(context.Context, *UploadDocumentRequest) (*IngestDocumentResponse, error)
 	// GetDocument retrieves a document by ID
 	GetDocument(context.Context, *GetDocumentRequest) (*Document, error)
 	// ListDocuments lists documents for a tenant
 	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
 	// DeleteDocument deletes a document and its chunks
 	DeleteDocument(context.Context, *DeleteDocumentRequest) (*DeleteDocumentResponse, error)
+	// RechunkDocument re-chunks and re-embeds a document from its stored
+	// original content, optionally with a new chunker override
+	RechunkDocument(context.Context, *RechunkDocumentRequest) (*IngestDocumentResponse, error)
+	// GetDocumentContent streams back the original content a document was
+	// ingested from. The first message carries the content type and size.
+	GetDocumentContent(*GetDocumentContentRequest, grpc.ServerStreamingServer[DocumentContentChunk]) error
+	// WatchDocument streams a document's processing progress, starting with
+	// its current state, until it is ready or has failed. Browsers can use
+	// GET /v1/documents/{id}/events instead, which sends the same messages as
+	// server-sent "progress" events. EventSource cannot set headers, so that
+	// endpoint also takes a token from IssueToken with a ttl of at most 15
+	// minutes in its access_token query parameter.
+	WatchDocument(*WatchDocumentRequest, grpc.ServerStreamingServer[DocumentProgress]) error
 	// GetDocumentChunks retrieves chunks for a document
 	GetDocumentChunks(context.Context, *GetDocumentChunksRequest) (*GetDocumentChunksResponse, error)
+	// AddTags tags a document; tags are lowercased and existing tags are kept
+	AddTags(context.Context, *AddTagsRequest) (*DocumentTagsResponse, error)
+	// RemoveTags removes tags from a document
+	RemoveTags(context.Context, *RemoveTagsRequest) (*DocumentTagsResponse, error)
+	// ListDocumentsByTag lists a tenant's documents with a tag
+	ListDocumentsByTag(context.Context, *ListDocumentsByTagRequest) (*ListDocumentsResponse, error)
+	// PreviewChunks chunks content as IngestDocument would, without storing or
+	// embedding anything, so chunker settings can be tuned before an ingest
+	PreviewChunks(context.Context, *PreviewChunksRequest) (*PreviewChunksResponse, error)
 	mustEmbedUnimplementedDocumentServiceServer()
 }
 
@@ -149,6 +319,9 @@ func (UnimplementedDocumentServiceServer) IngestDocument(context.Context, *Inges
 func (UnimplementedDocumentServiceServer) IngestURL(context.Context, *IngestURLRequest) (*IngestDocumentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IngestURL not implemented")
 }
+func (UnimplementedDocumentServiceServer) UploadDocument(context.Context, *UploadDocumentRequest) (*IngestDocumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadDocument not implemented")
+}
 func (UnimplementedDocumentServiceServer) GetDocument(context.Context, *GetDocumentRequest) (*Document, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDocument not implemented")
 }
@@ -158,9 +331,30 @@ func (UnimplementedDocumentServiceServer) ListDocuments(context.Context, *ListDo
 func (UnimplementedDocumentServiceServer) DeleteDocument(context.Context, *DeleteDocumentRequest) (*DeleteDocumentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteDocument not implemented")
 }
+func (UnimplementedDocumentServiceServer) RechunkDocument(context.Context, *RechunkDocumentRequest) (*IngestDocumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RechunkDocument not implemented")
+}
+func (UnimplementedDocumentServiceServer) GetDocumentContent(*GetDocumentContentRequest, grpc.ServerStreamingServer[DocumentContentChunk]) error {
+	return status.Error(codes.Unimplemented, "method GetDocumentContent not implemented")
+}
+func (UnimplementedDocumentServiceServer) WatchDocument(*WatchDocumentRequest, grpc.ServerStreamingServer[DocumentProgress]) error {
+	return status.Error(codes.Unimplemented, "method WatchDocument not implemented")
+}
 func (UnimplementedDocumentServiceServer) GetDocumentChunks(context.Context, *GetDocumentChunksRequest) (*GetDocumentChunksResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetDocumentChunks not implemented")
 }
+func (UnimplementedDocumentServiceServer) AddTags(context.Context, *AddTagsRequest) (*DocumentTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedDocumentServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*DocumentTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedDocumentServiceServer) ListDocumentsByTag(context.Context, *ListDocumentsByTagRequest) (*ListDocumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDocumentsByTag not implemented")
+}
+func (UnimplementedDocumentServiceServer) PreviewChunks(context.Context, *PreviewChunksRequest) (*PreviewChunksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewChunks not implemented")
+}
 func (UnimplementedDocumentServiceServer) mustEmbedUnimplementedDocumentServiceServer() {}
 func (UnimplementedDocumentServiceServer) testEmbeddedByValue()                         {}
 
@@ -218,6 +412,24 @@ func _DocumentService_IngestURL_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocumentService_UploadDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).UploadDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_UploadDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).UploadDocument(ctx, req.(*UploadDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DocumentService_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDocumentRequest)
 	if err := dec(in); err != nil {
@@ -272,6 +484,46 @@ func _DocumentService_DeleteDocument_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocumentService_RechunkDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RechunkDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).RechunkDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_RechunkDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).RechunkDocument(ctx, req.(*RechunkDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_GetDocumentContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetDocumentContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServiceServer).GetDocumentContent(m, &grpc.GenericServerStream[GetDocumentContentRequest, DocumentContentChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_GetDocumentContentServer = grpc.ServerStreamingServer[DocumentContentChunk]
+
+func _DocumentService_WatchDocument_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDocumentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentServiceServer).WatchDocument(m, &grpc.GenericServerStream[WatchDocumentRequest, DocumentProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DocumentService_WatchDocumentServer = grpc.ServerStreamingServer[DocumentProgress]
+
 func _DocumentService_GetDocumentChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDocumentChunksRequest)
 	if err := dec(in); err != nil {
@@ -290,6 +542,78 @@ func _DocumentService_GetDocumentChunks_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocumentService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_ListDocumentsByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDocumentsByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).ListDocumentsByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_ListDocumentsByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).ListDocumentsByTag(ctx, req.(*ListDocumentsByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DocumentService_PreviewChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentServiceServer).PreviewChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DocumentService_PreviewChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentServiceServer).PreviewChunks(ctx, req.(*PreviewChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DocumentService_ServiceDesc is the grpc.ServiceDesc for DocumentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +629,10 @@ var DocumentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IngestURL",
 			Handler:    _DocumentService_IngestURL_Handler,
 		},
+		{
+			MethodName: "UploadDocument",
+			Handler:    _DocumentService_UploadDocument_Handler,
+		},
 		{
 			MethodName: "GetDocument",
 			Handler:    _DocumentService_GetDocument_Handler,
@@ -317,11 +645,42 @@ var DocumentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDocument",
 			Handler:    _DocumentService_DeleteDocument_Handler,
 		},
+		{
+			MethodName: "RechunkDocument",
+			Handler:    _DocumentService_RechunkDocument_Handler,
+		},
 		{
 			MethodName: "GetDocumentChunks",
 			Handler:    _DocumentService_GetDocumentChunks_Handler,
 		},
+		{
+			MethodName: "AddTags",
+			Handler:    _DocumentService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _DocumentService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListDocumentsByTag",
+			Handler:    _DocumentService_ListDocumentsByTag_Handler,
+		},
+		{
+			MethodName: "PreviewChunks",
+			Handler:    _DocumentService_PreviewChunks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetDocumentContent",
+			Handler:       _DocumentService_GetDocumentContent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchDocument",
+			Handler:       _DocumentService_WatchDocument_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rag/v1/document.proto",
 }
@@ -0,0 +1,688 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/feed.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Feed represents a polled RSS/Atom feed and its sync state
+type Feed struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId            string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Url                 string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Title               string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	PollIntervalSeconds int32                  `protobuf:"varint,5,opt,name=poll_interval_seconds,json=pollIntervalSeconds,proto3" json:"poll_interval_seconds,omitempty"`
+	LastPolledAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_polled_at,json=lastPolledAt,proto3" json:"last_polled_at,omitempty"`
+	LastItemAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_item_at,json=lastItemAt,proto3" json:"last_item_at,omitempty"`                                                    // Newest item publication date seen
+	LastError           string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                                                         // Error from the last poll, if any
+	ItemCount           int32                  `protobuf:"varint,9,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`                                                        // Items ingested so far
+	Metadata            map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Added to every ingested document
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ProjectId           string                 `protobuf:"bytes,12,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Project items are ingested into; empty for the whole tenant
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_rag_v1_feed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Feed) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Feed) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Feed) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Feed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Feed) GetPollIntervalSeconds() int32 {
+	if x != nil {
+		return x.PollIntervalSeconds
+	}
+	return 0
+}
+
+func (x *Feed) GetLastPolledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPolledAt
+	}
+	return nil
+}
+
+func (x *Feed) GetLastItemAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastItemAt
+	}
+	return nil
+}
+
+func (x *Feed) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Feed) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *Feed) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Feed) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Feed) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type CreateFeedRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TenantId            string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Url                 string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	PollIntervalSeconds int32                  `protobuf:"varint,3,opt,name=poll_interval_seconds,json=pollIntervalSeconds,proto3" json:"poll_interval_seconds,omitempty"` // Optional: defaults to the server setting
+	Metadata            map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProjectId           string                 `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Optional project to ingest into; defaults to the API key's
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateFeedRequest) Reset() {
+	*x = CreateFeedRequest{}
+	mi := &file_rag_v1_feed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFeedRequest) ProtoMessage() {}
+
+func (x *CreateFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFeedRequest.ProtoReflect.Descriptor instead.
+func (*CreateFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateFeedRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateFeedRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateFeedRequest) GetPollIntervalSeconds() int32 {
+	if x != nil {
+		return x.PollIntervalSeconds
+	}
+	return 0
+}
+
+func (x *CreateFeedRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateFeedRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // Only this project's feeds; defaults to the API key's
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedsRequest) Reset() {
+	*x = ListFeedsRequest{}
+	mi := &file_rag_v1_feed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedsRequest) ProtoMessage() {}
+
+func (x *ListFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListFeedsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListFeedsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFeedsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListFeedsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListFeedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []*Feed                `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedsResponse) Reset() {
+	*x = ListFeedsResponse{}
+	mi := &file_rag_v1_feed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedsResponse) ProtoMessage() {}
+
+func (x *ListFeedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeedsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListFeedsResponse) GetFeeds() []*Feed {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+func (x *ListFeedsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeedRequest) Reset() {
+	*x = DeleteFeedRequest{}
+	mi := &file_rag_v1_feed_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeedRequest) ProtoMessage() {}
+
+func (x *DeleteFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeedRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteFeedRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFeedResponse) Reset() {
+	*x = DeleteFeedResponse{}
+	mi := &file_rag_v1_feed_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFeedResponse) ProtoMessage() {}
+
+func (x *DeleteFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFeedResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFeedResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteFeedResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type SyncFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncFeedRequest) Reset() {
+	*x = SyncFeedRequest{}
+	mi := &file_rag_v1_feed_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncFeedRequest) ProtoMessage() {}
+
+func (x *SyncFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncFeedRequest.ProtoReflect.Descriptor instead.
+func (*SyncFeedRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SyncFeedRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SyncFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemsFound    int32                  `protobuf:"varint,1,opt,name=items_found,json=itemsFound,proto3" json:"items_found,omitempty"`
+	ItemsIngested int32                  `protobuf:"varint,2,opt,name=items_ingested,json=itemsIngested,proto3" json:"items_ingested,omitempty"`
+	Feed          *Feed                  `protobuf:"bytes,3,opt,name=feed,proto3" json:"feed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncFeedResponse) Reset() {
+	*x = SyncFeedResponse{}
+	mi := &file_rag_v1_feed_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncFeedResponse) ProtoMessage() {}
+
+func (x *SyncFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_feed_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncFeedResponse.ProtoReflect.Descriptor instead.
+func (*SyncFeedResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_feed_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SyncFeedResponse) GetItemsFound() int32 {
+	if x != nil {
+		return x.ItemsFound
+	}
+	return 0
+}
+
+func (x *SyncFeedResponse) GetItemsIngested() int32 {
+	if x != nil {
+		return x.ItemsIngested
+	}
+	return 0
+}
+
+func (x *SyncFeedResponse) GetFeed() *Feed {
+	if x != nil {
+		return x.Feed
+	}
+	return nil
+}
+
+var File_rag_v1_feed_proto protoreflect.FileDescriptor
+
+const file_rag_v1_feed_proto_rawDesc = "" +
+	"\n" +
+	"\x11rag/v1/feed.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x9c\x04\n" +
+	"\x04Feed\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x122\n" +
+	"\x15poll_interval_seconds\x18\x05 \x01(\x05R\x13pollIntervalSeconds\x12@\n" +
+	"\x0elast_polled_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\flastPolledAt\x12<\n" +
+	"\flast_item_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastItemAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\t \x01(\x05R\titemCount\x126\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2\x1a.rag.v1.Feed.MetadataEntryR\bmetadata\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\f \x01(\tR\tprojectId\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x02\n" +
+	"\x11CreateFeedRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x122\n" +
+	"\x15poll_interval_seconds\x18\x03 \x01(\x05R\x13pollIntervalSeconds\x12C\n" +
+	"\bmetadata\x18\x04 \x03(\v2'.rag.v1.CreateFeedRequest.MetadataEntryR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tR\tprojectId\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x01\n" +
+	"\x10ListFeedsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\"_\n" +
+	"\x11ListFeedsResponse\x12\"\n" +
+	"\x05feeds\x18\x01 \x03(\v2\f.rag.v1.FeedR\x05feeds\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"#\n" +
+	"\x11DeleteFeedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x12DeleteFeedResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"!\n" +
+	"\x0fSyncFeedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"|\n" +
+	"\x10SyncFeedResponse\x12\x1f\n" +
+	"\vitems_found\x18\x01 \x01(\x05R\n" +
+	"itemsFound\x12%\n" +
+	"\x0eitems_ingested\x18\x02 \x01(\x05R\ritemsIngested\x12 \n" +
+	"\x04feed\x18\x03 \x01(\v2\f.rag.v1.FeedR\x04feed2\xe8\x02\n" +
+	"\vFeedService\x12K\n" +
+	"\n" +
+	"CreateFeed\x12\x19.rag.v1.CreateFeedRequest\x1a\f.rag.v1.Feed\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/feeds\x12S\n" +
+	"\tListFeeds\x12\x18.rag.v1.ListFeedsRequest\x1a\x19.rag.v1.ListFeedsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/feeds\x12[\n" +
+	"\n" +
+	"DeleteFeed\x12\x19.rag.v1.DeleteFeedRequest\x1a\x1a.rag.v1.DeleteFeedResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/feeds/{id}\x12Z\n" +
+	"\bSyncFeed\x12\x17.rag.v1.SyncFeedRequest\x1a\x18.rag.v1.SyncFeedResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\"\x13/v1/feeds/{id}/syncB\xee\x01\x92Aq\x12G\n" +
+	"\fRAG Feed API\x122Multi-tenant RAG service - RSS/Atom feed connector2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\tFeedProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_feed_proto_rawDescOnce sync.Once
+	file_rag_v1_feed_proto_rawDescData []byte
+)
+
+func file_rag_v1_feed_proto_rawDescGZIP() []byte {
+	file_rag_v1_feed_proto_rawDescOnce.Do(func() {
+		file_rag_v1_feed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_feed_proto_rawDesc), len(file_rag_v1_feed_proto_rawDesc)))
+	})
+	return file_rag_v1_feed_proto_rawDescData
+}
+
+var file_rag_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_rag_v1_feed_proto_goTypes = []any{
+	(*Feed)(nil),                  // 0: rag.v1.Feed
+	(*CreateFeedRequest)(nil),     // 1: rag.v1.CreateFeedRequest
+	(*ListFeedsRequest)(nil),      // 2: rag.v1.ListFeedsRequest
+	(*ListFeedsResponse)(nil),     // 3: rag.v1.ListFeedsResponse
+	(*DeleteFeedRequest)(nil),     // 4: rag.v1.DeleteFeedRequest
+	(*DeleteFeedResponse)(nil),    // 5: rag.v1.DeleteFeedResponse
+	(*SyncFeedRequest)(nil),       // 6: rag.v1.SyncFeedRequest
+	(*SyncFeedResponse)(nil),      // 7: rag.v1.SyncFeedResponse
+	nil,                           // 8: rag.v1.Feed.MetadataEntry
+	nil,                           // 9: rag.v1.CreateFeedRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_rag_v1_feed_proto_depIdxs = []int32{
+	10, // 0: rag.v1.Feed.last_polled_at:type_name -> google.protobuf.Timestamp
+	10, // 1: rag.v1.Feed.last_item_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: rag.v1.Feed.metadata:type_name -> rag.v1.Feed.MetadataEntry
+	10, // 3: rag.v1.Feed.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 4: rag.v1.CreateFeedRequest.metadata:type_name -> rag.v1.CreateFeedRequest.MetadataEntry
+	0,  // 5: rag.v1.ListFeedsResponse.feeds:type_name -> rag.v1.Feed
+	0,  // 6: rag.v1.SyncFeedResponse.feed:type_name -> rag.v1.Feed
+	1,  // 7: rag.v1.FeedService.CreateFeed:input_type -> rag.v1.CreateFeedRequest
+	2,  // 8: rag.v1.FeedService.ListFeeds:input_type -> rag.v1.ListFeedsRequest
+	4,  // 9: rag.v1.FeedService.DeleteFeed:input_type -> rag.v1.DeleteFeedRequest
+	6,  // 10: rag.v1.FeedService.SyncFeed:input_type -> rag.v1.SyncFeedRequest
+	0,  // 11: rag.v1.FeedService.CreateFeed:output_type -> rag.v1.Feed
+	3,  // 12: rag.v1.FeedService.ListFeeds:output_type -> rag.v1.ListFeedsResponse
+	5,  // 13: rag.v1.FeedService.DeleteFeed:output_type -> rag.v1.DeleteFeedResponse
+	7,  // 14: rag.v1.FeedService.SyncFeed:output_type -> rag.v1.SyncFeedResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_feed_proto_init() }
+func file_rag_v1_feed_proto_init() {
+	if File_rag_v1_feed_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_feed_proto_rawDesc), len(file_rag_v1_feed_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_feed_proto_goTypes,
+		DependencyIndexes: file_rag_v1_feed_proto_depIdxs,
+		MessageInfos:      file_rag_v1_feed_proto_msgTypes,
+	}.Build()
+	File_rag_v1_feed_proto = out.File
+	file_rag_v1_feed_proto_goTypes = nil
+	file_rag_v1_feed_proto_depIdxs = nil
+}
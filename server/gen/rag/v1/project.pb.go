@@ -0,0 +1,681 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/project.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Project struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                  `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name          string                  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Retrieval     *ProjectRetrievalConfig `protobuf:"bytes,4,opt,name=retrieval,proto3" json:"retrieval,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_rag_v1_project_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetRetrieval() *ProjectRetrievalConfig {
+	if x != nil {
+		return x.Retrieval
+	}
+	return nil
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ProjectRetrievalConfig overrides the tenant's retrieval settings for a
+// project's queries. Unset fields keep the tenant's.
+type ProjectRetrievalConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TopK            int32                  `protobuf:"varint,1,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	MinScore        float32                `protobuf:"fixed32,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	SystemPrompt    string                 `protobuf:"bytes,3,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	LlmModel        string                 `protobuf:"bytes,4,opt,name=llm_model,json=llmModel,proto3" json:"llm_model,omitempty"`
+	RerankerEnabled *bool                  `protobuf:"varint,5,opt,name=reranker_enabled,json=rerankerEnabled,proto3,oneof" json:"reranker_enabled,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProjectRetrievalConfig) Reset() {
+	*x = ProjectRetrievalConfig{}
+	mi := &file_rag_v1_project_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectRetrievalConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectRetrievalConfig) ProtoMessage() {}
+
+func (x *ProjectRetrievalConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectRetrievalConfig.ProtoReflect.Descriptor instead.
+func (*ProjectRetrievalConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProjectRetrievalConfig) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *ProjectRetrievalConfig) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *ProjectRetrievalConfig) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *ProjectRetrievalConfig) GetLlmModel() string {
+	if x != nil {
+		return x.LlmModel
+	}
+	return ""
+}
+
+func (x *ProjectRetrievalConfig) GetRerankerEnabled() bool {
+	if x != nil && x.RerankerEnabled != nil {
+		return *x.RerankerEnabled
+	}
+	return false
+}
+
+type CreateProjectRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Unique within the tenant
+	Name          string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Retrieval     *ProjectRetrievalConfig `protobuf:"bytes,3,opt,name=retrieval,proto3" json:"retrieval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_rag_v1_project_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateProjectRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetRetrieval() *ProjectRetrievalConfig {
+	if x != nil {
+		return x.Retrieval
+	}
+	return nil
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_rag_v1_project_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProjectRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_rag_v1_project_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListProjectsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_rag_v1_project_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type UpdateProjectRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id       string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Empty keeps the name
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Replaces the retrieval settings when set
+	Retrieval     *ProjectRetrievalConfig `protobuf:"bytes,4,opt,name=retrieval,proto3" json:"retrieval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_rag_v1_project_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProjectRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetRetrieval() *ProjectRetrievalConfig {
+	if x != nil {
+		return x.Retrieval
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_rag_v1_project_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteProjectRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_rag_v1_project_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_project_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_project_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteProjectResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_rag_v1_project_proto protoreflect.FileDescriptor
+
+const file_rag_v1_project_proto_rawDesc = "" +
+	"\n" +
+	"\x14rag/v1/project.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xfe\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12<\n" +
+	"\tretrieval\x18\x04 \x01(\v2\x1e.rag.v1.ProjectRetrievalConfigR\tretrieval\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd1\x01\n" +
+	"\x16ProjectRetrievalConfig\x12\x13\n" +
+	"\x05top_k\x18\x01 \x01(\x05R\x04topK\x12\x1b\n" +
+	"\tmin_score\x18\x02 \x01(\x02R\bminScore\x12#\n" +
+	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12\x1b\n" +
+	"\tllm_model\x18\x04 \x01(\tR\bllmModel\x12.\n" +
+	"\x10reranker_enabled\x18\x05 \x01(\bH\x00R\x0frerankerEnabled\x88\x01\x01B\x13\n" +
+	"\x11_reranker_enabled\"\x85\x01\n" +
+	"\x14CreateProjectRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12<\n" +
+	"\tretrieval\x18\x03 \x01(\v2\x1e.rag.v1.ProjectRetrievalConfigR\tretrieval\"@\n" +
+	"\x11GetProjectRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"2\n" +
+	"\x13ListProjectsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"C\n" +
+	"\x14ListProjectsResponse\x12+\n" +
+	"\bprojects\x18\x01 \x03(\v2\x0f.rag.v1.ProjectR\bprojects\"\x95\x01\n" +
+	"\x14UpdateProjectRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12<\n" +
+	"\tretrieval\x18\x04 \x01(\v2\x1e.rag.v1.ProjectRetrievalConfigR\tretrieval\"C\n" +
+	"\x14DeleteProjectRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"1\n" +
+	"\x15DeleteProjectResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe6\x03\n" +
+	"\x0eProjectService\x12W\n" +
+	"\rCreateProject\x12\x1c.rag.v1.CreateProjectRequest\x1a\x0f.rag.v1.Project\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/projects\x12S\n" +
+	"\n" +
+	"GetProject\x12\x19.rag.v1.GetProjectRequest\x1a\x0f.rag.v1.Project\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/projects/{id}\x12_\n" +
+	"\fListProjects\x12\x1b.rag.v1.ListProjectsRequest\x1a\x1c.rag.v1.ListProjectsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/projects\x12\\\n" +
+	"\rUpdateProject\x12\x1c.rag.v1.UpdateProjectRequest\x1a\x0f.rag.v1.Project\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/v1/projects/{id}\x12g\n" +
+	"\rDeleteProject\x12\x1c.rag.v1.DeleteProjectRequest\x1a\x1d.rag.v1.DeleteProjectResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/projects/{id}B\xe5\x01\x92Ae\x12;\n" +
+	"\x0fRAG Project API\x12#Multi-tenant RAG service - Projects2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\fProjectProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_project_proto_rawDescOnce sync.Once
+	file_rag_v1_project_proto_rawDescData []byte
+)
+
+func file_rag_v1_project_proto_rawDescGZIP() []byte {
+	file_rag_v1_project_proto_rawDescOnce.Do(func() {
+		file_rag_v1_project_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_project_proto_rawDesc), len(file_rag_v1_project_proto_rawDesc)))
+	})
+	return file_rag_v1_project_proto_rawDescData
+}
+
+var file_rag_v1_project_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_rag_v1_project_proto_goTypes = []any{
+	(*Project)(nil),                // 0: rag.v1.Project
+	(*ProjectRetrievalConfig)(nil), // 1: rag.v1.ProjectRetrievalConfig
+	(*CreateProjectRequest)(nil),   // 2: rag.v1.CreateProjectRequest
+	(*GetProjectRequest)(nil),      // 3: rag.v1.GetProjectRequest
+	(*ListProjectsRequest)(nil),    // 4: rag.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),   // 5: rag.v1.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),   // 6: rag.v1.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),   // 7: rag.v1.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),  // 8: rag.v1.DeleteProjectResponse
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+}
+var file_rag_v1_project_proto_depIdxs = []int32{
+	1,  // 0: rag.v1.Project.retrieval:type_name -> rag.v1.ProjectRetrievalConfig
+	9,  // 1: rag.v1.Project.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: rag.v1.Project.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: rag.v1.CreateProjectRequest.retrieval:type_name -> rag.v1.ProjectRetrievalConfig
+	0,  // 4: rag.v1.ListProjectsResponse.projects:type_name -> rag.v1.Project
+	1,  // 5: rag.v1.UpdateProjectRequest.retrieval:type_name -> rag.v1.ProjectRetrievalConfig
+	2,  // 6: rag.v1.ProjectService.CreateProject:input_type -> rag.v1.CreateProjectRequest
+	3,  // 7: rag.v1.ProjectService.GetProject:input_type -> rag.v1.GetProjectRequest
+	4,  // 8: rag.v1.ProjectService.ListProjects:input_type -> rag.v1.ListProjectsRequest
+	6,  // 9: rag.v1.ProjectService.UpdateProject:input_type -> rag.v1.UpdateProjectRequest
+	7,  // 10: rag.v1.ProjectService.DeleteProject:input_type -> rag.v1.DeleteProjectRequest
+	0,  // 11: rag.v1.ProjectService.CreateProject:output_type -> rag.v1.Project
+	0,  // 12: rag.v1.ProjectService.GetProject:output_type -> rag.v1.Project
+	5,  // 13: rag.v1.ProjectService.ListProjects:output_type -> rag.v1.ListProjectsResponse
+	0,  // 14: rag.v1.ProjectService.UpdateProject:output_type -> rag.v1.Project
+	8,  // 15: rag.v1.ProjectService.DeleteProject:output_type -> rag.v1.DeleteProjectResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_project_proto_init() }
+func file_rag_v1_project_proto_init() {
+	if File_rag_v1_project_proto != nil {
+		return
+	}
+	file_rag_v1_project_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_project_proto_rawDesc), len(file_rag_v1_project_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_project_proto_goTypes,
+		DependencyIndexes: file_rag_v1_project_proto_depIdxs,
+		MessageInfos:      file_rag_v1_project_proto_msgTypes,
+	}.Build()
+	File_rag_v1_project_proto = out.File
+	file_rag_v1_project_proto_goTypes = nil
+	file_rag_v1_project_proto_depIdxs = nil
+}
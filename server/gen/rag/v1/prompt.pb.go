@@ -0,0 +1,674 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/prompt.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PromptTemplate is one version of a tenant's prompt template
+type PromptTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Template      string                 `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTemplate) Reset() {
+	*x = PromptTemplate{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTemplate) ProtoMessage() {}
+
+func (x *PromptTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTemplate.ProtoReflect.Descriptor instead.
+func (*PromptTemplate) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PromptTemplate) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PromptTemplate) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *PromptTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreatePromptTemplateRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TenantId    string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Template    string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Make the new version active right away
+	Activate      bool `protobuf:"varint,4,opt,name=activate,proto3" json:"activate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromptTemplateRequest) Reset() {
+	*x = CreatePromptTemplateRequest{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromptTemplateRequest) ProtoMessage() {}
+
+func (x *CreatePromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreatePromptTemplateRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreatePromptTemplateRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *CreatePromptTemplateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePromptTemplateRequest) GetActivate() bool {
+	if x != nil {
+		return x.Activate
+	}
+	return false
+}
+
+type GetPromptTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptTemplateRequest) Reset() {
+	*x = GetPromptTemplateRequest{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptTemplateRequest) ProtoMessage() {}
+
+func (x *GetPromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetPromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPromptTemplateRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetPromptTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListPromptTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptTemplatesRequest) Reset() {
+	*x = ListPromptTemplatesRequest{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptTemplatesRequest) ProtoMessage() {}
+
+func (x *ListPromptTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPromptTemplatesRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListPromptTemplatesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPromptTemplatesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListPromptTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*PromptTemplate      `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptTemplatesResponse) Reset() {
+	*x = ListPromptTemplatesResponse{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptTemplatesResponse) ProtoMessage() {}
+
+func (x *ListPromptTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPromptTemplatesResponse) GetTemplates() []*PromptTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+func (x *ListPromptTemplatesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListPromptTemplatesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ActivatePromptTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivatePromptTemplateRequest) Reset() {
+	*x = ActivatePromptTemplateRequest{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivatePromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivatePromptTemplateRequest) ProtoMessage() {}
+
+func (x *ActivatePromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivatePromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*ActivatePromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ActivatePromptTemplateRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ActivatePromptTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ActivatePromptTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveVersion int32                  `protobuf:"varint,1,opt,name=active_version,json=activeVersion,proto3" json:"active_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivatePromptTemplateResponse) Reset() {
+	*x = ActivatePromptTemplateResponse{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivatePromptTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivatePromptTemplateResponse) ProtoMessage() {}
+
+func (x *ActivatePromptTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivatePromptTemplateResponse.ProtoReflect.Descriptor instead.
+func (*ActivatePromptTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActivatePromptTemplateResponse) GetActiveVersion() int32 {
+	if x != nil {
+		return x.ActiveVersion
+	}
+	return 0
+}
+
+type ValidatePromptTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      string                 `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidatePromptTemplateRequest) Reset() {
+	*x = ValidatePromptTemplateRequest{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePromptTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePromptTemplateRequest) ProtoMessage() {}
+
+func (x *ValidatePromptTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePromptTemplateRequest.ProtoReflect.Descriptor instead.
+func (*ValidatePromptTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ValidatePromptTemplateRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type ValidatePromptTemplateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Valid bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// Why the template is invalid
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The template rendered with sample data, when valid
+	Preview       string `protobuf:"bytes,3,opt,name=preview,proto3" json:"preview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidatePromptTemplateResponse) Reset() {
+	*x = ValidatePromptTemplateResponse{}
+	mi := &file_rag_v1_prompt_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatePromptTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatePromptTemplateResponse) ProtoMessage() {}
+
+func (x *ValidatePromptTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_prompt_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatePromptTemplateResponse.ProtoReflect.Descriptor instead.
+func (*ValidatePromptTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_prompt_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ValidatePromptTemplateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidatePromptTemplateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidatePromptTemplateResponse) GetPreview() string {
+	if x != nil {
+		return x.Preview
+	}
+	return ""
+}
+
+var File_rag_v1_prompt_proto protoreflect.FileDescriptor
+
+const file_rag_v1_prompt_proto_rawDesc = "" +
+	"\n" +
+	"\x13rag/v1/prompt.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd8\x01\n" +
+	"\x0ePromptTemplate\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x1a\n" +
+	"\btemplate\x18\x03 \x01(\tR\btemplate\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x94\x01\n" +
+	"\x1bCreatePromptTemplateRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bactivate\x18\x04 \x01(\bR\bactivate\"Q\n" +
+	"\x18GetPromptTemplateRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"u\n" +
+	"\x1aListPromptTemplatesRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x9c\x01\n" +
+	"\x1bListPromptTemplatesResponse\x124\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x16.rag.v1.PromptTemplateR\ttemplates\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"V\n" +
+	"\x1dActivatePromptTemplateRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"G\n" +
+	"\x1eActivatePromptTemplateResponse\x12%\n" +
+	"\x0eactive_version\x18\x01 \x01(\x05R\ractiveVersion\";\n" +
+	"\x1dValidatePromptTemplateRequest\x12\x1a\n" +
+	"\btemplate\x18\x01 \x01(\tR\btemplate\"f\n" +
+	"\x1eValidatePromptTemplateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
+	"\apreview\x18\x03 \x01(\tR\apreview2\xac\x05\n" +
+	"\rPromptService\x12t\n" +
+	"\x14CreatePromptTemplate\x12#.rag.v1.CreatePromptTemplateRequest\x1a\x16.rag.v1.PromptTemplate\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/prompt-templates\x12u\n" +
+	"\x11GetPromptTemplate\x12 .rag.v1.GetPromptTemplateRequest\x1a\x16.rag.v1.PromptTemplate\"&\x82\xd3\xe4\x93\x02 \x12\x1e/v1/prompt-templates/{version}\x12|\n" +
+	"\x13ListPromptTemplates\x12\".rag.v1.ListPromptTemplatesRequest\x1a#.rag.v1.ListPromptTemplatesResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/prompt-templates\x12\x9b\x01\n" +
+	"\x16ActivatePromptTemplate\x12%.rag.v1.ActivatePromptTemplateRequest\x1a&.rag.v1.ActivatePromptTemplateResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/prompt-templates/{version}/activate\x12\x91\x01\n" +
+	"\x16ValidatePromptTemplate\x12%.rag.v1.ValidatePromptTemplateRequest\x1a&.rag.v1.ValidatePromptTemplateResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/prompt-templates/validateB\xf4\x01\x92Au\x12K\n" +
+	"\x17RAG Prompt Template API\x12+Multi-tenant RAG service - Prompt templates2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\vPromptProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_prompt_proto_rawDescOnce sync.Once
+	file_rag_v1_prompt_proto_rawDescData []byte
+)
+
+func file_rag_v1_prompt_proto_rawDescGZIP() []byte {
+	file_rag_v1_prompt_proto_rawDescOnce.Do(func() {
+		file_rag_v1_prompt_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_prompt_proto_rawDesc), len(file_rag_v1_prompt_proto_rawDesc)))
+	})
+	return file_rag_v1_prompt_proto_rawDescData
+}
+
+var file_rag_v1_prompt_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_rag_v1_prompt_proto_goTypes = []any{
+	(*PromptTemplate)(nil),                 // 0: rag.v1.PromptTemplate
+	(*CreatePromptTemplateRequest)(nil),    // 1: rag.v1.CreatePromptTemplateRequest
+	(*GetPromptTemplateRequest)(nil),       // 2: rag.v1.GetPromptTemplateRequest
+	(*ListPromptTemplatesRequest)(nil),     // 3: rag.v1.ListPromptTemplatesRequest
+	(*ListPromptTemplatesResponse)(nil),    // 4: rag.v1.ListPromptTemplatesResponse
+	(*ActivatePromptTemplateRequest)(nil),  // 5: rag.v1.ActivatePromptTemplateRequest
+	(*ActivatePromptTemplateResponse)(nil), // 6: rag.v1.ActivatePromptTemplateResponse
+	(*ValidatePromptTemplateRequest)(nil),  // 7: rag.v1.ValidatePromptTemplateRequest
+	(*ValidatePromptTemplateResponse)(nil), // 8: rag.v1.ValidatePromptTemplateResponse
+	(*timestamppb.Timestamp)(nil),          // 9: google.protobuf.Timestamp
+}
+var file_rag_v1_prompt_proto_depIdxs = []int32{
+	9, // 0: rag.v1.PromptTemplate.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: rag.v1.ListPromptTemplatesResponse.templates:type_name -> rag.v1.PromptTemplate
+	1, // 2: rag.v1.PromptService.CreatePromptTemplate:input_type -> rag.v1.CreatePromptTemplateRequest
+	2, // 3: rag.v1.PromptService.GetPromptTemplate:input_type -> rag.v1.GetPromptTemplateRequest
+	3, // 4: rag.v1.PromptService.ListPromptTemplates:input_type -> rag.v1.ListPromptTemplatesRequest
+	5, // 5: rag.v1.PromptService.ActivatePromptTemplate:input_type -> rag.v1.ActivatePromptTemplateRequest
+	7, // 6: rag.v1.PromptService.ValidatePromptTemplate:input_type -> rag.v1.ValidatePromptTemplateRequest
+	0, // 7: rag.v1.PromptService.CreatePromptTemplate:output_type -> rag.v1.PromptTemplate
+	0, // 8: rag.v1.PromptService.GetPromptTemplate:output_type -> rag.v1.PromptTemplate
+	4, // 9: rag.v1.PromptService.ListPromptTemplates:output_type -> rag.v1.ListPromptTemplatesResponse
+	6, // 10: rag.v1.PromptService.ActivatePromptTemplate:output_type -> rag.v1.ActivatePromptTemplateResponse
+	8, // 11: rag.v1.PromptService.ValidatePromptTemplate:output_type -> rag.v1.ValidatePromptTemplateResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_prompt_proto_init() }
+func file_rag_v1_prompt_proto_init() {
+	if File_rag_v1_prompt_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_prompt_proto_rawDesc), len(file_rag_v1_prompt_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_prompt_proto_goTypes,
+		DependencyIndexes: file_rag_v1_prompt_proto_depIdxs,
+		MessageInfos:      file_rag_v1_prompt_proto_msgTypes,
+	}.Build()
+	File_rag_v1_prompt_proto = out.File
+	file_rag_v1_prompt_proto_goTypes = nil
+	file_rag_v1_prompt_proto_depIdxs = nil
+}
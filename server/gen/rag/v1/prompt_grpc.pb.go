@@ -0,0 +1,309 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: rag/v1/prompt.proto
+
+package ragv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PromptService_CreatePromptTemplate_FullMethodName   = "/rag.v1.PromptService/CreatePromptTemplate"
+	PromptService_GetPromptTemplate_FullMethodName      = "/rag.v1.PromptService/GetPromptTemplate"
+	PromptService_ListPromptTemplates_FullMethodName    = "/rag.v1.PromptService/ListPromptTemplates"
+	PromptService_ActivatePromptTemplate_FullMethodName = "/rag.v1.PromptService/ActivatePromptTemplate"
+	PromptService_ValidatePromptTemplate_FullMethodName = "/rag.v1.PromptService/ValidatePromptTemplate"
+)
+
+// PromptServiceClient is the client API for PromptService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PromptService manages the templates a tenant's RAG prompts are rendered
+// from. Templates use Go text/template syntax and see .SystemPrompt,
+// .Summary (of turns older than the history), .History, .Messages (each
+// with .Role and .Content), .Chunks (each with .Index, .Title, .Source,
+// .Content, .Score and .Metadata), .Query, .Language and .Labels (the
+// built-in headings in the answer language: .ConversationHistory,
+// .HistoryNote, .EarlierSummary, .ContextDocuments, .Doc, .Title, .Source,
+// .Question, .Answer, .Instruction, .NoContext and .Blocked).
+// Every change creates a new, immutable version; one version is active.
+type PromptServiceClient interface {
+	// CreatePromptTemplate validates a template and stores it as the tenant's
+	// next version
+	CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error)
+	// GetPromptTemplate retrieves a version of a tenant's template
+	GetPromptTemplate(ctx context.Context, in *GetPromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error)
+	// ListPromptTemplates lists a tenant's template versions, newest first
+	ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error)
+	// ActivatePromptTemplate makes a version the one queries are rendered
+	// with; version 0 restores the built-in layout
+	ActivatePromptTemplate(ctx context.Context, in *ActivatePromptTemplateRequest, opts ...grpc.CallOption) (*ActivatePromptTemplateResponse, error)
+	// ValidatePromptTemplate checks a template without storing it and
+	// renders it with sample data
+	ValidatePromptTemplate(ctx context.Context, in *ValidatePromptTemplateRequest, opts ...grpc.CallOption) (*ValidatePromptTemplateResponse, error)
+}
+
+type promptServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPromptServiceClient(cc grpc.ClientConnInterface) PromptServiceClient {
+	return &promptServiceClient{cc}
+}
+
+func (c *promptServiceClient) CreatePromptTemplate(ctx context.Context, in *CreatePromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptTemplate)
+	err := c.cc.Invoke(ctx, PromptService_CreatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) GetPromptTemplate(ctx context.Context, in *GetPromptTemplateRequest, opts ...grpc.CallOption) (*PromptTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromptTemplate)
+	err := c.cc.Invoke(ctx, PromptService_GetPromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPromptTemplatesResponse)
+	err := c.cc.Invoke(ctx, PromptService_ListPromptTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) ActivatePromptTemplate(ctx context.Context, in *ActivatePromptTemplateRequest, opts ...grpc.CallOption) (*ActivatePromptTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivatePromptTemplateResponse)
+	err := c.cc.Invoke(ctx, PromptService_ActivatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promptServiceClient) ValidatePromptTemplate(ctx context.Context, in *ValidatePromptTemplateRequest, opts ...grpc.CallOption) (*ValidatePromptTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidatePromptTemplateResponse)
+	err := c.cc.Invoke(ctx, PromptService_ValidatePromptTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PromptServiceServer is the server API for PromptService service.
+// All implementations must embed UnimplementedPromptServiceServer
+// for forward compatibility.
+//
+// PromptService manages the templates a tenant's RAG prompts are rendered
+// from. Templates use Go text/template syntax and see .SystemPrompt,
+// .Summary (of turns older than the history), .History, .Messages (each
+// with .Role and .Content), .Chunks (each with .Index, .Title, .Source,
+// .Content, .Score and .Metadata), .Query, .Language and .Labels (the
+// built-in headings in the answer language: .ConversationHistory,
+// .HistoryNote, .EarlierSummary, .ContextDocuments, .Doc, .Title, .Source,
+// .Question, .Answer, .Instruction, .NoContext and .Blocked).
+// Every change creates a new, immutable version; one version is active.
+type PromptServiceServer interface {
+	// CreatePromptTemplate validates a template and stores it as the tenant's
+	// next version
+	CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*PromptTemplate, error)
+	// GetPromptTemplate retrieves a version of a tenant's template
+	GetPromptTemplate(context.Context, *GetPromptTemplateRequest) (*PromptTemplate, error)
+	// ListPromptTemplates lists a tenant's template versions, newest first
+	ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error)
+	// ActivatePromptTemplate makes a version the one queries are rendered
+	// with; version 0 restores the built-in layout
+	ActivatePromptTemplate(context.Context, *ActivatePromptTemplateRequest) (*ActivatePromptTemplateResponse, error)
+	// ValidatePromptTemplate checks a template without storing it and
+	// renders it with sample data
+	ValidatePromptTemplate(context.Context, *ValidatePromptTemplateRequest) (*ValidatePromptTemplateResponse, error)
+	mustEmbedUnimplementedPromptServiceServer()
+}
+
+// UnimplementedPromptServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPromptServiceServer struct{}
+
+func (UnimplementedPromptServiceServer) CreatePromptTemplate(context.Context, *CreatePromptTemplateRequest) (*PromptTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePromptTemplate not implemented")
+}
+func (UnimplementedPromptServiceServer) GetPromptTemplate(context.Context, *GetPromptTemplateRequest) (*PromptTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPromptTemplate not implemented")
+}
+func (UnimplementedPromptServiceServer) ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPromptTemplates not implemented")
+}
+func (UnimplementedPromptServiceServer) ActivatePromptTemplate(context.Context, *ActivatePromptTemplateRequest) (*ActivatePromptTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ActivatePromptTemplate not implemented")
+}
+func (UnimplementedPromptServiceServer) ValidatePromptTemplate(context.Context, *ValidatePromptTemplateRequest) (*ValidatePromptTemplateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ValidatePromptTemplate not implemented")
+}
+func (UnimplementedPromptServiceServer) mustEmbedUnimplementedPromptServiceServer() {}
+func (UnimplementedPromptServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePromptServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PromptServiceServer will
+// result in compilation errors.
+type UnsafePromptServiceServer interface {
+	mustEmbedUnimplementedPromptServiceServer()
+}
+
+func RegisterPromptServiceServer(s grpc.ServiceRegistrar, srv PromptServiceServer) {
+	// If the following call panics, it indicates UnimplementedPromptServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PromptService_ServiceDesc, srv)
+}
+
+func _PromptService_CreatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).CreatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_CreatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).CreatePromptTemplate(ctx, req.(*CreatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_GetPromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).GetPromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_GetPromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).GetPromptTemplate(ctx, req.(*GetPromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_ListPromptTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromptTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).ListPromptTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_ListPromptTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).ListPromptTemplates(ctx, req.(*ListPromptTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_ActivatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).ActivatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_ActivatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).ActivatePromptTemplate(ctx, req.(*ActivatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PromptService_ValidatePromptTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePromptTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromptServiceServer).ValidatePromptTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PromptService_ValidatePromptTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromptServiceServer).ValidatePromptTemplate(ctx, req.(*ValidatePromptTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PromptService_ServiceDesc is the grpc.ServiceDesc for PromptService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PromptService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rag.v1.PromptService",
+	HandlerType: (*PromptServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePromptTemplate",
+			Handler:    _PromptService_CreatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "GetPromptTemplate",
+			Handler:    _PromptService_GetPromptTemplate_Handler,
+		},
+		{
+			MethodName: "ListPromptTemplates",
+			Handler:    _PromptService_ListPromptTemplates_Handler,
+		},
+		{
+			MethodName: "ActivatePromptTemplate",
+			Handler:    _PromptService_ActivatePromptTemplate_Handler,
+		},
+		{
+			MethodName: "ValidatePromptTemplate",
+			Handler:    _PromptService_ValidatePromptTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rag/v1/prompt.proto",
+}
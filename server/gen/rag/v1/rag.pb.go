@@ -23,6 +23,178 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AnswerType int32
+
+const (
+	AnswerType_ANSWER_TYPE_UNSPECIFIED AnswerType = 0
+	// Generated from retrieved context
+	AnswerType_ANSWER_TYPE_ANSWERED AnswerType = 1
+	// Retrieval found no chunks, or none scoring above the tenant's
+	// no_answer.min_top_score; the answer is the tenant's no-answer message
+	// when it skips the LLM, and otherwise the LLM's reply without context
+	AnswerType_ANSWER_TYPE_NO_CONTEXT AnswerType = 2
+	// A guardrail blocked the query or the answer; the answer is the tenant's
+	// blocked message
+	AnswerType_ANSWER_TYPE_BLOCKED AnswerType = 3
+	// The LLM called one of the tenant's tools instead of answering
+	AnswerType_ANSWER_TYPE_TOOL_CALL AnswerType = 4
+)
+
+// Enum value maps for AnswerType.
+var (
+	AnswerType_name = map[int32]string{
+		0: "ANSWER_TYPE_UNSPECIFIED",
+		1: "ANSWER_TYPE_ANSWERED",
+		2: "ANSWER_TYPE_NO_CONTEXT",
+		3: "ANSWER_TYPE_BLOCKED",
+		4: "ANSWER_TYPE_TOOL_CALL",
+	}
+	AnswerType_value = map[string]int32{
+		"ANSWER_TYPE_UNSPECIFIED": 0,
+		"ANSWER_TYPE_ANSWERED":    1,
+		"ANSWER_TYPE_NO_CONTEXT":  2,
+		"ANSWER_TYPE_BLOCKED":     3,
+		"ANSWER_TYPE_TOOL_CALL":   4,
+	}
+)
+
+func (x AnswerType) Enum() *AnswerType {
+	p := new(AnswerType)
+	*p = x
+	return p
+}
+
+func (x AnswerType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnswerType) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_rag_proto_enumTypes[0].Descriptor()
+}
+
+func (AnswerType) Type() protoreflect.EnumType {
+	return &file_rag_v1_rag_proto_enumTypes[0]
+}
+
+func (x AnswerType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnswerType.Descriptor instead.
+func (AnswerType) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{0}
+}
+
+type RetrievalMode int32
+
+const (
+	RetrievalMode_RETRIEVAL_MODE_UNSPECIFIED RetrievalMode = 0
+	// Dense vector similarity; falls back to keyword search when the vector store is unavailable
+	RetrievalMode_RETRIEVAL_MODE_VECTOR RetrievalMode = 1
+	// Postgres full-text search; scores are text ranks, so min_score does not apply
+	RetrievalMode_RETRIEVAL_MODE_KEYWORD RetrievalMode = 2
+	// Vector and keyword results fused by reciprocal rank (sparse vectors when configured)
+	RetrievalMode_RETRIEVAL_MODE_HYBRID RetrievalMode = 3
+)
+
+// Enum value maps for RetrievalMode.
+var (
+	RetrievalMode_name = map[int32]string{
+		0: "RETRIEVAL_MODE_UNSPECIFIED",
+		1: "RETRIEVAL_MODE_VECTOR",
+		2: "RETRIEVAL_MODE_KEYWORD",
+		3: "RETRIEVAL_MODE_HYBRID",
+	}
+	RetrievalMode_value = map[string]int32{
+		"RETRIEVAL_MODE_UNSPECIFIED": 0,
+		"RETRIEVAL_MODE_VECTOR":      1,
+		"RETRIEVAL_MODE_KEYWORD":     2,
+		"RETRIEVAL_MODE_HYBRID":      3,
+	}
+)
+
+func (x RetrievalMode) Enum() *RetrievalMode {
+	p := new(RetrievalMode)
+	*p = x
+	return p
+}
+
+func (x RetrievalMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetrievalMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_rag_proto_enumTypes[1].Descriptor()
+}
+
+func (RetrievalMode) Type() protoreflect.EnumType {
+	return &file_rag_v1_rag_proto_enumTypes[1]
+}
+
+func (x RetrievalMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetrievalMode.Descriptor instead.
+func (RetrievalMode) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{1}
+}
+
+type SummarizeStage int32
+
+const (
+	SummarizeStage_SUMMARIZE_STAGE_UNSPECIFIED SummarizeStage = 0
+	// Summarizing the documents' parts
+	SummarizeStage_SUMMARIZE_STAGE_MAP SummarizeStage = 1
+	// Condensing part summaries too long to summarize at once
+	SummarizeStage_SUMMARIZE_STAGE_REDUCE SummarizeStage = 2
+	// Writing the final summary, which follows as tokens
+	SummarizeStage_SUMMARIZE_STAGE_FINAL SummarizeStage = 3
+)
+
+// Enum value maps for SummarizeStage.
+var (
+	SummarizeStage_name = map[int32]string{
+		0: "SUMMARIZE_STAGE_UNSPECIFIED",
+		1: "SUMMARIZE_STAGE_MAP",
+		2: "SUMMARIZE_STAGE_REDUCE",
+		3: "SUMMARIZE_STAGE_FINAL",
+	}
+	SummarizeStage_value = map[string]int32{
+		"SUMMARIZE_STAGE_UNSPECIFIED": 0,
+		"SUMMARIZE_STAGE_MAP":         1,
+		"SUMMARIZE_STAGE_REDUCE":      2,
+		"SUMMARIZE_STAGE_FINAL":       3,
+	}
+)
+
+func (x SummarizeStage) Enum() *SummarizeStage {
+	p := new(SummarizeStage)
+	*p = x
+	return p
+}
+
+func (x SummarizeStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SummarizeStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_rag_proto_enumTypes[2].Descriptor()
+}
+
+func (SummarizeStage) Type() protoreflect.EnumType {
+	return &file_rag_v1_rag_proto_enumTypes[2]
+}
+
+func (x SummarizeStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SummarizeStage.Descriptor instead.
+func (SummarizeStage) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{2}
+}
+
 type QueryRequest struct {
 	state    protoimpl.MessageState `protogen:"open.v1"`
 	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
@@ -31,7 +203,10 @@ type QueryRequest struct {
 	// Session ID for conversation memory (optional).
 	// If provided, the system will remember previous exchanges in this session.
 	// If empty, the query is treated as stateless (no memory).
-	SessionId     string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SessionId string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Project whose documents and retrieval settings are used; defaults to
+	// the API key's. Empty searches all the tenant's documents.
+	ProjectId     string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -94,6 +269,13 @@ func (x *QueryRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *QueryRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
 type QueryOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Number of chunks to retrieve (overrides tenant config)
@@ -105,7 +287,32 @@ type QueryOptions struct {
 	// Temperature for LLM generation (0.0 - 2.0)
 	Temperature float32 `protobuf:"fixed32,4,opt,name=temperature,proto3" json:"temperature,omitempty"`
 	// Maximum tokens in response
-	MaxTokens     int32 `protobuf:"varint,5,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	MaxTokens int32 `protobuf:"varint,5,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	// Widen retrieved chunks with surrounding content before prompting (optional)
+	ContextExpansion *ContextExpansion `protobuf:"bytes,6,opt,name=context_expansion,json=contextExpansion,proto3" json:"context_expansion,omitempty"`
+	// Only retrieve from documents with at least one of these tags (optional)
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Only retrieve from documents in at least one of these collections (optional)
+	CollectionIds []string `protobuf:"bytes,8,rep,name=collection_ids,json=collectionIds,proto3" json:"collection_ids,omitempty"`
+	// Replaces the tenant's score boost for this query (optional)
+	ScoreBoost *ScoreBoostConfig `protobuf:"bytes,9,opt,name=score_boost,json=scoreBoost,proto3" json:"score_boost,omitempty"`
+	// ISO 639-1 code of the language to answer in, which also localizes the
+	// prompt's headings (overrides tenant config; "auto" detects it from the query)
+	Language string `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	// Let the LLM search again with refined queries and fetch whole documents
+	// before answering, up to the tenant's agent.max_steps calls. Ignored by
+	// ExplainQuery.
+	Agentic bool `protobuf:"varint,11,opt,name=agentic,proto3" json:"agentic,omitempty"`
+	// Only retrieve from documents in one of these languages, ISO 639-1 codes
+	// detected or declared at ingestion (optional)
+	Languages []string `protobuf:"bytes,12,rep,name=languages,proto3" json:"languages,omitempty"`
+	// How chunks are retrieved:
+	//   ""     - vector search for the query (default)
+	//   vector - the same
+	//   graph  - expand the query with relations of the entities it names,
+	//            from the tenant's knowledge graph, before vector search
+	//            (needs TenantConfig.knowledge_graph)
+	Strategy      string `protobuf:"bytes,13,opt,name=strategy,proto3" json:"strategy,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -175,29 +382,91 @@ func (x *QueryOptions) GetMaxTokens() int32 {
 	return 0
 }
 
-type QueryResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Answer        string                 `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
-	Sources       []*RetrievedChunk      `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
-	Metadata      *QueryMetadata         `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *QueryOptions) GetContextExpansion() *ContextExpansion {
+	if x != nil {
+		return x.ContextExpansion
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetCollectionIds() []string {
+	if x != nil {
+		return x.CollectionIds
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetScoreBoost() *ScoreBoostConfig {
+	if x != nil {
+		return x.ScoreBoost
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *QueryOptions) GetAgentic() bool {
+	if x != nil {
+		return x.Agentic
+	}
+	return false
+}
+
+func (x *QueryOptions) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *QueryOptions) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+// ContextExpansion controls parent-document / neighbor retrieval.
+// Small chunks are matched for precision, then replaced in the LLM context
+// by their surrounding content. Returned sources still reference the matched chunk.
+type ContextExpansion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Expansion mode: "none" (default), "neighbors", "parent"
+	//   neighbors - include `window` adjacent chunks on each side of the hit
+	//   parent    - include every chunk of the hit's parent section
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Number of neighboring chunks on each side (neighbors mode, default 1)
+	Window        int32 `protobuf:"varint,2,opt,name=window,proto3" json:"window,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryResponse) Reset() {
-	*x = QueryResponse{}
+func (x *ContextExpansion) Reset() {
+	*x = ContextExpansion{}
 	mi := &file_rag_v1_rag_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryResponse) String() string {
+func (x *ContextExpansion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryResponse) ProtoMessage() {}
+func (*ContextExpansion) ProtoMessage() {}
 
-func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+func (x *ContextExpansion) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_v1_rag_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -209,59 +478,58 @@ func (x *QueryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
-func (*QueryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContextExpansion.ProtoReflect.Descriptor instead.
+func (*ContextExpansion) Descriptor() ([]byte, []int) {
 	return file_rag_v1_rag_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *QueryResponse) GetAnswer() string {
+func (x *ContextExpansion) GetMode() string {
 	if x != nil {
-		return x.Answer
+		return x.Mode
 	}
 	return ""
 }
 
-func (x *QueryResponse) GetSources() []*RetrievedChunk {
-	if x != nil {
-		return x.Sources
-	}
-	return nil
-}
-
-func (x *QueryResponse) GetMetadata() *QueryMetadata {
+func (x *ContextExpansion) GetWindow() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.Window
 	}
-	return nil
+	return 0
 }
 
-type RetrievedChunk struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
-	ChunkId       string                 `protobuf:"bytes,2,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Score         float32                `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
-	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"` // Document source (URL, filename)
-	Title         string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`   // Document title
-	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type QueryResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Answer   string                 `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	Sources  []*RetrievedChunk      `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`
+	Metadata *QueryMetadata         `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Whether the answer is grounded in retrieved context
+	AnswerType AnswerType `protobuf:"varint,4,opt,name=answer_type,json=answerType,proto3,enum=rag.v1.AnswerType" json:"answer_type,omitempty"`
+	// What the tenant's guardrails blocked or rewrote
+	GuardrailEvents []*GuardrailEvent `protobuf:"bytes,5,rep,name=guardrail_events,json=guardrailEvents,proto3" json:"guardrail_events,omitempty"`
+	// Retrieval tool calls the LLM made (agentic queries only)
+	AgentSteps []*AgentStep `protobuf:"bytes,6,rep,name=agent_steps,json=agentSteps,proto3" json:"agent_steps,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*QueryResponse_ToolCall
+	Action        isQueryResponse_Action `protobuf_oneof:"action"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetrievedChunk) Reset() {
-	*x = RetrievedChunk{}
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
 	mi := &file_rag_v1_rag_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrievedChunk) String() string {
+func (x *QueryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrievedChunk) ProtoMessage() {}
+func (*QueryResponse) ProtoMessage() {}
 
-func (x *RetrievedChunk) ProtoReflect() protoreflect.Message {
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_v1_rag_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -273,94 +541,106 @@ func (x *RetrievedChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrievedChunk.ProtoReflect.Descriptor instead.
-func (*RetrievedChunk) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
 	return file_rag_v1_rag_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *RetrievedChunk) GetDocumentId() string {
+func (x *QueryResponse) GetAnswer() string {
 	if x != nil {
-		return x.DocumentId
+		return x.Answer
 	}
 	return ""
 }
 
-func (x *RetrievedChunk) GetChunkId() string {
+func (x *QueryResponse) GetSources() []*RetrievedChunk {
 	if x != nil {
-		return x.ChunkId
+		return x.Sources
 	}
-	return ""
+	return nil
 }
 
-func (x *RetrievedChunk) GetContent() string {
+func (x *QueryResponse) GetMetadata() *QueryMetadata {
 	if x != nil {
-		return x.Content
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (x *RetrievedChunk) GetScore() float32 {
+func (x *QueryResponse) GetAnswerType() AnswerType {
 	if x != nil {
-		return x.Score
+		return x.AnswerType
 	}
-	return 0
+	return AnswerType_ANSWER_TYPE_UNSPECIFIED
 }
 
-func (x *RetrievedChunk) GetSource() string {
+func (x *QueryResponse) GetGuardrailEvents() []*GuardrailEvent {
 	if x != nil {
-		return x.Source
+		return x.GuardrailEvents
 	}
-	return ""
+	return nil
 }
 
-func (x *RetrievedChunk) GetTitle() string {
+func (x *QueryResponse) GetAgentSteps() []*AgentStep {
 	if x != nil {
-		return x.Title
+		return x.AgentSteps
 	}
-	return ""
+	return nil
 }
 
-func (x *RetrievedChunk) GetMetadata() map[string]string {
+func (x *QueryResponse) GetAction() isQueryResponse_Action {
 	if x != nil {
-		return x.Metadata
+		return x.Action
 	}
 	return nil
 }
 
-type QueryMetadata struct {
+func (x *QueryResponse) GetToolCall() *ToolCall {
+	if x != nil {
+		if x, ok := x.Action.(*QueryResponse_ToolCall); ok {
+			return x.ToolCall
+		}
+	}
+	return nil
+}
+
+type isQueryResponse_Action interface {
+	isQueryResponse_Action()
+}
+
+type QueryResponse_ToolCall struct {
+	// One of the tenant's tools the LLM called instead of answering; the
+	// answer is then empty
+	ToolCall *ToolCall `protobuf:"bytes,7,opt,name=tool_call,json=toolCall,proto3,oneof"`
+}
+
+func (*QueryResponse_ToolCall) isQueryResponse_Action() {}
+
+// ToolCall is the LLM's invocation of one of the tenant's tools, for the
+// client app to carry out
+type ToolCall struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Time taken for retrieval in milliseconds
-	RetrievalTimeMs int64 `protobuf:"varint,1,opt,name=retrieval_time_ms,json=retrievalTimeMs,proto3" json:"retrieval_time_ms,omitempty"`
-	// Time taken for LLM generation in milliseconds
-	GenerationTimeMs int64 `protobuf:"varint,2,opt,name=generation_time_ms,json=generationTimeMs,proto3" json:"generation_time_ms,omitempty"`
-	// Total time in milliseconds
-	TotalTimeMs int64 `protobuf:"varint,3,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`
-	// Number of chunks retrieved
-	ChunksRetrieved int32 `protobuf:"varint,4,opt,name=chunks_retrieved,json=chunksRetrieved,proto3" json:"chunks_retrieved,omitempty"`
-	// Model used for generation
-	Model string `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`
-	// Tokens used in prompt
-	PromptTokens int32 `protobuf:"varint,6,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
-	// Tokens in completion
-	CompletionTokens int32 `protobuf:"varint,7,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// JSON object matching the tool's parameters schema
+	Arguments     string `protobuf:"bytes,2,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryMetadata) Reset() {
-	*x = QueryMetadata{}
+func (x *ToolCall) Reset() {
+	*x = ToolCall{}
 	mi := &file_rag_v1_rag_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryMetadata) String() string {
+func (x *ToolCall) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryMetadata) ProtoMessage() {}
+func (*ToolCall) ProtoMessage() {}
 
-func (x *QueryMetadata) ProtoReflect() protoreflect.Message {
+func (x *ToolCall) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_v1_rag_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -372,89 +652,1703 @@ func (x *QueryMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryMetadata.ProtoReflect.Descriptor instead.
-func (*QueryMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use ToolCall.ProtoReflect.Descriptor instead.
+func (*ToolCall) Descriptor() ([]byte, []int) {
 	return file_rag_v1_rag_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *QueryMetadata) GetRetrievalTimeMs() int64 {
+func (x *ToolCall) GetName() string {
 	if x != nil {
-		return x.RetrievalTimeMs
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *QueryMetadata) GetGenerationTimeMs() int64 {
+func (x *ToolCall) GetArguments() string {
 	if x != nil {
-		return x.GenerationTimeMs
+		return x.Arguments
+	}
+	return ""
+}
+
+// AgentStep records a retrieval tool call made by the LLM in an agentic query
+type AgentStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the call, from 1
+	Step int32 `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	// "search" or "fetch_document"
+	Tool string `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	// The refined query searched for (search only)
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// The document read (fetch_document only)
+	DocumentId string `protobuf:"bytes,4,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	// Chunks the call added to the context, which are among the sources
+	ChunksAdded int32 `protobuf:"varint,5,opt,name=chunks_added,json=chunksAdded,proto3" json:"chunks_added,omitempty"`
+	// Why the call failed; the LLM continues without its result
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentStep) Reset() {
+	*x = AgentStep{}
+	mi := &file_rag_v1_rag_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStep) ProtoMessage() {}
+
+func (x *AgentStep) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStep.ProtoReflect.Descriptor instead.
+func (*AgentStep) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AgentStep) GetStep() int32 {
+	if x != nil {
+		return x.Step
 	}
 	return 0
 }
 
-func (x *QueryMetadata) GetTotalTimeMs() int64 {
+func (x *AgentStep) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *AgentStep) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AgentStep) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *AgentStep) GetChunksAdded() int32 {
+	if x != nil {
+		return x.ChunksAdded
+	}
+	return 0
+}
+
+func (x *AgentStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GuardrailEvent records a guardrail filter blocking or rewriting text
+type GuardrailEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "input" (the query), "context" (a retrieved chunk) or "output" (the answer)
+	Stage string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// Filter name, e.g. "pii"
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// "blocked" or "modified"
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The chunk affected (context stage only)
+	ChunkId       string `protobuf:"bytes,5,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GuardrailEvent) Reset() {
+	*x = GuardrailEvent{}
+	mi := &file_rag_v1_rag_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuardrailEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuardrailEvent) ProtoMessage() {}
+
+func (x *GuardrailEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuardrailEvent.ProtoReflect.Descriptor instead.
+func (*GuardrailEvent) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GuardrailEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *GuardrailEvent) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *GuardrailEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GuardrailEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GuardrailEvent) GetChunkId() string {
+	if x != nil {
+		return x.ChunkId
+	}
+	return ""
+}
+
+type RetrievedChunk struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ChunkId    string                 `protobuf:"bytes,2,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	Content    string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Score      float32                `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
+	Source     string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"` // Document source (URL, filename)
+	Title      string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`   // Document title
+	Metadata   map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Adjacent chunks of the same document, in chunk order (Retrieve only,
+	// populated when RetrieveOptions.neighbors_before/neighbors_after are set)
+	NeighborsBefore []*DocumentChunk `protobuf:"bytes,8,rep,name=neighbors_before,json=neighborsBefore,proto3" json:"neighbors_before,omitempty"`
+	NeighborsAfter  []*DocumentChunk `protobuf:"bytes,9,rep,name=neighbors_after,json=neighborsAfter,proto3" json:"neighbors_after,omitempty"`
+	// Other documents containing an identical chunk, for tenants that
+	// deduplicate chunks (TenantConfig.dedup_chunks)
+	SharedDocumentIds []string `protobuf:"bytes,10,rep,name=shared_document_ids,json=sharedDocumentIds,proto3" json:"shared_document_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RetrievedChunk) Reset() {
+	*x = RetrievedChunk{}
+	mi := &file_rag_v1_rag_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrievedChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrievedChunk) ProtoMessage() {}
+
+func (x *RetrievedChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrievedChunk.ProtoReflect.Descriptor instead.
+func (*RetrievedChunk) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RetrievedChunk) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *RetrievedChunk) GetChunkId() string {
+	if x != nil {
+		return x.ChunkId
+	}
+	return ""
+}
+
+func (x *RetrievedChunk) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *RetrievedChunk) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RetrievedChunk) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RetrievedChunk) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RetrievedChunk) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RetrievedChunk) GetNeighborsBefore() []*DocumentChunk {
+	if x != nil {
+		return x.NeighborsBefore
+	}
+	return nil
+}
+
+func (x *RetrievedChunk) GetNeighborsAfter() []*DocumentChunk {
+	if x != nil {
+		return x.NeighborsAfter
+	}
+	return nil
+}
+
+func (x *RetrievedChunk) GetSharedDocumentIds() []string {
+	if x != nil {
+		return x.SharedDocumentIds
+	}
+	return nil
+}
+
+type QueryMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time taken for retrieval in milliseconds
+	RetrievalTimeMs int64 `protobuf:"varint,1,opt,name=retrieval_time_ms,json=retrievalTimeMs,proto3" json:"retrieval_time_ms,omitempty"`
+	// Time taken for LLM generation in milliseconds
+	GenerationTimeMs int64 `protobuf:"varint,2,opt,name=generation_time_ms,json=generationTimeMs,proto3" json:"generation_time_ms,omitempty"`
+	// Total time in milliseconds
+	TotalTimeMs int64 `protobuf:"varint,3,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`
+	// Number of chunks retrieved
+	ChunksRetrieved int32 `protobuf:"varint,4,opt,name=chunks_retrieved,json=chunksRetrieved,proto3" json:"chunks_retrieved,omitempty"`
+	// Model used for generation
+	Model string `protobuf:"bytes,5,opt,name=model,proto3" json:"model,omitempty"`
+	// Tokens used in prompt, including the system prompt (estimated)
+	PromptTokens int32 `protobuf:"varint,6,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	// Tokens in completion (estimated)
+	CompletionTokens int32 `protobuf:"varint,7,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	// Language the answer was requested in; empty when none was set or detected
+	Language string `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	// What was left out of the prompt to fit the model's context window
+	Truncation *PromptTruncation `protobuf:"bytes,9,opt,name=truncation,proto3" json:"truncation,omitempty"`
+	// Price of the prompt and completion tokens at the model's list price, in
+	// USD; 0 for local and unknown models
+	EstimatedCostUsd float64 `protobuf:"fixed64,10,opt,name=estimated_cost_usd,json=estimatedCostUsd,proto3" json:"estimated_cost_usd,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *QueryMetadata) Reset() {
+	*x = QueryMetadata{}
+	mi := &file_rag_v1_rag_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryMetadata) ProtoMessage() {}
+
+func (x *QueryMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryMetadata.ProtoReflect.Descriptor instead.
+func (*QueryMetadata) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryMetadata) GetRetrievalTimeMs() int64 {
+	if x != nil {
+		return x.RetrievalTimeMs
+	}
+	return 0
+}
+
+func (x *QueryMetadata) GetGenerationTimeMs() int64 {
+	if x != nil {
+		return x.GenerationTimeMs
+	}
+	return 0
+}
+
+func (x *QueryMetadata) GetTotalTimeMs() int64 {
 	if x != nil {
 		return x.TotalTimeMs
 	}
-	return 0
+	return 0
+}
+
+func (x *QueryMetadata) GetChunksRetrieved() int32 {
+	if x != nil {
+		return x.ChunksRetrieved
+	}
+	return 0
+}
+
+func (x *QueryMetadata) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *QueryMetadata) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *QueryMetadata) GetCompletionTokens() int32 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *QueryMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *QueryMetadata) GetTruncation() *PromptTruncation {
+	if x != nil {
+		return x.Truncation
+	}
+	return nil
+}
+
+func (x *QueryMetadata) GetEstimatedCostUsd() float64 {
+	if x != nil {
+		return x.EstimatedCostUsd
+	}
+	return 0
+}
+
+// PromptTruncation reports what was left out of a prompt to fit the model's
+// context window. Chunks go first, lowest score first, down to the last one;
+// then the oldest history, then the end of the last chunk.
+type PromptTruncation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether anything was left out
+	Truncated bool `protobuf:"varint,1,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Context window of the model (and of its fallbacks, if smaller) in tokens
+	ContextLength int32 `protobuf:"varint,2,opt,name=context_length,json=contextLength,proto3" json:"context_length,omitempty"`
+	// Chunks dropped, and chunks whose content was cut short
+	ChunksDropped   int32 `protobuf:"varint,3,opt,name=chunks_dropped,json=chunksDropped,proto3" json:"chunks_dropped,omitempty"`
+	ChunksTruncated int32 `protobuf:"varint,4,opt,name=chunks_truncated,json=chunksTruncated,proto3" json:"chunks_truncated,omitempty"`
+	// Oldest history messages dropped, and whether the history summary was
+	HistoryMessagesDropped int32 `protobuf:"varint,5,opt,name=history_messages_dropped,json=historyMessagesDropped,proto3" json:"history_messages_dropped,omitempty"`
+	SummaryDropped         bool  `protobuf:"varint,6,opt,name=summary_dropped,json=summaryDropped,proto3" json:"summary_dropped,omitempty"`
+	// Whether the prompt is still too long, e.g. because of the query alone
+	Overflow      bool `protobuf:"varint,7,opt,name=overflow,proto3" json:"overflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTruncation) Reset() {
+	*x = PromptTruncation{}
+	mi := &file_rag_v1_rag_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTruncation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTruncation) ProtoMessage() {}
+
+func (x *PromptTruncation) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTruncation.ProtoReflect.Descriptor instead.
+func (*PromptTruncation) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PromptTruncation) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *PromptTruncation) GetContextLength() int32 {
+	if x != nil {
+		return x.ContextLength
+	}
+	return 0
+}
+
+func (x *PromptTruncation) GetChunksDropped() int32 {
+	if x != nil {
+		return x.ChunksDropped
+	}
+	return 0
+}
+
+func (x *PromptTruncation) GetChunksTruncated() int32 {
+	if x != nil {
+		return x.ChunksTruncated
+	}
+	return 0
+}
+
+func (x *PromptTruncation) GetHistoryMessagesDropped() int32 {
+	if x != nil {
+		return x.HistoryMessagesDropped
+	}
+	return 0
+}
+
+func (x *PromptTruncation) GetSummaryDropped() bool {
+	if x != nil {
+		return x.SummaryDropped
+	}
+	return false
+}
+
+func (x *PromptTruncation) GetOverflow() bool {
+	if x != nil {
+		return x.Overflow
+	}
+	return false
+}
+
+// QueryStreamResponse is sent as a stream for interactive queries
+type QueryStreamResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*QueryStreamResponse_Source
+	//	*QueryStreamResponse_Token
+	//	*QueryStreamResponse_Metadata
+	//	*QueryStreamResponse_Error
+	//	*QueryStreamResponse_NoContext
+	//	*QueryStreamResponse_Guardrail
+	//	*QueryStreamResponse_AgentStep
+	//	*QueryStreamResponse_ToolCall
+	Event         isQueryStreamResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryStreamResponse) Reset() {
+	*x = QueryStreamResponse{}
+	mi := &file_rag_v1_rag_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryStreamResponse) ProtoMessage() {}
+
+func (x *QueryStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryStreamResponse.ProtoReflect.Descriptor instead.
+func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueryStreamResponse) GetEvent() isQueryStreamResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetSource() *RetrievedChunk {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_Source); ok {
+			return x.Source
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetToken() string {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_Token); ok {
+			return x.Token
+		}
+	}
+	return ""
+}
+
+func (x *QueryStreamResponse) GetMetadata() *QueryMetadata {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetError() *StreamError {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetNoContext() *NoContext {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_NoContext); ok {
+			return x.NoContext
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetGuardrail() *GuardrailEvent {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_Guardrail); ok {
+			return x.Guardrail
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetAgentStep() *AgentStep {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_AgentStep); ok {
+			return x.AgentStep
+		}
+	}
+	return nil
+}
+
+func (x *QueryStreamResponse) GetToolCall() *ToolCall {
+	if x != nil {
+		if x, ok := x.Event.(*QueryStreamResponse_ToolCall); ok {
+			return x.ToolCall
+		}
+	}
+	return nil
+}
+
+type isQueryStreamResponse_Event interface {
+	isQueryStreamResponse_Event()
+}
+
+type QueryStreamResponse_Source struct {
+	// Sources are sent first before generation starts
+	Source *RetrievedChunk `protobuf:"bytes,1,opt,name=source,proto3,oneof"`
+}
+
+type QueryStreamResponse_Token struct {
+	// Token chunks from LLM generation
+	Token string `protobuf:"bytes,2,opt,name=token,proto3,oneof"`
+}
+
+type QueryStreamResponse_Metadata struct {
+	// Final metadata sent after generation completes
+	Metadata *QueryMetadata `protobuf:"bytes,3,opt,name=metadata,proto3,oneof"`
+}
+
+type QueryStreamResponse_Error struct {
+	// Error if something goes wrong during streaming
+	Error *StreamError `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
+}
+
+type QueryStreamResponse_NoContext struct {
+	// Sent after the sources when there is no context to answer from
+	NoContext *NoContext `protobuf:"bytes,5,opt,name=no_context,json=noContext,proto3,oneof"`
+}
+
+type QueryStreamResponse_Guardrail struct {
+	// Sent when a guardrail blocks or rewrites the query, a chunk or the answer
+	Guardrail *GuardrailEvent `protobuf:"bytes,6,opt,name=guardrail,proto3,oneof"`
+}
+
+type QueryStreamResponse_AgentStep struct {
+	// Sent as the LLM makes each retrieval tool call in an agentic query
+	AgentStep *AgentStep `protobuf:"bytes,7,opt,name=agent_step,json=agentStep,proto3,oneof"`
+}
+
+type QueryStreamResponse_ToolCall struct {
+	// Sent instead of tokens when the LLM calls one of the tenant's tools
+	ToolCall *ToolCall `protobuf:"bytes,8,opt,name=tool_call,json=toolCall,proto3,oneof"`
+}
+
+func (*QueryStreamResponse_Source) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_Token) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_Metadata) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_Error) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_NoContext) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_Guardrail) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_AgentStep) isQueryStreamResponse_Event() {}
+
+func (*QueryStreamResponse_ToolCall) isQueryStreamResponse_Event() {}
+
+// NoContext signals that retrieval found nothing relevant to the query
+type NoContext struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tenant's no-answer message
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the LLM is skipped. If so the message also follows as the only
+	// token, for clients that ignore this event.
+	LlmSkipped    bool `protobuf:"varint,2,opt,name=llm_skipped,json=llmSkipped,proto3" json:"llm_skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoContext) Reset() {
+	*x = NoContext{}
+	mi := &file_rag_v1_rag_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoContext) ProtoMessage() {}
+
+func (x *NoContext) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoContext.ProtoReflect.Descriptor instead.
+func (*NoContext) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NoContext) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NoContext) GetLlmSkipped() bool {
+	if x != nil {
+		return x.LlmSkipped
+	}
+	return false
+}
+
+type StreamError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamError) Reset() {
+	*x = StreamError{}
+	mi := &file_rag_v1_rag_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamError) ProtoMessage() {}
+
+func (x *StreamError) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamError.ProtoReflect.Descriptor instead.
+func (*StreamError) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StreamError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RetrieveRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Query    string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Options  *RetrieveOptions       `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	// How chunks are found (default vector)
+	Mode RetrievalMode `protobuf:"varint,4,opt,name=mode,proto3,enum=rag.v1.RetrievalMode" json:"mode,omitempty"`
+	// Project whose documents and retrieval settings are used; defaults to
+	// the API key's. Empty searches all the tenant's documents.
+	ProjectId     string `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveRequest) Reset() {
+	*x = RetrieveRequest{}
+	mi := &file_rag_v1_rag_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveRequest) ProtoMessage() {}
+
+func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveRequest.ProtoReflect.Descriptor instead.
+func (*RetrieveRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RetrieveRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *RetrieveRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RetrieveRequest) GetOptions() *RetrieveOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *RetrieveRequest) GetMode() RetrievalMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RetrievalMode_RETRIEVAL_MODE_UNSPECIFIED
+}
+
+func (x *RetrieveRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type RetrieveOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of chunks to retrieve
+	TopK int32 `protobuf:"varint,1,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	// Minimum similarity score threshold (0.0 - 1.0)
+	MinScore float32 `protobuf:"fixed32,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// Filter by document IDs (optional)
+	DocumentIds []string `protobuf:"bytes,3,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+	// Number of adjacent chunks to return before/after each hit (max 5 each)
+	NeighborsBefore int32 `protobuf:"varint,4,opt,name=neighbors_before,json=neighborsBefore,proto3" json:"neighbors_before,omitempty"`
+	NeighborsAfter  int32 `protobuf:"varint,5,opt,name=neighbors_after,json=neighborsAfter,proto3" json:"neighbors_after,omitempty"`
+	// Only retrieve from documents with at least one of these tags (optional)
+	Tags []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Only retrieve from documents in at least one of these collections (optional)
+	CollectionIds []string `protobuf:"bytes,7,rep,name=collection_ids,json=collectionIds,proto3" json:"collection_ids,omitempty"`
+	// Replaces the tenant's score boost for this request (optional)
+	ScoreBoost *ScoreBoostConfig `protobuf:"bytes,8,opt,name=score_boost,json=scoreBoost,proto3" json:"score_boost,omitempty"`
+	// Only retrieve from documents in one of these languages, ISO 639-1 codes
+	// detected or declared at ingestion (optional)
+	Languages     []string `protobuf:"bytes,9,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveOptions) Reset() {
+	*x = RetrieveOptions{}
+	mi := &file_rag_v1_rag_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveOptions) ProtoMessage() {}
+
+func (x *RetrieveOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveOptions.ProtoReflect.Descriptor instead.
+func (*RetrieveOptions) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RetrieveOptions) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *RetrieveOptions) GetMinScore() float32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *RetrieveOptions) GetDocumentIds() []string {
+	if x != nil {
+		return x.DocumentIds
+	}
+	return nil
+}
+
+func (x *RetrieveOptions) GetNeighborsBefore() int32 {
+	if x != nil {
+		return x.NeighborsBefore
+	}
+	return 0
+}
+
+func (x *RetrieveOptions) GetNeighborsAfter() int32 {
+	if x != nil {
+		return x.NeighborsAfter
+	}
+	return 0
+}
+
+func (x *RetrieveOptions) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RetrieveOptions) GetCollectionIds() []string {
+	if x != nil {
+		return x.CollectionIds
+	}
+	return nil
+}
+
+func (x *RetrieveOptions) GetScoreBoost() *ScoreBoostConfig {
+	if x != nil {
+		return x.ScoreBoost
+	}
+	return nil
+}
+
+func (x *RetrieveOptions) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+type RetrieveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        []*RetrievedChunk      `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Metadata      *RetrieveMetadata      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveResponse) Reset() {
+	*x = RetrieveResponse{}
+	mi := &file_rag_v1_rag_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveResponse) ProtoMessage() {}
+
+func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveResponse.ProtoReflect.Descriptor instead.
+func (*RetrieveResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RetrieveResponse) GetChunks() []*RetrievedChunk {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *RetrieveResponse) GetMetadata() *RetrieveMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type RetrieveMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time taken for retrieval in milliseconds
+	RetrievalTimeMs int64 `protobuf:"varint,1,opt,name=retrieval_time_ms,json=retrievalTimeMs,proto3" json:"retrieval_time_ms,omitempty"`
+	// Number of chunks retrieved
+	ChunksRetrieved int32 `protobuf:"varint,2,opt,name=chunks_retrieved,json=chunksRetrieved,proto3" json:"chunks_retrieved,omitempty"`
+	// Total chunks searched
+	TotalChunksSearched int32 `protobuf:"varint,3,opt,name=total_chunks_searched,json=totalChunksSearched,proto3" json:"total_chunks_searched,omitempty"`
+	// Mode actually used, which differs from the requested one after a fallback
+	Mode          RetrievalMode `protobuf:"varint,4,opt,name=mode,proto3,enum=rag.v1.RetrievalMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetrieveMetadata) Reset() {
+	*x = RetrieveMetadata{}
+	mi := &file_rag_v1_rag_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetrieveMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveMetadata) ProtoMessage() {}
+
+func (x *RetrieveMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveMetadata.ProtoReflect.Descriptor instead.
+func (*RetrieveMetadata) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RetrieveMetadata) GetRetrievalTimeMs() int64 {
+	if x != nil {
+		return x.RetrievalTimeMs
+	}
+	return 0
+}
+
+func (x *RetrieveMetadata) GetChunksRetrieved() int32 {
+	if x != nil {
+		return x.ChunksRetrieved
+	}
+	return 0
+}
+
+func (x *RetrieveMetadata) GetTotalChunksSearched() int32 {
+	if x != nil {
+		return x.TotalChunksSearched
+	}
+	return 0
+}
+
+func (x *RetrieveMetadata) GetMode() RetrievalMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RetrievalMode_RETRIEVAL_MODE_UNSPECIFIED
+}
+
+type ExplainQueryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Euclidean norm and dimension of the query embedding
+	QueryEmbeddingNorm      float32 `protobuf:"fixed32,1,opt,name=query_embedding_norm,json=queryEmbeddingNorm,proto3" json:"query_embedding_norm,omitempty"`
+	QueryEmbeddingDimension int32   `protobuf:"varint,2,opt,name=query_embedding_dimension,json=queryEmbeddingDimension,proto3" json:"query_embedding_dimension,omitempty"`
+	// Whether the vector store search fused dense and sparse vectors
+	Hybrid bool `protobuf:"varint,3,opt,name=hybrid,proto3" json:"hybrid,omitempty"`
+	// Every candidate the vector store returned, in its ranking order
+	Candidates []*ExplainCandidate `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Whether the tenant's reranker rescored the deduplicated candidates
+	Reranked bool `protobuf:"varint,5,opt,name=reranked,proto3" json:"reranked,omitempty"`
+	// The chunks the prompt is built from, in prompt order, including any
+	// left out to fit the model's context window
+	Sources []*RetrievedChunk `protobuf:"bytes,6,rep,name=sources,proto3" json:"sources,omitempty"`
+	// The system prompt and full prompt that would be sent to the LLM
+	SystemPrompt string `protobuf:"bytes,7,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Prompt       string `protobuf:"bytes,8,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// Estimated token counts of the prompt's parts
+	TokenCounts *ExplainTokenCounts `protobuf:"bytes,9,opt,name=token_counts,json=tokenCounts,proto3" json:"token_counts,omitempty"`
+	// Model that would generate the answer
+	Model string `protobuf:"bytes,10,opt,name=model,proto3" json:"model,omitempty"`
+	// Time taken for retrieval in milliseconds
+	RetrievalTimeMs int64 `protobuf:"varint,11,opt,name=retrieval_time_ms,json=retrievalTimeMs,proto3" json:"retrieval_time_ms,omitempty"`
+	// Prompt template version the prompt was rendered with; 0 is the built-in layout
+	PromptTemplateVersion int32 `protobuf:"varint,12,opt,name=prompt_template_version,json=promptTemplateVersion,proto3" json:"prompt_template_version,omitempty"`
+	// Language the answer would be requested in; empty when none was set or detected
+	Language string `protobuf:"bytes,13,opt,name=language,proto3" json:"language,omitempty"`
+	// Whether Query would answer from context, or is blocked by a guardrail
+	AnswerType AnswerType `protobuf:"varint,14,opt,name=answer_type,json=answerType,proto3,enum=rag.v1.AnswerType" json:"answer_type,omitempty"`
+	// What the tenant's input and context guardrails blocked or rewrote
+	GuardrailEvents []*GuardrailEvent `protobuf:"bytes,15,rep,name=guardrail_events,json=guardrailEvents,proto3" json:"guardrail_events,omitempty"`
+	// What was left out of the prompt to fit the model's context window
+	Truncation *PromptTruncation `protobuf:"bytes,16,opt,name=truncation,proto3" json:"truncation,omitempty"`
+	// Text embedded for the vector search: the query, expanded with
+	// knowledge graph relations for strategy=graph
+	SearchText    string `protobuf:"bytes,17,opt,name=search_text,json=searchText,proto3" json:"search_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainQueryResponse) Reset() {
+	*x = ExplainQueryResponse{}
+	mi := &file_rag_v1_rag_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainQueryResponse) ProtoMessage() {}
+
+func (x *ExplainQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainQueryResponse.ProtoReflect.Descriptor instead.
+func (*ExplainQueryResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExplainQueryResponse) GetQueryEmbeddingNorm() float32 {
+	if x != nil {
+		return x.QueryEmbeddingNorm
+	}
+	return 0
+}
+
+func (x *ExplainQueryResponse) GetQueryEmbeddingDimension() int32 {
+	if x != nil {
+		return x.QueryEmbeddingDimension
+	}
+	return 0
+}
+
+func (x *ExplainQueryResponse) GetHybrid() bool {
+	if x != nil {
+		return x.Hybrid
+	}
+	return false
+}
+
+func (x *ExplainQueryResponse) GetCandidates() []*ExplainCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *ExplainQueryResponse) GetReranked() bool {
+	if x != nil {
+		return x.Reranked
+	}
+	return false
+}
+
+func (x *ExplainQueryResponse) GetSources() []*RetrievedChunk {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *ExplainQueryResponse) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *ExplainQueryResponse) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *ExplainQueryResponse) GetTokenCounts() *ExplainTokenCounts {
+	if x != nil {
+		return x.TokenCounts
+	}
+	return nil
+}
+
+func (x *ExplainQueryResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ExplainQueryResponse) GetRetrievalTimeMs() int64 {
+	if x != nil {
+		return x.RetrievalTimeMs
+	}
+	return 0
+}
+
+func (x *ExplainQueryResponse) GetPromptTemplateVersion() int32 {
+	if x != nil {
+		return x.PromptTemplateVersion
+	}
+	return 0
+}
+
+func (x *ExplainQueryResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ExplainQueryResponse) GetAnswerType() AnswerType {
+	if x != nil {
+		return x.AnswerType
+	}
+	return AnswerType_ANSWER_TYPE_UNSPECIFIED
+}
+
+func (x *ExplainQueryResponse) GetGuardrailEvents() []*GuardrailEvent {
+	if x != nil {
+		return x.GuardrailEvents
+	}
+	return nil
+}
+
+func (x *ExplainQueryResponse) GetTruncation() *PromptTruncation {
+	if x != nil {
+		return x.Truncation
+	}
+	return nil
+}
+
+func (x *ExplainQueryResponse) GetSearchText() string {
+	if x != nil {
+		return x.SearchText
+	}
+	return ""
+}
+
+// ExplainCandidate traces one vector store result through the pipeline
+type ExplainCandidate struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ChunkId    string                 `protobuf:"bytes,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	DocumentId string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	Title      string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	// Cosine similarity between the query and chunk embeddings
+	DenseScore float32 `protobuf:"fixed32,4,opt,name=dense_score,json=denseScore,proto3" json:"dense_score,omitempty"`
+	// Dot product of the query and chunk sparse vectors (hybrid only)
+	SparseScore *float32 `protobuf:"fixed32,5,opt,name=sparse_score,json=sparseScore,proto3,oneof" json:"sparse_score,omitempty"`
+	// Reciprocal rank fusion score the vector store ranked by (hybrid only)
+	FusedScore *float32 `protobuf:"fixed32,6,opt,name=fused_score,json=fusedScore,proto3,oneof" json:"fused_score,omitempty"`
+	// Chunk ID of the higher-ranked candidate this one was dropped as a
+	// near-duplicate of, with their similarity by the tenant's
+	// duplicate_results measure
+	DuplicateOf         string  `protobuf:"bytes,7,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	DuplicateSimilarity float32 `protobuf:"fixed32,8,opt,name=duplicate_similarity,json=duplicateSimilarity,proto3" json:"duplicate_similarity,omitempty"`
+	// Score the reranker gave, after the tenant's calibration, when it ran
+	// and kept this candidate among its top_k
+	RerankerScore *float32 `protobuf:"fixed32,9,opt,name=reranker_score,json=rerankerScore,proto3,oneof" json:"reranker_score,omitempty"`
+	// Position among the sources (1-based) and score after reranking and
+	// boosting; 0 when the candidate was not selected
+	FinalRank  int32   `protobuf:"varint,10,opt,name=final_rank,json=finalRank,proto3" json:"final_rank,omitempty"`
+	FinalScore float32 `protobuf:"fixed32,11,opt,name=final_score,json=finalScore,proto3" json:"final_score,omitempty"`
+	// Dropped because its reranker score is below the tenant's
+	// rerank.threshold
+	BelowRerankThreshold bool `protobuf:"varint,12,opt,name=below_rerank_threshold,json=belowRerankThreshold,proto3" json:"below_rerank_threshold,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ExplainCandidate) Reset() {
+	*x = ExplainCandidate{}
+	mi := &file_rag_v1_rag_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainCandidate) ProtoMessage() {}
+
+func (x *ExplainCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainCandidate.ProtoReflect.Descriptor instead.
+func (*ExplainCandidate) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExplainCandidate) GetChunkId() string {
+	if x != nil {
+		return x.ChunkId
+	}
+	return ""
+}
+
+func (x *ExplainCandidate) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *ExplainCandidate) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ExplainCandidate) GetDenseScore() float32 {
+	if x != nil {
+		return x.DenseScore
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetSparseScore() float32 {
+	if x != nil && x.SparseScore != nil {
+		return *x.SparseScore
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetFusedScore() float32 {
+	if x != nil && x.FusedScore != nil {
+		return *x.FusedScore
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *ExplainCandidate) GetDuplicateSimilarity() float32 {
+	if x != nil {
+		return x.DuplicateSimilarity
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetRerankerScore() float32 {
+	if x != nil && x.RerankerScore != nil {
+		return *x.RerankerScore
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetFinalRank() int32 {
+	if x != nil {
+		return x.FinalRank
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetFinalScore() float32 {
+	if x != nil {
+		return x.FinalScore
+	}
+	return 0
+}
+
+func (x *ExplainCandidate) GetBelowRerankThreshold() bool {
+	if x != nil {
+		return x.BelowRerankThreshold
+	}
+	return false
+}
+
+// ExplainTokenCounts estimates prompt size by part
+type ExplainTokenCounts struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SystemPrompt int32                  `protobuf:"varint,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	Context      int32                  `protobuf:"varint,2,opt,name=context,proto3" json:"context,omitempty"`
+	History      int32                  `protobuf:"varint,3,opt,name=history,proto3" json:"history,omitempty"`
+	Query        int32                  `protobuf:"varint,4,opt,name=query,proto3" json:"query,omitempty"`
+	// The whole prompt, including the parts above and its headings
+	Prompt int32 `protobuf:"varint,5,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// Maximum tokens the answer may use
+	MaxCompletion int32 `protobuf:"varint,6,opt,name=max_completion,json=maxCompletion,proto3" json:"max_completion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainTokenCounts) Reset() {
+	*x = ExplainTokenCounts{}
+	mi := &file_rag_v1_rag_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainTokenCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainTokenCounts) ProtoMessage() {}
+
+func (x *ExplainTokenCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainTokenCounts.ProtoReflect.Descriptor instead.
+func (*ExplainTokenCounts) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExplainTokenCounts) GetSystemPrompt() int32 {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return 0
+}
+
+func (x *ExplainTokenCounts) GetContext() int32 {
+	if x != nil {
+		return x.Context
+	}
+	return 0
+}
+
+func (x *ExplainTokenCounts) GetHistory() int32 {
+	if x != nil {
+		return x.History
+	}
+	return 0
+}
+
+func (x *ExplainTokenCounts) GetQuery() int32 {
+	if x != nil {
+		return x.Query
+	}
+	return 0
+}
+
+func (x *ExplainTokenCounts) GetPrompt() int32 {
+	if x != nil {
+		return x.Prompt
+	}
+	return 0
+}
+
+func (x *ExplainTokenCounts) GetMaxCompletion() int32 {
+	if x != nil {
+		return x.MaxCompletion
+	}
+	return 0
+}
+
+type SummarizeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Documents to summarize, at most 50. Without them, the tenant's ready
+	// documents matching tags and collection_id are summarized.
+	DocumentIds []string `protobuf:"bytes,2,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+	// Documents with at least one of these tags (without document_ids)
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Documents in this collection (without document_ids)
+	CollectionId string `protobuf:"bytes,4,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Form of the summary:
+	//   paragraph - one to three paragraphs (default)
+	//   bullets   - a bulleted list of the key points
+	//   brief     - one or two sentences
+	//   detailed  - a longer summary with headings, covering every main point
+	Style string `protobuf:"bytes,5,opt,name=style,proto3" json:"style,omitempty"`
+	// ISO 639-1 code of the language to summarize in (overrides tenant
+	// config; "auto" detects it from the documents)
+	Language      string `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeRequest) Reset() {
+	*x = SummarizeRequest{}
+	mi := &file_rag_v1_rag_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeRequest) ProtoMessage() {}
+
+func (x *SummarizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SummarizeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetDocumentIds() []string {
+	if x != nil {
+		return x.DocumentIds
+	}
+	return nil
 }
 
-func (x *QueryMetadata) GetChunksRetrieved() int32 {
+func (x *SummarizeRequest) GetTags() []string {
 	if x != nil {
-		return x.ChunksRetrieved
+		return x.Tags
 	}
-	return 0
+	return nil
 }
 
-func (x *QueryMetadata) GetModel() string {
+func (x *SummarizeRequest) GetCollectionId() string {
 	if x != nil {
-		return x.Model
+		return x.CollectionId
 	}
 	return ""
 }
 
-func (x *QueryMetadata) GetPromptTokens() int32 {
+func (x *SummarizeRequest) GetStyle() string {
 	if x != nil {
-		return x.PromptTokens
+		return x.Style
 	}
-	return 0
+	return ""
 }
 
-func (x *QueryMetadata) GetCompletionTokens() int32 {
+func (x *SummarizeRequest) GetLanguage() string {
 	if x != nil {
-		return x.CompletionTokens
+		return x.Language
 	}
-	return 0
+	return ""
 }
 
-// QueryStreamResponse is sent as a stream for interactive queries
-type QueryStreamResponse struct {
+type SummarizeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Event:
 	//
-	//	*QueryStreamResponse_Source
-	//	*QueryStreamResponse_Token
-	//	*QueryStreamResponse_Metadata
-	//	*QueryStreamResponse_Error
-	Event         isQueryStreamResponse_Event `protobuf_oneof:"event"`
+	//	*SummarizeResponse_Progress
+	//	*SummarizeResponse_Token
+	//	*SummarizeResponse_Metadata
+	//	*SummarizeResponse_Error
+	Event         isSummarizeResponse_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryStreamResponse) Reset() {
-	*x = QueryStreamResponse{}
-	mi := &file_rag_v1_rag_proto_msgTypes[5]
+func (x *SummarizeResponse) Reset() {
+	*x = SummarizeResponse{}
+	mi := &file_rag_v1_rag_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryStreamResponse) String() string {
+func (x *SummarizeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryStreamResponse) ProtoMessage() {}
+func (*SummarizeResponse) ProtoMessage() {}
 
-func (x *QueryStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[5]
+func (x *SummarizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,109 +2359,111 @@ func (x *QueryStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryStreamResponse.ProtoReflect.Descriptor instead.
-func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SummarizeResponse.ProtoReflect.Descriptor instead.
+func (*SummarizeResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *QueryStreamResponse) GetEvent() isQueryStreamResponse_Event {
+func (x *SummarizeResponse) GetEvent() isSummarizeResponse_Event {
 	if x != nil {
 		return x.Event
 	}
 	return nil
 }
 
-func (x *QueryStreamResponse) GetSource() *RetrievedChunk {
+func (x *SummarizeResponse) GetProgress() *SummarizeProgress {
 	if x != nil {
-		if x, ok := x.Event.(*QueryStreamResponse_Source); ok {
-			return x.Source
+		if x, ok := x.Event.(*SummarizeResponse_Progress); ok {
+			return x.Progress
 		}
 	}
 	return nil
 }
 
-func (x *QueryStreamResponse) GetToken() string {
+func (x *SummarizeResponse) GetToken() string {
 	if x != nil {
-		if x, ok := x.Event.(*QueryStreamResponse_Token); ok {
+		if x, ok := x.Event.(*SummarizeResponse_Token); ok {
 			return x.Token
 		}
 	}
 	return ""
 }
 
-func (x *QueryStreamResponse) GetMetadata() *QueryMetadata {
+func (x *SummarizeResponse) GetMetadata() *SummarizeMetadata {
 	if x != nil {
-		if x, ok := x.Event.(*QueryStreamResponse_Metadata); ok {
+		if x, ok := x.Event.(*SummarizeResponse_Metadata); ok {
 			return x.Metadata
 		}
 	}
 	return nil
 }
 
-func (x *QueryStreamResponse) GetError() *StreamError {
+func (x *SummarizeResponse) GetError() *StreamError {
 	if x != nil {
-		if x, ok := x.Event.(*QueryStreamResponse_Error); ok {
+		if x, ok := x.Event.(*SummarizeResponse_Error); ok {
 			return x.Error
 		}
 	}
 	return nil
 }
 
-type isQueryStreamResponse_Event interface {
-	isQueryStreamResponse_Event()
+type isSummarizeResponse_Event interface {
+	isSummarizeResponse_Event()
 }
 
-type QueryStreamResponse_Source struct {
-	// Sources are sent first before generation starts
-	Source *RetrievedChunk `protobuf:"bytes,1,opt,name=source,proto3,oneof"`
+type SummarizeResponse_Progress struct {
+	// Sent as the documents' parts and the part summaries are summarized
+	Progress *SummarizeProgress `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
 }
 
-type QueryStreamResponse_Token struct {
-	// Token chunks from LLM generation
+type SummarizeResponse_Token struct {
+	// Token chunks of the final summary
 	Token string `protobuf:"bytes,2,opt,name=token,proto3,oneof"`
 }
 
-type QueryStreamResponse_Metadata struct {
-	// Final metadata sent after generation completes
-	Metadata *QueryMetadata `protobuf:"bytes,3,opt,name=metadata,proto3,oneof"`
+type SummarizeResponse_Metadata struct {
+	// Sent after the summary completes
+	Metadata *SummarizeMetadata `protobuf:"bytes,3,opt,name=metadata,proto3,oneof"`
 }
 
-type QueryStreamResponse_Error struct {
-	// Error if something goes wrong during streaming
+type SummarizeResponse_Error struct {
+	// Error if something goes wrong during summarization
 	Error *StreamError `protobuf:"bytes,4,opt,name=error,proto3,oneof"`
 }
 
-func (*QueryStreamResponse_Source) isQueryStreamResponse_Event() {}
+func (*SummarizeResponse_Progress) isSummarizeResponse_Event() {}
 
-func (*QueryStreamResponse_Token) isQueryStreamResponse_Event() {}
+func (*SummarizeResponse_Token) isSummarizeResponse_Event() {}
 
-func (*QueryStreamResponse_Metadata) isQueryStreamResponse_Event() {}
+func (*SummarizeResponse_Metadata) isSummarizeResponse_Event() {}
 
-func (*QueryStreamResponse_Error) isQueryStreamResponse_Event() {}
+func (*SummarizeResponse_Error) isSummarizeResponse_Event() {}
 
-type StreamError struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+type SummarizeProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Stage SummarizeStage         `protobuf:"varint,1,opt,name=stage,proto3,enum=rag.v1.SummarizeStage" json:"stage,omitempty"`
+	// Parts of the stage summarized so far, out of total
+	Completed     int32 `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Total         int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamError) Reset() {
-	*x = StreamError{}
-	mi := &file_rag_v1_rag_proto_msgTypes[6]
+func (x *SummarizeProgress) Reset() {
+	*x = SummarizeProgress{}
+	mi := &file_rag_v1_rag_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamError) String() string {
+func (x *SummarizeProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamError) ProtoMessage() {}
+func (*SummarizeProgress) ProtoMessage() {}
 
-func (x *StreamError) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[6]
+func (x *SummarizeProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -578,49 +2474,61 @@ func (x *StreamError) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamError.ProtoReflect.Descriptor instead.
-func (*StreamError) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SummarizeProgress.ProtoReflect.Descriptor instead.
+func (*SummarizeProgress) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *StreamError) GetCode() string {
+func (x *SummarizeProgress) GetStage() SummarizeStage {
 	if x != nil {
-		return x.Code
+		return x.Stage
 	}
-	return ""
+	return SummarizeStage_SUMMARIZE_STAGE_UNSPECIFIED
 }
 
-func (x *StreamError) GetMessage() string {
+func (x *SummarizeProgress) GetCompleted() int32 {
 	if x != nil {
-		return x.Message
+		return x.Completed
 	}
-	return ""
+	return 0
 }
 
-type RetrieveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	Options       *RetrieveOptions       `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+func (x *SummarizeProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SummarizeMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Documents the summary covers
+	DocumentIds []string `protobuf:"bytes,1,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+	ChunkCount  int32    `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	// LLM calls made, including the final summary's
+	LlmCalls      int32  `protobuf:"varint,3,opt,name=llm_calls,json=llmCalls,proto3" json:"llm_calls,omitempty"`
+	Model         string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Language      string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	TotalTimeMs   int64  `protobuf:"varint,6,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetrieveRequest) Reset() {
-	*x = RetrieveRequest{}
-	mi := &file_rag_v1_rag_proto_msgTypes[7]
+func (x *SummarizeMetadata) Reset() {
+	*x = SummarizeMetadata{}
+	mi := &file_rag_v1_rag_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrieveRequest) String() string {
+func (x *SummarizeMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveRequest) ProtoMessage() {}
+func (*SummarizeMetadata) ProtoMessage() {}
 
-func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[7]
+func (x *SummarizeMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,59 +2539,87 @@ func (x *RetrieveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveRequest.ProtoReflect.Descriptor instead.
-func (*RetrieveRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SummarizeMetadata.ProtoReflect.Descriptor instead.
+func (*SummarizeMetadata) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *RetrieveRequest) GetTenantId() string {
+func (x *SummarizeMetadata) GetDocumentIds() []string {
 	if x != nil {
-		return x.TenantId
+		return x.DocumentIds
+	}
+	return nil
+}
+
+func (x *SummarizeMetadata) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *SummarizeMetadata) GetLlmCalls() int32 {
+	if x != nil {
+		return x.LlmCalls
+	}
+	return 0
+}
+
+func (x *SummarizeMetadata) GetModel() string {
+	if x != nil {
+		return x.Model
 	}
 	return ""
 }
 
-func (x *RetrieveRequest) GetQuery() string {
+func (x *SummarizeMetadata) GetLanguage() string {
 	if x != nil {
-		return x.Query
+		return x.Language
 	}
 	return ""
 }
 
-func (x *RetrieveRequest) GetOptions() *RetrieveOptions {
+func (x *SummarizeMetadata) GetTotalTimeMs() int64 {
 	if x != nil {
-		return x.Options
+		return x.TotalTimeMs
 	}
-	return nil
+	return 0
 }
 
-type RetrieveOptions struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Number of chunks to retrieve
-	TopK int32 `protobuf:"varint,1,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
-	// Minimum similarity score threshold (0.0 - 1.0)
-	MinScore float32 `protobuf:"fixed32,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
-	// Filter by document IDs (optional)
-	DocumentIds   []string `protobuf:"bytes,3,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+type GenerateQuestionsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Documents to draw questions from, at most 50. Without them, the
+	// tenant's ready documents matching tags and collection_id are used.
+	DocumentIds []string `protobuf:"bytes,2,rep,name=document_ids,json=documentIds,proto3" json:"document_ids,omitempty"`
+	// Documents with at least one of these tags (without document_ids)
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Documents in this collection (without document_ids)
+	CollectionId string `protobuf:"bytes,4,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// Questions to generate, spread across the documents (default 10, max 100)
+	MaxQuestions int32 `protobuf:"varint,5,opt,name=max_questions,json=maxQuestions,proto3" json:"max_questions,omitempty"`
+	// ISO 639-1 code of the language to write in (overrides tenant config;
+	// "auto" detects it from the documents)
+	Language      string `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetrieveOptions) Reset() {
-	*x = RetrieveOptions{}
-	mi := &file_rag_v1_rag_proto_msgTypes[8]
+func (x *GenerateQuestionsRequest) Reset() {
+	*x = GenerateQuestionsRequest{}
+	mi := &file_rag_v1_rag_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrieveOptions) String() string {
+func (x *GenerateQuestionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveOptions) ProtoMessage() {}
+func (*GenerateQuestionsRequest) ProtoMessage() {}
 
-func (x *RetrieveOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[8]
+func (x *GenerateQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,55 +2630,80 @@ func (x *RetrieveOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveOptions.ProtoReflect.Descriptor instead.
-func (*RetrieveOptions) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GenerateQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*GenerateQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *RetrieveOptions) GetTopK() int32 {
+func (x *GenerateQuestionsRequest) GetTenantId() string {
 	if x != nil {
-		return x.TopK
+		return x.TenantId
 	}
-	return 0
+	return ""
 }
 
-func (x *RetrieveOptions) GetMinScore() float32 {
+func (x *GenerateQuestionsRequest) GetDocumentIds() []string {
 	if x != nil {
-		return x.MinScore
+		return x.DocumentIds
 	}
-	return 0
+	return nil
 }
 
-func (x *RetrieveOptions) GetDocumentIds() []string {
+func (x *GenerateQuestionsRequest) GetTags() []string {
 	if x != nil {
-		return x.DocumentIds
+		return x.Tags
 	}
 	return nil
 }
 
-type RetrieveResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunks        []*RetrievedChunk      `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
-	Metadata      *RetrieveMetadata      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *GenerateQuestionsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GenerateQuestionsRequest) GetMaxQuestions() int32 {
+	if x != nil {
+		return x.MaxQuestions
+	}
+	return 0
+}
+
+func (x *GenerateQuestionsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GenerateQuestionsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Questions []*GeneratedQuestion   `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	// Chunks of the documents, of which the questions' passages were drawn
+	ChunkCount    int32  `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	Model         string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Language      string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	TotalTimeMs   int64  `protobuf:"varint,5,opt,name=total_time_ms,json=totalTimeMs,proto3" json:"total_time_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RetrieveResponse) Reset() {
-	*x = RetrieveResponse{}
-	mi := &file_rag_v1_rag_proto_msgTypes[9]
+func (x *GenerateQuestionsResponse) Reset() {
+	*x = GenerateQuestionsResponse{}
+	mi := &file_rag_v1_rag_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrieveResponse) String() string {
+func (x *GenerateQuestionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveResponse) ProtoMessage() {}
+func (*GenerateQuestionsResponse) ProtoMessage() {}
 
-func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[9]
+func (x *GenerateQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,52 +2714,72 @@ func (x *RetrieveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveResponse.ProtoReflect.Descriptor instead.
-func (*RetrieveResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GenerateQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*GenerateQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *RetrieveResponse) GetChunks() []*RetrievedChunk {
+func (x *GenerateQuestionsResponse) GetQuestions() []*GeneratedQuestion {
 	if x != nil {
-		return x.Chunks
+		return x.Questions
 	}
 	return nil
 }
 
-func (x *RetrieveResponse) GetMetadata() *RetrieveMetadata {
+func (x *GenerateQuestionsResponse) GetChunkCount() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.ChunkCount
 	}
-	return nil
+	return 0
 }
 
-type RetrieveMetadata struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Time taken for retrieval in milliseconds
-	RetrievalTimeMs int64 `protobuf:"varint,1,opt,name=retrieval_time_ms,json=retrievalTimeMs,proto3" json:"retrieval_time_ms,omitempty"`
-	// Number of chunks retrieved
-	ChunksRetrieved int32 `protobuf:"varint,2,opt,name=chunks_retrieved,json=chunksRetrieved,proto3" json:"chunks_retrieved,omitempty"`
-	// Total chunks searched
-	TotalChunksSearched int32 `protobuf:"varint,3,opt,name=total_chunks_searched,json=totalChunksSearched,proto3" json:"total_chunks_searched,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *GenerateQuestionsResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
 }
 
-func (x *RetrieveMetadata) Reset() {
-	*x = RetrieveMetadata{}
-	mi := &file_rag_v1_rag_proto_msgTypes[10]
+func (x *GenerateQuestionsResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GenerateQuestionsResponse) GetTotalTimeMs() int64 {
+	if x != nil {
+		return x.TotalTimeMs
+	}
+	return 0
+}
+
+type GeneratedQuestion struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Question string                 `protobuf:"bytes,1,opt,name=question,proto3" json:"question,omitempty"`
+	// Answer drawn only from the sources
+	Answer string `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+	// Chunks the answer is drawn from (score is unset)
+	Sources       []*RetrievedChunk `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratedQuestion) Reset() {
+	*x = GeneratedQuestion{}
+	mi := &file_rag_v1_rag_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetrieveMetadata) String() string {
+func (x *GeneratedQuestion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetrieveMetadata) ProtoMessage() {}
+func (*GeneratedQuestion) ProtoMessage() {}
 
-func (x *RetrieveMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_rag_proto_msgTypes[10]
+func (x *GeneratedQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_rag_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -809,54 +2790,93 @@ func (x *RetrieveMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetrieveMetadata.ProtoReflect.Descriptor instead.
-func (*RetrieveMetadata) Descriptor() ([]byte, []int) {
-	return file_rag_v1_rag_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GeneratedQuestion.ProtoReflect.Descriptor instead.
+func (*GeneratedQuestion) Descriptor() ([]byte, []int) {
+	return file_rag_v1_rag_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *RetrieveMetadata) GetRetrievalTimeMs() int64 {
+func (x *GeneratedQuestion) GetQuestion() string {
 	if x != nil {
-		return x.RetrievalTimeMs
+		return x.Question
 	}
-	return 0
+	return ""
 }
 
-func (x *RetrieveMetadata) GetChunksRetrieved() int32 {
+func (x *GeneratedQuestion) GetAnswer() string {
 	if x != nil {
-		return x.ChunksRetrieved
+		return x.Answer
 	}
-	return 0
+	return ""
 }
 
-func (x *RetrieveMetadata) GetTotalChunksSearched() int32 {
+func (x *GeneratedQuestion) GetSources() []*RetrievedChunk {
 	if x != nil {
-		return x.TotalChunksSearched
+		return x.Sources
 	}
-	return 0
+	return nil
 }
 
 var File_rag_v1_rag_proto protoreflect.FileDescriptor
 
 const file_rag_v1_rag_proto_rawDesc = "" +
 	"\n" +
-	"\x10rag/v1/rag.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x90\x01\n" +
+	"\x10rag/v1/rag.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x15rag/v1/document.proto\x1a\x13rag/v1/tenant.proto\"\xaf\x01\n" +
 	"\fQueryRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12.\n" +
 	"\aoptions\x18\x03 \x01(\v2\x14.rag.v1.QueryOptionsR\aoptions\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x04 \x01(\tR\tsessionId\"\xa6\x01\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tR\tprojectId\"\xd3\x03\n" +
 	"\fQueryOptions\x12\x13\n" +
 	"\x05top_k\x18\x01 \x01(\x05R\x04topK\x12\x1b\n" +
 	"\tmin_score\x18\x02 \x01(\x02R\bminScore\x12#\n" +
 	"\rsystem_prompt\x18\x03 \x01(\tR\fsystemPrompt\x12 \n" +
 	"\vtemperature\x18\x04 \x01(\x02R\vtemperature\x12\x1d\n" +
 	"\n" +
-	"max_tokens\x18\x05 \x01(\x05R\tmaxTokens\"\x8c\x01\n" +
+	"max_tokens\x18\x05 \x01(\x05R\tmaxTokens\x12E\n" +
+	"\x11context_expansion\x18\x06 \x01(\v2\x18.rag.v1.ContextExpansionR\x10contextExpansion\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12%\n" +
+	"\x0ecollection_ids\x18\b \x03(\tR\rcollectionIds\x129\n" +
+	"\vscore_boost\x18\t \x01(\v2\x18.rag.v1.ScoreBoostConfigR\n" +
+	"scoreBoost\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12\x18\n" +
+	"\aagentic\x18\v \x01(\bR\aagentic\x12\x1c\n" +
+	"\tlanguages\x18\f \x03(\tR\tlanguages\x12\x1a\n" +
+	"\bstrategy\x18\r \x01(\tR\bstrategy\">\n" +
+	"\x10ContextExpansion\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\x05R\x06window\"\xf3\x02\n" +
 	"\rQueryResponse\x12\x16\n" +
 	"\x06answer\x18\x01 \x01(\tR\x06answer\x120\n" +
 	"\asources\x18\x02 \x03(\v2\x16.rag.v1.RetrievedChunkR\asources\x121\n" +
-	"\bmetadata\x18\x03 \x01(\v2\x15.rag.v1.QueryMetadataR\bmetadata\"\xa9\x02\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x15.rag.v1.QueryMetadataR\bmetadata\x123\n" +
+	"\vanswer_type\x18\x04 \x01(\x0e2\x12.rag.v1.AnswerTypeR\n" +
+	"answerType\x12A\n" +
+	"\x10guardrail_events\x18\x05 \x03(\v2\x16.rag.v1.GuardrailEventR\x0fguardrailEvents\x122\n" +
+	"\vagent_steps\x18\x06 \x03(\v2\x11.rag.v1.AgentStepR\n" +
+	"agentSteps\x12/\n" +
+	"\ttool_call\x18\a \x01(\v2\x10.rag.v1.ToolCallH\x00R\btoolCallB\b\n" +
+	"\x06action\"<\n" +
+	"\bToolCall\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\targuments\x18\x02 \x01(\tR\targuments\"\xa3\x01\n" +
+	"\tAgentStep\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\x05R\x04step\x12\x12\n" +
+	"\x04tool\x18\x02 \x01(\tR\x04tool\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x1f\n" +
+	"\vdocument_id\x18\x04 \x01(\tR\n" +
+	"documentId\x12!\n" +
+	"\fchunks_added\x18\x05 \x01(\x05R\vchunksAdded\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x89\x01\n" +
+	"\x0eGuardrailEvent\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x19\n" +
+	"\bchunk_id\x18\x05 \x01(\tR\achunkId\"\xdb\x03\n" +
 	"\x0eRetrievedChunk\x12\x1f\n" +
 	"\vdocument_id\x18\x01 \x01(\tR\n" +
 	"documentId\x12\x19\n" +
@@ -865,10 +2885,14 @@ const file_rag_v1_rag_proto_rawDesc = "" +
 	"\x05score\x18\x04 \x01(\x02R\x05score\x12\x16\n" +
 	"\x06source\x18\x05 \x01(\tR\x06source\x12\x14\n" +
 	"\x05title\x18\x06 \x01(\tR\x05title\x12@\n" +
-	"\bmetadata\x18\a \x03(\v2$.rag.v1.RetrievedChunk.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2$.rag.v1.RetrievedChunk.MetadataEntryR\bmetadata\x12@\n" +
+	"\x10neighbors_before\x18\b \x03(\v2\x15.rag.v1.DocumentChunkR\x0fneighborsBefore\x12>\n" +
+	"\x0fneighbors_after\x18\t \x03(\v2\x15.rag.v1.DocumentChunkR\x0eneighborsAfter\x12.\n" +
+	"\x13shared_document_ids\x18\n" +
+	" \x03(\tR\x11sharedDocumentIds\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa0\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x03\n" +
 	"\rQueryMetadata\x12*\n" +
 	"\x11retrieval_time_ms\x18\x01 \x01(\x03R\x0fretrievalTimeMs\x12,\n" +
 	"\x12generation_time_ms\x18\x02 \x01(\x03R\x10generationTimeMs\x12\"\n" +
@@ -876,36 +2900,188 @@ const file_rag_v1_rag_proto_rawDesc = "" +
 	"\x10chunks_retrieved\x18\x04 \x01(\x05R\x0fchunksRetrieved\x12\x14\n" +
 	"\x05model\x18\x05 \x01(\tR\x05model\x12#\n" +
 	"\rprompt_tokens\x18\x06 \x01(\x05R\fpromptTokens\x12+\n" +
-	"\x11completion_tokens\x18\a \x01(\x05R\x10completionTokens\"\xca\x01\n" +
+	"\x11completion_tokens\x18\a \x01(\x05R\x10completionTokens\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x128\n" +
+	"\n" +
+	"truncation\x18\t \x01(\v2\x18.rag.v1.PromptTruncationR\n" +
+	"truncation\x12,\n" +
+	"\x12estimated_cost_usd\x18\n" +
+	" \x01(\x01R\x10estimatedCostUsd\"\xa8\x02\n" +
+	"\x10PromptTruncation\x12\x1c\n" +
+	"\ttruncated\x18\x01 \x01(\bR\ttruncated\x12%\n" +
+	"\x0econtext_length\x18\x02 \x01(\x05R\rcontextLength\x12%\n" +
+	"\x0echunks_dropped\x18\x03 \x01(\x05R\rchunksDropped\x12)\n" +
+	"\x10chunks_truncated\x18\x04 \x01(\x05R\x0fchunksTruncated\x128\n" +
+	"\x18history_messages_dropped\x18\x05 \x01(\x05R\x16historyMessagesDropped\x12'\n" +
+	"\x0fsummary_dropped\x18\x06 \x01(\bR\x0esummaryDropped\x12\x1a\n" +
+	"\boverflow\x18\a \x01(\bR\boverflow\"\x9b\x03\n" +
 	"\x13QueryStreamResponse\x120\n" +
 	"\x06source\x18\x01 \x01(\v2\x16.rag.v1.RetrievedChunkH\x00R\x06source\x12\x16\n" +
 	"\x05token\x18\x02 \x01(\tH\x00R\x05token\x123\n" +
 	"\bmetadata\x18\x03 \x01(\v2\x15.rag.v1.QueryMetadataH\x00R\bmetadata\x12+\n" +
-	"\x05error\x18\x04 \x01(\v2\x13.rag.v1.StreamErrorH\x00R\x05errorB\a\n" +
-	"\x05event\";\n" +
+	"\x05error\x18\x04 \x01(\v2\x13.rag.v1.StreamErrorH\x00R\x05error\x122\n" +
+	"\n" +
+	"no_context\x18\x05 \x01(\v2\x11.rag.v1.NoContextH\x00R\tnoContext\x126\n" +
+	"\tguardrail\x18\x06 \x01(\v2\x16.rag.v1.GuardrailEventH\x00R\tguardrail\x122\n" +
+	"\n" +
+	"agent_step\x18\a \x01(\v2\x11.rag.v1.AgentStepH\x00R\tagentStep\x12/\n" +
+	"\ttool_call\x18\b \x01(\v2\x10.rag.v1.ToolCallH\x00R\btoolCallB\a\n" +
+	"\x05event\"F\n" +
+	"\tNoContext\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
+	"\vllm_skipped\x18\x02 \x01(\bR\n" +
+	"llmSkipped\";\n" +
 	"\vStreamError\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"w\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xc1\x01\n" +
 	"\x0fRetrieveRequest\x12\x1b\n" +
 	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x121\n" +
-	"\aoptions\x18\x03 \x01(\v2\x17.rag.v1.RetrieveOptionsR\aoptions\"f\n" +
+	"\aoptions\x18\x03 \x01(\v2\x17.rag.v1.RetrieveOptionsR\aoptions\x12)\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x15.rag.v1.RetrievalModeR\x04mode\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\tR\tprojectId\"\xce\x02\n" +
 	"\x0fRetrieveOptions\x12\x13\n" +
 	"\x05top_k\x18\x01 \x01(\x05R\x04topK\x12\x1b\n" +
 	"\tmin_score\x18\x02 \x01(\x02R\bminScore\x12!\n" +
-	"\fdocument_ids\x18\x03 \x03(\tR\vdocumentIds\"x\n" +
+	"\fdocument_ids\x18\x03 \x03(\tR\vdocumentIds\x12)\n" +
+	"\x10neighbors_before\x18\x04 \x01(\x05R\x0fneighborsBefore\x12'\n" +
+	"\x0fneighbors_after\x18\x05 \x01(\x05R\x0eneighborsAfter\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12%\n" +
+	"\x0ecollection_ids\x18\a \x03(\tR\rcollectionIds\x129\n" +
+	"\vscore_boost\x18\b \x01(\v2\x18.rag.v1.ScoreBoostConfigR\n" +
+	"scoreBoost\x12\x1c\n" +
+	"\tlanguages\x18\t \x03(\tR\tlanguages\"x\n" +
 	"\x10RetrieveResponse\x12.\n" +
 	"\x06chunks\x18\x01 \x03(\v2\x16.rag.v1.RetrievedChunkR\x06chunks\x124\n" +
-	"\bmetadata\x18\x02 \x01(\v2\x18.rag.v1.RetrieveMetadataR\bmetadata\"\x9d\x01\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x18.rag.v1.RetrieveMetadataR\bmetadata\"\xc8\x01\n" +
 	"\x10RetrieveMetadata\x12*\n" +
 	"\x11retrieval_time_ms\x18\x01 \x01(\x03R\x0fretrievalTimeMs\x12)\n" +
 	"\x10chunks_retrieved\x18\x02 \x01(\x05R\x0fchunksRetrieved\x122\n" +
-	"\x15total_chunks_searched\x18\x03 \x01(\x05R\x13totalChunksSearched2\x91\x02\n" +
+	"\x15total_chunks_searched\x18\x03 \x01(\x05R\x13totalChunksSearched\x12)\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x15.rag.v1.RetrievalModeR\x04mode\"\x89\x06\n" +
+	"\x14ExplainQueryResponse\x120\n" +
+	"\x14query_embedding_norm\x18\x01 \x01(\x02R\x12queryEmbeddingNorm\x12:\n" +
+	"\x19query_embedding_dimension\x18\x02 \x01(\x05R\x17queryEmbeddingDimension\x12\x16\n" +
+	"\x06hybrid\x18\x03 \x01(\bR\x06hybrid\x128\n" +
+	"\n" +
+	"candidates\x18\x04 \x03(\v2\x18.rag.v1.ExplainCandidateR\n" +
+	"candidates\x12\x1a\n" +
+	"\breranked\x18\x05 \x01(\bR\breranked\x120\n" +
+	"\asources\x18\x06 \x03(\v2\x16.rag.v1.RetrievedChunkR\asources\x12#\n" +
+	"\rsystem_prompt\x18\a \x01(\tR\fsystemPrompt\x12\x16\n" +
+	"\x06prompt\x18\b \x01(\tR\x06prompt\x12=\n" +
+	"\ftoken_counts\x18\t \x01(\v2\x1a.rag.v1.ExplainTokenCountsR\vtokenCounts\x12\x14\n" +
+	"\x05model\x18\n" +
+	" \x01(\tR\x05model\x12*\n" +
+	"\x11retrieval_time_ms\x18\v \x01(\x03R\x0fretrievalTimeMs\x126\n" +
+	"\x17prompt_template_version\x18\f \x01(\x05R\x15promptTemplateVersion\x12\x1a\n" +
+	"\blanguage\x18\r \x01(\tR\blanguage\x123\n" +
+	"\vanswer_type\x18\x0e \x01(\x0e2\x12.rag.v1.AnswerTypeR\n" +
+	"answerType\x12A\n" +
+	"\x10guardrail_events\x18\x0f \x03(\v2\x16.rag.v1.GuardrailEventR\x0fguardrailEvents\x128\n" +
+	"\n" +
+	"truncation\x18\x10 \x01(\v2\x18.rag.v1.PromptTruncationR\n" +
+	"truncation\x12\x1f\n" +
+	"\vsearch_text\x18\x11 \x01(\tR\n" +
+	"searchText\"\xff\x03\n" +
+	"\x10ExplainCandidate\x12\x19\n" +
+	"\bchunk_id\x18\x01 \x01(\tR\achunkId\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1f\n" +
+	"\vdense_score\x18\x04 \x01(\x02R\n" +
+	"denseScore\x12&\n" +
+	"\fsparse_score\x18\x05 \x01(\x02H\x00R\vsparseScore\x88\x01\x01\x12$\n" +
+	"\vfused_score\x18\x06 \x01(\x02H\x01R\n" +
+	"fusedScore\x88\x01\x01\x12!\n" +
+	"\fduplicate_of\x18\a \x01(\tR\vduplicateOf\x121\n" +
+	"\x14duplicate_similarity\x18\b \x01(\x02R\x13duplicateSimilarity\x12*\n" +
+	"\x0ereranker_score\x18\t \x01(\x02H\x02R\rrerankerScore\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"final_rank\x18\n" +
+	" \x01(\x05R\tfinalRank\x12\x1f\n" +
+	"\vfinal_score\x18\v \x01(\x02R\n" +
+	"finalScore\x124\n" +
+	"\x16below_rerank_threshold\x18\f \x01(\bR\x14belowRerankThresholdB\x0f\n" +
+	"\r_sparse_scoreB\x0e\n" +
+	"\f_fused_scoreB\x11\n" +
+	"\x0f_reranker_score\"\xc2\x01\n" +
+	"\x12ExplainTokenCounts\x12#\n" +
+	"\rsystem_prompt\x18\x01 \x01(\x05R\fsystemPrompt\x12\x18\n" +
+	"\acontext\x18\x02 \x01(\x05R\acontext\x12\x18\n" +
+	"\ahistory\x18\x03 \x01(\x05R\ahistory\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\x05R\x05query\x12\x16\n" +
+	"\x06prompt\x18\x05 \x01(\x05R\x06prompt\x12%\n" +
+	"\x0emax_completion\x18\x06 \x01(\x05R\rmaxCompletion\"\xbd\x01\n" +
+	"\x10SummarizeRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12!\n" +
+	"\fdocument_ids\x18\x02 \x03(\tR\vdocumentIds\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12#\n" +
+	"\rcollection_id\x18\x04 \x01(\tR\fcollectionId\x12\x14\n" +
+	"\x05style\x18\x05 \x01(\tR\x05style\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\"\xd3\x01\n" +
+	"\x11SummarizeResponse\x127\n" +
+	"\bprogress\x18\x01 \x01(\v2\x19.rag.v1.SummarizeProgressH\x00R\bprogress\x12\x16\n" +
+	"\x05token\x18\x02 \x01(\tH\x00R\x05token\x127\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x19.rag.v1.SummarizeMetadataH\x00R\bmetadata\x12+\n" +
+	"\x05error\x18\x04 \x01(\v2\x13.rag.v1.StreamErrorH\x00R\x05errorB\a\n" +
+	"\x05event\"u\n" +
+	"\x11SummarizeProgress\x12,\n" +
+	"\x05stage\x18\x01 \x01(\x0e2\x16.rag.v1.SummarizeStageR\x05stage\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x05R\tcompleted\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\xca\x01\n" +
+	"\x11SummarizeMetadata\x12!\n" +
+	"\fdocument_ids\x18\x01 \x03(\tR\vdocumentIds\x12\x1f\n" +
+	"\vchunk_count\x18\x02 \x01(\x05R\n" +
+	"chunkCount\x12\x1b\n" +
+	"\tllm_calls\x18\x03 \x01(\x05R\bllmCalls\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12\"\n" +
+	"\rtotal_time_ms\x18\x06 \x01(\x03R\vtotalTimeMs\"\xd4\x01\n" +
+	"\x18GenerateQuestionsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12!\n" +
+	"\fdocument_ids\x18\x02 \x03(\tR\vdocumentIds\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12#\n" +
+	"\rcollection_id\x18\x04 \x01(\tR\fcollectionId\x12#\n" +
+	"\rmax_questions\x18\x05 \x01(\x05R\fmaxQuestions\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\"\xcb\x01\n" +
+	"\x19GenerateQuestionsResponse\x127\n" +
+	"\tquestions\x18\x01 \x03(\v2\x19.rag.v1.GeneratedQuestionR\tquestions\x12\x1f\n" +
+	"\vchunk_count\x18\x02 \x01(\x05R\n" +
+	"chunkCount\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\"\n" +
+	"\rtotal_time_ms\x18\x05 \x01(\x03R\vtotalTimeMs\"y\n" +
+	"\x11GeneratedQuestion\x12\x1a\n" +
+	"\bquestion\x18\x01 \x01(\tR\bquestion\x12\x16\n" +
+	"\x06answer\x18\x02 \x01(\tR\x06answer\x120\n" +
+	"\asources\x18\x03 \x03(\v2\x16.rag.v1.RetrievedChunkR\asources*\x93\x01\n" +
+	"\n" +
+	"AnswerType\x12\x1b\n" +
+	"\x17ANSWER_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ANSWER_TYPE_ANSWERED\x10\x01\x12\x1a\n" +
+	"\x16ANSWER_TYPE_NO_CONTEXT\x10\x02\x12\x17\n" +
+	"\x13ANSWER_TYPE_BLOCKED\x10\x03\x12\x19\n" +
+	"\x15ANSWER_TYPE_TOOL_CALL\x10\x04*\x81\x01\n" +
+	"\rRetrievalMode\x12\x1e\n" +
+	"\x1aRETRIEVAL_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15RETRIEVAL_MODE_VECTOR\x10\x01\x12\x1a\n" +
+	"\x16RETRIEVAL_MODE_KEYWORD\x10\x02\x12\x19\n" +
+	"\x15RETRIEVAL_MODE_HYBRID\x10\x03*\x81\x01\n" +
+	"\x0eSummarizeStage\x12\x1f\n" +
+	"\x1bSUMMARIZE_STAGE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SUMMARIZE_STAGE_MAP\x10\x01\x12\x1a\n" +
+	"\x16SUMMARIZE_STAGE_REDUCE\x10\x02\x12\x19\n" +
+	"\x15SUMMARIZE_STAGE_FINAL\x10\x032\xce\x04\n" +
 	"\n" +
 	"RAGService\x12J\n" +
 	"\x05Query\x12\x14.rag.v1.QueryRequest\x1a\x15.rag.v1.QueryResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/query\x12_\n" +
 	"\vQueryStream\x12\x14.rag.v1.QueryRequest\x1a\x1b.rag.v1.QueryStreamResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/query/stream0\x01\x12V\n" +
-	"\bRetrieve\x12\x17.rag.v1.RetrieveRequest\x1a\x18.rag.v1.RetrieveResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/retrieveB\xea\x01\x92An\x12D\n" +
+	"\bRetrieve\x12\x17.rag.v1.RetrieveRequest\x1a\x18.rag.v1.RetrieveResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/retrieve\x12`\n" +
+	"\fExplainQuery\x12\x14.rag.v1.QueryRequest\x1a\x1c.rag.v1.ExplainQueryResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/query/explain\x12\\\n" +
+	"\tSummarize\x12\x18.rag.v1.SummarizeRequest\x1a\x19.rag.v1.SummarizeResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/summarize0\x01\x12{\n" +
+	"\x11GenerateQuestions\x12 .rag.v1.GenerateQuestionsRequest\x1a!.rag.v1.GenerateQuestionsResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/questions/generateB\xea\x01\x92An\x12D\n" +
 	"\rRAG Query API\x12.Multi-tenant RAG service - Query and retrieval2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
 	"\n" +
 	"com.rag.v1B\bRagProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
@@ -922,43 +3098,99 @@ func file_rag_v1_rag_proto_rawDescGZIP() []byte {
 	return file_rag_v1_rag_proto_rawDescData
 }
 
-var file_rag_v1_rag_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_rag_v1_rag_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_rag_v1_rag_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_rag_v1_rag_proto_goTypes = []any{
-	(*QueryRequest)(nil),        // 0: rag.v1.QueryRequest
-	(*QueryOptions)(nil),        // 1: rag.v1.QueryOptions
-	(*QueryResponse)(nil),       // 2: rag.v1.QueryResponse
-	(*RetrievedChunk)(nil),      // 3: rag.v1.RetrievedChunk
-	(*QueryMetadata)(nil),       // 4: rag.v1.QueryMetadata
-	(*QueryStreamResponse)(nil), // 5: rag.v1.QueryStreamResponse
-	(*StreamError)(nil),         // 6: rag.v1.StreamError
-	(*RetrieveRequest)(nil),     // 7: rag.v1.RetrieveRequest
-	(*RetrieveOptions)(nil),     // 8: rag.v1.RetrieveOptions
-	(*RetrieveResponse)(nil),    // 9: rag.v1.RetrieveResponse
-	(*RetrieveMetadata)(nil),    // 10: rag.v1.RetrieveMetadata
-	nil,                         // 11: rag.v1.RetrievedChunk.MetadataEntry
+	(AnswerType)(0),                   // 0: rag.v1.AnswerType
+	(RetrievalMode)(0),                // 1: rag.v1.RetrievalMode
+	(SummarizeStage)(0),               // 2: rag.v1.SummarizeStage
+	(*QueryRequest)(nil),              // 3: rag.v1.QueryRequest
+	(*QueryOptions)(nil),              // 4: rag.v1.QueryOptions
+	(*ContextExpansion)(nil),          // 5: rag.v1.ContextExpansion
+	(*QueryResponse)(nil),             // 6: rag.v1.QueryResponse
+	(*ToolCall)(nil),                  // 7: rag.v1.ToolCall
+	(*AgentStep)(nil),                 // 8: rag.v1.AgentStep
+	(*GuardrailEvent)(nil),            // 9: rag.v1.GuardrailEvent
+	(*RetrievedChunk)(nil),            // 10: rag.v1.RetrievedChunk
+	(*QueryMetadata)(nil),             // 11: rag.v1.QueryMetadata
+	(*PromptTruncation)(nil),          // 12: rag.v1.PromptTruncation
+	(*QueryStreamResponse)(nil),       // 13: rag.v1.QueryStreamResponse
+	(*NoContext)(nil),                 // 14: rag.v1.NoContext
+	(*StreamError)(nil),               // 15: rag.v1.StreamError
+	(*RetrieveRequest)(nil),           // 16: rag.v1.RetrieveRequest
+	(*RetrieveOptions)(nil),           // 17: rag.v1.RetrieveOptions
+	(*RetrieveResponse)(nil),          // 18: rag.v1.RetrieveResponse
+	(*RetrieveMetadata)(nil),          // 19: rag.v1.RetrieveMetadata
+	(*ExplainQueryResponse)(nil),      // 20: rag.v1.ExplainQueryResponse
+	(*ExplainCandidate)(nil),          // 21: rag.v1.ExplainCandidate
+	(*ExplainTokenCounts)(nil),        // 22: rag.v1.ExplainTokenCounts
+	(*SummarizeRequest)(nil),          // 23: rag.v1.SummarizeRequest
+	(*SummarizeResponse)(nil),         // 24: rag.v1.SummarizeResponse
+	(*SummarizeProgress)(nil),         // 25: rag.v1.SummarizeProgress
+	(*SummarizeMetadata)(nil),         // 26: rag.v1.SummarizeMetadata
+	(*GenerateQuestionsRequest)(nil),  // 27: rag.v1.GenerateQuestionsRequest
+	(*GenerateQuestionsResponse)(nil), // 28: rag.v1.GenerateQuestionsResponse
+	(*GeneratedQuestion)(nil),         // 29: rag.v1.GeneratedQuestion
+	nil,                               // 30: rag.v1.RetrievedChunk.MetadataEntry
+	(*ScoreBoostConfig)(nil),          // 31: rag.v1.ScoreBoostConfig
+	(*DocumentChunk)(nil),             // 32: rag.v1.DocumentChunk
 }
 var file_rag_v1_rag_proto_depIdxs = []int32{
-	1,  // 0: rag.v1.QueryRequest.options:type_name -> rag.v1.QueryOptions
-	3,  // 1: rag.v1.QueryResponse.sources:type_name -> rag.v1.RetrievedChunk
-	4,  // 2: rag.v1.QueryResponse.metadata:type_name -> rag.v1.QueryMetadata
-	11, // 3: rag.v1.RetrievedChunk.metadata:type_name -> rag.v1.RetrievedChunk.MetadataEntry
-	3,  // 4: rag.v1.QueryStreamResponse.source:type_name -> rag.v1.RetrievedChunk
-	4,  // 5: rag.v1.QueryStreamResponse.metadata:type_name -> rag.v1.QueryMetadata
-	6,  // 6: rag.v1.QueryStreamResponse.error:type_name -> rag.v1.StreamError
-	8,  // 7: rag.v1.RetrieveRequest.options:type_name -> rag.v1.RetrieveOptions
-	3,  // 8: rag.v1.RetrieveResponse.chunks:type_name -> rag.v1.RetrievedChunk
-	10, // 9: rag.v1.RetrieveResponse.metadata:type_name -> rag.v1.RetrieveMetadata
-	0,  // 10: rag.v1.RAGService.Query:input_type -> rag.v1.QueryRequest
-	0,  // 11: rag.v1.RAGService.QueryStream:input_type -> rag.v1.QueryRequest
-	7,  // 12: rag.v1.RAGService.Retrieve:input_type -> rag.v1.RetrieveRequest
-	2,  // 13: rag.v1.RAGService.Query:output_type -> rag.v1.QueryResponse
-	5,  // 14: rag.v1.RAGService.QueryStream:output_type -> rag.v1.QueryStreamResponse
-	9,  // 15: rag.v1.RAGService.Retrieve:output_type -> rag.v1.RetrieveResponse
-	13, // [13:16] is the sub-list for method output_type
-	10, // [10:13] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	4,  // 0: rag.v1.QueryRequest.options:type_name -> rag.v1.QueryOptions
+	5,  // 1: rag.v1.QueryOptions.context_expansion:type_name -> rag.v1.ContextExpansion
+	31, // 2: rag.v1.QueryOptions.score_boost:type_name -> rag.v1.ScoreBoostConfig
+	10, // 3: rag.v1.QueryResponse.sources:type_name -> rag.v1.RetrievedChunk
+	11, // 4: rag.v1.QueryResponse.metadata:type_name -> rag.v1.QueryMetadata
+	0,  // 5: rag.v1.QueryResponse.answer_type:type_name -> rag.v1.AnswerType
+	9,  // 6: rag.v1.QueryResponse.guardrail_events:type_name -> rag.v1.GuardrailEvent
+	8,  // 7: rag.v1.QueryResponse.agent_steps:type_name -> rag.v1.AgentStep
+	7,  // 8: rag.v1.QueryResponse.tool_call:type_name -> rag.v1.ToolCall
+	30, // 9: rag.v1.RetrievedChunk.metadata:type_name -> rag.v1.RetrievedChunk.MetadataEntry
+	32, // 10: rag.v1.RetrievedChunk.neighbors_before:type_name -> rag.v1.DocumentChunk
+	32, // 11: rag.v1.RetrievedChunk.neighbors_after:type_name -> rag.v1.DocumentChunk
+	12, // 12: rag.v1.QueryMetadata.truncation:type_name -> rag.v1.PromptTruncation
+	10, // 13: rag.v1.QueryStreamResponse.source:type_name -> rag.v1.RetrievedChunk
+	11, // 14: rag.v1.QueryStreamResponse.metadata:type_name -> rag.v1.QueryMetadata
+	15, // 15: rag.v1.QueryStreamResponse.error:type_name -> rag.v1.StreamError
+	14, // 16: rag.v1.QueryStreamResponse.no_context:type_name -> rag.v1.NoContext
+	9,  // 17: rag.v1.QueryStreamResponse.guardrail:type_name -> rag.v1.GuardrailEvent
+	8,  // 18: rag.v1.QueryStreamResponse.agent_step:type_name -> rag.v1.AgentStep
+	7,  // 19: rag.v1.QueryStreamResponse.tool_call:type_name -> rag.v1.ToolCall
+	17, // 20: rag.v1.RetrieveRequest.options:type_name -> rag.v1.RetrieveOptions
+	1,  // 21: rag.v1.RetrieveRequest.mode:type_name -> rag.v1.RetrievalMode
+	31, // 22: rag.v1.RetrieveOptions.score_boost:type_name -> rag.v1.ScoreBoostConfig
+	10, // 23: rag.v1.RetrieveResponse.chunks:type_name -> rag.v1.RetrievedChunk
+	19, // 24: rag.v1.RetrieveResponse.metadata:type_name -> rag.v1.RetrieveMetadata
+	1,  // 25: rag.v1.RetrieveMetadata.mode:type_name -> rag.v1.RetrievalMode
+	21, // 26: rag.v1.ExplainQueryResponse.candidates:type_name -> rag.v1.ExplainCandidate
+	10, // 27: rag.v1.ExplainQueryResponse.sources:type_name -> rag.v1.RetrievedChunk
+	22, // 28: rag.v1.ExplainQueryResponse.token_counts:type_name -> rag.v1.ExplainTokenCounts
+	0,  // 29: rag.v1.ExplainQueryResponse.answer_type:type_name -> rag.v1.AnswerType
+	9,  // 30: rag.v1.ExplainQueryResponse.guardrail_events:type_name -> rag.v1.GuardrailEvent
+	12, // 31: rag.v1.ExplainQueryResponse.truncation:type_name -> rag.v1.PromptTruncation
+	25, // 32: rag.v1.SummarizeResponse.progress:type_name -> rag.v1.SummarizeProgress
+	26, // 33: rag.v1.SummarizeResponse.metadata:type_name -> rag.v1.SummarizeMetadata
+	15, // 34: rag.v1.SummarizeResponse.error:type_name -> rag.v1.StreamError
+	2,  // 35: rag.v1.SummarizeProgress.stage:type_name -> rag.v1.SummarizeStage
+	29, // 36: rag.v1.GenerateQuestionsResponse.questions:type_name -> rag.v1.GeneratedQuestion
+	10, // 37: rag.v1.GeneratedQuestion.sources:type_name -> rag.v1.RetrievedChunk
+	3,  // 38: rag.v1.RAGService.Query:input_type -> rag.v1.QueryRequest
+	3,  // 39: rag.v1.RAGService.QueryStream:input_type -> rag.v1.QueryRequest
+	16, // 40: rag.v1.RAGService.Retrieve:input_type -> rag.v1.RetrieveRequest
+	3,  // 41: rag.v1.RAGService.ExplainQuery:input_type -> rag.v1.QueryRequest
+	23, // 42: rag.v1.RAGService.Summarize:input_type -> rag.v1.SummarizeRequest
+	27, // 43: rag.v1.RAGService.GenerateQuestions:input_type -> rag.v1.GenerateQuestionsRequest
+	6,  // 44: rag.v1.RAGService.Query:output_type -> rag.v1.QueryResponse
+	13, // 45: rag.v1.RAGService.QueryStream:output_type -> rag.v1.QueryStreamResponse
+	18, // 46: rag.v1.RAGService.Retrieve:output_type -> rag.v1.RetrieveResponse
+	20, // 47: rag.v1.RAGService.ExplainQuery:output_type -> rag.v1.ExplainQueryResponse
+	24, // 48: rag.v1.RAGService.Summarize:output_type -> rag.v1.SummarizeResponse
+	28, // 49: rag.v1.RAGService.GenerateQuestions:output_type -> rag.v1.GenerateQuestionsResponse
+	44, // [44:50] is the sub-list for method output_type
+	38, // [38:44] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_rag_v1_rag_proto_init() }
@@ -966,24 +3198,41 @@ func file_rag_v1_rag_proto_init() {
 	if File_rag_v1_rag_proto != nil {
 		return
 	}
-	file_rag_v1_rag_proto_msgTypes[5].OneofWrappers = []any{
+	file_rag_v1_document_proto_init()
+	file_rag_v1_tenant_proto_init()
+	file_rag_v1_rag_proto_msgTypes[3].OneofWrappers = []any{
+		(*QueryResponse_ToolCall)(nil),
+	}
+	file_rag_v1_rag_proto_msgTypes[10].OneofWrappers = []any{
 		(*QueryStreamResponse_Source)(nil),
 		(*QueryStreamResponse_Token)(nil),
 		(*QueryStreamResponse_Metadata)(nil),
 		(*QueryStreamResponse_Error)(nil),
+		(*QueryStreamResponse_NoContext)(nil),
+		(*QueryStreamResponse_Guardrail)(nil),
+		(*QueryStreamResponse_AgentStep)(nil),
+		(*QueryStreamResponse_ToolCall)(nil),
+	}
+	file_rag_v1_rag_proto_msgTypes[18].OneofWrappers = []any{}
+	file_rag_v1_rag_proto_msgTypes[21].OneofWrappers = []any{
+		(*SummarizeResponse_Progress)(nil),
+		(*SummarizeResponse_Token)(nil),
+		(*SummarizeResponse_Metadata)(nil),
+		(*SummarizeResponse_Error)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_rag_proto_rawDesc), len(file_rag_v1_rag_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_rag_v1_rag_proto_goTypes,
 		DependencyIndexes: file_rag_v1_rag_proto_depIdxs,
+		EnumInfos:         file_rag_v1_rag_proto_enumTypes,
 		MessageInfos:      file_rag_v1_rag_proto_msgTypes,
 	}.Build()
 	File_rag_v1_rag_proto = out.File
@@ -19,9 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RAGService_Query_FullMethodName       = "/rag.v1.RAGService/Query"
-	RAGService_QueryStream_FullMethodName = "/rag.v1.RAGService/QueryStream"
-	RAGService_Retrieve_FullMethodName    = "/rag.v1.RAGService/Retrieve"
+	RAGService_Query_FullMethodName             = "/rag.v1.RAGService/Query"
+	RAGService_QueryStream_FullMethodName       = "/rag.v1.RAGService/QueryStream"
+	RAGService_Retrieve_FullMethodName          = "/rag.v1.RAGService/Retrieve"
+	RAGService_ExplainQuery_FullMethodName      = "/rag.v1.RAGService/ExplainQuery"
+	RAGService_Summarize_FullMethodName         = "/rag.v1.RAGService/Summarize"
+	RAGService_GenerateQuestions_FullMethodName = "/rag.v1.RAGService/GenerateQuestions"
 )
 
 // RAGServiceClient is the client API for RAGService service.
@@ -36,6 +39,17 @@ type RAGServiceClient interface {
 	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueryStreamResponse], error)
 	// Retrieve only retrieves relevant chunks without LLM generation
 	Retrieve(ctx context.Context, in *RetrieveRequest, opts ...grpc.CallOption) (*RetrieveResponse, error)
+	// ExplainQuery runs a query's retrieval and prompt building without
+	// generating an answer, and reports what each stage did
+	ExplainQuery(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*ExplainQueryResponse, error)
+	// Summarize summarizes whole documents, however long: their chunks are
+	// summarized in parts, the part summaries combined, and the final summary
+	// streamed after progress events (SSE via grpc-gateway)
+	Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SummarizeResponse], error)
+	// GenerateQuestions drafts questions that documents answer, each with an
+	// answer grounded in the chunks it cites, for seeding evaluation sets and
+	// FAQ pages
+	GenerateQuestions(ctx context.Context, in *GenerateQuestionsRequest, opts ...grpc.CallOption) (*GenerateQuestionsResponse, error)
 }
 
 type rAGServiceClient struct {
@@ -85,6 +99,45 @@ func (c *rAGServiceClient) Retrieve(ctx context.Context, in *RetrieveRequest, op
 	return out, nil
 }
 
+func (c *rAGServiceClient) ExplainQuery(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*ExplainQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainQueryResponse)
+	err := c.cc.Invoke(ctx, RAGService_ExplainQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rAGServiceClient) Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SummarizeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RAGService_ServiceDesc.Streams[1], RAGService_Summarize_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SummarizeRequest, SummarizeResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RAGService_SummarizeClient = grpc.ServerStreamingClient[SummarizeResponse]
+
+func (c *rAGServiceClient) GenerateQuestions(ctx context.Context, in *GenerateQuestionsRequest, opts ...grpc.CallOption) (*GenerateQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateQuestionsResponse)
+	err := c.cc.Invoke(ctx, RAGService_GenerateQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RAGServiceServer is the server API for RAGService service.
 // All implementations must embed UnimplementedRAGServiceServer
 // for forward compatibility.
@@ -97,6 +150,17 @@ type RAGServiceServer interface {
 	QueryStream(*QueryRequest, grpc.ServerStreamingServer[QueryStreamResponse]) error
 	// Retrieve only retrieves relevant chunks without LLM generation
 	Retrieve(context.Context, *RetrieveRequest) (*RetrieveResponse, error)
+	// ExplainQuery runs a query's retrieval and prompt building without
+	// generating an answer, and reports what each stage did
+	ExplainQuery(context.Context, *QueryRequest) (*ExplainQueryResponse, error)
+	// Summarize summarizes whole documents, however long: their chunks are
+	// summarized in parts, the part summaries combined, and the final summary
+	// streamed after progress events (SSE via grpc-gateway)
+	Summarize(*SummarizeRequest, grpc.ServerStreamingServer[SummarizeResponse]) error
+	// GenerateQuestions drafts questions that documents answer, each with an
+	// answer grounded in the chunks it cites, for seeding evaluation sets and
+	// FAQ pages
+	GenerateQuestions(context.Context, *GenerateQuestionsRequest) (*GenerateQuestionsResponse, error)
 	mustEmbedUnimplementedRAGServiceServer()
 }
 
@@ -116,6 +180,15 @@ func (UnimplementedRAGServiceServer) QueryStream(*QueryRequest, grpc.ServerStrea
 func (UnimplementedRAGServiceServer) Retrieve(context.Context, *RetrieveRequest) (*RetrieveResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Retrieve not implemented")
 }
+func (UnimplementedRAGServiceServer) ExplainQuery(context.Context, *QueryRequest) (*ExplainQueryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExplainQuery not implemented")
+}
+func (UnimplementedRAGServiceServer) Summarize(*SummarizeRequest, grpc.ServerStreamingServer[SummarizeResponse]) error {
+	return status.Error(codes.Unimplemented, "method Summarize not implemented")
+}
+func (UnimplementedRAGServiceServer) GenerateQuestions(context.Context, *GenerateQuestionsRequest) (*GenerateQuestionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateQuestions not implemented")
+}
 func (UnimplementedRAGServiceServer) mustEmbedUnimplementedRAGServiceServer() {}
 func (UnimplementedRAGServiceServer) testEmbeddedByValue()                    {}
 
@@ -184,6 +257,53 @@ func _RAGService_Retrieve_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RAGService_ExplainQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RAGServiceServer).ExplainQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RAGService_ExplainQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RAGServiceServer).ExplainQuery(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RAGService_Summarize_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SummarizeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RAGServiceServer).Summarize(m, &grpc.GenericServerStream[SummarizeRequest, SummarizeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RAGService_SummarizeServer = grpc.ServerStreamingServer[SummarizeResponse]
+
+func _RAGService_GenerateQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RAGServiceServer).GenerateQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RAGService_GenerateQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RAGServiceServer).GenerateQuestions(ctx, req.(*GenerateQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RAGService_ServiceDesc is the grpc.ServiceDesc for RAGService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,6 +319,14 @@ var RAGService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Retrieve",
 			Handler:    _RAGService_Retrieve_Handler,
 		},
+		{
+			MethodName: "ExplainQuery",
+			Handler:    _RAGService_ExplainQuery_Handler,
+		},
+		{
+			MethodName: "GenerateQuestions",
+			Handler:    _RAGService_GenerateQuestions_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -206,6 +334,11 @@ var RAGService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RAGService_QueryStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Summarize",
+			Handler:       _RAGService_Summarize_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rag/v1/rag.proto",
 }
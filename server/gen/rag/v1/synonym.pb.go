@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: rag/v1/synonym.proto
+
+package ragv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SynonymEntry maps a term to the terms a query containing it also matches
+type SynonymEntry struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// The term, normalized to lowercase words of letters and digits
+	Term          string                 `protobuf:"bytes,2,opt,name=term,proto3" json:"term,omitempty"`
+	Synonyms      []string               `protobuf:"bytes,3,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SynonymEntry) Reset() {
+	*x = SynonymEntry{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SynonymEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynonymEntry) ProtoMessage() {}
+
+func (x *SynonymEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynonymEntry.ProtoReflect.Descriptor instead.
+func (*SynonymEntry) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SynonymEntry) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SynonymEntry) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *SynonymEntry) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+func (x *SynonymEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SynonymEntry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetSynonymsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// A term of up to 4 words, e.g. "k8s"
+	Term string `protobuf:"bytes,2,opt,name=term,proto3" json:"term,omitempty"`
+	// Up to 20 terms it also matches, e.g. "kubernetes"
+	Synonyms      []string `protobuf:"bytes,3,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSynonymsRequest) Reset() {
+	*x = SetSynonymsRequest{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSynonymsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSynonymsRequest) ProtoMessage() {}
+
+func (x *SetSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*SetSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetSynonymsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SetSynonymsRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *SetSynonymsRequest) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+type ListSynonymsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSynonymsRequest) Reset() {
+	*x = ListSynonymsRequest{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSynonymsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSynonymsRequest) ProtoMessage() {}
+
+func (x *ListSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*ListSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSynonymsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *ListSynonymsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSynonymsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSynonymsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*SynonymEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSynonymsResponse) Reset() {
+	*x = ListSynonymsResponse{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSynonymsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSynonymsResponse) ProtoMessage() {}
+
+func (x *ListSynonymsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSynonymsResponse.ProtoReflect.Descriptor instead.
+func (*ListSynonymsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListSynonymsResponse) GetEntries() []*SynonymEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListSynonymsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListSynonymsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type DeleteSynonymsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Term          string                 `protobuf:"bytes,2,opt,name=term,proto3" json:"term,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSynonymsRequest) Reset() {
+	*x = DeleteSynonymsRequest{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSynonymsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSynonymsRequest) ProtoMessage() {}
+
+func (x *DeleteSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteSynonymsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *DeleteSynonymsRequest) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+type DeleteSynonymsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSynonymsResponse) Reset() {
+	*x = DeleteSynonymsResponse{}
+	mi := &file_rag_v1_synonym_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSynonymsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSynonymsResponse) ProtoMessage() {}
+
+func (x *DeleteSynonymsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_synonym_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSynonymsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSynonymsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_synonym_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteSynonymsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_rag_v1_synonym_proto protoreflect.FileDescriptor
+
+const file_rag_v1_synonym_proto_rawDesc = "" +
+	"\n" +
+	"\x14rag/v1/synonym.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd1\x01\n" +
+	"\fSynonymEntry\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\tR\x04term\x12\x1a\n" +
+	"\bsynonyms\x18\x03 \x03(\tR\bsynonyms\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"a\n" +
+	"\x12SetSynonymsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\tR\x04term\x12\x1a\n" +
+	"\bsynonyms\x18\x03 \x03(\tR\bsynonyms\"n\n" +
+	"\x13ListSynonymsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x8f\x01\n" +
+	"\x14ListSynonymsResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.rag.v1.SynonymEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"H\n" +
+	"\x15DeleteSynonymsRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\tR\x04term\"2\n" +
+	"\x16DeleteSynonymsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xc0\x02\n" +
+	"\x0eSynonymService\x12_\n" +
+	"\vSetSynonyms\x12\x1a.rag.v1.SetSynonymsRequest\x1a\x14.rag.v1.SynonymEntry\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\x1a\x13/v1/synonyms/{term}\x12_\n" +
+	"\fListSynonyms\x12\x1b.rag.v1.ListSynonymsRequest\x1a\x1c.rag.v1.ListSynonymsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/synonyms\x12l\n" +
+	"\x0eDeleteSynonyms\x12\x1d.rag.v1.DeleteSynonymsRequest\x1a\x1e.rag.v1.DeleteSynonymsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/synonyms/{term}B\xf1\x01\x92Aq\x12G\n" +
+	"\x0fRAG Synonym API\x12/Multi-tenant RAG service - Synonym dictionaries2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
+	"\n" +
+	"com.rag.v1B\fSynonymProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
+
+var (
+	file_rag_v1_synonym_proto_rawDescOnce sync.Once
+	file_rag_v1_synonym_proto_rawDescData []byte
+)
+
+func file_rag_v1_synonym_proto_rawDescGZIP() []byte {
+	file_rag_v1_synonym_proto_rawDescOnce.Do(func() {
+		file_rag_v1_synonym_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rag_v1_synonym_proto_rawDesc), len(file_rag_v1_synonym_proto_rawDesc)))
+	})
+	return file_rag_v1_synonym_proto_rawDescData
+}
+
+var file_rag_v1_synonym_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rag_v1_synonym_proto_goTypes = []any{
+	(*SynonymEntry)(nil),           // 0: rag.v1.SynonymEntry
+	(*SetSynonymsRequest)(nil),     // 1: rag.v1.SetSynonymsRequest
+	(*ListSynonymsRequest)(nil),    // 2: rag.v1.ListSynonymsRequest
+	(*ListSynonymsResponse)(nil),   // 3: rag.v1.ListSynonymsResponse
+	(*DeleteSynonymsRequest)(nil),  // 4: rag.v1.DeleteSynonymsRequest
+	(*DeleteSynonymsResponse)(nil), // 5: rag.v1.DeleteSynonymsResponse
+	(*timestamppb.Timestamp)(nil),  // 6: google.protobuf.Timestamp
+}
+var file_rag_v1_synonym_proto_depIdxs = []int32{
+	6, // 0: rag.v1.SynonymEntry.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: rag.v1.SynonymEntry.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: rag.v1.ListSynonymsResponse.entries:type_name -> rag.v1.SynonymEntry
+	1, // 3: rag.v1.SynonymService.SetSynonyms:input_type -> rag.v1.SetSynonymsRequest
+	2, // 4: rag.v1.SynonymService.ListSynonyms:input_type -> rag.v1.ListSynonymsRequest
+	4, // 5: rag.v1.SynonymService.DeleteSynonyms:input_type -> rag.v1.DeleteSynonymsRequest
+	0, // 6: rag.v1.SynonymService.SetSynonyms:output_type -> rag.v1.SynonymEntry
+	3, // 7: rag.v1.SynonymService.ListSynonyms:output_type -> rag.v1.ListSynonymsResponse
+	5, // 8: rag.v1.SynonymService.DeleteSynonyms:output_type -> rag.v1.DeleteSynonymsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rag_v1_synonym_proto_init() }
+func file_rag_v1_synonym_proto_init() {
+	if File_rag_v1_synonym_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_synonym_proto_rawDesc), len(file_rag_v1_synonym_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rag_v1_synonym_proto_goTypes,
+		DependencyIndexes: file_rag_v1_synonym_proto_depIdxs,
+		MessageInfos:      file_rag_v1_synonym_proto_msgTypes,
+	}.Build()
+	File_rag_v1_synonym_proto = out.File
+	file_rag_v1_synonym_proto_goTypes = nil
+	file_rag_v1_synonym_proto_depIdxs = nil
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: rag/v1/synonym.proto
+
+package ragv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SynonymService_SetSynonyms_FullMethodName    = "/rag.v1.SynonymService/SetSynonyms"
+	SynonymService_ListSynonyms_FullMethodName   = "/rag.v1.SynonymService/ListSynonyms"
+	SynonymService_DeleteSynonyms_FullMethodName = "/rag.v1.SynonymService/DeleteSynonyms"
+)
+
+// SynonymServiceClient is the client API for SynonymService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SynonymService manages a tenant's synonym and abbreviation dictionary.
+// Queries are expanded with it before keyword search and sparse
+// vectorization, so a query for "k8s" also matches chunks that only say
+// "Kubernetes". Entries are one-way: add "kubernetes" -> "k8s" as well to
+// expand both. Terms are matched case-insensitively, ignoring punctuation,
+// and changes reach queries within a minute.
+type SynonymServiceClient interface {
+	// SetSynonyms creates or replaces the entry of a term
+	SetSynonyms(ctx context.Context, in *SetSynonymsRequest, opts ...grpc.CallOption) (*SynonymEntry, error)
+	// ListSynonyms lists a tenant's entries by term
+	ListSynonyms(ctx context.Context, in *ListSynonymsRequest, opts ...grpc.CallOption) (*ListSynonymsResponse, error)
+	// DeleteSynonyms deletes the entry of a term
+	DeleteSynonyms(ctx context.Context, in *DeleteSynonymsRequest, opts ...grpc.CallOption) (*DeleteSynonymsResponse, error)
+}
+
+type synonymServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSynonymServiceClient(cc grpc.ClientConnInterface) SynonymServiceClient {
+	return &synonymServiceClient{cc}
+}
+
+func (c *synonymServiceClient) SetSynonyms(ctx context.Context, in *SetSynonymsRequest, opts ...grpc.CallOption) (*SynonymEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SynonymEntry)
+	err := c.cc.Invoke(ctx, SynonymService_SetSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synonymServiceClient) ListSynonyms(ctx context.Context, in *ListSynonymsRequest, opts ...grpc.CallOption) (*ListSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSynonymsResponse)
+	err := c.cc.Invoke(ctx, SynonymService_ListSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synonymServiceClient) DeleteSynonyms(ctx context.Context, in *DeleteSynonymsRequest, opts ...grpc.CallOption) (*DeleteSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSynonymsResponse)
+	err := c.cc.Invoke(ctx, SynonymService_DeleteSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SynonymServiceServer is the server API for SynonymService service.
+// All implementations must embed UnimplementedSynonymServiceServer
+// for forward compatibility.
+//
+// SynonymService manages a tenant's synonym and abbreviation dictionary.
+// Queries are expanded with it before keyword search and sparse
+// vectorization, so a query for "k8s" also matches chunks that only say
+// "Kubernetes". Entries are one-way: add "kubernetes" -> "k8s" as well to
+// expand both. Terms are matched case-insensitively, ignoring punctuation,
+// and changes reach queries within a minute.
+type SynonymServiceServer interface {
+	// SetSynonyms creates or replaces the entry of a term
+	SetSynonyms(context.Context, *SetSynonymsRequest) (*SynonymEntry, error)
+	// ListSynonyms lists a tenant's entries by term
+	ListSynonyms(context.Context, *ListSynonymsRequest) (*ListSynonymsResponse, error)
+	// DeleteSynonyms deletes the entry of a term
+	DeleteSynonyms(context.Context, *DeleteSynonymsRequest) (*DeleteSynonymsResponse, error)
+	mustEmbedUnimplementedSynonymServiceServer()
+}
+
+// UnimplementedSynonymServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSynonymServiceServer struct{}
+
+func (UnimplementedSynonymServiceServer) SetSynonyms(context.Context, *SetSynonymsRequest) (*SynonymEntry, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSynonyms not implemented")
+}
+func (UnimplementedSynonymServiceServer) ListSynonyms(context.Context, *ListSynonymsRequest) (*ListSynonymsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSynonyms not implemented")
+}
+func (UnimplementedSynonymServiceServer) DeleteSynonyms(context.Context, *DeleteSynonymsRequest) (*DeleteSynonymsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSynonyms not implemented")
+}
+func (UnimplementedSynonymServiceServer) mustEmbedUnimplementedSynonymServiceServer() {}
+func (UnimplementedSynonymServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeSynonymServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SynonymServiceServer will
+// result in compilation errors.
+type UnsafeSynonymServiceServer interface {
+	mustEmbedUnimplementedSynonymServiceServer()
+}
+
+func RegisterSynonymServiceServer(s grpc.ServiceRegistrar, srv SynonymServiceServer) {
+	// If the following call panics, it indicates UnimplementedSynonymServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SynonymService_ServiceDesc, srv)
+}
+
+func _SynonymService_SetSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynonymServiceServer).SetSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynonymService_SetSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynonymServiceServer).SetSynonyms(ctx, req.(*SetSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynonymService_ListSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynonymServiceServer).ListSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynonymService_ListSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynonymServiceServer).ListSynonyms(ctx, req.(*ListSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynonymService_DeleteSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynonymServiceServer).DeleteSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynonymService_DeleteSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynonymServiceServer).DeleteSynonyms(ctx, req.(*DeleteSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SynonymService_ServiceDesc is the grpc.ServiceDesc for SynonymService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SynonymService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rag.v1.SynonymService",
+	HandlerType: (*SynonymServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetSynonyms",
+			Handler:    _SynonymService_SetSynonyms_Handler,
+		},
+		{
+			MethodName: "ListSynonyms",
+			Handler:    _SynonymService_ListSynonyms_Handler,
+		},
+		{
+			MethodName: "DeleteSynonyms",
+			Handler:    _SynonymService_DeleteSynonyms_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rag/v1/synonym.proto",
+}
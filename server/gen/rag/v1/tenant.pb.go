@@ -24,17 +24,139 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// TenantStatus is a tenant's lifecycle status. A tenant's data is kept in
+// every status.
+type TenantStatus int32
+
+const (
+	TenantStatus_TENANT_STATUS_UNSPECIFIED TenantStatus = 0
+	// Every request is allowed
+	TenantStatus_TENANT_STATUS_ACTIVE TenantStatus = 1
+	// Queries and reads are allowed; ingestion and changes fail with
+	// FailedPrecondition (reason TENANT_READ_ONLY)
+	TenantStatus_TENANT_STATUS_READ_ONLY TenantStatus = 2
+	// Every request fails with PermissionDenied (reason TENANT_SUSPENDED), and
+	// feeds and crawls pause
+	TenantStatus_TENANT_STATUS_SUSPENDED TenantStatus = 3
+	// Like suspended (reason TENANT_ARCHIVED), for tenants that have left
+	TenantStatus_TENANT_STATUS_ARCHIVED TenantStatus = 4
+)
+
+// Enum value maps for TenantStatus.
+var (
+	TenantStatus_name = map[int32]string{
+		0: "TENANT_STATUS_UNSPECIFIED",
+		1: "TENANT_STATUS_ACTIVE",
+		2: "TENANT_STATUS_READ_ONLY",
+		3: "TENANT_STATUS_SUSPENDED",
+		4: "TENANT_STATUS_ARCHIVED",
+	}
+	TenantStatus_value = map[string]int32{
+		"TENANT_STATUS_UNSPECIFIED": 0,
+		"TENANT_STATUS_ACTIVE":      1,
+		"TENANT_STATUS_READ_ONLY":   2,
+		"TENANT_STATUS_SUSPENDED":   3,
+		"TENANT_STATUS_ARCHIVED":    4,
+	}
+)
+
+func (x TenantStatus) Enum() *TenantStatus {
+	p := new(TenantStatus)
+	*p = x
+	return p
+}
+
+func (x TenantStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_tenant_proto_enumTypes[0].Descriptor()
+}
+
+func (TenantStatus) Type() protoreflect.EnumType {
+	return &file_rag_v1_tenant_proto_enumTypes[0]
+}
+
+func (x TenantStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantStatus.Descriptor instead.
+func (TenantStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{0}
+}
+
+// ReindexStatus represents the progress of a reindex job
+type ReindexStatus int32
+
+const (
+	ReindexStatus_REINDEX_STATUS_UNSPECIFIED ReindexStatus = 0
+	ReindexStatus_REINDEX_STATUS_PENDING     ReindexStatus = 1
+	ReindexStatus_REINDEX_STATUS_RUNNING     ReindexStatus = 2
+	ReindexStatus_REINDEX_STATUS_COMPLETED   ReindexStatus = 3
+	ReindexStatus_REINDEX_STATUS_FAILED      ReindexStatus = 4
+)
+
+// Enum value maps for ReindexStatus.
+var (
+	ReindexStatus_name = map[int32]string{
+		0: "REINDEX_STATUS_UNSPECIFIED",
+		1: "REINDEX_STATUS_PENDING",
+		2: "REINDEX_STATUS_RUNNING",
+		3: "REINDEX_STATUS_COMPLETED",
+		4: "REINDEX_STATUS_FAILED",
+	}
+	ReindexStatus_value = map[string]int32{
+		"REINDEX_STATUS_UNSPECIFIED": 0,
+		"REINDEX_STATUS_PENDING":     1,
+		"REINDEX_STATUS_RUNNING":     2,
+		"REINDEX_STATUS_COMPLETED":   3,
+		"REINDEX_STATUS_FAILED":      4,
+	}
+)
+
+func (x ReindexStatus) Enum() *ReindexStatus {
+	p := new(ReindexStatus)
+	*p = x
+	return p
+}
+
+func (x ReindexStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReindexStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rag_v1_tenant_proto_enumTypes[1].Descriptor()
+}
+
+func (ReindexStatus) Type() protoreflect.EnumType {
+	return &file_rag_v1_tenant_proto_enumTypes[1]
+}
+
+func (x ReindexStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReindexStatus.Descriptor instead.
+func (ReindexStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{1}
+}
+
 type Tenant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ApiKey        string                 `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
-	Config        *TenantConfig          `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
-	Usage         *TenantUsage           `protobuf:"bytes,5,opt,name=usage,proto3" json:"usage,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ApiKey          string                 `protobuf:"bytes,3,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Config          *TenantConfig          `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	Usage           *TenantUsage           `protobuf:"bytes,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Status          TenantStatus           `protobuf:"varint,8,opt,name=status,proto3,enum=rag.v1.TenantStatus" json:"status,omitempty"`
+	StatusReason    string                 `protobuf:"bytes,9,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"` // Why the status was set; included in rejected requests' errors
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Tenant) Reset() {
@@ -116,11 +238,33 @@ func (x *Tenant) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Tenant) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
+}
+
+func (x *Tenant) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Tenant) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
 type TenantConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Embedding model to use (e.g., "nomic-embed-text", "multilingual-e5-large")
 	EmbeddingModel string `protobuf:"bytes,1,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
-	// LLM model for generation (e.g., "llama3.2")
+	// LLM model for generation (e.g., "llama3.2", or "anthropic/claude-3-5-haiku-latest"
+	// to select a provider)
 	LlmModel string `protobuf:"bytes,2,opt,name=llm_model,json=llmModel,proto3" json:"llm_model,omitempty"`
 	// Chunking configuration
 	Chunker *ChunkerConfig `protobuf:"bytes,3,opt,name=chunker,proto3" json:"chunker,omitempty"`
@@ -130,11 +274,75 @@ type TenantConfig struct {
 	MinScore float32 `protobuf:"fixed32,5,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
 	// Default system prompt for RAG queries
 	SystemPrompt string `protobuf:"bytes,6,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
-	// Enable LLM-based reranking for improved relevance.
-	// Trade-off: +1-3s latency, ~2x LLM cost, but better accuracy.
+	// Enable reranking for improved relevance, by the reranker rerank.provider
+	// selects. Trade-off with the LLM reranker: +1-3s latency, ~2x LLM cost,
+	// but better accuracy.
 	RerankerEnabled bool `protobuf:"varint,7,opt,name=reranker_enabled,json=rerankerEnabled,proto3" json:"reranker_enabled,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Embedding vector dimension. Resolved from the embedding model when unset;
+	// set explicitly for models the server does not know. A value below the
+	// model's dimension truncates and renormalizes vectors (for Matryoshka
+	// models such as nomic-embed-text), trading a little recall for memory.
+	EmbeddingDimension int32 `protobuf:"varint,8,opt,name=embedding_dimension,json=embeddingDimension,proto3" json:"embedding_dimension,omitempty"`
+	// Models tried in order when llm_model fails with a server error or timeout.
+	// Models may name a provider, e.g. "anthropic/claude-3-5-haiku-latest".
+	LlmFallbackModels []string `protobuf:"bytes,9,rep,name=llm_fallback_models,json=llmFallbackModels,proto3" json:"llm_fallback_models,omitempty"`
+	// How the tenant's vectors are stored. Fixed at creation; change it with
+	// ReindexTenant.
+	VectorStorage *VectorStorageConfig `protobuf:"bytes,10,opt,name=vector_storage,json=vectorStorage,proto3" json:"vector_storage,omitempty"`
+	// Keep each document's original content for RechunkDocument and
+	// GetDocumentContent. Unset uses the server default.
+	StoreContent *bool `protobuf:"varint,11,opt,name=store_content,json=storeContent,proto3,oneof" json:"store_content,omitempty"`
+	// Adjust retrieval scores by document age and priority
+	ScoreBoost *ScoreBoostConfig `protobuf:"bytes,12,opt,name=score_boost,json=scoreBoost,proto3" json:"score_boost,omitempty"`
+	// Active prompt template version; 0 uses the built-in layout. Output only;
+	// change it with PromptService.ActivatePromptTemplate.
+	PromptTemplateVersion int32 `protobuf:"varint,13,opt,name=prompt_template_version,json=promptTemplateVersion,proto3" json:"prompt_template_version,omitempty"`
+	// ISO 639-1 code of the language to answer in, which also localizes the
+	// prompt's headings. Empty or "auto" detects it from each query.
+	Language string `protobuf:"bytes,14,opt,name=language,proto3" json:"language,omitempty"`
+	// What a query does when retrieval finds nothing relevant
+	NoAnswer *NoAnswerConfig `protobuf:"bytes,15,opt,name=no_answer,json=noAnswer,proto3" json:"no_answer,omitempty"`
+	// Filters screening the tenant's queries, retrieved chunks and answers
+	Guardrails *GuardrailConfig `protobuf:"bytes,16,opt,name=guardrails,proto3" json:"guardrails,omitempty"`
+	// Detect personal data in ingested chunks. Applies to documents ingested
+	// or re-chunked after it is set.
+	Pii *PIIConfig `protobuf:"bytes,17,opt,name=pii,proto3" json:"pii,omitempty"`
+	// How much of a session's conversation the prompt includes
+	History *HistoryConfig `protobuf:"bytes,18,opt,name=history,proto3" json:"history,omitempty"`
+	// Limits of agentic queries (QueryOptions.agentic)
+	Agent *AgentConfig `protobuf:"bytes,19,opt,name=agent,proto3" json:"agent,omitempty"`
+	// Actions the LLM may ask the client app to take instead of answering,
+	// returned as QueryResponse.tool_call (at most 16). A non-empty list
+	// replaces the tenant's tools when updated. With tools, QueryStream sends
+	// the answer as one token, since whether the LLM calls a tool is known
+	// only once it finishes.
+	Tools []*ToolDefinition `protobuf:"bytes,20,rep,name=tools,proto3" json:"tools,omitempty"`
+	// Store one vector for identical chunks across the tenant's documents,
+	// such as headers and footers of crawled pages. Retrieved chunks list
+	// the other documents containing them in shared_document_ids; tag and
+	// collection filters match a shared chunk by the document that first
+	// contained it. Applies to documents ingested after it is enabled.
+	DedupChunks *bool `protobuf:"varint,21,opt,name=dedup_chunks,json=dedupChunks,proto3,oneof" json:"dedup_chunks,omitempty"`
+	// Detection of documents nearly identical to one already ingested
+	NearDuplicates *NearDuplicateConfig `protobuf:"bytes,22,opt,name=near_duplicates,json=nearDuplicates,proto3" json:"near_duplicates,omitempty"`
+	// Headless-browser rendering of IngestURL requests with use_headless
+	Headless *HeadlessConfig `protobuf:"bytes,23,opt,name=headless,proto3" json:"headless,omitempty"`
+	// Reranker, calibration and relevance threshold of reranker scores
+	// (with reranker_enabled)
+	Rerank *RerankConfig `protobuf:"bytes,24,opt,name=rerank,proto3" json:"rerank,omitempty"`
+	// How retrieved chunks repeating a higher-ranked one are found and
+	// dropped before prompting
+	DuplicateResults *DuplicateResultConfig `protobuf:"bytes,25,opt,name=duplicate_results,json=duplicateResults,proto3" json:"duplicate_results,omitempty"`
+	// Extraction of entities and relations from chunks, for queries with
+	// strategy=graph
+	KnowledgeGraph *KnowledgeGraphConfig `protobuf:"bytes,26,opt,name=knowledge_graph,json=knowledgeGraph,proto3" json:"knowledge_graph,omitempty"`
+	// Trees of chunk summaries stored beside the chunks, for broad questions
+	SummaryIndex *SummaryIndexConfig `protobuf:"bytes,27,opt,name=summary_index,json=summaryIndex,proto3" json:"summary_index,omitempty"`
+	// How text is split into terms for keyword search and hybrid search's
+	// sparse vectors
+	TextAnalysis  *TextAnalysisConfig `protobuf:"bytes,28,opt,name=text_analysis,json=textAnalysis,proto3" json:"text_analysis,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TenantConfig) Reset() {
@@ -216,34 +424,193 @@ func (x *TenantConfig) GetRerankerEnabled() bool {
 	return false
 }
 
-type ChunkerConfig struct {
+func (x *TenantConfig) GetEmbeddingDimension() int32 {
+	if x != nil {
+		return x.EmbeddingDimension
+	}
+	return 0
+}
+
+func (x *TenantConfig) GetLlmFallbackModels() []string {
+	if x != nil {
+		return x.LlmFallbackModels
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetVectorStorage() *VectorStorageConfig {
+	if x != nil {
+		return x.VectorStorage
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetStoreContent() bool {
+	if x != nil && x.StoreContent != nil {
+		return *x.StoreContent
+	}
+	return false
+}
+
+func (x *TenantConfig) GetScoreBoost() *ScoreBoostConfig {
+	if x != nil {
+		return x.ScoreBoost
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetPromptTemplateVersion() int32 {
+	if x != nil {
+		return x.PromptTemplateVersion
+	}
+	return 0
+}
+
+func (x *TenantConfig) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TenantConfig) GetNoAnswer() *NoAnswerConfig {
+	if x != nil {
+		return x.NoAnswer
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetGuardrails() *GuardrailConfig {
+	if x != nil {
+		return x.Guardrails
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetPii() *PIIConfig {
+	if x != nil {
+		return x.Pii
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetHistory() *HistoryConfig {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetAgent() *AgentConfig {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetTools() []*ToolDefinition {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetDedupChunks() bool {
+	if x != nil && x.DedupChunks != nil {
+		return *x.DedupChunks
+	}
+	return false
+}
+
+func (x *TenantConfig) GetNearDuplicates() *NearDuplicateConfig {
+	if x != nil {
+		return x.NearDuplicates
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetHeadless() *HeadlessConfig {
+	if x != nil {
+		return x.Headless
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetRerank() *RerankConfig {
+	if x != nil {
+		return x.Rerank
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetDuplicateResults() *DuplicateResultConfig {
+	if x != nil {
+		return x.DuplicateResults
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetKnowledgeGraph() *KnowledgeGraphConfig {
+	if x != nil {
+		return x.KnowledgeGraph
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetSummaryIndex() *SummaryIndexConfig {
+	if x != nil {
+		return x.SummaryIndex
+	}
+	return nil
+}
+
+func (x *TenantConfig) GetTextAnalysis() *TextAnalysisConfig {
+	if x != nil {
+		return x.TextAnalysis
+	}
+	return nil
+}
+
+// TextAnalysisConfig picks the language rules keyword search and sparse
+// vectors use. The default lowercases words split at spaces and punctuation
+// and cuts CJK text into character bigrams. With remove_stopwords or
+// stemming, keyword search over en and de documents uses Postgres's english
+// or german configuration, which does both. Stored chunks keep the rules
+// they were indexed with until ReindexTenant re-indexes them, so reindex
+// after changing these.
+type TextAnalysisConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Chunking method: "semantic", "fixed", "sentence"
-	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
-	// Target chunk size in tokens
-	TargetSize int32 `protobuf:"varint,2,opt,name=target_size,json=targetSize,proto3" json:"target_size,omitempty"`
-	// Maximum chunk size in tokens
-	MaxSize int32 `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
-	// Overlap between chunks in tokens
-	Overlap       int32 `protobuf:"varint,4,opt,name=overlap,proto3" json:"overlap,omitempty"`
+	// ISO 639-1 language of the tenant's documents. en, de and ja have
+	// stopword lists; en and de have stemmers. Other languages get the
+	// default rules.
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	// Drop the language's stopwords ("the", "und", particles such as "の")
+	RemoveStopwords bool `protobuf:"varint,2,opt,name=remove_stopwords,json=removeStopwords,proto3" json:"remove_stopwords,omitempty"`
+	// Words dropped in addition to the language's, up to 500
+	Stopwords []string `protobuf:"bytes,3,rep,name=stopwords,proto3" json:"stopwords,omitempty"`
+	// Reduce words to their stems: "queries" matches "query" and "Verträge"
+	// matches "Vertrag"
+	Stemming bool `protobuf:"varint,4,opt,name=stemming,proto3" json:"stemming,omitempty"`
+	// Keep letter case, so "Go" does not match "go"
+	CaseSensitive bool `protobuf:"varint,5,opt,name=case_sensitive,json=caseSensitive,proto3" json:"case_sensitive,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChunkerConfig) Reset() {
-	*x = ChunkerConfig{}
+func (x *TextAnalysisConfig) Reset() {
+	*x = TextAnalysisConfig{}
 	mi := &file_rag_v1_tenant_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChunkerConfig) String() string {
+func (x *TextAnalysisConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChunkerConfig) ProtoMessage() {}
+func (*TextAnalysisConfig) ProtoMessage() {}
 
-func (x *ChunkerConfig) ProtoReflect() protoreflect.Message {
+func (x *TextAnalysisConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_v1_tenant_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -255,62 +622,78 @@ func (x *ChunkerConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChunkerConfig.ProtoReflect.Descriptor instead.
-func (*ChunkerConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use TextAnalysisConfig.ProtoReflect.Descriptor instead.
+func (*TextAnalysisConfig) Descriptor() ([]byte, []int) {
 	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ChunkerConfig) GetMethod() string {
+func (x *TextAnalysisConfig) GetLanguage() string {
 	if x != nil {
-		return x.Method
+		return x.Language
 	}
 	return ""
 }
 
-func (x *ChunkerConfig) GetTargetSize() int32 {
+func (x *TextAnalysisConfig) GetRemoveStopwords() bool {
 	if x != nil {
-		return x.TargetSize
+		return x.RemoveStopwords
 	}
-	return 0
+	return false
 }
 
-func (x *ChunkerConfig) GetMaxSize() int32 {
+func (x *TextAnalysisConfig) GetStopwords() []string {
 	if x != nil {
-		return x.MaxSize
+		return x.Stopwords
 	}
-	return 0
+	return nil
 }
 
-func (x *ChunkerConfig) GetOverlap() int32 {
+func (x *TextAnalysisConfig) GetStemming() bool {
 	if x != nil {
-		return x.Overlap
+		return x.Stemming
 	}
-	return 0
+	return false
 }
 
-type TenantUsage struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	DocumentCount   int32                  `protobuf:"varint,1,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
-	ChunkCount      int32                  `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
-	QueryCountMonth int64                  `protobuf:"varint,3,opt,name=query_count_month,json=queryCountMonth,proto3" json:"query_count_month,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *TextAnalysisConfig) GetCaseSensitive() bool {
+	if x != nil {
+		return x.CaseSensitive
+	}
+	return false
 }
 
-func (x *TenantUsage) Reset() {
-	*x = TenantUsage{}
+// DuplicateResultConfig picks the similarity measure query-time
+// deduplication uses
+type DuplicateResultConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How chunks are compared:
+	//   ""        - overlap of their word sets (Jaccard; default)
+	//   embedding - cosine similarity of their vectors, which catches
+	//               paraphrases and doesn't merge short code chunks
+	//               sharing keywords
+	//   off       - keep every chunk
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Similarity at which a chunk is a duplicate (0.0 - 1.0); 0 uses 0.7
+	// for Jaccard and 0.95 for embedding
+	Threshold     float32 `protobuf:"fixed32,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateResultConfig) Reset() {
+	*x = DuplicateResultConfig{}
 	mi := &file_rag_v1_tenant_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TenantUsage) String() string {
+func (x *DuplicateResultConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TenantUsage) ProtoMessage() {}
+func (*DuplicateResultConfig) ProtoMessage() {}
 
-func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+func (x *DuplicateResultConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_rag_v1_tenant_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -322,58 +705,1767 @@ func (x *TenantUsage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
-func (*TenantUsage) Descriptor() ([]byte, []int) {
+// Deprecated: Use DuplicateResultConfig.ProtoReflect.Descriptor instead.
+func (*DuplicateResultConfig) Descriptor() ([]byte, []int) {
 	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TenantUsage) GetDocumentCount() int32 {
+func (x *DuplicateResultConfig) GetMethod() string {
 	if x != nil {
-		return x.DocumentCount
+		return x.Method
 	}
-	return 0
+	return ""
 }
 
-func (x *TenantUsage) GetChunkCount() int32 {
+func (x *DuplicateResultConfig) GetThreshold() float32 {
 	if x != nil {
-		return x.ChunkCount
+		return x.Threshold
 	}
 	return 0
 }
 
-func (x *TenantUsage) GetQueryCountMonth() int64 {
+// KnowledgeGraphConfig builds a graph of the entities a tenant's documents
+// mention and the relations they state, extracted by the server's graph
+// model. Graph-strategy queries search for the query together with the
+// relations of the entities it names.
+type KnowledgeGraphConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Extract a graph from documents ingested or re-chunked from now on
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Relations a graph query is expanded with; 0 uses 10
+	MaxRelations int32 `protobuf:"varint,2,opt,name=max_relations,json=maxRelations,proto3" json:"max_relations,omitempty"`
+	// Entities of the query expanded from; 0 uses 5
+	MaxQueryEntities int32 `protobuf:"varint,3,opt,name=max_query_entities,json=maxQueryEntities,proto3" json:"max_query_entities,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *KnowledgeGraphConfig) Reset() {
+	*x = KnowledgeGraphConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnowledgeGraphConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnowledgeGraphConfig) ProtoMessage() {}
+
+func (x *KnowledgeGraphConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[4]
 	if x != nil {
-		return x.QueryCountMonth
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnowledgeGraphConfig.ProtoReflect.Descriptor instead.
+func (*KnowledgeGraphConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *KnowledgeGraphConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *KnowledgeGraphConfig) GetMaxRelations() int32 {
+	if x != nil {
+		return x.MaxRelations
+	}
+	return 0
+}
+
+func (x *KnowledgeGraphConfig) GetMaxQueryEntities() int32 {
+	if x != nil {
+		return x.MaxQueryEntities
+	}
+	return 0
+}
+
+// SummaryIndexConfig builds a RAPTOR-style tree of summaries of each
+// document: chunks are clustered by embedding similarity, each cluster is
+// summarized by the server's summary model, and the summaries are clustered
+// and summarized again until one is left. The summaries are searched with
+// the chunks, so broad questions ("what does this product do?") retrieve
+// them instead of scattered details. They carry type=summary and
+// summary_level metadata. Reindexing drops them until documents are
+// re-chunked.
+type SummaryIndexConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Build trees for documents ingested or re-chunked from now on
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Nodes summarized together (2-20); 0 uses the server default
+	ClusterSize int32 `protobuf:"varint,2,opt,name=cluster_size,json=clusterSize,proto3" json:"cluster_size,omitempty"`
+	// Levels of summaries above the chunks (up to 5); 0 uses the server default
+	MaxLevels     int32 `protobuf:"varint,3,opt,name=max_levels,json=maxLevels,proto3" json:"max_levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummaryIndexConfig) Reset() {
+	*x = SummaryIndexConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummaryIndexConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummaryIndexConfig) ProtoMessage() {}
+
+func (x *SummaryIndexConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummaryIndexConfig.ProtoReflect.Descriptor instead.
+func (*SummaryIndexConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SummaryIndexConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SummaryIndexConfig) GetClusterSize() int32 {
+	if x != nil {
+		return x.ClusterSize
+	}
+	return 0
+}
+
+func (x *SummaryIndexConfig) GetMaxLevels() int32 {
+	if x != nil {
+		return x.MaxLevels
+	}
+	return 0
+}
+
+// RerankConfig drops chunks the reranker judges irrelevant, instead of
+// always filling the prompt with top_k of them
+type RerankConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How raw reranker scores are mapped onto 0-1 before the threshold:
+	//   ""      - as they are (default)
+	//   min_max - rescaled per query so the best is 1 and the worst 0
+	//   sigmoid - 1 / (1 + e^-((score - sigmoid_center) / sigmoid_scale)),
+	//             for cross-encoders returning logits
+	Calibration   string  `protobuf:"bytes,1,opt,name=calibration,proto3" json:"calibration,omitempty"`
+	SigmoidCenter float32 `protobuf:"fixed32,2,opt,name=sigmoid_center,json=sigmoidCenter,proto3" json:"sigmoid_center,omitempty"`
+	// Default 1; smaller values sharpen the curve
+	SigmoidScale float32 `protobuf:"fixed32,3,opt,name=sigmoid_scale,json=sigmoidScale,proto3" json:"sigmoid_scale,omitempty"`
+	// Chunks whose calibrated score is below this are dropped (0.0 - 1.0);
+	// 0 keeps them all. A query may end up with no chunks.
+	Threshold float32 `protobuf:"fixed32,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Reranker scoring the candidates: "llm" (an LLM prompt, slow) or
+	// "cohere" (Cohere's hosted Rerank API). Empty uses the server's
+	// RERANKER_DEFAULT_PROVIDER; a provider the server isn't configured
+	// for skips reranking.
+	Provider      string `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RerankConfig) Reset() {
+	*x = RerankConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RerankConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerankConfig) ProtoMessage() {}
+
+func (x *RerankConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerankConfig.ProtoReflect.Descriptor instead.
+func (*RerankConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RerankConfig) GetCalibration() string {
+	if x != nil {
+		return x.Calibration
+	}
+	return ""
+}
+
+func (x *RerankConfig) GetSigmoidCenter() float32 {
+	if x != nil {
+		return x.SigmoidCenter
+	}
+	return 0
+}
+
+func (x *RerankConfig) GetSigmoidScale() float32 {
+	if x != nil {
+		return x.SigmoidScale
+	}
+	return 0
+}
+
+func (x *RerankConfig) GetThreshold() float32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *RerankConfig) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+// HeadlessConfig lets a tenant ingest pages that build their content with
+// JavaScript, rendered by the server's headless browser service
+type HeadlessConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether IngestURL may render pages (use_headless); without it such
+	// requests fail with FAILED_PRECONDITION
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Longest a page may take to load (default 30, at most 120)
+	TimeoutSeconds int32 `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HeadlessConfig) Reset() {
+	*x = HeadlessConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadlessConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadlessConfig) ProtoMessage() {}
+
+func (x *HeadlessConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadlessConfig.ProtoReflect.Descriptor instead.
+func (*HeadlessConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HeadlessConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *HeadlessConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// NearDuplicateConfig flags new documents whose text nearly matches a ready
+// document of the tenant's, such as pages differing only in dates or ads,
+// by comparing SimHash fingerprints
+type NearDuplicateConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What to do with a near-duplicate:
+	//   ""   - nothing (default)
+	//   flag - ingest it, with Document.near_duplicate_of set
+	//   skip - don't index it; it fails with near_duplicate_of set
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Most bits of the 64-bit fingerprints that may differ (default 3, at
+	// most 16)
+	MaxDistance   int32 `protobuf:"varint,2,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearDuplicateConfig) Reset() {
+	*x = NearDuplicateConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearDuplicateConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearDuplicateConfig) ProtoMessage() {}
+
+func (x *NearDuplicateConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearDuplicateConfig.ProtoReflect.Descriptor instead.
+func (*NearDuplicateConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NearDuplicateConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *NearDuplicateConfig) GetMaxDistance() int32 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+// ToolDefinition describes an action a client app can take, such as
+// creating a support ticket
+type ToolDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique name of letters, digits, "_" and "-", at most 64 characters
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// When the LLM should call the tool
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// JSON schema of the tool's arguments, an object; empty takes none
+	Parameters    string `protobuf:"bytes,3,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolDefinition) Reset() {
+	*x = ToolDefinition{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolDefinition) ProtoMessage() {}
+
+func (x *ToolDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolDefinition.ProtoReflect.Descriptor instead.
+func (*ToolDefinition) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ToolDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ToolDefinition) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+// AgentConfig bounds agentic queries
+type AgentConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most retrieval tool calls the LLM may make before answering (default 3,
+	// at most 10)
+	MaxSteps      int32 `protobuf:"varint,1,opt,name=max_steps,json=maxSteps,proto3" json:"max_steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentConfig) Reset() {
+	*x = AgentConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfig) ProtoMessage() {}
+
+func (x *AgentConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfig.ProtoReflect.Descriptor instead.
+func (*AgentConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AgentConfig) GetMaxSteps() int32 {
+	if x != nil {
+		return x.MaxSteps
+	}
+	return 0
+}
+
+// HistoryConfig controls the conversation history of session queries
+type HistoryConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recent messages included in the prompt (default 10, at most 20)
+	Window int32 `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	// Summarize messages older than the window with the LLM, and include the
+	// summary in the prompt instead of dropping them. The summary is updated
+	// in the background after each answer.
+	Summarize     bool `protobuf:"varint,2,opt,name=summarize,proto3" json:"summarize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryConfig) Reset() {
+	*x = HistoryConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryConfig) ProtoMessage() {}
+
+func (x *HistoryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryConfig.ProtoReflect.Descriptor instead.
+func (*HistoryConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HistoryConfig) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *HistoryConfig) GetSummarize() bool {
+	if x != nil {
+		return x.Summarize
+	}
+	return false
+}
+
+// PIIConfig controls detection of emails, phone numbers, US Social Security
+// numbers and payment card numbers during ingestion
+type PIIConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "" or "off" (default), "tag" or "redact"
+	//   tag    - record the kinds found in the chunk metadata pii_types
+	//            (comma-separated) and their number in pii_count
+	//   redact - also replace each match in the chunk with a placeholder such
+	//            as [EMAIL]. Stored original content is kept as ingested;
+	//            disable store_content to keep only redacted chunks.
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Kinds to detect: "email", "phone", "ssn", "credit_card"; empty detects all
+	Kinds         []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PIIConfig) Reset() {
+	*x = PIIConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PIIConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PIIConfig) ProtoMessage() {}
+
+func (x *PIIConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PIIConfig.ProtoReflect.Descriptor instead.
+func (*PIIConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PIIConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *PIIConfig) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+// GuardrailConfig names the built-in filters run at each stage of a query:
+// "injection" (prompt-injection patterns), "pii" (redacts emails, phone,
+// SSN and card numbers) and "topics" (banned_topics). Filters run in order.
+type GuardrailConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Run on the query; blocking it answers with blocked_message
+	Input []string `protobuf:"bytes,1,rep,name=input,proto3" json:"input,omitempty"`
+	// Run on each retrieved chunk; blocked chunks are left out of the prompt
+	Context []string `protobuf:"bytes,2,rep,name=context,proto3" json:"context,omitempty"`
+	// Run on the answer; blocking it replaces it with blocked_message. Streamed
+	// answers are then sent as one token once checked.
+	Output []string `protobuf:"bytes,3,rep,name=output,proto3" json:"output,omitempty"`
+	// Phrases the topics filter blocks, matched case-insensitively
+	BannedTopics []string `protobuf:"bytes,4,rep,name=banned_topics,json=bannedTopics,proto3" json:"banned_topics,omitempty"`
+	// Answer given when a query or answer is blocked; empty uses a localized
+	// "I can't help with that request."
+	BlockedMessage string `protobuf:"bytes,5,opt,name=blocked_message,json=blockedMessage,proto3" json:"blocked_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GuardrailConfig) Reset() {
+	*x = GuardrailConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GuardrailConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuardrailConfig) ProtoMessage() {}
+
+func (x *GuardrailConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuardrailConfig.ProtoReflect.Descriptor instead.
+func (*GuardrailConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GuardrailConfig) GetInput() []string {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *GuardrailConfig) GetContext() []string {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *GuardrailConfig) GetOutput() []string {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *GuardrailConfig) GetBannedTopics() []string {
+	if x != nil {
+		return x.BannedTopics
+	}
+	return nil
+}
+
+func (x *GuardrailConfig) GetBlockedMessage() string {
+	if x != nil {
+		return x.BlockedMessage
+	}
+	return ""
+}
+
+// NoAnswerConfig controls queries that retrieval finds no relevant context for
+type NoAnswerConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A query whose best vector store score is below this has no context;
+	// 0 only treats queries without results as having none
+	MinTopScore float32 `protobuf:"fixed32,1,opt,name=min_top_score,json=minTopScore,proto3" json:"min_top_score,omitempty"`
+	// Answer with the message instead of calling the LLM
+	SkipLlm bool `protobuf:"varint,2,opt,name=skip_llm,json=skipLlm,proto3" json:"skip_llm,omitempty"`
+	// Answer given when the LLM is skipped; empty uses a localized
+	// "The documents don't cover this."
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoAnswerConfig) Reset() {
+	*x = NoAnswerConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoAnswerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoAnswerConfig) ProtoMessage() {}
+
+func (x *NoAnswerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoAnswerConfig.ProtoReflect.Descriptor instead.
+func (*NoAnswerConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NoAnswerConfig) GetMinTopScore() float32 {
+	if x != nil {
+		return x.MinTopScore
+	}
+	return 0
+}
+
+func (x *NoAnswerConfig) GetSkipLlm() bool {
+	if x != nil {
+		return x.SkipLlm
+	}
+	return false
+}
+
+func (x *NoAnswerConfig) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ScoreBoostConfig re-scores retrieved chunks after search and reranking
+type ScoreBoostConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Age in days at which the decaying share of a score halves; 0 disables
+	// recency decay
+	RecencyHalfLifeDays float32 `protobuf:"fixed32,1,opt,name=recency_half_life_days,json=recencyHalfLifeDays,proto3" json:"recency_half_life_days,omitempty"`
+	// Share of the score that never decays (0.0 - 1.0); 0 decays it fully
+	RecencyFloor float32 `protobuf:"fixed32,2,opt,name=recency_floor,json=recencyFloor,proto3" json:"recency_floor,omitempty"`
+	// Metadata key holding the document date (YYYY-MM-DD or RFC 3339).
+	// Default "published_at", falling back to "ingested_at".
+	DateField string `protobuf:"bytes,3,opt,name=date_field,json=dateField,proto3" json:"date_field,omitempty"`
+	// Metadata key holding a numeric priority; scores are multiplied by
+	// 1 + priority_weight * priority
+	PriorityField  string  `protobuf:"bytes,4,opt,name=priority_field,json=priorityField,proto3" json:"priority_field,omitempty"`
+	PriorityWeight float32 `protobuf:"fixed32,5,opt,name=priority_weight,json=priorityWeight,proto3" json:"priority_weight,omitempty"`
+	// Favors crawled pages close to the crawl's root URL: scores are divided
+	// by 1 + depth_weight * depth, the link hops recorded in "crawl_depth".
+	// 0 disables it; documents that weren't crawled are unaffected.
+	DepthWeight float32 `protobuf:"fixed32,6,opt,name=depth_weight,json=depthWeight,proto3" json:"depth_weight,omitempty"`
+	// Favors chunks in preferred_language (ISO 639-1): scores of chunks in
+	// another language are divided by 1 + language_weight. Chunks whose
+	// language is unknown are unaffected.
+	PreferredLanguage string  `protobuf:"bytes,7,opt,name=preferred_language,json=preferredLanguage,proto3" json:"preferred_language,omitempty"`
+	LanguageWeight    float32 `protobuf:"fixed32,8,opt,name=language_weight,json=languageWeight,proto3" json:"language_weight,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ScoreBoostConfig) Reset() {
+	*x = ScoreBoostConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreBoostConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBoostConfig) ProtoMessage() {}
+
+func (x *ScoreBoostConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBoostConfig.ProtoReflect.Descriptor instead.
+func (*ScoreBoostConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ScoreBoostConfig) GetRecencyHalfLifeDays() float32 {
+	if x != nil {
+		return x.RecencyHalfLifeDays
+	}
+	return 0
+}
+
+func (x *ScoreBoostConfig) GetRecencyFloor() float32 {
+	if x != nil {
+		return x.RecencyFloor
+	}
+	return 0
+}
+
+func (x *ScoreBoostConfig) GetDateField() string {
+	if x != nil {
+		return x.DateField
+	}
+	return ""
+}
+
+func (x *ScoreBoostConfig) GetPriorityField() string {
+	if x != nil {
+		return x.PriorityField
+	}
+	return ""
+}
+
+func (x *ScoreBoostConfig) GetPriorityWeight() float32 {
+	if x != nil {
+		return x.PriorityWeight
+	}
+	return 0
+}
+
+func (x *ScoreBoostConfig) GetDepthWeight() float32 {
+	if x != nil {
+		return x.DepthWeight
+	}
+	return 0
+}
+
+func (x *ScoreBoostConfig) GetPreferredLanguage() string {
+	if x != nil {
+		return x.PreferredLanguage
+	}
+	return ""
+}
+
+func (x *ScoreBoostConfig) GetLanguageWeight() float32 {
+	if x != nil {
+		return x.LanguageWeight
+	}
+	return 0
+}
+
+type VectorStorageConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Vector quantization: "" (none), "scalar" (int8, ~4x less memory) or
+	// "binary" (1 bit per dimension, ~32x less memory; for 1024+ dimensions).
+	// Quantized vectors are kept in RAM and rescored with the originals.
+	Quantization string `protobuf:"bytes,1,opt,name=quantization,proto3" json:"quantization,omitempty"`
+	// Keep original vectors on disk (memory-mapped) instead of in RAM
+	OnDiskVectors bool `protobuf:"varint,2,opt,name=on_disk_vectors,json=onDiskVectors,proto3" json:"on_disk_vectors,omitempty"`
+	// Keep payloads (chunk text and metadata) on disk instead of in RAM
+	OnDiskPayload bool `protobuf:"varint,3,opt,name=on_disk_payload,json=onDiskPayload,proto3" json:"on_disk_payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorStorageConfig) Reset() {
+	*x = VectorStorageConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorStorageConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorStorageConfig) ProtoMessage() {}
+
+func (x *VectorStorageConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorStorageConfig.ProtoReflect.Descriptor instead.
+func (*VectorStorageConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VectorStorageConfig) GetQuantization() string {
+	if x != nil {
+		return x.Quantization
+	}
+	return ""
+}
+
+func (x *VectorStorageConfig) GetOnDiskVectors() bool {
+	if x != nil {
+		return x.OnDiskVectors
+	}
+	return false
+}
+
+func (x *VectorStorageConfig) GetOnDiskPayload() bool {
+	if x != nil {
+		return x.OnDiskPayload
+	}
+	return false
+}
+
+type ChunkerConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chunking method: "semantic", "fixed", "sentence", "tabular".
+	// "tabular" parses CSV content and emits one chunk per row group.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Target chunk size in tokens
+	TargetSize int32 `protobuf:"varint,2,opt,name=target_size,json=targetSize,proto3" json:"target_size,omitempty"`
+	// Maximum chunk size in tokens
+	MaxSize int32 `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// Overlap between chunks in tokens; see overlap_percent
+	Overlap int32 `protobuf:"varint,4,opt,name=overlap,proto3" json:"overlap,omitempty"`
+	// Rows per chunk for tabular content (CSV, spreadsheets); default 1
+	RowsPerChunk int32 `protobuf:"varint,5,opt,name=rows_per_chunk,json=rowsPerChunk,proto3" json:"rows_per_chunk,omitempty"`
+	// ISO 639-1 language of the documents, whose abbreviations ("z.B.",
+	// "bzw.") do not end sentences; en, de, fr and es are known, others and
+	// empty use English's
+	Language string `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	// More abbreviations whose period does not end a sentence, e.g. "approx."
+	Abbreviations []string `protobuf:"bytes,7,rep,name=abbreviations,proto3" json:"abbreviations,omitempty"`
+	// Overlap between chunks as a percentage (1-50) of target_size, instead
+	// of overlap, which it wins over when both are set
+	OverlapPercent int32 `protobuf:"varint,8,opt,name=overlap_percent,json=overlapPercent,proto3" json:"overlap_percent,omitempty"`
+	// How semantic chunks render markdown tables: "markdown" (default) as
+	// written, or "key_value" with each row as "column: value" lines. Tables
+	// over max_size are split into fragments that repeat the header row.
+	TableFormat string `protobuf:"bytes,9,opt,name=table_format,json=tableFormat,proto3" json:"table_format,omitempty"`
+	// Template over a chunk's metadata whose rendering is prepended to each
+	// chunk before embedding, replacing the "[Section: ...]" prefix, e.g.
+	// "Title: {{.title}} | Section: {{.section_path}}\n". Only field lookups
+	// are allowed. Metadata a chunk lacks renders empty.
+	ContextTemplate string `protobuf:"bytes,10,opt,name=context_template,json=contextTemplate,proto3" json:"context_template,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChunkerConfig) Reset() {
+	*x = ChunkerConfig{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChunkerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChunkerConfig) ProtoMessage() {}
+
+func (x *ChunkerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChunkerConfig.ProtoReflect.Descriptor instead.
+func (*ChunkerConfig) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChunkerConfig) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ChunkerConfig) GetTargetSize() int32 {
+	if x != nil {
+		return x.TargetSize
+	}
+	return 0
+}
+
+func (x *ChunkerConfig) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *ChunkerConfig) GetOverlap() int32 {
+	if x != nil {
+		return x.Overlap
+	}
+	return 0
+}
+
+func (x *ChunkerConfig) GetRowsPerChunk() int32 {
+	if x != nil {
+		return x.RowsPerChunk
+	}
+	return 0
+}
+
+func (x *ChunkerConfig) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ChunkerConfig) GetAbbreviations() []string {
+	if x != nil {
+		return x.Abbreviations
+	}
+	return nil
+}
+
+func (x *ChunkerConfig) GetOverlapPercent() int32 {
+	if x != nil {
+		return x.OverlapPercent
+	}
+	return 0
+}
+
+func (x *ChunkerConfig) GetTableFormat() string {
+	if x != nil {
+		return x.TableFormat
+	}
+	return ""
+}
+
+func (x *ChunkerConfig) GetContextTemplate() string {
+	if x != nil {
+		return x.ContextTemplate
+	}
+	return ""
+}
+
+type TenantUsage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DocumentCount   int32                  `protobuf:"varint,1,opt,name=document_count,json=documentCount,proto3" json:"document_count,omitempty"`
+	ChunkCount      int32                  `protobuf:"varint,2,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	QueryCountMonth int64                  `protobuf:"varint,3,opt,name=query_count_month,json=queryCountMonth,proto3" json:"query_count_month,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TenantUsage) Reset() {
+	*x = TenantUsage{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsage) ProtoMessage() {}
+
+func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
+func (*TenantUsage) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TenantUsage) GetDocumentCount() int32 {
+	if x != nil {
+		return x.DocumentCount
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetQueryCountMonth() int64 {
+	if x != nil {
+		return x.QueryCountMonth
+	}
+	return 0
+}
+
+type CreateTenantRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Config *TenantConfig          `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// Optional: specify a custom ID instead of generating one
+	// Useful for testing and deterministic tenant creation
+	Id            string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTenantRequest) Reset() {
+	*x = CreateTenantRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTenantRequest) ProtoMessage() {}
+
+func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
+func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateTenantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTenantRequest) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantRequest) Reset() {
+	*x = GetTenantRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantRequest) ProtoMessage() {}
+
+func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTenantsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTenantsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+func (x *ListTenantsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Config        *TenantConfig          `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantRequest) Reset() {
+	*x = UpdateTenantRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantRequest) ProtoMessage() {}
+
+func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTenantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTenantRequest) GetConfig() *TenantConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type DeleteTenantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantRequest) Reset() {
+	*x = DeleteTenantRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantRequest) ProtoMessage() {}
+
+func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteTenantRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTenantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantResponse) Reset() {
+	*x = DeleteTenantResponse{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantResponse) ProtoMessage() {}
+
+func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteTenantResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RegenerateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateAPIKeyRequest) Reset() {
+	*x = RegenerateAPIKeyRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateAPIKeyRequest) ProtoMessage() {}
+
+func (x *RegenerateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RegenerateAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetTenantStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        TenantStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=rag.v1.TenantStatus" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Optional: shown to the tenant in rejected requests' errors
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantStatusRequest) Reset() {
+	*x = SetTenantStatusRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantStatusRequest) ProtoMessage() {}
+
+func (x *SetTenantStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetTenantStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetTenantStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetTenantStatusRequest) GetStatus() TenantStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TenantStatus_TENANT_STATUS_UNSPECIFIED
+}
+
+func (x *SetTenantStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RegenerateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateAPIKeyResponse) Reset() {
+	*x = RegenerateAPIKeyResponse{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateAPIKeyResponse) ProtoMessage() {}
+
+func (x *RegenerateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RegenerateAPIKeyResponse) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+// APIKey is a tenant API key limited to a set of scopes. The tenant's own key
+// (Tenant.api_key) has every scope.
+type APIKey struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name     string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Scopes: "read" (query and list), "ingest" (add documents and feeds),
+	// "admin" (everything, including deletes and tenant settings)
+	Scopes []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// The key itself, only returned by CreateAPIKey
+	ApiKey string `protobuf:"bytes,5,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// First characters of the key, to tell keys apart
+	KeyPrefix string                 `protobuf:"bytes,6,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Project the key is bound to; empty for the whole tenant
+	ProjectId     string `protobuf:"bytes,8,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *APIKey) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
 }
 
-type CreateTenantRequest struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Config *TenantConfig          `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	// Optional: specify a custom ID instead of generating one
-	// Useful for testing and deterministic tenant creation
-	Id            string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+type CreateAPIKeyRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TenantId string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes   []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Binds the key to a project, so it only sees and ingests the project's
+	// documents. Project keys can't have the admin scope.
+	ProjectId     string `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTenantRequest) Reset() {
-	*x = CreateTenantRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[4]
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTenantRequest) String() string {
+func (x *CreateAPIKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTenantRequest) ProtoMessage() {}
+func (*CreateAPIKeyRequest) ProtoMessage() {}
 
-func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[4]
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,54 +2476,61 @@ func (x *CreateTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTenantRequest.ProtoReflect.Descriptor instead.
-func (*CreateTenantRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *CreateTenantRequest) GetName() string {
+func (x *CreateAPIKeyRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *CreateTenantRequest) GetConfig() *TenantConfig {
+func (x *CreateAPIKeyRequest) GetScopes() []string {
 	if x != nil {
-		return x.Config
+		return x.Scopes
 	}
 	return nil
 }
 
-func (x *CreateTenantRequest) GetId() string {
+func (x *CreateAPIKeyRequest) GetProjectId() string {
 	if x != nil {
-		return x.Id
+		return x.ProjectId
 	}
 	return ""
 }
 
-type GetTenantRequest struct {
+type ListAPIKeysRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTenantRequest) Reset() {
-	*x = GetTenantRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[5]
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTenantRequest) String() string {
+func (x *ListAPIKeysRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTenantRequest) ProtoMessage() {}
+func (*ListAPIKeysRequest) ProtoMessage() {}
 
-func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[5]
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -442,41 +2541,40 @@ func (x *GetTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTenantRequest.ProtoReflect.Descriptor instead.
-func (*GetTenantRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *GetTenantRequest) GetId() string {
+func (x *ListAPIKeysRequest) GetTenantId() string {
 	if x != nil {
-		return x.Id
+		return x.TenantId
 	}
 	return ""
 }
 
-type ListTenantsRequest struct {
+type ListAPIKeysResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTenantsRequest) Reset() {
-	*x = ListTenantsRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[6]
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTenantsRequest) String() string {
+func (x *ListAPIKeysResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTenantsRequest) ProtoMessage() {}
+func (*ListAPIKeysResponse) ProtoMessage() {}
 
-func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[6]
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,48 +2585,41 @@ func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
-func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ListTenantsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *ListTenantsRequest) GetPageToken() string {
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
 	if x != nil {
-		return x.PageToken
+		return x.ApiKeys
 	}
-	return ""
+	return nil
 }
 
-type ListTenantsResponse struct {
+type DeleteAPIKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTenantsResponse) Reset() {
-	*x = ListTenantsResponse{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[7]
+func (x *DeleteAPIKeyRequest) Reset() {
+	*x = DeleteAPIKeyRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTenantsResponse) String() string {
+func (x *DeleteAPIKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTenantsResponse) ProtoMessage() {}
+func (*DeleteAPIKeyRequest) ProtoMessage() {}
 
-func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[7]
+func (x *DeleteAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,49 +2630,47 @@ func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
-func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DeleteAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ListTenantsResponse) GetTenants() []*Tenant {
+func (x *DeleteAPIKeyRequest) GetTenantId() string {
 	if x != nil {
-		return x.Tenants
+		return x.TenantId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListTenantsResponse) GetNextPageToken() string {
+func (x *DeleteAPIKeyRequest) GetId() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.Id
 	}
 	return ""
 }
 
-type UpdateTenantRequest struct {
+type DeleteAPIKeyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Config        *TenantConfig          `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateTenantRequest) Reset() {
-	*x = UpdateTenantRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[8]
+func (x *DeleteAPIKeyResponse) Reset() {
+	*x = DeleteAPIKeyResponse{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateTenantRequest) String() string {
+func (x *DeleteAPIKeyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTenantRequest) ProtoMessage() {}
+func (*DeleteAPIKeyResponse) ProtoMessage() {}
 
-func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[8]
+func (x *DeleteAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,54 +2681,48 @@ func (x *UpdateTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTenantRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTenantRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *UpdateTenantRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *UpdateTenantRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use DeleteAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *UpdateTenantRequest) GetConfig() *TenantConfig {
+func (x *DeleteAPIKeyResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Config
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type DeleteTenantRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+type ReindexTenantRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Embedding model to migrate to (defaults to the tenant's current model)
+	EmbeddingModel string `protobuf:"bytes,2,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	// Vector dimension to migrate to, at most the model's own dimension.
+	// Defaults to the current dimension when the model is unchanged, and to
+	// the model's full dimension otherwise.
+	EmbeddingDimension int32 `protobuf:"varint,3,opt,name=embedding_dimension,json=embeddingDimension,proto3" json:"embedding_dimension,omitempty"`
+	// Vector storage for the rebuilt collection (defaults to the current storage)
+	VectorStorage *VectorStorageConfig `protobuf:"bytes,4,opt,name=vector_storage,json=vectorStorage,proto3" json:"vector_storage,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTenantRequest) Reset() {
-	*x = DeleteTenantRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[9]
+func (x *ReindexTenantRequest) Reset() {
+	*x = ReindexTenantRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantRequest) String() string {
+func (x *ReindexTenantRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantRequest) ProtoMessage() {}
+func (*ReindexTenantRequest) ProtoMessage() {}
 
-func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[9]
+func (x *ReindexTenantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,40 +2733,62 @@ func (x *DeleteTenantRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTenantRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ReindexTenantRequest.ProtoReflect.Descriptor instead.
+func (*ReindexTenantRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *DeleteTenantRequest) GetId() string {
+func (x *ReindexTenantRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type DeleteTenantResponse struct {
+func (x *ReindexTenantRequest) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
+}
+
+func (x *ReindexTenantRequest) GetEmbeddingDimension() int32 {
+	if x != nil {
+		return x.EmbeddingDimension
+	}
+	return 0
+}
+
+func (x *ReindexTenantRequest) GetVectorStorage() *VectorStorageConfig {
+	if x != nil {
+		return x.VectorStorage
+	}
+	return nil
+}
+
+type GetReindexJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteTenantResponse) Reset() {
-	*x = DeleteTenantResponse{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[10]
+func (x *GetReindexJobRequest) Reset() {
+	*x = GetReindexJobRequest{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteTenantResponse) String() string {
+func (x *GetReindexJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTenantResponse) ProtoMessage() {}
+func (*GetReindexJobRequest) ProtoMessage() {}
 
-func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[10]
+func (x *GetReindexJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,40 +2799,57 @@ func (x *DeleteTenantResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTenantResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTenantResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetReindexJobRequest.ProtoReflect.Descriptor instead.
+func (*GetReindexJobRequest) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *DeleteTenantResponse) GetSuccess() bool {
+func (x *GetReindexJobRequest) GetTenantId() string {
 	if x != nil {
-		return x.Success
+		return x.TenantId
 	}
-	return false
+	return ""
 }
 
-type RegenerateAPIKeyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetReindexJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
 }
 
-func (x *RegenerateAPIKeyRequest) Reset() {
-	*x = RegenerateAPIKeyRequest{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[11]
+type ReindexJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId       string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Status         ReindexStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=rag.v1.ReindexStatus" json:"status,omitempty"`
+	EmbeddingModel string                 `protobuf:"bytes,4,opt,name=embedding_model,json=embeddingModel,proto3" json:"embedding_model,omitempty"`
+	Dimension      int32                  `protobuf:"varint,5,opt,name=dimension,proto3" json:"dimension,omitempty"`
+	ChunksTotal    int32                  `protobuf:"varint,6,opt,name=chunks_total,json=chunksTotal,proto3" json:"chunks_total,omitempty"`
+	ChunksDone     int32                  `protobuf:"varint,7,opt,name=chunks_done,json=chunksDone,proto3" json:"chunks_done,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Error details if status is FAILED
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReindexJob) Reset() {
+	*x = ReindexJob{}
+	mi := &file_rag_v1_tenant_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RegenerateAPIKeyRequest) String() string {
+func (x *ReindexJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RegenerateAPIKeyRequest) ProtoMessage() {}
+func (*ReindexJob) ProtoMessage() {}
 
-func (x *RegenerateAPIKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[11]
+func (x *ReindexJob) ProtoReflect() protoreflect.Message {
+	mi := &file_rag_v1_tenant_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,67 +2860,93 @@ func (x *RegenerateAPIKeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RegenerateAPIKeyRequest.ProtoReflect.Descriptor instead.
-func (*RegenerateAPIKeyRequest) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ReindexJob.ProtoReflect.Descriptor instead.
+func (*ReindexJob) Descriptor() ([]byte, []int) {
+	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *RegenerateAPIKeyRequest) GetId() string {
+func (x *ReindexJob) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type RegenerateAPIKeyResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ApiKey        string                 `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReindexJob) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
 }
 
-func (x *RegenerateAPIKeyResponse) Reset() {
-	*x = RegenerateAPIKeyResponse{}
-	mi := &file_rag_v1_tenant_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ReindexJob) GetStatus() ReindexStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReindexStatus_REINDEX_STATUS_UNSPECIFIED
 }
 
-func (x *RegenerateAPIKeyResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReindexJob) GetEmbeddingModel() string {
+	if x != nil {
+		return x.EmbeddingModel
+	}
+	return ""
 }
 
-func (*RegenerateAPIKeyResponse) ProtoMessage() {}
+func (x *ReindexJob) GetDimension() int32 {
+	if x != nil {
+		return x.Dimension
+	}
+	return 0
+}
 
-func (x *RegenerateAPIKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rag_v1_tenant_proto_msgTypes[12]
+func (x *ReindexJob) GetChunksTotal() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ChunksTotal
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RegenerateAPIKeyResponse.ProtoReflect.Descriptor instead.
-func (*RegenerateAPIKeyResponse) Descriptor() ([]byte, []int) {
-	return file_rag_v1_tenant_proto_rawDescGZIP(), []int{12}
+func (x *ReindexJob) GetChunksDone() int32 {
+	if x != nil {
+		return x.ChunksDone
+	}
+	return 0
 }
 
-func (x *RegenerateAPIKeyResponse) GetApiKey() string {
+func (x *ReindexJob) GetErrorMessage() string {
 	if x != nil {
-		return x.ApiKey
+		return x.ErrorMessage
 	}
 	return ""
 }
 
+func (x *ReindexJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReindexJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ReindexJob) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
 var File_rag_v1_tenant_proto protoreflect.FileDescriptor
 
 const file_rag_v1_tenant_proto_rawDesc = "" +
 	"\n" +
-	"\x13rag/v1/tenant.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x94\x02\n" +
+	"\x13rag/v1/tenant.proto\x12\x06rag.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xaf\x03\n" +
 	"\x06Tenant\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
@@ -808,7 +2956,11 @@ const file_rag_v1_tenant_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x87\x02\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\x06status\x18\b \x01(\x0e2\x14.rag.v1.TenantStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\t \x01(\tR\fstatusReason\x12F\n" +
+	"\x11status_changed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0fstatusChangedAt\"\x8a\v\n" +
 	"\fTenantConfig\x12'\n" +
 	"\x0fembedding_model\x18\x01 \x01(\tR\x0eembeddingModel\x12\x1b\n" +
 	"\tllm_model\x18\x02 \x01(\tR\bllmModel\x12/\n" +
@@ -816,13 +2968,115 @@ const file_rag_v1_tenant_proto_rawDesc = "" +
 	"\x05top_k\x18\x04 \x01(\x05R\x04topK\x12\x1b\n" +
 	"\tmin_score\x18\x05 \x01(\x02R\bminScore\x12#\n" +
 	"\rsystem_prompt\x18\x06 \x01(\tR\fsystemPrompt\x12)\n" +
-	"\x10reranker_enabled\x18\a \x01(\bR\x0frerankerEnabled\"}\n" +
+	"\x10reranker_enabled\x18\a \x01(\bR\x0frerankerEnabled\x12/\n" +
+	"\x13embedding_dimension\x18\b \x01(\x05R\x12embeddingDimension\x12.\n" +
+	"\x13llm_fallback_models\x18\t \x03(\tR\x11llmFallbackModels\x12B\n" +
+	"\x0evector_storage\x18\n" +
+	" \x01(\v2\x1b.rag.v1.VectorStorageConfigR\rvectorStorage\x12(\n" +
+	"\rstore_content\x18\v \x01(\bH\x00R\fstoreContent\x88\x01\x01\x129\n" +
+	"\vscore_boost\x18\f \x01(\v2\x18.rag.v1.ScoreBoostConfigR\n" +
+	"scoreBoost\x126\n" +
+	"\x17prompt_template_version\x18\r \x01(\x05R\x15promptTemplateVersion\x12\x1a\n" +
+	"\blanguage\x18\x0e \x01(\tR\blanguage\x123\n" +
+	"\tno_answer\x18\x0f \x01(\v2\x16.rag.v1.NoAnswerConfigR\bnoAnswer\x127\n" +
+	"\n" +
+	"guardrails\x18\x10 \x01(\v2\x17.rag.v1.GuardrailConfigR\n" +
+	"guardrails\x12#\n" +
+	"\x03pii\x18\x11 \x01(\v2\x11.rag.v1.PIIConfigR\x03pii\x12/\n" +
+	"\ahistory\x18\x12 \x01(\v2\x15.rag.v1.HistoryConfigR\ahistory\x12)\n" +
+	"\x05agent\x18\x13 \x01(\v2\x13.rag.v1.AgentConfigR\x05agent\x12,\n" +
+	"\x05tools\x18\x14 \x03(\v2\x16.rag.v1.ToolDefinitionR\x05tools\x12&\n" +
+	"\fdedup_chunks\x18\x15 \x01(\bH\x01R\vdedupChunks\x88\x01\x01\x12D\n" +
+	"\x0fnear_duplicates\x18\x16 \x01(\v2\x1b.rag.v1.NearDuplicateConfigR\x0enearDuplicates\x122\n" +
+	"\bheadless\x18\x17 \x01(\v2\x16.rag.v1.HeadlessConfigR\bheadless\x12,\n" +
+	"\x06rerank\x18\x18 \x01(\v2\x14.rag.v1.RerankConfigR\x06rerank\x12J\n" +
+	"\x11duplicate_results\x18\x19 \x01(\v2\x1d.rag.v1.DuplicateResultConfigR\x10duplicateResults\x12E\n" +
+	"\x0fknowledge_graph\x18\x1a \x01(\v2\x1c.rag.v1.KnowledgeGraphConfigR\x0eknowledgeGraph\x12?\n" +
+	"\rsummary_index\x18\x1b \x01(\v2\x1a.rag.v1.SummaryIndexConfigR\fsummaryIndex\x12?\n" +
+	"\rtext_analysis\x18\x1c \x01(\v2\x1a.rag.v1.TextAnalysisConfigR\ftextAnalysisB\x10\n" +
+	"\x0e_store_contentB\x0f\n" +
+	"\r_dedup_chunks\"\xbc\x01\n" +
+	"\x12TextAnalysisConfig\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12)\n" +
+	"\x10remove_stopwords\x18\x02 \x01(\bR\x0fremoveStopwords\x12\x1c\n" +
+	"\tstopwords\x18\x03 \x03(\tR\tstopwords\x12\x1a\n" +
+	"\bstemming\x18\x04 \x01(\bR\bstemming\x12%\n" +
+	"\x0ecase_sensitive\x18\x05 \x01(\bR\rcaseSensitive\"M\n" +
+	"\x15DuplicateResultConfig\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x02R\tthreshold\"\x83\x01\n" +
+	"\x14KnowledgeGraphConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12#\n" +
+	"\rmax_relations\x18\x02 \x01(\x05R\fmaxRelations\x12,\n" +
+	"\x12max_query_entities\x18\x03 \x01(\x05R\x10maxQueryEntities\"p\n" +
+	"\x12SummaryIndexConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12!\n" +
+	"\fcluster_size\x18\x02 \x01(\x05R\vclusterSize\x12\x1d\n" +
+	"\n" +
+	"max_levels\x18\x03 \x01(\x05R\tmaxLevels\"\xb6\x01\n" +
+	"\fRerankConfig\x12 \n" +
+	"\vcalibration\x18\x01 \x01(\tR\vcalibration\x12%\n" +
+	"\x0esigmoid_center\x18\x02 \x01(\x02R\rsigmoidCenter\x12#\n" +
+	"\rsigmoid_scale\x18\x03 \x01(\x02R\fsigmoidScale\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x02R\tthreshold\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\"S\n" +
+	"\x0eHeadlessConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12'\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x05R\x0etimeoutSeconds\"L\n" +
+	"\x13NearDuplicateConfig\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12!\n" +
+	"\fmax_distance\x18\x02 \x01(\x05R\vmaxDistance\"f\n" +
+	"\x0eToolDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"parameters\x18\x03 \x01(\tR\n" +
+	"parameters\"*\n" +
+	"\vAgentConfig\x12\x1b\n" +
+	"\tmax_steps\x18\x01 \x01(\x05R\bmaxSteps\"E\n" +
+	"\rHistoryConfig\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\x05R\x06window\x12\x1c\n" +
+	"\tsummarize\x18\x02 \x01(\bR\tsummarize\"5\n" +
+	"\tPIIConfig\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x14\n" +
+	"\x05kinds\x18\x02 \x03(\tR\x05kinds\"\xa7\x01\n" +
+	"\x0fGuardrailConfig\x12\x14\n" +
+	"\x05input\x18\x01 \x03(\tR\x05input\x12\x18\n" +
+	"\acontext\x18\x02 \x03(\tR\acontext\x12\x16\n" +
+	"\x06output\x18\x03 \x03(\tR\x06output\x12#\n" +
+	"\rbanned_topics\x18\x04 \x03(\tR\fbannedTopics\x12'\n" +
+	"\x0fblocked_message\x18\x05 \x01(\tR\x0eblockedMessage\"i\n" +
+	"\x0eNoAnswerConfig\x12\"\n" +
+	"\rmin_top_score\x18\x01 \x01(\x02R\vminTopScore\x12\x19\n" +
+	"\bskip_llm\x18\x02 \x01(\bR\askipLlm\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xd6\x02\n" +
+	"\x10ScoreBoostConfig\x123\n" +
+	"\x16recency_half_life_days\x18\x01 \x01(\x02R\x13recencyHalfLifeDays\x12#\n" +
+	"\rrecency_floor\x18\x02 \x01(\x02R\frecencyFloor\x12\x1d\n" +
+	"\n" +
+	"date_field\x18\x03 \x01(\tR\tdateField\x12%\n" +
+	"\x0epriority_field\x18\x04 \x01(\tR\rpriorityField\x12'\n" +
+	"\x0fpriority_weight\x18\x05 \x01(\x02R\x0epriorityWeight\x12!\n" +
+	"\fdepth_weight\x18\x06 \x01(\x02R\vdepthWeight\x12-\n" +
+	"\x12preferred_language\x18\a \x01(\tR\x11preferredLanguage\x12'\n" +
+	"\x0flanguage_weight\x18\b \x01(\x02R\x0elanguageWeight\"\x89\x01\n" +
+	"\x13VectorStorageConfig\x12\"\n" +
+	"\fquantization\x18\x01 \x01(\tR\fquantization\x12&\n" +
+	"\x0fon_disk_vectors\x18\x02 \x01(\bR\ronDiskVectors\x12&\n" +
+	"\x0fon_disk_payload\x18\x03 \x01(\bR\ronDiskPayload\"\xdc\x02\n" +
 	"\rChunkerConfig\x12\x16\n" +
 	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1f\n" +
 	"\vtarget_size\x18\x02 \x01(\x05R\n" +
 	"targetSize\x12\x19\n" +
 	"\bmax_size\x18\x03 \x01(\x05R\amaxSize\x12\x18\n" +
-	"\aoverlap\x18\x04 \x01(\x05R\aoverlap\"\x81\x01\n" +
+	"\aoverlap\x18\x04 \x01(\x05R\aoverlap\x12$\n" +
+	"\x0erows_per_chunk\x18\x05 \x01(\x05R\frowsPerChunk\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12$\n" +
+	"\rabbreviations\x18\a \x03(\tR\rabbreviations\x12'\n" +
+	"\x0foverlap_percent\x18\b \x01(\x05R\x0eoverlapPercent\x12!\n" +
+	"\ftable_format\x18\t \x01(\tR\vtableFormat\x12)\n" +
+	"\x10context_template\x18\n" +
+	" \x01(\tR\x0fcontextTemplate\"\x81\x01\n" +
 	"\vTenantUsage\x12%\n" +
 	"\x0edocument_count\x18\x01 \x01(\x05R\rdocumentCount\x12\x1f\n" +
 	"\vchunk_count\x18\x02 \x01(\x05R\n" +
@@ -850,16 +3104,90 @@ const file_rag_v1_tenant_proto_rawDesc = "" +
 	"\x14DeleteTenantResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\")\n" +
 	"\x17RegenerateAPIKeyRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"n\n" +
+	"\x16SetTenantStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.rag.v1.TenantStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"3\n" +
 	"\x18RegenerateAPIKeyResponse\x12\x17\n" +
-	"\aapi_key\x18\x01 \x01(\tR\x06apiKey2\xd1\x04\n" +
+	"\aapi_key\x18\x01 \x01(\tR\x06apiKey\"\xf3\x01\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x17\n" +
+	"\aapi_key\x18\x05 \x01(\tR\x06apiKey\x12\x1d\n" +
+	"\n" +
+	"key_prefix\x18\x06 \x01(\tR\tkeyPrefix\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\b \x01(\tR\tprojectId\"}\n" +
+	"\x13CreateAPIKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tR\tprojectId\"1\n" +
+	"\x12ListAPIKeysRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\"@\n" +
+	"\x13ListAPIKeysResponse\x12)\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x0e.rag.v1.APIKeyR\aapiKeys\"B\n" +
+	"\x13DeleteAPIKeyRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"0\n" +
+	"\x14DeleteAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc4\x01\n" +
+	"\x14ReindexTenantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x0fembedding_model\x18\x02 \x01(\tR\x0eembeddingModel\x12/\n" +
+	"\x13embedding_dimension\x18\x03 \x01(\x05R\x12embeddingDimension\x12B\n" +
+	"\x0evector_storage\x18\x04 \x01(\v2\x1b.rag.v1.VectorStorageConfigR\rvectorStorage\"J\n" +
+	"\x14GetReindexJobRequest\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\xcd\x03\n" +
+	"\n" +
+	"ReindexJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.rag.v1.ReindexStatusR\x06status\x12'\n" +
+	"\x0fembedding_model\x18\x04 \x01(\tR\x0eembeddingModel\x12\x1c\n" +
+	"\tdimension\x18\x05 \x01(\x05R\tdimension\x12!\n" +
+	"\fchunks_total\x18\x06 \x01(\x05R\vchunksTotal\x12\x1f\n" +
+	"\vchunks_done\x18\a \x01(\x05R\n" +
+	"chunksDone\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt*\x9d\x01\n" +
+	"\fTenantStatus\x12\x1d\n" +
+	"\x19TENANT_STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TENANT_STATUS_ACTIVE\x10\x01\x12\x1b\n" +
+	"\x17TENANT_STATUS_READ_ONLY\x10\x02\x12\x1b\n" +
+	"\x17TENANT_STATUS_SUSPENDED\x10\x03\x12\x1a\n" +
+	"\x16TENANT_STATUS_ARCHIVED\x10\x04*\xa0\x01\n" +
+	"\rReindexStatus\x12\x1e\n" +
+	"\x1aREINDEX_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16REINDEX_STATUS_PENDING\x10\x01\x12\x1a\n" +
+	"\x16REINDEX_STATUS_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18REINDEX_STATUS_COMPLETED\x10\x03\x12\x19\n" +
+	"\x15REINDEX_STATUS_FAILED\x10\x042\xeb\t\n" +
 	"\rTenantService\x12S\n" +
 	"\fCreateTenant\x12\x1b.rag.v1.CreateTenantRequest\x1a\x0e.rag.v1.Tenant\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/tenants\x12O\n" +
 	"\tGetTenant\x12\x18.rag.v1.GetTenantRequest\x1a\x0e.rag.v1.Tenant\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tenants/{id}\x12[\n" +
 	"\vListTenants\x12\x1a.rag.v1.ListTenantsRequest\x1a\x1b.rag.v1.ListTenantsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/tenants\x12X\n" +
 	"\fUpdateTenant\x12\x1b.rag.v1.UpdateTenantRequest\x1a\x0e.rag.v1.Tenant\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/v1/tenants/{id}\x12c\n" +
 	"\fDeleteTenant\x12\x1b.rag.v1.DeleteTenantRequest\x1a\x1c.rag.v1.DeleteTenantResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/tenants/{id}\x12~\n" +
-	"\x10RegenerateAPIKey\x12\x1f.rag.v1.RegenerateAPIKeyRequest\x1a .rag.v1.RegenerateAPIKeyResponse\"'\x82\xd3\xe4\x93\x02!\"\x1f/v1/tenants/{id}/regenerate-keyB\xec\x01\x92Am\x12C\n" +
+	"\x10RegenerateAPIKey\x12\x1f.rag.v1.RegenerateAPIKeyRequest\x1a .rag.v1.RegenerateAPIKeyResponse\"'\x82\xd3\xe4\x93\x02!\"\x1f/v1/tenants/{id}/regenerate-key\x12e\n" +
+	"\x0fSetTenantStatus\x12\x1e.rag.v1.SetTenantStatusRequest\x1a\x0e.rag.v1.Tenant\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tenants/{id}/status\x12f\n" +
+	"\rReindexTenant\x12\x1c.rag.v1.ReindexTenantRequest\x1a\x12.rag.v1.ReindexJob\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/tenants/{id}/reindex\x12s\n" +
+	"\rGetReindexJob\x12\x1c.rag.v1.GetReindexJobRequest\x1a\x12.rag.v1.ReindexJob\"0\x82\xd3\xe4\x93\x02*\x12(/v1/tenants/{tenant_id}/reindex/{job_id}\x12h\n" +
+	"\fCreateAPIKey\x12\x1b.rag.v1.CreateAPIKeyRequest\x1a\x0e.rag.v1.APIKey\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/tenants/{tenant_id}/api-keys\x12p\n" +
+	"\vListAPIKeys\x12\x1a.rag.v1.ListAPIKeysRequest\x1a\x1b.rag.v1.ListAPIKeysResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/tenants/{tenant_id}/api-keys\x12x\n" +
+	"\fDeleteAPIKey\x12\x1b.rag.v1.DeleteAPIKeyRequest\x1a\x1c.rag.v1.DeleteAPIKeyResponse\"-\x82\xd3\xe4\x93\x02'*%/v1/tenants/{tenant_id}/api-keys/{id}B\xec\x01\x92Am\x12C\n" +
 	"\x0eRAG Tenant API\x12,Multi-tenant RAG service - Tenant management2\x031.0*\x02\x01\x022\x10application/json:\x10application/json\n" +
 	"\n" +
 	"com.rag.v1B\vTenantProtoP\x01Z(github.com/knoguchi/rag/gen/rag/v1;ragv1\xa2\x02\x03RXX\xaa\x02\x06Rag.V1\xca\x02\x06Rag\\V1\xe2\x02\x12Rag\\V1\\GPBMetadata\xea\x02\aRag::V1b\x06proto3"
@@ -876,49 +3204,114 @@ func file_rag_v1_tenant_proto_rawDescGZIP() []byte {
 	return file_rag_v1_tenant_proto_rawDescData
 }
 
-var file_rag_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_rag_v1_tenant_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rag_v1_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_rag_v1_tenant_proto_goTypes = []any{
-	(*Tenant)(nil),                   // 0: rag.v1.Tenant
-	(*TenantConfig)(nil),             // 1: rag.v1.TenantConfig
-	(*ChunkerConfig)(nil),            // 2: rag.v1.ChunkerConfig
-	(*TenantUsage)(nil),              // 3: rag.v1.TenantUsage
-	(*CreateTenantRequest)(nil),      // 4: rag.v1.CreateTenantRequest
-	(*GetTenantRequest)(nil),         // 5: rag.v1.GetTenantRequest
-	(*ListTenantsRequest)(nil),       // 6: rag.v1.ListTenantsRequest
-	(*ListTenantsResponse)(nil),      // 7: rag.v1.ListTenantsResponse
-	(*UpdateTenantRequest)(nil),      // 8: rag.v1.UpdateTenantRequest
-	(*DeleteTenantRequest)(nil),      // 9: rag.v1.DeleteTenantRequest
-	(*DeleteTenantResponse)(nil),     // 10: rag.v1.DeleteTenantResponse
-	(*RegenerateAPIKeyRequest)(nil),  // 11: rag.v1.RegenerateAPIKeyRequest
-	(*RegenerateAPIKeyResponse)(nil), // 12: rag.v1.RegenerateAPIKeyResponse
-	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
+	(TenantStatus)(0),                // 0: rag.v1.TenantStatus
+	(ReindexStatus)(0),               // 1: rag.v1.ReindexStatus
+	(*Tenant)(nil),                   // 2: rag.v1.Tenant
+	(*TenantConfig)(nil),             // 3: rag.v1.TenantConfig
+	(*TextAnalysisConfig)(nil),       // 4: rag.v1.TextAnalysisConfig
+	(*DuplicateResultConfig)(nil),    // 5: rag.v1.DuplicateResultConfig
+	(*KnowledgeGraphConfig)(nil),     // 6: rag.v1.KnowledgeGraphConfig
+	(*SummaryIndexConfig)(nil),       // 7: rag.v1.SummaryIndexConfig
+	(*RerankConfig)(nil),             // 8: rag.v1.RerankConfig
+	(*HeadlessConfig)(nil),           // 9: rag.v1.HeadlessConfig
+	(*NearDuplicateConfig)(nil),      // 10: rag.v1.NearDuplicateConfig
+	(*ToolDefinition)(nil),           // 11: rag.v1.ToolDefinition
+	(*AgentConfig)(nil),              // 12: rag.v1.AgentConfig
+	(*HistoryConfig)(nil),            // 13: rag.v1.HistoryConfig
+	(*PIIConfig)(nil),                // 14: rag.v1.PIIConfig
+	(*GuardrailConfig)(nil),          // 15: rag.v1.GuardrailConfig
+	(*NoAnswerConfig)(nil),           // 16: rag.v1.NoAnswerConfig
+	(*ScoreBoostConfig)(nil),         // 17: rag.v1.ScoreBoostConfig
+	(*VectorStorageConfig)(nil),      // 18: rag.v1.VectorStorageConfig
+	(*ChunkerConfig)(nil),            // 19: rag.v1.ChunkerConfig
+	(*TenantUsage)(nil),              // 20: rag.v1.TenantUsage
+	(*CreateTenantRequest)(nil),      // 21: rag.v1.CreateTenantRequest
+	(*GetTenantRequest)(nil),         // 22: rag.v1.GetTenantRequest
+	(*ListTenantsRequest)(nil),       // 23: rag.v1.ListTenantsRequest
+	(*ListTenantsResponse)(nil),      // 24: rag.v1.ListTenantsResponse
+	(*UpdateTenantRequest)(nil),      // 25: rag.v1.UpdateTenantRequest
+	(*DeleteTenantRequest)(nil),      // 26: rag.v1.DeleteTenantRequest
+	(*DeleteTenantResponse)(nil),     // 27: rag.v1.DeleteTenantResponse
+	(*RegenerateAPIKeyRequest)(nil),  // 28: rag.v1.RegenerateAPIKeyRequest
+	(*SetTenantStatusRequest)(nil),   // 29: rag.v1.SetTenantStatusRequest
+	(*RegenerateAPIKeyResponse)(nil), // 30: rag.v1.RegenerateAPIKeyResponse
+	(*APIKey)(nil),                   // 31: rag.v1.APIKey
+	(*CreateAPIKeyRequest)(nil),      // 32: rag.v1.CreateAPIKeyRequest
+	(*ListAPIKeysRequest)(nil),       // 33: rag.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),      // 34: rag.v1.ListAPIKeysResponse
+	(*DeleteAPIKeyRequest)(nil),      // 35: rag.v1.DeleteAPIKeyRequest
+	(*DeleteAPIKeyResponse)(nil),     // 36: rag.v1.DeleteAPIKeyResponse
+	(*ReindexTenantRequest)(nil),     // 37: rag.v1.ReindexTenantRequest
+	(*GetReindexJobRequest)(nil),     // 38: rag.v1.GetReindexJobRequest
+	(*ReindexJob)(nil),               // 39: rag.v1.ReindexJob
+	(*timestamppb.Timestamp)(nil),    // 40: google.protobuf.Timestamp
 }
 var file_rag_v1_tenant_proto_depIdxs = []int32{
-	1,  // 0: rag.v1.Tenant.config:type_name -> rag.v1.TenantConfig
-	3,  // 1: rag.v1.Tenant.usage:type_name -> rag.v1.TenantUsage
-	13, // 2: rag.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
-	13, // 3: rag.v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 4: rag.v1.TenantConfig.chunker:type_name -> rag.v1.ChunkerConfig
-	1,  // 5: rag.v1.CreateTenantRequest.config:type_name -> rag.v1.TenantConfig
-	0,  // 6: rag.v1.ListTenantsResponse.tenants:type_name -> rag.v1.Tenant
-	1,  // 7: rag.v1.UpdateTenantRequest.config:type_name -> rag.v1.TenantConfig
-	4,  // 8: rag.v1.TenantService.CreateTenant:input_type -> rag.v1.CreateTenantRequest
-	5,  // 9: rag.v1.TenantService.GetTenant:input_type -> rag.v1.GetTenantRequest
-	6,  // 10: rag.v1.TenantService.ListTenants:input_type -> rag.v1.ListTenantsRequest
-	8,  // 11: rag.v1.TenantService.UpdateTenant:input_type -> rag.v1.UpdateTenantRequest
-	9,  // 12: rag.v1.TenantService.DeleteTenant:input_type -> rag.v1.DeleteTenantRequest
-	11, // 13: rag.v1.TenantService.RegenerateAPIKey:input_type -> rag.v1.RegenerateAPIKeyRequest
-	0,  // 14: rag.v1.TenantService.CreateTenant:output_type -> rag.v1.Tenant
-	0,  // 15: rag.v1.TenantService.GetTenant:output_type -> rag.v1.Tenant
-	7,  // 16: rag.v1.TenantService.ListTenants:output_type -> rag.v1.ListTenantsResponse
-	0,  // 17: rag.v1.TenantService.UpdateTenant:output_type -> rag.v1.Tenant
-	10, // 18: rag.v1.TenantService.DeleteTenant:output_type -> rag.v1.DeleteTenantResponse
-	12, // 19: rag.v1.TenantService.RegenerateAPIKey:output_type -> rag.v1.RegenerateAPIKeyResponse
-	14, // [14:20] is the sub-list for method output_type
-	8,  // [8:14] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	3,  // 0: rag.v1.Tenant.config:type_name -> rag.v1.TenantConfig
+	20, // 1: rag.v1.Tenant.usage:type_name -> rag.v1.TenantUsage
+	40, // 2: rag.v1.Tenant.created_at:type_name -> google.protobuf.Timestamp
+	40, // 3: rag.v1.Tenant.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: rag.v1.Tenant.status:type_name -> rag.v1.TenantStatus
+	40, // 5: rag.v1.Tenant.status_changed_at:type_name -> google.protobuf.Timestamp
+	19, // 6: rag.v1.TenantConfig.chunker:type_name -> rag.v1.ChunkerConfig
+	18, // 7: rag.v1.TenantConfig.vector_storage:type_name -> rag.v1.VectorStorageConfig
+	17, // 8: rag.v1.TenantConfig.score_boost:type_name -> rag.v1.ScoreBoostConfig
+	16, // 9: rag.v1.TenantConfig.no_answer:type_name -> rag.v1.NoAnswerConfig
+	15, // 10: rag.v1.TenantConfig.guardrails:type_name -> rag.v1.GuardrailConfig
+	14, // 11: rag.v1.TenantConfig.pii:type_name -> rag.v1.PIIConfig
+	13, // 12: rag.v1.TenantConfig.history:type_name -> rag.v1.HistoryConfig
+	12, // 13: rag.v1.TenantConfig.agent:type_name -> rag.v1.AgentConfig
+	11, // 14: rag.v1.TenantConfig.tools:type_name -> rag.v1.ToolDefinition
+	10, // 15: rag.v1.TenantConfig.near_duplicates:type_name -> rag.v1.NearDuplicateConfig
+	9,  // 16: rag.v1.TenantConfig.headless:type_name -> rag.v1.HeadlessConfig
+	8,  // 17: rag.v1.TenantConfig.rerank:type_name -> rag.v1.RerankConfig
+	5,  // 18: rag.v1.TenantConfig.duplicate_results:type_name -> rag.v1.DuplicateResultConfig
+	6,  // 19: rag.v1.TenantConfig.knowledge_graph:type_name -> rag.v1.KnowledgeGraphConfig
+	7,  // 20: rag.v1.TenantConfig.summary_index:type_name -> rag.v1.SummaryIndexConfig
+	4,  // 21: rag.v1.TenantConfig.text_analysis:type_name -> rag.v1.TextAnalysisConfig
+	3,  // 22: rag.v1.CreateTenantRequest.config:type_name -> rag.v1.TenantConfig
+	2,  // 23: rag.v1.ListTenantsResponse.tenants:type_name -> rag.v1.Tenant
+	3,  // 24: rag.v1.UpdateTenantRequest.config:type_name -> rag.v1.TenantConfig
+	0,  // 25: rag.v1.SetTenantStatusRequest.status:type_name -> rag.v1.TenantStatus
+	40, // 26: rag.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	31, // 27: rag.v1.ListAPIKeysResponse.api_keys:type_name -> rag.v1.APIKey
+	18, // 28: rag.v1.ReindexTenantRequest.vector_storage:type_name -> rag.v1.VectorStorageConfig
+	1,  // 29: rag.v1.ReindexJob.status:type_name -> rag.v1.ReindexStatus
+	40, // 30: rag.v1.ReindexJob.created_at:type_name -> google.protobuf.Timestamp
+	40, // 31: rag.v1.ReindexJob.started_at:type_name -> google.protobuf.Timestamp
+	40, // 32: rag.v1.ReindexJob.completed_at:type_name -> google.protobuf.Timestamp
+	21, // 33: rag.v1.TenantService.CreateTenant:input_type -> rag.v1.CreateTenantRequest
+	22, // 34: rag.v1.TenantService.GetTenant:input_type -> rag.v1.GetTenantRequest
+	23, // 35: rag.v1.TenantService.ListTenants:input_type -> rag.v1.ListTenantsRequest
+	25, // 36: rag.v1.TenantService.UpdateTenant:input_type -> rag.v1.UpdateTenantRequest
+	26, // 37: rag.v1.TenantService.DeleteTenant:input_type -> rag.v1.DeleteTenantRequest
+	28, // 38: rag.v1.TenantService.RegenerateAPIKey:input_type -> rag.v1.RegenerateAPIKeyRequest
+	29, // 39: rag.v1.TenantService.SetTenantStatus:input_type -> rag.v1.SetTenantStatusRequest
+	37, // 40: rag.v1.TenantService.ReindexTenant:input_type -> rag.v1.ReindexTenantRequest
+	38, // 41: rag.v1.TenantService.GetReindexJob:input_type -> rag.v1.GetReindexJobRequest
+	32, // 42: rag.v1.TenantService.CreateAPIKey:input_type -> rag.v1.CreateAPIKeyRequest
+	33, // 43: rag.v1.TenantService.ListAPIKeys:input_type -> rag.v1.ListAPIKeysRequest
+	35, // 44: rag.v1.TenantService.DeleteAPIKey:input_type -> rag.v1.DeleteAPIKeyRequest
+	2,  // 45: rag.v1.TenantService.CreateTenant:output_type -> rag.v1.Tenant
+	2,  // 46: rag.v1.TenantService.GetTenant:output_type -> rag.v1.Tenant
+	24, // 47: rag.v1.TenantService.ListTenants:output_type -> rag.v1.ListTenantsResponse
+	2,  // 48: rag.v1.TenantService.UpdateTenant:output_type -> rag.v1.Tenant
+	27, // 49: rag.v1.TenantService.DeleteTenant:output_type -> rag.v1.DeleteTenantResponse
+	30, // 50: rag.v1.TenantService.RegenerateAPIKey:output_type -> rag.v1.RegenerateAPIKeyResponse
+	2,  // 51: rag.v1.TenantService.SetTenantStatus:output_type -> rag.v1.Tenant
+	39, // 52: rag.v1.TenantService.ReindexTenant:output_type -> rag.v1.ReindexJob
+	39, // 53: rag.v1.TenantService.GetReindexJob:output_type -> rag.v1.ReindexJob
+	31, // 54: rag.v1.TenantService.CreateAPIKey:output_type -> rag.v1.APIKey
+	34, // 55: rag.v1.TenantService.ListAPIKeys:output_type -> rag.v1.ListAPIKeysResponse
+	36, // 56: rag.v1.TenantService.DeleteAPIKey:output_type -> rag.v1.DeleteAPIKeyResponse
+	45, // [45:57] is the sub-list for method output_type
+	33, // [33:45] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_rag_v1_tenant_proto_init() }
@@ -926,18 +3319,20 @@ func file_rag_v1_tenant_proto_init() {
 	if File_rag_v1_tenant_proto != nil {
 		return
 	}
+	file_rag_v1_tenant_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rag_v1_tenant_proto_rawDesc), len(file_rag_v1_tenant_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_rag_v1_tenant_proto_goTypes,
 		DependencyIndexes: file_rag_v1_tenant_proto_depIdxs,
+		EnumInfos:         file_rag_v1_tenant_proto_enumTypes,
 		MessageInfos:      file_rag_v1_tenant_proto_msgTypes,
 	}.Build()
 	File_rag_v1_tenant_proto = out.File
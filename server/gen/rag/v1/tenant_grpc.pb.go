@@ -25,6 +25,12 @@ const (
 	TenantService_UpdateTenant_FullMethodName     = "/rag.v1.TenantService/UpdateTenant"
 	TenantService_DeleteTenant_FullMethodName     = "/rag.v1.TenantService/DeleteTenant"
 	TenantService_RegenerateAPIKey_FullMethodName = "/rag.v1.TenantService/RegenerateAPIKey"
+	TenantService_SetTenantStatus_FullMethodName  = "/rag.v1.TenantService/SetTenantStatus"
+	TenantService_ReindexTenant_FullMethodName    = "/rag.v1.TenantService/ReindexTenant"
+	TenantService_GetReindexJob_FullMethodName    = "/rag.v1.TenantService/GetReindexJob"
+	TenantService_CreateAPIKey_FullMethodName     = "/rag.v1.TenantService/CreateAPIKey"
+	TenantService_ListAPIKeys_FullMethodName      = "/rag.v1.TenantService/ListAPIKeys"
+	TenantService_DeleteAPIKey_FullMethodName     = "/rag.v1.TenantService/DeleteAPIKey"
 )
 
 // TenantServiceClient is the client API for TenantService service.
@@ -45,6 +51,21 @@ type TenantServiceClient interface {
 	DeleteTenant(ctx context.Context, in *DeleteTenantRequest, opts ...grpc.CallOption) (*DeleteTenantResponse, error)
 	// RegenerateAPIKey generates a new API key for a tenant
 	RegenerateAPIKey(ctx context.Context, in *RegenerateAPIKeyRequest, opts ...grpc.CallOption) (*RegenerateAPIKeyResponse, error)
+	// SetTenantStatus moves a tenant through its lifecycle (admin only), e.g.
+	// suspending a nonpaying tenant without deleting its data
+	SetTenantStatus(ctx context.Context, in *SetTenantStatusRequest, opts ...grpc.CallOption) (*Tenant, error)
+	// ReindexTenant re-embeds all stored chunks with a new embedding model in the background.
+	// Queries keep using the current collection until the new one is complete.
+	ReindexTenant(ctx context.Context, in *ReindexTenantRequest, opts ...grpc.CallOption) (*ReindexJob, error)
+	// GetReindexJob retrieves the progress of a reindex job
+	GetReindexJob(ctx context.Context, in *GetReindexJobRequest, opts ...grpc.CallOption) (*ReindexJob, error)
+	// CreateAPIKey creates an additional API key limited to the given scopes,
+	// e.g. a read-only key for a public search widget
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error)
+	// ListAPIKeys lists a tenant's scoped API keys; the keys themselves are not returned
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	// DeleteAPIKey revokes a scoped API key
+	DeleteAPIKey(ctx context.Context, in *DeleteAPIKeyRequest, opts ...grpc.CallOption) (*DeleteAPIKeyResponse, error)
 }
 
 type tenantServiceClient struct {
@@ -115,6 +136,66 @@ func (c *tenantServiceClient) RegenerateAPIKey(ctx context.Context, in *Regenera
 	return out, nil
 }
 
+func (c *tenantServiceClient) SetTenantStatus(ctx context.Context, in *SetTenantStatusRequest, opts ...grpc.CallOption) (*Tenant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tenant)
+	err := c.cc.Invoke(ctx, TenantService_SetTenantStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) ReindexTenant(ctx context.Context, in *ReindexTenantRequest, opts ...grpc.CallOption) (*ReindexJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexJob)
+	err := c.cc.Invoke(ctx, TenantService_ReindexTenant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) GetReindexJob(ctx context.Context, in *GetReindexJobRequest, opts ...grpc.CallOption) (*ReindexJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexJob)
+	err := c.cc.Invoke(ctx, TenantService_GetReindexJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*APIKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(APIKey)
+	err := c.cc.Invoke(ctx, TenantService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, TenantService_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tenantServiceClient) DeleteAPIKey(ctx context.Context, in *DeleteAPIKeyRequest, opts ...grpc.CallOption) (*DeleteAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAPIKeyResponse)
+	err := c.cc.Invoke(ctx, TenantService_DeleteAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TenantServiceServer is the server API for TenantService service.
 // All implementations must embed UnimplementedTenantServiceServer
 // for forward compatibility.
@@ -133,6 +214,21 @@ type TenantServiceServer interface {
 	DeleteTenant(context.Context, *DeleteTenantRequest) (*DeleteTenantResponse, error)
 	// RegenerateAPIKey generates a new API key for a tenant
 	RegenerateAPIKey(context.Context, *RegenerateAPIKeyRequest) (*RegenerateAPIKeyResponse, error)
+	// SetTenantStatus moves a tenant through its lifecycle (admin only), e.g.
+	// suspending a nonpaying tenant without deleting its data
+	SetTenantStatus(context.Context, *SetTenantStatusRequest) (*Tenant, error)
+	// ReindexTenant re-embeds all stored chunks with a new embedding model in the background.
+	// Queries keep using the current collection until the new one is complete.
+	ReindexTenant(context.Context, *ReindexTenantRequest) (*ReindexJob, error)
+	// GetReindexJob retrieves the progress of a reindex job
+	GetReindexJob(context.Context, *GetReindexJobRequest) (*ReindexJob, error)
+	// CreateAPIKey creates an additional API key limited to the given scopes,
+	// e.g. a read-only key for a public search widget
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKey, error)
+	// ListAPIKeys lists a tenant's scoped API keys; the keys themselves are not returned
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// DeleteAPIKey revokes a scoped API key
+	DeleteAPIKey(context.Context, *DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error)
 	mustEmbedUnimplementedTenantServiceServer()
 }
 
@@ -161,6 +257,24 @@ func (UnimplementedTenantServiceServer) DeleteTenant(context.Context, *DeleteTen
 func (UnimplementedTenantServiceServer) RegenerateAPIKey(context.Context, *RegenerateAPIKeyRequest) (*RegenerateAPIKeyResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RegenerateAPIKey not implemented")
 }
+func (UnimplementedTenantServiceServer) SetTenantStatus(context.Context, *SetTenantStatusRequest) (*Tenant, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTenantStatus not implemented")
+}
+func (UnimplementedTenantServiceServer) ReindexTenant(context.Context, *ReindexTenantRequest) (*ReindexJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReindexTenant not implemented")
+}
+func (UnimplementedTenantServiceServer) GetReindexJob(context.Context, *GetReindexJobRequest) (*ReindexJob, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReindexJob not implemented")
+}
+func (UnimplementedTenantServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*APIKey, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedTenantServiceServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedTenantServiceServer) DeleteAPIKey(context.Context, *DeleteAPIKeyRequest) (*DeleteAPIKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAPIKey not implemented")
+}
 func (UnimplementedTenantServiceServer) mustEmbedUnimplementedTenantServiceServer() {}
 func (UnimplementedTenantServiceServer) testEmbeddedByValue()                       {}
 
@@ -290,6 +404,114 @@ func _TenantService_RegenerateAPIKey_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TenantService_SetTenantStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTenantStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).SetTenantStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_SetTenantStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).SetTenantStatus(ctx, req.(*SetTenantStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_ReindexTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).ReindexTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_ReindexTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).ReindexTenant(ctx, req.(*ReindexTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_GetReindexJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReindexJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).GetReindexJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_GetReindexJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).GetReindexJob(ctx, req.(*GetReindexJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TenantService_DeleteAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TenantServiceServer).DeleteAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TenantService_DeleteAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TenantServiceServer).DeleteAPIKey(ctx, req.(*DeleteAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TenantService_ServiceDesc is the grpc.ServiceDesc for TenantService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -321,6 +543,30 @@ var TenantService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegenerateAPIKey",
 			Handler:    _TenantService_RegenerateAPIKey_Handler,
 		},
+		{
+			MethodName: "SetTenantStatus",
+			Handler:    _TenantService_SetTenantStatus_Handler,
+		},
+		{
+			MethodName: "ReindexTenant",
+			Handler:    _TenantService_ReindexTenant_Handler,
+		},
+		{
+			MethodName: "GetReindexJob",
+			Handler:    _TenantService_GetReindexJob_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _TenantService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _TenantService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "DeleteAPIKey",
+			Handler:    _TenantService_DeleteAPIKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rag/v1/tenant.proto",
@@ -0,0 +1,196 @@
+// Package analysis turns text into the terms sparse vectors are built from.
+// An Analyzer splits text into words, lowercases them, drops stopwords and
+// reduces words to stems, with rules for the language of a tenant's
+// documents: German folds umlauts and strips inflections, and Japanese,
+// which has no spaces, is split into character bigrams.
+package analysis
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages with their own stopwords and rules. Other languages are
+// analyzed alike: words are split at spaces and punctuation, and CJK text
+// into character bigrams.
+const (
+	English  = "en"
+	German   = "de"
+	Japanese = "ja"
+)
+
+// Config configures an Analyzer. The zero value lowercases words and keeps
+// every one of them.
+type Config struct {
+	// Language is the ISO 639-1 code of the text's language
+	Language string
+
+	// RemoveStopwords drops the language's stopwords
+	RemoveStopwords bool
+
+	// Stopwords are dropped in addition to the language's
+	Stopwords []string
+
+	// Stemming reduces words to their stems, for English and German
+	Stemming bool
+
+	// CaseSensitive keeps letter case, so "Go" and "go" are different terms
+	CaseSensitive bool
+}
+
+// Analyzer splits text into terms. It is safe for concurrent use.
+type Analyzer struct {
+	language      string
+	stopwords     map[string]bool
+	stem          func(string) string
+	caseSensitive bool
+}
+
+// New creates an analyzer.
+func New(cfg Config) *Analyzer {
+	a := &Analyzer{
+		language:      strings.ToLower(cfg.Language),
+		caseSensitive: cfg.CaseSensitive,
+	}
+	if cfg.RemoveStopwords || len(cfg.Stopwords) > 0 {
+		a.stopwords = make(map[string]bool)
+		if cfg.RemoveStopwords {
+			for _, w := range stopwords[a.language] {
+				a.stopwords[w] = true
+			}
+		}
+		for _, w := range cfg.Stopwords {
+			a.stopwords[strings.ToLower(strings.TrimSpace(w))] = true
+		}
+	}
+	if cfg.Stemming {
+		switch a.language {
+		case English:
+			a.stem = stemEnglish
+		case German:
+			a.stem = stemGerman
+		}
+	}
+	return a
+}
+
+// SupportsStemming reports whether an Analyzer stems words of a language.
+func SupportsStemming(language string) bool {
+	language = strings.ToLower(language)
+	return language == English || language == German
+}
+
+// Terms returns the terms of a text in order, repeated as often as they
+// occur.
+func (a *Analyzer) Terms(text string) []string {
+	var terms []string
+	for _, w := range splitWords(text) {
+		lower := strings.ToLower(w.text)
+		if w.cjk {
+			if a.stopwords[lower] || (a.language == Japanese && a.stopwords != nil && isHiraganaParticle(lower)) {
+				continue
+			}
+			terms = append(terms, bigrams(lower)...)
+			continue
+		}
+		if a.stopwords[lower] {
+			continue
+		}
+		term := w.text
+		if !a.caseSensitive {
+			term = lower
+		}
+		if a.stem != nil {
+			term = a.stem(term)
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// segment is a word, or a run of CJK characters of one script
+type segment struct {
+	text string
+	cjk  bool
+}
+
+// splitWords splits text into runs of letters and digits, cutting CJK text
+// into runs of one script since it has no spaces between words
+func splitWords(text string) []segment {
+	var segments []segment
+	start, script := -1, scriptNone
+	flush := func(end int) {
+		if start >= 0 {
+			segments = append(segments, segment{text: text[start:end], cjk: script != scriptWord})
+		}
+		start, script = -1, scriptNone
+	}
+	for i, r := range text {
+		s := scriptOf(r)
+		if s == scriptNone {
+			flush(i)
+			continue
+		}
+		if start >= 0 && s != script {
+			flush(i)
+		}
+		if start < 0 {
+			start, script = i, s
+		}
+	}
+	flush(len(text))
+	return segments
+}
+
+// Scripts splitWords separates runs of
+const (
+	scriptNone = iota
+	scriptWord // letters and digits of alphabets, written with spaces between words
+	scriptHan
+	scriptHiragana
+	scriptKatakana
+	scriptHangul
+)
+
+func scriptOf(r rune) int {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return scriptHan
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r), r == 'ー':
+		return scriptKatakana
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+		return scriptWord
+	}
+	return scriptNone
+}
+
+// bigrams returns the overlapping character pairs of a CJK run, or the run
+// itself when it is one character
+func bigrams(run string) []string {
+	runes := []rune(run)
+	if len(runes) < 2 {
+		return []string{run}
+	}
+	pairs := make([]string, len(runes)-1)
+	for i := range pairs {
+		pairs[i] = string(runes[i : i+2])
+	}
+	return pairs
+}
+
+// isHiraganaParticle reports whether a hiragana run is short enough to be
+// grammar (particles and inflections such as の, は, です) rather than a word
+func isHiraganaParticle(run string) bool {
+	n := 0
+	for _, r := range run {
+		if !unicode.Is(unicode.Hiragana, r) {
+			return false
+		}
+		n++
+	}
+	return n <= 2
+}
@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer_Terms(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		text string
+		want []string
+	}{
+		{
+			name: "default lowercases and keeps every word",
+			text: "The Servers are DOWN",
+			want: []string{"the", "servers", "are", "down"},
+		},
+		{
+			name: "english stopwords and stemming",
+			cfg:  Config{Language: English, RemoveStopwords: true, Stemming: true},
+			text: "What are the queries of our servers' status?",
+			want: []string{"query", "server", "status"},
+		},
+		{
+			name: "german stopwords and stemming",
+			cfg:  Config{Language: German, RemoveStopwords: true, Stemming: true},
+			text: "Die Verträge und der Vertrag für Häuser",
+			want: []string{"vertrag", "vertrag", "haus"},
+		},
+		{
+			name: "case sensitive",
+			cfg:  Config{CaseSensitive: true},
+			text: "Go go",
+			want: []string{"Go", "go"},
+		},
+		{
+			name: "custom stopwords",
+			cfg:  Config{Stopwords: []string{"Acme"}},
+			text: "acme widgets",
+			want: []string{"widgets"},
+		},
+		{
+			name: "japanese bigrams",
+			cfg:  Config{Language: Japanese},
+			text: "東京都の天気",
+			want: []string{"東京", "京都", "の", "天気"},
+		},
+		{
+			name: "japanese particles dropped with stopwords",
+			cfg:  Config{Language: Japanese, RemoveStopwords: true},
+			text: "東京都の天気について、データベースです",
+			want: []string{"東京", "京都", "天気", "デー", "ータ", "タベ", "ベー", "ース"},
+		},
+		{
+			name: "mixed scripts split",
+			text: "GPUサーバー2台",
+			want: []string{"gpu", "サー", "ーバ", "バー", "2", "台"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.cfg).Terms(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Terms(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStemEnglish(t *testing.T) {
+	tests := map[string]string{
+		"queries": "query",
+		"files":   "file",
+		"shoes":   "shoes",
+		"class":   "class",
+		"status":  "status",
+		"keys":    "key",
+		"user's":  "user",
+		"is":      "is",
+	}
+	for in, want := range tests {
+		if got := stemEnglish(in); got != want {
+			t.Errorf("stemEnglish(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVectorizer_Vectorize(t *testing.T) {
+	v := NewVectorizer(Config{Language: English, RemoveStopwords: true})
+	sv := v.Vectorize("the cat and the cat and a dog")
+	if len(sv.Indices) != 2 || len(sv.Values) != 2 {
+		t.Fatalf("Vectorize = %+v, want two terms", sv)
+	}
+	if sv.Indices[0] >= sv.Indices[1] {
+		t.Errorf("indices %v are not ascending", sv.Indices)
+	}
+	cat, dog := termIndex("cat"), termIndex("dog")
+	weights := map[uint32]float32{sv.Indices[0]: sv.Values[0], sv.Indices[1]: sv.Values[1]}
+	if weights[cat] <= weights[dog] || weights[cat] >= 2*weights[dog] {
+		t.Errorf("weights cat=%v dog=%v, want cat saturated above dog", weights[cat], weights[dog])
+	}
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import "strings"
+
+// stemEnglish strips plural and possessive endings, after Harman's "S"
+// stemmer: "queries" becomes "query", "servers'" and "server's" become
+// "server". Heavier suffix stripping merges unrelated words too often for
+// keyword matching.
+func stemEnglish(word string) string {
+	word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "'")
+	n := len(word)
+	if n < 3 || word[n-1] != 's' {
+		return word
+	}
+	switch word[n-2] {
+	case 'u', 's':
+		return word // "status", "class"
+	case 'e':
+		if n > 3 && word[n-3] == 'i' && word[n-4] != 'a' && word[n-4] != 'e' {
+			return word[:n-3] + "y"
+		}
+		if strings.ContainsRune("iaoe", rune(word[n-3])) {
+			return word // "shoes" is kept whole so "shoe" and "hoes" stay apart
+		}
+	}
+	return word[:n-1]
+}
+
+// germanUmlauts folds umlauts and ß, so inflections that add an umlaut
+// ("Haus", "Häuser") share a stem
+var germanUmlauts = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "Ä", "A", "Ö", "O", "Ü", "U", "ß", "ss")
+
+// stemGerman is Savoy's light German stemmer: it folds umlauts and strips
+// the common inflectional endings, so "Verträge" and "Vertrag" match
+func stemGerman(word string) string {
+	s := []rune(germanUmlauts.Replace(word))
+	s = germanStep1(s)
+	s = germanStep2(s)
+	return string(s)
+}
+
+// stEnding reports whether r may precede an inflectional "s" or "st"
+func stEnding(r rune) bool {
+	return strings.ContainsRune("bdfghklmnt", r)
+}
+
+func germanStep1(s []rune) []rune {
+	n := len(s)
+	switch {
+	case n > 5 && hasSuffix(s, "ern"):
+		return s[:n-3]
+	case n > 4 && (hasSuffix(s, "em") || hasSuffix(s, "en") || hasSuffix(s, "er") || hasSuffix(s, "es")):
+		return s[:n-2]
+	case n > 3 && s[n-1] == 'e':
+		return s[:n-1]
+	case n > 3 && s[n-1] == 's' && stEnding(s[n-2]):
+		return s[:n-1]
+	}
+	return s
+}
+
+func germanStep2(s []rune) []rune {
+	n := len(s)
+	switch {
+	case n > 5 && hasSuffix(s, "est"):
+		return s[:n-3]
+	case n > 4 && (hasSuffix(s, "er") || hasSuffix(s, "en")):
+		return s[:n-2]
+	case n > 4 && hasSuffix(s, "st") && stEnding(s[n-3]):
+		return s[:n-2]
+	}
+	return s
+}
+
+func hasSuffix(s []rune, suffix string) bool {
+	return strings.HasSuffix(string(s), suffix)
+}
@@ -0,0 +1,34 @@
+package analysis
+
+// stopwords are the frequent function words of each language, which match
+// nearly every chunk and drown out the words a query is about. Japanese
+// particles not listed are dropped by length; see isHiraganaParticle.
+var stopwords = map[string][]string{
+	English: {
+		"a", "about", "an", "and", "are", "as", "at", "be", "been", "but",
+		"by", "can", "did", "do", "does", "for", "from", "had", "has", "have",
+		"he", "her", "his", "how", "i", "if", "in", "into", "is", "it", "its",
+		"me", "my", "no", "not", "of", "on", "or", "our", "she", "so", "such",
+		"than", "that", "the", "their", "them", "then", "there", "these",
+		"they", "this", "those", "to", "was", "we", "were", "what", "when",
+		"where", "which", "who", "why", "will", "with", "would", "you", "your",
+	},
+	German: {
+		"aber", "als", "am", "an", "auch", "auf", "aus", "bei", "bin", "bis",
+		"bist", "da", "damit", "dann", "das", "dass", "dem", "den", "denn",
+		"der", "des", "die", "dies", "diese", "dieser", "dieses", "doch", "du",
+		"durch", "ein", "eine", "einem", "einen", "einer", "eines", "er", "es",
+		"für", "hat", "hatte", "ich", "ihr", "ihre", "im", "in", "ist", "ja",
+		"kann", "man", "mit", "nach", "nicht", "noch", "nur", "ob", "oder",
+		"sein", "seine", "sich", "sie", "sind", "so", "über", "um", "und",
+		"uns", "unter", "vom", "von", "vor", "war", "waren", "was", "wenn",
+		"werden", "wie", "wir", "wird", "wo", "zu", "zum", "zur",
+	},
+	Japanese: {
+		"あります", "ありません", "いる", "います", "および", "か", "が",
+		"から", "こと", "この", "これ", "され", "して", "します", "した",
+		"する", "その", "それ", "ため", "です", "でした", "では", "として",
+		"どの", "ない", "など", "なる", "について", "において", "ます",
+		"ません", "まで", "もの", "よう", "より", "られ", "れる",
+	},
+}
@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+// bm25K1 is BM25's term frequency saturation: a term's weight grows with
+// its count in a text but levels off, so repeating a word adds little
+const bm25K1 = 1.2
+
+// Vectorizer builds BM25-weighted sparse vectors of texts' terms, hashing
+// each term to an index. Inverse document frequency is left to the vector
+// store, which knows how many of a collection's points contain each index.
+// Documents and queries must be vectorized with the same configuration.
+type Vectorizer struct {
+	analyzer *Analyzer
+}
+
+// NewVectorizer creates a vectorizer analyzing text with cfg.
+func NewVectorizer(cfg Config) *Vectorizer {
+	return &Vectorizer{analyzer: New(cfg)}
+}
+
+// Vectorize returns the sparse vector of a text, its indices ascending.
+func (v *Vectorizer) Vectorize(text string) *vectorstore.SparseVector {
+	counts := make(map[uint32]int)
+	for _, term := range v.analyzer.Terms(text) {
+		counts[termIndex(term)]++
+	}
+	indices := make([]uint32, 0, len(counts))
+	for index := range counts {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	sv := &vectorstore.SparseVector{Indices: indices, Values: make([]float32, len(indices))}
+	for i, index := range indices {
+		tf := float64(counts[index])
+		sv.Values[i] = float32(tf * (bm25K1 + 1) / (tf + bm25K1))
+	}
+	return sv
+}
+
+// termIndex hashes a term to its sparse vector index
+func termIndex(term string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(term))
+	return h.Sum32()
+}
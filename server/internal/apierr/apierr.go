@@ -0,0 +1,136 @@
+// Package apierr defines the API's domain errors. Each maps to a gRPC status
+// code and carries a google.rpc.ErrorInfo detail whose reason clients can
+// switch on, instead of parsing messages.
+package apierr
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain of the API's errors.
+const Domain = "rag.knoguchi.github.io"
+
+// Domain errors. Return them with New or Wrap to add a message and metadata;
+// errors.Is matches the results against these.
+var (
+	// ErrQuotaExceeded is a tenant limit the request would exceed.
+	ErrQuotaExceeded = &Error{Code: codes.ResourceExhausted, Reason: "QUOTA_EXCEEDED", Message: "quota exceeded"}
+	// ErrEmbeddingFailed is a failure of the embedding model.
+	ErrEmbeddingFailed = &Error{Code: codes.Unavailable, Reason: "EMBEDDING_FAILED", Message: "embedding failed"}
+	// ErrCollectionMissing is a collection that does not exist or that the
+	// caller's tenant does not own.
+	ErrCollectionMissing = &Error{Code: codes.NotFound, Reason: "COLLECTION_MISSING", Message: "collection not found"}
+	// ErrLLMTimeout is an LLM that did not answer in time.
+	ErrLLMTimeout = &Error{Code: codes.DeadlineExceeded, Reason: "LLM_TIMEOUT", Message: "LLM timed out"}
+	// ErrTenantSuspended is a request of a suspended tenant.
+	ErrTenantSuspended = &Error{Code: codes.PermissionDenied, Reason: "TENANT_SUSPENDED", Message: "tenant is suspended"}
+	// ErrTenantArchived is a request of an archived tenant.
+	ErrTenantArchived = &Error{Code: codes.PermissionDenied, Reason: "TENANT_ARCHIVED", Message: "tenant is archived"}
+	// ErrTenantReadOnly is a change, such as ingesting a document, requested
+	// by a read-only tenant.
+	ErrTenantReadOnly = &Error{Code: codes.FailedPrecondition, Reason: "TENANT_READ_ONLY", Message: "tenant is read-only"}
+)
+
+// Error is a domain error with its gRPC status code and ErrorInfo reason.
+type Error struct {
+	// Code is the gRPC status code the error is returned with.
+	Code codes.Code
+
+	// Reason identifies the kind of error in ErrorInfo, e.g. "QUOTA_EXCEEDED".
+	Reason string
+
+	// Message describes the error.
+	Message string
+
+	// Metadata adds details to ErrorInfo, such as the limit exceeded.
+	Metadata map[string]string
+
+	cause error
+}
+
+// New returns an error of e's kind with msg, or e's message if msg is
+// empty, and metadata from alternating keys and values.
+func (e *Error) New(msg string, keyvals ...string) *Error {
+	return e.Wrap(nil, msg, keyvals...)
+}
+
+// Wrap is New for an error caused by cause, whose message is appended.
+func (e *Error) Wrap(cause error, msg string, keyvals ...string) *Error {
+	if msg == "" {
+		msg = e.Message
+	}
+	err := &Error{Code: e.Code, Reason: e.Reason, Message: msg, cause: cause}
+	if len(keyvals) > 1 {
+		err.Metadata = make(map[string]string, len(keyvals)/2)
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			err.Metadata[keyvals[i]] = keyvals[i+1]
+		}
+	}
+	return err
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the error's cause.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an error of the same kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Reason == e.Reason
+}
+
+// GRPCStatus returns the error's status with its ErrorInfo, which gRPC
+// returns for it, even when it is wrapped.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   e.Reason,
+		Domain:   Domain,
+		Metadata: e.Metadata,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// Reason returns the ErrorInfo reason of an error from the API, or "" if it
+// has none.
+func Reason(err error) string {
+	if info := Info(err); info != nil {
+		return info.Reason
+	}
+	return ""
+}
+
+// Info returns the API ErrorInfo of an error, a domain error or a status
+// received from the API, or nil if it has none.
+func Info(err error) *errdetails.ErrorInfo {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return &errdetails.ErrorInfo{Reason: domainErr.Reason, Domain: Domain, Metadata: domainErr.Metadata}
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return info
+		}
+	}
+	return nil
+}
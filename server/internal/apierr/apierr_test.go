@@ -0,0 +1,57 @@
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestError_Status(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("query: %w", ErrEmbeddingFailed.Wrap(cause, "failed to embed query", "model", "nomic-embed-text"))
+
+	if !errors.Is(err, ErrEmbeddingFailed) || errors.Is(err, ErrLLMTimeout) {
+		t.Error("errors.Is should match the error's kind only")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is should match the cause")
+	}
+
+	st := status.Convert(err)
+	if st.Code() != codes.Unavailable {
+		t.Errorf("code = %v, want Unavailable", st.Code())
+	}
+	if want := "query: failed to embed query: connection refused"; st.Message() != want {
+		t.Errorf("message = %q, want %q", st.Message(), want)
+	}
+
+	// Clients read the reason from the status they receive
+	info := Info(st.Err())
+	if info == nil || info.Reason != "EMBEDDING_FAILED" || info.Domain != Domain || info.Metadata["model"] != "nomic-embed-text" {
+		t.Errorf("ErrorInfo = %v", info)
+	}
+}
+
+func TestReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ErrCollectionMissing.New("", "collection_id", "c1"), "COLLECTION_MISSING"},
+		{ErrQuotaExceeded.New("too many terms").GRPCStatus().Err(), "QUOTA_EXCEEDED"},
+		{status.Error(codes.NotFound, "document not found"), ""},
+		{errors.New("plain"), ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := Reason(tt.err); got != tt.want {
+			t.Errorf("Reason(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+	if msg := ErrCollectionMissing.New("").Error(); msg != "collection not found" {
+		t.Errorf("default message = %q", msg)
+	}
+}
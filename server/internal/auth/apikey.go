@@ -3,6 +3,9 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"maps"
 	"strings"
 
 	"github.com/google/uuid"
@@ -22,6 +25,12 @@ const (
 
 	// tenantContextKey is the context key for storing tenant info
 	tenantContextKey contextKey = "tenant"
+
+	// adminContextKey is the context key for storing the authenticated admin
+	adminContextKey contextKey = "admin"
+
+	// adminServicePrefix prefixes all AdminService method names
+	adminServicePrefix = "/rag.v1.AdminService/"
 )
 
 // TenantInfo holds tenant information extracted from authentication
@@ -30,14 +39,26 @@ type TenantInfo struct {
 	Name   string
 	APIKey string
 	Config repository.TenantConfig
+
+	// Scopes granted by the credentials; nil for the tenant's own key, which
+	// grants every scope
+	Scopes []string
+
+	// ProjectID is the project the credentials are bound to; nil for the
+	// whole tenant
+	ProjectID *uuid.UUID
 }
 
 // APIKeyInterceptor provides gRPC interceptor for API key validation
 type APIKeyInterceptor struct {
-	tenantRepo     repository.TenantRepository
-	skipMethods    map[string]bool
-	adminAPIKey    string
-	adminMethods   map[string]bool
+	tenantRepo   repository.TenantRepository
+	skipMethods  map[string]bool
+	adminAPIKey  string
+	adminMethods map[string]bool
+	jwt          *JWTManager
+	oidc         *OIDCVerifier
+	apiKeyRepo   repository.APIKeyRepository
+	methodScopes map[string]string
 }
 
 // NewAPIKeyInterceptor creates a new API key interceptor
@@ -49,13 +70,23 @@ func NewAPIKeyInterceptor(tenantRepo repository.TenantRepository, adminAPIKey st
 			// Health check endpoints
 			"/grpc.health.v1.Health/Check": true,
 			"/grpc.health.v1.Health/Watch": true,
+			// Reflection only describes the API
+			"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+			"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+			// Token endpoints check their own credentials
+			"/rag.v1.AuthService/IssueToken":   true,
+			"/rag.v1.AuthService/RefreshToken": true,
 		},
+		methodScopes: maps.Clone(defaultMethodScopes),
 		adminMethods: map[string]bool{
 			// Tenant management requires admin auth
 			"/rag.v1.TenantService/CreateTenant":     true,
 			"/rag.v1.TenantService/ListTenants":      true,
 			"/rag.v1.TenantService/DeleteTenant":     true,
 			"/rag.v1.TenantService/RegenerateAPIKey": true,
+			"/rag.v1.TenantService/SetTenantStatus":  true,
+			"/rag.v1.TenantService/ReindexTenant":    true,
+			"/rag.v1.TenantService/GetReindexJob":    true,
 		},
 	}
 }
@@ -68,6 +99,34 @@ func (i *APIKeyInterceptor) WithSkipMethods(methods ...string) *APIKeyIntercepto
 	return i
 }
 
+// WithJWT also accepts "Authorization: Bearer <token>" for tenant methods
+func (i *APIKeyInterceptor) WithJWT(m *JWTManager) *APIKeyInterceptor {
+	i.jwt = m
+	return i
+}
+
+// WithOIDC also accepts identity provider bearer tokens for admin methods
+func (i *APIKeyInterceptor) WithOIDC(v *OIDCVerifier) *APIKeyInterceptor {
+	i.oidc = v
+	return i
+}
+
+// WithScopedKeys also accepts the tenants' scoped API keys, limiting each to
+// the methods its scopes allow
+func (i *APIKeyInterceptor) WithScopedKeys(repo repository.APIKeyRepository) *APIKeyInterceptor {
+	i.apiKeyRepo = repo
+	return i
+}
+
+// WithMethodScopes sets the scope required by tenant methods; unlisted
+// methods require ScopeAdmin
+func (i *APIKeyInterceptor) WithMethodScopes(scopes map[string]string) *APIKeyInterceptor {
+	for method, scope := range scopes {
+		i.methodScopes[method] = scope
+	}
+	return i
+}
+
 // WithAdminMethods adds methods that require admin authentication
 func (i *APIKeyInterceptor) WithAdminMethods(methods ...string) *APIKeyInterceptor {
 	for _, method := range methods {
@@ -76,6 +135,12 @@ func (i *APIKeyInterceptor) WithAdminMethods(methods ...string) *APIKeyIntercept
 	return i
 }
 
+// isAdminMethod reports whether a method requires the admin API key.
+// Every AdminService method is admin-only.
+func (i *APIKeyInterceptor) isAdminMethod(method string) bool {
+	return i.adminMethods[method] || strings.HasPrefix(method, adminServicePrefix)
+}
+
 // UnaryInterceptor returns a gRPC unary interceptor for API key validation
 func (i *APIKeyInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(
@@ -89,42 +154,11 @@ func (i *APIKeyInterceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
-		// Extract API key from metadata
-		apiKey, err := extractAPIKey(ctx)
+		ctx, err := i.authenticate(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
 
-		// Check if this is an admin method
-		if i.adminMethods[info.FullMethod] {
-			if i.adminAPIKey == "" {
-				return nil, status.Error(codes.PermissionDenied, "admin API key not configured")
-			}
-			if apiKey != i.adminAPIKey {
-				return nil, status.Error(codes.PermissionDenied, "invalid admin API key")
-			}
-			// Admin methods don't need tenant context
-			return handler(ctx, req)
-		}
-
-		// Validate tenant API key
-		tenant, err := i.tenantRepo.GetByAPIKey(ctx, apiKey)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to validate API key: %v", err)
-		}
-		if tenant == nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid API key")
-		}
-
-		// Store tenant info in context
-		tenantInfo := &TenantInfo{
-			ID:     tenant.ID,
-			Name:   tenant.Name,
-			APIKey: tenant.APIKey,
-			Config: tenant.Config,
-		}
-		ctx = context.WithValue(ctx, tenantContextKey, tenantInfo)
-
 		return handler(ctx, req)
 	}
 }
@@ -142,48 +176,164 @@ func (i *APIKeyInterceptor) StreamInterceptor() grpc.StreamServerInterceptor {
 			return handler(srv, ss)
 		}
 
-		ctx := ss.Context()
-
-		// Extract API key from metadata
-		apiKey, err := extractAPIKey(ctx)
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		// Check if this is an admin method
-		if i.adminMethods[info.FullMethod] {
-			if i.adminAPIKey == "" {
-				return status.Error(codes.PermissionDenied, "admin API key not configured")
+		// Store tenant info in context using wrapped stream
+		wrappedStream := &wrappedServerStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		}
+
+		return handler(srv, wrappedStream)
+	}
+}
+
+// authenticate validates the request's credentials for a method and returns
+// the context with tenant or admin info attached. Tenants authenticate with
+// their API key or, when JWTs are enabled, a bearer token; admin methods need
+// the admin key or, when OIDC is enabled, an identity provider token.
+func (i *APIKeyInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if token, ok := BearerToken(ctx); ok {
+		switch {
+		case i.isAdminMethod(method) && i.oidc != nil:
+			admin, err := i.adminFromOIDC(ctx, token)
+			if err != nil {
+				return nil, err
 			}
-			if apiKey != i.adminAPIKey {
-				return status.Error(codes.PermissionDenied, "invalid admin API key")
+			return WithAdmin(ctx, admin), nil
+		case i.isAdminMethod(method) && i.jwt != nil:
+			return nil, status.Error(codes.PermissionDenied, "admin methods require the admin API key")
+		case i.jwt != nil:
+			tenant, key, err := i.tenantFromToken(ctx, token)
+			if err != nil {
+				return nil, err
 			}
-			return handler(srv, ss)
+			return i.authorizeTenant(ctx, method, tenant, key)
 		}
+	}
 
-		// Validate tenant API key
-		tenant, err := i.tenantRepo.GetByAPIKey(ctx, apiKey)
-		if err != nil {
-			return status.Errorf(codes.Internal, "failed to validate API key: %v", err)
-		}
-		if tenant == nil {
-			return status.Error(codes.Unauthenticated, "invalid API key")
+	// Extract API key from metadata
+	apiKey, err := extractAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if this is an admin method
+	if i.isAdminMethod(method) {
+		if err := checkAdminKey(apiKey, i.adminAPIKey); err != nil {
+			return nil, err
 		}
+		// Admin methods don't need tenant context
+		return WithAdmin(ctx, &AdminInfo{Subject: "admin-api-key", Method: "api_key"}), nil
+	}
 
-		// Store tenant info in context using wrapped stream
-		tenantInfo := &TenantInfo{
-			ID:     tenant.ID,
-			Name:   tenant.Name,
-			APIKey: tenant.APIKey,
-			Config: tenant.Config,
+	// Validate tenant API key
+	tenant, key, err := ResolveAPIKey(ctx, i.tenantRepo, i.apiKeyRepo, apiKey)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to validate API key: %v", err)
+	}
+
+	return i.authorizeTenant(ctx, method, tenant, key)
+}
+
+// authorizeTenant checks the scoped key, if any, and the tenant's status allow
+// the method and returns the context with tenant info attached
+func (i *APIKeyInterceptor) authorizeTenant(ctx context.Context, method string, tenant *repository.Tenant, key *repository.APIKey) (context.Context, error) {
+	var scopes []string
+	if key != nil {
+		scopes = key.Scopes
+	}
+	ctx = WithTenant(ctx, tenant, scopes)
+	info, _ := TenantFromContext(ctx)
+	if key != nil {
+		info.ProjectID = key.ProjectID
+	}
+	scope := i.methodScope(method)
+	if !info.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key lacks the %q scope", scope)
+	}
+	if err := CheckTenantStatus(tenant, scope); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// tenantFromToken validates a bearer token and loads its tenant. Tokens bound
+// to an API key stop working once the tenant regenerates that key.
+func (i *APIKeyInterceptor) tenantFromToken(ctx context.Context, token string) (*repository.Tenant, *repository.APIKey, error) {
+	claims, err := i.jwt.ValidateToken(token)
+	if err != nil {
+		if errors.Is(err, ErrExpiredToken) {
+			return nil, nil, status.Error(codes.Unauthenticated, "token has expired; refresh it with AuthService.RefreshToken")
 		}
-		wrappedStream := &wrappedServerStream{
-			ServerStream: ss,
-			ctx:          context.WithValue(ctx, tenantContextKey, tenantInfo),
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return TenantForClaims(ctx, i.tenantRepo, i.apiKeyRepo, claims)
+}
+
+// adminFromOIDC validates an identity provider token for an admin method
+func (i *APIKeyInterceptor) adminFromOIDC(ctx context.Context, token string) (*AdminInfo, error) {
+	admin, err := i.oidc.Verify(ctx, token)
+	switch {
+	case errors.Is(err, ErrExpiredToken):
+		return nil, status.Error(codes.Unauthenticated, "identity token has expired")
+	case errors.Is(err, ErrInvalidClaims):
+		return nil, status.Errorf(codes.PermissionDenied, "identity token not accepted: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Unauthenticated, "invalid identity token: %v", err)
+	}
+	return admin, nil
+}
+
+// TenantForClaims loads the tenant a token was issued to and, for tokens
+// exchanged for a scoped key, that key. Tokens whose tenant was deleted or
+// whose API key has since been regenerated or revoked are rejected, as are
+// tokens that don't name the key they were exchanged for.
+func TenantForClaims(ctx context.Context, tenants repository.TenantRepository, keys repository.APIKeyRepository, claims *Claims) (*repository.Tenant, *repository.APIKey, error) {
+	tenantID, err := claims.GetTenantID()
+	if err != nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	tenant, err := tenants.GetByID(ctx, tenantID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, status.Error(codes.Unauthenticated, "token tenant no longer exists")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to load tenant: %v", err)
+	}
+
+	if claims.KeyFingerprint == "" {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if claims.KeyID == "" {
+		if claims.KeyFingerprint != keyFingerprint(tenant.APIKey) {
+			return nil, nil, status.Error(codes.Unauthenticated, "token was revoked by an API key change")
 		}
+		return tenant, nil, nil
+	}
 
-		return handler(srv, wrappedStream)
+	// Exchanged for a scoped key: the key must still exist
+	keyID, err := uuid.Parse(claims.KeyID)
+	if err != nil || keys == nil {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	key, err := keys.GetByID(ctx, keyID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, status.Error(codes.Unauthenticated, "token was revoked with its API key")
 	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to load API key: %v", err)
+	}
+	if key.TenantID != tenant.ID || claims.KeyFingerprint != keyFingerprint(key.Key) {
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return tenant, key, nil
 }
 
 // wrappedServerStream wraps a grpc.ServerStream with a modified context
@@ -217,6 +367,74 @@ func extractAPIKey(ctx context.Context) (string, error) {
 	return apiKey, nil
 }
 
+// APIKeyFromContext returns the API key sent in the x-api-key header
+func APIKeyFromContext(ctx context.Context) (string, error) {
+	return extractAPIKey(ctx)
+}
+
+// BearerToken returns the token from an "Authorization: Bearer" header, if any
+func BearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+		if found && strings.EqualFold(scheme, "bearer") && strings.TrimSpace(token) != "" {
+			return strings.TrimSpace(token), true
+		}
+	}
+	return "", false
+}
+
+// checkAdminKey compares a presented API key against the configured admin key
+func checkAdminKey(apiKey, adminAPIKey string) error {
+	if adminAPIKey == "" {
+		return status.Error(codes.PermissionDenied, "admin API key not configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey), []byte(adminAPIKey)) != 1 {
+		return status.Error(codes.PermissionDenied, "invalid admin API key")
+	}
+	return nil
+}
+
+// VerifyAdminKey checks that the incoming request carries the admin API key,
+// or was already authenticated as an admin by the interceptor. Services use it
+// to protect admin endpoints independently of the interceptor.
+func VerifyAdminKey(ctx context.Context, adminAPIKey string) error {
+	if _, ok := AdminFromContext(ctx); ok {
+		return nil
+	}
+	apiKey, err := extractAPIKey(ctx)
+	if err != nil {
+		return err
+	}
+	return checkAdminKey(apiKey, adminAPIKey)
+}
+
+// WithTenant returns a context carrying the tenant as the authenticated
+// caller, limited to scopes (nil for every scope)
+func WithTenant(ctx context.Context, tenant *repository.Tenant, scopes []string) context.Context {
+	return context.WithValue(ctx, tenantContextKey, &TenantInfo{
+		ID:     tenant.ID,
+		Name:   tenant.Name,
+		APIKey: tenant.APIKey,
+		Config: tenant.Config,
+		Scopes: scopes,
+	})
+}
+
+// WithAdmin returns a context carrying the authenticated admin
+func WithAdmin(ctx context.Context, admin *AdminInfo) context.Context {
+	return context.WithValue(ctx, adminContextKey, admin)
+}
+
+// AdminFromContext extracts the authenticated admin from context
+func AdminFromContext(ctx context.Context) (*AdminInfo, bool) {
+	admin, ok := ctx.Value(adminContextKey).(*AdminInfo)
+	return admin, ok
+}
+
 // TenantFromContext extracts tenant info from context
 func TenantFromContext(ctx context.Context) (*TenantInfo, bool) {
 	tenant, ok := ctx.Value(tenantContextKey).(*TenantInfo)
@@ -1,12 +1,15 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
 )
 
 var (
@@ -23,14 +26,25 @@ type Claims struct {
 	jwt.RegisteredClaims
 	TenantID   string `json:"tenant_id"`
 	TenantName string `json:"tenant_name,omitempty"`
+
+	// KeyFingerprint identifies the API key the token was exchanged for, so
+	// regenerating the key revokes the token
+	KeyFingerprint string `json:"key_fp,omitempty"`
+
+	// KeyID and Scopes are set for tokens exchanged for a scoped API key
+	KeyID  string   `json:"key_id,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // JWTConfig holds configuration for JWT token generation and validation
 type JWTConfig struct {
-	Secret     string
-	Expiry     time.Duration
-	Issuer     string
+	Secret        string
+	Expiry        time.Duration
+	Issuer        string
 	SigningMethod jwt.SigningMethod
+
+	// RefreshWindow is how long after expiry a token may still be refreshed
+	RefreshWindow time.Duration
 }
 
 // DefaultJWTConfig returns a default JWT configuration
@@ -40,6 +54,7 @@ func DefaultJWTConfig(secret string) *JWTConfig {
 		Expiry:        24 * time.Hour,
 		Issuer:        "rag-service",
 		SigningMethod: jwt.SigningMethodHS256,
+		RefreshWindow: 7 * 24 * time.Hour,
 	}
 }
 
@@ -126,58 +141,119 @@ func (c *Claims) GetTenantID() (uuid.UUID, error) {
 	return uuid.Parse(c.TenantID)
 }
 
-// RefreshToken creates a new token based on an existing valid token
-func (m *JWTManager) RefreshToken(tokenString string) (string, error) {
-	claims, err := m.ValidateToken(tokenString)
+// IssueTenantToken generates a token bound to the API key it was exchanged
+// for: the tenant's own key, or the scoped key when key is non-nil. A ttl of
+// zero or more than the configured expiry uses the configured expiry.
+func (m *JWTManager) IssueTenantToken(tenant *repository.Tenant, key *repository.APIKey, ttl time.Duration) (string, time.Time, error) {
+	if ttl <= 0 || ttl > m.config.Expiry {
+		ttl = m.config.Expiry
+	}
+	claims := &Claims{
+		TenantID:       tenant.ID.String(),
+		TenantName:     tenant.Name,
+		KeyFingerprint: keyFingerprint(tenant.APIKey),
+	}
+	if key != nil {
+		claims.KeyFingerprint = keyFingerprint(key.Key)
+		claims.KeyID = key.ID.String()
+		claims.Scopes = key.Scopes
+	}
+	return m.issue(claims, ttl)
+}
+
+// issue fills in the registered claims and signs a token with the given lifetime
+func (m *JWTManager) issue(claims *Claims, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.New().String(),
+		Issuer:    m.config.Issuer,
+		Subject:   claims.TenantID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	token, err := jwt.NewWithClaims(m.config.SigningMethod, claims).SignedString([]byte(m.config.Secret))
 	if err != nil {
-		// Allow refreshing tokens that are expired but otherwise valid
-		if !errors.Is(err, ErrExpiredToken) {
-			return "", err
-		}
-		// Re-parse to get the claims even if expired
-		token, _ := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(m.config.Secret), nil
-		}, jwt.WithoutClaimsValidation())
-		if token == nil {
-			return "", ErrInvalidToken
-		}
-		var ok bool
-		claims, ok = token.Claims.(*Claims)
-		if !ok {
-			return "", ErrInvalidClaims
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// keyFingerprint is a short, non-reversible identifier for an API key
+func keyFingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// parseAllowExpired verifies a token's signature and algorithm but not its
+// time-based claims
+func (m *JWTManager) parseAllowExpired(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != m.config.SigningMethod.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
+		return []byte(m.config.Secret), nil
+	}, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+	return claims, nil
+}
 
-	tenantID, err := claims.GetTenantID()
+// ValidateForRefresh returns the claims of a token that is valid or expired
+// no longer than the refresh window ago
+func (m *JWTManager) ValidateForRefresh(tokenString string) (*Claims, error) {
+	claims, err := m.ValidateToken(tokenString)
+	if err == nil || !errors.Is(err, ErrExpiredToken) {
+		return claims, err
+	}
+
+	claims, err = m.parseAllowExpired(tokenString)
 	if err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil || time.Since(claims.ExpiresAt.Time) > m.config.RefreshWindow {
+		return nil, ErrExpiredToken
+	}
+	return claims, nil
+}
+
+// RefreshToken creates a new token based on an existing valid token, or one
+// that expired within the refresh window
+func (m *JWTManager) RefreshToken(tokenString string) (string, error) {
+	claims, err := m.ValidateForRefresh(tokenString)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := claims.GetTenantID(); err != nil {
 		return "", fmt.Errorf("invalid tenant ID in claims: %w", err)
 	}
 
-	return m.GenerateToken(tenantID, claims.TenantName)
+	// Keep the key binding so a refreshed token is still revoked with the key
+	token, _, err := m.issue(&Claims{
+		TenantID:       claims.TenantID,
+		TenantName:     claims.TenantName,
+		KeyFingerprint: claims.KeyFingerprint,
+		KeyID:          claims.KeyID,
+		Scopes:         claims.Scopes,
+	}, m.config.Expiry)
+	return token, err
 }
 
 // TokenExpiry returns the expiry time of a token
 func (m *JWTManager) TokenExpiry(tokenString string) (time.Time, error) {
-	claims, err := m.ValidateToken(tokenString)
-	if err != nil && !errors.Is(err, ErrExpiredToken) {
+	claims, err := m.parseAllowExpired(tokenString)
+	if err != nil {
 		return time.Time{}, err
 	}
 
-	// If validation failed due to expiry, re-parse without validation
-	if errors.Is(err, ErrExpiredToken) {
-		token, _ := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(m.config.Secret), nil
-		}, jwt.WithoutClaimsValidation())
-		if token == nil {
-			return time.Time{}, ErrInvalidToken
-		}
-		var ok bool
-		claims, ok = token.Claims.(*Claims)
-		if !ok {
-			return time.Time{}, ErrInvalidClaims
-		}
-	}
-
 	if claims.ExpiresAt == nil {
 		return time.Time{}, errors.New("token has no expiry")
 	}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateForRefresh(t *testing.T) {
+	cfg := DefaultJWTConfig("secret")
+	cfg.RefreshWindow = time.Hour
+	m := NewJWTManager(cfg)
+	tenantID := uuid.New()
+
+	token, _, err := m.issue(&Claims{TenantID: tenantID.String(), KeyFingerprint: keyFingerprint("key")}, -30*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ValidateToken(token); !errors.Is(err, ErrExpiredToken) {
+		t.Fatalf("ValidateToken error = %v, want expired", err)
+	}
+	claims, err := m.ValidateForRefresh(token)
+	if err != nil {
+		t.Fatalf("token inside the refresh window rejected: %v", err)
+	}
+	if claims.KeyFingerprint != keyFingerprint("key") {
+		t.Error("key fingerprint lost")
+	}
+
+	stale, _, _ := m.issue(&Claims{TenantID: tenantID.String()}, -2*time.Hour)
+	if _, err := m.ValidateForRefresh(stale); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("token past the refresh window: error = %v, want expired", err)
+	}
+
+	// Tokens signed with another secret are never refreshable
+	forged, _, _ := NewJWTManager(DefaultJWTConfig("other")).issue(&Claims{TenantID: tenantID.String()}, -time.Minute)
+	if _, err := m.ValidateForRefresh(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("forged token: error = %v, want invalid", err)
+	}
+	if _, err := m.RefreshToken(forged); err == nil {
+		t.Error("forged token refreshed")
+	}
+}
@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// jwksCacheTTL is how long fetched signing keys are trusted before a refetch
+	jwksCacheTTL = time.Hour
+	// jwksMinRefresh limits refetches triggered by tokens with unknown key IDs
+	jwksMinRefresh = time.Minute
+)
+
+// oidcSigningMethods are the asymmetric algorithms accepted from an identity provider
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// OIDCConfig configures validation of identity provider tokens for admin methods
+type OIDCConfig struct {
+	// IssuerURL is the provider's issuer; its discovery document is read from
+	// <IssuerURL>/.well-known/openid-configuration
+	IssuerURL string
+	// ClientID and Audience are the accepted "aud" values; at least one is required
+	ClientID string
+	Audience string
+
+	// AdminGroups, when set, restricts admin access to tokens listing one of
+	// these values in GroupsClaim (default "groups")
+	AdminGroups []string
+	GroupsClaim string
+
+	// HTTPClient fetches the discovery document and keys (default 10s timeout)
+	HTTPClient *http.Client
+}
+
+// AdminInfo identifies the operator authenticated for an admin method
+type AdminInfo struct {
+	Subject string
+	Email   string
+	// Method is "api_key" or "oidc"
+	Method string
+}
+
+// OIDCVerifier validates tokens issued by an OpenID Connect provider. The
+// provider's signing keys are fetched lazily and cached, so the service starts
+// even when the provider is briefly unreachable.
+type OIDCVerifier struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	jwksURI   string
+	keys      map[string]crypto.PublicKey
+	fetched   time.Time
+	attempted time.Time
+}
+
+// NewOIDCVerifier creates a verifier for the configured issuer
+func NewOIDCVerifier(cfg OIDCConfig) (*OIDCVerifier, error) {
+	if cfg.IssuerURL == "" {
+		return nil, errors.New("OIDC issuer URL is required")
+	}
+	if cfg.ClientID == "" && cfg.Audience == "" {
+		return nil, errors.New("OIDC client ID or audience is required")
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCVerifier{cfg: cfg, client: client}, nil
+}
+
+// Verify validates a raw token's signature, issuer, audience, expiry and,
+// when configured, admin group membership
+func (v *OIDCVerifier) Verify(ctx context.Context, raw string) (*AdminInfo, error) {
+	token, err := jwt.Parse(raw, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, kid)
+	},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(v.cfg.IssuerURL),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidClaims
+	}
+
+	aud, err := claims.GetAudience()
+	if err != nil || !v.audienceAllowed(aud) {
+		return nil, fmt.Errorf("%w: audience not accepted", ErrInvalidClaims)
+	}
+
+	if len(v.cfg.AdminGroups) > 0 && !v.inAdminGroup(claims[v.cfg.GroupsClaim]) {
+		return nil, fmt.Errorf("%w: not a member of an admin group", ErrInvalidClaims)
+	}
+
+	subject, _ := claims.GetSubject()
+	email, _ := claims["email"].(string)
+	return &AdminInfo{Subject: subject, Email: email, Method: "oidc"}, nil
+}
+
+func (v *OIDCVerifier) audienceAllowed(aud []string) bool {
+	for _, want := range []string{v.cfg.Audience, v.cfg.ClientID} {
+		if want != "" && slices.Contains(aud, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// inAdminGroup accepts a groups claim given as a list or a single string
+func (v *OIDCVerifier) inAdminGroup(claim interface{}) bool {
+	var groups []string
+	switch c := claim.(type) {
+	case string:
+		groups = []string{c}
+	case []interface{}:
+		for _, g := range c {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+	for _, g := range groups {
+		if slices.Contains(v.cfg.AdminGroups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the signing key for a key ID, refetching the key set when the
+// cache is stale or the ID is unknown (the provider may have rotated keys)
+func (v *OIDCVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key, ok := v.lookup(kid)
+	if ok && time.Since(v.fetched) < jwksCacheTTL {
+		return key, nil
+	}
+	if time.Since(v.attempted) >= jwksMinRefresh {
+		v.attempted = time.Now()
+		err := v.refresh(ctx)
+		if err == nil {
+			key, ok = v.lookup(kid)
+		} else if !ok {
+			return nil, err
+		}
+		// On failure keep serving the cached key while the provider is unreachable
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// lookup finds a cached key; tokens without a key ID match a sole key
+func (v *OIDCVerifier) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+// refresh reads the discovery document, if not yet done, and the key set
+func (v *OIDCVerifier) refresh(ctx context.Context) error {
+	if v.jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		url := strings.TrimSuffix(v.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+		if err := v.getJSON(ctx, url, &discovery); err != nil {
+			return fmt.Errorf("OIDC discovery: %w", err)
+		}
+		if discovery.Issuer != v.cfg.IssuerURL {
+			return fmt.Errorf("OIDC discovery: issuer %q does not match %q", discovery.Issuer, v.cfg.IssuerURL)
+		}
+		if discovery.JWKSURI == "" {
+			return errors.New("OIDC discovery: no jwks_uri")
+		}
+		v.jwksURI = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, v.jwksURI, &set); err != nil {
+		return fmt.Errorf("OIDC signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// Skip key types we don't use rather than rejecting the whole set
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("OIDC signing keys: no usable keys")
+	}
+
+	v.keys, v.fetched = keys, time.Now()
+	return nil
+}
+
+func (v *OIDCVerifier) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jsonWebKey is an RSA or EC public key from a JWK set (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestOIDCVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	issuer = srv.URL
+
+	v, err := NewOIDCVerifier(OIDCConfig{IssuerURL: issuer, ClientID: "rag-admin", AdminGroups: []string{"ops"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		raw, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":    issuer,
+			"aud":    "rag-admin",
+			"sub":    "user-1",
+			"email":  "ops@example.com",
+			"groups": []string{"eng", "ops"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}
+		for k, val := range overrides {
+			c[k] = val
+		}
+		return c
+	}
+
+	ctx := context.Background()
+	admin, err := v.Verify(ctx, sign(claims(nil)))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if admin.Subject != "user-1" || admin.Email != "ops@example.com" || admin.Method != "oidc" {
+		t.Errorf("admin = %+v", admin)
+	}
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   error
+	}{
+		{"wrong audience", claims(jwt.MapClaims{"aud": "other"}), ErrInvalidClaims},
+		{"wrong issuer", claims(jwt.MapClaims{"iss": "https://evil.example.com"}), ErrInvalidToken},
+		{"not in admin group", claims(jwt.MapClaims{"groups": []string{"eng"}}), ErrInvalidClaims},
+		{"expired", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}), ErrExpiredToken},
+		{"no expiry", claims(jwt.MapClaims{"exp": nil}), ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		if tt.claims["exp"] == nil {
+			delete(tt.claims, "exp")
+		}
+		if _, err := v.Verify(ctx, sign(tt.claims)); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// Symmetric tokens are refused even when signed with a guessable secret
+	hs, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(nil)).SignedString([]byte("k1"))
+	if _, err := v.Verify(ctx, hs); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("HS256 token: error = %v, want invalid", err)
+	}
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// API key scopes. A tenant's own key has every scope; additional keys carry a subset.
+const (
+	// ScopeRead allows queries and reading documents, feeds and tenant settings
+	ScopeRead = "read"
+	// ScopeIngest allows adding documents and feeds
+	ScopeIngest = "ingest"
+	// ScopeAdmin allows everything a tenant can do, including deletes and settings
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists the valid API key scopes
+var Scopes = []string{ScopeRead, ScopeIngest, ScopeAdmin}
+
+// defaultMethodScopes maps tenant methods to the scope they require. Tenant
+// methods not listed require ScopeAdmin, so new methods are closed to
+// restricted keys until they are classified here.
+var defaultMethodScopes = map[string]string{
+	"/rag.v1.RAGService/Query":             ScopeRead,
+	"/rag.v1.RAGService/QueryStream":       ScopeRead,
+	"/rag.v1.RAGService/Retrieve":          ScopeRead,
+	"/rag.v1.RAGService/ExplainQuery":      ScopeRead,
+	"/rag.v1.RAGService/Summarize":         ScopeRead,
+	"/rag.v1.RAGService/GenerateQuestions": ScopeRead,
+
+	"/rag.v1.DocumentService/GetDocument":        ScopeRead,
+	"/rag.v1.DocumentService/ListDocuments":      ScopeRead,
+	"/rag.v1.DocumentService/GetDocumentChunks":  ScopeRead,
+	"/rag.v1.DocumentService/ListDocumentsByTag": ScopeRead,
+	"/rag.v1.DocumentService/GetDocumentContent": ScopeRead,
+	"/rag.v1.DocumentService/WatchDocument":      ScopeRead,
+	"/rag.v1.DocumentService/IngestDocument":     ScopeIngest,
+	"/rag.v1.DocumentService/IngestURL":          ScopeIngest,
+	"/rag.v1.DocumentService/UploadDocument":     ScopeIngest,
+	"/rag.v1.DocumentService/AddTags":            ScopeIngest,
+	"/rag.v1.DocumentService/RemoveTags":         ScopeIngest,
+	"/rag.v1.DocumentService/RechunkDocument":    ScopeIngest,
+	"/rag.v1.DocumentService/PreviewChunks":      ScopeIngest,
+
+	"/rag.v1.CollectionService/GetCollection":    ScopeRead,
+	"/rag.v1.CollectionService/ListCollections":  ScopeRead,
+	"/rag.v1.CollectionService/CreateCollection": ScopeIngest,
+	"/rag.v1.CollectionService/AddDocuments":     ScopeIngest,
+	"/rag.v1.CollectionService/RemoveDocuments":  ScopeIngest,
+
+	"/rag.v1.PromptService/GetPromptTemplate":   ScopeRead,
+	"/rag.v1.PromptService/ListPromptTemplates": ScopeRead,
+
+	"/rag.v1.SynonymService/ListSynonyms": ScopeRead,
+
+	"/rag.v1.ProjectService/GetProject":   ScopeRead,
+	"/rag.v1.ProjectService/ListProjects": ScopeRead,
+
+	"/rag.v1.SessionService/CreateSession":     ScopeRead,
+	"/rag.v1.SessionService/GetSessionHistory": ScopeRead,
+	"/rag.v1.SessionService/ClearSession":      ScopeRead,
+	"/rag.v1.SessionService/ListSessions":      ScopeRead,
+
+	"/rag.v1.FeedService/ListFeeds":  ScopeRead,
+	"/rag.v1.FeedService/CreateFeed": ScopeIngest,
+	"/rag.v1.FeedService/SyncFeed":   ScopeIngest,
+
+	"/rag.v1.CrawlService/GetCrawlJob":   ScopeRead,
+	"/rag.v1.CrawlService/ListCrawlJobs": ScopeRead,
+	"/rag.v1.CrawlService/StartCrawl":    ScopeIngest,
+
+	"/rag.v1.TenantService/GetTenant": ScopeRead,
+}
+
+// ValidScope reports whether scope is a known API key scope
+func ValidScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+// HasScope reports whether the tenant's credentials grant a scope. Nil scopes
+// mean the tenant's own key, which grants everything.
+func (t *TenantInfo) HasScope(scope string) bool {
+	return t.Scopes == nil || slices.Contains(t.Scopes, ScopeAdmin) || slices.Contains(t.Scopes, scope)
+}
+
+// methodScope returns the scope a tenant method requires
+func (i *APIKeyInterceptor) methodScope(method string) string {
+	if scope, ok := i.methodScopes[method]; ok {
+		return scope
+	}
+	return ScopeAdmin
+}
+
+// ResolveAPIKey finds the tenant for an API key: either the tenant's own key
+// or, when keys is non-nil, a scoped key. The scoped key is nil for the
+// tenant's own key. Returns repository.ErrNotFound for unknown keys.
+func ResolveAPIKey(ctx context.Context, tenants repository.TenantRepository, keys repository.APIKeyRepository, apiKey string) (*repository.Tenant, *repository.APIKey, error) {
+	tenant, err := tenants.GetByAPIKey(ctx, apiKey)
+	if err == nil || !errors.Is(err, repository.ErrNotFound) || keys == nil {
+		return tenant, nil, err
+	}
+
+	key, err := keys.GetByKey(ctx, apiKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	tenant, err = tenants.GetByID(ctx, key.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, key, nil
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTenants struct {
+	repository.TenantRepository
+	tenant *repository.Tenant
+}
+
+func (f *fakeTenants) GetByAPIKey(_ context.Context, apiKey string) (*repository.Tenant, error) {
+	if apiKey == f.tenant.APIKey {
+		return f.tenant, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeTenants) GetByID(_ context.Context, id uuid.UUID) (*repository.Tenant, error) {
+	if id == f.tenant.ID {
+		return f.tenant, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+type fakeKeys struct {
+	repository.APIKeyRepository
+	keys []*repository.APIKey
+}
+
+func (f *fakeKeys) GetByKey(_ context.Context, apiKey string) (*repository.APIKey, error) {
+	for _, k := range f.keys {
+		if k.Key == apiKey {
+			return k, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeKeys) GetByID(_ context.Context, id uuid.UUID) (*repository.APIKey, error) {
+	for _, k := range f.keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func TestScopedKeys(t *testing.T) {
+	tenant := &repository.Tenant{ID: uuid.New(), Name: "acme", APIKey: "rag_owner"}
+	widget := &repository.APIKey{ID: uuid.New(), TenantID: tenant.ID, Key: "rag_widget", Scopes: []string{ScopeRead}}
+	keys := &fakeKeys{keys: []*repository.APIKey{widget}}
+	jwtManager := NewJWTManager(DefaultJWTConfig("secret"))
+	interceptor := NewAPIKeyInterceptor(&fakeTenants{tenant: tenant}, "admin").
+		WithJWT(jwtManager).
+		WithScopedKeys(keys)
+	unary := interceptor.UnaryInterceptor()
+
+	call := func(method string, md ...string) codes.Code {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(md...))
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			if _, ok := TenantFromContext(ctx); !ok {
+				t.Errorf("%s: no tenant in context", method)
+			}
+			return nil, nil
+		})
+		return status.Code(err)
+	}
+
+	token, _, err := jwtManager.IssueTenantToken(tenant, widget, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unbound, err := jwtManager.GenerateToken(tenant.ID, tenant.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		md     []string
+		want   codes.Code
+	}{
+		{"owner key deletes", "/rag.v1.DocumentService/DeleteDocument", []string{APIKeyHeader, "rag_owner"}, codes.OK},
+		{"widget key queries", "/rag.v1.RAGService/Query", []string{APIKeyHeader, "rag_widget"}, codes.OK},
+		{"widget key cannot delete", "/rag.v1.DocumentService/DeleteDocument", []string{APIKeyHeader, "rag_widget"}, codes.PermissionDenied},
+		{"widget key cannot ingest", "/rag.v1.DocumentService/IngestDocument", []string{APIKeyHeader, "rag_widget"}, codes.PermissionDenied},
+		{"unclassified methods need admin", "/rag.v1.FutureService/Do", []string{APIKeyHeader, "rag_widget"}, codes.PermissionDenied},
+		{"widget token queries", "/rag.v1.RAGService/Query", []string{"authorization", "Bearer " + token}, codes.OK},
+		{"widget token cannot delete", "/rag.v1.DocumentService/DeleteDocument", []string{"authorization", "Bearer " + token}, codes.PermissionDenied},
+		{"token without key fingerprint", "/rag.v1.RAGService/Query", []string{"authorization", "Bearer " + unbound}, codes.Unauthenticated},
+		{"unknown key", "/rag.v1.RAGService/Query", []string{APIKeyHeader, "rag_nope"}, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		if got := call(tt.method, tt.md...); got != tt.want {
+			t.Errorf("%s: code = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Revoking the key revokes tokens exchanged for it
+	keys.keys = nil
+	if got := call("/rag.v1.RAGService/Query", "authorization", "Bearer "+token); got != codes.Unauthenticated {
+		t.Errorf("revoked key's token: code = %v, want Unauthenticated", got)
+	}
+}
+
+func TestProjectKeys(t *testing.T) {
+	tenant := &repository.Tenant{ID: uuid.New(), Name: "acme", APIKey: "rag_owner"}
+	projectID := uuid.New()
+	projectKey := &repository.APIKey{ID: uuid.New(), TenantID: tenant.ID, Key: "rag_project", Scopes: []string{ScopeRead}, ProjectID: &projectID}
+	jwtManager := NewJWTManager(DefaultJWTConfig("secret"))
+	unary := NewAPIKeyInterceptor(&fakeTenants{tenant: tenant}, "admin").
+		WithJWT(jwtManager).
+		WithScopedKeys(&fakeKeys{keys: []*repository.APIKey{projectKey}}).
+		UnaryInterceptor()
+
+	project := func(md ...string) *uuid.UUID {
+		var got *uuid.UUID
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(md...))
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/rag.v1.RAGService/Query"}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			info, _ := TenantFromContext(ctx)
+			got = info.ProjectID
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		return got
+	}
+
+	token, _, err := jwtManager.IssueTenantToken(tenant, projectKey, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := project(APIKeyHeader, "rag_owner"); got != nil {
+		t.Errorf("owner key: project = %v, want none", got)
+	}
+	if got := project(APIKeyHeader, "rag_project"); got == nil || *got != projectID {
+		t.Errorf("project key: project = %v, want %v", got, projectID)
+	}
+	if got := project("authorization", "Bearer "+token); got == nil || *got != projectID {
+		t.Errorf("project token: project = %v, want %v", got, projectID)
+	}
+}
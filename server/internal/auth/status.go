@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// CheckTenantStatus returns the error for a request needing scope that a tenant's
+// lifecycle status forbids, or nil. Suspended and archived tenants may make
+// no requests; read-only tenants only those needing ScopeRead.
+func CheckTenantStatus(tenant *repository.Tenant, scope string) error {
+	var err *apierr.Error
+	switch tenant.Status {
+	case repository.TenantSuspended:
+		err = apierr.ErrTenantSuspended
+	case repository.TenantArchived:
+		err = apierr.ErrTenantArchived
+	case repository.TenantReadOnly:
+		if scope == ScopeRead {
+			return nil
+		}
+		err = apierr.ErrTenantReadOnly
+	default:
+		return nil
+	}
+
+	msg := err.Message
+	if tenant.StatusReason != "" {
+		msg += ": " + tenant.StatusReason
+	}
+	return err.New(msg, "tenant_id", tenant.ID.String(), "status", tenant.Status)
+}
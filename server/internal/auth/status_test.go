@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantStatus(t *testing.T) {
+	tenant := &repository.Tenant{ID: uuid.New(), Name: "acme", APIKey: "rag_owner"}
+	unary := NewAPIKeyInterceptor(&fakeTenants{tenant: tenant}, "admin").UnaryInterceptor()
+
+	call := func(method string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, "rag_owner"))
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	const (
+		query  = "/rag.v1.RAGService/Query"
+		ingest = "/rag.v1.DocumentService/IngestDocument"
+		update = "/rag.v1.TenantService/UpdateTenant"
+	)
+	tests := []struct {
+		status string
+		method string
+		want   codes.Code
+		reason string
+	}{
+		{repository.TenantActive, ingest, codes.OK, ""},
+		{"", ingest, codes.OK, ""},
+		{repository.TenantReadOnly, query, codes.OK, ""},
+		{repository.TenantReadOnly, ingest, codes.FailedPrecondition, "TENANT_READ_ONLY"},
+		{repository.TenantReadOnly, update, codes.FailedPrecondition, "TENANT_READ_ONLY"},
+		{repository.TenantSuspended, query, codes.PermissionDenied, "TENANT_SUSPENDED"},
+		{repository.TenantArchived, query, codes.PermissionDenied, "TENANT_ARCHIVED"},
+	}
+	for _, tt := range tests {
+		tenant.Status = tt.status
+		err := call(tt.method)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s %s: code = %v, want %v", tt.status, tt.method, got, tt.want)
+		}
+		if got := apierr.Reason(err); got != tt.reason {
+			t.Errorf("%s %s: reason = %q, want %q", tt.status, tt.method, got, tt.reason)
+		}
+	}
+}
+
+func TestCheckTenantStatusReason(t *testing.T) {
+	tenant := &repository.Tenant{ID: uuid.New(), Status: repository.TenantSuspended, StatusReason: "invoice overdue"}
+	err := CheckTenantStatus(tenant, ScopeRead)
+	if got, want := status.Convert(err).Message(), "tenant is suspended: invoice overdue"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+	if info := apierr.Info(err); info.GetMetadata()["status"] != repository.TenantSuspended {
+		t.Errorf("metadata = %v, want status %s", info.GetMetadata(), repository.TenantSuspended)
+	}
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// reloadable are the settings a Bus applies without a restart. Others that
+// change are reported, and take effect at the next start.
+var reloadable = map[string]bool{
+	"LOG_LEVEL":               true,
+	"LOG_LEVELS":              true,
+	"LOG_DEBUG_TENANTS":       true,
+	"DEFAULT_TOP_K":           true,
+	"DEFAULT_MIN_SCORE":       true,
+	"CORS_ALLOWED_ORIGINS":    true,
+	"RATE_LIMIT_RPS":          true,
+	"RATE_LIMIT_BURST":        true,
+	"RATE_LIMIT_TENANT_RPS":   true,
+	"RATE_LIMIT_TENANT_BURST": true,
+}
+
+// Change is a setting that changed on a reload. Its values are redacted as
+// Redacted redacts them.
+type Change struct {
+	Key      string
+	OldValue string
+	NewValue string
+
+	// Applied is false for settings that take effect at the next start
+	Applied bool
+}
+
+// Bus holds the configuration in effect and reloads it from the config file
+// and environment, passing the changes it can apply at runtime to its
+// subscribers.
+type Bus struct {
+	path        string
+	current     atomic.Pointer[Config]
+	subscribers []func(*Config)
+
+	mu sync.Mutex // serializes reloads
+}
+
+// NewBus creates a Bus holding cfg, loaded from the config file at path
+// ("" for none).
+func NewBus(cfg *Config, path string) *Bus {
+	b := &Bus{path: path}
+	b.current.Store(cfg)
+	return b
+}
+
+// Current returns the configuration in effect.
+func (b *Bus) Current() *Config {
+	return b.current.Load()
+}
+
+// Subscribe registers fn to be called with the configuration in effect after
+// each reload that applies changes. Subscribers are registered before the
+// Bus is shared.
+func (b *Bus) Subscribe(fn func(*Config)) {
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Reload loads the configuration again and applies the reloadable settings
+// that changed. An invalid configuration is rejected whole, keeping the one
+// in effect. Environment variables are those the process started with, so
+// changes come from the config file.
+func (b *Bus) Reload() ([]Change, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	next, err := load(b.path)
+	if err != nil {
+		slog.Warn("failed to reload configuration; keeping the current one", "error", err)
+		return nil, err
+	}
+
+	current := b.current.Load()
+	merged := *current
+	oldSettings, newSettings := current.settings(), next.settings()
+	mergedValue, nextValue := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(next).Elem()
+
+	var changes []Change
+	var applied, pending []string
+	for i := range mergedValue.NumField() {
+		key := envKey(mergedValue.Type().Field(i))
+		if key == "" || oldSettings[key] == newSettings[key] {
+			continue
+		}
+		change := Change{
+			Key:      key,
+			OldValue: redactSetting(key, oldSettings[key]),
+			NewValue: redactSetting(key, newSettings[key]),
+			Applied:  reloadable[key],
+		}
+		if change.Applied {
+			mergedValue.Field(i).Set(nextValue.Field(i))
+			applied = append(applied, key)
+		} else {
+			pending = append(pending, key)
+		}
+		changes = append(changes, change)
+	}
+
+	if len(applied) > 0 {
+		b.current.Store(&merged)
+		for _, fn := range b.subscribers {
+			fn(&merged)
+		}
+	}
+	if len(changes) > 0 {
+		slog.Info("reloaded configuration", "applied", applied, "restart_required", pending)
+	}
+	return changes, nil
+}
+
+// Watch reloads the configuration whenever the config file changes, checking
+// every interval, until ctx is cancelled. It returns at once without a config
+// file or interval.
+func (b *Bus) Watch(ctx context.Context, interval time.Duration) {
+	if b.path == "" || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	modTime := fileModTime(b.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if m := fileModTime(b.path); !m.Equal(modTime) {
+			modTime = m
+			_, _ = b.Reload()
+		}
+	}
+}
+
+// fileModTime returns a file's modification time, or the zero time when it
+// can't be read
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBusReload(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "log_level: info\ngrpc_port: 9090\n")
+	setJWTSecret(t)
+	cfg, err := load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := NewBus(cfg, path)
+	var notified *Config
+	bus.Subscribe(func(cfg *Config) { notified = cfg })
+
+	if err := os.WriteFile(path, []byte("log_level: debug\ngrpc_port: 9191\nadmin_api_key: s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := bus.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]Change{}
+	for _, c := range changes {
+		got[c.Key] = c
+	}
+	if c := got["LOG_LEVEL"]; !c.Applied || c.OldValue != "info" || c.NewValue != "debug" {
+		t.Errorf("LOG_LEVEL change = %+v, want info to debug applied", c)
+	}
+	if c := got["GRPC_PORT"]; c.Applied || c.NewValue != "9191" {
+		t.Errorf("GRPC_PORT change = %+v, want 9191 waiting for a restart", c)
+	}
+	if c := got["ADMIN_API_KEY"]; c.NewValue != "[REDACTED]" {
+		t.Errorf("ADMIN_API_KEY change = %+v, want its value redacted", c)
+	}
+
+	current := bus.Current()
+	if notified != current || current.LogLevel != "debug" || current.GRPCPort != 9090 {
+		t.Errorf("current = %+v, want debug logging on port 9090 passed to subscribers", current)
+	}
+
+	// An invalid file is rejected whole
+	if err := os.WriteFile(path, []byte("log_level: loud\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bus.Reload(); err == nil {
+		t.Error("invalid config reloaded")
+	}
+	if bus.Current() != current {
+		t.Error("invalid config replaced the current one")
+	}
+}
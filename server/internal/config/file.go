@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/caarlos0/env/v10"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable holding the config file's path.
+const FileEnv = "CONFIG_FILE"
+
+// readFile reads a YAML (or JSON) config file into the environment variables
+// it sets. Keys are the variables' names in any case, and may be nested:
+//
+//	log_level: debug
+//	qdrant:
+//	  url: http://qdrant:6333
+//	cors_allowed_origins: [https://app.example.com, https://admin.example.com]
+//
+// sets LOG_LEVEL, QDRANT_URL and CORS_ALLOWED_ORIGINS. Unknown keys are
+// errors, so typos don't go unnoticed.
+func readFile(path string) (map[string]string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q: use YAML (.yaml, .yml) or JSON (.json)", ext)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&root); err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(root.Content) == 0 {
+		return map[string]string{}, nil
+	}
+
+	f := &fileReader{path: path, known: knownKeys(), values: make(map[string]string)}
+	f.readMapping(root.Content[0], "", "")
+	if len(f.errs) > 0 {
+		return nil, fmt.Errorf("invalid config file:\n  %s", strings.Join(f.errs, "\n  "))
+	}
+	return f.values, nil
+}
+
+// fileReader flattens a config file's nodes into environment variables
+type fileReader struct {
+	path   string
+	known  map[string]bool
+	values map[string]string
+	errs   []string
+}
+
+func (f *fileReader) errorf(node *yaml.Node, format string, args ...any) {
+	f.errs = append(f.errs, fmt.Sprintf("%s:%d: ", f.path, node.Line)+fmt.Sprintf(format, args...))
+}
+
+// readMapping reads a mapping whose keys are prefixed by prefix, an
+// environment variable name, and name, their dotted name in the file
+func (f *fileReader) readMapping(node *yaml.Node, prefix, name string) {
+	if node.Kind != yaml.MappingNode {
+		f.errorf(node, "expected a mapping of settings")
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := prefix + strings.ToUpper(strings.ReplaceAll(keyNode.Value, "-", "_"))
+		keyName := keyNode.Value
+		if name != "" {
+			keyName = name + "." + keyNode.Value
+		}
+		f.readValue(keyNode, valueNode, key, keyName)
+	}
+}
+
+// readValue reads the value of one key
+func (f *fileReader) readValue(keyNode, node *yaml.Node, key, name string) {
+	if node.Kind == yaml.MappingNode {
+		f.readMapping(node, key+"_", name)
+		return
+	}
+	if !f.known[key] {
+		if suggestion := closestKey(key, f.known); suggestion != "" {
+			f.errorf(keyNode, "unknown setting %q; did you mean %q?", name, strings.ToLower(suggestion))
+		} else {
+			f.errorf(keyNode, "unknown setting %q", name)
+		}
+		return
+	}
+	if _, ok := f.values[key]; ok {
+		f.errorf(keyNode, "%q sets %s again", name, key)
+		return
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			f.values[key] = ""
+			return
+		}
+		f.values[key] = node.Value
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				f.errorf(item, "%q must be a list of values", name)
+				return
+			}
+			items = append(items, item.Value)
+		}
+		f.values[key] = strings.Join(items, ",")
+	default:
+		f.errorf(node, "%q must be a value or a list of values", name)
+	}
+}
+
+// knownKeys returns the environment variables of the Config fields
+func knownKeys() map[string]bool {
+	params, err := env.GetFieldParams(&Config{})
+	if err != nil {
+		panic(err) // only fails on a malformed Config
+	}
+	known := make(map[string]bool, len(params))
+	for _, p := range params {
+		known[p.Key] = true
+	}
+	return known
+}
+
+// closestKey returns the known key nearest to a misspelt one, or "" when
+// none is close
+func closestKey(key string, known map[string]bool) string {
+	keys := make([]string, 0, len(known))
+	for k := range known {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	best, bestDistance := "", 3
+	for _, k := range keys {
+		if d := editDistance(key, k); d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	return best
+}
+
+// editDistance is the edit distance between two strings, counting swapped
+// neighbouring letters as one edit
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setJWTSecret sets the one setting without a default
+func setJWTSecret(t *testing.T) {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "0123456789abcdef0123456789abcdef")
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+grpc_port: 9191
+log:
+  level: debug
+qdrant:
+  grpc_url: qdrant:6334
+cors_allowed_origins: [https://a.example.com, https://b.example.com]
+SHUTDOWN_TIMEOUT: 5s
+`)
+	t.Setenv("LOG_LEVEL", "warn")
+	setJWTSecret(t)
+
+	cfg, err := load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GRPCPort != 9191 {
+		t.Errorf("GRPCPort = %d, want 9191", cfg.GRPCPort)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("LogLevel = %q, want the environment's warn", cfg.LogLevel)
+	}
+	if cfg.QdrantGRPCURL != "qdrant:6334" {
+		t.Errorf("QdrantGRPCURL = %q, want qdrant:6334", cfg.QdrantGRPCURL)
+	}
+	if got := strings.Join(cfg.CORSAllowedOrigins, " "); got != "https://a.example.com https://b.example.com" {
+		t.Errorf("CORSAllowedOrigins = %q", got)
+	}
+	if cfg.ShutdownTimeout != 5*time.Second {
+		t.Errorf("ShutdownTimeout = %v, want 5s", cfg.ShutdownTimeout)
+	}
+	if cfg.HTTPPort != 8080 {
+		t.Errorf("HTTPPort = %d, want the default 8080", cfg.HTTPPort)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"misspelt key", "config.yaml", "qdrant:\n  ulr: http://qdrant:6333\n", `config.yaml:2: unknown setting "qdrant.ulr"; did you mean "qdrant_url"?`},
+		{"set twice", "config.yaml", "qdrant_url: a\nqdrant:\n  url: b\n", `"qdrant.url" sets QDRANT_URL again`},
+		{"bad value", "config.yaml", "grpc_port: lots\n", `GRPC_PORT="lots" (from `},
+		{"out of range", "config.yaml", "default:\n  min_score: 2\n", "DEFAULT_MIN_SCORE must be between 0 and 1"},
+		{"unsupported format", "config.toml", "grpc_port = 1\n", `unsupported config file format ".toml"`},
+		{"short JWT secret", "config.yaml", "jwt_secret: s3cret\n", "JWT_SECRET must be at least 32 bytes"},
+		{"example JWT secret", "config.yaml", "jwt_secret: change-this-in-production\n", "JWT_SECRET must be changed from the example value"},
+	}
+	for _, tt := range tests {
+		_, err := load(writeConfig(t, tt.file, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestJWTSecretRequired(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	if _, err := load(""); err == nil || !strings.Contains(err.Error(), "JWT_SECRET is required") {
+		t.Errorf("error = %v, want JWT_SECRET required with auth enabled", err)
+	}
+
+	t.Setenv("AUTH_ENABLED", "false")
+	if _, err := load(""); err != nil {
+		t.Errorf("load with auth disabled: %v", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	setJWTSecret(t)
+	cfg, err := load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.AdminAPIKey = "admin-secret"
+	cfg.DatabaseURL = "postgres://rag:hunter2@db:5432/rag"
+
+	settings := cfg.Redacted()
+	if got := settings["ADMIN_API_KEY"]; got != "[REDACTED]" {
+		t.Errorf("ADMIN_API_KEY = %q, want [REDACTED]", got)
+	}
+	if got := settings["DATABASE_URL"]; strings.Contains(got, "hunter2") {
+		t.Errorf("DATABASE_URL = %q still has its password", got)
+	}
+	if got := settings["GRPC_PORT"]; got != "9090" {
+		t.Errorf("GRPC_PORT = %q, want 9090", got)
+	}
+}
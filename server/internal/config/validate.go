@@ -0,0 +1,243 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/knoguchi/rag/internal/logging"
+)
+
+// Validate checks that settings are in range and consistent, reporting each
+// problem with the environment variable to fix.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.check(c.GRPCPort >= 1 && c.GRPCPort <= 65535, "GRPC_PORT must be between 1 and 65535")
+	v.check(c.HTTPPort >= 1 && c.HTTPPort <= 65535, "HTTP_PORT must be between 1 and 65535")
+	v.check(c.GRPCPort != c.HTTPPort, "GRPC_PORT and HTTP_PORT must differ")
+
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		v.add("LOG_LEVEL: %v", err)
+	}
+	if _, err := logging.ParseComponentLevels(c.LogLevels); err != nil {
+		v.add("LOG_LEVELS: %v", err)
+	}
+	v.oneOf("LOG_FORMAT", c.LogFormat, logging.FormatJSON, logging.FormatText)
+	v.fraction("LOG_REQUEST_SAMPLE_RATE", c.LogRequestSampleRate)
+	v.nonNegative("SHUTDOWN_TIMEOUT", c.ShutdownTimeout)
+	v.nonNegative("INGEST_DRAIN_TIMEOUT", c.IngestDrainTimeout)
+
+	v.pair("GRPC_TLS_CERT_FILE", c.GRPCTLSCertFile, "GRPC_TLS_KEY_FILE", c.GRPCTLSKeyFile)
+	v.pair("HTTP_TLS_CERT_FILE", c.HTTPTLSCertFile, "HTTP_TLS_KEY_FILE", c.HTTPTLSKeyFile)
+	v.check(c.GRPCTLSClientCAFile == "" || c.GRPCTLSCertFile != "", "GRPC_TLS_CLIENT_CA_FILE requires GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE")
+	v.check(c.HTTPTLSClientCAFile == "" || c.HTTPTLSCertFile != "", "HTTP_TLS_CLIENT_CA_FILE requires HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE")
+	v.positive("TLS_RELOAD_INTERVAL", c.TLSReloadInterval)
+
+	v.atLeast("GRPC_MAX_RECV_MSG_SIZE", c.GRPCMaxRecvMsgSize, 1)
+	v.atLeast("GRPC_MAX_SEND_MSG_SIZE", c.GRPCMaxSendMsgSize, 1)
+	v.oneOf("GRPC_COMPRESSION", c.GRPCCompression, "", "gzip")
+
+	v.check(c.DatabaseURL != "", "DATABASE_URL is required")
+	v.check(c.QdrantGRPCURL != "", "QDRANT_GRPC_URL is required")
+	v.oneOf("QDRANT_SHARED_QUANTIZATION", c.QdrantSharedQuantization, "", "scalar", "binary")
+	v.atLeast("QDRANT_MAX_ATTEMPTS", c.QdrantMaxAttempts, 1)
+	v.atLeast("QDRANT_POOL_SIZE", c.QdrantPoolSize, 1)
+	v.url("OLLAMA_URL", c.OllamaURL)
+	v.positive("OLLAMA_PULL_TIMEOUT", c.OllamaPullTimeout)
+
+	v.atLeast("EMBEDDING_MAX_ATTEMPTS", c.EmbeddingMaxAttempts, 1)
+	v.positive("EMBEDDING_REQUEST_TIMEOUT", c.EmbeddingRequestTimeout)
+	v.atLeast("EMBEDDING_BATCH_SIZE", c.EmbeddingBatchSize, 1)
+	v.oneOf("VLLM_ENDPOINT", c.VLLMEndpoint, "chat", "completions")
+	v.atLeast("LLM_BREAKER_THRESHOLD", c.LLMBreakerThreshold, 1)
+	v.atLeast("RERANK_LLM_BATCH_SIZE", c.RerankLLMBatchSize, 1)
+	v.atLeast("RERANK_LLM_CONCURRENCY", c.RerankLLMConcurrency, 1)
+
+	// Tokens are only checked when authentication is enabled
+	if c.AuthEnabled {
+		v.check(c.JWTSecret != "", "JWT_SECRET is required when AUTH_ENABLED is true")
+		v.check(c.JWTSecret == "" || len(c.JWTSecret) >= minJWTSecretLength, fmt.Sprintf("JWT_SECRET must be at least %d bytes", minJWTSecretLength))
+		v.check(c.JWTSecret != placeholderJWTSecret, "JWT_SECRET must be changed from the example value")
+	}
+	v.positive("JWT_EXPIRY", c.JWTExpiry)
+	v.nonNegative("JWT_REFRESH_WINDOW", c.JWTRefreshWindow)
+
+	v.check(c.RateLimitRPS >= 0, "RATE_LIMIT_RPS must not be negative")
+	v.check(c.RateLimitTenantRPS >= 0, "RATE_LIMIT_TENANT_RPS must not be negative")
+	v.check(c.RateLimitRPS == 0 || c.RateLimitBurst >= 1, "RATE_LIMIT_BURST must be at least 1 when RATE_LIMIT_RPS is set")
+	v.check(c.RateLimitTenantRPS == 0 || c.RateLimitTenantBurst >= 1, "RATE_LIMIT_TENANT_BURST must be at least 1 when RATE_LIMIT_TENANT_RPS is set")
+
+	v.atLeast("ADMIN_RECENT_REQUESTS", c.AdminRecentRequests, 0)
+	v.positive("QUERY_COUNT_FLUSH_INTERVAL", c.QueryCountFlushInterval)
+	v.atLeast("QUERY_COUNT_RETENTION_MONTHS", c.QueryCountRetentionMonths, 0)
+	v.positive("FEED_POLL_INTERVAL", c.FeedPollInterval)
+	v.positive("FEED_DEFAULT_POLL_INTERVAL", c.FeedDefaultPollInterval)
+
+	v.oneOf("OCR_ENGINE", c.OCREngine, "", "tesseract", "http")
+	v.check(c.OCREngine != "http" || c.OCRURL != "", "OCR_URL is required when OCR_ENGINE is http")
+
+	v.atLeast("CRAWL_MAX_CONCURRENCY", c.CrawlMaxConcurrency, 1)
+	v.check(c.CrawlHostConcurrency >= 1 && c.CrawlHostConcurrency <= 2, "CRAWL_HOST_CONCURRENCY must be 1 or 2")
+	v.nonNegative("CRAWL_DELAY", c.CrawlDelay)
+	v.fraction("CRAWL_DELAY_JITTER", c.CrawlDelayJitter)
+	v.atLeast("CRAWL_MAX_RETRIES", c.CrawlMaxRetries, 0)
+	v.check(c.MaxDownloadSize > 0, "MAX_DOWNLOAD_SIZE must be positive")
+	v.atLeast("INGESTION_CHECKPOINT_CHUNKS", c.IngestionCheckpointChunks, 0)
+	v.atLeast("EMBED_BATCH_SIZE", c.EmbedBatchSize, 1)
+	v.atLeast("EMBED_CONCURRENCY", c.EmbedConcurrency, 1)
+
+	v.oneOf("DEFAULT_CHUNK_METHOD", c.DefaultChunkMethod, "fixed", "sentence", "semantic", "tabular")
+	v.atLeast("DEFAULT_CHUNK_TARGET_SIZE", c.DefaultChunkTargetSize, 1)
+	v.check(c.DefaultChunkMaxSize >= c.DefaultChunkTargetSize, "DEFAULT_CHUNK_MAX_SIZE must be at least DEFAULT_CHUNK_TARGET_SIZE")
+	v.check(c.DefaultChunkOverlap >= 0 && c.DefaultChunkOverlap < c.DefaultChunkTargetSize, "DEFAULT_CHUNK_OVERLAP must be at least 0 and less than DEFAULT_CHUNK_TARGET_SIZE")
+	v.atLeast("DEFAULT_TOP_K", c.DefaultTopK, 1)
+	v.fraction("DEFAULT_MIN_SCORE", float64(c.DefaultMinScore))
+
+	if len(v.problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(v.problems, "\n  "))
+	}
+	return nil
+}
+
+// minJWTSecretLength is the shortest JWT_SECRET accepted; HS256 keys should
+// be at least as long as the hash
+const minJWTSecretLength = 32
+
+// placeholderJWTSecret is the JWT_SECRET earlier releases defaulted to, and
+// that .env.example files still carry. Anyone can forge tokens signed with it.
+const placeholderJWTSecret = "change-this-in-production"
+
+// validator collects a config's problems
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) check(ok bool, problem string) {
+	if !ok {
+		v.problems = append(v.problems, problem)
+	}
+}
+
+func (v *validator) oneOf(key, value string, valid ...string) {
+	for _, s := range valid {
+		if value == s {
+			return
+		}
+	}
+	var names []string
+	for _, s := range valid {
+		if s != "" {
+			names = append(names, s)
+		}
+	}
+	v.add("%s=%q must be one of %s", key, value, strings.Join(names, ", "))
+}
+
+func (v *validator) atLeast(key string, value, least int) {
+	v.check(value >= least, fmt.Sprintf("%s must be at least %d", key, least))
+}
+
+func (v *validator) fraction(key string, value float64) {
+	v.check(value >= 0 && value <= 1, fmt.Sprintf("%s must be between 0 and 1", key))
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	v.check(d > 0, fmt.Sprintf("%s must be positive", key))
+}
+
+func (v *validator) nonNegative(key string, d time.Duration) {
+	v.check(d >= 0, fmt.Sprintf("%s must not be negative", key))
+}
+
+func (v *validator) pair(key, value, otherKey, other string) {
+	v.check((value == "") == (other == ""), fmt.Sprintf("%s and %s must be set together", key, otherKey))
+}
+
+func (v *validator) url(key, value string) {
+	if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+		v.add("%s=%q must be an absolute URL such as http://localhost:11434", key, value)
+	}
+}
+
+// secretSuffixes mark the settings Redacted masks
+var secretSuffixes = []string{"_KEY", "_SECRET", "_PASSWORD"}
+
+// Redacted returns the settings by environment variable, as they would be
+// written in one, with secrets masked and passwords removed from URLs. It is
+// what the service logs at startup.
+func (c *Config) Redacted() map[string]string {
+	settings := c.settings()
+	for key, value := range settings {
+		settings[key] = redactSetting(key, value)
+	}
+	return settings
+}
+
+// settings returns the settings by environment variable, unredacted
+func (c *Config) settings() map[string]string {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	settings := make(map[string]string, t.NumField())
+	for i := range t.NumField() {
+		if key := envKey(t.Field(i)); key != "" {
+			settings[key] = formatValue(v.Field(i))
+		}
+	}
+	return settings
+}
+
+// redactSetting masks a secret setting's value, or the passwords in a URL's
+func redactSetting(key, value string) string {
+	switch {
+	case value == "":
+		return value
+	case isSecret(key):
+		return "[REDACTED]"
+	case strings.Contains(value, "://"):
+		return redactURL(value)
+	}
+	return value
+}
+
+func isSecret(key string) bool {
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValue formats a setting as its environment variable takes it
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// redactURL removes the password of a URL, or of each of a list of them
+func redactURL(value string) string {
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		u, err := url.Parse(part)
+		if err != nil {
+			continue
+		}
+		if _, ok := u.User.Password(); ok {
+			parts[i] = u.Redacted()
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,268 @@
+// Package connector provides sources that pull external content into tenants.
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// maxFeedSize caps the size of a fetched feed document
+const maxFeedSize = 10 << 20 // 10 MB
+
+// Feed is a parsed RSS or Atom feed
+type Feed struct {
+	Title string
+	Items []FeedItem
+}
+
+// FeedItem is a single feed entry
+type FeedItem struct {
+	GUID      string
+	Title     string
+	Link      string
+	Content   string // HTML or plain text body (full content when available, else summary)
+	Published *time.Time
+}
+
+// Key returns a stable identifier for deduplication: the guid, else the link,
+// else a hash of title and publication date.
+func (i FeedItem) Key() string {
+	if i.GUID != "" {
+		return i.GUID
+	}
+	if i.Link != "" {
+		return i.Link
+	}
+	published := ""
+	if i.Published != nil {
+		published = i.Published.UTC().Format(time.RFC3339)
+	}
+	sum := sha256.Sum256([]byte(i.Title + "\n" + published))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// FetchFeed downloads and parses a feed
+func FetchFeed(ctx context.Context, client *http.Client, url string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	return ParseFeed(body)
+}
+
+// RSS 2.0 / RSS 1.0 (RDF) structures
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items []rssItem `xml:"item"` // RSS 1.0 places items next to the channel
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+// Atom structures
+type atomFeed struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Summary   atomText   `xml:"summary"`
+	Content   atomText   `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// value returns the text body; xhtml content is kept as markup
+func (t atomText) value() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+// ParseFeed parses an RSS 2.0, RSS 1.0 or Atom document
+func ParseFeed(data []byte) (*Feed, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "rss", "RDF":
+		var doc rssDocument
+		if err := unmarshalXML(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse RSS: %w", err)
+		}
+		items := append(doc.Channel.Items, doc.Items...)
+		feed := &Feed{Title: strings.TrimSpace(doc.Channel.Title)}
+		for _, item := range items {
+			feed.Items = append(feed.Items, item.toFeedItem())
+		}
+		return feed, nil
+	case "feed":
+		var doc atomFeed
+		if err := unmarshalXML(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom: %w", err)
+		}
+		feed := &Feed{Title: strings.TrimSpace(doc.Title)}
+		for _, entry := range doc.Entries {
+			feed.Items = append(feed.Items, entry.toFeedItem())
+		}
+		return feed, nil
+	default:
+		return nil, fmt.Errorf("unsupported feed format: <%s>", root)
+	}
+}
+
+func (i rssItem) toFeedItem() FeedItem {
+	content := i.Encoded
+	if content == "" {
+		content = i.Description
+	}
+	published := parseFeedTime(i.PubDate)
+	if published == nil {
+		published = parseFeedTime(i.Date)
+	}
+	return FeedItem{
+		GUID:      strings.TrimSpace(i.GUID),
+		Title:     strings.TrimSpace(i.Title),
+		Link:      strings.TrimSpace(i.Link),
+		Content:   strings.TrimSpace(content),
+		Published: published,
+	}
+}
+
+func (e atomEntry) toFeedItem() FeedItem {
+	content := e.Content.value()
+	if content == "" {
+		content = e.Summary.value()
+	}
+	published := parseFeedTime(e.Published)
+	if published == nil {
+		published = parseFeedTime(e.Updated)
+	}
+
+	// Prefer the alternate (HTML page) link
+	var link string
+	for _, l := range e.Links {
+		if l.Rel == "" || l.Rel == "alternate" {
+			link = l.Href
+			break
+		}
+	}
+	if link == "" && len(e.Links) > 0 {
+		link = e.Links[0].Href
+	}
+
+	return FeedItem{
+		GUID:      strings.TrimSpace(e.ID),
+		Title:     strings.TrimSpace(e.Title),
+		Link:      strings.TrimSpace(link),
+		Content:   content,
+		Published: published,
+	}
+}
+
+// rootElement returns the local name of the document's root element
+func rootElement(data []byte) (string, error) {
+	decoder := newXMLDecoder(data)
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to read feed: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func unmarshalXML(data []byte, v any) error {
+	return newXMLDecoder(data).Decode(v)
+}
+
+// newXMLDecoder creates a lenient decoder that handles non-UTF-8 feeds
+func newXMLDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	return decoder
+}
+
+// feedTimeLayouts are the date formats commonly found in feeds
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseFeedTime parses a feed date, returning nil if it is missing or unrecognized
+func parseFeedTime(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
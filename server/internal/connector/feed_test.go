@@ -0,0 +1,103 @@
+package connector
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFeed_RSS(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Example Blog</title>
+    <item>
+      <title>First post</title>
+      <link>https://example.com/first</link>
+      <guid>post-1</guid>
+      <pubDate>Mon, 02 Jan 2006 15:04:05 -0700</pubDate>
+      <description>Short summary</description>
+      <content:encoded><![CDATA[<p>Full body</p>]]></content:encoded>
+    </item>
+    <item>
+      <title>Second post</title>
+      <link>https://example.com/second</link>
+      <description>Only a summary</description>
+    </item>
+  </channel>
+</rss>`
+
+	feed, err := ParseFeed([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if feed.Title != "Example Blog" {
+		t.Errorf("expected title 'Example Blog', got %q", feed.Title)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.Key() != "post-1" {
+		t.Errorf("expected guid key, got %q", first.Key())
+	}
+	if first.Content != "<p>Full body</p>" {
+		t.Errorf("expected content:encoded body, got %q", first.Content)
+	}
+	if first.Published == nil || first.Published.Year() != 2006 {
+		t.Errorf("expected pubDate to be parsed, got %v", first.Published)
+	}
+
+	second := feed.Items[1]
+	if second.Key() != "https://example.com/second" {
+		t.Errorf("expected link key when guid is missing, got %q", second.Key())
+	}
+	if second.Content != "Only a summary" {
+		t.Errorf("expected description fallback, got %q", second.Content)
+	}
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Atom</title>
+  <entry>
+    <id>urn:uuid:1225c695</id>
+    <title>Atom entry</title>
+    <link rel="alternate" href="https://example.com/atom-entry"/>
+    <updated>2024-03-01T10:00:00Z</updated>
+    <content type="html">&lt;p&gt;Body&lt;/p&gt;</content>
+  </entry>
+</feed>`
+
+	feed, err := ParseFeed([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(feed.Items))
+	}
+
+	entry := feed.Items[0]
+	if entry.Key() != "urn:uuid:1225c695" {
+		t.Errorf("expected id key, got %q", entry.Key())
+	}
+	if entry.Link != "https://example.com/atom-entry" {
+		t.Errorf("expected alternate link, got %q", entry.Link)
+	}
+	if entry.Content != "<p>Body</p>" {
+		t.Errorf("expected unescaped html content, got %q", entry.Content)
+	}
+	if entry.Published == nil || entry.Published.Month() != 3 {
+		t.Errorf("expected updated date fallback, got %v", entry.Published)
+	}
+}
+
+func TestParseFeed_Unsupported(t *testing.T) {
+	_, err := ParseFeed([]byte(`<html><body>not a feed</body></html>`))
+	if err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package crawl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowed is returned for URLs a host's robots.txt does not let the
+// user agent fetch
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+const (
+	// maxRobotsSize is the size of robots.txt read; rules beyond it are
+	// ignored, as RFC 9309 allows
+	maxRobotsSize = 500 << 10
+
+	// maxCrawlDelay caps the crawl delay a host can ask for
+	maxCrawlDelay = 30 * time.Second
+
+	// unavailableRobotsTTL is how long an unreachable robots.txt disallows
+	// its host before it is fetched again
+	unavailableRobotsTTL = time.Minute
+)
+
+// Rules are the robots.txt rules that apply to one user agent
+type Rules struct {
+	rules []rule
+
+	// CrawlDelay is the delay the host asks for between requests
+	CrawlDelay time.Duration
+}
+
+// rule is one Allow or Disallow line
+type rule struct {
+	pattern string
+	allow   bool
+}
+
+// group is a user-agent group of a robots.txt file
+type group struct {
+	agents     []string
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+// allowAll and disallowAll are the rules for hosts without a robots.txt and
+// for hosts whose robots.txt is unavailable
+var (
+	allowAll    = &Rules{}
+	disallowAll = &Rules{rules: []rule{{pattern: "/"}}}
+)
+
+// ParseRobots returns the rules of a robots.txt file for a user agent: those
+// of the groups naming its product token ("RAG-Service" for
+// "RAG-Service/1.0"), or of the "*" groups if none does.
+func ParseRobots(data []byte, userAgent string) *Rules {
+	var groups []*group
+	var current *group
+	inAgents := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines share one group
+			if !inAgents {
+				current = &group{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inAgents = false
+			if current != nil && value != "" {
+				current.rules = append(current.rules, rule{pattern: value, allow: key == "allow"})
+			}
+		case "crawl-delay":
+			inAgents = false
+			if seconds, err := strconv.ParseFloat(value, 64); current != nil && err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		default:
+			inAgents = false
+		}
+	}
+
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexByte(token, '/'); i >= 0 {
+		token = token[:i]
+	}
+	rules := matchingRules(groups, token)
+	if rules == nil {
+		rules = matchingRules(groups, "*")
+	}
+	if rules == nil {
+		return allowAll
+	}
+	return rules
+}
+
+// matchingRules merges the groups naming an agent, or returns nil if none does
+func matchingRules(groups []*group, agent string) *Rules {
+	var rules *Rules
+	for _, g := range groups {
+		for _, a := range g.agents {
+			if a != agent {
+				continue
+			}
+			if rules == nil {
+				rules = &Rules{}
+			}
+			rules.rules = append(rules.rules, g.rules...)
+			rules.CrawlDelay = max(rules.CrawlDelay, g.crawlDelay)
+			break
+		}
+	}
+	return rules
+}
+
+// Allowed reports whether a path, with its query, may be fetched. The
+// longest matching pattern decides, and Allow wins a tie.
+func (r *Rules) Allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+	allowed, longest := true, -1
+	for _, rl := range r.rules {
+		if !matchPattern(rl.pattern, path) {
+			continue
+		}
+		if n := len(rl.pattern); n > longest || (n == longest && rl.allow) {
+			allowed, longest = rl.allow, n
+		}
+	}
+	return allowed
+}
+
+// matchPattern matches a path against a robots.txt pattern, where "*" is any
+// run of characters and a trailing "$" anchors the end of the path
+func matchPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	parts := strings.Split(strings.TrimSuffix(pattern, "$"), "*")
+
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(rest, part)
+		}
+		j := strings.Index(rest, part)
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(part):]
+	}
+	return !anchored || rest == ""
+}
+
+// Robots fetches and caches the robots.txt of each host for one user agent.
+type Robots struct {
+	client    *http.Client
+	userAgent string
+	ttl       time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostRobots
+}
+
+// hostRobots are a host's cached rules
+type hostRobots struct {
+	rules   *Rules
+	err     error // why robots.txt was unavailable
+	expires time.Time
+}
+
+// NewRobots creates a robots.txt cache fetching with client and keeping each
+// host's rules for ttl.
+func NewRobots(client *http.Client, userAgent string, ttl time.Duration) *Robots {
+	return &Robots{
+		client:    client,
+		userAgent: userAgent,
+		ttl:       ttl,
+		hosts:     make(map[string]*hostRobots),
+	}
+}
+
+// Check returns ErrDisallowed if the user agent may not fetch a URL. A
+// robots.txt that is missing allows everything; one that is unavailable
+// (a server error or no response) disallows everything for a while.
+func (r *Robots) Check(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host, err := r.host(ctx, u)
+	if err != nil {
+		return err
+	}
+	if host.err != nil {
+		return fmt.Errorf("%w: robots.txt unavailable: %v", ErrDisallowed, host.err)
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if !host.rules.Allowed(path + queryOf(u)) {
+		return ErrDisallowed
+	}
+	return nil
+}
+
+// CrawlDelay returns the delay a URL's host asks for between requests,
+// capped at 30 seconds; 0 if its robots.txt sets none or is unavailable.
+func (r *Robots) CrawlDelay(ctx context.Context, rawURL string) time.Duration {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0
+	}
+	host, err := r.host(ctx, u)
+	if err != nil {
+		return 0
+	}
+	return min(host.rules.CrawlDelay, maxCrawlDelay)
+}
+
+// host returns the cached rules of a URL's host, fetching them when missing
+// or expired
+func (r *Robots) host(ctx context.Context, u *url.URL) (*hostRobots, error) {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrNotHTTP
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	r.mu.Lock()
+	host, ok := r.hosts[origin]
+	r.mu.Unlock()
+	if ok && time.Now().Before(host.expires) {
+		return host, nil
+	}
+
+	host = r.fetch(ctx, origin)
+	r.mu.Lock()
+	r.hosts[origin] = host
+	r.mu.Unlock()
+	return host, nil
+}
+
+// fetch downloads and parses an origin's robots.txt
+func (r *Robots) fetch(ctx context.Context, origin string) *hostRobots {
+	unavailable := func(err error) *hostRobots {
+		return &hostRobots{rules: disallowAll, err: err, expires: time.Now().Add(min(r.ttl, unavailableRobotsTTL))}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", origin+"/robots.txt", nil)
+	if err != nil {
+		return unavailable(err)
+	}
+	req.Header.Set("User-Agent", r.userAgent)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return unavailable(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return unavailable(fmt.Errorf("HTTP %d", resp.StatusCode))
+	case resp.StatusCode >= 400:
+		// No robots.txt: everything is allowed
+		return &hostRobots{rules: allowAll, expires: time.Now().Add(r.ttl)}
+	case resp.StatusCode != http.StatusOK:
+		return unavailable(fmt.Errorf("HTTP %d", resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsSize))
+	if err != nil {
+		return unavailable(err)
+	}
+	return &hostRobots{rules: ParseRobots(data, r.userAgent), expires: time.Now().Add(r.ttl)}
+}
+
+// queryOf returns a URL's query with its leading "?", if it has one
+func queryOf(u *url.URL) string {
+	if u.RawQuery == "" {
+		return ""
+	}
+	return "?" + u.RawQuery
+}
@@ -0,0 +1,112 @@
+package crawl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testRobots = `# comments are ignored
+User-agent: *
+Disallow: /private
+Crawl-delay: 2
+
+User-agent: RAG-Service
+User-agent: other-bot
+Disallow: /
+Allow: /docs/
+Disallow: /docs/*.pdf$
+Disallow: /docs/drafts
+Crawl-delay: 0.5
+`
+
+func TestParseRobots(t *testing.T) {
+	rules := ParseRobots([]byte(testRobots), "RAG-Service/1.0")
+	tests := map[string]bool{
+		"/":                  false,
+		"/robots.txt":        true,
+		"/docs/":             true,
+		"/docs/guide":        true,
+		"/docs/guide.pdf":    false,
+		"/docs/guide.pdf?x":  true,
+		"/docs/drafts/a":     false,
+		"/private":           false,
+		"/docs/index.html?q": true,
+	}
+	for path, want := range tests {
+		if got := rules.Allowed(path); got != want {
+			t.Errorf("Allowed(%q) = %v, want %v", path, got, want)
+		}
+	}
+	if rules.CrawlDelay != 500*time.Millisecond {
+		t.Errorf("CrawlDelay = %v, want 500ms", rules.CrawlDelay)
+	}
+
+	// Other agents fall back to the * group
+	rules = ParseRobots([]byte(testRobots), "SomeBot")
+	if rules.Allowed("/private/x") || !rules.Allowed("/docs/drafts") {
+		t.Error("expected the * group to apply to an unnamed agent")
+	}
+	if rules.CrawlDelay != 2*time.Second {
+		t.Errorf("CrawlDelay = %v, want 2s", rules.CrawlDelay)
+	}
+
+	if rules := ParseRobots([]byte("User-agent: googlebot\nDisallow: /\n"), "RAG-Service"); !rules.Allowed("/a") {
+		t.Error("expected rules without a matching group to allow everything")
+	}
+}
+
+func TestRobotsCheck(t *testing.T) {
+	fetches := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.Write([]byte("User-agent: *\nDisallow: /secret\n"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	robots := NewRobots(server.Client(), "RAG-Service/1.0", time.Hour)
+	ctx := context.Background()
+	if err := robots.Check(ctx, server.URL+"/page"); err != nil {
+		t.Errorf("Check(/page) = %v, want nil", err)
+	}
+	if err := robots.Check(ctx, server.URL+"/secret/x"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("Check(/secret/x) = %v, want ErrDisallowed", err)
+	}
+	if fetches != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1", fetches)
+	}
+}
+
+func TestRobotsUnavailable(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if err := NewRobots(server.Client(), "RAG-Service", time.Hour).Check(ctx, server.URL+"/a"); err != nil {
+		t.Errorf("missing robots.txt: Check = %v, want nil", err)
+	}
+	status = http.StatusServiceUnavailable
+	if err := NewRobots(server.Client(), "RAG-Service", time.Hour).Check(ctx, server.URL+"/a"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("unavailable robots.txt: Check = %v, want ErrDisallowed", err)
+	}
+}
+
+func TestRobotsCrawlDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nCrawl-delay: 600\n"))
+	}))
+	defer server.Close()
+
+	robots := NewRobots(server.Client(), "RAG-Service", time.Hour)
+	if got := robots.CrawlDelay(context.Background(), server.URL+"/a"); got != maxCrawlDelay {
+		t.Errorf("CrawlDelay = %v, want it capped at %v", got, maxCrawlDelay)
+	}
+}
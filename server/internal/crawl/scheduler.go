@@ -0,0 +1,259 @@
+package crawl
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxHostConcurrency caps the requests in flight to one host, whatever the
+// configuration asks for
+const maxHostConcurrency = 2
+
+// SchedulerConfig holds the politeness limits of a Scheduler.
+type SchedulerConfig struct {
+	// MaxConcurrency is the number of requests in flight across all hosts
+	// (default 8).
+	MaxConcurrency int
+
+	// HostConcurrency is the number of requests in flight to one host
+	// (default 1, at most 2).
+	HostConcurrency int
+
+	// Delay is the time between the starts of two requests to one host. A
+	// longer Crawl-delay in the host's robots.txt takes precedence.
+	Delay time.Duration
+
+	// Jitter adds up to this fraction of the delay, at random, so requests
+	// don't arrive in lockstep (0 to 1).
+	Jitter float64
+
+	// MaxBackoff caps how long a host that answered 429 or 503 is left
+	// alone, whether it sent Retry-After or not (default 5 minutes).
+	MaxBackoff time.Duration
+
+	// MaxRetries is how many times Do repeats a throttled request
+	// (default 3; negative disables retries).
+	MaxRetries int
+
+	// Robots, if set, supplies each host's Crawl-delay.
+	Robots *Robots
+}
+
+// Scheduler spaces and limits the requests of a crawl so that no host is
+// overwhelmed, and backs off from hosts that signal they are.
+type Scheduler struct {
+	cfg    SchedulerConfig
+	global chan struct{}
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState is the scheduling state of one host
+type hostState struct {
+	active   int           // requests in flight
+	next     time.Time     // earliest start of the next request
+	failures int           // consecutive throttled responses
+	wake     chan struct{} // closed when a request finishes
+}
+
+// Slot is permission to send one request.
+type Slot struct {
+	scheduler *Scheduler
+	origin    string
+	delay     time.Duration
+	once      sync.Once
+}
+
+// NewScheduler creates a scheduler with the given limits.
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 8
+	}
+	cfg.HostConcurrency = min(max(cfg.HostConcurrency, 1), maxHostConcurrency)
+	cfg.Jitter = min(max(cfg.Jitter, 0), 1)
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	return &Scheduler{
+		cfg:    cfg,
+		global: make(chan struct{}, cfg.MaxConcurrency),
+		hosts:  make(map[string]*hostState),
+	}
+}
+
+// Acquire waits until a request to a URL's host keeps within the limits,
+// and returns the slot to release once the response arrives.
+func (s *Scheduler) Acquire(ctx context.Context, rawURL string) (*Slot, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrNotHTTP
+	}
+	origin := u.Scheme + "://" + u.Host
+
+	delay := s.cfg.Delay
+	if s.cfg.Robots != nil {
+		delay = max(delay, s.cfg.Robots.CrawlDelay(ctx, rawURL))
+	}
+	if delay > 0 && s.cfg.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * s.cfg.Jitter * float64(delay))
+	}
+
+	for {
+		s.mu.Lock()
+		h := s.host(origin)
+		now := time.Now()
+		if h.active < s.cfg.HostConcurrency && !now.Before(h.next) {
+			h.active++
+			h.next = now.Add(delay)
+			s.mu.Unlock()
+			break
+		}
+		// Wait for the host's delay to pass or, at its concurrency, for a
+		// request to it to finish
+		wake := h.wake
+		timer := time.NewTimer(h.next.Sub(now))
+		if h.active >= s.cfg.HostConcurrency {
+			timer.Stop()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-timer.C:
+		case <-wake:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		timer.Stop()
+	}
+
+	slot := &Slot{scheduler: s, origin: origin, delay: delay}
+	select {
+	case s.global <- struct{}{}:
+		return slot, nil
+	case <-ctx.Done():
+		s.finish(origin, nil, delay)
+		return nil, ctx.Err()
+	}
+}
+
+// Release frees the slot. A 429 or 503 response backs the host off, for
+// as long as its Retry-After asks if it sent one; a nil response is a
+// request that failed without one.
+func (sl *Slot) Release(resp *http.Response) {
+	sl.once.Do(func() {
+		<-sl.scheduler.global
+		sl.scheduler.finish(sl.origin, resp, sl.delay)
+	})
+}
+
+// Do sends a request within the scheduler's limits, repeating it after
+// backing off while the host throttles it. Requests with a body are only
+// repeated if they can be rewound.
+func (s *Scheduler) Do(client *http.Client, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		slot, err := s.Acquire(req.Context(), req.URL.String())
+		if err != nil {
+			return nil, err
+		}
+
+		try := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				slot.Release(nil)
+				return nil, err
+			}
+			try = req.Clone(req.Context())
+			try.Body = body
+		}
+
+		resp, err := client.Do(try)
+		slot.Release(resp)
+		if err != nil {
+			return nil, err
+		}
+		if !throttled(resp) || attempt >= s.cfg.MaxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+// host returns a host's state, creating it; s.mu must be held
+func (s *Scheduler) host(origin string) *hostState {
+	h, ok := s.hosts[origin]
+	if !ok {
+		h = &hostState{wake: make(chan struct{})}
+		s.hosts[origin] = h
+	}
+	return h
+}
+
+// finish records the end of a request to a host and wakes its waiters
+func (s *Scheduler) finish(origin string, resp *http.Response, delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.host(origin)
+	h.active--
+	if resp != nil {
+		if throttled(resp) {
+			h.failures++
+			backoff, ok := retryAfter(resp, time.Now())
+			if !ok {
+				// Double the wait with each throttled response in a row
+				backoff = max(delay, time.Second) << min(h.failures, 16)
+			}
+			h.next = later(h.next, time.Now().Add(min(backoff, s.cfg.MaxBackoff)))
+		} else {
+			h.failures = 0
+		}
+	}
+	close(h.wake)
+	h.wake = make(chan struct{})
+	if h.active == 0 && h.failures == 0 && time.Now().After(h.next) {
+		delete(s.hosts, origin)
+	}
+}
+
+// throttled reports whether a response asks the client to slow down
+func throttled(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// retryAfter parses a response's Retry-After header, in seconds or as an
+// HTTP date
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// later returns the later of two times
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,123 @@
+package crawl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedulerHostConcurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		active.Add(-1)
+	}))
+	defer server.Close()
+
+	scheduler := NewScheduler(SchedulerConfig{HostConcurrency: 5})
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			resp, err := scheduler.Do(server.Client(), req)
+			if err != nil {
+				t.Errorf("Do: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != maxHostConcurrency {
+		t.Errorf("peak concurrency = %d, want %d", got, maxHostConcurrency)
+	}
+}
+
+func TestSchedulerDelay(t *testing.T) {
+	scheduler := NewScheduler(SchedulerConfig{Delay: 30 * time.Millisecond})
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		slot, err := scheduler.Acquire(ctx, "https://example.com/a")
+		if err != nil {
+			t.Fatalf("Acquire: %v", err)
+		}
+		slot.Release(nil)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least 2 delays", elapsed)
+	}
+
+	// Other hosts are not held back
+	start = time.Now()
+	slot, err := scheduler.Acquire(ctx, "https://other.example.com/")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	slot.Release(nil)
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("request to another host waited %v", elapsed)
+	}
+}
+
+func TestSchedulerRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	scheduler := NewScheduler(SchedulerConfig{})
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	start := time.Now()
+	resp, err := scheduler.Do(server.Client(), req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || requests.Load() != 2 {
+		t.Errorf("status %d after %d requests, want 200 after 2", resp.StatusCode, requests.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want Retry-After's 1s", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"120":                           2 * time.Minute,
+		"Thu, 01 Jan 2026 00:00:30 GMT": 30 * time.Second,
+		"Wed, 31 Dec 2025 00:00:00 GMT": 0,
+	}
+	for value, want := range tests {
+		resp := &http.Response{Header: http.Header{"Retry-After": {value}}}
+		if got, ok := retryAfter(resp, now); !ok || got != want {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	if _, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": {"soon"}}}, now); ok {
+		t.Error("expected an unparseable Retry-After to be ignored")
+	}
+}
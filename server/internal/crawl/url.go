@@ -0,0 +1,89 @@
+// Package crawl provides the building blocks for fetching web pages
+// politely: URL canonicalization, robots.txt rules and per-host scheduling.
+package crawl
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrNotHTTP is returned for URLs that are not absolute http or https URLs
+var ErrNotHTTP = errors.New("not an absolute http(s) URL")
+
+// trackingParams are query parameters that identify the visitor or the
+// campaign that led to a page, never the page itself
+var trackingParams = map[string]bool{
+	"gclid": true, "dclid": true, "gbraid": true, "wbraid": true, "fbclid": true,
+	"msclkid": true, "yclid": true, "twclid": true, "igshid": true,
+	"mc_cid": true, "mc_eid": true, "_ga": true, "_gl": true, "_hsenc": true,
+	"_hsmi": true, "mkt_tok": true, "ref_src": true, "oly_anon_id": true, "oly_enc_id": true,
+}
+
+// Canonicalize normalizes a URL so that addresses of the same page compare
+// equal: the scheme and host are lowercased, default ports, fragments and
+// tracking parameters (utm_* and click IDs) are dropped, dot segments and a
+// trailing slash are removed, and the remaining query parameters are sorted.
+func Canonicalize(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrNotHTTP
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+
+	p := "/"
+	if u.Path != "" {
+		p = path.Clean("/" + u.Path)
+	}
+	u.Path = p
+	u.RawPath = ""
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), nil
+}
+
+// Resolve canonicalizes ref, resolved against the page URL base, as found in
+// links and <link rel="canonical">
+func Resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", err
+	}
+	return Canonicalize(b.ResolveReference(r).String())
+}
+
+// SourceKey is a canonical URL without its scheme, so the http and https
+// addresses of a page share one key
+func SourceKey(canonical string) string {
+	if i := strings.Index(canonical, "://"); i >= 0 {
+		return canonical[i+3:]
+	}
+	return canonical
+}
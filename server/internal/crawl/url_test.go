@@ -0,0 +1,52 @@
+package crawl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := map[string]string{
+		"http://example.com/a":                            "http://example.com/a",
+		"https://Example.COM/a/":                          "https://example.com/a",
+		"https://example.com/a?utm_source=x&utm_medium=y": "https://example.com/a",
+		"https://example.com:443/a/../b/./c#top":          "https://example.com/b/c",
+		"http://example.com:8080":                         "http://example.com:8080/",
+		"https://example.com/?b=2&a=1&fbclid=abc":         "https://example.com/?a=1&b=2",
+		"https://example.com./docs//guide/":               "https://example.com/docs/guide",
+		"https://[::1]:443/x":                             "https://[::1]/x",
+		"http://[::1]:8080/x":                             "http://[::1]:8080/x",
+	}
+	for raw, want := range tests {
+		got, err := Canonicalize(raw)
+		if err != nil {
+			t.Errorf("Canonicalize(%q): %v", raw, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Canonicalize(%q) = %q, want %q", raw, got, want)
+		}
+	}
+
+	for _, raw := range []string{"ftp://example.com/a", "/relative/path", "report.pdf"} {
+		if _, err := Canonicalize(raw); !errors.Is(err, ErrNotHTTP) {
+			t.Errorf("Canonicalize(%q) error = %v, want ErrNotHTTP", raw, err)
+		}
+	}
+}
+
+func TestResolveAndSourceKey(t *testing.T) {
+	got, err := Resolve("https://example.com/blog/post?page=2", "/blog/post/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "https://example.com/blog/post" {
+		t.Errorf("Resolve = %q", got)
+	}
+
+	a, _ := Canonicalize("http://example.com/a")
+	b, _ := Canonicalize("https://example.com/a/?utm_source=x")
+	if SourceKey(a) != SourceKey(b) || SourceKey(a) != "example.com/a" {
+		t.Errorf("source keys differ: %q, %q", SourceKey(a), SourceKey(b))
+	}
+}
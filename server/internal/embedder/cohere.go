@@ -0,0 +1,189 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+const (
+	// DefaultCohereBaseURL is the Cohere API endpoint.
+	DefaultCohereBaseURL = "https://api.cohere.com"
+
+	// DefaultCohereModel is the default Cohere embedding model.
+	DefaultCohereModel = "embed-english-v3.0"
+
+	// cohereMaxBatchSize is the API's limit on texts per request.
+	cohereMaxBatchSize = 96
+)
+
+// CohereConfig holds configuration for the Cohere embedder.
+type CohereConfig struct {
+	// BaseURL is the API endpoint (default: https://api.cohere.com).
+	BaseURL string
+
+	// APIKey is the Cohere API key.
+	APIKey string
+
+	// Model is the embedding model (default: embed-english-v3.0).
+	Model string
+
+	// Dimension is the embedding dimension (default: from KnownModels).
+	Dimension int
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+
+	// Retry controls retries and per-request timeouts for transient failures.
+	Retry RetryConfig
+}
+
+// CohereEmbedder implements the Embedder interface using Cohere's v2 embed API.
+// Documents are embedded with input_type search_document and queries with
+// search_query, as the v3 models are trained asymmetrically.
+type CohereEmbedder struct {
+	baseURL   string
+	apiKey    string
+	model     string
+	dimension int
+	client    *http.Client
+	retry     RetryConfig
+}
+
+// cohereRequest represents the request body for the embed API.
+type cohereRequest struct {
+	Model          string   `json:"model"`
+	Texts          []string `json:"texts"`
+	InputType      string   `json:"input_type"`
+	EmbeddingTypes []string `json:"embedding_types"`
+}
+
+// cohereResponse represents the response from the embed API.
+type cohereResponse struct {
+	Embeddings struct {
+		Float [][]float32 `json:"float"`
+	} `json:"embeddings"`
+}
+
+// NewCohereEmbedder creates a new Cohere embedder with the given configuration.
+func NewCohereEmbedder(cfg CohereConfig) *CohereEmbedder {
+	e := &CohereEmbedder{
+		baseURL:   strings.TrimSuffix(cfg.BaseURL, "/"),
+		apiKey:    cfg.APIKey,
+		model:     cfg.Model,
+		dimension: cfg.Dimension,
+		client:    cfg.HTTPClient,
+		retry:     cfg.Retry.withDefaults(),
+	}
+	if e.baseURL == "" {
+		e.baseURL = DefaultCohereBaseURL
+	}
+	if e.model == "" {
+		e.model = DefaultCohereModel
+	}
+	if e.dimension <= 0 {
+		e.dimension = GetModelConfig(e.model).Dimension
+	}
+	if e.client == nil {
+		e.client = http.DefaultClient
+	}
+	return e
+}
+
+// Embed generates a document embedding for a single text input.
+func (e *CohereEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return e.embedOne(ctx, text, "search_document")
+}
+
+// EmbedQuery generates a query embedding for a single text input.
+func (e *CohereEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return e.embedOne(ctx, text, "search_query")
+}
+
+// EmbedDocuments generates document embeddings for texts being indexed.
+func (e *CohereEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	return e.EmbedBatch(ctx, texts)
+}
+
+// EmbedBatch generates document embeddings for multiple text inputs. If some
+// requests fail, it returns the other embeddings with a *BatchError.
+func (e *CohereEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	spans := splitBatches(texts, cohereMaxBatchSize, DefaultMaxBatchBytes)
+	return embedSpans(ctx, texts, spans, e.retry, func(ctx context.Context, texts []string) ([][]float32, error) {
+		return e.embed(ctx, texts, "search_document")
+	})
+}
+
+func (e *CohereEmbedder) embedOne(ctx context.Context, text, inputType string) ([]float32, error) {
+	embeddings, err := withRetry(ctx, e.retry, func(ctx context.Context) ([][]float32, error) {
+		return e.embed(ctx, []string{text}, inputType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// embed makes a single embed API request.
+func (e *CohereEmbedder) embed(ctx context.Context, texts []string, inputType string) ([][]float32, error) {
+	jsonBody, err := json.Marshal(cohereRequest{
+		Model:          e.model,
+		Texts:          texts,
+		InputType:      inputType,
+		EmbeddingTypes: []string{"float"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v2/embed", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Provider: "cohere", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var cohereResp cohereResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cohereResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(cohereResp.Embeddings.Float) != len(texts) {
+		return nil, fmt.Errorf("cohere returned %d embeddings for %d inputs", len(cohereResp.Embeddings.Float), len(texts))
+	}
+
+	return cohereResp.Embeddings.Float, nil
+}
+
+// Dimension returns the dimensionality of the embedding vectors.
+func (e *CohereEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// ModelName returns the model, qualified by provider as in tenant configuration.
+func (e *CohereEmbedder) ModelName() string {
+	return "cohere/" + e.model
+}
+
+// Ensure CohereEmbedder implements Embedder interface.
+var _ Embedder = (*CohereEmbedder)(nil)
@@ -12,6 +12,15 @@ type Embedder interface {
 	// Returns a slice of embeddings in the same order as the input texts.
 	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
 
+	// EmbedQuery generates an embedding vector for a search query. Models
+	// trained asymmetrically embed queries differently from documents, via a
+	// task prefix (nomic-embed-text) or an input type (Cohere, Voyage).
+	EmbedQuery(ctx context.Context, text string) ([]float32, error)
+
+	// EmbedDocuments generates embedding vectors for texts being indexed for
+	// retrieval, in the same order as the input texts.
+	EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error)
+
 	// Dimension returns the dimensionality of the embedding vectors.
 	Dimension() int
 
@@ -21,10 +30,13 @@ type Embedder interface {
 
 // ModelConfig holds configuration for a specific embedding model.
 type ModelConfig struct {
-	Dimension       int // Embedding dimension
-	ContextLength   int // Max tokens the model can process
-	MaxChunkWords   int // Recommended max chunk size in words (safe limit)
+	Dimension        int // Embedding dimension
+	ContextLength    int // Max tokens the model can process
+	MaxChunkWords    int // Recommended max chunk size in words (safe limit)
 	TargetChunkWords int // Recommended target chunk size in words
+
+	QueryPrefix    string // Prepended to search queries, for models trained with task prefixes
+	DocumentPrefix string // Prepended to indexed documents
 }
 
 // KnownModels maps embedding model names to their configurations.
@@ -33,14 +45,17 @@ var KnownModels = map[string]ModelConfig{
 	"nomic-embed-text": {
 		Dimension:        768,
 		ContextLength:    8192,
-		MaxChunkWords:    512,  // ~700 tokens, safe margin under 8192
+		MaxChunkWords:    512, // ~700 tokens, safe margin under 8192
 		TargetChunkWords: 256,
+		QueryPrefix:      "search_query: ",
+		DocumentPrefix:   "search_document: ",
 	},
 	"mxbai-embed-large": {
 		Dimension:        1024,
 		ContextLength:    512,
-		MaxChunkWords:    300,  // Very limited context
+		MaxChunkWords:    300, // Very limited context
 		TargetChunkWords: 150,
+		QueryPrefix:      "Represent this sentence for searching relevant passages: ",
 	},
 	"all-minilm": {
 		Dimension:        384,
@@ -53,12 +68,54 @@ var KnownModels = map[string]ModelConfig{
 		ContextLength:    8192,
 		MaxChunkWords:    512,
 		TargetChunkWords: 256,
+		QueryPrefix:      "Represent this sentence for searching relevant passages: ",
+	},
+	"embed-english-v3.0": {
+		Dimension:        1024,
+		ContextLength:    512,
+		MaxChunkWords:    300,
+		TargetChunkWords: 150,
 	},
+	"embed-multilingual-v3.0": {
+		Dimension:        1024,
+		ContextLength:    512,
+		MaxChunkWords:    300,
+		TargetChunkWords: 150,
+	},
+	"embed-english-light-v3.0": {
+		Dimension:        384,
+		ContextLength:    512,
+		MaxChunkWords:    300,
+		TargetChunkWords: 150,
+	},
+	"voyage-3": {
+		Dimension:        1024,
+		ContextLength:    32000,
+		MaxChunkWords:    512,
+		TargetChunkWords: 256,
+	},
+	"voyage-3-lite": {
+		Dimension:        512,
+		ContextLength:    32000,
+		MaxChunkWords:    512,
+		TargetChunkWords: 256,
+	},
+}
+
+// LookupModelConfig returns the configuration for a known model.
+// Provider-qualified names such as "cohere/embed-english-v3.0" are looked up by model.
+func LookupModelConfig(modelName string) (ModelConfig, bool) {
+	if cfg, ok := KnownModels[modelName]; ok {
+		return cfg, true
+	}
+	_, model := ParseModelRef(modelName)
+	cfg, ok := KnownModels[model]
+	return cfg, ok
 }
 
 // GetModelConfig returns the configuration for a model, or defaults if unknown.
 func GetModelConfig(modelName string) ModelConfig {
-	if cfg, ok := KnownModels[modelName]; ok {
+	if cfg, ok := LookupModelConfig(modelName); ok {
 		return cfg
 	}
 	// Conservative defaults for unknown models
@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
+
+	"github.com/knoguchi/rag/internal/requestid"
 )
 
 const (
@@ -22,6 +27,12 @@ const (
 
 	// DefaultBatchConcurrency is the default number of concurrent embedding requests.
 	DefaultBatchConcurrency = 4
+
+	// DefaultMaxBatchSize is the default number of texts sent per /api/embed request.
+	DefaultMaxBatchSize = 64
+
+	// DefaultMaxBatchBytes is the default limit on the text bytes per /api/embed request.
+	DefaultMaxBatchBytes = 1 << 20
 )
 
 // OllamaConfig holds configuration for the Ollama embedder.
@@ -38,8 +49,22 @@ type OllamaConfig struct {
 	// BatchConcurrency is the number of concurrent requests for batch embedding.
 	BatchConcurrency int
 
+	// MaxBatchSize is the maximum number of texts per /api/embed request (default: 64).
+	MaxBatchSize int
+
+	// MaxBatchBytes is the maximum total text size per /api/embed request
+	// (default: 1 MiB). A single larger text is still sent on its own.
+	MaxBatchBytes int
+
 	// HTTPClient is an optional custom HTTP client.
 	HTTPClient *http.Client
+
+	// Retry controls retries and per-request timeouts for transient failures.
+	Retry RetryConfig
+
+	// DisableTaskPrefixes turns off the model's query and document prefixes
+	// (see ModelConfig), for collections indexed without them.
+	DisableTaskPrefixes bool
 }
 
 // OllamaEmbedder implements the Embedder interface using Ollama's API.
@@ -48,20 +73,39 @@ type OllamaEmbedder struct {
 	model            string
 	dimension        int
 	batchConcurrency int
+	maxBatchSize     int
+	maxBatchBytes    int
 	client           *http.Client
+	retry            RetryConfig
+	queryPrefix      string
+	documentPrefix   string
+
+	// legacy is set once the server turns out to predate /api/embed (Ollama < 0.3.4)
+	legacy atomic.Bool
 }
 
-// ollamaRequest represents the request body for Ollama embedding API.
+// ollamaRequest represents the request body for the legacy /api/embeddings endpoint.
 type ollamaRequest struct {
 	Model  string `json:"model"`
 	Prompt string `json:"prompt"`
 }
 
-// ollamaResponse represents the response from Ollama embedding API.
+// ollamaResponse represents the response from the legacy /api/embeddings endpoint.
 type ollamaResponse struct {
 	Embedding []float64 `json:"embedding"`
 }
 
+// ollamaBatchRequest represents the request body for the /api/embed batch endpoint.
+type ollamaBatchRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// ollamaBatchResponse represents the response from the /api/embed batch endpoint.
+type ollamaBatchResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
 // NewOllamaEmbedder creates a new Ollama embedder with the given configuration.
 func NewOllamaEmbedder(cfg OllamaConfig) *OllamaEmbedder {
 	baseURL := cfg.BaseURL
@@ -84,22 +128,127 @@ func NewOllamaEmbedder(cfg OllamaConfig) *OllamaEmbedder {
 		batchConcurrency = DefaultBatchConcurrency
 	}
 
+	maxBatchSize := cfg.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	maxBatchBytes := cfg.MaxBatchBytes
+	if maxBatchBytes <= 0 {
+		maxBatchBytes = DefaultMaxBatchBytes
+	}
+
 	client := cfg.HTTPClient
 	if client == nil {
 		client = http.DefaultClient
 	}
 
-	return &OllamaEmbedder{
+	e := &OllamaEmbedder{
 		baseURL:          baseURL,
 		model:            model,
 		dimension:        dimension,
 		batchConcurrency: batchConcurrency,
+		maxBatchSize:     maxBatchSize,
+		maxBatchBytes:    maxBatchBytes,
 		client:           client,
+		retry:            cfg.Retry.withDefaults(),
+	}
+	if !cfg.DisableTaskPrefixes {
+		modelCfg := GetModelConfig(model)
+		e.queryPrefix, e.documentPrefix = modelCfg.QueryPrefix, modelCfg.DocumentPrefix
+	}
+	return e
+}
+
+// EmbedQuery embeds a search query with the model's query prefix.
+func (e *OllamaEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return e.Embed(ctx, e.queryPrefix+text)
+}
+
+// EmbedDocuments embeds texts for indexing with the model's document prefix.
+func (e *OllamaEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	if e.documentPrefix == "" {
+		return e.EmbedBatch(ctx, texts)
 	}
+	prefixed := make([]string, len(texts))
+	for i, text := range texts {
+		prefixed[i] = e.documentPrefix + text
+	}
+	return e.EmbedBatch(ctx, prefixed)
 }
 
-// Embed generates an embedding vector for a single text input.
+// Embed generates an embedding vector for a single text input, retrying
+// transient failures with exponential backoff.
 func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	if !e.legacy.Load() {
+		embeddings, err := withRetry(ctx, e.retry, func(ctx context.Context) ([][]float32, error) {
+			return e.embedInputs(ctx, []string{text})
+		})
+		if !errors.Is(err, errNoBatchEndpoint) {
+			if err != nil {
+				return nil, err
+			}
+			return embeddings[0], nil
+		}
+	}
+	return withRetry(ctx, e.retry, func(ctx context.Context) ([]float32, error) {
+		return e.embed(ctx, text)
+	})
+}
+
+// errNoBatchEndpoint means the server has no /api/embed endpoint.
+var errNoBatchEndpoint = errors.New("ollama server does not support /api/embed")
+
+// embedInputs embeds several texts in one /api/embed request.
+func (e *OllamaEmbedder) embedInputs(ctx context.Context, texts []string) ([][]float32, error) {
+	jsonBody, err := json.Marshal(ollamaBatchRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/embed", e.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		// Unknown routes get a plain 404; a missing model is a JSON error
+		if resp.StatusCode == http.StatusNotFound && strings.Contains(string(body), "page not found") {
+			e.legacy.Store(true)
+			return nil, errNoBatchEndpoint
+		}
+		return nil, &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var batchResp ollamaBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(batchResp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("ollama returned %d embeddings for %d inputs", len(batchResp.Embeddings), len(texts))
+	}
+	for _, embedding := range batchResp.Embeddings {
+		if len(embedding) == 0 {
+			return nil, fmt.Errorf("empty embedding returned from Ollama")
+		}
+	}
+
+	return batchResp.Embeddings, nil
+}
+
+// embed makes a single request to the legacy /api/embeddings endpoint.
+func (e *OllamaEmbedder) embed(ctx context.Context, text string) ([]float32, error) {
 	reqBody := ollamaRequest{
 		Model:  e.model,
 		Prompt: text,
@@ -116,6 +265,7 @@ func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, err
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
 
 	resp, err := e.client.Do(req)
 	if err != nil {
@@ -125,7 +275,7 @@ func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, err
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ollama API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var ollamaResp ollamaResponse
@@ -147,12 +297,81 @@ func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, err
 }
 
 // EmbedBatch generates embedding vectors for multiple text inputs.
-// It processes requests concurrently for efficiency.
+// Texts are sent to /api/embed in sub-batches bounded by MaxBatchSize and
+// MaxBatchBytes, several at a time. If some texts fail, it returns the other
+// embeddings (nil at failed indexes) with a *BatchError.
 func (e *OllamaEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	if len(texts) == 0 {
 		return [][]float32{}, nil
 	}
+	if e.legacy.Load() {
+		return e.embedEach(ctx, texts)
+	}
 
+	results := make([][]float32, len(texts))
+	errs := make([]error, len(texts))
+
+	var wg sync.WaitGroup
+	var legacy atomic.Bool
+	semaphore := make(chan struct{}, e.batchConcurrency)
+
+	for _, span := range splitBatches(texts, e.maxBatchSize, e.maxBatchBytes) {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				for i := start; i < end; i++ {
+					errs[i] = ctx.Err()
+				}
+				return
+			}
+
+			embeddings, err := withRetry(ctx, e.retry, func(ctx context.Context) ([][]float32, error) {
+				return e.embedInputs(ctx, texts[start:end])
+			})
+			if errors.Is(err, errNoBatchEndpoint) {
+				legacy.Store(true)
+				return
+			}
+			if err != nil {
+				for i := start; i < end; i++ {
+					errs[i] = fmt.Errorf("failed to embed batch %d-%d: %w", start, end-1, err)
+				}
+				return
+			}
+			copy(results[start:end], embeddings)
+		}(span[0], span[1])
+	}
+
+	wg.Wait()
+
+	if legacy.Load() {
+		return e.embedEach(ctx, texts)
+	}
+	return batchResult(ctx, results, errs)
+}
+
+// splitBatches divides texts into contiguous [start, end) spans of at most
+// maxSize texts and maxBytes bytes.
+func splitBatches(texts []string, maxSize, maxBytes int) [][2]int {
+	var spans [][2]int
+	start, size := 0, 0
+	for i, text := range texts {
+		if i > start && (i-start >= maxSize || size+len(text) > maxBytes) {
+			spans = append(spans, [2]int{start, i})
+			start, size = i, 0
+		}
+		size += len(text)
+	}
+	return append(spans, [2]int{start, len(texts)})
+}
+
+// embedEach embeds texts with one legacy request per text, for servers without /api/embed.
+func (e *OllamaEmbedder) embedEach(ctx context.Context, texts []string) ([][]float32, error) {
 	results := make([][]float32, len(texts))
 	errors := make([]error, len(texts))
 
@@ -173,7 +392,9 @@ func (e *OllamaEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]fl
 				return
 			}
 
-			embedding, err := e.Embed(ctx, t)
+			embedding, err := withRetry(ctx, e.retry, func(ctx context.Context) ([]float32, error) {
+				return e.embed(ctx, t)
+			})
 			if err != nil {
 				errors[idx] = fmt.Errorf("failed to embed text at index %d: %w", idx, err)
 				return
@@ -184,12 +405,24 @@ func (e *OllamaEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]fl
 
 	wg.Wait()
 
-	// Check for errors
-	for i, err := range errors {
+	return batchResult(ctx, results, errors)
+}
+
+// batchResult reports partial failures so callers can retry only those texts.
+func batchResult(ctx context.Context, results [][]float32, errs []error) ([][]float32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	failed := make(map[int]error)
+	for i, err := range errs {
 		if err != nil {
-			return nil, fmt.Errorf("batch embedding failed at index %d: %w", i, err)
+			failed[i] = err
 		}
 	}
+	if len(failed) > 0 {
+		return results, &BatchError{Errors: failed}
+	}
 
 	return results, nil
 }
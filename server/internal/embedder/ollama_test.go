@@ -0,0 +1,195 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+var fastRetry = RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestOllamaEmbedder_RetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"embeddings":[[0.1,0.2]]}`))
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, Retry: fastRetry})
+	vec, err := e.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vec) != 2 || calls.Load() != 3 {
+		t.Errorf("expected success on third attempt, got %v after %d calls", vec, calls.Load())
+	}
+}
+
+func TestOllamaEmbedder_NoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, Retry: fastRetry})
+	_, err := e.Embed(context.Background(), "hello")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 APIError, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestEmbedAll_RetriesOnlyFailedTexts(t *testing.T) {
+	var failed atomic.Bool
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaBatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if strings.HasSuffix(req.Input[0], "flaky") && !failed.Swap(true) {
+			http.Error(w, "bad input", http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req.Input[0])
+		_, _ = w.Write([]byte(`{"embeddings":[[1]]}`))
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, BatchConcurrency: 1, MaxBatchSize: 1, Retry: fastRetry})
+	texts := []string{"a", "flaky", "b"}
+
+	partial, err := e.EmbedBatch(context.Background(), texts)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Failed()) != 1 || batchErr.Failed()[0] != 1 {
+		t.Fatalf("expected index 1 to fail, got %v", err)
+	}
+	if partial[0] == nil || partial[1] != nil || partial[2] == nil {
+		t.Errorf("expected partial results around the failure, got %v", partial)
+	}
+
+	failed.Store(false)
+	requests = nil
+	embeddings, err := EmbedAll(context.Background(), e, texts, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(embeddings) != 3 || embeddings[1] == nil {
+		t.Errorf("expected all embeddings, got %v", embeddings)
+	}
+	if len(requests) != 3 || requests[2] != "search_document: flaky" {
+		t.Errorf("expected only the failed text to be re-sent, got %v", requests)
+	}
+}
+
+func TestOllamaEmbedder_EmbedBatchSplitsRequests(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			http.NotFound(w, r)
+			return
+		}
+		var req ollamaBatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		sizes = append(sizes, len(req.Input))
+		resp := ollamaBatchResponse{}
+		for _, text := range req.Input {
+			resp.Embeddings = append(resp.Embeddings, []float32{float32(len(text))})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, BatchConcurrency: 1, MaxBatchSize: 2, MaxBatchBytes: 10})
+	texts := []string{"a", "bb", "ccc", "dddddddddddd", "e"}
+	embeddings, err := e.EmbedBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, text := range texts {
+		if embeddings[i][0] != float32(len(text)) {
+			t.Errorf("embedding %d out of order: %v", i, embeddings[i])
+		}
+	}
+	// Two per request, and the oversized text goes alone
+	sort.Ints(sizes)
+	if len(sizes) != 4 || sizes[0] != 1 || sizes[1] != 1 || sizes[2] != 1 || sizes[3] != 2 {
+		t.Errorf("unexpected request sizes %v", sizes)
+	}
+}
+
+func TestOllamaEmbedder_FallsBackToLegacyEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"embedding":[0.5]}`))
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL})
+	embeddings, err := e.EmbedBatch(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(embeddings) != 2 || embeddings[1][0] != 0.5 || !e.legacy.Load() {
+		t.Errorf("expected legacy embeddings, got %v", embeddings)
+	}
+}
+
+func TestOllamaEmbedder_TaskPrefixes(t *testing.T) {
+	var inputs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaBatchRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		inputs = append(inputs, req.Input...)
+		_, _ = w.Write([]byte(`{"embeddings":[[1]]}`))
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, Model: "nomic-embed-text"})
+	_, _ = e.EmbedQuery(context.Background(), "what is rag")
+	_, _ = e.EmbedDocuments(context.Background(), []string{"rag is retrieval"})
+
+	plain := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, Model: "nomic-embed-text", DisableTaskPrefixes: true})
+	_, _ = plain.EmbedQuery(context.Background(), "what is rag")
+
+	want := []string{"search_query: what is rag", "search_document: rag is retrieval", "what is rag"}
+	if strings.Join(inputs, "|") != strings.Join(want, "|") {
+		t.Errorf("got inputs %q, want %q", inputs, want)
+	}
+}
+
+func TestOllamaEmbedder_ForwardsRequestID(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(requestid.Header)
+		_, _ = w.Write([]byte(`{"embeddings":[[1]]}`))
+	}))
+	defer server.Close()
+
+	e := NewOllamaEmbedder(OllamaConfig{BaseURL: server.URL, Model: "nomic-embed-text"})
+	if _, err := e.EmbedQuery(requestid.NewContext(context.Background(), "req-42"), "what is rag"); err != nil {
+		t.Fatal(err)
+	}
+	if got != "req-42" {
+		t.Errorf("%s = %q, want the request's ID", requestid.Header, got)
+	}
+}
@@ -0,0 +1,78 @@
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Pool lazily creates and caches embedders per model name so tenants can use different models.
+type Pool struct {
+	mu        sync.Mutex
+	fallback  Embedder
+	factory   func(model string) (Embedder, error)
+	embedders map[string]Embedder
+}
+
+// NewPool creates a pool that serves fallback for its own model (or an empty model name)
+// and builds embedders for other models with factory.
+func NewPool(fallback Embedder, factory func(model string) (Embedder, error)) *Pool {
+	return &Pool{
+		fallback:  fallback,
+		factory:   factory,
+		embedders: map[string]Embedder{fallback.ModelName(): fallback},
+	}
+}
+
+// Get returns the embedder for the given model.
+func (p *Pool) Get(model string) Embedder {
+	if model == "" {
+		return p.fallback
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.embedders[model]; ok {
+		return e
+	}
+
+	e, err := p.factory(model)
+	if err != nil {
+		// Not cached, so the model works once the provider is configured
+		return &unavailableEmbedder{model: model, err: err}
+	}
+	p.embedders[model] = e
+	return e
+}
+
+// unavailableEmbedder fails every call for a model whose provider cannot be created.
+type unavailableEmbedder struct {
+	model string
+	err   error
+}
+
+func (u *unavailableEmbedder) Embed(context.Context, string) ([]float32, error) {
+	return nil, fmt.Errorf("embedding model %s is unavailable: %w", u.model, u.err)
+}
+
+func (u *unavailableEmbedder) EmbedBatch(ctx context.Context, _ []string) ([][]float32, error) {
+	_, err := u.Embed(ctx, "")
+	return nil, err
+}
+
+func (u *unavailableEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return u.Embed(ctx, text)
+}
+
+func (u *unavailableEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	return u.EmbedBatch(ctx, texts)
+}
+
+func (u *unavailableEmbedder) Dimension() int {
+	return GetModelConfig(u.model).Dimension
+}
+
+func (u *unavailableEmbedder) ModelName() string {
+	return u.model
+}
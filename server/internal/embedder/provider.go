@@ -0,0 +1,81 @@
+package embedder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProviderConfig describes an embedding backend.
+type ProviderConfig struct {
+	// Kind is the backend type: "ollama", "cohere" or "voyage".
+	Kind string
+
+	// BaseURL is the backend endpoint (default depends on Kind).
+	BaseURL string
+
+	// APIKey authenticates with hosted providers.
+	APIKey string
+
+	// Model is the embedding model.
+	Model string
+
+	// Retry controls retries and per-request timeouts for transient failures.
+	Retry RetryConfig
+
+	// MaxBatchSize limits texts per request for Ollama.
+	MaxBatchSize int
+
+	// DisableTaskPrefixes turns off query and document prefixes for Ollama models.
+	DisableTaskPrefixes bool
+}
+
+// ParseModelRef splits an embedding model reference of the form
+// "provider/model" (e.g., "cohere/embed-english-v3.0"). References without a
+// known provider prefix are Ollama models.
+func ParseModelRef(ref string) (kind, model string) {
+	if kind, model, ok := strings.Cut(ref, "/"); ok {
+		switch kind {
+		case "ollama", "cohere", "voyage":
+			return kind, model
+		}
+	}
+	return "ollama", ref
+}
+
+// NewProvider creates an embedder from configuration.
+func NewProvider(cfg ProviderConfig) (Embedder, error) {
+	switch cfg.Kind {
+	case "", "ollama":
+		return NewOllamaEmbedder(OllamaConfig{
+			BaseURL:             cfg.BaseURL,
+			Model:               cfg.Model,
+			Dimension:           GetModelConfig(cfg.Model).Dimension,
+			Retry:               cfg.Retry,
+			MaxBatchSize:        cfg.MaxBatchSize,
+			DisableTaskPrefixes: cfg.DisableTaskPrefixes,
+		}), nil
+	case "cohere":
+		if cfg.APIKey == "" {
+			return nil, errors.New("cohere embedder requires an API key")
+		}
+		return NewCohereEmbedder(CohereConfig{
+			BaseURL: cfg.BaseURL,
+			APIKey:  cfg.APIKey,
+			Model:   cfg.Model,
+			Retry:   cfg.Retry,
+		}), nil
+	case "voyage":
+		if cfg.APIKey == "" {
+			return nil, errors.New("voyage embedder requires an API key")
+		}
+		return NewVoyageEmbedder(VoyageConfig{
+			BaseURL: cfg.BaseURL,
+			APIKey:  cfg.APIKey,
+			Model:   cfg.Model,
+			Retry:   cfg.Retry,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider: %s", cfg.Kind)
+	}
+}
@@ -0,0 +1,74 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseModelRef(t *testing.T) {
+	tests := []struct {
+		ref, kind, model string
+	}{
+		{"nomic-embed-text", "ollama", "nomic-embed-text"},
+		{"cohere/embed-english-v3.0", "cohere", "embed-english-v3.0"},
+		{"voyage/voyage-3", "voyage", "voyage-3"},
+		{"jina/jina-embeddings-v2", "ollama", "jina/jina-embeddings-v2"},
+	}
+	for _, tt := range tests {
+		kind, model := ParseModelRef(tt.ref)
+		if kind != tt.kind || model != tt.model {
+			t.Errorf("ParseModelRef(%q) = %q, %q; want %q, %q", tt.ref, kind, model, tt.kind, tt.model)
+		}
+	}
+	if GetModelConfig("cohere/embed-english-v3.0").Dimension != 1024 {
+		t.Error("expected provider-qualified model to resolve to its known config")
+	}
+}
+
+func TestCohereEmbedder_InputTypes(t *testing.T) {
+	var inputTypes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req cohereRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		inputTypes = append(inputTypes, req.InputType)
+		var resp cohereResponse
+		for range req.Texts {
+			resp.Embeddings.Float = append(resp.Embeddings.Float, []float32{1, 0})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	e := NewCohereEmbedder(CohereConfig{BaseURL: server.URL, APIKey: "key"})
+	if _, err := e.EmbedDocuments(context.Background(), []string{"doc one", "doc two"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := e.EmbedQuery(context.Background(), "question"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inputTypes) != 2 || inputTypes[0] != "search_document" || inputTypes[1] != "search_query" {
+		t.Errorf("unexpected input types %v", inputTypes)
+	}
+	if e.ModelName() != "cohere/embed-english-v3.0" || e.Dimension() != 1024 {
+		t.Errorf("unexpected model %s (dimension %d)", e.ModelName(), e.Dimension())
+	}
+}
+
+func TestVoyageEmbedder_OrdersByIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"embedding":[2],"index":1},{"embedding":[1],"index":0}]}`))
+	}))
+	defer server.Close()
+
+	e := NewVoyageEmbedder(VoyageConfig{BaseURL: server.URL, APIKey: "key"})
+	embeddings, err := e.EmbedBatch(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if embeddings[0][0] != 1 || embeddings[1][0] != 2 {
+		t.Errorf("expected embeddings in input order, got %v", embeddings)
+	}
+}
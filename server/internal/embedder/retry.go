@@ -0,0 +1,253 @@
+package embedder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of attempts per embedding request.
+	DefaultMaxAttempts = 3
+
+	// DefaultInitialBackoff is the default delay before the first retry.
+	DefaultInitialBackoff = 500 * time.Millisecond
+
+	// DefaultMaxBackoff caps the delay between retries.
+	DefaultMaxBackoff = 10 * time.Second
+
+	// DefaultJitter is the default fraction of each delay that is randomized.
+	DefaultJitter = 0.2
+
+	// DefaultRequestTimeout bounds a single embedding request.
+	DefaultRequestTimeout = 60 * time.Second
+)
+
+// RetryConfig controls how transient embedding failures are retried.
+// Zero values are replaced with the defaults above.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first (1 disables retries).
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry; it doubles with each attempt.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0-1) of each delay that is randomized, so
+	// concurrent requests do not retry in lockstep.
+	Jitter float64
+
+	// RequestTimeout bounds each attempt.
+	RequestTimeout time.Duration
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = DefaultInitialBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	if c.Jitter <= 0 || c.Jitter > 1 {
+		c.Jitter = DefaultJitter
+	}
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = DefaultRequestTimeout
+	}
+	return c
+}
+
+// backoff returns the delay before the given retry (1 for the first retry).
+func (c RetryConfig) backoff(retry int) time.Duration {
+	delay := c.InitialBackoff << (retry - 1)
+	if delay <= 0 || delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	spread := float64(delay) * c.Jitter
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
+
+// APIError is a non-200 response from an embedding backend.
+type APIError struct {
+	// Provider identifies the backend (e.g., "ollama", "cohere").
+	Provider string
+
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Body is the response body, usually an error message.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s embedding API error (status %d): %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// IsRetryable reports whether an embedding error is transient: a 5xx or 429
+// response, a timeout or a connection failure. Cancellation is not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// withRetry calls fn until it succeeds, fails permanently or runs out of
+// attempts. Each attempt gets its own timeout; the parent context bounds the total.
+func withRetry[T any](ctx context.Context, cfg RetryConfig, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	var err error
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.RequestTimeout)
+		var result T
+		result, err = fn(attemptCtx)
+		cancel()
+		if err == nil {
+			return result, nil
+		}
+		// A deadline on the parent context is the caller's, not a transient failure
+		if ctx.Err() != nil {
+			return zero, err
+		}
+		if attempt >= cfg.MaxAttempts || !IsRetryable(err) {
+			break
+		}
+
+		timer := time.NewTimer(cfg.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if cfg.MaxAttempts > 1 && IsRetryable(err) {
+		return zero, fmt.Errorf("giving up after %d attempts: %w", cfg.MaxAttempts, err)
+	}
+	return zero, err
+}
+
+// BatchError reports which texts of a batch failed to embed. EmbedBatch
+// returns it alongside the successful embeddings (nil at failed indexes), so
+// callers can retry only the failures.
+type BatchError struct {
+	// Errors maps input indexes to their failure.
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	failed := e.Failed()
+	msg := fmt.Sprintf("%d of the batch failed to embed", len(failed))
+	if len(failed) > 0 {
+		msg += fmt.Sprintf(" (first at index %d: %v)", failed[0], e.Errors[failed[0]])
+	}
+	return msg
+}
+
+// Unwrap exposes the individual failures to errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, idx := range e.Failed() {
+		errs = append(errs, e.Errors[idx])
+	}
+	return errs
+}
+
+// Failed returns the failed input indexes in ascending order.
+func (e *BatchError) Failed() []int {
+	idxs := make([]int, 0, len(e.Errors))
+	for idx := range e.Errors {
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	return idxs
+}
+
+// EmbedAll embeds documents for indexing, re-embedding the failures of a
+// partially failed batch up to rounds more times before giving up.
+func EmbedAll(ctx context.Context, e Embedder, texts []string, rounds int) ([][]float32, error) {
+	embeddings, err := e.EmbedDocuments(ctx, texts)
+	for round := 0; round < rounds; round++ {
+		var batchErr *BatchError
+		if !errors.As(err, &batchErr) || len(embeddings) != len(texts) {
+			break
+		}
+		failed := batchErr.Failed()
+		retryTexts := make([]string, len(failed))
+		for i, idx := range failed {
+			retryTexts[i] = texts[idx]
+		}
+
+		retried, retryErr := e.EmbedDocuments(ctx, retryTexts)
+		var retryBatchErr *BatchError
+		if retryErr != nil && (!errors.As(retryErr, &retryBatchErr) || len(retried) != len(retryTexts)) {
+			return nil, retryErr
+		}
+		remaining := make(map[int]error)
+		for i, idx := range failed {
+			if retried[i] != nil {
+				embeddings[idx] = retried[i]
+			} else if retryBatchErr != nil {
+				remaining[idx] = retryBatchErr.Errors[i]
+			}
+		}
+		if len(remaining) == 0 {
+			return embeddings, nil
+		}
+		err = &BatchError{Errors: remaining}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
+
+// embedSpans embeds each [start, end) span of texts with one retried call to
+// fn, collecting per-span failures into a *BatchError.
+func embedSpans(ctx context.Context, texts []string, spans [][2]int, cfg RetryConfig, fn func(ctx context.Context, texts []string) ([][]float32, error)) ([][]float32, error) {
+	results := make([][]float32, len(texts))
+	failed := make(map[int]error)
+	for _, span := range spans {
+		start, end := span[0], span[1]
+		embeddings, err := withRetry(ctx, cfg, func(ctx context.Context) ([][]float32, error) {
+			return fn(ctx, texts[start:end])
+		})
+		if err == nil && len(embeddings) != end-start {
+			err = fmt.Errorf("got %d embeddings for %d inputs", len(embeddings), end-start)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			for i := start; i < end; i++ {
+				failed[i] = fmt.Errorf("failed to embed batch %d-%d: %w", start, end-1, err)
+			}
+			continue
+		}
+		copy(results[start:end], embeddings)
+	}
+	if len(failed) > 0 {
+		return results, &BatchError{Errors: failed}
+	}
+	return results, nil
+}
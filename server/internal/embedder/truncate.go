@@ -0,0 +1,87 @@
+package embedder
+
+import (
+	"context"
+	"math"
+)
+
+// Truncate returns an embedder whose vectors are cut to the first dim
+// components and L2-normalized. Models trained with Matryoshka representation
+// learning (e.g., nomic-embed-text v1.5) keep most of their quality when
+// truncated this way. e is returned unchanged if dim is not below its dimension.
+func Truncate(e Embedder, dim int) Embedder {
+	if dim <= 0 || dim >= e.Dimension() {
+		return e
+	}
+	return &truncatedEmbedder{base: e, dim: dim}
+}
+
+// truncatedEmbedder wraps an embedder with dimension truncation.
+type truncatedEmbedder struct {
+	base Embedder
+	dim  int
+}
+
+func (t *truncatedEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec, err := t.base.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	return truncateVector(vec, t.dim), nil
+}
+
+func (t *truncatedEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	vec, err := t.base.EmbedQuery(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	return truncateVector(vec, t.dim), nil
+}
+
+func (t *truncatedEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs, err := t.base.EmbedBatch(ctx, texts)
+	return t.truncateAll(vecs), err
+}
+
+func (t *truncatedEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs, err := t.base.EmbedDocuments(ctx, texts)
+	return t.truncateAll(vecs), err
+}
+
+// truncateAll truncates each vector in place, skipping the nil entries of partial batches.
+func (t *truncatedEmbedder) truncateAll(vecs [][]float32) [][]float32 {
+	for i, vec := range vecs {
+		if vec != nil {
+			vecs[i] = truncateVector(vec, t.dim)
+		}
+	}
+	return vecs
+}
+
+func (t *truncatedEmbedder) Dimension() int {
+	return t.dim
+}
+
+func (t *truncatedEmbedder) ModelName() string {
+	return t.base.ModelName()
+}
+
+// truncateVector returns the first dim components of vec, scaled to unit length.
+func truncateVector(vec []float32, dim int) []float32 {
+	if len(vec) > dim {
+		vec = vec[:dim]
+	}
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	out := make([]float32, len(vec))
+	if norm == 0 {
+		return out
+	}
+	scale := 1 / math.Sqrt(norm)
+	for i, v := range vec {
+		out[i] = float32(float64(v) * scale)
+	}
+	return out
+}
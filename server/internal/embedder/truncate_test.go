@@ -0,0 +1,24 @@
+package embedder
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTruncateVector(t *testing.T) {
+	vec := truncateVector([]float32{3, 4, 12, 99}, 2)
+	if len(vec) != 2 {
+		t.Fatalf("expected 2 dimensions, got %d", len(vec))
+	}
+	if math.Abs(float64(vec[0])-0.6) > 1e-6 || math.Abs(float64(vec[1])-0.8) > 1e-6 {
+		t.Errorf("expected unit vector [0.6 0.8], got %v", vec)
+	}
+
+	base := NewOllamaEmbedder(OllamaConfig{Model: "nomic-embed-text"})
+	if Truncate(base, 0) != Embedder(base) || Truncate(base, 768) != Embedder(base) {
+		t.Error("expected no wrapping at or above the model dimension")
+	}
+	if d := Truncate(base, 256).Dimension(); d != 256 {
+		t.Errorf("expected truncated dimension 256, got %d", d)
+	}
+}
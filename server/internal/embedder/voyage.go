@@ -0,0 +1,193 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+const (
+	// DefaultVoyageBaseURL is the Voyage AI API endpoint.
+	DefaultVoyageBaseURL = "https://api.voyageai.com"
+
+	// DefaultVoyageModel is the default Voyage embedding model.
+	DefaultVoyageModel = "voyage-3"
+
+	// voyageMaxBatchSize is the API's limit on texts per request.
+	voyageMaxBatchSize = 128
+)
+
+// VoyageConfig holds configuration for the Voyage embedder.
+type VoyageConfig struct {
+	// BaseURL is the API endpoint (default: https://api.voyageai.com).
+	BaseURL string
+
+	// APIKey is the Voyage API key.
+	APIKey string
+
+	// Model is the embedding model (default: voyage-3).
+	Model string
+
+	// Dimension is the embedding dimension (default: from KnownModels).
+	Dimension int
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+
+	// Retry controls retries and per-request timeouts for transient failures.
+	Retry RetryConfig
+}
+
+// VoyageEmbedder implements the Embedder interface using Voyage AI's embeddings API.
+// Documents and queries are embedded with input_type document and query,
+// which prepends Voyage's retrieval prompts.
+type VoyageEmbedder struct {
+	baseURL   string
+	apiKey    string
+	model     string
+	dimension int
+	client    *http.Client
+	retry     RetryConfig
+}
+
+// voyageRequest represents the request body for the embeddings API.
+type voyageRequest struct {
+	Model     string   `json:"model"`
+	Input     []string `json:"input"`
+	InputType string   `json:"input_type"`
+}
+
+// voyageResponse represents the response from the embeddings API.
+type voyageResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+}
+
+// NewVoyageEmbedder creates a new Voyage embedder with the given configuration.
+func NewVoyageEmbedder(cfg VoyageConfig) *VoyageEmbedder {
+	e := &VoyageEmbedder{
+		baseURL:   strings.TrimSuffix(cfg.BaseURL, "/"),
+		apiKey:    cfg.APIKey,
+		model:     cfg.Model,
+		dimension: cfg.Dimension,
+		client:    cfg.HTTPClient,
+		retry:     cfg.Retry.withDefaults(),
+	}
+	if e.baseURL == "" {
+		e.baseURL = DefaultVoyageBaseURL
+	}
+	if e.model == "" {
+		e.model = DefaultVoyageModel
+	}
+	if e.dimension <= 0 {
+		e.dimension = GetModelConfig(e.model).Dimension
+	}
+	if e.client == nil {
+		e.client = http.DefaultClient
+	}
+	return e
+}
+
+// Embed generates a document embedding for a single text input.
+func (e *VoyageEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return e.embedOne(ctx, text, "document")
+}
+
+// EmbedQuery generates a query embedding for a single text input.
+func (e *VoyageEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return e.embedOne(ctx, text, "query")
+}
+
+// EmbedDocuments generates document embeddings for texts being indexed.
+func (e *VoyageEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	return e.EmbedBatch(ctx, texts)
+}
+
+// EmbedBatch generates document embeddings for multiple text inputs. If some
+// requests fail, it returns the other embeddings with a *BatchError.
+func (e *VoyageEmbedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	spans := splitBatches(texts, voyageMaxBatchSize, DefaultMaxBatchBytes)
+	return embedSpans(ctx, texts, spans, e.retry, func(ctx context.Context, texts []string) ([][]float32, error) {
+		return e.embed(ctx, texts, "document")
+	})
+}
+
+func (e *VoyageEmbedder) embedOne(ctx context.Context, text, inputType string) ([]float32, error) {
+	embeddings, err := withRetry(ctx, e.retry, func(ctx context.Context) ([][]float32, error) {
+		return e.embed(ctx, []string{text}, inputType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// embed makes a single embeddings API request.
+func (e *VoyageEmbedder) embed(ctx context.Context, texts []string, inputType string) ([][]float32, error) {
+	jsonBody, err := json.Marshal(voyageRequest{Model: e.model, Input: texts, InputType: inputType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v1/embeddings", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Provider: "voyage", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var voyageResp voyageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&voyageResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(voyageResp.Data) != len(texts) {
+		return nil, fmt.Errorf("voyage returned %d embeddings for %d inputs", len(voyageResp.Data), len(texts))
+	}
+
+	// The API may return data out of input order
+	embeddings := make([][]float32, len(texts))
+	for _, d := range voyageResp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("voyage returned embedding for unknown index %d", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+
+	return embeddings, nil
+}
+
+// Dimension returns the dimensionality of the embedding vectors.
+func (e *VoyageEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// ModelName returns the model, qualified by provider as in tenant configuration.
+func (e *VoyageEmbedder) ModelName() string {
+	return "voyage/" + e.model
+}
+
+// Ensure VoyageEmbedder implements Embedder interface.
+var _ Embedder = (*VoyageEmbedder)(nil)
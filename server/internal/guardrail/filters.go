@@ -0,0 +1,128 @@
+package guardrail
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Names of the built-in filters, as tenants configure them.
+const (
+	FilterInjection = "injection"
+	FilterPII       = "pii"
+	FilterTopics    = "topics"
+)
+
+// BuiltinNames lists the built-in filters.
+var BuiltinNames = []string{FilterInjection, FilterPII, FilterTopics}
+
+// Builtin returns the built-in filter with the given name. bannedTopics
+// configures the topics filter.
+func Builtin(name string, bannedTopics []string) (Filter, error) {
+	switch name {
+	case FilterInjection:
+		return InjectionFilter{}, nil
+	case FilterPII:
+		return PIIFilter{}, nil
+	case FilterTopics:
+		return NewTopicFilter(bannedTopics), nil
+	}
+	return nil, fmt.Errorf("unknown guardrail filter %q (want one of %s)", name, strings.Join(BuiltinNames, ", "))
+}
+
+// injectionPatterns match instructions aimed at the LLM rather than the
+// reader, the usual shape of prompt injection planted in indexed content.
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\s+(?:all\s+|any\s+)?(?:of\s+)?(?:the\s+|your\s+|my\s+)?(?:previous|prior|above|earlier|preceding|original|system)\s+(?:instructions|prompts?|rules|directions|guidelines)`),
+	regexp.MustCompile(`(?i)\b(?:reveal|print|show|repeat|output)\s+(?:me\s+)?(?:your|the)\s+(?:system\s+prompt|hidden\s+(?:prompt|instructions)|initial\s+instructions)`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(?:DAN\b|in\s+developer\s+mode|jailbroken|unrestricted)`),
+	regexp.MustCompile(`(?i)\bnew\s+(?:system\s+)?instructions\s*:`),
+	regexp.MustCompile(`<\|im_start\|>|<\|im_end\|>|<\|system\|>|\[/?INST\]|<</?SYS>>`),
+}
+
+// InjectionFilter blocks text containing prompt-injection patterns. At the
+// context stage it drops retrieved chunks that try to instruct the LLM.
+type InjectionFilter struct{}
+
+// Name implements Filter.
+func (InjectionFilter) Name() string { return FilterInjection }
+
+// Check implements Filter.
+func (InjectionFilter) Check(_ context.Context, text string) (Result, error) {
+	for _, p := range injectionPatterns {
+		if m := p.FindString(text); m != "" {
+			return Result{Text: text, Blocked: true, Reason: fmt.Sprintf("prompt-injection pattern %q", m)}, nil
+		}
+	}
+	return Result{Text: text}, nil
+}
+
+// PIIFilter redacts email addresses, phone numbers, Social Security numbers
+// and payment card numbers.
+type PIIFilter struct{}
+
+// Name implements Filter.
+func (PIIFilter) Name() string { return FilterPII }
+
+// Check implements Filter.
+func (PIIFilter) Check(_ context.Context, text string) (Result, error) {
+	redacted, counts := RedactPII(text)
+	if len(counts) == 0 {
+		return Result{Text: text}, nil
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind, n := range counts {
+		kinds = append(kinds, fmt.Sprintf("%d %s", n, kind))
+	}
+	sort.Strings(kinds)
+	return Result{Text: redacted, Reason: "redacted " + strings.Join(kinds, ", ")}, nil
+}
+
+// TopicFilter blocks text mentioning a banned topic. Topics are matched as
+// case-insensitive phrases on word boundaries.
+type TopicFilter struct {
+	topics   []string
+	patterns []*regexp.Regexp
+}
+
+// NewTopicFilter creates a TopicFilter for the given topics.
+func NewTopicFilter(topics []string) *TopicFilter {
+	f := &TopicFilter{}
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		// Word boundaries only make sense around letters and digits; a
+		// topic like "C++" or one in a script without spaces is matched as is
+		pattern := regexp.QuoteMeta(topic)
+		pattern = strings.Join(strings.Fields(pattern), `\s+`)
+		if first, last := []rune(topic)[0], []rune(topic)[len([]rune(topic))-1]; isASCIIWord(first) && isASCIIWord(last) {
+			pattern = `\b` + pattern + `\b`
+		}
+		f.topics = append(f.topics, topic)
+		f.patterns = append(f.patterns, regexp.MustCompile(`(?i)`+pattern))
+	}
+	return f
+}
+
+// Name implements Filter.
+func (*TopicFilter) Name() string { return FilterTopics }
+
+// Check implements Filter.
+func (f *TopicFilter) Check(_ context.Context, text string) (Result, error) {
+	for i, p := range f.patterns {
+		if p.MatchString(text) {
+			return Result{Text: text, Blocked: true, Reason: fmt.Sprintf("banned topic %q", f.topics[i])}, nil
+		}
+	}
+	return Result{Text: text}, nil
+}
+
+// isASCIIWord reports whether r is a character \b treats as part of a word
+func isASCIIWord(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
@@ -0,0 +1,102 @@
+// Package guardrail screens RAG traffic with filters run at three stages: on
+// the user's query, on each retrieved chunk before it reaches the prompt,
+// and on the generated answer. A filter can pass text through, rewrite it
+// (e.g. redact PII) or block it.
+package guardrail
+
+import (
+	"context"
+	"fmt"
+)
+
+// Stage is the point in a query a filter runs at.
+type Stage string
+
+const (
+	StageInput   Stage = "input"   // the user's query; blocking rejects the query
+	StageContext Stage = "context" // each retrieved chunk; blocking drops the chunk
+	StageOutput  Stage = "output"  // the answer; blocking withholds it
+)
+
+// Actions recorded in Events.
+const (
+	ActionBlocked  = "blocked"
+	ActionModified = "modified"
+)
+
+// Result is a filter's verdict on a text.
+type Result struct {
+	Text    string // The text to continue with, possibly rewritten
+	Blocked bool
+	Reason  string // Why the text was blocked or rewritten
+}
+
+// Filter checks a text. Implementations must be safe for concurrent use.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, text string) (Result, error)
+}
+
+// Event records a filter blocking or rewriting a text.
+type Event struct {
+	Stage  Stage
+	Filter string
+	Action string
+	Reason string
+}
+
+// Pipeline holds the filters of each stage, run in order.
+type Pipeline struct {
+	Input   []Filter
+	Context []Filter
+	Output  []Filter
+}
+
+// Empty reports whether the pipeline has no filters at a stage.
+func (p Pipeline) Empty(stage Stage) bool {
+	return len(p.filters(stage)) == 0
+}
+
+// Append returns a pipeline running p's filters and then other's.
+func (p Pipeline) Append(other Pipeline) Pipeline {
+	return Pipeline{
+		Input:   append(p.Input[:len(p.Input):len(p.Input)], other.Input...),
+		Context: append(p.Context[:len(p.Context):len(p.Context)], other.Context...),
+		Output:  append(p.Output[:len(p.Output):len(p.Output)], other.Output...),
+	}
+}
+
+// Run passes text through a stage's filters, each seeing the previous one's
+// output, and stops at the first that blocks it. It returns the final text,
+// whether it was blocked and what the filters did.
+func (p Pipeline) Run(ctx context.Context, stage Stage, text string) (string, bool, []Event, error) {
+	var events []Event
+	for _, f := range p.filters(stage) {
+		result, err := f.Check(ctx, text)
+		if err != nil {
+			return "", false, events, fmt.Errorf("guardrail %s: %w", f.Name(), err)
+		}
+		if result.Blocked {
+			events = append(events, Event{Stage: stage, Filter: f.Name(), Action: ActionBlocked, Reason: result.Reason})
+			return text, true, events, nil
+		}
+		if result.Text != text {
+			events = append(events, Event{Stage: stage, Filter: f.Name(), Action: ActionModified, Reason: result.Reason})
+			text = result.Text
+		}
+	}
+	return text, false, events, nil
+}
+
+// filters returns a stage's filters
+func (p Pipeline) filters(stage Stage) []Filter {
+	switch stage {
+	case StageInput:
+		return p.Input
+	case StageContext:
+		return p.Context
+	case StageOutput:
+		return p.Output
+	}
+	return nil
+}
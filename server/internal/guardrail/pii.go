@@ -0,0 +1,169 @@
+package guardrail
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kinds of PII found by FindPII.
+const (
+	PIIEmail      = "email"
+	PIIPhone      = "phone"
+	PIISSN        = "ssn"
+	PIICreditCard = "credit_card"
+)
+
+// PIIKinds lists every kind of PII FindPII detects.
+var PIIKinds = []string{PIIEmail, PIIPhone, PIISSN, PIICreditCard}
+
+// PIIMatch is a span of text holding PII.
+type PIIMatch struct {
+	Kind       string
+	Start, End int // byte offsets
+}
+
+var (
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	creditCardPattern = regexp.MustCompile(`\d(?:[ -]?\d){12,18}`)
+	ssnPattern        = regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)
+	phonePattern      = regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{2,4}\)|\d{2,4})[ .-]?\d{3,4}[ .-]\d{3,4}`)
+)
+
+// FindPII returns the email addresses, phone numbers, US Social Security
+// numbers and payment card numbers in text, in order and without overlaps.
+// Card numbers must pass the Luhn check.
+func FindPII(text string) []PIIMatch {
+	var matches []PIIMatch
+	taken := func(start, end int) bool {
+		for _, m := range matches {
+			if start < m.End && m.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+	// Earlier kinds win overlaps, so a card number is not also a phone number
+	for _, p := range []struct {
+		kind  string
+		re    *regexp.Regexp
+		valid func(string) bool
+	}{
+		{PIIEmail, emailPattern, nil},
+		{PIICreditCard, creditCardPattern, luhnValid},
+		{PIISSN, ssnPattern, ssnValid},
+		{PIIPhone, phonePattern, phoneValid},
+	} {
+		for _, loc := range p.re.FindAllStringIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if p.kind != PIIEmail && !digitBounded(text, start, end) {
+				continue
+			}
+			if p.valid != nil && !p.valid(text[start:end]) {
+				continue
+			}
+			if !taken(start, end) {
+				matches = append(matches, PIIMatch{Kind: p.kind, Start: start, End: end})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+	return matches
+}
+
+// RedactPII replaces each PII match in text with a placeholder such as
+// [EMAIL], returning the redacted text and the number of matches by kind.
+func RedactPII(text string) (string, map[string]int) {
+	matches := FindPII(text)
+	if len(matches) == 0 {
+		return text, nil
+	}
+	counts := make(map[string]int)
+	for _, m := range matches {
+		counts[m.Kind]++
+	}
+	return RedactMatches(text, matches), counts
+}
+
+// RedactMatches replaces the given matches of FindPII in text with their
+// placeholders.
+func RedactMatches(text string, matches []PIIMatch) string {
+	out := make([]byte, 0, len(text))
+	last := 0
+	for _, m := range matches {
+		out = append(out, text[last:m.Start]...)
+		out = append(out, piiPlaceholder(m.Kind)...)
+		last = m.End
+	}
+	out = append(out, text[last:]...)
+	return string(out)
+}
+
+// piiPlaceholder is the text a PII match is redacted to
+func piiPlaceholder(kind string) string {
+	switch kind {
+	case PIIEmail:
+		return "[EMAIL]"
+	case PIIPhone:
+		return "[PHONE]"
+	case PIISSN:
+		return "[SSN]"
+	case PIICreditCard:
+		return "[CREDIT_CARD]"
+	}
+	return "[REDACTED]"
+}
+
+// digitBounded reports whether text[start:end] is not part of a longer
+// number, directly or through a single separator as in "4111 1111"
+func digitBounded(text string, start, end int) bool {
+	isDigit := func(i int) bool { return i >= 0 && i < len(text) && text[i] >= '0' && text[i] <= '9' }
+	isSep := func(i int) bool { return i >= 0 && i < len(text) && strings.IndexByte(" .-", text[i]) >= 0 }
+	before := isDigit(start-1) || (isSep(start-1) && isDigit(start-2))
+	after := isDigit(end) || (isSep(end) && isDigit(end+1))
+	return !before && !after
+}
+
+// digits returns the digits of s
+func digits(s string) []byte {
+	var d []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			d = append(d, s[i]-'0')
+		}
+	}
+	return d
+}
+
+// luhnValid reports whether s has 13-19 digits that pass the Luhn checksum
+func luhnValid(s string) bool {
+	d := digits(s)
+	if len(d) < 13 || len(d) > 19 {
+		return false
+	}
+	sum := 0
+	for i := len(d) - 1; i >= 0; i-- {
+		n := int(d[i])
+		if (len(d)-1-i)%2 == 1 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// ssnValid rejects numbers the SSA never issues: area 000, 666 or 9xx,
+// group 00 and serial 0000
+func ssnValid(s string) bool {
+	area, group, serial := s[0:3], s[4:6], s[7:11]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// phoneValid requires 10-15 digits, the length of a number with area code
+func phoneValid(s string) bool {
+	n := len(digits(s))
+	return n >= 10 && n <= 15
+}
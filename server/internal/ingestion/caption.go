@@ -0,0 +1,280 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// ImageRef is an image referenced by document content
+type ImageRef struct {
+	// URL is the image source as written in the document: absolute, relative
+	// to the page, or a data: URI
+	URL string
+
+	// Alt is the alt text, falling back to the enclosing figure's caption
+	Alt string
+}
+
+// minImageDimension skips icons and tracking pixels with declared sizes below it
+const minImageDimension = 64
+
+// contentImages collects <img> elements in document order, without duplicates
+func contentImages(nodes []*html.Node) []ImageRef {
+	var images []ImageRef
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		walk(n, func(n *html.Node) bool {
+			if n.Type != html.ElementNode || n.DataAtom != atom.Img {
+				return true
+			}
+			src, _ := attr(n, "src")
+			if lazy, ok := attr(n, "data-src"); ok && (src == "" || strings.HasPrefix(src, "data:")) {
+				src = lazy // lazy-loaded images keep a placeholder in src
+			}
+			src = strings.TrimSpace(src)
+			if src == "" || seen[src] || isTinyImage(n) {
+				return true
+			}
+			seen[src] = true
+
+			alt, _ := attr(n, "alt")
+			alt = collapseSpace(alt)
+			if alt == "" {
+				for p := n.Parent; p != nil; p = p.Parent {
+					if p.Type == html.ElementNode && p.DataAtom == atom.Figure {
+						if caption := findElement(p, atom.Figcaption); caption != nil {
+							alt = collapseSpace(textContent(caption))
+						}
+						break
+					}
+				}
+			}
+			images = append(images, ImageRef{URL: src, Alt: alt})
+			return true
+		})
+	}
+	return images
+}
+
+// isTinyImage reports whether an image declares a width or height below minImageDimension
+func isTinyImage(n *html.Node) bool {
+	for _, key := range []string{"width", "height"} {
+		if v, ok := attr(n, key); ok {
+			if size, err := strconv.Atoi(strings.TrimSuffix(v, "px")); err == nil && size < minImageDimension {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Defaults for image captioning
+const (
+	DefaultCaptionMaxImages    = 10
+	DefaultCaptionMaxImageSize = 10 << 20 // 10 MB
+	DefaultCaptionPrompt       = "Describe this image in a few sentences for a search index. " +
+		"Transcribe any visible text, and for charts or diagrams state what they show."
+)
+
+// CaptionConfig configures an ImageCaptioner
+type CaptionConfig struct {
+	// Model is the multimodal model (e.g. llava)
+	Model string
+
+	// Prompt is the captioning instruction (default: DefaultCaptionPrompt)
+	Prompt string
+
+	// MaxImages caps the images captioned per document (default: 10)
+	MaxImages int
+
+	// MaxImageSize caps the bytes downloaded per image (default: 10 MB)
+	MaxImageSize int64
+
+	// HTTPClient fetches images (default: 30s timeout)
+	HTTPClient *http.Client
+}
+
+// ImageCaptioner describes the images of HTML documents with a multimodal
+// model so their content becomes searchable
+type ImageCaptioner struct {
+	llm          llm.LLM
+	model        string
+	prompt       string
+	maxImages    int
+	maxImageSize int64
+	client       *http.Client
+}
+
+// NewImageCaptioner creates a captioner backed by a multimodal LLM
+func NewImageCaptioner(l llm.LLM, cfg CaptionConfig) *ImageCaptioner {
+	c := &ImageCaptioner{
+		llm:          l,
+		model:        cfg.Model,
+		prompt:       cfg.Prompt,
+		maxImages:    cfg.MaxImages,
+		maxImageSize: cfg.MaxImageSize,
+		client:       cfg.HTTPClient,
+	}
+	if c.prompt == "" {
+		c.prompt = DefaultCaptionPrompt
+	}
+	if c.maxImages <= 0 {
+		c.maxImages = DefaultCaptionMaxImages
+	}
+	if c.maxImageSize <= 0 {
+		c.maxImageSize = DefaultCaptionMaxImageSize
+	}
+	if c.client == nil {
+		c.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return c
+}
+
+// CaptionSections captions up to MaxImages images and returns one section per
+// caption with type=image_caption and the image_url metadata. Relative URLs
+// are resolved against baseURL. Captioning is best-effort: images that cannot
+// be fetched or described are logged and skipped.
+func (c *ImageCaptioner) CaptionSections(ctx context.Context, images []ImageRef, baseURL string) []Section {
+	var base *url.URL
+	if baseURL != "" {
+		base, _ = url.Parse(baseURL)
+	}
+
+	var sections []Section
+	for _, img := range images {
+		if len(sections) >= c.maxImages {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		src, ok := resolveImageURL(img.URL, base)
+		if !ok {
+			continue
+		}
+		caption, err := c.Caption(ctx, src)
+		if err != nil {
+			slog.Warn("failed to caption image", "url", truncateURL(src), "error", err)
+			continue
+		}
+
+		content := caption
+		if img.Alt != "" {
+			content = "Image: " + img.Alt + "\n\n" + caption
+		}
+		metadata := map[string]string{
+			"type":          "image_caption",
+			"caption_model": c.model,
+		}
+		// data: URIs are not useful as references and would bloat the payload
+		if !strings.HasPrefix(src, "data:") {
+			metadata["image_url"] = src
+		}
+		if img.Alt != "" {
+			metadata["image_alt"] = img.Alt
+		}
+		sections = append(sections, Section{Content: content, Metadata: metadata})
+	}
+	return sections
+}
+
+// Caption fetches an image (http(s) or data: URI) and describes it
+func (c *ImageCaptioner) Caption(ctx context.Context, src string) (string, error) {
+	data, err := c.loadImage(ctx, src)
+	if err != nil {
+		return "", err
+	}
+
+	caption, err := c.llm.Generate(ctx, c.prompt, llm.GenerateOptions{
+		Model:  c.model,
+		Images: [][]byte{data},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate caption: %w", err)
+	}
+	caption = strings.TrimSpace(caption)
+	if caption == "" {
+		return "", errors.New("model returned an empty caption")
+	}
+	return caption, nil
+}
+
+// loadImage reads image bytes from a data: URI or over HTTP
+func (c *ImageCaptioner) loadImage(ctx context.Context, src string) ([]byte, error) {
+	if rest, ok := strings.CutPrefix(src, "data:"); ok {
+		header, payload, found := strings.Cut(rest, ",")
+		if !found || !strings.HasSuffix(header, ";base64") || !strings.HasPrefix(header, "image/") {
+			return nil, errors.New("unsupported data URI")
+		}
+		return base64.StdEncoding.DecodeString(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "RAG-Service/1.0")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d fetching image", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "image/") {
+		return nil, fmt.Errorf("not an image: %s", ct)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > c.maxImageSize {
+		return nil, fmt.Errorf("image exceeds %d bytes", c.maxImageSize)
+	}
+	return data, nil
+}
+
+// resolveImageURL resolves src against base, accepting only http(s) and data: URIs
+func resolveImageURL(src string, base *url.URL) (string, bool) {
+	if strings.HasPrefix(src, "data:") {
+		return src, true
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+	return u.String(), true
+}
+
+// truncateURL shortens URLs (notably data: URIs) for logging
+func truncateURL(s string) string {
+	if len(s) > 120 {
+		return s[:120] + "..."
+	}
+	return s
+}
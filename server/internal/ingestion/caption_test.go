@@ -0,0 +1,95 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// captionLLM records the images it was asked to describe
+type captionLLM struct {
+	images [][]byte
+}
+
+func (l *captionLLM) Generate(_ context.Context, _ string, opts llm.GenerateOptions) (string, error) {
+	l.images = append(l.images, opts.Images...)
+	return " A bar chart of quarterly revenue. ", nil
+}
+
+func (l *captionLLM) GenerateStream(context.Context, string, llm.GenerateOptions) (<-chan llm.StreamChunk, error) {
+	return nil, nil
+}
+
+func (l *captionLLM) GenerateStructured(context.Context, string, json.RawMessage, llm.GenerateOptions) (json.RawMessage, error) {
+	return nil, nil
+}
+
+func TestExtractHTML_Images(t *testing.T) {
+	page := `<html><body><article>
+<p>Revenue grew in every region this quarter, led by strong demand for the new product line.</p>
+<figure><img src="/charts/q3.png"><figcaption>Q3 revenue</figcaption></figure>
+<img src="/pixel.gif" width="1" height="1">
+<img src="data:image/gif;base64,R0lGOD" data-src="/lazy.jpg" alt="Team photo">
+<img src="/charts/q3.png">
+</article></body></html>`
+
+	content, err := ExtractHTML(page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ImageRef{{URL: "/charts/q3.png", Alt: "Q3 revenue"}, {URL: "/lazy.jpg", Alt: "Team photo"}}
+	if len(content.Images) != len(want) {
+		t.Fatalf("expected %d images, got %+v", len(want), content.Images)
+	}
+	for i := range want {
+		if content.Images[i] != want[i] {
+			t.Errorf("image %d = %+v, want %+v", i, content.Images[i], want[i])
+		}
+	}
+}
+
+func TestImageCaptioner_CaptionSections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/charts/q3.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png-bytes"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	model := &captionLLM{}
+	captioner := NewImageCaptioner(model, CaptionConfig{Model: "llava", MaxImages: 2})
+
+	images := []ImageRef{
+		{URL: "/charts/q3.png", Alt: "Q3 revenue"},
+		{URL: "/missing.png"},
+		{URL: "data:image/png;base64,aW5saW5l"},
+		{URL: "/charts/q3.png?again"},
+	}
+	sections := captioner.CaptionSections(context.Background(), images, server.URL+"/report/")
+
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 captions (missing image skipped, capped at 2), got %d", len(sections))
+	}
+	first := sections[0]
+	if first.Content != "Image: Q3 revenue\n\nA bar chart of quarterly revenue." {
+		t.Errorf("unexpected caption content: %q", first.Content)
+	}
+	if first.Metadata["type"] != "image_caption" || first.Metadata["image_url"] != server.URL+"/charts/q3.png" {
+		t.Errorf("unexpected caption metadata: %v", first.Metadata)
+	}
+	if _, ok := sections[1].Metadata["image_url"]; ok {
+		t.Error("expected no image_url for data: URIs")
+	}
+	if len(model.images) != 2 || string(model.images[1]) != "inline" {
+		t.Errorf("expected fetched and decoded image bytes, got %q", model.images)
+	}
+}
@@ -0,0 +1,113 @@
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+	"text/template/parse"
+)
+
+// maxContextTemplateLength caps a chunker config's context template
+const maxContextTemplateLength = 1000
+
+// maxContextLength caps a chunk's rendered context; longer renderings, from
+// long metadata values, are cut short
+const maxContextLength = 2000
+
+// ContextTemplate is a parsed chunk context template
+type ContextTemplate struct {
+	parts []contextPart
+}
+
+// contextPart is literal text or, when field is set, a metadata lookup
+type contextPart struct {
+	text  string
+	field string
+}
+
+// ParseContextTemplate parses a chunk context template over a chunk's
+// metadata, such as "Title: {{.title}} | Section: {{.section}}\n". It uses
+// Go template syntax but allows only field lookups, so rendering cannot loop
+// or call functions. Metadata a chunk lacks renders empty.
+func ParseContextTemplate(text string) (*ContextTemplate, error) {
+	if len(text) > maxContextTemplateLength {
+		return nil, fmt.Errorf("context_template is over %d bytes", maxContextTemplateLength)
+	}
+	trees, err := parse.Parse("context", text, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid context_template: %w", err)
+	}
+
+	tmpl := &ContextTemplate{}
+	tree := trees["context"]
+	if tree == nil || tree.Root == nil {
+		return tmpl, nil
+	}
+	for _, node := range tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			tmpl.parts = append(tmpl.parts, contextPart{text: string(n.Text)})
+		case *parse.ActionNode:
+			field, ok := fieldLookup(n.Pipe)
+			if !ok {
+				return nil, fmt.Errorf("invalid context_template: %s: only field lookups such as {{.title}} are allowed", n)
+			}
+			tmpl.parts = append(tmpl.parts, contextPart{field: field})
+		default:
+			return nil, fmt.Errorf("invalid context_template: %s: only field lookups such as {{.title}} are allowed", n)
+		}
+	}
+	return tmpl, nil
+}
+
+// fieldLookup returns the metadata key of a pipeline that is a single
+// top-level field such as .title
+func fieldLookup(pipe *parse.PipeNode) (string, bool) {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return "", false
+	}
+	return field.Ident[0], true
+}
+
+// Render renders the template over a chunk's metadata, cut short at
+// maxContextLength bytes
+func (t *ContextTemplate) Render(metadata map[string]string) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.field != "" {
+			b.WriteString(metadata[part.field])
+		} else {
+			b.WriteString(part.text)
+		}
+		if b.Len() > maxContextLength {
+			return strings.ToValidUTF8(b.String()[:maxContextLength], "")
+		}
+	}
+	return b.String()
+}
+
+// ValidateContextTemplate checks a chunker config's context template
+func ValidateContextTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	_, err := ParseContextTemplate(text)
+	return err
+}
+
+// injectContext prepends each chunk's rendering of the context template to
+// its content, so the context is embedded with it. Chunks whose rendering
+// is blank are left as they are.
+func (c *Chunker) injectContext(chunks []Chunk) {
+	if c.context == nil {
+		return
+	}
+	for i := range chunks {
+		if rendered := c.context.Render(chunks[i].Metadata); strings.TrimSpace(rendered) != "" {
+			chunks[i].Content = rendered + chunks[i].Content
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ingestion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestPipeline_ContextTemplate(t *testing.T) {
+	pipeline := NewPipeline(PipelineConfig{
+		Chunker: repository.ChunkerConfig{
+			Method:          "semantic",
+			TargetSize:      50,
+			MaxSize:         100,
+			ContextTemplate: "Title: {{.title}} | Section: {{.section_path}} {{.missing}}\n",
+		},
+	})
+
+	result, err := pipeline.ProcessWithMetadata(context.Background(),
+		"# Guide\n\n## Setup\n\nRun the installer.", map[string]string{"title": "Manual"})
+	if err != nil {
+		t.Fatalf("ProcessWithMetadata: %v", err)
+	}
+	if len(result.Chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(result.Chunks))
+	}
+	content := result.Chunks[0].Content
+	if want := "Title: Manual | Section: Guide > Setup \n# Guide"; !strings.HasPrefix(content, want) {
+		t.Errorf("content = %q, want prefix %q", content, want)
+	}
+	if strings.Contains(content, "[Section:") {
+		t.Errorf("content %q still has the section prefix the template replaces", content)
+	}
+}
+
+func TestValidateContextTemplate(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{"", false},
+		{"{{.title}}: ", false},
+		{"{{.title", true},
+		{"{{.title.name}}", true},
+		{"{{range 1000000000000}}x{{end}}", true},
+		{"{{if .title}}{{.title}}{{end}}", true},
+		{"{{printf \"%s\" .title}}", true},
+		{"{{$x := .title}}", true},
+		{"{{range 1000000000000}}x{{end}}", true},
+		{"{{if .title}}{{.title}}{{end}}", true},
+		{"{{printf \"%s\" .title}}", true},
+		{"{{$x := .title}}", true},
+		{strings.Repeat("x", maxContextTemplateLength+1), true},
+	}
+	for _, tt := range tests {
+		if err := ValidateContextTemplate(tt.text); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateContextTemplate(%.20q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+		}
+	}
+}
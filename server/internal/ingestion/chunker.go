@@ -5,7 +5,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/knoguchi/rag/internal/repository"
 )
@@ -15,11 +14,22 @@ type Chunk struct {
 	Content  string
 	Index    int
 	Metadata map[string]string
+
+	// ParentStart and ParentEnd are the inclusive chunk index range of the
+	// section this chunk belongs to, used for parent-document retrieval.
+	ParentStart int
+	ParentEnd   int
 }
 
+// MaxOverlapPercent caps ChunkerConfig.OverlapPercent, so consecutive chunks
+// share at most half their content.
+const MaxOverlapPercent = 50
+
 // Chunker handles text chunking with different strategies
 type Chunker struct {
-	config repository.ChunkerConfig
+	config    repository.ChunkerConfig
+	sentences *SentenceSplitter
+	context   *ContextTemplate // ContextTemplate; nil when unset
 }
 
 // NewChunker creates a new Chunker with the given configuration
@@ -34,11 +44,23 @@ func NewChunker(config repository.ChunkerConfig) *Chunker {
 	if config.Overlap < 0 {
 		config.Overlap = 50
 	}
+	if config.OverlapPercent > 0 {
+		config.Overlap = config.TargetSize * min(config.OverlapPercent, MaxOverlapPercent) / 100
+	}
 	if config.Method == "" {
 		config.Method = "semantic"
 	}
 
-	return &Chunker{config: config}
+	c := &Chunker{
+		config:    config,
+		sentences: NewSentenceSplitter(config.Language, config.Abbreviations),
+	}
+	if config.ContextTemplate != "" {
+		// Configs are validated before they are saved; an invalid template
+		// injects no context
+		c.context, _ = ParseContextTemplate(config.ContextTemplate)
+	}
+	return c
 }
 
 // Chunk splits content into chunks based on the configured method
@@ -48,23 +70,49 @@ func (c *Chunker) Chunk(content string) []Chunk {
 		return nil
 	}
 
+	var chunks []Chunk
 	switch c.config.Method {
 	case "fixed":
-		return c.chunkFixed(content)
+		chunks = c.chunkFixed(content)
 	case "sentence":
-		return c.chunkSentence(content)
+		chunks = c.chunkSentence(content)
 	case "semantic":
-		return c.chunkSemantic(content)
+		chunks = c.chunkSemantic(content)
+	case "tabular":
+		chunks = c.chunkTabular(content)
 	default:
 		// Default to semantic if unknown method
-		return c.chunkSemantic(content)
+		chunks = c.chunkSemantic(content)
 	}
+
+	assignParentSpans(chunks)
+	return chunks
 }
 
-// estimateTokens approximates token count from text
-// Uses the heuristic: tokens ≈ words / 0.75
-func estimateTokens(text string) int {
-	words := len(strings.Fields(text))
+// assignParentSpans groups consecutive chunks that share a section header into
+// a parent span. Chunks without section context form a span of their own.
+func assignParentSpans(chunks []Chunk) {
+	for start := 0; start < len(chunks); {
+		section := chunks[start].Metadata["section"]
+		end := start
+		if section != "" {
+			for end+1 < len(chunks) && chunks[end+1].Metadata["section"] == section {
+				end++
+			}
+		}
+		for i := start; i <= end; i++ {
+			chunks[i].ParentStart = chunks[start].Index
+			chunks[i].ParentEnd = chunks[end].Index
+		}
+		start = end + 1
+	}
+}
+
+// EstimateTokens approximates token count from text
+// Uses the heuristic: tokens ≈ words / 0.75, with words counted by
+// CountWords so each CJK character is about one token
+func EstimateTokens(text string) int {
+	words := CountWords(text)
 	// tokens ≈ words / 0.75, which is words * 1.33
 	// For simplicity, we use word count as a reasonable proxy
 	return words
@@ -81,7 +129,7 @@ func estimateTokensFromWords(wordCount int) int {
 
 // chunkFixed splits content into fixed-size chunks with overlap
 func (c *Chunker) chunkFixed(content string) []Chunk {
-	words := strings.Fields(content)
+	words := segmentWords(content)
 	if len(words) == 0 {
 		return nil
 	}
@@ -90,6 +138,7 @@ func (c *Chunker) chunkFixed(content string) []Chunk {
 	targetWords := c.config.TargetSize // Using word count as token proxy
 	overlapWords := c.config.Overlap
 
+	prevEnd := 0
 	for i := 0; i < len(words); {
 		end := i + targetWords
 		if end > len(words) {
@@ -97,16 +146,21 @@ func (c *Chunker) chunkFixed(content string) []Chunk {
 		}
 
 		chunkWords := words[i:end]
-		chunkContent := strings.Join(chunkWords, " ")
+		chunkContent := joinWords(chunkWords)
 
+		metadata := map[string]string{
+			"method":     "fixed",
+			"word_count": intToString(len(chunkWords)),
+		}
+		if prevEnd > i {
+			metadata["overlap_words"] = intToString(prevEnd - i)
+		}
 		chunks = append(chunks, Chunk{
-			Content: chunkContent,
-			Index:   len(chunks),
-			Metadata: map[string]string{
-				"method":     "fixed",
-				"word_count": intToString(len(chunkWords)),
-			},
+			Content:  chunkContent,
+			Index:    len(chunks),
+			Metadata: metadata,
 		})
+		prevEnd = end
 
 		// Move forward by target minus overlap
 		step := targetWords - overlapWords
@@ -133,7 +187,7 @@ func (c *Chunker) chunkFixed(content string) []Chunk {
 
 // chunkSentence groups sentences until target size is reached
 func (c *Chunker) chunkSentence(content string) []Chunk {
-	sentences := splitSentences(content)
+	sentences := c.sentences.Split(content)
 	if len(sentences) == 0 {
 		return nil
 	}
@@ -141,26 +195,38 @@ func (c *Chunker) chunkSentence(content string) []Chunk {
 	var chunks []Chunk
 	var currentSentences []string
 	currentWordCount := 0
+	// overlapWordCount is how many of currentWordCount repeat the last chunk
+	overlapWordCount := 0
+
+	flush := func() {
+		chunk := c.createSentenceChunk(currentSentences, len(chunks))
+		if overlapWordCount > 0 {
+			chunk.Metadata["overlap_words"] = intToString(overlapWordCount)
+		}
+		chunks = append(chunks, chunk)
+	}
 
 	for _, sentence := range sentences {
-		sentenceWords := len(strings.Fields(sentence))
+		sentenceWords := CountWords(sentence)
 
 		// If adding this sentence would exceed max size and we have content, flush
-		if currentWordCount+sentenceWords > c.config.MaxSize && currentWordCount > 0 {
-			chunks = append(chunks, c.createSentenceChunk(currentSentences, len(chunks)))
+		if currentWordCount+sentenceWords > c.config.MaxSize && currentWordCount > overlapWordCount {
+			flush()
 
 			// Calculate overlap - keep last few sentences if possible
 			currentSentences, currentWordCount = c.calculateSentenceOverlap(currentSentences)
+			overlapWordCount = currentWordCount
 		}
 
 		// If single sentence exceeds max, split it by words
 		if sentenceWords > c.config.MaxSize {
 			// Flush current content first
-			if currentWordCount > 0 {
-				chunks = append(chunks, c.createSentenceChunk(currentSentences, len(chunks)))
-				currentSentences = nil
-				currentWordCount = 0
+			if currentWordCount > overlapWordCount {
+				flush()
 			}
+			currentSentences = nil
+			currentWordCount = 0
+			overlapWordCount = 0
 			// Split the long sentence
 			splitChunks := c.splitLongSentence(sentence, len(chunks))
 			chunks = append(chunks, splitChunks...)
@@ -172,14 +238,15 @@ func (c *Chunker) chunkSentence(content string) []Chunk {
 
 		// If we've reached target size, flush
 		if currentWordCount >= c.config.TargetSize {
-			chunks = append(chunks, c.createSentenceChunk(currentSentences, len(chunks)))
+			flush()
 			currentSentences, currentWordCount = c.calculateSentenceOverlap(currentSentences)
+			overlapWordCount = currentWordCount
 		}
 	}
 
-	// Flush remaining content
-	if len(currentSentences) > 0 {
-		chunks = append(chunks, c.createSentenceChunk(currentSentences, len(chunks)))
+	// Flush remaining content, unless it only repeats the last chunk
+	if currentWordCount > overlapWordCount {
+		flush()
 	}
 
 	return chunks
@@ -194,33 +261,42 @@ func (c *Chunker) createSentenceChunk(sentences []string, index int) Chunk {
 		Metadata: map[string]string{
 			"method":         "sentence",
 			"sentence_count": intToString(len(sentences)),
-			"word_count":     intToString(len(strings.Fields(content))),
+			"word_count":     intToString(CountWords(content)),
 		},
 	}
 }
 
-// calculateSentenceOverlap calculates which sentences to keep for overlap
+// calculateSentenceOverlap calculates which sentences to keep for overlap:
+// as many whole trailing sentences as fit in the overlap, or the overlap's
+// worth of trailing words when the last sentence alone is longer. It
+// returns the sentences and their word count.
 func (c *Chunker) calculateSentenceOverlap(sentences []string) ([]string, int) {
 	if c.config.Overlap <= 0 || len(sentences) == 0 {
 		return nil, 0
 	}
 
-	var overlapSentences []string
-	overlapWords := 0
-
 	// Work backwards from the end to collect overlap
-	for i := len(sentences) - 1; i >= 0 && overlapWords < c.config.Overlap; i-- {
-		sentenceWords := len(strings.Fields(sentences[i]))
-		overlapSentences = append([]string{sentences[i]}, overlapSentences...)
+	start, overlapWords := len(sentences), 0
+	for start > 0 {
+		sentenceWords := CountWords(sentences[start-1])
+		if overlapWords+sentenceWords > c.config.Overlap {
+			break
+		}
+		start--
 		overlapWords += sentenceWords
 	}
+	if start < len(sentences) {
+		return append([]string(nil), sentences[start:]...), overlapWords
+	}
 
-	return overlapSentences, overlapWords
+	words := segmentWords(sentences[len(sentences)-1])
+	tail := words[len(words)-c.config.Overlap:]
+	return []string{joinWords(tail)}, len(tail)
 }
 
 // splitLongSentence splits a sentence that exceeds max size
 func (c *Chunker) splitLongSentence(sentence string, startIndex int) []Chunk {
-	words := strings.Fields(sentence)
+	words := segmentWords(sentence)
 	var chunks []Chunk
 
 	for i := 0; i < len(words); {
@@ -230,7 +306,7 @@ func (c *Chunker) splitLongSentence(sentence string, startIndex int) []Chunk {
 		}
 
 		chunkWords := words[i:end]
-		content := strings.Join(chunkWords, " ")
+		content := joinWords(chunkWords)
 
 		chunks = append(chunks, Chunk{
 			Content: content,
@@ -269,15 +345,20 @@ type contentBlock struct {
 	content   string
 	header    string // Current section header context
 	level     int    // Header level (1-6)
+	path      string // Headings down to header, e.g. "Guide > Setup > Install"
+	part      int    // Fragment number of a table split by splitTables; 0 if whole
 }
 
+// sectionPathSeparator separates the headings of a section path
+const sectionPathSeparator = " > "
+
 // chunkSemantic performs smart semantic chunking that:
 // 1. Preserves code blocks and tables as atomic units
 // 2. Keeps header context for each chunk
 // 3. Groups related paragraphs together
 func (c *Chunker) chunkSemantic(content string) []Chunk {
 	// Step 1: Parse content into semantic blocks
-	blocks := c.parseIntoBlocks(content)
+	blocks := c.splitTables(c.parseIntoBlocks(content))
 
 	// Step 2: Group blocks into chunks respecting size limits
 	chunks := c.groupBlocksIntoChunks(blocks)
@@ -300,6 +381,9 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 	var blocks []contentBlock
 	currentHeader := ""
 	currentLevel := 0
+	currentPath := ""
+	// headings are the enclosing headings of the current one, outermost first
+	var headings []contentBlock
 
 	// Patterns for detecting different block types
 	headerPattern := regexp.MustCompile(`(?m)^(#{1,6})\s+(.+)$`)
@@ -337,6 +421,7 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 					content:   codeContent,
 					header:    currentHeader,
 					level:     currentLevel,
+					path:      currentPath,
 				})
 				continue
 			}
@@ -346,11 +431,24 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 		if headerMatch := headerPattern.FindStringSubmatch(para); headerMatch != nil {
 			currentLevel = len(headerMatch[1])
 			currentHeader = headerMatch[2]
+
+			// A heading closes the sections at its level and below
+			for len(headings) > 0 && headings[len(headings)-1].level >= currentLevel {
+				headings = headings[:len(headings)-1]
+			}
+			headings = append(headings, contentBlock{header: currentHeader, level: currentLevel})
+			names := make([]string, len(headings))
+			for i, h := range headings {
+				names[i] = h.header
+			}
+			currentPath = strings.Join(names, sectionPathSeparator)
+
 			blocks = append(blocks, contentBlock{
 				blockType: "header",
 				content:   para,
 				header:    currentHeader,
 				level:     currentLevel,
+				path:      currentPath,
 			})
 			continue
 		}
@@ -362,6 +460,7 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 				content:   para,
 				header:    currentHeader,
 				level:     currentLevel,
+				path:      currentPath,
 			})
 			continue
 		}
@@ -373,6 +472,7 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 				content:   para,
 				header:    currentHeader,
 				level:     currentLevel,
+				path:      currentPath,
 			})
 			continue
 		}
@@ -383,6 +483,7 @@ func (c *Chunker) parseIntoBlocks(content string) []contentBlock {
 			content:   para,
 			header:    currentHeader,
 			level:     currentLevel,
+			path:      currentPath,
 		})
 	}
 
@@ -407,7 +508,6 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 	var chunks []Chunk
 	var currentBlocks []contentBlock
 	currentWords := 0
-	currentHeader := ""
 
 	flushChunk := func() {
 		if len(currentBlocks) == 0 {
@@ -417,22 +517,22 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 		// Build chunk content with context
 		var contentParts []string
 
-		// Add header context if available
-		headerAdded := false
-		for _, block := range currentBlocks {
-			if block.header != "" && !headerAdded {
-				// Add contextual header as a prefix for better retrieval
-				prefix := strings.Repeat("#", block.level) + " " + block.header
-				if currentBlocks[0].blockType != "header" || currentBlocks[0].content != prefix {
-					contentParts = append(contentParts, "[Section: "+block.header+"]")
-					headerAdded = true
-				}
+		// The chunk belongs to the section its last block is in. Its header
+		// path is a prefix for better retrieval, unless the chunk starts with
+		// the section's own top-level header or a context template replaces it.
+		section := currentBlocks[len(currentBlocks)-1]
+		if section.header != "" && c.context == nil {
+			prefix := strings.Repeat("#", section.level) + " " + section.header
+			if currentBlocks[0].content != prefix || section.path != section.header {
+				contentParts = append(contentParts, "[Section: "+section.path+"]")
 			}
+		}
+		for _, block := range currentBlocks {
 			contentParts = append(contentParts, block.content)
 		}
 
 		content := strings.Join(contentParts, "\n\n")
-		wordCount := len(strings.Fields(content))
+		wordCount := CountWords(content)
 
 		metadata := map[string]string{
 			"method":     "semantic",
@@ -450,8 +550,14 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 		if blockTypes["table"] > 0 {
 			metadata["contains_table"] = "true"
 		}
-		if currentHeader != "" {
-			metadata["section"] = currentHeader
+		for _, block := range currentBlocks {
+			if block.part > 0 {
+				metadata["table_part"] = intToString(block.part)
+			}
+		}
+		if section.header != "" {
+			metadata["section"] = section.header
+			metadata["section_path"] = section.path
 		}
 
 		chunks = append(chunks, Chunk{
@@ -465,12 +571,7 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 	}
 
 	for _, block := range blocks {
-		blockWords := len(strings.Fields(block.content))
-
-		// Update current header context
-		if block.blockType == "header" {
-			currentHeader = block.header
-		}
+		blockWords := CountWords(block.content)
 
 		// Code blocks and tables are kept as atomic units if possible
 		isAtomic := block.blockType == "code" || block.blockType == "table"
@@ -503,8 +604,21 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 				continue
 			}
 
-			// Flush current chunk
-			flushChunk()
+			// Flush current chunk. Headers at its end introduce this block,
+			// so they move on with it into the next chunk.
+			keep := len(currentBlocks)
+			for keep > 0 && currentBlocks[keep-1].blockType == "header" {
+				keep--
+			}
+			if keep > 0 {
+				headers := append([]contentBlock(nil), currentBlocks[keep:]...)
+				currentBlocks = currentBlocks[:keep]
+				flushChunk()
+				currentBlocks = headers
+				for _, header := range headers {
+					currentWords += CountWords(header.content)
+				}
+			}
 		}
 
 		currentBlocks = append(currentBlocks, block)
@@ -520,30 +634,31 @@ func (c *Chunker) groupBlocksIntoChunks(blocks []contentBlock) []Chunk {
 // splitLargeBlock splits a large block that exceeds max size
 func (c *Chunker) splitLargeBlock(block contentBlock) []Chunk {
 	var chunks []Chunk
-	sentences := splitSentences(block.content)
+	sentences := c.sentences.Split(block.content)
 
 	var currentSentences []string
 	currentWords := 0
 
 	for _, sentence := range sentences {
-		sentenceWords := len(strings.Fields(sentence))
+		sentenceWords := CountWords(sentence)
 
 		if currentWords+sentenceWords > c.config.TargetSize && currentWords > 0 {
 			content := strings.Join(currentSentences, " ")
 
-			// Add section context
-			if block.header != "" {
-				content = "[Section: " + block.header + "]\n\n" + content
+			// Add section context, unless a context template replaces it
+			if block.header != "" && c.context == nil {
+				content = "[Section: " + block.path + "]\n\n" + content
 			}
 
 			chunks = append(chunks, Chunk{
 				Content: strings.TrimSpace(content),
 				Index:   len(chunks),
 				Metadata: map[string]string{
-					"method":     "semantic",
-					"word_count": intToString(currentWords),
-					"section":    block.header,
-					"split":      "true",
+					"method":       "semantic",
+					"word_count":   intToString(currentWords),
+					"section":      block.header,
+					"section_path": block.path,
+					"split":        "true",
 				},
 			})
 
@@ -558,18 +673,19 @@ func (c *Chunker) splitLargeBlock(block contentBlock) []Chunk {
 	// Flush remaining
 	if len(currentSentences) > 0 {
 		content := strings.Join(currentSentences, " ")
-		if block.header != "" {
-			content = "[Section: " + block.header + "]\n\n" + content
+		if block.header != "" && c.context == nil {
+			content = "[Section: " + block.path + "]\n\n" + content
 		}
 
 		chunks = append(chunks, Chunk{
 			Content: strings.TrimSpace(content),
 			Index:   len(chunks),
 			Metadata: map[string]string{
-				"method":     "semantic",
-				"word_count": intToString(currentWords),
-				"section":    block.header,
-				"split":      "true",
+				"method":       "semantic",
+				"word_count":   intToString(currentWords),
+				"section":      block.header,
+				"section_path": block.path,
+				"split":        "true",
 			},
 		})
 	}
@@ -577,7 +693,8 @@ func (c *Chunker) splitLargeBlock(block contentBlock) []Chunk {
 	return chunks
 }
 
-// addSemanticOverlap adds contextual overlap between chunks
+// addSemanticOverlap prefixes each chunk with the end of the one before it,
+// measured out as calculateSentenceOverlap does for sentence chunks
 func (c *Chunker) addSemanticOverlap(chunks []Chunk) []Chunk {
 	if len(chunks) <= 1 {
 		return chunks
@@ -593,26 +710,18 @@ func (c *Chunker) addSemanticOverlap(chunks []Chunk) []Chunk {
 		}
 
 		// Add context from previous chunk
-		if i > 0 && c.config.Overlap > 0 {
-			prevContent := chunks[i-1].Content
-			prevWords := strings.Fields(prevContent)
-
-			if len(prevWords) > 0 {
-				overlapCount := c.config.Overlap
-				if overlapCount > len(prevWords) {
-					overlapCount = len(prevWords)
-				}
-
-				// Get the last N words from previous chunk
-				overlapWords := prevWords[len(prevWords)-overlapCount:]
-				overlapText := strings.Join(overlapWords, " ")
-
-				// Only add overlap if it's meaningful (not just a header)
-				if !strings.HasPrefix(overlapText, "[Section:") {
-					result[i].Content = "[...] " + overlapText + "\n\n" + result[i].Content
-					result[i].Metadata["has_overlap"] = "true"
-					result[i].Metadata["overlap_words"] = intToString(overlapCount)
-				}
+		// Table fragments repeat their header row instead
+		continued := i > 0 && chunk.Metadata["table_part"] != "" && chunks[i-1].Metadata["table_part"] != ""
+		if i > 0 && c.config.Overlap > 0 && !continued {
+			// The section context line is not part of the overlap
+			prevContent := sectionContextPattern.ReplaceAllString(chunks[i-1].Content, "")
+			overlapSentences, overlapCount := c.calculateSentenceOverlap(c.sentences.Split(prevContent))
+
+			if overlapCount > 0 {
+				overlapText := strings.Join(overlapSentences, " ")
+				result[i].Content = "[...] " + overlapText + "\n\n" + result[i].Content
+				result[i].Metadata["has_overlap"] = "true"
+				result[i].Metadata["overlap_words"] = intToString(overlapCount)
 			}
 		}
 	}
@@ -620,70 +729,22 @@ func (c *Chunker) addSemanticOverlap(chunks []Chunk) []Chunk {
 	return result
 }
 
+// sectionContextPattern matches the "[Section: ...]" lines semantic chunks
+// start with
+var sectionContextPattern = regexp.MustCompile(`(?m)^\[Section: .*\]\n*`)
+
 // ============================================================================
 // Utility Functions
 // ============================================================================
 
-// splitSentences splits text into sentences
+// splitSentences splits English text into sentences
 func splitSentences(text string) []string {
-	// Normalize whitespace
-	text = strings.TrimSpace(text)
-	if text == "" {
-		return nil
-	}
-
-	// Simple sentence splitting on . ! ? followed by space or end
-	// This is a simplified approach; production would need more sophisticated NLP
-	var sentences []string
-	var current strings.Builder
-
-	runes := []rune(text)
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
-		current.WriteRune(r)
-
-		// Check for sentence boundary
-		if r == '.' || r == '!' || r == '?' {
-			// Look ahead for space or end
-			if i+1 >= len(runes) || unicode.IsSpace(runes[i+1]) {
-				// Check for common abbreviations (simple heuristic)
-				sentence := strings.TrimSpace(current.String())
-				if sentence != "" && !isAbbreviation(sentence) {
-					sentences = append(sentences, sentence)
-					current.Reset()
-				}
-			}
-		}
-	}
-
-	// Add remaining text as final sentence
-	remaining := strings.TrimSpace(current.String())
-	if remaining != "" {
-		sentences = append(sentences, remaining)
-	}
-
-	return sentences
+	return defaultSentenceSplitter.Split(text)
 }
 
-// isAbbreviation checks if a sentence ends with a common abbreviation
+// isAbbreviation checks if a sentence ends with a common English abbreviation
 func isAbbreviation(text string) bool {
-	// Common abbreviations that shouldn't end sentences
-	abbreviations := []string{
-		"mr.", "mrs.", "ms.", "dr.", "prof.",
-		"inc.", "ltd.", "corp.",
-		"etc.", "e.g.", "i.e.",
-		"vs.", "v.",
-		"st.", "ave.", "blvd.",
-		"no.", "vol.", "pg.",
-	}
-
-	lower := strings.ToLower(text)
-	for _, abbr := range abbreviations {
-		if strings.HasSuffix(lower, abbr) {
-			return true
-		}
-	}
-	return false
+	return defaultSentenceSplitter.abbreviations[strings.ToLower(lastWord([]rune(text)))]
 }
 
 // intToString converts int to string
@@ -71,6 +71,27 @@ func TestChunker_FixedMethod(t *testing.T) {
 	}
 }
 
+func TestChunker_FixedMethodCJK(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:     "fixed",
+		TargetSize: 10,
+		MaxSize:    20,
+	})
+
+	// 25 characters without spaces are 25 words, not one
+	content := strings.Repeat("東京都庁舎", 5)
+	chunks := chunker.Chunk(content)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+	if chunks[0].Content != "東京都庁舎東京都庁舎" {
+		t.Errorf("first chunk = %q, want 10 characters without spaces", chunks[0].Content)
+	}
+	if chunks[0].Metadata["word_count"] != "10" {
+		t.Errorf("first chunk word_count = %s, want 10", chunks[0].Metadata["word_count"])
+	}
+}
+
 func TestChunker_SentenceMethod(t *testing.T) {
 	chunker := NewChunker(repository.ChunkerConfig{
 		Method:     "sentence",
@@ -163,6 +184,129 @@ And some more text after the code.
 	}
 }
 
+func TestChunker_SemanticSectionPath(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:     "semantic",
+		TargetSize: 8,
+		MaxSize:    100,
+	})
+
+	content := `# Guide
+
+Welcome to the guide.
+
+## Setup
+
+### Install
+
+Run the installer and wait for it to finish completely.
+
+## Usage
+
+Start the server.`
+
+	paths := make(map[string]string)
+	for _, chunk := range chunker.Chunk(content) {
+		paths[chunk.Metadata["section"]] = chunk.Metadata["section_path"]
+		if chunk.Metadata["section"] == "Install" && !strings.HasPrefix(chunk.Content, "[Section: Guide > Setup > Install]") {
+			t.Errorf("Install chunk = %q, want it prefixed with its section path", chunk.Content)
+		}
+	}
+	want := map[string]string{
+		"Guide":   "Guide",
+		"Install": "Guide > Setup > Install",
+		"Usage":   "Guide > Usage",
+	}
+	for section, path := range want {
+		if paths[section] != path {
+			t.Errorf("section %q path = %q, want %q", section, paths[section], path)
+		}
+	}
+}
+
+func TestChunker_OverlapPercent(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:         "fixed",
+		TargetSize:     10,
+		MaxSize:        20,
+		OverlapPercent: 20,
+	})
+
+	chunks := chunker.Chunk(strings.Repeat("word ", 25))
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+	if _, ok := chunks[0].Metadata["overlap_words"]; ok {
+		t.Error("first chunk should not overlap")
+	}
+	for _, chunk := range chunks[1:] {
+		if chunk.Metadata["overlap_words"] != "2" {
+			t.Errorf("chunk %d overlap_words = %q, want 2", chunk.Index, chunk.Metadata["overlap_words"])
+		}
+	}
+}
+
+func TestChunker_SentenceOverlap(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:     "sentence",
+		TargetSize: 8,
+		MaxSize:    50,
+		Overlap:    4,
+	})
+
+	chunks := chunker.Chunk("One two three four five. Six seven eight. Nine ten eleven twelve thirteen.")
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	// Only whole sentences within the overlap are repeated
+	if want := "Six seven eight. Nine ten eleven twelve thirteen."; chunks[1].Content != want {
+		t.Errorf("second chunk = %q, want %q", chunks[1].Content, want)
+	}
+	if chunks[1].Metadata["overlap_words"] != "3" {
+		t.Errorf("overlap_words = %q, want 3", chunks[1].Metadata["overlap_words"])
+	}
+}
+
+func TestChunker_SemanticOverlap(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:     "semantic",
+		TargetSize: 8,
+		MaxSize:    100,
+		Overlap:    6,
+	})
+
+	chunks := chunker.Chunk("Alpha beta gamma delta. Short one here.\n\nNext paragraph starts here now.")
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if want := "[...] Short one here.\n\nNext paragraph starts here now."; chunks[1].Content != want {
+		t.Errorf("second chunk = %q, want %q", chunks[1].Content, want)
+	}
+	if chunks[1].Metadata["overlap_words"] != "3" {
+		t.Errorf("overlap_words = %q, want 3", chunks[1].Metadata["overlap_words"])
+	}
+}
+
+func TestAssignParentSpans(t *testing.T) {
+	chunks := []Chunk{
+		{Index: 0, Metadata: map[string]string{"section": "Intro"}},
+		{Index: 1, Metadata: map[string]string{"section": "Intro"}},
+		{Index: 2, Metadata: map[string]string{}},
+		{Index: 3, Metadata: map[string]string{"section": "Usage"}},
+		{Index: 4, Metadata: map[string]string{"section": "Usage"}},
+		{Index: 5, Metadata: map[string]string{"section": "Usage"}},
+	}
+
+	assignParentSpans(chunks)
+
+	expected := [][2]int{{0, 1}, {0, 1}, {2, 2}, {3, 5}, {3, 5}, {3, 5}}
+	for i, chunk := range chunks {
+		if chunk.ParentStart != expected[i][0] || chunk.ParentEnd != expected[i][1] {
+			t.Errorf("chunk %d: expected span %v, got [%d %d]", i, expected[i], chunk.ParentStart, chunk.ParentEnd)
+		}
+	}
+}
+
 func TestSplitSentences(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -238,13 +382,14 @@ func TestEstimateTokens(t *testing.T) {
 		{"hello", 1},
 		{"hello world", 2},
 		{"one two three four five", 5},
+		{"東京の天気", 5},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			result := estimateTokens(tt.input)
+			result := EstimateTokens(tt.input)
 			if result != tt.expected {
-				t.Errorf("estimateTokens(%q) = %d, expected %d", tt.input, result, tt.expected)
+				t.Errorf("EstimateTokens(%q) = %d, expected %d", tt.input, result, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,323 @@
+package ingestion
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Metadata keys set by enrichment
+const (
+	MetadataLanguage    = "language"     // ISO 639-1 code
+	MetadataPublishedAt = "published_at" // YYYY-MM-DD
+	MetadataAuthor      = "author"
+	MetadataEntities    = "entities" // comma-separated, most frequent first
+)
+
+// Enricher detects document metadata such as language, dates and entities.
+// Keys it cannot detect are left out.
+type Enricher interface {
+	Enrich(ctx context.Context, text string) map[string]string
+}
+
+// HeuristicEnricher detects metadata with scripts, word lists and patterns,
+// without calling a model
+type HeuristicEnricher struct {
+	// MaxEntities caps the entities reported; 0 uses 10
+	MaxEntities int
+}
+
+// enrichScanLength bounds how much of a document is scanned for a date or
+// author line; both appear near the top when they appear at all
+const enrichScanLength = 2000
+
+// Enrich implements Enricher
+func (e HeuristicEnricher) Enrich(_ context.Context, text string) map[string]string {
+	metadata := make(map[string]string)
+	if lang := DetectLanguage(text); lang != "" {
+		metadata[MetadataLanguage] = lang
+	}
+
+	head := text
+	if len(head) > enrichScanLength {
+		head = head[:enrichScanLength]
+	}
+	if date := DetectDate(head); !date.IsZero() {
+		metadata[MetadataPublishedAt] = date.Format(time.DateOnly)
+	}
+	if author := DetectAuthor(head); author != "" {
+		metadata[MetadataAuthor] = author
+	}
+
+	maxEntities := e.MaxEntities
+	if maxEntities <= 0 {
+		maxEntities = 10
+	}
+	if entities := ExtractEntities(text, maxEntities); len(entities) > 0 {
+		metadata[MetadataEntities] = strings.Join(entities, ", ")
+	}
+	return metadata
+}
+
+// languageScripts maps scripts to the language they most likely indicate
+var languageScripts = []struct {
+	table *unicode.RangeTable
+	lang  string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// languageStopwords are frequent function words of Latin-script languages
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "with", "are", "this", "on", "be", "was"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "den", "ein", "eine", "zu", "von", "auf", "sich", "auch"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "dans", "pour", "pas", "que", "qui", "sur", "du", "avec"},
+	"es": {"el", "los", "las", "y", "del", "es", "una", "por", "con", "para", "que", "como", "pero", "más", "está"},
+	"it": {"il", "di", "che", "e", "gli", "una", "per", "non", "sono", "della", "del", "con", "questo", "anche", "più"},
+	"pt": {"o", "os", "e", "do", "da", "uma", "para", "com", "não", "que", "em", "dos", "das", "mais", "foi"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "voor", "met", "zijn", "ook", "maar", "wordt"},
+}
+
+// stopwordLanguages indexes languageStopwords by word
+var stopwordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range languageStopwords {
+		for _, w := range words {
+			w = strings.ToLower(w)
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the ISO 639-1 language of text from its script and,
+// for Latin script, its stopwords. It returns "" when unsure.
+func DetectLanguage(text string) string {
+	var letters, han, kana, latin int
+	scripts := make([]int, len(languageScripts))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			for i, s := range languageScripts {
+				if unicode.Is(s.table, r) {
+					scripts[i]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese mixes kana into Han text; Chinese has none
+	if (han+kana)*2 > letters {
+		if kana*10 > han+kana {
+			return "ja"
+		}
+		return "zh"
+	}
+	for i, s := range languageScripts {
+		if scripts[i]*2 > letters {
+			return s.lang
+		}
+	}
+	if latin*2 <= letters {
+		return ""
+	}
+
+	scores := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for _, lang := range stopwordLanguages[word] {
+			scores[lang]++
+		}
+	}
+
+	ranked := make([]string, 0, len(scores))
+	for lang := range scores {
+		ranked = append(ranked, lang)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if scores[ranked[i]] != scores[ranked[j]] {
+			return scores[ranked[i]] > scores[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	if len(ranked) == 0 || scores[ranked[0]] < 3 {
+		return ""
+	}
+	// Require a clear margin over the runner-up
+	if len(ranked) > 1 && scores[ranked[0]]*2 < scores[ranked[1]]*3 {
+		return ""
+	}
+	return ranked[0]
+}
+
+var (
+	isoDatePattern  = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	monthDayPattern = regexp.MustCompile(`(?i)\b(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sep|sept|oct|nov|dec)\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`)
+	dayMonthPattern = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sep|sept|oct|nov|dec)\.?,?\s+(\d{4})\b`)
+)
+
+// DetectDate finds the first plausible calendar date in text, written as
+// 2006-01-02, "January 2, 2006" or "2 January 2006". It returns the zero
+// time when there is none.
+func DetectDate(text string) time.Time {
+	type match struct {
+		at   int
+		date time.Time
+	}
+	var found []match
+
+	for _, m := range isoDatePattern.FindAllStringSubmatchIndex(text, -1) {
+		if date, err := time.Parse(time.DateOnly, text[m[0]:m[1]]); err == nil {
+			found = append(found, match{m[0], date})
+		}
+	}
+	for _, p := range []struct {
+		re                  *regexp.Regexp
+		month, day, yearIdx int
+	}{
+		{monthDayPattern, 1, 2, 3},
+		{dayMonthPattern, 2, 1, 3},
+	} {
+		for _, m := range p.re.FindAllStringSubmatch(text, -1) {
+			// Month names parse case-insensitively
+			date, err := time.Parse("Jan 2 2006", m[p.month][:3]+" "+m[p.day]+" "+m[p.yearIdx])
+			if err == nil {
+				found = append(found, match{strings.Index(text, m[0]), date})
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].at < found[j].at })
+	latest := time.Now().AddDate(1, 0, 0)
+	for _, m := range found {
+		if m.date.Year() >= 1990 && m.date.Before(latest) {
+			return m.date
+		}
+	}
+	return time.Time{}
+}
+
+// maxTitleLength is the longest first line DetectTitle accepts as a title
+const maxTitleLength = 120
+
+// DetectTitle returns the first Markdown heading in text, or failing that its
+// first line when short enough to be a title
+func DetectTitle(text string) string {
+	first := ""
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if title := strings.TrimSpace(strings.TrimLeft(line, "#")); title != "" {
+				return title
+			}
+			continue
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	if len(first) > maxTitleLength || strings.ContainsAny(strings.TrimRight(first, ".!?"), ".!?") {
+		return ""
+	}
+	return strings.TrimRight(first, ".")
+}
+
+// authorPattern matches a byline such as "By Jane Doe" or "Author: Jane Doe"
+var authorPattern = regexp.MustCompile(`(?m)^[\s*_#>-]*(?:[Bb]y|BY|[Aa]uthor:|[Ww]ritten by)[ \t]+(\p{Lu}[\p{L}'.-]*(?:[ \t]+\p{Lu}[\p{L}'.-]*){0,3})`)
+
+// DetectAuthor finds the name in a byline near the start of text
+func DetectAuthor(text string) string {
+	m := authorPattern.FindStringSubmatch(text)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimRight(m[1], ".")
+}
+
+// entityPattern matches runs of capitalized words and acronyms
+var entityPattern = regexp.MustCompile(`\p{Lu}[\p{L}\d&.-]*(?:[ \t]+(?:of[ \t]+|de[ \t]+|von[ \t]+)?\p{Lu}[\p{L}\d&.-]*)*`)
+
+// entityStopwords are capitalized words that start sentences rather than names
+var entityStopwords = map[string]bool{
+	"the": true, "a": true, "an": true, "this": true, "that": true, "these": true, "those": true,
+	"it": true, "we": true, "you": true, "i": true, "he": true, "she": true, "they": true,
+	"in": true, "on": true, "at": true, "for": true, "if": true, "when": true, "but": true,
+	"and": true, "or": true, "to": true, "of": true, "by": true, "with": true, "as": true,
+}
+
+// ExtractEntities returns the names - multi-word capitalized phrases and
+// acronyms - that occur at least twice, most frequent first
+func ExtractEntities(text string, limit int) []string {
+	counts := make(map[string]int)
+	var order []string
+	for _, phrase := range entityPattern.FindAllString(text, -1) {
+		words := strings.Fields(strings.Trim(phrase, ".-"))
+		for len(words) > 0 && entityStopwords[strings.ToLower(words[0])] {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		entity := strings.Join(words, " ")
+		if len(words) == 1 && !isAcronym(entity) {
+			continue
+		}
+		if counts[entity] == 0 {
+			order = append(order, entity)
+		}
+		counts[entity]++
+	}
+
+	var entities []string
+	for _, entity := range order {
+		if counts[entity] >= 2 {
+			entities = append(entities, entity)
+		}
+	}
+	// Stable, so ties keep the order of first appearance
+	sort.SliceStable(entities, func(i, j int) bool { return counts[entities[i]] > counts[entities[j]] })
+	if len(entities) > limit {
+		entities = entities[:limit]
+	}
+	return entities
+}
+
+// isAcronym reports whether a word is 2-6 capital letters or digits, like "NASA" or "AWS"
+func isAcronym(word string) bool {
+	if len(word) < 2 || len(word) > 6 {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsUpper(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return unicode.IsUpper(rune(word[0]))
+}
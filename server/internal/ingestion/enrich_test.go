@@ -0,0 +1,78 @@
+package ingestion
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := map[string]string{
+		"The quick brown fox jumps over the lazy dog, and this is the end of it.":            "en",
+		"Der schnelle braune Fuchs springt über den faulen Hund, und das ist nicht alles.":   "de",
+		"Le renard brun saute par-dessus le chien paresseux et les chats sont dans la cour.": "fr",
+		"東京は日本の首都です。ここには多くの人が住んでいます。":                                                        "ja",
+		"北京是中国的首都，有很多人住在这里。":                                                                 "zh",
+		"Москва является столицей России.":                                                   "ru",
+		"12345 !!!": "",
+	}
+	for text, want := range tests {
+		if got := DetectLanguage(text); got != want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestHeuristicEnricher(t *testing.T) {
+	text := `# Release notes
+By Jane Doe
+Published March 3rd, 2024
+
+Acme Cloud now supports AWS regions. Acme Cloud customers can deploy to AWS
+from the console, and the Acme Cloud team is working on more regions.`
+
+	metadata := HeuristicEnricher{}.Enrich(context.Background(), text)
+	want := map[string]string{
+		MetadataLanguage:    "en",
+		MetadataPublishedAt: "2024-03-03",
+		MetadataAuthor:      "Jane Doe",
+		MetadataEntities:    "Acme Cloud, AWS",
+	}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Errorf("%s = %q, want %q", k, metadata[k], v)
+		}
+	}
+}
+
+func TestDetectDate(t *testing.T) {
+	tests := map[string]string{
+		"Updated 2023-11-05 by the docs team": "2023-11-05",
+		"Posted on 14 February 2022":          "2022-02-14",
+		"Version 1.2, build 0001-01-01":       "",
+	}
+	for text, want := range tests {
+		got := DetectDate(text)
+		if want == "" {
+			if !got.IsZero() {
+				t.Errorf("DetectDate(%q) = %v, want none", text, got)
+			}
+			continue
+		}
+		if got.Format("2006-01-02") != want {
+			t.Errorf("DetectDate(%q) = %v, want %s", text, got, want)
+		}
+	}
+}
+
+func TestDetectTitle(t *testing.T) {
+	tests := map[string]string{
+		"Intro text\n\n## Getting Started\nBody":    "Getting Started",
+		"Release Notes\n\nThis release fixes bugs.": "Release Notes",
+		"This is a sentence. And another one.":      "",
+	}
+	for text, want := range tests {
+		if got := DetectTitle(text); got != want {
+			t.Errorf("DetectTitle(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedFormat is returned when no extractor handles a file
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// ExtractedDocument is the text extracted from an uploaded file
+type ExtractedDocument struct {
+	// Title is the document title found in the file, if any
+	Title string
+
+	// Sections are the document's parts in order (e.g. one per slide). Each
+	// section is chunked separately so chunks never straddle two sections.
+	Sections []Section
+
+	// Metadata is added to the document (e.g. format, page or slide count)
+	Metadata map[string]string
+
+	// Images are the images the document references, for optional captioning
+	Images []ImageRef
+}
+
+// Section is a part of an extracted document
+type Section struct {
+	// Content is Markdown text
+	Content string
+
+	// Metadata is attached to every chunk of the section
+	Metadata map[string]string
+
+	// Table, if set, holds the section's rows; they are chunked row by row
+	// instead of chunking Content
+	Table *Table
+}
+
+// Text returns the content of all sections joined by blank lines
+func (d *ExtractedDocument) Text() string {
+	parts := make([]string, 0, len(d.Sections))
+	for _, s := range d.Sections {
+		if content := strings.TrimSpace(s.Content); content != "" {
+			parts = append(parts, content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Extractor converts file contents into text for chunking
+type Extractor interface {
+	Extract(ctx context.Context, data []byte) (*ExtractedDocument, error)
+}
+
+// ExtractorFunc adapts a function to the Extractor interface
+type ExtractorFunc func(ctx context.Context, data []byte) (*ExtractedDocument, error)
+
+// Extract calls f(ctx, data)
+func (f ExtractorFunc) Extract(ctx context.Context, data []byte) (*ExtractedDocument, error) {
+	return f(ctx, data)
+}
+
+// ExtractorRegistry maps content types and file extensions to extractors
+type ExtractorRegistry struct {
+	mu         sync.RWMutex
+	extractors map[string]Extractor // content type -> extractor
+	extensions map[string]string    // ".ext" -> content type
+}
+
+// NewExtractorRegistry creates an empty registry
+func NewExtractorRegistry() *ExtractorRegistry {
+	return &ExtractorRegistry{
+		extractors: make(map[string]Extractor),
+		extensions: make(map[string]string),
+	}
+}
+
+// Content types of the built-in extractors
+const (
+	ContentTypeText     = "text/plain"
+	ContentTypeMarkdown = "text/markdown"
+	ContentTypeHTML     = "text/html"
+	ContentTypeDOCX     = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	ContentTypePPTX     = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	ContentTypeXLSX     = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	ContentTypeCSV      = "text/csv"
+	ContentTypeTSV      = "text/tab-separated-values"
+)
+
+// DefaultExtractors returns a registry with the built-in extractors
+func DefaultExtractors() *ExtractorRegistry {
+	r := NewExtractorRegistry()
+	r.Register(ContentTypeText, ExtractorFunc(extractPlainText), ".txt", ".text", ".log")
+	r.Register(ContentTypeMarkdown, ExtractorFunc(extractPlainText), ".md", ".markdown")
+	r.Register(ContentTypeHTML, ExtractorFunc(extractHTMLFile), ".html", ".htm")
+	r.Register(ContentTypeDOCX, ExtractorFunc(ExtractDOCX), ".docx")
+	r.Register(ContentTypePPTX, ExtractorFunc(ExtractPPTX), ".pptx")
+	r.Register(ContentTypeXLSX, ExtractorFunc(ExtractXLSX), ".xlsx")
+	r.Register(ContentTypeCSV, ExtractorFunc(ExtractCSV), ".csv")
+	r.Register(ContentTypeTSV, ExtractorFunc(ExtractCSV), ".tsv")
+	return r
+}
+
+// Register adds an extractor for a content type and the file extensions that imply it.
+// Registering an existing content type replaces its extractor.
+func (r *ExtractorRegistry) Register(contentType string, e Extractor, extensions ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contentType = normalizeContentType(contentType)
+	r.extractors[contentType] = e
+	for _, ext := range extensions {
+		r.extensions[strings.ToLower(ext)] = contentType
+	}
+}
+
+// Lookup finds the extractor for a file. An explicit content type wins; generic
+// or missing types fall back to the filename extension, then content sniffing.
+// It returns the resolved content type alongside the extractor.
+func (r *ExtractorRegistry) Lookup(contentType, filename string, data []byte) (Extractor, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contentType = normalizeContentType(contentType)
+	if e, ok := r.extractors[contentType]; ok {
+		return e, contentType, nil
+	}
+
+	if ext := strings.ToLower(filepath.Ext(filename)); ext != "" {
+		if ct, ok := r.extensions[ext]; ok {
+			return r.extractors[ct], ct, nil
+		}
+	}
+
+	if contentType == "" || contentType == "application/octet-stream" {
+		sniffed := normalizeContentType(http.DetectContentType(data))
+		if e, ok := r.extractors[sniffed]; ok {
+			return e, sniffed, nil
+		}
+	}
+
+	if contentType == "" {
+		contentType = filepath.Ext(filename)
+	}
+	return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, contentType)
+}
+
+// normalizeContentType strips parameters and lowercases a MIME type
+func normalizeContentType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// extractPlainText passes text and Markdown through unchanged
+func extractPlainText(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	return &ExtractedDocument{
+		Sections: []Section{{Content: string(data)}},
+	}, nil
+}
+
+// extractHTMLFile extracts the main content of an uploaded HTML page
+func extractHTMLFile(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	content, err := ExtractHTML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	metadata := content.Quality.Metadata()
+	for k, v := range content.Structured.Metadata() {
+		metadata[k] = v
+	}
+	return &ExtractedDocument{
+		Title:    content.Title,
+		Sections: []Section{{Content: content.Markdown}},
+		Metadata: metadata,
+		Images:   content.Images,
+	}, nil
+}
@@ -0,0 +1,230 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// Defaults for knowledge graph extraction
+const (
+	DefaultGraphMaxEntities = 15
+	DefaultGraphConcurrency = 4
+)
+
+// GraphEntity is a named thing a chunk mentions
+type GraphEntity struct {
+	Name string // as written in the chunk
+	Type string // person, organization, place, product, concept, event or other
+}
+
+// GraphRelation is a fact a chunk states about two entities, read as
+// "Source Relation Target"
+type GraphRelation struct {
+	Source   string
+	Relation string // a short lowercase verb phrase, e.g. "founded"
+	Target   string
+}
+
+// ChunkGraph is what a chunk says about entities
+type ChunkGraph struct {
+	Entities  []GraphEntity
+	Relations []GraphRelation
+}
+
+// graphSystemPrompt instructs the LLM that extracts entities and relations
+const graphSystemPrompt = `You extract a knowledge graph from text: the named entities it mentions and the relations it states between them.
+
+Rules:
+- Entities are specific people, organizations, places, products, events and named concepts; skip generic nouns.
+- Write each entity's name as the text does, in its fullest form.
+- Relations are short lowercase verb phrases, such as "founded", "is part of" or "acquired".
+- Only include relations the text states; do not infer.`
+
+// graphSchema constrains the extracted graph
+var graphSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "entities": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "type": {"type": "string", "enum": ["person", "organization", "place", "product", "concept", "event", "other"]}
+        },
+        "required": ["name", "type"]
+      }
+    },
+    "relations": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "source": {"type": "string"},
+          "relation": {"type": "string"},
+          "target": {"type": "string"}
+        },
+        "required": ["source", "relation", "target"]
+      }
+    }
+  },
+  "required": ["entities", "relations"]
+}`)
+
+// GraphConfig configures a GraphExtractor
+type GraphConfig struct {
+	// Model is the LLM that reads the chunks; empty uses the client's default
+	Model string
+
+	// MaxEntities caps the entities kept per chunk (default: 15)
+	MaxEntities int
+
+	// Concurrency is how many chunks are read at once (default: 4)
+	Concurrency int
+}
+
+// GraphExtractor reads entities and the relations between them out of
+// chunks with an LLM, for graph-augmented retrieval
+type GraphExtractor struct {
+	llm         llm.LLM
+	model       string
+	maxEntities int
+	concurrency int
+}
+
+// NewGraphExtractor creates an extractor backed by an LLM
+func NewGraphExtractor(l llm.LLM, cfg GraphConfig) *GraphExtractor {
+	e := &GraphExtractor{
+		llm:         l,
+		model:       cfg.Model,
+		maxEntities: cfg.MaxEntities,
+		concurrency: cfg.Concurrency,
+	}
+	if e.maxEntities <= 0 {
+		e.maxEntities = DefaultGraphMaxEntities
+	}
+	if e.concurrency <= 0 {
+		e.concurrency = DefaultGraphConcurrency
+	}
+	return e
+}
+
+// Model returns the model that reads the chunks
+func (e *GraphExtractor) Model() string {
+	return e.model
+}
+
+// Extract reads the graph of one chunk. Relations are kept only between
+// entities it names, and duplicates are dropped.
+func (e *GraphExtractor) Extract(ctx context.Context, text string) (ChunkGraph, error) {
+	raw, err := e.llm.GenerateStructured(ctx, text, graphSchema, llm.GenerateOptions{
+		Model:        e.model,
+		SystemPrompt: graphSystemPrompt,
+		MaxTokens:    1024,
+	})
+	if err != nil {
+		return ChunkGraph{}, err
+	}
+	var reply struct {
+		Entities []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"entities"`
+		Relations []struct {
+			Source   string `json:"source"`
+			Relation string `json:"relation"`
+			Target   string `json:"target"`
+		} `json:"relations"`
+	}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return ChunkGraph{}, fmt.Errorf("failed to parse graph: %w", err)
+	}
+
+	var graph ChunkGraph
+	names := make(map[string]string) // key to name
+	for _, entity := range reply.Entities {
+		name := collapseSpace(entity.Name)
+		key := EntityKey(name)
+		if key == "" || names[key] != "" {
+			continue
+		}
+		if len(graph.Entities) == e.maxEntities {
+			break
+		}
+		names[key] = name
+		graph.Entities = append(graph.Entities, GraphEntity{Name: name, Type: strings.ToLower(entity.Type)})
+	}
+
+	seen := make(map[GraphRelation]bool)
+	for _, relation := range reply.Relations {
+		source, target := names[EntityKey(relation.Source)], names[EntityKey(relation.Target)]
+		r := GraphRelation{
+			Source:   source,
+			Relation: strings.ToLower(collapseSpace(relation.Relation)),
+			Target:   target,
+		}
+		if source == "" || target == "" || source == target || r.Relation == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+		graph.Relations = append(graph.Relations, r)
+	}
+	return graph, nil
+}
+
+// ExtractAll reads the graphs of chunks, Concurrency at a time. A chunk that
+// fails is left with an empty graph; the first error is returned with the
+// rest of the graphs.
+func (e *GraphExtractor) ExtractAll(ctx context.Context, texts []string) ([]ChunkGraph, error) {
+	graphs := make([]ChunkGraph, len(texts))
+	errs := make([]error, len(texts))
+	semaphore := make(chan struct{}, e.concurrency)
+	done := make(chan struct{})
+	for i, text := range texts {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			graphs[i], errs[i] = e.Extract(ctx, text)
+		}()
+	}
+	for range texts {
+		<-done
+	}
+	for _, err := range errs {
+		if err != nil {
+			return graphs, err
+		}
+	}
+	return graphs, nil
+}
+
+// EntityKey returns the form entity names are matched by: lowercase, with
+// punctuation dropped and spaces collapsed, so "Acme, Inc." matches "acme inc"
+func EntityKey(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			space = true
+		}
+	}
+	return b.String()
+}
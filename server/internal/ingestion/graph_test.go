@@ -0,0 +1,106 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// graphLLM answers GenerateStructured with a fixed graph, or fails for
+// prompts containing "fail"
+type graphLLM struct {
+	reply string
+}
+
+func (l *graphLLM) Generate(context.Context, string, llm.GenerateOptions) (string, error) {
+	return "", nil
+}
+
+func (l *graphLLM) GenerateStream(context.Context, string, llm.GenerateOptions) (<-chan llm.StreamChunk, error) {
+	return nil, nil
+}
+
+func (l *graphLLM) GenerateStructured(_ context.Context, prompt string, _ json.RawMessage, _ llm.GenerateOptions) (json.RawMessage, error) {
+	if strings.Contains(prompt, "fail") {
+		return nil, errors.New("model unavailable")
+	}
+	return json.RawMessage(l.reply), nil
+}
+
+func TestGraphExtractor_Extract(t *testing.T) {
+	e := NewGraphExtractor(&graphLLM{reply: `{
+		"entities": [
+			{"name": "Jane  Doe", "type": "Person"},
+			{"name": "Acme, Inc.", "type": "organization"},
+			{"name": "jane doe", "type": "person"},
+			{"name": " ", "type": "other"}
+		],
+		"relations": [
+			{"source": "Jane Doe", "relation": "Founded", "target": "Acme Inc"},
+			{"source": "jane doe", "relation": "founded", "target": "acme, inc."},
+			{"source": "Jane Doe", "relation": "met", "target": "John Roe"},
+			{"source": "Acme Inc", "relation": "is", "target": "Acme, Inc."}
+		]
+	}`}, GraphConfig{})
+
+	graph, err := e.Extract(context.Background(), "Jane Doe founded Acme, Inc.")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	want := []GraphEntity{{"Jane Doe", "person"}, {"Acme, Inc.", "organization"}}
+	if len(graph.Entities) != len(want) || graph.Entities[0] != want[0] || graph.Entities[1] != want[1] {
+		t.Errorf("Entities = %+v, want %+v", graph.Entities, want)
+	}
+	// Duplicates, relations to unnamed entities and self-relations are dropped
+	if len(graph.Relations) != 1 || graph.Relations[0] != (GraphRelation{"Jane Doe", "founded", "Acme, Inc."}) {
+		t.Errorf("Relations = %+v, want Jane Doe founded Acme, Inc.", graph.Relations)
+	}
+}
+
+func TestGraphExtractor_MaxEntities(t *testing.T) {
+	e := NewGraphExtractor(&graphLLM{reply: `{
+		"entities": [{"name": "A", "type": "other"}, {"name": "B", "type": "other"}, {"name": "C", "type": "other"}],
+		"relations": [{"source": "A", "relation": "knows", "target": "C"}]
+	}`}, GraphConfig{MaxEntities: 2})
+
+	graph, err := e.Extract(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(graph.Entities) != 2 || len(graph.Relations) != 0 {
+		t.Errorf("graph = %+v, want two entities and no relations", graph)
+	}
+}
+
+func TestGraphExtractor_ExtractAll(t *testing.T) {
+	e := NewGraphExtractor(&graphLLM{reply: `{"entities": [{"name": "Acme", "type": "organization"}], "relations": []}`}, GraphConfig{Concurrency: 2})
+
+	graphs, err := e.ExtractAll(context.Background(), []string{"one", "fail", "three"})
+	if err == nil {
+		t.Error("ExtractAll did not report the failed chunk")
+	}
+	if len(graphs) != 3 || len(graphs[0].Entities) != 1 || len(graphs[1].Entities) != 0 || len(graphs[2].Entities) != 1 {
+		t.Errorf("graphs = %+v, want the failed chunk alone empty", graphs)
+	}
+}
+
+func TestEntityKey(t *testing.T) {
+	tests := map[string]string{
+		"Acme, Inc.":      "acme inc",
+		"  Jane   Doe ":   "jane doe",
+		"Rolls-Royce":     "rolls royce",
+		"U.S.":            "us",
+		"東京":              "東京",
+		"...":             "",
+		"GPT-4 Turbo (x)": "gpt 4 turbo x",
+	}
+	for name, want := range tests {
+		if got := EntityKey(name); got != want {
+			t.Errorf("EntityKey(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Link is a hyperlink found on a page
+type Link struct {
+	// Href is the link target as written, relative or absolute
+	Href string
+
+	// Text is the anchor text, falling back to the title attribute or the
+	// alt text of a linked image
+	Text string
+}
+
+// ExtractLinks returns the links on an HTML page that a crawler may follow,
+// navigation included. Links marked rel="nofollow", and all links of a page
+// whose robots meta tag says nofollow, are left out, as are javascript:,
+// mailto: and same-page fragment links.
+func ExtractLinks(content string) ([]Link, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	if robotsNofollow(doc) {
+		return nil, nil
+	}
+
+	var links []Link
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || n.DataAtom != atom.A {
+			return true
+		}
+		href, _ := attr(n, "href")
+		href = strings.TrimSpace(href)
+		rel, _ := attr(n, "rel")
+		if href == "" || strings.HasPrefix(href, "#") || hasToken(rel, "nofollow") {
+			return false
+		}
+		if scheme, _, ok := strings.Cut(href, ":"); ok && !strings.Contains(scheme, "/") {
+			if scheme = strings.ToLower(scheme); scheme != "http" && scheme != "https" {
+				return false
+			}
+		}
+		links = append(links, Link{Href: href, Text: anchorText(n)})
+		return false
+	})
+	return links, nil
+}
+
+// anchorText returns the text a link is labelled with
+func anchorText(a *html.Node) string {
+	if text := collapseSpace(textContent(a)); text != "" {
+		return text
+	}
+	if title, ok := attr(a, "title"); ok && strings.TrimSpace(title) != "" {
+		return collapseSpace(title)
+	}
+	if img := findElement(a, atom.Img); img != nil {
+		alt, _ := attr(img, "alt")
+		return collapseSpace(alt)
+	}
+	return ""
+}
+
+// robotsNofollow reports whether a page's robots meta tag forbids following
+// its links
+func robotsNofollow(doc *html.Node) bool {
+	nofollow := false
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Meta {
+			name, _ := attr(n, "name")
+			if strings.EqualFold(name, "robots") {
+				content, _ := attr(n, "content")
+				directives := strings.ReplaceAll(content, ",", " ")
+				nofollow = nofollow || hasToken(directives, "nofollow") || hasToken(directives, "none")
+			}
+			return false
+		}
+		return n.DataAtom != atom.Body
+	})
+	return nofollow
+}
+
+// hasToken reports whether a space-separated attribute value contains a
+// token, ignoring case
+func hasToken(value, token string) bool {
+	for _, t := range strings.Fields(value) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
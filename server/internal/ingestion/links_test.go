@@ -0,0 +1,47 @@
+package ingestion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	page := `<html><head><title>Docs</title></head><body>
+<nav><a href="/docs/">Docs home</a></nav>
+<main>
+  <p>See the <a href="guide.html">  installation
+     guide</a> and <a href="https://example.org/api" title="API reference"></a>.</p>
+  <a href="/logo"><img src="logo.png" alt="Logo"></a>
+  <a href="#top">Top</a>
+  <a href="mailto:docs@example.com">Mail us</a>
+  <a href="javascript:void(0)">Menu</a>
+  <a href="/login" rel="nofollow">Log in</a>
+</main></body></html>`
+
+	links, err := ExtractLinks(page)
+	if err != nil {
+		t.Fatalf("ExtractLinks: %v", err)
+	}
+	want := []Link{
+		{Href: "/docs/", Text: "Docs home"},
+		{Href: "guide.html", Text: "installation guide"},
+		{Href: "https://example.org/api", Text: "API reference"},
+		{Href: "/logo", Text: "Logo"},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("links = %+v, want %+v", links, want)
+	}
+}
+
+func TestExtractLinksRobotsNofollow(t *testing.T) {
+	page := `<html><head><meta name="robots" content="noindex, nofollow"></head>
+<body><a href="/a">A</a></body></html>`
+
+	links, err := ExtractLinks(page)
+	if err != nil {
+		t.Fatalf("ExtractLinks: %v", err)
+	}
+	if len(links) != 0 {
+		t.Errorf("expected no links on a nofollow page, got %+v", links)
+	}
+}
@@ -0,0 +1,323 @@
+package ingestion
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLToMarkdown converts an HTML fragment to Markdown without main content
+// selection, for input that is already the content (e.g. feed item bodies)
+func HTMLToMarkdown(content string) string {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return ""
+	}
+	body := findElement(doc, atom.Body)
+	if body == nil {
+		body = doc
+	}
+	removeBoilerplate(body)
+	return renderMarkdown(body)
+}
+
+// renderMarkdown converts nodes to Markdown, keeping the structure the semantic
+// chunker understands: ATX headings, lists, pipe tables and fenced code blocks
+func renderMarkdown(nodes ...*html.Node) string {
+	var m markdownWriter
+	for _, n := range nodes {
+		m.node(n)
+	}
+	return cleanMarkdown(m.sb.String())
+}
+
+// markdownWriter accumulates Markdown output
+type markdownWriter struct {
+	sb strings.Builder
+}
+
+// atLineStart reports whether the output is empty or ends with a newline
+func (m *markdownWriter) atLineStart() bool {
+	s := m.sb.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+// text writes inline text with whitespace collapsed
+func (m *markdownWriter) text(s string) {
+	s = inlineSpace.ReplaceAllString(strings.ReplaceAll(s, "\n", " "), " ")
+	if m.atLineStart() || strings.HasSuffix(m.sb.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	m.sb.WriteString(s)
+}
+
+// block separates block-level output with a blank line
+func (m *markdownWriter) block(s string) {
+	if s == "" {
+		return
+	}
+	m.sb.WriteString("\n\n")
+	m.sb.WriteString(s)
+	m.sb.WriteString("\n\n")
+}
+
+func (m *markdownWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		m.node(c)
+	}
+}
+
+func (m *markdownWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		m.text(n.Data)
+		return
+	case html.DocumentNode:
+		m.children(n)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if heading := inlineMarkdown(n); heading != "" {
+			m.block(strings.Repeat("#", level) + " " + heading)
+		}
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Main, atom.Header,
+		atom.Figure, atom.Figcaption, atom.Address, atom.Dl, atom.Dd, atom.Dt:
+		m.sb.WriteString("\n\n")
+		m.children(n)
+		m.sb.WriteString("\n\n")
+	case atom.Ul, atom.Ol:
+		m.block(listMarkdown(n))
+	case atom.Table:
+		m.block(tableMarkdown(n))
+	case atom.Pre:
+		m.block(codeBlockMarkdown(n))
+	case atom.Blockquote:
+		inner := renderMarkdown(childNodes(n)...)
+		if inner != "" {
+			lines := strings.Split(inner, "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight("> "+line, " ")
+			}
+			m.block(strings.Join(lines, "\n"))
+		}
+	case atom.Hr:
+		m.block("---")
+	case atom.Br:
+		m.sb.WriteString("\n")
+	case atom.Strong, atom.B:
+		m.wrap(n, "**")
+	case atom.Em, atom.I:
+		m.wrap(n, "*")
+	case atom.Code:
+		if code := collapseSpace(textContent(n)); code != "" {
+			m.text(" ")
+			m.sb.WriteString("`" + code + "`")
+		}
+	case atom.Img:
+		// Images carry no text for retrieval; alt text is usually decorative
+	default:
+		m.children(n)
+	}
+}
+
+// wrap writes inline content surrounded by a Markdown emphasis marker
+func (m *markdownWriter) wrap(n *html.Node, marker string) {
+	inner := inlineMarkdown(n)
+	if inner == "" {
+		return
+	}
+	m.text(" ")
+	m.sb.WriteString(marker + inner + marker)
+}
+
+// inlineMarkdown renders a node's children as a single line
+func inlineMarkdown(n *html.Node) string {
+	var m markdownWriter
+	m.children(n)
+	return collapseSpace(m.sb.String())
+}
+
+// listMarkdown renders a <ul> or <ol>; nested lists are indented under their item
+func listMarkdown(list *html.Node) string {
+	ordered := list.DataAtom == atom.Ol
+	counter := 1
+	if start, ok := attr(list, "start"); ok && ordered {
+		if v, err := strconv.Atoi(start); err == nil {
+			counter = v
+		}
+	}
+
+	var lines []string
+	for li := list.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+
+		marker := "-"
+		if ordered {
+			marker = strconv.Itoa(counter) + "."
+			counter++
+		}
+		indent := strings.Repeat(" ", len(marker)+1)
+
+		item := renderMarkdown(childNodes(li)...)
+		first := true
+		for _, line := range strings.Split(item, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if first {
+				lines = append(lines, marker+" "+line)
+				first = false
+			} else {
+				lines = append(lines, indent+line)
+			}
+		}
+		if first {
+			lines = append(lines, marker)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableMarkdown renders a table as a pipe table, using the first row as header
+func tableMarkdown(table *html.Node) string {
+	var rows [][]string
+	columns := 0
+	walk(table, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if n != table && n.DataAtom == atom.Table {
+			return false // nested tables are flattened into their cell
+		}
+		if n.DataAtom != atom.Tr {
+			return true
+		}
+
+		var cells []string
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Td || c.DataAtom == atom.Th) {
+				cells = append(cells, strings.ReplaceAll(inlineMarkdown(c), "|", `\|`))
+			}
+		}
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+			columns = max(columns, len(cells))
+		}
+		return false
+	})
+	return pipeTable(rows, columns)
+}
+
+// pipeTable renders rows as a Markdown pipe table, using the first row as header.
+// Cells must already be single-line with pipes escaped.
+func pipeTable(rows [][]string, columns int) string {
+	if len(rows) == 0 || columns == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// codeBlockMarkdown renders a <pre> as a fenced code block, keeping whitespace
+func codeBlockMarkdown(pre *html.Node) string {
+	lang := codeLanguage(pre)
+	if code := findElement(pre, atom.Code); code != nil && lang == "" {
+		lang = codeLanguage(code)
+	}
+
+	var sb strings.Builder
+	walk(pre, func(n *html.Node) bool {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		} else if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+			sb.WriteString("\n")
+		}
+		return true
+	})
+
+	code := strings.Trim(sb.String(), "\n")
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	return "```" + lang + "\n" + code + "\n```"
+}
+
+// codeLanguage reads a language-* or lang-* class
+func codeLanguage(n *html.Node) string {
+	for _, class := range strings.Fields(classAttr(n)) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if lang, ok := strings.CutPrefix(class, prefix); ok && lang != "" {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+func childNodes(n *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodes = append(nodes, c)
+	}
+	return nodes
+}
+
+// cleanMarkdown trims trailing whitespace and collapses runs of blank lines,
+// leaving fenced code blocks untouched
+func cleanMarkdown(s string) string {
+	var out []string
+	inFence := false
+	blank := false
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			out = append(out, strings.TrimSpace(line))
+			blank = false
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t")
+		if strings.TrimSpace(line) == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
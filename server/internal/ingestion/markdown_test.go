@@ -0,0 +1,52 @@
+package ingestion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestHTMLToMarkdown_Structure(t *testing.T) {
+	input := `<h2>Install</h2>
+<p>Run the <code>installer</code> with <strong>admin</strong> rights.</p>
+<ul>
+  <li>Linux
+    <ol><li>Download</li><li>Extract</li></ol>
+  </li>
+  <li>macOS</li>
+</ul>
+<table>
+  <tr><th>Flag</th><th>Meaning</th></tr>
+  <tr><td>-v</td><td>verbose | chatty</td></tr>
+</table>
+<pre><code class="language-go">func main() {
+	fmt.Println("hi")
+}</code></pre>`
+
+	got := HTMLToMarkdown(input)
+
+	want := "## Install\n\n" +
+		"Run the `installer` with **admin** rights.\n\n" +
+		"- Linux\n  1. Download\n  2. Extract\n- macOS\n\n" +
+		"| Flag | Meaning |\n| --- | --- |\n| -v | verbose \\| chatty |\n\n" +
+		"```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```"
+	if got != want {
+		t.Errorf("unexpected markdown:\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestHTMLToMarkdown_ChunkerBlocks(t *testing.T) {
+	md := HTMLToMarkdown(`<h1>Guide</h1><p>Intro text.</p><pre>x := 1</pre><table><tr><td>a</td><td>b</td></tr></table>`)
+
+	chunker := NewChunker(repository.ChunkerConfig{Method: "semantic"})
+	blocks := chunker.parseIntoBlocks(md)
+
+	types := make([]string, len(blocks))
+	for i, b := range blocks {
+		types[i] = b.blockType
+	}
+	if strings.Join(types, ",") != "header,paragraph,code,table" {
+		t.Errorf("expected header,paragraph,code,table blocks, got %v", types)
+	}
+}
@@ -0,0 +1,78 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/knoguchi/rag/internal/ocr"
+)
+
+// Content types handled by OCR
+const (
+	ContentTypePDF  = "application/pdf"
+	ContentTypePNG  = "image/png"
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypeTIFF = "image/tiff"
+	ContentTypeWebP = "image/webp"
+	ContentTypeBMP  = "image/bmp"
+)
+
+// RegisterOCR registers OCR extractors for scanned PDFs and images
+func RegisterOCR(r *ExtractorRegistry, engine ocr.Engine) {
+	r.Register(ContentTypePDF, NewOCRExtractor(engine, ContentTypePDF), ".pdf")
+	r.Register(ContentTypePNG, NewOCRExtractor(engine, ContentTypePNG), ".png")
+	r.Register(ContentTypeJPEG, NewOCRExtractor(engine, ContentTypeJPEG), ".jpg", ".jpeg")
+	r.Register(ContentTypeTIFF, NewOCRExtractor(engine, ContentTypeTIFF), ".tif", ".tiff")
+	r.Register(ContentTypeWebP, NewOCRExtractor(engine, ContentTypeWebP), ".webp")
+	r.Register(ContentTypeBMP, NewOCRExtractor(engine, ContentTypeBMP), ".bmp")
+}
+
+// NewOCRExtractor returns an extractor that recognizes text with engine, one
+// section per page. Every chunk is marked extraction=ocr with the page's
+// ocr_confidence so low-quality scans can be filtered or reviewed.
+func NewOCRExtractor(engine ocr.Engine, contentType string) Extractor {
+	return ExtractorFunc(func(ctx context.Context, data []byte) (*ExtractedDocument, error) {
+		pages, err := engine.Recognize(ctx, data, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("OCR failed: %w", err)
+		}
+
+		doc := &ExtractedDocument{
+			Metadata: map[string]string{
+				"extraction": "ocr",
+				"ocr_engine": engine.Name(),
+				"page_count": strconv.Itoa(len(pages)),
+			},
+		}
+
+		// Document confidence is weighted by the amount of text on each page
+		var weighted float64
+		total := 0
+		for _, page := range pages {
+			text := strings.TrimSpace(page.Text)
+			if text == "" {
+				continue
+			}
+			doc.Sections = append(doc.Sections, Section{
+				Content: text,
+				Metadata: map[string]string{
+					"extraction":     "ocr",
+					"ocr_confidence": formatConfidence(page.Confidence),
+					"page_number":    strconv.Itoa(page.Number),
+				},
+			})
+			weighted += page.Confidence * float64(len(text))
+			total += len(text)
+		}
+		if total > 0 {
+			doc.Metadata["ocr_confidence"] = formatConfidence(weighted / float64(total))
+		}
+		return doc, nil
+	})
+}
+
+func formatConfidence(c float64) string {
+	return strconv.FormatFloat(c, 'f', 2, 64)
+}
@@ -0,0 +1,540 @@
+package ingestion
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxZipEntrySize caps how much of a single archive entry is decompressed,
+// guarding against zip bombs
+const maxZipEntrySize = 64 << 20 // 64 MB
+
+// Office Open XML namespaces
+const (
+	nsWordML    = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	nsDrawingML = "http://schemas.openxmlformats.org/drawingml/2006/main"
+	nsPresentML = "http://schemas.openxmlformats.org/presentationml/2006/main"
+	nsRelations = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// ExtractDOCX extracts a Word document as Markdown. Heading and Title styles
+// become headings, numbered/bulleted paragraphs become list items and tables
+// become pipe tables.
+func ExtractDOCX(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open docx: %w", err)
+	}
+
+	body, err := readZipEntry(zr, "word/document.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docx: %w", err)
+	}
+
+	content, err := parseWordDocument(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse docx: %w", err)
+	}
+
+	return &ExtractedDocument{
+		Title:    coreTitle(zr),
+		Sections: []Section{{Content: content}},
+		Metadata: map[string]string{"format": "docx"},
+	}, nil
+}
+
+// wordTable collects the rows of a table being parsed
+type wordTable struct {
+	rows [][]string
+	row  []string
+	cell []string
+}
+
+// parseWordDocument converts word/document.xml to Markdown
+func parseWordDocument(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		blocks    []string
+		tables    []*wordTable
+		paragraph strings.Builder
+		style     string
+		listLevel = -1
+		inText    bool
+	)
+
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != nsWordML {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				paragraph.Reset()
+				style = ""
+				listLevel = -1
+			case "pStyle":
+				style = xmlAttr(t, "val")
+			case "ilvl":
+				if lvl, err := strconv.Atoi(xmlAttr(t, "val")); err == nil {
+					listLevel = lvl
+				}
+			case "numPr":
+				listLevel = max(listLevel, 0)
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteString(" ")
+			case "br", "cr":
+				paragraph.WriteString(" ")
+			case "tbl":
+				tables = append(tables, &wordTable{})
+			case "tr":
+				if len(tables) > 0 {
+					tables[len(tables)-1].row = nil
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tables[len(tables)-1].cell = nil
+				}
+			}
+
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+
+		case xml.EndElement:
+			if t.Name.Space != nsWordML {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text := collapseSpace(paragraph.String())
+				if text == "" {
+					continue
+				}
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tbl.cell = append(tbl.cell, text)
+					continue
+				}
+				blocks = append(blocks, wordBlock(text, style, listLevel))
+			case "tc":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tbl.row = append(tbl.row, strings.ReplaceAll(strings.Join(tbl.cell, " "), "|", `\|`))
+				}
+			case "tr":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					if len(tbl.row) > 0 {
+						tbl.rows = append(tbl.rows, tbl.row)
+					}
+				}
+			case "tbl":
+				tbl := tables[len(tables)-1]
+				tables = tables[:len(tables)-1]
+				if len(tables) > 0 {
+					// Nested tables are flattened into the enclosing cell
+					parent := tables[len(tables)-1]
+					for _, row := range tbl.rows {
+						parent.cell = append(parent.cell, strings.Join(row, " "))
+					}
+					continue
+				}
+				columns := 0
+				for _, row := range tbl.rows {
+					columns = max(columns, len(row))
+				}
+				if table := pipeTable(tbl.rows, columns); table != "" {
+					blocks = append(blocks, table)
+				}
+			}
+		}
+	}
+
+	return joinListBlocks(blocks), nil
+}
+
+// wordBlock renders a paragraph according to its style
+func wordBlock(text, style string, listLevel int) string {
+	if level := headingLevel(style); level > 0 {
+		return strings.Repeat("#", level) + " " + text
+	}
+	if listLevel >= 0 {
+		return strings.Repeat("  ", listLevel) + "- " + text
+	}
+	return text
+}
+
+// headingLevel maps Word paragraph styles (Title, Heading1, "heading 2") to a heading level
+func headingLevel(style string) int {
+	s := strings.ToLower(strings.ReplaceAll(style, " ", ""))
+	if s == "title" {
+		return 1
+	}
+	if rest, ok := strings.CutPrefix(s, "heading"); ok {
+		if level, err := strconv.Atoi(rest); err == nil && level >= 1 && level <= 6 {
+			return level
+		}
+	}
+	return 0
+}
+
+// joinListBlocks joins blocks with blank lines, keeping consecutive list items together
+func joinListBlocks(blocks []string) string {
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			if isListItem(block) && isListItem(blocks[i-1]) {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		sb.WriteString(block)
+	}
+	return sb.String()
+}
+
+func isListItem(block string) bool {
+	return strings.HasPrefix(strings.TrimLeft(block, " "), "- ")
+}
+
+// ExtractPPTX extracts a PowerPoint deck as one section per slide, in
+// presentation order. Each section carries slide_number and slide_title
+// metadata; speaker notes are included under the slide.
+func ExtractPPTX(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pptx: %w", err)
+	}
+
+	slides, err := slidePaths(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pptx: %w", err)
+	}
+
+	doc := &ExtractedDocument{
+		Title: coreTitle(zr),
+		Metadata: map[string]string{
+			"format":      "pptx",
+			"slide_count": strconv.Itoa(len(slides)),
+		},
+	}
+
+	for i, slidePath := range slides {
+		number := i + 1
+		xmlData, err := readZipEntry(zr, slidePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slide %d: %w", number, err)
+		}
+		title, blocks, err := parseSlide(xmlData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse slide %d: %w", number, err)
+		}
+
+		if notesPath := slideNotesPath(zr, slidePath); notesPath != "" {
+			if notesData, err := readZipEntry(zr, notesPath); err == nil {
+				if _, notes, err := parseSlide(notesData); err == nil && len(notes) > 0 {
+					blocks = append(blocks, "Notes: "+strings.Join(notes, " "))
+				}
+			}
+		}
+
+		heading := "## Slide " + strconv.Itoa(number)
+		if title != "" {
+			heading += ": " + title
+		}
+		if doc.Title == "" && title != "" {
+			doc.Title = title
+		}
+
+		metadata := map[string]string{"slide_number": strconv.Itoa(number)}
+		if title != "" {
+			metadata["slide_title"] = title
+		}
+		doc.Sections = append(doc.Sections, Section{
+			Content:  strings.Join(append([]string{heading}, blocks...), "\n\n"),
+			Metadata: metadata,
+		})
+	}
+
+	return doc, nil
+}
+
+// parseSlide returns a slide's title and its other text blocks (one per shape
+// or table). Speaker notes pages use the same structure.
+func parseSlide(data []byte) (string, []string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		title      string
+		blocks     []string
+		shapeLines []string
+		paragraph  strings.Builder
+		isTitle    bool
+		isNotesAux bool // slide image and number placeholders on notes pages
+		inText     bool
+		rows       [][]string
+		row        []string
+		cell       []string
+		inTable    bool
+	)
+
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Space == nsPresentML && t.Name.Local == "sp":
+				shapeLines = nil
+				isTitle, isNotesAux = false, false
+			case t.Name.Space == nsPresentML && t.Name.Local == "ph":
+				switch xmlAttr(t, "type") {
+				case "title", "ctrTitle":
+					isTitle = true
+				case "sldImg", "sldNum", "hdr", "ftr", "dt":
+					isNotesAux = true
+				}
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tbl":
+				inTable, rows = true, nil
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tr":
+				row = nil
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tc":
+				cell = nil
+			case t.Name.Space == nsDrawingML && t.Name.Local == "p":
+				paragraph.Reset()
+			case t.Name.Space == nsDrawingML && t.Name.Local == "t":
+				inText = true
+			case t.Name.Space == nsDrawingML && t.Name.Local == "br":
+				paragraph.WriteString(" ")
+			}
+
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+
+		case xml.EndElement:
+			switch {
+			case t.Name.Space == nsDrawingML && t.Name.Local == "t":
+				inText = false
+			case t.Name.Space == nsDrawingML && t.Name.Local == "p":
+				text := collapseSpace(paragraph.String())
+				if text == "" {
+					continue
+				}
+				if inTable {
+					cell = append(cell, text)
+				} else {
+					shapeLines = append(shapeLines, text)
+				}
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tc":
+				row = append(row, strings.ReplaceAll(strings.Join(cell, " "), "|", `\|`))
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tr":
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			case t.Name.Space == nsDrawingML && t.Name.Local == "tbl":
+				inTable = false
+				columns := 0
+				for _, r := range rows {
+					columns = max(columns, len(r))
+				}
+				if table := pipeTable(rows, columns); table != "" {
+					blocks = append(blocks, table)
+				}
+			case t.Name.Space == nsPresentML && t.Name.Local == "sp":
+				switch {
+				case isNotesAux || len(shapeLines) == 0:
+				case isTitle && title == "":
+					title = strings.Join(shapeLines, " ")
+				default:
+					blocks = append(blocks, strings.Join(shapeLines, "\n"))
+				}
+			}
+		}
+	}
+
+	return title, blocks, nil
+}
+
+// relationship is an entry of an OPC .rels part
+type relationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+type relationships struct {
+	Relationships []relationship `xml:"Relationship"`
+}
+
+// slidePaths returns the slide parts in presentation order, falling back to
+// file name order if presentation.xml cannot be resolved
+func slidePaths(zr *zip.Reader) ([]string, error) {
+	if paths := orderedSlidePaths(zr); len(paths) > 0 {
+		return paths, nil
+	}
+
+	var paths []string
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, "ppt/slides/slide") && strings.HasSuffix(f.Name, ".xml") {
+			paths = append(paths, f.Name)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no slides found")
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return slideNumber(paths[i]) < slideNumber(paths[j])
+	})
+	return paths, nil
+}
+
+// orderedSlidePaths resolves ppt/presentation.xml's slide list through its relationships
+func orderedSlidePaths(zr *zip.Reader) []string {
+	presentation, err := readZipEntry(zr, "ppt/presentation.xml")
+	if err != nil {
+		return nil
+	}
+	rels := readRelationships(zr, "ppt/_rels/presentation.xml.rels")
+	if len(rels) == 0 {
+		return nil
+	}
+
+	var paths []string
+	decoder := xml.NewDecoder(bytes.NewReader(presentation))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Space != nsPresentML || start.Name.Local != "sldId" {
+			continue
+		}
+		for _, a := range start.Attr {
+			if a.Name.Space == nsRelations && a.Name.Local == "id" {
+				if rel, ok := rels[a.Value]; ok {
+					paths = append(paths, resolveTarget("ppt", rel.Target))
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// slideNotesPath returns the notes part linked from a slide, if any
+func slideNotesPath(zr *zip.Reader, slidePath string) string {
+	dir, file := path.Split(slidePath)
+	for _, rel := range readRelationships(zr, path.Join(dir, "_rels", file+".rels")) {
+		if strings.HasSuffix(rel.Type, "/notesSlide") {
+			return resolveTarget(dir, rel.Target)
+		}
+	}
+	return ""
+}
+
+// readRelationships reads a .rels part keyed by relationship ID
+func readRelationships(zr *zip.Reader, name string) map[string]relationship {
+	data, err := readZipEntry(zr, name)
+	if err != nil {
+		return nil
+	}
+	var rels relationships
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil
+	}
+	result := make(map[string]relationship, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		result[rel.ID] = rel
+	}
+	return result
+}
+
+// resolveTarget resolves a relationship target against the source part's directory
+func resolveTarget(dir, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(dir, target)
+}
+
+// slideNumber parses N from ppt/slides/slideN.xml
+func slideNumber(name string) int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path.Base(name), "slide"), ".xml"))
+	return n
+}
+
+// coreTitle reads dc:title from docProps/core.xml
+func coreTitle(zr *zip.Reader) string {
+	data, err := readZipEntry(zr, "docProps/core.xml")
+	if err != nil {
+		return ""
+	}
+	var props struct {
+		Title string `xml:"http://purl.org/dc/elements/1.1/ title"`
+	}
+	if err := xml.Unmarshal(data, &props); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(props.Title)
+}
+
+// readZipEntry reads a file from an archive, up to maxZipEntrySize
+func readZipEntry(zr *zip.Reader, name string) ([]byte, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxZipEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxZipEntrySize {
+		return nil, fmt.Errorf("%s exceeds %d bytes", name, maxZipEntrySize)
+	}
+	return data, nil
+}
+
+// xmlAttr returns the value of an attribute by local name
+func xmlAttr(t xml.StartElement, local string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,178 @@
+package ingestion
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+// buildZip creates an in-memory archive from name -> content
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const corePropsXML = `<?xml version="1.0"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <dc:title>Quarterly Report</dc:title>
+</cp:coreProperties>`
+
+func TestExtractDOCX(t *testing.T) {
+	document := `<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Overview</w:t></w:r></w:p>
+    <w:p><w:r><w:t xml:space="preserve">Revenue grew </w:t></w:r><w:r><w:t>12%.</w:t></w:r></w:p>
+    <w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>First point</w:t></w:r></w:p>
+    <w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Nested point</w:t></w:r></w:p>
+    <w:tbl>
+      <w:tr><w:tc><w:p><w:r><w:t>Region</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Sales</w:t></w:r></w:p></w:tc></w:tr>
+      <w:tr><w:tc><w:p><w:r><w:t>EMEA</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>42</w:t></w:r></w:p></w:tc></w:tr>
+    </w:tbl>
+  </w:body>
+</w:document>`
+
+	data := buildZip(t, map[string]string{
+		"word/document.xml": document,
+		"docProps/core.xml": corePropsXML,
+	})
+
+	doc, err := ExtractDOCX(context.Background(), data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if doc.Title != "Quarterly Report" {
+		t.Errorf("expected core title, got %q", doc.Title)
+	}
+
+	want := "# Overview\n\nRevenue grew 12%.\n\n- First point\n  - Nested point\n\n" +
+		"| Region | Sales |\n| --- | --- |\n| EMEA | 42 |"
+	if got := doc.Text(); got != want {
+		t.Errorf("unexpected content:\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestExtractPPTX(t *testing.T) {
+	slide := func(title, body string) string {
+		return `<?xml version="1.0"?>
+<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>` + title + `</a:t></a:r></a:p></p:txBody></p:sp>
+    <p:sp><p:nvSpPr><p:nvPr><p:ph idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>` + body + `</a:t></a:r></a:p></p:txBody></p:sp>
+  </p:spTree></p:cSld>
+</p:sld>`
+	}
+
+	// Presentation order differs from file name order
+	data := buildZip(t, map[string]string{
+		"ppt/presentation.xml": `<?xml version="1.0"?>
+<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <p:sldIdLst><p:sldId id="256" r:id="rId3"/><p:sldId id="257" r:id="rId2"/></p:sldIdLst>
+</p:presentation>`,
+		"ppt/_rels/presentation.xml.rels": `<?xml version="1.0"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>
+  <Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide2.xml"/>
+</Relationships>`,
+		"ppt/slides/slide1.xml": slide("Results", "Revenue up"),
+		"ppt/slides/slide2.xml": slide("Agenda", "Intro and results"),
+		"ppt/slides/_rels/slide2.xml.rels": `<?xml version="1.0"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/>
+</Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<?xml version="1.0"?>
+<p:notes xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+  <p:cSld><p:spTree>
+    <p:sp><p:nvSpPr><p:nvPr><p:ph type="sldImg"/></p:nvPr></p:nvSpPr></p:sp>
+    <p:sp><p:nvSpPr><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>Keep it short</a:t></a:r></a:p></p:txBody></p:sp>
+  </p:spTree></p:cSld>
+</p:notes>`,
+	})
+
+	doc, err := ExtractPPTX(context.Background(), data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(doc.Sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(doc.Sections))
+	}
+	if doc.Title != "Agenda" {
+		t.Errorf("expected first slide title as document title, got %q", doc.Title)
+	}
+	if doc.Metadata["slide_count"] != "2" {
+		t.Errorf("expected slide_count 2, got %q", doc.Metadata["slide_count"])
+	}
+
+	first := doc.Sections[0]
+	if first.Metadata["slide_number"] != "1" || first.Metadata["slide_title"] != "Agenda" {
+		t.Errorf("unexpected first slide metadata: %v", first.Metadata)
+	}
+	want := "## Slide 1: Agenda\n\nIntro and results\n\nNotes: Keep it short"
+	if first.Content != want {
+		t.Errorf("unexpected first slide content:\n%s\n\nwant:\n%s", first.Content, want)
+	}
+
+	// Slide metadata reaches every chunk of the slide
+	result, err := NewPipelineWithDefaults().ProcessSections(context.Background(), doc.Sections, nil)
+	if err != nil {
+		t.Fatalf("unexpected pipeline error: %v", err)
+	}
+	for i, chunk := range result.Chunks {
+		if chunk.Index != i {
+			t.Errorf("expected sequential chunk indexes, got %d at %d", chunk.Index, i)
+		}
+		if chunk.Metadata["slide_number"] == "" {
+			t.Errorf("chunk %d is missing slide_number", i)
+		}
+	}
+	if last := result.Chunks[len(result.Chunks)-1]; last.Metadata["slide_number"] != "2" {
+		t.Errorf("expected last chunk from slide 2, got %q", last.Metadata["slide_number"])
+	}
+}
+
+func TestExtractorRegistry_Lookup(t *testing.T) {
+	registry := DefaultExtractors()
+
+	tests := []struct {
+		contentType string
+		filename    string
+		want        string
+	}{
+		{"", "deck.PPTX", ContentTypePPTX},
+		{"application/octet-stream", "report.docx", ContentTypeDOCX},
+		{"text/html; charset=utf-8", "page", ContentTypeHTML},
+		{"", "notes", ContentTypeText}, // sniffed
+	}
+	for _, tt := range tests {
+		_, got, err := registry.Lookup(tt.contentType, tt.filename, []byte("plain words"))
+		if err != nil {
+			t.Errorf("Lookup(%q, %q) returned error: %v", tt.contentType, tt.filename, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Lookup(%q, %q) = %q, want %q", tt.contentType, tt.filename, got, tt.want)
+		}
+	}
+
+	if _, _, err := registry.Lookup("application/x-unknown", "file.bin", nil); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
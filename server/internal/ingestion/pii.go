@@ -0,0 +1,77 @@
+package ingestion
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/knoguchi/rag/internal/guardrail"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// PII policy modes
+const (
+	PIIModeOff    = ""
+	PIIModeTag    = "tag"
+	PIIModeRedact = "redact"
+)
+
+// Metadata keys set by PII detection
+const (
+	MetadataPIITypes = "pii_types" // comma-separated kinds, sorted
+	MetadataPIICount = "pii_count"
+)
+
+// ValidatePIIConfig checks a PII policy's mode and kinds
+func ValidatePIIConfig(config repository.PIIConfig) error {
+	switch config.Mode {
+	case PIIModeOff, PIIModeTag, PIIModeRedact:
+	default:
+		return fmt.Errorf("pii mode must be %q or %q, got %q", PIIModeTag, PIIModeRedact, config.Mode)
+	}
+	for _, kind := range config.Kinds {
+		if !slices.Contains(guardrail.PIIKinds, kind) {
+			return fmt.Errorf("unknown pii kind %q (want one of %s)", kind, strings.Join(guardrail.PIIKinds, ", "))
+		}
+	}
+	return nil
+}
+
+// ApplyPIIPolicy detects the policy's kinds of PII in each chunk, recording
+// them in the chunk metadata and, in redact mode, replacing them with
+// placeholders
+func ApplyPIIPolicy(chunks []Chunk, policy repository.PIIConfig) {
+	if policy.Mode == PIIModeOff {
+		return
+	}
+	for i := range chunks {
+		var matches []guardrail.PIIMatch
+		for _, m := range guardrail.FindPII(chunks[i].Content) {
+			if len(policy.Kinds) == 0 || slices.Contains(policy.Kinds, m.Kind) {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		kinds := make([]string, 0, len(matches))
+		for _, m := range matches {
+			if !slices.Contains(kinds, m.Kind) {
+				kinds = append(kinds, m.Kind)
+			}
+		}
+		sort.Strings(kinds)
+		if chunks[i].Metadata == nil {
+			chunks[i].Metadata = make(map[string]string)
+		}
+		chunks[i].Metadata[MetadataPIITypes] = strings.Join(kinds, ",")
+		chunks[i].Metadata[MetadataPIICount] = strconv.Itoa(len(matches))
+
+		if policy.Mode == PIIModeRedact {
+			chunks[i].Content = guardrail.RedactMatches(chunks[i].Content, matches)
+		}
+	}
+}
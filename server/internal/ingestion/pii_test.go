@@ -0,0 +1,73 @@
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestApplyPIIPolicy(t *testing.T) {
+	content := "Reach me at jane@example.com or (555) 123-4567. SSN 123-45-6789."
+
+	tests := []struct {
+		name        string
+		policy      repository.PIIConfig
+		wantContent string
+		wantTypes   string
+		wantCount   string
+	}{
+		{
+			name:        "off",
+			policy:      repository.PIIConfig{},
+			wantContent: content,
+		},
+		{
+			name:        "tag",
+			policy:      repository.PIIConfig{Mode: PIIModeTag},
+			wantContent: content,
+			wantTypes:   "email,phone,ssn",
+			wantCount:   "3",
+		},
+		{
+			name:        "redact",
+			policy:      repository.PIIConfig{Mode: PIIModeRedact},
+			wantContent: "Reach me at [EMAIL] or [PHONE]. SSN [SSN].",
+			wantTypes:   "email,phone,ssn",
+			wantCount:   "3",
+		},
+		{
+			name:        "redact selected kinds",
+			policy:      repository.PIIConfig{Mode: PIIModeRedact, Kinds: []string{"ssn"}},
+			wantContent: "Reach me at jane@example.com or (555) 123-4567. SSN [SSN].",
+			wantTypes:   "ssn",
+			wantCount:   "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := []Chunk{{Content: content}}
+			ApplyPIIPolicy(chunks, tt.policy)
+			if chunks[0].Content != tt.wantContent {
+				t.Errorf("content = %q, want %q", chunks[0].Content, tt.wantContent)
+			}
+			if got := chunks[0].Metadata[MetadataPIITypes]; got != tt.wantTypes {
+				t.Errorf("%s = %q, want %q", MetadataPIITypes, got, tt.wantTypes)
+			}
+			if got := chunks[0].Metadata[MetadataPIICount]; got != tt.wantCount {
+				t.Errorf("%s = %q, want %q", MetadataPIICount, got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestValidatePIIConfig(t *testing.T) {
+	if err := ValidatePIIConfig(repository.PIIConfig{Mode: PIIModeTag, Kinds: []string{"email", "credit_card"}}); err != nil {
+		t.Errorf("valid config rejected: %v", err)
+	}
+	if err := ValidatePIIConfig(repository.PIIConfig{Mode: "mask"}); err == nil {
+		t.Error("unknown mode accepted")
+	}
+	if err := ValidatePIIConfig(repository.PIIConfig{Mode: PIIModeRedact, Kinds: []string{"passport"}}); err == nil {
+		t.Error("unknown kind accepted")
+	}
+}
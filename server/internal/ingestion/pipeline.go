@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/knoguchi/rag/internal/repository"
@@ -19,6 +21,9 @@ type PipelineConfig struct {
 
 	// Additional metadata to include in all chunks
 	DefaultMetadata map[string]string
+
+	// PII detection applied to every chunk (off by default)
+	PII repository.PIIConfig
 }
 
 // PipelineResult holds the result of processing content through the pipeline
@@ -57,10 +62,12 @@ type PipelineStats struct {
 	ProcessingTime time.Duration
 }
 
-// Pipeline orchestrates the ingestion process
+// Pipeline orchestrates the ingestion process as a series of stages, see
+// Phase. It starts with the built-in stages; AddStage adds custom ones.
 type Pipeline struct {
 	config  PipelineConfig
 	chunker *Chunker
+	stages  []Stage
 }
 
 // NewPipeline creates a new ingestion pipeline
@@ -68,6 +75,7 @@ func NewPipeline(config PipelineConfig) *Pipeline {
 	return &Pipeline{
 		config:  config,
 		chunker: NewChunker(config.Chunker),
+		stages:  builtinStages(),
 	}
 }
 
@@ -85,70 +93,29 @@ func NewPipelineWithDefaults() *Pipeline {
 
 // Process takes content and processes it through the ingestion pipeline
 func (p *Pipeline) Process(ctx context.Context, content string) (*PipelineResult, error) {
-	startTime := time.Now()
-
-	// Validate input
-	content = strings.TrimSpace(content)
-	if content == "" {
-		return nil, fmt.Errorf("content cannot be empty")
-	}
-
-	// Check for context cancellation
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	default:
-	}
-
-	// Generate document ID and content hash
-	documentID := uuid.New()
-	contentHash := hashContent(content)
-
-	// Perform chunking
-	chunks := p.chunker.Chunk(content)
-
-	// Add default metadata to all chunks
-	if p.config.DefaultMetadata != nil {
-		for i := range chunks {
-			if chunks[i].Metadata == nil {
-				chunks[i].Metadata = make(map[string]string)
-			}
-			for k, v := range p.config.DefaultMetadata {
-				// Don't override existing metadata
-				if _, exists := chunks[i].Metadata[k]; !exists {
-					chunks[i].Metadata[k] = v
-				}
-			}
-		}
-	}
-
-	// Add document reference to all chunks
-	for i := range chunks {
-		if chunks[i].Metadata == nil {
-			chunks[i].Metadata = make(map[string]string)
-		}
-		chunks[i].Metadata["document_id"] = documentID.String()
-		chunks[i].Metadata["content_hash"] = contentHash
-	}
-
-	// Calculate statistics
-	processingTime := time.Since(startTime)
-	stats := p.calculateStats(content, chunks, processingTime)
-
-	return &PipelineResult{
-		DocumentID:  documentID,
-		ContentHash: contentHash,
-		Chunks:      chunks,
-		Stats:       stats,
-	}, nil
+	return p.ProcessSections(ctx, []Section{{Content: content}}, nil)
 }
 
 // ProcessWithMetadata processes content with additional metadata
+// (priority: chunk metadata > provided metadata > default metadata)
 func (p *Pipeline) ProcessWithMetadata(ctx context.Context, content string, metadata map[string]string) (*PipelineResult, error) {
+	return p.ProcessSections(ctx, []Section{{Content: content}}, metadata)
+}
+
+// ProcessSections runs a document made of sections through the pipeline's
+// stages. The built-in ones chunk each section separately, so no chunk spans
+// two sections (e.g. two slides), and table sections by rows. Metadata
+// priority is chunk > section > provided > default.
+func (p *Pipeline) ProcessSections(ctx context.Context, sections []Section, metadata map[string]string) (*PipelineResult, error) {
 	startTime := time.Now()
 
-	// Validate input
-	content = strings.TrimSpace(content)
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if content := strings.TrimSpace(section.Content); content != "" {
+			parts = append(parts, content)
+		}
+	}
+	content := strings.Join(parts, "\n\n")
 	if content == "" {
 		return nil, fmt.Errorf("content cannot be empty")
 	}
@@ -160,50 +127,24 @@ func (p *Pipeline) ProcessWithMetadata(ctx context.Context, content string, meta
 	default:
 	}
 
-	// Generate document ID and content hash
-	documentID := uuid.New()
-	contentHash := hashContent(content)
-
-	// Perform chunking
-	chunks := p.chunker.Chunk(content)
-
-	// Merge metadata sources (priority: chunk metadata > provided metadata > default metadata)
-	for i := range chunks {
-		if chunks[i].Metadata == nil {
-			chunks[i].Metadata = make(map[string]string)
-		}
-
-		// Add default metadata first (lowest priority)
-		if p.config.DefaultMetadata != nil {
-			for k, v := range p.config.DefaultMetadata {
-				if _, exists := chunks[i].Metadata[k]; !exists {
-					chunks[i].Metadata[k] = v
-				}
-			}
-		}
-
-		// Add provided metadata (middle priority)
-		if metadata != nil {
-			for k, v := range metadata {
-				if _, exists := chunks[i].Metadata[k]; !exists {
-					chunks[i].Metadata[k] = v
-				}
-			}
-		}
-
-		// Add document reference (always added)
-		chunks[i].Metadata["document_id"] = documentID.String()
-		chunks[i].Metadata["content_hash"] = contentHash
+	job := &Job{
+		DocumentID:  uuid.New(),
+		ContentHash: hashContent(content),
+		Sections:    sections,
+		Metadata:    metadata,
+		pipeline:    p,
+	}
+	if err := p.runStages(ctx, job); err != nil {
+		return nil, err
 	}
 
-	// Calculate statistics
 	processingTime := time.Since(startTime)
-	stats := p.calculateStats(content, chunks, processingTime)
+	stats := p.calculateStats(content, job.Chunks, processingTime)
 
 	return &PipelineResult{
-		DocumentID:  documentID,
-		ContentHash: contentHash,
-		Chunks:      chunks,
+		DocumentID:  job.DocumentID,
+		ContentHash: job.ContentHash,
+		Chunks:      job.Chunks,
 		Stats:       stats,
 	}, nil
 }
@@ -233,52 +174,15 @@ func (p *Pipeline) ProcessBatch(ctx context.Context, contents []string) ([]*Pipe
 	return results, nil
 }
 
-// Rechunk allows reprocessing with a different chunking configuration
+// Rechunk allows reprocessing with a different chunking configuration,
+// through the same stages but without the default metadata
 func (p *Pipeline) Rechunk(ctx context.Context, content string, chunkerConfig repository.ChunkerConfig) (*PipelineResult, error) {
-	// Create a temporary chunker with the new config
-	tempChunker := NewChunker(chunkerConfig)
-
-	startTime := time.Now()
-
-	// Validate input
-	content = strings.TrimSpace(content)
-	if content == "" {
-		return nil, fmt.Errorf("content cannot be empty")
-	}
-
-	// Check for context cancellation
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	default:
+	rechunker := &Pipeline{
+		config:  PipelineConfig{Chunker: chunkerConfig, PII: p.config.PII},
+		chunker: NewChunker(chunkerConfig),
+		stages:  slices.Clone(p.stages),
 	}
-
-	// Generate document ID and content hash
-	documentID := uuid.New()
-	contentHash := hashContent(content)
-
-	// Perform chunking with temporary chunker
-	chunks := tempChunker.Chunk(content)
-
-	// Add document reference to all chunks
-	for i := range chunks {
-		if chunks[i].Metadata == nil {
-			chunks[i].Metadata = make(map[string]string)
-		}
-		chunks[i].Metadata["document_id"] = documentID.String()
-		chunks[i].Metadata["content_hash"] = contentHash
-	}
-
-	// Calculate statistics
-	processingTime := time.Since(startTime)
-	stats := p.calculateStats(content, chunks, processingTime)
-
-	return &PipelineResult{
-		DocumentID:  documentID,
-		ContentHash: contentHash,
-		Chunks:      chunks,
-		Stats:       stats,
-	}, nil
+	return rechunker.Process(ctx, content)
 }
 
 // GetConfig returns the current pipeline configuration
@@ -294,11 +198,11 @@ func (p *Pipeline) UpdateConfig(config PipelineConfig) {
 
 // calculateStats computes statistics for the pipeline result
 func (p *Pipeline) calculateStats(content string, chunks []Chunk, processingTime time.Duration) PipelineStats {
-	originalWords := len(strings.Fields(content))
+	originalWords := CountWords(content)
 
 	totalChunkWords := 0
 	for _, chunk := range chunks {
-		totalChunkWords += len(strings.Fields(chunk.Content))
+		totalChunkWords += CountWords(chunk.Content)
 	}
 
 	avgChunkWords := 0
@@ -325,12 +229,14 @@ func hashContent(content string) string {
 // ChunkToDocumentChunk converts a Chunk to a DocumentChunk for storage
 func ChunkToDocumentChunk(chunk Chunk, documentID uuid.UUID) *repository.DocumentChunk {
 	return &repository.DocumentChunk{
-		ID:         uuid.New(),
-		DocumentID: documentID,
-		ChunkIndex: chunk.Index,
-		Content:    chunk.Content,
-		Metadata:   chunk.Metadata,
-		CreatedAt:  time.Now(),
+		ID:          uuid.New(),
+		DocumentID:  documentID,
+		ChunkIndex:  chunk.Index,
+		Content:     chunk.Content,
+		Metadata:    chunk.Metadata,
+		ParentStart: chunk.ParentStart,
+		ParentEnd:   chunk.ParentEnd,
+		CreatedAt:   time.Now(),
 	}
 }
 
@@ -341,28 +247,47 @@ func ChunksToDocumentChunks(chunks []Chunk, documentID uuid.UUID) []*repository.
 
 	for i, chunk := range chunks {
 		docChunks[i] = &repository.DocumentChunk{
-			ID:         uuid.New(),
-			DocumentID: documentID,
-			ChunkIndex: chunk.Index,
-			Content:    chunk.Content,
-			Metadata:   chunk.Metadata,
-			CreatedAt:  now,
+			ID:          uuid.New(),
+			DocumentID:  documentID,
+			ChunkIndex:  chunk.Index,
+			Content:     chunk.Content,
+			Metadata:    chunk.Metadata,
+			ParentStart: chunk.ParentStart,
+			ParentEnd:   chunk.ParentEnd,
+			CreatedAt:   now,
 		}
 	}
 
 	return docChunks
 }
 
+// maxChunkerAbbreviations caps the abbreviations a chunker config adds
+const maxChunkerAbbreviations = 200
+
+// ValidateAbbreviations checks a chunker config's extra abbreviations
+func ValidateAbbreviations(abbreviations []string) error {
+	if len(abbreviations) > maxChunkerAbbreviations {
+		return fmt.Errorf("at most %d abbreviations are allowed", maxChunkerAbbreviations)
+	}
+	for _, abbr := range abbreviations {
+		if strings.TrimSpace(abbr) == "" || strings.ContainsFunc(abbr, unicode.IsSpace) {
+			return fmt.Errorf("abbreviation %q must be one word", abbr)
+		}
+	}
+	return nil
+}
+
 // ValidateChunkerConfig validates a chunker configuration
 func ValidateChunkerConfig(config repository.ChunkerConfig) error {
 	validMethods := map[string]bool{
 		"fixed":    true,
 		"semantic": true,
 		"sentence": true,
+		"tabular":  true,
 	}
 
 	if config.Method != "" && !validMethods[config.Method] {
-		return fmt.Errorf("invalid chunking method: %s (valid: fixed, semantic, sentence)", config.Method)
+		return fmt.Errorf("invalid chunking method: %s (valid: fixed, semantic, sentence, tabular)", config.Method)
 	}
 
 	if config.TargetSize < 0 {
@@ -381,6 +306,34 @@ func ValidateChunkerConfig(config repository.ChunkerConfig) error {
 		return fmt.Errorf("overlap cannot be negative")
 	}
 
+	if config.OverlapPercent < 0 || config.OverlapPercent > MaxOverlapPercent {
+		return fmt.Errorf("overlap_percent must be between 0 and %d", MaxOverlapPercent)
+	}
+
+	if config.TableFormat != "" && config.TableFormat != TableFormatMarkdown && config.TableFormat != TableFormatKeyValue {
+		return fmt.Errorf("invalid table_format: %s (valid: %s, %s)", config.TableFormat, TableFormatMarkdown, TableFormatKeyValue)
+	}
+
+	if config.Overlap > 0 && config.OverlapPercent > 0 {
+		return fmt.Errorf("set overlap or overlap_percent, not both")
+	}
+
+	if config.RowsPerChunk < 0 {
+		return fmt.Errorf("rows_per_chunk cannot be negative")
+	}
+
+	if config.Language != "" && (len(config.Language) != 2 || strings.ToLower(config.Language) != config.Language) {
+		return fmt.Errorf("language %q must be a lowercase ISO 639-1 code", config.Language)
+	}
+
+	if err := ValidateAbbreviations(config.Abbreviations); err != nil {
+		return err
+	}
+
+	if err := ValidateContextTemplate(config.ContextTemplate); err != nil {
+		return err
+	}
+
 	if config.Overlap > 0 && config.TargetSize > 0 && config.Overlap >= config.TargetSize {
 		return fmt.Errorf("overlap (%d) must be less than target_size (%d)", config.Overlap, config.TargetSize)
 	}
@@ -0,0 +1,527 @@
+package ingestion
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLContent is the main content extracted from an HTML page
+type HTMLContent struct {
+	// Title is the page title (<title>, falling back to the first <h1>)
+	Title string
+
+	// Canonical is the href of the page's <link rel="canonical">, as written
+	Canonical string
+
+	// Text is the extracted main content as plain text, one block per paragraph
+	Text string
+
+	// Markdown is the extracted main content with headings, lists, tables and
+	// code blocks preserved
+	Markdown string
+
+	// Quality describes how the content was selected
+	Quality ExtractionQuality
+
+	// Images are the images in the main content, for optional captioning
+	Images []ImageRef
+
+	// Structured is what the page declares about itself in JSON-LD,
+	// OpenGraph and <meta> tags
+	Structured PageMetadata
+}
+
+// ExtractionQuality holds signals about how reliable an extraction is
+type ExtractionQuality struct {
+	// Method is "readability" when a main content node was found, "body" when the
+	// whole (cleaned) body was used
+	Method string
+
+	// Score is the content score of the selected node
+	Score float64
+
+	// TextLength is the character length of the extracted text
+	TextLength int
+
+	// TextRatio is the extracted text length over the page's visible text length
+	TextRatio float64
+
+	// LinkDensity is the fraction of extracted text that is inside links
+	LinkDensity float64
+}
+
+// Metadata returns the quality signals as document metadata
+func (q ExtractionQuality) Metadata() map[string]string {
+	return map[string]string{
+		"extraction_method":       q.Method,
+		"extraction_score":        strconv.FormatFloat(q.Score, 'f', 1, 64),
+		"extraction_text_length":  strconv.Itoa(q.TextLength),
+		"extraction_text_ratio":   strconv.FormatFloat(q.TextRatio, 'f', 2, 64),
+		"extraction_link_density": strconv.FormatFloat(q.LinkDensity, 'f', 2, 64),
+	}
+}
+
+var (
+	// unlikelyCandidates matches class/id values of boilerplate containers
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|consent|cookie|disqus|extra|foot|gdpr|header|legends|menu|modal|nav|newsletter|pager|pagination|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|toolbar|widget|ad-break|advert`)
+
+	// maybeCandidate rescues unlikely matches that still look like content
+	maybeCandidate = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+
+	positiveWeight = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story|documentation`)
+	negativeWeight = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|cookie|consent|foot|footer|footnote|gdpr|masthead|media|meta|modal|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget|advert`)
+
+	// unlikelyRoles are ARIA roles that never hold main content
+	unlikelyRoles = map[string]bool{
+		"navigation": true, "banner": true, "contentinfo": true, "complementary": true,
+		"dialog": true, "alertdialog": true, "menu": true, "menubar": true, "search": true,
+	}
+)
+
+// minContentLength is the text length below which the readability pick is
+// considered a failure and the whole body is used instead
+const minContentLength = 250
+
+// ExtractHTML extracts the main content of an HTML page, removing navigation,
+// sidebars, footers, cookie banners and other boilerplate.
+//
+// It follows the readability approach: paragraphs score their parent and
+// grandparent by length and comma count, scores are weighted by class/id hints
+// and penalised by link density, and the best-scoring node (plus related
+// siblings) becomes the content.
+func ExtractHTML(content string) (*HTMLContent, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	result := &HTMLContent{Title: documentTitle(doc), Canonical: canonicalLink(doc), Structured: pageMetadata(doc)}
+
+	body := findElement(doc, atom.Body)
+	if body == nil {
+		body = doc
+	}
+
+	removeBoilerplate(body)
+	pageTextLength := len(collapseSpace(textContent(body)))
+
+	nodes, score := selectContent(body)
+	method := "readability"
+	if nodes == nil {
+		nodes = []*html.Node{body}
+		method = "body"
+	}
+
+	var sb strings.Builder
+	var linkLength, textLength int
+	for _, n := range nodes {
+		renderText(n, &sb)
+		linkLength += linkTextLength(n)
+		textLength += len(collapseSpace(textContent(n)))
+	}
+	result.Text = normalizeBlocks(sb.String())
+	result.Markdown = renderMarkdown(nodes...)
+	result.Images = contentImages(nodes)
+
+	if result.Title == "" {
+		result.Title = result.Structured.Title
+	}
+	if result.Title == "" {
+		if h1 := findElement(body, atom.H1); h1 != nil {
+			result.Title = collapseSpace(textContent(h1))
+		}
+	}
+
+	result.Quality = ExtractionQuality{
+		Method:     method,
+		Score:      math.Round(score*10) / 10,
+		TextLength: len(result.Text),
+	}
+	if pageTextLength > 0 {
+		result.Quality.TextRatio = math.Min(1, float64(textLength)/float64(pageTextLength))
+	}
+	if textLength > 0 {
+		result.Quality.LinkDensity = float64(linkLength) / float64(textLength)
+	}
+
+	return result, nil
+}
+
+// documentTitle returns the text of the <title> element
+func documentTitle(doc *html.Node) string {
+	if n := findElement(doc, atom.Title); n != nil {
+		return collapseSpace(textContent(n))
+	}
+	return ""
+}
+
+// canonicalLink returns the href of the first <link rel="canonical">
+func canonicalLink(doc *html.Node) string {
+	var href string
+	walk(doc, func(n *html.Node) bool {
+		if href != "" {
+			return false
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Link {
+			rel, _ := attr(n, "rel")
+			for _, token := range strings.Fields(rel) {
+				if strings.EqualFold(token, "canonical") {
+					href, _ = attr(n, "href")
+					break
+				}
+			}
+			return false
+		}
+		return n.DataAtom != atom.Body
+	})
+	return strings.TrimSpace(href)
+}
+
+// removeBoilerplate strips elements that never contribute to main content
+func removeBoilerplate(root *html.Node) {
+	var remove []*html.Node
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			remove = append(remove, n)
+			return false
+		}
+		if n.Type != html.ElementNode || n == root {
+			return true
+		}
+		if isBoilerplate(n) {
+			remove = append(remove, n)
+			return false
+		}
+		return true
+	})
+	for _, n := range remove {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+// isBoilerplate reports whether an element should be dropped before scoring
+func isBoilerplate(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Iframe, atom.Svg, atom.Canvas,
+		atom.Form, atom.Button, atom.Input, atom.Select, atom.Textarea, atom.Template,
+		atom.Nav, atom.Aside, atom.Footer, atom.Object, atom.Embed:
+		return true
+	case atom.Header:
+		// Article headers carry the headline; page headers carry navigation
+		return !hasAncestor(n, atom.Article, atom.Main)
+	case atom.Body, atom.Main, atom.Article:
+		return false
+	}
+
+	if _, ok := attr(n, "hidden"); ok {
+		return true
+	}
+	if v, _ := attr(n, "aria-hidden"); v == "true" {
+		return true
+	}
+	if style, _ := attr(n, "style"); strings.Contains(strings.ReplaceAll(style, " ", ""), "display:none") {
+		return true
+	}
+	if role, _ := attr(n, "role"); unlikelyRoles[strings.ToLower(role)] {
+		return true
+	}
+
+	hint := classAndID(n)
+	if hint != "" && unlikelyCandidates.MatchString(hint) && !maybeCandidate.MatchString(hint) {
+		return true
+	}
+	return false
+}
+
+// selectContent scores the tree and returns the best content node and siblings
+// that belong with it, or nil if nothing scored well enough
+func selectContent(body *html.Node) ([]*html.Node, float64) {
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+
+	initialize := func(n *html.Node) {
+		if _, ok := scores[n]; ok {
+			return
+		}
+		scores[n] = tagWeight(n) + classWeight(n)
+		candidates = append(candidates, n)
+	}
+
+	walk(body, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.Td, atom.Blockquote, atom.Li, atom.Dd:
+		default:
+			return true
+		}
+
+		text := collapseSpace(textContent(n))
+		if len(text) < 25 {
+			return false
+		}
+
+		// One point for the paragraph, one per comma, one per 100 chars (max 3)
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text)/100), 3)
+
+		if parent := n.Parent; parent != nil && parent.Type == html.ElementNode {
+			initialize(parent)
+			scores[parent] += score
+			if grand := parent.Parent; grand != nil && grand.Type == html.ElementNode {
+				initialize(grand)
+				scores[grand] += score / 2
+			}
+		}
+		return false
+	})
+
+	var top *html.Node
+	var topScore float64
+	for _, c := range candidates {
+		final := scores[c] * (1 - linkDensity(c))
+		scores[c] = final
+		if top == nil || final > topScore {
+			top, topScore = c, final
+		}
+	}
+	if top == nil || len(collapseSpace(textContent(top))) < minContentLength {
+		return nil, 0
+	}
+
+	// Include siblings that look like part of the same content
+	if top.Parent == nil || top.DataAtom == atom.Body {
+		return []*html.Node{top}, topScore
+	}
+	threshold := math.Max(10, topScore*0.2)
+	topHint := classAttr(top)
+
+	var nodes []*html.Node
+	for sib := top.Parent.FirstChild; sib != nil; sib = sib.NextSibling {
+		if sib == top {
+			nodes = append(nodes, sib)
+			continue
+		}
+		if sib.Type != html.ElementNode {
+			continue
+		}
+
+		bonus := 0.0
+		if topHint != "" && classAttr(sib) == topHint {
+			bonus = topScore * 0.2
+		}
+		if s, ok := scores[sib]; ok && s+bonus >= threshold {
+			nodes = append(nodes, sib)
+			continue
+		}
+		if sib.DataAtom == atom.P {
+			text := collapseSpace(textContent(sib))
+			density := linkDensity(sib)
+			if (len(text) > 80 && density < 0.25) ||
+				(len(text) > 0 && density == 0 && strings.ContainsAny(text, ".!?")) {
+				nodes = append(nodes, sib)
+			}
+		}
+	}
+	return nodes, topScore
+}
+
+// tagWeight is the readability starting score for a candidate element
+func tagWeight(n *html.Node) float64 {
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		return 10
+	case atom.Div:
+		return 5
+	case atom.Pre, atom.Td, atom.Blockquote, atom.Section:
+		return 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li:
+		return -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		return -5
+	}
+	return 0
+}
+
+// classWeight rewards content-like and penalises boilerplate-like class/id values
+func classWeight(n *html.Node) float64 {
+	weight := 0.0
+	for _, v := range []string{classAttr(n), idAttr(n)} {
+		if v == "" {
+			continue
+		}
+		if negativeWeight.MatchString(v) {
+			weight -= 25
+		}
+		if positiveWeight.MatchString(v) {
+			weight += 25
+		}
+	}
+	if role, _ := attr(n, "role"); role == "main" {
+		weight += 25
+	}
+	return weight
+}
+
+// linkDensity is the fraction of a node's text that is inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(collapseSpace(textContent(n)))
+	if total == 0 {
+		return 0
+	}
+	return float64(linkTextLength(n)) / float64(total)
+}
+
+// linkTextLength is the length of text inside <a> elements under n
+func linkTextLength(n *html.Node) int {
+	length := 0
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && c.DataAtom == atom.A {
+			length += len(collapseSpace(textContent(c)))
+			return false
+		}
+		return true
+	})
+	return length
+}
+
+// blockElements start a new line of text when rendered
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Blockquote: true, atom.Dd: true, atom.Div: true,
+	atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true, atom.H1: true,
+	atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Hr: true,
+	atom.Li: true, atom.Main: true, atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true,
+	atom.Table: true, atom.Tr: true, atom.Ul: true, atom.Header: true,
+}
+
+// renderText writes the visible text of n, separating block elements with blank lines
+func renderText(n *html.Node, sb *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		switch n.DataAtom {
+		case atom.Br:
+			sb.WriteString("\n")
+			return
+		case atom.Td, atom.Th:
+			sb.WriteString(" ")
+		}
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		sb.WriteString("\n\n")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderText(c, sb)
+	}
+	if block {
+		sb.WriteString("\n\n")
+	}
+}
+
+var (
+	inlineSpace = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines  = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// normalizeBlocks collapses whitespace within lines and separates blocks by one blank line
+func normalizeBlocks(text string) string {
+	text = inlineSpace.ReplaceAllString(text, " ")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// collapseSpace trims and collapses all whitespace runs to single spaces
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// textContent returns the concatenated text of all descendant text nodes
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+			sb.WriteString(" ")
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// walk visits n and its descendants depth-first; returning false skips children
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, visit)
+		c = next
+	}
+}
+
+// findElement returns the first element with the given tag
+func findElement(root *html.Node, tag atom.Atom) *html.Node {
+	var found *html.Node
+	walk(root, func(n *html.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n.Type == html.ElementNode && n.DataAtom == tag {
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// hasAncestor reports whether n is nested inside any of the given tags
+func hasAncestor(n *html.Node, tags ...atom.Atom) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		for _, tag := range tags {
+			if p.DataAtom == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func classAttr(n *html.Node) string {
+	v, _ := attr(n, "class")
+	return v
+}
+
+func idAttr(n *html.Node) string {
+	v, _ := attr(n, "id")
+	return v
+}
+
+func classAndID(n *html.Node) string {
+	return strings.TrimSpace(classAttr(n) + " " + idAttr(n))
+}
@@ -0,0 +1,77 @@
+package ingestion
+
+import (
+	"strings"
+	"testing"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html>
+<head><title>How Vector Search Works</title><link rel="Canonical" href=" /blog/vector-search "><style>body { color: red; }</style></head>
+<body>
+  <header><a href="/">Home</a> <a href="/blog">Blog</a> <a href="/about">About</a></header>
+  <nav><ul><li><a href="/a">Getting started</a></li><li><a href="/b">Reference</a></li></ul></nav>
+  <div id="cookie-banner">We use cookies to improve your experience. Accept all cookies?</div>
+  <div class="layout">
+    <aside class="sidebar"><p>Related posts you might enjoy, curated by our editors, updated daily.</p></aside>
+    <div class="post-content">
+      <h1>How Vector Search Works</h1>
+      <p>Vector search finds documents by comparing embeddings, which are dense numeric representations of text, images, or audio.</p>
+      <p>Each document is embedded once at ingestion time, and the query is embedded at search time, so the comparison is cheap.</p>
+      <p>Approximate nearest neighbour indexes, such as HNSW, trade a small amount of recall for large speedups on big collections.</p>
+      <script>trackPageView();</script>
+    </div>
+  </div>
+  <footer>Copyright 2024 Example Corp. All rights reserved. Terms, privacy, and imprint.</footer>
+</body>
+</html>`
+
+func TestExtractHTML_RemovesBoilerplate(t *testing.T) {
+	content, err := ExtractHTML(articlePage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if content.Title != "How Vector Search Works" {
+		t.Errorf("expected page title, got %q", content.Title)
+	}
+	if content.Canonical != "/blog/vector-search" {
+		t.Errorf("expected canonical link, got %q", content.Canonical)
+	}
+	if content.Quality.Method != "readability" {
+		t.Errorf("expected readability method, got %q", content.Quality.Method)
+	}
+
+	for _, want := range []string{"Vector search finds documents", "HNSW"} {
+		if !strings.Contains(content.Text, want) {
+			t.Errorf("expected text to contain %q, got:\n%s", want, content.Text)
+		}
+	}
+	for _, unwanted := range []string{"Getting started", "cookies", "Related posts", "Copyright", "trackPageView", "color: red"} {
+		if strings.Contains(content.Text, unwanted) {
+			t.Errorf("expected boilerplate %q to be removed, got:\n%s", unwanted, content.Text)
+		}
+	}
+
+	// Paragraphs stay separate blocks
+	if strings.Count(content.Text, "\n\n") < 3 {
+		t.Errorf("expected blank lines between blocks, got:\n%s", content.Text)
+	}
+}
+
+func TestExtractHTML_FallsBackToBody(t *testing.T) {
+	content, err := ExtractHTML(`<html><body><nav>Menu</nav><span>Short page</span></body></html>`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if content.Quality.Method != "body" {
+		t.Errorf("expected body fallback, got %q", content.Quality.Method)
+	}
+	if content.Text != "Short page" {
+		t.Errorf("expected cleaned body text, got %q", content.Text)
+	}
+	if content.Quality.Metadata()["extraction_method"] != "body" {
+		t.Errorf("expected extraction_method metadata")
+	}
+}
@@ -0,0 +1,181 @@
+package ingestion
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sentenceAbbreviations lists, by ISO 639-1 language, abbreviations whose
+// period does not end a sentence. Text in other languages uses English's.
+var sentenceAbbreviations = map[string][]string{
+	"en": {
+		"mr.", "mrs.", "ms.", "dr.", "prof.", "sr.", "jr.",
+		"inc.", "ltd.", "corp.", "co.",
+		"etc.", "e.g.", "i.e.", "cf.", "approx.",
+		"vs.", "v.",
+		"st.", "ave.", "blvd.", "mt.",
+		"no.", "vol.", "pg.", "pp.", "fig.", "eq.", "ch.", "sec.",
+		"jan.", "feb.", "mar.", "apr.", "jun.", "jul.", "aug.", "sep.", "sept.", "oct.", "nov.", "dec.",
+		"u.s.", "a.m.", "p.m.",
+	},
+	"de": {
+		"z.b.", "bzw.", "usw.", "ca.", "vgl.", "d.h.", "u.a.", "evtl.", "ggf.",
+		"inkl.", "bsp.", "nr.", "str.", "abs.", "hr.", "fr.", "dr.", "prof.",
+		"s.", "z.t.", "u.u.", "etc.",
+	},
+	"fr": {
+		"m.", "mme.", "mlle.", "dr.", "env.", "p.ex.", "etc.", "cf.", "av.",
+		"bd.", "n°.", "p.", "vol.",
+	},
+	"es": {
+		"sr.", "sra.", "srta.", "dr.", "dra.", "ud.", "uds.", "etc.", "pág.",
+		"p.ej.", "av.", "núm.", "vol.",
+	},
+}
+
+// SentenceSplitter splits text into sentences. It ends sentences at ".",
+// "!", "?" and ellipses followed by whitespace, and at CJK full stops, but
+// not after abbreviations, initials ("J. Smith") or list numbers ("1."),
+// inside decimals and URLs, or where the next sentence would start with a
+// lowercase letter. Closing quotes and brackets stay with their sentence.
+type SentenceSplitter struct {
+	abbreviations map[string]bool
+}
+
+// NewSentenceSplitter creates a splitter knowing the language's
+// abbreviations and extra ones, such as "approx." or a product's "v."
+func NewSentenceSplitter(language string, abbreviations []string) *SentenceSplitter {
+	list, ok := sentenceAbbreviations[strings.ToLower(language)]
+	if !ok {
+		list = sentenceAbbreviations["en"]
+	}
+	s := &SentenceSplitter{abbreviations: make(map[string]bool, len(list)+len(abbreviations))}
+	for _, abbr := range list {
+		s.abbreviations[abbr] = true
+	}
+	for _, abbr := range abbreviations {
+		abbr = strings.ToLower(strings.TrimSpace(abbr))
+		if abbr == "" {
+			continue
+		}
+		if !strings.HasSuffix(abbr, ".") {
+			abbr += "."
+		}
+		s.abbreviations[abbr] = true
+	}
+	return s
+}
+
+// defaultSentenceSplitter splits sentences of English text
+var defaultSentenceSplitter = NewSentenceSplitter("en", nil)
+
+// Split returns the sentences of text, trimmed.
+func (s *SentenceSplitter) Split(text string) []string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) == 0 {
+		return nil
+	}
+
+	var sentences []string
+	emit := func(from, to int) {
+		if sentence := strings.TrimSpace(string(runes[from:to])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if !isSentenceTerminal(runes[i]) {
+			continue
+		}
+		// Take in the whole run of terminals ("?!", "...") and the quotes
+		// and brackets closing the sentence
+		end := i
+		cjk := false
+		for end < len(runes) && isSentenceTerminal(runes[end]) {
+			cjk = cjk || isCJKTerminal(runes[end])
+			end++
+		}
+		terminals := string(runes[i:end])
+		closed := end
+		for end < len(runes) && isSentenceCloser(runes[end]) {
+			end++
+		}
+		// A quoted question goes on in 「明日も？」と聞いた
+		quoted := end > closed && end < len(runes) && unicode.IsLetter(runes[end])
+
+		if (cjk && !quoted) || (!cjk && s.endsSentence(runes, start, i, end, terminals)) {
+			emit(start, end)
+			start = end
+		}
+		i = end - 1
+	}
+	emit(start, len(runes))
+	return sentences
+}
+
+// endsSentence reports whether the terminals at runes[at:] (through end,
+// with closers) end the sentence begun at start
+func (s *SentenceSplitter) endsSentence(runes []rune, start, at, end int, terminals string) bool {
+	if end == len(runes) {
+		return true
+	}
+	// "3.14", "example.com" and "e.g.x" go on without a space
+	if !unicode.IsSpace(runes[end]) {
+		return false
+	}
+	next := end
+	for next < len(runes) && unicode.IsSpace(runes[next]) {
+		next++
+	}
+	nextLower := next < len(runes) && unicode.IsLower(runes[next])
+
+	switch terminals {
+	case ".":
+		word := lastWord(runes[start:at])
+		if s.abbreviations[strings.ToLower(word)+"."] {
+			return false
+		}
+		if r := []rune(word); len(r) == 1 && unicode.IsUpper(r[0]) {
+			return false // an initial
+		}
+		if isNumber(word) && strings.TrimSpace(string(runes[start:at])) == word {
+			return false // a numbered list item
+		}
+		return !nextLower
+	case "...", "…":
+		// An ellipsis ends a sentence only if a new one starts after it
+		return !nextLower
+	}
+	return true
+}
+
+// lastWord returns the word before a terminal, without opening quotes and brackets
+func lastWord(runes []rune) string {
+	i := len(runes)
+	for i > 0 && !unicode.IsSpace(runes[i-1]) {
+		i--
+	}
+	return strings.TrimLeftFunc(string(runes[i:]), func(r rune) bool {
+		return unicode.In(r, unicode.Ps, unicode.Pi) || r == '"' || r == '\''
+	})
+}
+
+func isNumber(word string) bool {
+	return word != "" && strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+func isSentenceTerminal(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…' || isCJKTerminal(r)
+}
+
+// isCJKTerminal reports whether r is a full stop of text written without
+// spaces, which ends a sentence without a space after it
+func isCJKTerminal(r rune) bool {
+	return r == '。' || r == '！' || r == '？' || r == '｡'
+}
+
+// isSentenceCloser reports whether r closes a quote or bracket
+func isSentenceCloser(r rune) bool {
+	return r == '"' || r == '\'' || unicode.In(r, unicode.Pe, unicode.Pf)
+}
@@ -0,0 +1,79 @@
+package ingestion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSentenceSplitter_Split(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		input    string
+		want     []string
+	}{
+		{
+			name:  "decimals and versions",
+			input: "Pi is 3.14 roughly. Upgrade to v2.1.3 today.",
+			want:  []string{"Pi is 3.14 roughly.", "Upgrade to v2.1.3 today."},
+		},
+		{
+			name:  "urls",
+			input: "See https://example.com/docs.html for details. Then restart.",
+			want:  []string{"See https://example.com/docs.html for details.", "Then restart."},
+		},
+		{
+			name:  "abbreviations and initials",
+			input: "Dr. Smith met J. R. Jones, e.g. at the first. Inc. was founded later.",
+			want:  []string{"Dr. Smith met J. R. Jones, e.g. at the first.", "Inc. was founded later."},
+		},
+		{
+			name:  "ellipses",
+			input: "Wait... what happened? It stopped… Then it restarted.",
+			want:  []string{"Wait... what happened?", "It stopped…", "Then it restarted."},
+		},
+		{
+			name:  "quotes and brackets",
+			input: `He said "Stop." Then he left. (It was late.) Done?!`,
+			want:  []string{`He said "Stop."`, "Then he left.", "(It was late.)", "Done?!"},
+		},
+		{
+			name:  "numbered list",
+			input: "1. Install the server. 2. Start it.",
+			want:  []string{"1. Install the server.", "2. Start it."},
+		},
+		{
+			name:  "lowercase continuation",
+			input: "The approx. value is 5. the rest follows.",
+			want:  []string{"The approx. value is 5. the rest follows."},
+		},
+		{
+			name:     "german abbreviations",
+			language: "de",
+			input:    "Das gilt z.B. für Verträge bzw. Angebote. Danach folgt mehr.",
+			want:     []string{"Das gilt z.B. für Verträge bzw. Angebote.", "Danach folgt mehr."},
+		},
+		{
+			name:  "japanese",
+			input: "東京は晴れです。「明日も？」と聞いた。",
+			want:  []string{"東京は晴れです。", "「明日も？」と聞いた。"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSentenceSplitter(tt.language, nil).Split(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentenceSplitter_ExtraAbbreviations(t *testing.T) {
+	s := NewSentenceSplitter("en", []string{"Rev"})
+	got := s.Split("Ask Rev. Green. He knows.")
+	want := []string{"Ask Rev. Green.", "He knows."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split = %q, want %q", got, want)
+	}
+}
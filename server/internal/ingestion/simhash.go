@@ -0,0 +1,61 @@
+package ingestion
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// simHashShingle is how many consecutive words make up one SimHash feature
+const simHashShingle = 3
+
+// SimHash returns a 64-bit fingerprint of text in which similar texts differ
+// in few bits. It hashes overlapping word shingles, lowercased, skipping
+// numbers so pages differing only in dates, counters or prices still match.
+// Text without words hashes to 0.
+func SimHash(text string) uint64 {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var kept []string
+	for _, field := range fields {
+		// CJK text has no spaces; Words splits it into characters
+		for _, w := range Words(field) {
+			if strings.IndexFunc(w, unicode.IsLetter) >= 0 {
+				kept = append(kept, w)
+			}
+		}
+	}
+	if len(kept) == 0 {
+		return 0
+	}
+
+	size := min(simHashShingle, len(kept))
+	var weights [64]int
+	for i := 0; i+size <= len(kept); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(kept[i:i+size], " ")))
+		sum := h.Sum64()
+		for bit := range weights {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance returns how many bits two fingerprints differ in
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,35 @@
+package ingestion
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimHash(t *testing.T) {
+	article := strings.Repeat("The quarterly report shows steady growth in every region we operate in. ", 3) +
+		"Revenue rose on strong demand for cloud services, while costs stayed flat. " +
+		"The board approved a new dividend and a share buyback program for next year."
+
+	// Only the date and a view counter differ
+	a := SimHash("Posted 2024-01-05, 1,203 views. " + article)
+	b := SimHash("Posted 2024-03-17, 98 views. " + article)
+	if d := HammingDistance(a, b); d != 0 {
+		t.Errorf("texts differing only in numbers are %d bits apart, want 0", d)
+	}
+
+	// A small edit stays close
+	c := SimHash(article + " Shares closed higher.")
+	if d := HammingDistance(a, c); d > 8 {
+		t.Errorf("lightly edited text is %d bits apart", d)
+	}
+
+	// Unrelated text is far
+	other := SimHash("Preheat the oven, whisk the eggs with sugar until pale, then fold in the flour and bake for twenty minutes.")
+	if d := HammingDistance(a, other); d < 16 {
+		t.Errorf("unrelated text is only %d bits apart", d)
+	}
+
+	if SimHash("12 34 -- 56") != 0 {
+		t.Error("text without words should hash to 0")
+	}
+}
@@ -0,0 +1,63 @@
+package ingestion
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// ContentTypeXHTML is HTML served as XML
+const ContentTypeXHTML = "application/xhtml+xml"
+
+// sniffLen is how much of the content is inspected, as http.DetectContentType does
+const sniffLen = 512
+
+// MediaType returns a Content-Type header's lowercased media type, without parameters
+func MediaType(contentType string) string {
+	return normalizeContentType(contentType)
+}
+
+// IsHTML reports whether a media type is an HTML page
+func IsHTML(mediaType string) bool {
+	return mediaType == ContentTypeHTML || mediaType == ContentTypeXHTML
+}
+
+// SniffContentType returns the media type of fetched content. The declared
+// Content-Type is trusted, except that a missing or generic one is replaced
+// by sniffing the content, and binary content declared as text is reported
+// as what it looks like, or as application/octet-stream.
+func SniffContentType(declared string, data []byte) string {
+	mediaType := normalizeContentType(declared)
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		mediaType = normalizeContentType(http.DetectContentType(data))
+	}
+	if isTextual(mediaType) && LooksBinary(data) {
+		mediaType = normalizeContentType(http.DetectContentType(data))
+		if isTextual(mediaType) {
+			mediaType = "application/octet-stream"
+		}
+	}
+	return mediaType
+}
+
+// LooksBinary reports whether content is not text: its first bytes hold a
+// NUL byte or are more than a tenth control characters. Text in any ASCII
+// compatible charset passes.
+func LooksBinary(data []byte) bool {
+	sample := data[:min(len(data), sniffLen)]
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	control := 0
+	for _, b := range sample {
+		if (b < 0x20 && b != '\t' && b != '\n' && b != '\r' && b != '\f' && b != 0x1b) || b == 0x7f {
+			control++
+		}
+	}
+	return control*10 > len(sample)
+}
+
+// isTextual reports whether a media type is text that extractors read as is
+func isTextual(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") || mediaType == ContentTypeXHTML
+}
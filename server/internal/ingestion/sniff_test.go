@@ -0,0 +1,43 @@
+package ingestion
+
+import "testing"
+
+func TestSniffContentType(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n1 0 obj\n")
+	zip := []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00")
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name     string
+		declared string
+		data     []byte
+		want     string
+	}{
+		{"declared html", "text/html; charset=utf-8", []byte("<p>hi</p>"), "text/html"},
+		{"declared pdf", "application/pdf", pdf, "application/pdf"},
+		{"missing type sniffed", "", []byte("<!DOCTYPE html><html><body>hi</body></html>"), "text/html"},
+		{"generic type sniffed", "application/octet-stream", pdf, "application/pdf"},
+		{"zip served as text", "text/plain", zip, "application/zip"},
+		{"image served as html", "text/html", png, "image/png"},
+		{"latin-1 text", "text/plain; charset=iso-8859-1", []byte("caf\xe9 cr\xe8me br\xfbl\xe9e"), "text/plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SniffContentType(tt.declared, tt.data); got != tt.want {
+				t.Errorf("SniffContentType(%q) = %q, want %q", tt.declared, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLooksBinary(t *testing.T) {
+	if LooksBinary([]byte("plain text\twith tabs\r\nand lines\n")) {
+		t.Error("text reported as binary")
+	}
+	if !LooksBinary([]byte("text with a \x00 byte")) {
+		t.Error("NUL byte not reported as binary")
+	}
+	if !LooksBinary([]byte("\x01\x02\x03\x04abc")) {
+		t.Error("control characters not reported as binary")
+	}
+}
@@ -0,0 +1,271 @@
+package ingestion
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExtractCSV extracts delimited text as a single table section
+func ExtractCSV(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	table, err := ParseCSV(string(data))
+	if err != nil {
+		return nil, err
+	}
+	return &ExtractedDocument{
+		Sections: []Section{{Content: table.Text(), Table: table}},
+		Metadata: map[string]string{
+			"format":    "csv",
+			"row_count": strconv.Itoa(len(table.Rows)),
+		},
+	}, nil
+}
+
+// ExtractXLSX extracts an Excel workbook as one table section per sheet, in
+// workbook order. The first non-empty row of each sheet is its header. Dates
+// stored as serial numbers are kept as numbers.
+func ExtractXLSX(_ context.Context, data []byte) (*ExtractedDocument, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open xlsx: %w", err)
+	}
+
+	sheets, err := workbookSheets(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read xlsx: %w", err)
+	}
+	sharedStrings, err := readSharedStrings(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shared strings: %w", err)
+	}
+
+	doc := &ExtractedDocument{
+		Title: coreTitle(zr),
+		Metadata: map[string]string{
+			"format":      "xlsx",
+			"sheet_count": strconv.Itoa(len(sheets)),
+		},
+	}
+
+	for _, sheet := range sheets {
+		xmlData, err := readZipEntry(zr, sheet.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %q: %w", sheet.name, err)
+		}
+		table, err := parseWorksheet(xmlData, sharedStrings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sheet %q: %w", sheet.name, err)
+		}
+		if table == nil {
+			continue
+		}
+		table.Name = sheet.name
+		doc.Sections = append(doc.Sections, Section{
+			Content:  table.Text(),
+			Metadata: map[string]string{"sheet": sheet.name},
+			Table:    table,
+		})
+	}
+
+	if len(doc.Sections) == 0 {
+		return nil, errors.New("workbook has no data")
+	}
+	return doc, nil
+}
+
+// workbookSheet is a sheet name and its part path
+type workbookSheet struct {
+	name string
+	path string
+}
+
+// workbookSheets resolves xl/workbook.xml's sheet list through its relationships
+func workbookSheets(zr *zip.Reader) ([]workbookSheet, error) {
+	workbook, err := readZipEntry(zr, "xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	rels := readRelationships(zr, "xl/_rels/workbook.xml.rels")
+
+	var sheets []workbookSheet
+	decoder := xml.NewDecoder(bytes.NewReader(workbook))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "sheet" {
+			continue
+		}
+		for _, a := range start.Attr {
+			if a.Name.Space == nsRelations && a.Name.Local == "id" {
+				if rel, ok := rels[a.Value]; ok {
+					sheets = append(sheets, workbookSheet{
+						name: xmlAttr(start, "name"),
+						path: resolveTarget("xl", rel.Target),
+					})
+				}
+			}
+		}
+	}
+	if len(sheets) == 0 {
+		return nil, errors.New("no sheets found")
+	}
+	return sheets, nil
+}
+
+// readSharedStrings reads xl/sharedStrings.xml; workbooks without strings omit it
+func readSharedStrings(zr *zip.Reader) ([]string, error) {
+	data, err := readZipEntry(zr, "xl/sharedStrings.xml")
+	if err != nil {
+		return nil, nil
+	}
+
+	var strs []string
+	var sb strings.Builder
+	inText := false
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				sb.Reset()
+			case "t":
+				inText = true
+			case "rPh":
+				// Phonetic hints duplicate the visible text
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				strs = append(strs, sb.String())
+			case "t":
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				sb.Write(t)
+			}
+		}
+	}
+	return strs, nil
+}
+
+// parseWorksheet reads a sheet's cells into a table, returning nil for empty sheets
+func parseWorksheet(data []byte, sharedStrings []string) (*Table, error) {
+	var rows []TableRow
+	var row *TableRow
+	var cellRef, cellType string
+	var value strings.Builder
+	inValue := false
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				number, _ := strconv.Atoi(xmlAttr(t, "r"))
+				if number == 0 {
+					number = len(rows) + 1
+				}
+				row = &TableRow{Number: number}
+			case "c":
+				cellRef, cellType = xmlAttr(t, "r"), xmlAttr(t, "t")
+				value.Reset()
+			case "v", "t":
+				inValue = true
+			case "rPh":
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v", "t":
+				inValue = false
+			case "c":
+				if row == nil {
+					continue
+				}
+				col := columnIndex(cellRef, len(row.Cells))
+				for len(row.Cells) <= col {
+					row.Cells = append(row.Cells, "")
+				}
+				row.Cells[col] = cellValue(strings.TrimSpace(value.String()), cellType, sharedStrings)
+			case "row":
+				if row != nil && !isEmptyRecord(row.Cells) {
+					rows = append(rows, *row)
+				}
+				row = nil
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &Table{
+		Columns: headerNames(rows[0].Cells),
+		Rows:    rows[1:],
+	}, nil
+}
+
+// cellValue decodes a cell by its type attribute
+func cellValue(v, typ string, sharedStrings []string) string {
+	switch typ {
+	case "s":
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 && i < len(sharedStrings) {
+			return sharedStrings[i]
+		}
+		return ""
+	case "b":
+		if v == "1" {
+			return "true"
+		}
+		return "false"
+	}
+	return v
+}
+
+// columnIndex converts a cell reference's letters (e.g. "AB12") to a 0-based
+// column, defaulting to the next column when the reference is missing
+func columnIndex(ref string, next int) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	if col == 0 {
+		return next
+	}
+	return col - 1
+}
@@ -0,0 +1,236 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Phase is a step of ingestion. A pipeline runs its stages phase by phase in
+// the order of Phases, and the stages of a phase in the order they were added.
+type Phase string
+
+// Phases of ingestion
+const (
+	// PhaseExtract turns the source into sections. Files and pages are
+	// extracted before the pipeline runs; stages here can add or replace
+	// sections, such as attachments of their own format.
+	PhaseExtract Phase = "extract"
+	// PhaseClean cleans section text before it is chunked.
+	PhaseClean Phase = "clean"
+	// PhaseChunk chunks sections and sets chunk metadata.
+	PhaseChunk Phase = "chunk"
+	// PhaseEnrich adds to chunks' metadata and content, and detects PII.
+	PhaseEnrich Phase = "enrich"
+	// PhaseEmbed runs once chunks are final, before the document service
+	// embeds them.
+	PhaseEmbed Phase = "embed"
+	// PhaseStore runs last, before the document service stores the chunks
+	// and their vectors, such as to mirror chunks elsewhere.
+	PhaseStore Phase = "store"
+)
+
+// Phases lists the phases in the order they run.
+var Phases = []Phase{PhaseExtract, PhaseClean, PhaseChunk, PhaseEnrich, PhaseEmbed, PhaseStore}
+
+// Names of the built-in stages
+const (
+	StageTrim    = "trim"    // PhaseClean: trims sections and drops empty ones
+	StageChunk   = "chunk"   // PhaseChunk: chunks sections with the chunker config
+	StageContext = "context" // PhaseChunk: prepends the chunker config's context template
+	StagePII     = "pii"     // PhaseEnrich: applies the PII policy
+)
+
+// Job is the document a pipeline's stages work on.
+type Job struct {
+	// DocumentID and ContentHash identify the ingestion; ContentHash is of
+	// the content as given, before cleaning
+	DocumentID  uuid.UUID
+	ContentHash string
+
+	// Sections are the document's parts; chunk stages chunk each one
+	Sections []Section
+
+	// Metadata is the document's metadata, added to chunks that don't set a key
+	Metadata map[string]string
+
+	// Chunks are the chunks so far, set by PhaseChunk
+	Chunks []Chunk
+
+	// pipeline is the pipeline running the job, whose config the built-in
+	// stages use
+	pipeline *Pipeline
+}
+
+// Stage is a step of a pipeline, such as a tenant's own cleaning or
+// enrichment.
+type Stage interface {
+	// Name identifies the stage in its pipeline.
+	Name() string
+	// Phase is when the stage runs.
+	Phase() Phase
+	// Run processes the job; an error stops the pipeline.
+	Run(ctx context.Context, job *Job) error
+}
+
+// NewStage creates a stage running fn.
+func NewStage(phase Phase, name string, fn func(ctx context.Context, job *Job) error) Stage {
+	return funcStage{name: name, phase: phase, fn: fn}
+}
+
+type funcStage struct {
+	name  string
+	phase Phase
+	fn    func(ctx context.Context, job *Job) error
+}
+
+func (s funcStage) Name() string                            { return s.name }
+func (s funcStage) Phase() Phase                            { return s.phase }
+func (s funcStage) Run(ctx context.Context, job *Job) error { return s.fn(ctx, job) }
+
+// AddStage adds a stage after the others of its phase. Stage names are
+// unique within a pipeline.
+func (p *Pipeline) AddStage(stage Stage) error {
+	if !validPhase(stage.Phase()) {
+		return fmt.Errorf("stage %q has unknown phase %q", stage.Name(), stage.Phase())
+	}
+	for _, s := range p.stages {
+		if s.Name() == stage.Name() {
+			return fmt.Errorf("pipeline already has a stage %q", stage.Name())
+		}
+	}
+	p.stages = append(p.stages, stage)
+	return nil
+}
+
+// ReplaceStage replaces the stage with the given name, such as a built-in
+// one, keeping its place. It reports whether the pipeline had the stage.
+func (p *Pipeline) ReplaceStage(name string, stage Stage) bool {
+	for i, s := range p.stages {
+		if s.Name() == name {
+			p.stages[i] = stage
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveStage removes the stage with the given name. It reports whether
+// the pipeline had the stage.
+func (p *Pipeline) RemoveStage(name string) bool {
+	for i, s := range p.stages {
+		if s.Name() == name {
+			p.stages = append(p.stages[:i], p.stages[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// StageNames returns the names of the pipeline's stages in the order they run.
+func (p *Pipeline) StageNames() []string {
+	var names []string
+	for _, phase := range Phases {
+		for _, s := range p.stages {
+			if s.Phase() == phase {
+				names = append(names, s.Name())
+			}
+		}
+	}
+	return names
+}
+
+func validPhase(phase Phase) bool {
+	for _, p := range Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// runStages runs the pipeline's stages on job, phase by phase
+func (p *Pipeline) runStages(ctx context.Context, job *Job) error {
+	for _, phase := range Phases {
+		for _, stage := range p.stages {
+			if stage.Phase() != phase {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := stage.Run(ctx, job); err != nil {
+				return fmt.Errorf("%s stage %q: %w", phase, stage.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// builtinStages returns the stages a new pipeline starts with
+func builtinStages() []Stage {
+	return []Stage{
+		NewStage(PhaseClean, StageTrim, trimSections),
+		NewStage(PhaseChunk, StageChunk, chunkSections),
+		NewStage(PhaseChunk, StageContext, func(_ context.Context, job *Job) error {
+			job.pipeline.chunker.injectContext(job.Chunks)
+			return nil
+		}),
+		NewStage(PhaseEnrich, StagePII, func(_ context.Context, job *Job) error {
+			ApplyPIIPolicy(job.Chunks, job.pipeline.config.PII)
+			return nil
+		}),
+	}
+}
+
+// trimSections trims the sections' text and drops sections left without
+// text or a table
+func trimSections(_ context.Context, job *Job) error {
+	sections := make([]Section, 0, len(job.Sections))
+	for _, section := range job.Sections {
+		section.Content = strings.TrimSpace(section.Content)
+		if section.Content != "" || section.Table != nil {
+			sections = append(sections, section)
+		}
+	}
+	job.Sections = sections
+	return nil
+}
+
+// chunkSections chunks each section separately and concatenates the
+// results, so no chunk spans two sections (e.g. two slides). Table sections
+// are chunked by rows. Metadata priority is chunk > section > document >
+// default.
+func chunkSections(_ context.Context, job *Job) error {
+	p := job.pipeline
+	var chunks []Chunk
+	for _, section := range job.Sections {
+		base := len(chunks)
+		sectionChunks := p.chunker.Chunk(section.Content)
+		if section.Table != nil {
+			sectionChunks = p.chunker.ChunkTable(section.Table)
+		}
+		for _, chunk := range sectionChunks {
+			chunk.Index += base
+			chunk.ParentStart += base
+			chunk.ParentEnd += base
+			if chunk.Metadata == nil {
+				chunk.Metadata = make(map[string]string)
+			}
+			for _, m := range []map[string]string{section.Metadata, job.Metadata, p.config.DefaultMetadata} {
+				for k, v := range m {
+					if _, exists := chunk.Metadata[k]; !exists {
+						chunk.Metadata[k] = v
+					}
+				}
+			}
+			chunk.Metadata["document_id"] = job.DocumentID.String()
+			chunk.Metadata["content_hash"] = job.ContentHash
+			chunks = append(chunks, chunk)
+		}
+	}
+	job.Chunks = chunks
+	return nil
+}
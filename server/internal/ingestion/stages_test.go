@@ -0,0 +1,77 @@
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPipeline_CustomStages(t *testing.T) {
+	pipeline := NewPipelineWithDefaults()
+	var order []string
+	stages := []Stage{
+		NewStage(PhaseEnrich, "tag", func(_ context.Context, job *Job) error {
+			order = append(order, "tag")
+			for i := range job.Chunks {
+				job.Chunks[i].Metadata["reviewed"] = "true"
+			}
+			return nil
+		}),
+		NewStage(PhaseClean, "redact-codes", func(_ context.Context, job *Job) error {
+			order = append(order, "redact-codes")
+			for i := range job.Sections {
+				job.Sections[i].Content = strings.ReplaceAll(job.Sections[i].Content, "X-123", "[code]")
+			}
+			return nil
+		}),
+	}
+	for _, stage := range stages {
+		if err := pipeline.AddStage(stage); err != nil {
+			t.Fatalf("AddStage(%s): %v", stage.Name(), err)
+		}
+	}
+
+	want := []string{StageTrim, "redact-codes", StageChunk, StageContext, StagePII, "tag"}
+	if got := pipeline.StageNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StageNames = %v, want %v", got, want)
+	}
+
+	result, err := pipeline.Process(context.Background(), "Use code X-123 to log in.")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"redact-codes", "tag"}) {
+		t.Errorf("stages ran in order %v", order)
+	}
+	if len(result.Chunks) != 1 || result.Chunks[0].Content != "Use code [code] to log in." {
+		t.Fatalf("chunks = %+v, want the cleaned content", result.Chunks)
+	}
+	if result.Chunks[0].Metadata["reviewed"] != "true" {
+		t.Error("enrich stage metadata missing")
+	}
+}
+
+func TestPipeline_StageErrors(t *testing.T) {
+	pipeline := NewPipelineWithDefaults()
+	if err := pipeline.AddStage(NewStage("transform", "x", nil)); err == nil {
+		t.Error("AddStage accepted an unknown phase")
+	}
+	if err := pipeline.AddStage(NewStage(PhaseClean, StageTrim, nil)); err == nil {
+		t.Error("AddStage accepted a duplicate name")
+	}
+
+	failure := errors.New("store unavailable")
+	pipeline.AddStage(NewStage(PhaseStore, "mirror", func(context.Context, *Job) error { return failure }))
+	if _, err := pipeline.Process(context.Background(), "Some text."); !errors.Is(err, failure) {
+		t.Errorf("Process error = %v, want the stage's error", err)
+	}
+
+	if !pipeline.RemoveStage("mirror") || pipeline.RemoveStage("mirror") {
+		t.Error("RemoveStage should remove the stage once")
+	}
+	if _, err := pipeline.Process(context.Background(), "Some text."); err != nil {
+		t.Errorf("Process after RemoveStage: %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package ingestion
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Metadata keys set from a page's structured data
+const (
+	MetadataDescription = "description"
+	MetadataModifiedAt  = "modified_at" // YYYY-MM-DD
+	MetadataPageType    = "page_type"   // schema.org @type or og:type, e.g. "NewsArticle"
+	MetadataSiteName    = "site_name"
+	MetadataImageURL    = "image_url"
+)
+
+// maxDescriptionLength caps the description kept, in runes
+const maxDescriptionLength = 500
+
+// PageMetadata is what a page says about itself in JSON-LD, OpenGraph and
+// <meta> tags. Fields the page doesn't declare are empty.
+type PageMetadata struct {
+	Title       string
+	Description string
+	Author      string
+	PublishedAt string // YYYY-MM-DD
+	ModifiedAt  string // YYYY-MM-DD
+	Type        string
+	SiteName    string
+	Image       string // as written; may be relative
+	Language    string // ISO 639-1
+}
+
+// Metadata returns the declared fields as document metadata, the title aside
+func (m PageMetadata) Metadata() map[string]string {
+	metadata := make(map[string]string)
+	for key, value := range map[string]string{
+		MetadataDescription: m.Description,
+		MetadataAuthor:      m.Author,
+		MetadataPublishedAt: m.PublishedAt,
+		MetadataModifiedAt:  m.ModifiedAt,
+		MetadataPageType:    m.Type,
+		MetadataSiteName:    m.SiteName,
+		MetadataImageURL:    m.Image,
+		MetadataLanguage:    m.Language,
+	} {
+		if value != "" {
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
+// pageMetadata reads a page's structured data. JSON-LD wins over OpenGraph,
+// which wins over plain <meta> tags.
+func pageMetadata(doc *html.Node) PageMetadata {
+	var ld PageMetadata
+	var lang string
+	meta := make(map[string]string)
+	walk(doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.Html:
+			lang, _ = attr(n, "lang")
+		case atom.Script:
+			if typ, _ := attr(n, "type"); strings.EqualFold(strings.TrimSpace(typ), "application/ld+json") {
+				ld = mergePageMetadata(ld, jsonLDMetadata(textContent(n)))
+			}
+			return false
+		case atom.Meta:
+			key, ok := attr(n, "property")
+			if !ok {
+				key, _ = attr(n, "name")
+			}
+			key = strings.ToLower(strings.TrimSpace(key))
+			content, _ := attr(n, "content")
+			if content = collapseSpace(content); key != "" && content != "" {
+				if _, seen := meta[key]; !seen {
+					meta[key] = content
+				}
+			}
+		}
+		return true
+	})
+
+	og := PageMetadata{
+		Title:       meta["og:title"],
+		Description: meta["og:description"],
+		Author:      firstNonEmpty(meta["article:author"], meta["author"]),
+		PublishedAt: dateOf(firstNonEmpty(meta["article:published_time"], meta["date"])),
+		ModifiedAt:  dateOf(firstNonEmpty(meta["article:modified_time"], meta["og:updated_time"])),
+		Type:        meta["og:type"],
+		SiteName:    meta["og:site_name"],
+		Image:       meta["og:image"],
+		Language:    languageTag(firstNonEmpty(lang, meta["og:locale"])),
+	}
+	if og.Description == "" {
+		og.Description = meta["description"]
+	}
+	// article:author is often a profile URL rather than a name
+	if strings.HasPrefix(og.Author, "http://") || strings.HasPrefix(og.Author, "https://") {
+		og.Author = meta["author"]
+	}
+
+	m := mergePageMetadata(ld, og)
+	m.Description = truncateText(m.Description, maxDescriptionLength)
+	return m
+}
+
+// jsonLDMetadata reads a JSON-LD block: its first node describing a creative
+// work, or its first node if none does
+func jsonLDMetadata(data string) PageMetadata {
+	var value any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &value); err != nil {
+		return PageMetadata{}
+	}
+
+	nodes := jsonLDNodes(value)
+	var chosen map[string]any
+	for _, node := range nodes {
+		if _, ok := node["headline"]; ok {
+			chosen = node
+			break
+		}
+		if _, ok := node["datePublished"]; ok {
+			chosen = node
+			break
+		}
+	}
+	if chosen == nil && len(nodes) > 0 {
+		chosen = nodes[0]
+	}
+	if chosen == nil {
+		return PageMetadata{}
+	}
+
+	m := PageMetadata{
+		Title:       firstNonEmpty(jsonString(chosen["headline"]), jsonString(chosen["name"])),
+		Description: jsonString(chosen["description"]),
+		Author:      jsonName(chosen["author"]),
+		PublishedAt: dateOf(jsonString(chosen["datePublished"])),
+		ModifiedAt:  dateOf(jsonString(chosen["dateModified"])),
+		Type:        jsonString(chosen["@type"]),
+		Image:       jsonURL(chosen["image"]),
+		Language:    languageTag(jsonString(chosen["inLanguage"])),
+	}
+	if publisher, ok := chosen["publisher"].(map[string]any); ok {
+		m.SiteName = jsonString(publisher["name"])
+	}
+	return m
+}
+
+// jsonLDNodes flattens a JSON-LD value, an object, array or @graph, into its nodes
+func jsonLDNodes(value any) []map[string]any {
+	switch v := value.(type) {
+	case []any:
+		var nodes []map[string]any
+		for _, item := range v {
+			nodes = append(nodes, jsonLDNodes(item)...)
+		}
+		return nodes
+	case map[string]any:
+		if graph, ok := v["@graph"]; ok {
+			return jsonLDNodes(graph)
+		}
+		return []map[string]any{v}
+	}
+	return nil
+}
+
+// jsonString returns a JSON-LD string value, or the first of a list of them
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return collapseSpace(v)
+	case []any:
+		if len(v) > 0 {
+			return jsonString(v[0])
+		}
+	}
+	return ""
+}
+
+// jsonName returns the names of a JSON-LD person or organization, or of a
+// list of them, comma separated
+func jsonName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return collapseSpace(v)
+	case map[string]any:
+		return jsonString(v["name"])
+	case []any:
+		var names []string
+		for _, item := range v {
+			if name := jsonName(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	return ""
+}
+
+// jsonURL returns a JSON-LD URL value, which may be an ImageObject
+func jsonURL(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		return jsonString(v["url"])
+	case []any:
+		if len(v) > 0 {
+			return jsonURL(v[0])
+		}
+	}
+	return jsonString(value)
+}
+
+// dateOf returns the YYYY-MM-DD date of an ISO 8601 date or date-time
+func dateOf(value string) string {
+	if len(value) < len(time.DateOnly) {
+		return ""
+	}
+	date, err := time.Parse(time.DateOnly, value[:len(time.DateOnly)])
+	if err != nil {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+// languageTag returns the ISO 639-1 code of a BCP 47 language tag or locale,
+// such as "en-US" or "pt_BR", or "" if it has none
+func languageTag(tag string) string {
+	primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	primary = strings.ToLower(primary)
+	if len(primary) != 2 || primary[0] < 'a' || primary[0] > 'z' || primary[1] < 'a' || primary[1] > 'z' {
+		return ""
+	}
+	return primary
+}
+
+// mergePageMetadata fills the empty fields of m from fallback
+func mergePageMetadata(m, fallback PageMetadata) PageMetadata {
+	m.Title = firstNonEmpty(m.Title, fallback.Title)
+	m.Description = firstNonEmpty(m.Description, fallback.Description)
+	m.Author = firstNonEmpty(m.Author, fallback.Author)
+	m.PublishedAt = firstNonEmpty(m.PublishedAt, fallback.PublishedAt)
+	m.ModifiedAt = firstNonEmpty(m.ModifiedAt, fallback.ModifiedAt)
+	m.Type = firstNonEmpty(m.Type, fallback.Type)
+	m.SiteName = firstNonEmpty(m.SiteName, fallback.SiteName)
+	m.Image = firstNonEmpty(m.Image, fallback.Image)
+	m.Language = firstNonEmpty(m.Language, fallback.Language)
+	return m
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncateText shortens text to at most n runes, ending with an ellipsis
+func truncateText(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:n-1])) + "…"
+}
@@ -0,0 +1,94 @@
+package ingestion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractHTMLStructuredData(t *testing.T) {
+	page := `<html lang="en-GB"><head>
+<title>Release notes | Example</title>
+<meta name="description" content="Plain description">
+<meta property="og:description" content="What changed in
+  version 2.">
+<meta property="og:type" content="article">
+<meta property="og:site_name" content="Example Docs">
+<meta property="article:author" content="https://example.com/people/ada">
+<meta name="author" content="Ada Lovelace">
+<script type="application/ld+json">
+{"@context": "https://schema.org", "@graph": [
+  {"@type": "WebSite", "name": "Example"},
+  {"@type": "NewsArticle", "headline": "Version 2 released",
+   "datePublished": "2024-03-05T09:30:00+01:00", "dateModified": "2024-03-07",
+   "author": [{"@type": "Person", "name": "Ada Lovelace"}, {"@type": "Person", "name": "Charles Babbage"}],
+   "image": {"@type": "ImageObject", "url": "https://example.com/cover.png"}}
+]}
+</script>
+</head><body><p>Body text.</p></body></html>`
+
+	content, err := ExtractHTML(page)
+	if err != nil {
+		t.Fatalf("ExtractHTML: %v", err)
+	}
+	want := map[string]string{
+		MetadataDescription: "What changed in version 2.",
+		MetadataAuthor:      "Ada Lovelace, Charles Babbage",
+		MetadataPublishedAt: "2024-03-05",
+		MetadataModifiedAt:  "2024-03-07",
+		MetadataPageType:    "NewsArticle",
+		MetadataSiteName:    "Example Docs",
+		MetadataImageURL:    "https://example.com/cover.png",
+		MetadataLanguage:    "en",
+	}
+	if got := content.Structured.Metadata(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metadata() = %v, want %v", got, want)
+	}
+	if content.Title != "Release notes | Example" {
+		t.Errorf("Title = %q, want the <title>", content.Title)
+	}
+}
+
+func TestPageMetadataFallbacks(t *testing.T) {
+	page := `<html><head>
+<meta property="og:title" content="Shared title">
+<meta name="description" content="Plain description">
+<meta property="article:author" content="https://example.com/people/ada">
+<meta property="article:published_time" content="2023-11-02T08:00:00Z">
+<meta property="og:locale" content="pt_BR">
+<script type="application/ld+json">{not json</script>
+</head><body><p>Body text.</p></body></html>`
+
+	content, err := ExtractHTML(page)
+	if err != nil {
+		t.Fatalf("ExtractHTML: %v", err)
+	}
+	want := PageMetadata{
+		Title:       "Shared title",
+		Description: "Plain description",
+		PublishedAt: "2023-11-02",
+		Language:    "pt",
+	}
+	if content.Structured != want {
+		t.Errorf("Structured = %+v, want %+v", content.Structured, want)
+	}
+	if content.Title != "Shared title" {
+		t.Errorf("Title = %q, want the og:title", content.Title)
+	}
+}
+
+func TestLanguageTag(t *testing.T) {
+	for tag, want := range map[string]string{
+		"en":         "en",
+		"en-US":      "en",
+		" DE ":       "de",
+		"pt_BR":      "pt",
+		"zh-Hant-TW": "zh",
+		"":           "",
+		"fil":        "",
+		"x-default":  "",
+	} {
+		if got := languageTag(tag); got != want {
+			t.Errorf("languageTag(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// Defaults for summary trees
+const (
+	DefaultSummaryClusterSize = 5
+	DefaultSummaryMaxLevels   = 3
+	DefaultSummaryConcurrency = 4
+)
+
+// MetadataSummaryLevel is the metadata key of a summary node's level: 1 for
+// summaries of chunks, 2 for summaries of those, and so on
+const MetadataSummaryLevel = "summary_level"
+
+// summaryTreeSystemPrompt instructs the LLM that summarizes a cluster
+const summaryTreeSystemPrompt = `You summarize related passages of a document into one passage for a search index.
+
+Say what the passages are about as a whole and keep the key facts, names and numbers, so the summary answers broad questions about the topic. Do not add anything the passages do not say. Output only the summary of at most 200 words.`
+
+// SummaryTreeConfig configures a SummaryTreeBuilder
+type SummaryTreeConfig struct {
+	// Model is the LLM that writes the summaries; empty uses the client's default
+	Model string
+
+	// ClusterSize is the average number of nodes summarized together (default: 5)
+	ClusterSize int
+
+	// MaxLevels caps the levels of summaries above the chunks (default: 3)
+	MaxLevels int
+
+	// Concurrency is how many clusters are summarized at once (default: 4)
+	Concurrency int
+}
+
+// SummaryNode is a summary of chunks, or of summaries one level down
+type SummaryNode struct {
+	Level    int
+	Content  string
+	Vector   []float32
+	Children []int // indexes of the nodes summarized: chunks for level 1, else nodes of the level below
+}
+
+// EmbedFunc embeds texts, returning one vector per text
+type EmbedFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// SummaryTreeBuilder builds RAPTOR-style summary trees: it clusters a
+// document's chunks by embedding similarity, summarizes each cluster with an
+// LLM, embeds the summaries and repeats with them until one summary is left.
+// Searched beside the chunks, the summaries answer broad questions no single
+// chunk does.
+type SummaryTreeBuilder struct {
+	llm         llm.LLM
+	model       string
+	clusterSize int
+	maxLevels   int
+	concurrency int
+}
+
+// NewSummaryTreeBuilder creates a builder backed by an LLM
+func NewSummaryTreeBuilder(l llm.LLM, cfg SummaryTreeConfig) *SummaryTreeBuilder {
+	b := &SummaryTreeBuilder{
+		llm:         l,
+		model:       cfg.Model,
+		clusterSize: cfg.ClusterSize,
+		maxLevels:   cfg.MaxLevels,
+		concurrency: cfg.Concurrency,
+	}
+	if b.clusterSize < 2 {
+		b.clusterSize = DefaultSummaryClusterSize
+	}
+	if b.maxLevels <= 0 {
+		b.maxLevels = DefaultSummaryMaxLevels
+	}
+	if b.concurrency <= 0 {
+		b.concurrency = DefaultSummaryConcurrency
+	}
+	return b
+}
+
+// Build returns the summary nodes of a document's chunks, level by level.
+// vectors holds the chunks' embeddings where known; missing ones are
+// embedded. clusterSize and maxLevels override the builder's when positive.
+func (b *SummaryTreeBuilder) Build(ctx context.Context, texts []string, vectors [][]float32, embed EmbedFunc, clusterSize, maxLevels int) ([]SummaryNode, error) {
+	if len(texts) < 2 {
+		return nil, nil
+	}
+	if clusterSize < 2 {
+		clusterSize = b.clusterSize
+	}
+	if maxLevels <= 0 {
+		maxLevels = b.maxLevels
+	}
+
+	vectors, err := completeVectors(ctx, texts, vectors, embed)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []SummaryNode
+	for level := 1; level <= maxLevels && len(texts) >= 2; level++ {
+		clusters := ClusterVectors(vectors, clusterSize)
+		summaries, err := b.summarizeClusters(ctx, texts, clusters)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: %w", level, err)
+		}
+		summaryVectors, err := embed(ctx, summaries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed level %d summaries: %w", level, err)
+		}
+		if len(summaryVectors) != len(summaries) {
+			return nil, fmt.Errorf("embedded %d of %d level %d summaries", len(summaryVectors), len(summaries), level)
+		}
+		for i, summary := range summaries {
+			nodes = append(nodes, SummaryNode{
+				Level:    level,
+				Content:  summary,
+				Vector:   summaryVectors[i],
+				Children: clusters[i],
+			})
+		}
+		texts, vectors = summaries, summaryVectors
+	}
+	return nodes, nil
+}
+
+// completeVectors embeds the texts whose vectors are missing
+func completeVectors(ctx context.Context, texts []string, vectors [][]float32, embed EmbedFunc) ([][]float32, error) {
+	complete := make([][]float32, len(texts))
+	var missing []int
+	for i := range texts {
+		if i < len(vectors) && len(vectors[i]) > 0 {
+			complete[i] = vectors[i]
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return complete, nil
+	}
+	batch := make([]string, len(missing))
+	for i, index := range missing {
+		batch[i] = texts[index]
+	}
+	embedded, err := embed(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed chunks: %w", err)
+	}
+	if len(embedded) != len(missing) {
+		return nil, fmt.Errorf("embedded %d of %d chunks", len(embedded), len(missing))
+	}
+	for i, index := range missing {
+		complete[index] = embedded[i]
+	}
+	return complete, nil
+}
+
+// summarizeClusters summarizes each cluster's texts, Concurrency at a time
+func (b *SummaryTreeBuilder) summarizeClusters(ctx context.Context, texts []string, clusters [][]int) ([]string, error) {
+	summaries := make([]string, len(clusters))
+	errs := make([]error, len(clusters))
+	semaphore := make(chan struct{}, b.concurrency)
+	done := make(chan struct{})
+	for i, cluster := range clusters {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			passages := make([]string, len(cluster))
+			for j, index := range cluster {
+				passages[j] = texts[index]
+			}
+			summary, err := b.llm.Generate(ctx, strings.Join(passages, "\n\n---\n\n"), llm.GenerateOptions{
+				Model:        b.model,
+				SystemPrompt: summaryTreeSystemPrompt,
+				Temperature:  0.1,
+				MaxTokens:    400,
+			})
+			summaries[i], errs[i] = strings.TrimSpace(summary), err
+			if err == nil && summaries[i] == "" {
+				errs[i] = fmt.Errorf("empty summary")
+			}
+		}()
+	}
+	for range clusters {
+		<-done
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summaries, nil
+}
+
+// kMeansIterations bounds the refinement of ClusterVectors
+const kMeansIterations = 10
+
+// ClusterVectors groups vectors by cosine similarity into clusters of about
+// size members, with k-means seeded at evenly spaced vectors so the result
+// is deterministic. Clusters over twice size are split, members are in
+// index order and clusters in the order of their first member.
+func ClusterVectors(vectors [][]float32, size int) [][]int {
+	n := len(vectors)
+	if n == 0 {
+		return nil
+	}
+	if size < 1 {
+		size = 1
+	}
+	k := (n + size - 1) / size
+	normalized := make([][]float64, n)
+	for i, v := range vectors {
+		normalized[i] = normalize(v)
+	}
+
+	centroids := make([][]float64, k)
+	for c := range centroids {
+		centroids[c] = normalized[c*n/k]
+	}
+	assignment := make([]int, n)
+	for iter := 0; iter < kMeansIterations; iter++ {
+		changed := iter == 0
+		for i, v := range normalized {
+			best, bestSim := 0, math.Inf(-1)
+			for c, centroid := range centroids {
+				if sim := dot(v, centroid); sim > bestSim {
+					best, bestSim = c, sim
+				}
+			}
+			if assignment[i] != best {
+				assignment[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		for c := range centroids {
+			var sum []float64
+			for i, v := range normalized {
+				if assignment[i] != c {
+					continue
+				}
+				if sum == nil {
+					sum = make([]float64, len(v))
+				}
+				for d := range v {
+					if d < len(sum) {
+						sum[d] += v[d]
+					}
+				}
+			}
+			if sum != nil {
+				centroids[c] = normalizeFloat64(sum)
+			}
+		}
+	}
+
+	members := make([][]int, k)
+	for i, c := range assignment {
+		members[c] = append(members[c], i)
+	}
+	var clusters [][]int
+	for _, cluster := range members {
+		for len(cluster) > 2*size {
+			clusters = append(clusters, cluster[:size])
+			cluster = cluster[size:]
+		}
+		if len(cluster) > 0 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i][0] < clusters[j][0] })
+	return clusters
+}
+
+// normalize returns v scaled to unit length
+func normalize(v []float32) []float64 {
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = float64(x)
+	}
+	return normalizeFloat64(out)
+}
+
+// normalizeFloat64 scales v to unit length in place; a zero vector is kept
+func normalizeFloat64(v []float64) []float64 {
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// dot returns the dot product of the vectors' common dimensions
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := 0; i < len(a) && i < len(b); i++ {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
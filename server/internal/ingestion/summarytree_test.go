@@ -0,0 +1,125 @@
+package ingestion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/llm"
+)
+
+// summaryLLM summarizes passages by counting them
+type summaryLLM struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (l *summaryLLM) Generate(_ context.Context, prompt string, _ llm.GenerateOptions) (string, error) {
+	l.mu.Lock()
+	l.calls++
+	l.mu.Unlock()
+	return fmt.Sprintf("summary of %d passages", strings.Count(prompt, "---")+1), nil
+}
+
+func (l *summaryLLM) GenerateStream(context.Context, string, llm.GenerateOptions) (<-chan llm.StreamChunk, error) {
+	return nil, nil
+}
+
+func (l *summaryLLM) GenerateStructured(context.Context, string, json.RawMessage, llm.GenerateOptions) (json.RawMessage, error) {
+	return nil, nil
+}
+
+// axisEmbed embeds texts mentioning "cats" and others on different axes
+func axisEmbed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if strings.Contains(text, "cats") {
+			vectors[i] = []float32{1, 0.1}
+		} else {
+			vectors[i] = []float32{0.1, 1}
+		}
+	}
+	return vectors, nil
+}
+
+func TestClusterVectors(t *testing.T) {
+	vectors := [][]float32{{1, 0}, {0, 1}, {0.9, 0.1}, {0.1, 0.9}, {1, 0.05}, {0.05, 1}}
+	got := ClusterVectors(vectors, 3)
+	want := [][]int{{0, 2, 4}, {1, 3, 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterVectors = %v, want %v", got, want)
+	}
+}
+
+func TestClusterVectors_SplitsLargeClusters(t *testing.T) {
+	vectors := make([][]float32, 7)
+	for i := range vectors {
+		vectors[i] = []float32{1, 0}
+	}
+	// Identical vectors all join the first cluster, which is then split
+	got := ClusterVectors(vectors, 2)
+	for _, cluster := range got {
+		if len(cluster) > 4 {
+			t.Errorf("cluster %v is over twice the size", cluster)
+		}
+	}
+	total := 0
+	for _, cluster := range got {
+		total += len(cluster)
+	}
+	if total != 7 {
+		t.Errorf("clusters %v hold %d vectors, want 7", got, total)
+	}
+}
+
+func TestSummaryTreeBuilder_Build(t *testing.T) {
+	texts := []string{"cats purr", "dogs bark", "cats nap", "dogs fetch"}
+	l := &summaryLLM{}
+	b := NewSummaryTreeBuilder(l, SummaryTreeConfig{})
+
+	// Only the first chunk's vector is known; the rest are embedded
+	nodes, err := b.Build(context.Background(), texts, [][]float32{{1, 0.1}}, axisEmbed, 2, 0)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("Build returned %d nodes, want two level 1 summaries and a root: %+v", len(nodes), nodes)
+	}
+	if nodes[0].Level != 1 || !reflect.DeepEqual(nodes[0].Children, []int{0, 2}) || nodes[0].Content != "summary of 2 passages" {
+		t.Errorf("first node = %+v, want the cat chunks summarized", nodes[0])
+	}
+	if nodes[1].Level != 1 || !reflect.DeepEqual(nodes[1].Children, []int{1, 3}) {
+		t.Errorf("second node = %+v, want the dog chunks summarized", nodes[1])
+	}
+	if nodes[2].Level != 2 || !reflect.DeepEqual(nodes[2].Children, []int{0, 1}) || len(nodes[2].Vector) == 0 {
+		t.Errorf("root = %+v, want both summaries summarized and embedded", nodes[2])
+	}
+	if l.calls != 3 {
+		t.Errorf("LLM called %d times, want 3", l.calls)
+	}
+}
+
+func TestSummaryTreeBuilder_MaxLevels(t *testing.T) {
+	texts := []string{"cats purr", "dogs bark", "cats nap", "dogs fetch"}
+	b := NewSummaryTreeBuilder(&summaryLLM{}, SummaryTreeConfig{MaxLevels: 1})
+
+	nodes, err := b.Build(context.Background(), texts, nil, axisEmbed, 2, 0)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].Level != 1 || nodes[1].Level != 1 {
+		t.Errorf("Build = %+v, want only level 1 summaries", nodes)
+	}
+}
+
+func TestSummaryTreeBuilder_SingleChunk(t *testing.T) {
+	b := NewSummaryTreeBuilder(&summaryLLM{}, SummaryTreeConfig{})
+	nodes, err := b.Build(context.Background(), []string{"only chunk"}, nil, axisEmbed, 0, 0)
+	if err != nil || len(nodes) != 0 {
+		t.Errorf("Build = %v, %v; want no summaries of a single chunk", nodes, err)
+	}
+}
@@ -0,0 +1,161 @@
+package ingestion
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Table formats of semantic chunks, see repository.ChunkerConfig.TableFormat
+const (
+	// TableFormatMarkdown keeps markdown tables as they are written.
+	TableFormatMarkdown = "markdown"
+	// TableFormatKeyValue renders each table row as "column: value" lines,
+	// as tabular chunks are, so every row names what its cells mean.
+	TableFormatKeyValue = "key_value"
+)
+
+// tableSeparatorPattern matches the row under a markdown table's header,
+// such as "|---|:---:|"
+var tableSeparatorPattern = regexp.MustCompile(`^\|?(\s*:?-+:?\s*\|)*\s*:?-+:?\s*\|?$`)
+
+// markdownTable is a markdown table split into its parts
+type markdownTable struct {
+	caption   string // lines before the table, such as "Table 1: Prices"
+	header    string
+	separator string
+	rows      []string
+}
+
+// parseMarkdownTable splits a table block into caption, header, separator
+// and rows. A table without a separator row has none.
+func parseMarkdownTable(content string) markdownTable {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	var t markdownTable
+	i := 0
+	var caption []string
+	for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+		caption = append(caption, lines[i])
+		i++
+	}
+	t.caption = strings.TrimSpace(strings.Join(caption, "\n"))
+	if i < len(lines) {
+		t.header = strings.TrimSpace(lines[i])
+		i++
+	}
+	if i < len(lines) && tableSeparatorPattern.MatchString(strings.TrimSpace(lines[i])) {
+		t.separator = strings.TrimSpace(lines[i])
+		i++
+	}
+	for ; i < len(lines); i++ {
+		if row := strings.TrimSpace(lines[i]); row != "" {
+			t.rows = append(t.rows, row)
+		}
+	}
+	return t
+}
+
+// tableCells splits a markdown table row into its trimmed cells, keeping
+// escaped pipes ("\|") inside cells
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if !strings.HasSuffix(row, `\|`) {
+		row = strings.TrimSuffix(row, "|")
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// render renders rows of the table in format, with the header repeated so
+// the rows stand alone
+func (t markdownTable) render(rows []string, format string) string {
+	if format == TableFormatKeyValue {
+		var parts []string
+		columns := tableCells(t.header)
+		for _, row := range rows {
+			if rendered := renderRow(columns, tableCells(row)); rendered != "" {
+				parts = append(parts, rendered)
+			}
+		}
+		return strings.Join(parts, "\n\n")
+	}
+
+	lines := []string{t.header}
+	if t.separator != "" {
+		lines = append(lines, t.separator)
+	}
+	return strings.Join(append(lines, rows...), "\n")
+}
+
+// splitTables renders the table blocks of blocks in the configured table
+// format and splits tables over MaxSize words into fragments of up to
+// TargetSize words, each repeating the header row. A fragment's number is
+// in its part field.
+func (c *Chunker) splitTables(blocks []contentBlock) []contentBlock {
+	var result []contentBlock
+	for _, block := range blocks {
+		if block.blockType != "table" {
+			result = append(result, block)
+			continue
+		}
+
+		t := parseMarkdownTable(block.content)
+		if len(t.rows) == 0 {
+			result = append(result, block)
+			continue
+		}
+		whole := block.content
+		if c.config.TableFormat == TableFormatKeyValue {
+			whole = t.render(t.rows, c.config.TableFormat)
+			if t.caption != "" {
+				whole = t.caption + "\n\n" + whole
+			}
+		}
+		if CountWords(whole) <= c.config.MaxSize || len(t.rows) == 1 {
+			block.content = whole
+			result = append(result, block)
+			continue
+		}
+
+		headerWords := CountWords(t.render(nil, c.config.TableFormat))
+		var rows []string
+		words, part := headerWords, 0
+		flush := func() {
+			if len(rows) == 0 {
+				return
+			}
+			part++
+			fragment := block
+			fragment.content = t.render(rows, c.config.TableFormat)
+			fragment.part = part
+			if part == 1 && t.caption != "" {
+				fragment.content = t.caption + "\n\n" + fragment.content
+			}
+			result = append(result, fragment)
+			rows, words = nil, headerWords
+		}
+		for _, row := range t.rows {
+			rowWords := CountWords(t.render([]string{row}, c.config.TableFormat)) - headerWords
+			if len(rows) > 0 && words+rowWords > c.config.TargetSize {
+				flush()
+			}
+			rows = append(rows, row)
+			words += rowWords
+		}
+		flush()
+	}
+	return result
+}
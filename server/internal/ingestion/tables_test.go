@@ -0,0 +1,71 @@
+package ingestion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestChunker_SemanticSplitsLargeTables(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:     "semantic",
+		TargetSize: 30,
+		MaxSize:    40,
+		Overlap:    5,
+	})
+
+	rows := []string{"| Name | Price |", "|------|------:|"}
+	for i := 1; i <= 20; i++ {
+		rows = append(rows, fmt.Sprintf("| item %d | %d.00 |", i, i))
+	}
+	chunks := chunker.Chunk("# Prices\n\n" + strings.Join(rows, "\n"))
+	if len(chunks) < 2 {
+		t.Fatalf("expected the table split into several chunks, got %d", len(chunks))
+	}
+
+	seen := 0
+	for i, chunk := range chunks {
+		if chunk.Metadata["table_part"] != intToString(i+1) {
+			t.Errorf("chunk %d table_part = %q, want %d", i, chunk.Metadata["table_part"], i+1)
+		}
+		if !strings.Contains(chunk.Content, "| Name | Price |\n|------|------:|\n") {
+			t.Errorf("chunk %d does not repeat the header row: %q", i, chunk.Content)
+		}
+		if strings.Contains(chunk.Content, "[...]") {
+			t.Errorf("chunk %d has overlap although it continues a table", i)
+		}
+		seen += strings.Count(chunk.Content, "| item ")
+	}
+	if seen != 20 {
+		t.Errorf("chunks hold %d rows, want each of the 20 once", seen)
+	}
+}
+
+func TestChunker_SemanticKeyValueTables(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{
+		Method:      "semantic",
+		TargetSize:  100,
+		MaxSize:     200,
+		TableFormat: TableFormatKeyValue,
+	})
+
+	content := "Supported plans:\n| Plan | Seats |\n|---|---|\n| Team | 10 |\n| Enterprise | |"
+	chunks := chunker.Chunk(content)
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	want := "Supported plans:\n\nPlan: Team\nSeats: 10\n\nPlan: Enterprise"
+	if chunks[0].Content != want {
+		t.Errorf("content = %q, want %q", chunks[0].Content, want)
+	}
+}
+
+func TestTableCells(t *testing.T) {
+	got := tableCells(`| a | b \| c |  |`)
+	if want := []string{"a", "b | c", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tableCells = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,327 @@
+package ingestion
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Table is tabular content: CSV/TSV rows or one spreadsheet sheet
+type Table struct {
+	// Name is the sheet name, if any
+	Name string
+
+	// Columns are the header names
+	Columns []string
+
+	// Rows are the data rows in source order
+	Rows []TableRow
+}
+
+// TableRow is a data row with its 1-based row number in the source
+type TableRow struct {
+	Number int
+	Cells  []string
+}
+
+// Text renders the table as tab-separated lines
+func (t *Table) Text() string {
+	lines := make([]string, 0, len(t.Rows)+1)
+	lines = append(lines, strings.Join(t.Columns, "\t"))
+	for _, row := range t.Rows {
+		lines = append(lines, strings.Join(row.Cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Column types inferred from cell values
+const (
+	ColumnText    = "text"
+	ColumnNumber  = "number"
+	ColumnBoolean = "boolean"
+	ColumnDate    = "date"
+)
+
+// ParseCSV parses delimited text with a header row. The delimiter (comma, tab,
+// semicolon or pipe) is detected from the header line.
+func ParseCSV(content string) (*Table, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+
+	header, _, _ := strings.Cut(content, "\n")
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = detectDelimiter(header)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	table := &Table{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if table.Columns == nil {
+			table.Columns = headerNames(record)
+			continue
+		}
+		table.Rows = append(table.Rows, TableRow{Number: line, Cells: record})
+	}
+
+	if len(table.Columns) == 0 {
+		return nil, errors.New("CSV has no header row")
+	}
+	return table, nil
+}
+
+// detectDelimiter picks the candidate delimiter that occurs most in the header
+func detectDelimiter(header string) rune {
+	best, bestCount := ',', 0
+	for _, d := range []rune{',', '\t', ';', '|'} {
+		if n := strings.Count(header, string(d)); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// headerNames trims header cells and names blank ones column_N
+func headerNames(record []string) []string {
+	names := make([]string, len(record))
+	for i, v := range record {
+		names[i] = strings.TrimSpace(v)
+		if names[i] == "" {
+			names[i] = "column_" + strconv.Itoa(i+1)
+		}
+	}
+	return names
+}
+
+// ColumnTypes infers a type per column: number, boolean or date when every
+// non-empty value parses as one, else text
+func (t *Table) ColumnTypes() []string {
+	types := make([]string, len(t.Columns))
+	for i := range t.Columns {
+		candidates := map[string]bool{ColumnNumber: true, ColumnBoolean: true, ColumnDate: true}
+		seen := false
+		for _, row := range t.Rows {
+			v := cell(row.Cells, i)
+			if v == "" {
+				continue
+			}
+			seen = true
+			if _, ok := parseNumber(v); !ok {
+				candidates[ColumnNumber] = false
+			}
+			if _, ok := parseBoolean(v); !ok {
+				candidates[ColumnBoolean] = false
+			}
+			if _, ok := parseDate(v); !ok {
+				candidates[ColumnDate] = false
+			}
+		}
+
+		types[i] = ColumnText
+		if seen {
+			for _, typ := range []string{ColumnNumber, ColumnBoolean, ColumnDate} {
+				if candidates[typ] {
+					types[i] = typ
+					break
+				}
+			}
+		}
+	}
+	return types
+}
+
+// maxTabularMetadataValue is the longest cell value copied into chunk metadata
+const maxTabularMetadataValue = 256
+
+// ChunkTable converts rows into chunks, RowsPerChunk rows at a time (default 1).
+// Every row is rendered with its column names so chunks stand alone, and groups
+// close early rather than exceed MaxSize words. Single-row chunks carry each
+// cell as col_<name> metadata, normalized to the column's type.
+func (c *Chunker) ChunkTable(t *Table) []Chunk {
+	rowsPerChunk := c.config.RowsPerChunk
+	if rowsPerChunk <= 0 {
+		rowsPerChunk = 1
+	}
+
+	types := t.ColumnTypes()
+	columnSummary := make([]string, len(t.Columns))
+	for i, name := range t.Columns {
+		columnSummary[i] = name + ":" + types[i]
+	}
+
+	var chunks []Chunk
+	var group []TableRow
+	var parts []string
+	words := 0
+
+	flush := func() {
+		if len(group) == 0 {
+			return
+		}
+		content := strings.Join(parts, "\n\n")
+		if t.Name != "" {
+			content = "[Sheet: " + t.Name + "]\n" + content
+		}
+
+		metadata := map[string]string{
+			"method":     "tabular",
+			"word_count": intToString(CountWords(content)),
+			"row_start":  intToString(group[0].Number),
+			"row_end":    intToString(group[len(group)-1].Number),
+			"columns":    strings.Join(columnSummary, ","),
+		}
+		if t.Name != "" {
+			metadata["sheet"] = t.Name
+		}
+		if len(group) == 1 {
+			for i, name := range t.Columns {
+				v := normalizeCell(cell(group[0].Cells, i), types[i])
+				if v != "" && len(v) <= maxTabularMetadataValue {
+					metadata["col_"+metadataKey(name)] = v
+				}
+			}
+		}
+
+		chunks = append(chunks, Chunk{
+			Content:  content,
+			Index:    len(chunks),
+			Metadata: metadata,
+		})
+		group, parts, words = nil, nil, 0
+	}
+
+	for _, row := range t.Rows {
+		rendered := renderRow(t.Columns, row.Cells)
+		if rendered == "" {
+			continue
+		}
+		rowWords := CountWords(rendered)
+		if len(group) > 0 && words+rowWords > c.config.MaxSize {
+			flush()
+		}
+		group = append(group, row)
+		parts = append(parts, rendered)
+		words += rowWords
+		if len(group) >= rowsPerChunk {
+			flush()
+		}
+	}
+	flush()
+
+	assignParentSpans(chunks)
+	return chunks
+}
+
+// chunkTabular parses CSV content and chunks it by rows, falling back to
+// semantic chunking when the content is not tabular
+func (c *Chunker) chunkTabular(content string) []Chunk {
+	table, err := ParseCSV(content)
+	if err != nil || len(table.Rows) == 0 {
+		return c.chunkSemantic(content)
+	}
+	return c.ChunkTable(table)
+}
+
+// renderRow renders non-empty cells as "column: value" lines
+func renderRow(columns, cells []string) string {
+	var lines []string
+	for i, v := range cells {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		name := "column_" + strconv.Itoa(i+1)
+		if i < len(columns) {
+			name = columns[i]
+		}
+		lines = append(lines, name+": "+v)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func cell(cells []string, i int) string {
+	if i < len(cells) {
+		return strings.TrimSpace(cells[i])
+	}
+	return ""
+}
+
+// normalizeCell formats a value canonically for its column type
+func normalizeCell(v, typ string) string {
+	switch typ {
+	case ColumnNumber:
+		if n, ok := parseNumber(v); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	case ColumnBoolean:
+		if b, ok := parseBoolean(v); ok {
+			return strconv.FormatBool(b)
+		}
+	case ColumnDate:
+		if d, ok := parseDate(v); ok {
+			return d.Format("2006-01-02")
+		}
+	}
+	return v
+}
+
+func parseNumber(v string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+	return n, err == nil
+}
+
+func parseBoolean(v string) (bool, bool) {
+	switch strings.ToLower(v) {
+	case "true", "yes", "y":
+		return true, true
+	case "false", "no", "n":
+		return false, true
+	}
+	return false, false
+}
+
+var tableDateLayouts = []string{"2006-01-02", "2006/01/02", "01/02/2006", "2006-01-02T15:04:05Z07:00", "2006-01-02 15:04:05"}
+
+func parseDate(v string) (time.Time, bool) {
+	for _, layout := range tableDateLayouts {
+		if d, err := time.Parse(layout, v); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+var nonKeyChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// metadataKey converts a column name to a snake_case metadata key
+func metadataKey(name string) string {
+	key := strings.Trim(nonKeyChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if key == "" {
+		return "column"
+	}
+	return key
+}
@@ -0,0 +1,149 @@
+package ingestion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestParseCSV(t *testing.T) {
+	content := "\ufeffsku;name;price\nA-1;\"Widget; large\";9.99\n\n;;\nB-2;Gadget;1,200\n"
+
+	table, err := ParseCSV(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(table.Columns, ","); got != "sku,name,price" {
+		t.Errorf("unexpected columns: %s", got)
+	}
+	if len(table.Rows) != 2 {
+		t.Fatalf("expected 2 rows (blank rows skipped), got %d", len(table.Rows))
+	}
+	if table.Rows[0].Cells[1] != "Widget; large" {
+		t.Errorf("expected quoted delimiter preserved, got %q", table.Rows[0].Cells[1])
+	}
+	if table.Rows[1].Number != 5 {
+		t.Errorf("expected source line 5 for second row, got %d", table.Rows[1].Number)
+	}
+
+	types := table.ColumnTypes()
+	if types[0] != ColumnText || types[2] != ColumnNumber {
+		t.Errorf("unexpected column types: %v", types)
+	}
+}
+
+func TestChunker_Tabular(t *testing.T) {
+	content := "question,answer,active,updated\n" +
+		"How do I reset my password?,Use the login page link.,yes,2024-03-01\n" +
+		"Can I export data?,\"Yes, as CSV.\",no,03/15/2024\n" +
+		"Is there an API?,See the docs.,yes,\n"
+
+	chunker := NewChunker(repository.ChunkerConfig{Method: "tabular"})
+	chunks := chunker.Chunk(content)
+	if len(chunks) != 3 {
+		t.Fatalf("expected one chunk per row, got %d", len(chunks))
+	}
+
+	first := chunks[0]
+	if !strings.Contains(first.Content, "question: How do I reset my password?\nanswer: Use the login page link.") {
+		t.Errorf("expected column context in content, got:\n%s", first.Content)
+	}
+	if first.Metadata["row_start"] != "2" || first.Metadata["row_end"] != "2" {
+		t.Errorf("unexpected row span: %v", first.Metadata)
+	}
+	if first.Metadata["col_active"] != "true" {
+		t.Errorf("expected normalized boolean, got %q", first.Metadata["col_active"])
+	}
+	if chunks[1].Metadata["col_updated"] != "2024-03-15" {
+		t.Errorf("expected normalized date, got %q", chunks[1].Metadata["col_updated"])
+	}
+	if !strings.Contains(first.Metadata["columns"], "updated:date") {
+		t.Errorf("expected column types, got %q", first.Metadata["columns"])
+	}
+	if _, ok := chunks[2].Metadata["col_updated"]; ok {
+		t.Error("expected empty cell to be omitted from metadata")
+	}
+
+	grouped := NewChunker(repository.ChunkerConfig{Method: "tabular", RowsPerChunk: 2}).Chunk(content)
+	if len(grouped) != 2 {
+		t.Fatalf("expected 2 row groups, got %d", len(grouped))
+	}
+	if grouped[0].Metadata["row_end"] != "3" {
+		t.Errorf("expected first group to end at row 3, got %q", grouped[0].Metadata["row_end"])
+	}
+	if _, ok := grouped[0].Metadata["col_question"]; ok {
+		t.Error("expected no per-column metadata on multi-row chunks")
+	}
+}
+
+func TestChunker_TabularFallback(t *testing.T) {
+	chunker := NewChunker(repository.ChunkerConfig{Method: "tabular"})
+	chunks := chunker.Chunk("Just a paragraph of prose.")
+	if len(chunks) != 1 || chunks[0].Metadata["method"] == "tabular" {
+		t.Errorf("expected semantic fallback for non-tabular content, got %+v", chunks)
+	}
+}
+
+func TestExtractXLSX(t *testing.T) {
+	data := buildZip(t, map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Products" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Name</t></si><si><t>Price</t></si><si><r><t>Wid</t></r><r><t>get</t></r></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>In stock</t></is></c></row>
+    <row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3" t="b"><v>1</v></c></row>
+    <row r="4"><c r="B4"><v>4.5</v></c></row>
+  </sheetData>
+</worksheet>`,
+	})
+
+	doc, err := ExtractXLSX(context.Background(), data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Sections) != 1 || doc.Sections[0].Table == nil {
+		t.Fatalf("expected one table section, got %+v", doc.Sections)
+	}
+
+	table := doc.Sections[0].Table
+	if got := strings.Join(table.Columns, ","); got != "Name,Price,In stock" {
+		t.Errorf("unexpected columns: %s", got)
+	}
+	if len(table.Rows) != 2 || table.Rows[0].Number != 3 {
+		t.Fatalf("unexpected rows: %+v", table.Rows)
+	}
+	if table.Rows[0].Cells[0] != "Widget" || table.Rows[0].Cells[2] != "true" {
+		t.Errorf("unexpected first row: %v", table.Rows[0].Cells)
+	}
+	if table.Rows[1].Cells[1] != "4.5" {
+		t.Errorf("expected sparse cell at column B, got %v", table.Rows[1].Cells)
+	}
+
+	result, err := NewPipelineWithDefaults().ProcessSections(context.Background(), doc.Sections, nil)
+	if err != nil {
+		t.Fatalf("unexpected pipeline error: %v", err)
+	}
+	if len(result.Chunks) != 2 {
+		t.Fatalf("expected a chunk per row, got %d", len(result.Chunks))
+	}
+	if got := result.Chunks[0].Metadata["sheet"]; got != "Products" {
+		t.Errorf("expected sheet metadata, got %q", got)
+	}
+	if !strings.HasPrefix(result.Chunks[0].Content, "[Sheet: Products]\nName: Widget") {
+		t.Errorf("unexpected chunk content:\n%s", result.Chunks[0].Content)
+	}
+}
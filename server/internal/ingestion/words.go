@@ -0,0 +1,115 @@
+package ingestion
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// word is a word of a text and whether whitespace preceded it, so words can
+// be joined back without putting spaces into text written without them
+type word struct {
+	text   string
+	spaced bool
+}
+
+// Kinds of runes segmentWords tells apart
+const (
+	runeSpace     = iota
+	runeIdeograph // Han and hiragana, one word per character
+	runeKatakana  // a run of katakana is one word
+	runeOther     // letters, digits and punctuation, split at whitespace
+)
+
+func runeKind(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return runeSpace
+	case unicode.In(r, unicode.Han, unicode.Hiragana):
+		return runeIdeograph
+	case unicode.Is(unicode.Katakana, r), r == 'ー':
+		return runeKatakana
+	}
+	return runeOther
+}
+
+// closingPunct reports whether r is punctuation that ends the word before
+// it, rather than opening the next one like "(" and "「"
+func closingPunct(r rune) bool {
+	return unicode.IsPunct(r) && !unicode.In(r, unicode.Ps, unicode.Pi)
+}
+
+// segmentWords splits text into words after Unicode word segmentation
+// (UAX #29) as far as sizing needs it: words are separated by whitespace,
+// each CJK ideograph and hiragana is a word and a run of katakana is one,
+// so Japanese and Chinese text, which has no spaces, is not one long word.
+// Punctuation stays with the word before it.
+func segmentWords(text string) []word {
+	var words []word
+	start, kind := -1, runeSpace
+	spaced := false
+	flush := func(end int) {
+		if start >= 0 {
+			words = append(words, word{text: text[start:end], spaced: spaced})
+			spaced = false
+		}
+		start, kind = -1, runeSpace
+	}
+
+	for i, r := range text {
+		size := utf8.RuneLen(r)
+		k := runeKind(r)
+		switch {
+		case k == runeSpace:
+			flush(i)
+			spaced = len(words) > 0
+		case k == runeIdeograph:
+			flush(i)
+			words = append(words, word{text: text[i : i+size], spaced: spaced})
+			spaced = false
+		case start < 0 && !spaced && len(words) > 0 && closingPunct(r):
+			// Punctuation right after an ideograph, such as "。", ends its word
+			words[len(words)-1].text += text[i : i+size]
+		case start >= 0 && kind == runeKatakana && closingPunct(r):
+			// Punctuation ends a katakana run, as it does an ideograph
+			flush(i + size)
+		default:
+			if start >= 0 && k != kind {
+				flush(i)
+			}
+			if start < 0 {
+				start, kind = i, k
+			}
+		}
+	}
+	flush(len(text))
+	return words
+}
+
+// joinWords joins words with single spaces where whitespace separated them
+func joinWords(words []word) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 && w.spaced {
+			b.WriteByte(' ')
+		}
+		b.WriteString(w.text)
+	}
+	return b.String()
+}
+
+// Words splits text into words by Unicode word segmentation; CJK text is
+// split into characters and katakana runs instead of being one word.
+func Words(text string) []string {
+	segmented := segmentWords(text)
+	words := make([]string, len(segmented))
+	for i, w := range segmented {
+		words[i] = w.text
+	}
+	return words
+}
+
+// CountWords returns the number of Words of text.
+func CountWords(text string) int {
+	return len(segmentWords(text))
+}
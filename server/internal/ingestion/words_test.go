@@ -0,0 +1,43 @@
+package ingestion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, world.\n  Again", []string{"Hello,", "world.", "Again"}},
+		{"東京の天気。", []string{"東", "京", "の", "天", "気。"}},
+		{"データベースを使う", []string{"データベース", "を", "使", "う"}},
+		{"GPUサーバー、2台", []string{"GPU", "サーバー、", "2", "台"}},
+		{"「東京」へ", []string{"「", "東", "京」", "へ"}},
+		{"한국어 텍스트", []string{"한국어", "텍스트"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got := Words(tt.text)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestJoinWords(t *testing.T) {
+	tests := map[string]string{
+		"Hello,  world\nagain": "Hello, world again",
+		"東京の 天気です。":            "東京の 天気です。",
+		"use サーバー now":         "use サーバー now",
+	}
+	for in, want := range tests {
+		if got := joinWords(segmentWords(in)); got != want {
+			t.Errorf("joinWords(segmentWords(%q)) = %q, want %q", in, got, want)
+		}
+	}
+}
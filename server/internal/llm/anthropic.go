@@ -0,0 +1,328 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+const (
+	// DefaultAnthropicBaseURL is the Anthropic API endpoint.
+	DefaultAnthropicBaseURL = "https://api.anthropic.com"
+
+	// DefaultAnthropicModel is the default Anthropic model.
+	DefaultAnthropicModel = "claude-3-5-haiku-latest"
+
+	// anthropicVersion is the Messages API version header value.
+	anthropicVersion = "2023-06-01"
+
+	// defaultAnthropicMaxTokens is used when no limit is given; the API requires one.
+	defaultAnthropicMaxTokens = 2048
+)
+
+// AnthropicConfig holds configuration for the Anthropic client.
+type AnthropicConfig struct {
+	// BaseURL is the API endpoint (default: https://api.anthropic.com).
+	BaseURL string
+
+	// APIKey is the Anthropic API key.
+	APIKey string
+
+	// Model is the default model (default: claude-3-5-haiku-latest).
+	Model string
+
+	// Timeout bounds non-streaming requests (default: 5 minutes).
+	Timeout time.Duration
+}
+
+// AnthropicClient implements the LLM interface using Anthropic's Messages API.
+type AnthropicClient struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewAnthropicClient creates a new Anthropic client with the given configuration.
+func NewAnthropicClient(cfg AnthropicConfig) *AnthropicClient {
+	c := &AnthropicClient{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+	if c.baseURL == "" {
+		c.baseURL = DefaultAnthropicBaseURL
+	}
+	if c.model == "" {
+		c.model = DefaultAnthropicModel
+	}
+	if c.httpClient.Timeout <= 0 {
+		c.httpClient.Timeout = 5 * time.Minute
+	}
+	return c
+}
+
+// anthropicRequest represents the request body for the Messages API.
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	Temperature *float32           `json:"temperature,omitempty"`
+	Stream      bool               `json:"stream,omitempty"`
+	Tools       []anthropicTool    `json:"tools,omitempty"`
+	ToolChoice  *anthropicChoice   `json:"tool_choice,omitempty"`
+}
+
+// anthropicTool declares a tool whose input must match InputSchema.
+type anthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+type anthropicChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// structuredToolName is the tool forced for GenerateStructured; its input is the answer.
+const structuredToolName = "respond"
+
+type anthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []anthropicContent `json:"content"`
+}
+
+type anthropicContent struct {
+	Type   string           `json:"type"`
+	Text   string           `json:"text,omitempty"`
+	Source *anthropicSource `json:"source,omitempty"`
+	Name   string           `json:"name,omitempty"`
+	Input  json.RawMessage  `json:"input,omitempty"`
+}
+
+type anthropicSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// anthropicResponse represents a non-streaming Messages API response.
+type anthropicResponse struct {
+	Content []anthropicContent `json:"content"`
+}
+
+// anthropicStreamEvent represents a server-sent event payload.
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ModelName returns the default model used by the client.
+func (c *AnthropicClient) ModelName() string {
+	return c.model
+}
+
+// Generate sends a prompt to Anthropic and returns the complete response.
+func (c *AnthropicClient) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	return c.generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStructured forces a tool call whose input schema is the requested
+// schema, which is how the Messages API guarantees JSON output.
+func (c *AnthropicClient) GenerateStructured(ctx context.Context, prompt string, schema json.RawMessage, opts GenerateOptions) (json.RawMessage, error) {
+	return generateStructured(ctx, prompt, schema, func(ctx context.Context, prompt string) (string, error) {
+		return c.generate(ctx, prompt, opts, schema)
+	})
+}
+
+// generate runs a non-streaming request. With a schema, it returns the forced
+// tool call's input instead of the text blocks.
+func (c *AnthropicClient) generate(ctx context.Context, prompt string, opts GenerateOptions, schema json.RawMessage) (string, error) {
+	req, err := c.buildRequest(ctx, prompt, opts, false, schema)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", &APIError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, block := range result.Content {
+		if schema != nil {
+			if block.Type == "tool_use" && block.Name == structuredToolName {
+				return string(block.Input), nil
+			}
+			continue
+		}
+		if block.Type == "text" {
+			sb.WriteString(block.Text)
+		}
+	}
+	return sb.String(), nil
+}
+
+// GenerateStream sends a prompt to Anthropic and streams the response over SSE.
+func (c *AnthropicClient) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan StreamChunk, error) {
+	req, err := c.buildRequest(ctx, prompt, opts, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+
+	// Create a client without timeout for streaming (context handles cancellation)
+	streamClient := &http.Client{}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &APIError{Provider: "anthropic", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	chunks := make(chan StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		send := func(chunk StreamChunk) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case chunks <- chunk:
+				return true
+			}
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+				send(StreamChunk{Error: fmt.Errorf("parsing stream event: %w", err), Done: true})
+				return
+			}
+
+			switch event.Type {
+			case "content_block_delta":
+				if event.Delta.Type == "text_delta" && !send(StreamChunk{Token: event.Delta.Text}) {
+					return
+				}
+			case "message_stop":
+				send(StreamChunk{Done: true})
+				return
+			case "error":
+				send(StreamChunk{Error: fmt.Errorf("anthropic stream error: %s: %s", event.Error.Type, event.Error.Message), Done: true})
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(StreamChunk{Error: fmt.Errorf("reading stream: %w", err), Done: true})
+		}
+	}()
+
+	return chunks, nil
+}
+
+// buildRequest constructs the HTTP request for the Messages API.
+func (c *AnthropicClient) buildRequest(ctx context.Context, prompt string, opts GenerateOptions, stream bool, schema json.RawMessage) (*http.Request, error) {
+	model := opts.Model
+	if model == "" {
+		model = c.model
+	}
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultAnthropicMaxTokens
+	}
+
+	var content []anthropicContent
+	for _, img := range opts.Images {
+		content = append(content, anthropicContent{
+			Type: "image",
+			Source: &anthropicSource{
+				Type:      "base64",
+				MediaType: http.DetectContentType(img),
+				Data:      base64.StdEncoding.EncodeToString(img),
+			},
+		})
+	}
+	content = append(content, anthropicContent{Type: "text", Text: prompt})
+
+	reqBody := anthropicRequest{
+		Model:     model,
+		MaxTokens: maxTokens,
+		System:    opts.SystemPrompt,
+		Messages:  []anthropicMessage{{Role: "user", Content: content}},
+		Stream:    stream,
+	}
+	if opts.Temperature > 0 {
+		reqBody.Temperature = &opts.Temperature
+	}
+	if schema != nil {
+		reqBody.Tools = []anthropicTool{{
+			Name:        structuredToolName,
+			Description: "Respond with the answer as structured data.",
+			InputSchema: schema,
+		}}
+		reqBody.ToolChoice = &anthropicChoice{Type: "tool", Name: structuredToolName}
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	return req, nil
+}
+
+// Ensure AnthropicClient implements LLM interface.
+var _ LLM = (*AnthropicClient)(nil)
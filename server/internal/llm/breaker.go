@@ -0,0 +1,130 @@
+package llm
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures that opens a circuit.
+	DefaultFailureThreshold = 5
+
+	// DefaultOpenTimeout is how long a circuit stays open before a trial request.
+	DefaultOpenTimeout = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned when a provider's circuit is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets all requests through.
+	BreakerClosed BreakerState = "closed"
+
+	// BreakerOpen rejects requests until the open timeout elapses.
+	BreakerOpen BreakerState = "open"
+
+	// BreakerHalfOpen lets a single trial request through.
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerConfig configures a circuit breaker.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default: 5).
+	FailureThreshold int
+
+	// OpenTimeout is how long the circuit stays open (default: 30s).
+	OpenTimeout time.Duration
+}
+
+// CircuitBreaker stops sending requests to a failing backend. After
+// FailureThreshold consecutive failures it opens; once OpenTimeout elapses a
+// single trial request is allowed, closing the circuit on success.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	timeout   time.Duration
+	failures  int
+	state     BreakerState
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	b := &CircuitBreaker{
+		threshold: cfg.FailureThreshold,
+		timeout:   cfg.OpenTimeout,
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+	if b.threshold <= 0 {
+		b.threshold = DefaultFailureThreshold
+	}
+	if b.timeout <= 0 {
+		b.timeout = DefaultOpenTimeout
+	}
+	return b
+}
+
+// Allow reports whether a request may be sent.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.timeout {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// Success records a successful request, closing the circuit.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.state = BreakerClosed
+	b.probing = false
+}
+
+// Failure records a failed request, opening the circuit at the threshold or
+// when a trial request fails.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// State returns the current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.timeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// APIError is a non-200 response from an LLM backend.
+type APIError struct {
+	// Provider identifies the backend (e.g., "ollama", "anthropic").
+	Provider string
+
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// Body is the response body, usually an error message.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API error (status %d): %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// IsRetryable reports whether an error is a backend failure that another
+// provider might not share: 5xx and 429 responses, timeouts and connection
+// errors. Client errors and cancellation are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -3,6 +3,7 @@ package llm
 
 import (
 	"context"
+	"encoding/json"
 )
 
 // GenerateOptions configures the LLM generation request.
@@ -18,6 +19,14 @@ type GenerateOptions struct {
 
 	// MaxTokens limits the maximum number of tokens in the response.
 	MaxTokens int
+
+	// Images are attached to the prompt for multimodal models (e.g., "llava").
+	Images [][]byte
+
+	// Fallbacks are models tried in order if Model fails with a retryable
+	// error. Only honored by Registry; model references may name a provider
+	// (e.g., "anthropic/claude-3-5-haiku-latest").
+	Fallbacks []string
 }
 
 // StreamChunk represents a single chunk of streamed response from the LLM.
@@ -43,4 +52,10 @@ type LLM interface {
 	// completes or an error occurs. Callers should check StreamChunk.Error and
 	// StreamChunk.Done to detect completion and errors.
 	GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan StreamChunk, error)
+
+	// GenerateStructured returns JSON matching the given JSON schema, using the
+	// provider's native JSON mode where available. Output that fails
+	// validation is retried with the validation error as feedback, returning
+	// ErrInvalidStructuredOutput if no attempt succeeds.
+	GenerateStructured(ctx context.Context, prompt string, schema json.RawMessage, opts GenerateOptions) (json.RawMessage, error)
 }
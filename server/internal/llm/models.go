@@ -0,0 +1,150 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModelInfo describes an LLM's limits, capabilities and price.
+type ModelInfo struct {
+	// Provider is the kind of provider that serves the model: "ollama",
+	// "anthropic" or "openai".
+	Provider string
+
+	// ContextLength is the context window in tokens, shared by the prompt
+	// and the completion.
+	ContextLength int
+
+	// JSONMode reports whether the model can be constrained to JSON output.
+	JSONMode bool
+
+	// Tools reports whether the model supports tool (function) calling.
+	Tools bool
+
+	// InputCostPerToken and OutputCostPerToken are list prices in USD per
+	// prompt and completion token; zero for models run locally.
+	InputCostPerToken  float64
+	OutputCostPerToken float64
+}
+
+// Cost returns the price in USD of a request with the given token counts.
+func (m ModelInfo) Cost(promptTokens, completionTokens int) float64 {
+	return float64(promptTokens)*m.InputCostPerToken + float64(completionTokens)*m.OutputCostPerToken
+}
+
+// DefaultContextLength is assumed for models not in ModelRegistry.
+const DefaultContextLength = 8192
+
+// ollamaContextLength is the context Ollama runs models with unless num_ctx
+// is set, which this client does not do; it is far below most models'
+// trained maximum.
+const ollamaContextLength = 4096
+
+// ollamaModel describes a model run locally by Ollama, which can constrain
+// any model to JSON.
+func ollamaModel(tools bool) ModelInfo {
+	return ModelInfo{Provider: "ollama", ContextLength: ollamaContextLength, JSONMode: true, Tools: tools}
+}
+
+// hostedModel describes a model of a hosted provider, priced in USD per
+// million tokens. Hosted models in the registry all support JSON output
+// and tools.
+func hostedModel(provider string, contextLength int, inputPerMillion, outputPerMillion float64) ModelInfo {
+	return ModelInfo{
+		Provider:           provider,
+		ContextLength:      contextLength,
+		JSONMode:           true,
+		Tools:              true,
+		InputCostPerToken:  inputPerMillion / 1e6,
+		OutputCostPerToken: outputPerMillion / 1e6,
+	}
+}
+
+// ModelRegistry maps model names to what is known about them. Model names
+// are looked up without their provider prefix.
+var ModelRegistry = map[string]ModelInfo{
+	// Ollama
+	"llama3.2":        ollamaModel(true),
+	"llama3.1":        ollamaModel(true),
+	"llama3":          ollamaModel(false),
+	"mistral":         ollamaModel(true),
+	"qwen2.5":         ollamaModel(true),
+	"gemma2":          ollamaModel(false),
+	"phi3":            ollamaModel(false),
+	"llava":           ollamaModel(false),
+	"llama3.2-vision": ollamaModel(false),
+
+	// Anthropic
+	"claude-3-5-haiku-latest":  hostedModel("anthropic", 200000, 0.80, 4),
+	"claude-3-5-sonnet-latest": hostedModel("anthropic", 200000, 3, 15),
+	"claude-3-7-sonnet-latest": hostedModel("anthropic", 200000, 3, 15),
+	"claude-3-haiku-20240307":  hostedModel("anthropic", 200000, 0.25, 1.25),
+	"claude-sonnet-4-0":        hostedModel("anthropic", 200000, 3, 15),
+	"claude-opus-4-0":          hostedModel("anthropic", 200000, 15, 75),
+
+	// OpenAI
+	"gpt-4o":        hostedModel("openai", 128000, 2.50, 10),
+	"gpt-4o-mini":   hostedModel("openai", 128000, 0.15, 0.60),
+	"gpt-4.1":       hostedModel("openai", 1047576, 2, 8),
+	"gpt-4.1-mini":  hostedModel("openai", 1047576, 0.40, 1.60),
+	"gpt-3.5-turbo": hostedModel("openai", 16385, 0.50, 1.50),
+}
+
+// LookupModel returns what is known about a model. References may name a
+// provider ("anthropic/claude-3-5-haiku-latest") or an Ollama tag
+// ("llama3.2:3b"), which are tried without.
+func LookupModel(ref string) (ModelInfo, bool) {
+	names := []string{ref}
+	if _, model, ok := strings.Cut(ref, "/"); ok {
+		names = append(names, model)
+	}
+	for _, name := range names {
+		if info, ok := ModelRegistry[name]; ok {
+			return info, true
+		}
+		if base, _, ok := strings.Cut(name, ":"); ok {
+			if info, ok := ModelRegistry[base]; ok {
+				return info, true
+			}
+		}
+	}
+	return ModelInfo{}, false
+}
+
+// GetModelInfo returns what is known about a model, or defaults if unknown.
+func GetModelInfo(ref string) ModelInfo {
+	if info, ok := LookupModel(ref); ok {
+		return info
+	}
+	return ModelInfo{ContextLength: DefaultContextLength}
+}
+
+// ValidateModel checks a model reference. Models missing from the registry
+// are accepted, since providers serve many more, but a reference must name a
+// model, and a registered model must not be routed to another kind of
+// provider ("ollama/gpt-4o").
+func ValidateModel(ref string) error {
+	if strings.TrimSpace(ref) != ref || ref == "" {
+		return fmt.Errorf("invalid model reference %q", ref)
+	}
+	provider, model, ok := strings.Cut(ref, "/")
+	if !ok {
+		return nil
+	}
+	if provider == "" || model == "" {
+		return fmt.Errorf("invalid model reference %q: want provider/model", ref)
+	}
+	if info, ok := LookupModel(model); ok && isProviderKind(provider) && provider != info.Provider {
+		return fmt.Errorf("model %s is served by %s, not %s", model, info.Provider, provider)
+	}
+	return nil
+}
+
+// isProviderKind reports whether name is a provider kind models are registered under.
+func isProviderKind(name string) bool {
+	switch name {
+	case "ollama", "anthropic", "openai":
+		return true
+	}
+	return false
+}
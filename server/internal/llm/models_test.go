@@ -0,0 +1,53 @@
+package llm
+
+import "testing"
+
+func TestLookupModel(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+		ok   bool
+	}{
+		{"gpt-4o", 128000, true},
+		{"anthropic/claude-3-5-haiku-latest", 200000, true},
+		{"llama3.2:3b", ollamaContextLength, true},
+		{"ollama/llama3.2:1b", ollamaContextLength, true},
+		{"my-finetune", 0, false},
+	}
+	for _, tt := range tests {
+		info, ok := LookupModel(tt.ref)
+		if ok != tt.ok || info.ContextLength != tt.want {
+			t.Errorf("LookupModel(%q) = %d, %v; want %d, %v", tt.ref, info.ContextLength, ok, tt.want, tt.ok)
+		}
+	}
+	if got := GetModelInfo("my-finetune").ContextLength; got != DefaultContextLength {
+		t.Errorf("GetModelInfo of unknown model = %d, want %d", got, DefaultContextLength)
+	}
+}
+
+func TestModelCost(t *testing.T) {
+	info := GetModelInfo("openai/gpt-4o")
+	if !info.JSONMode || !info.Tools {
+		t.Errorf("gpt-4o capabilities = %+v", info)
+	}
+	// 1M prompt tokens at $2.50 and 100k completion tokens at $10 per million
+	if got := info.Cost(1000000, 100000); got < 3.4999 || got > 3.5001 {
+		t.Errorf("Cost = %f, want 3.50", got)
+	}
+	if got := GetModelInfo("llama3.2").Cost(1000, 1000); got != 0 {
+		t.Errorf("local model cost = %f, want 0", got)
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	for _, ref := range []string{"llama3.2", "ollama/llama3.2:3b", "anthropic/claude-sonnet-4-0", "vllm/meta-llama/Llama-3.1-8B-Instruct", "my-finetune"} {
+		if err := ValidateModel(ref); err != nil {
+			t.Errorf("ValidateModel(%q) = %v", ref, err)
+		}
+	}
+	for _, ref := range []string{"", " llama3.2", "anthropic/", "/gpt-4o", "ollama/gpt-4o", "openai/claude-opus-4-0"} {
+		if err := ValidateModel(ref); err == nil {
+			t.Errorf("ValidateModel(%q) succeeded", ref)
+		}
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/knoguchi/rag/internal/requestid"
 )
 
 const (
@@ -51,6 +54,13 @@ func WithHTTPClient(client *http.Client) OllamaOption {
 	}
 }
 
+// WithTimeout sets the timeout for non-streaming requests.
+func WithTimeout(timeout time.Duration) OllamaOption {
+	return func(c *OllamaClient) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
 // WithModel sets the default model for the client.
 func WithModel(model string) OllamaOption {
 	return func(c *OllamaClient) {
@@ -77,11 +87,13 @@ func NewOllamaClient(opts ...OllamaOption) *OllamaClient {
 
 // ollamaRequest represents the request body for Ollama's generate API.
 type ollamaRequest struct {
-	Model       string                 `json:"model"`
-	Prompt      string                 `json:"prompt"`
-	System      string                 `json:"system,omitempty"`
-	Stream      bool                   `json:"stream"`
-	Options     map[string]interface{} `json:"options,omitempty"`
+	Model   string                 `json:"model"`
+	Prompt  string                 `json:"prompt"`
+	System  string                 `json:"system,omitempty"`
+	Stream  bool                   `json:"stream"`
+	Images  []string               `json:"images,omitempty"`
+	Format  json.RawMessage        `json:"format,omitempty"`
+	Options map[string]interface{} `json:"options,omitempty"`
 }
 
 // ollamaResponse represents the response from Ollama's generate API.
@@ -102,7 +114,19 @@ type ollamaResponse struct {
 
 // Generate sends a prompt to Ollama and returns the complete response.
 func (c *OllamaClient) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
-	req, err := c.buildRequest(ctx, prompt, opts, false)
+	return c.generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStructured constrains Ollama's output to the schema via the format parameter.
+func (c *OllamaClient) GenerateStructured(ctx context.Context, prompt string, schema json.RawMessage, opts GenerateOptions) (json.RawMessage, error) {
+	return generateStructured(ctx, prompt, schema, func(ctx context.Context, prompt string) (string, error) {
+		return c.generate(ctx, schemaInstruction(prompt, schema), opts, schema)
+	})
+}
+
+// generate runs a non-streaming request, with output constrained to format if set.
+func (c *OllamaClient) generate(ctx context.Context, prompt string, opts GenerateOptions, format json.RawMessage) (string, error) {
+	req, err := c.buildRequest(ctx, prompt, opts, false, format)
 	if err != nil {
 		return "", fmt.Errorf("building request: %w", err)
 	}
@@ -115,7 +139,7 @@ func (c *OllamaClient) Generate(ctx context.Context, prompt string, opts Generat
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("ollama API error (status %d): %s", resp.StatusCode, string(body))
+		return "", &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result ollamaResponse
@@ -128,7 +152,7 @@ func (c *OllamaClient) Generate(ctx context.Context, prompt string, opts Generat
 
 // GenerateStream sends a prompt to Ollama and returns a channel that streams response chunks.
 func (c *OllamaClient) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan StreamChunk, error) {
-	req, err := c.buildRequest(ctx, prompt, opts, true)
+	req, err := c.buildRequest(ctx, prompt, opts, true, nil)
 	if err != nil {
 		return nil, fmt.Errorf("building request: %w", err)
 	}
@@ -143,7 +167,7 @@ func (c *OllamaClient) GenerateStream(ctx context.Context, prompt string, opts G
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("ollama API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	chunks := make(chan StreamChunk)
@@ -204,8 +228,62 @@ func (c *OllamaClient) GenerateStream(ctx context.Context, prompt string, opts G
 	return chunks, nil
 }
 
+// ollamaTagsResponse represents the response from Ollama's tags (list models) API.
+type ollamaTagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+// ModelName returns the default model used by the client.
+func (c *OllamaClient) ModelName() string {
+	return c.model
+}
+
+// Ping checks that Ollama is reachable and the default model has been pulled.
+func (c *OllamaClient) Ping(ctx context.Context) error {
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasModel(models, c.model) {
+		return fmt.Errorf("model %q is not available in ollama", c.model)
+	}
+	return nil
+}
+
+// ListModels returns the names of the models Ollama has pulled.
+func (c *OllamaClient) ListModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/tags", nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var tags ollamaTagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+
+	models := make([]string, len(tags.Models))
+	for i, m := range tags.Models {
+		models[i] = m.Name
+	}
+	return models, nil
+}
+
 // buildRequest constructs the HTTP request for the Ollama API.
-func (c *OllamaClient) buildRequest(ctx context.Context, prompt string, opts GenerateOptions, stream bool) (*http.Request, error) {
+func (c *OllamaClient) buildRequest(ctx context.Context, prompt string, opts GenerateOptions, stream bool, format json.RawMessage) (*http.Request, error) {
 	model := opts.Model
 	if model == "" {
 		model = c.model
@@ -216,6 +294,12 @@ func (c *OllamaClient) buildRequest(ctx context.Context, prompt string, opts Gen
 		Prompt: prompt,
 		System: opts.SystemPrompt,
 		Stream: stream,
+		Format: format,
+	}
+
+	// Ollama takes images base64-encoded
+	for _, img := range opts.Images {
+		reqBody.Images = append(reqBody.Images, base64.StdEncoding.EncodeToString(img))
 	}
 
 	// Build options map for temperature and max tokens
@@ -241,6 +325,7 @@ func (c *OllamaClient) buildRequest(ctx context.Context, prompt string, opts Gen
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
 
 	return req, nil
 }
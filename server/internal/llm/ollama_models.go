@@ -0,0 +1,148 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pullLogInterval is how often PullModel's progress is logged while a
+// download is under way
+const pullLogInterval = 5 * time.Second
+
+// MissingModelsError reports models Ollama doesn't have.
+type MissingModelsError struct {
+	BaseURL string
+	Models  []string
+}
+
+func (e *MissingModelsError) Error() string {
+	return fmt.Sprintf("ollama at %s does not have model(s) %s; pull them with `ollama pull %s`",
+		e.BaseURL, strings.Join(e.Models, ", "), strings.Join(e.Models, "` and `ollama pull "))
+}
+
+// ollamaPullProgress is a line of Ollama's streamed pull API response.
+type ollamaPullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// hasModel reports whether a model is among Ollama's model names, which
+// always carry a tag; a model without one is the ":latest" tag.
+func hasModel(models []string, model string) bool {
+	if !strings.Contains(model, ":") {
+		model += ":latest"
+	}
+	for _, m := range models {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureModels checks that Ollama has each of models. When pull is set the
+// missing ones are pulled, logging their progress; otherwise they are
+// reported in a *MissingModelsError.
+func (c *OllamaClient) EnsureModels(ctx context.Context, models []string, pull bool) error {
+	available, err := c.ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("ollama is not reachable at %s: %w", c.baseURL, err)
+	}
+
+	var missing []string
+	for _, model := range models {
+		if !hasModel(available, model) && !hasModel(missing, model) {
+			missing = append(missing, model)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if !pull {
+		return &MissingModelsError{BaseURL: c.baseURL, Models: missing}
+	}
+
+	for _, model := range missing {
+		slog.Info("pulling ollama model", "model", model)
+		start := time.Now()
+		if err := c.PullModel(ctx, model); err != nil {
+			return fmt.Errorf("failed to pull ollama model %q: %w", model, err)
+		}
+		slog.Info("pulled ollama model", "model", model, "duration", time.Since(start).Round(time.Second))
+	}
+	return nil
+}
+
+// PullModel downloads a model into Ollama, logging its progress. It blocks
+// until the pull finishes or ctx is cancelled.
+func (c *OllamaClient) PullModel(ctx context.Context, model string) error {
+	body, err := json.Marshal(map[string]any{"model": model, "stream": true})
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/pull", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Pulls outlast the client's timeout; the context bounds them instead
+	client := *c.httpClient
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var status string
+	var logged time.Time
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var progress ollamaPullProgress
+		if err := json.Unmarshal(line, &progress); err != nil {
+			return fmt.Errorf("decoding progress: %w", err)
+		}
+		if progress.Error != "" {
+			return fmt.Errorf("ollama: %s", progress.Error)
+		}
+		if progress.Status == "success" {
+			return nil
+		}
+
+		// Log each step, and downloads every pullLogInterval
+		if progress.Status != status || (progress.Total > 0 && time.Since(logged) >= pullLogInterval) {
+			status, logged = progress.Status, time.Now()
+			attrs := []any{"model", model, "status", progress.Status}
+			if progress.Total > 0 {
+				attrs = append(attrs, "percent", progress.Completed*100/progress.Total,
+					"completed_mb", progress.Completed>>20, "total_mb", progress.Total>>20)
+			}
+			slog.Info("pulling ollama model", attrs...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading progress: %w", err)
+	}
+	return fmt.Errorf("pull of %q ended without success", model)
+}
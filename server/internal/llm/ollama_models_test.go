@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestEnsureModels(t *testing.T) {
+	var mu sync.Mutex
+	models := []string{"nomic-embed-text:latest"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/api/tags":
+			var tags ollamaTagsResponse
+			for _, m := range models {
+				tags.Models = append(tags.Models, struct {
+					Name string `json:"name"`
+				}{Name: m})
+			}
+			_ = json.NewEncoder(w).Encode(tags)
+		case "/api/pull":
+			var req struct {
+				Model string `json:"model"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Model == "nope" {
+				fmt.Fprintln(w, `{"error":"pull model manifest: file does not exist"}`)
+				return
+			}
+			fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+			fmt.Fprintln(w, `{"status":"pulling abc","digest":"sha256:abc","total":100,"completed":50}`)
+			fmt.Fprintln(w, `{"status":"success"}`)
+			models = append(models, req.Model+":latest")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewOllamaClient(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	err := client.EnsureModels(ctx, []string{"nomic-embed-text", "llama3.2"}, false)
+	var missing *MissingModelsError
+	if !errors.As(err, &missing) || len(missing.Models) != 1 || missing.Models[0] != "llama3.2" {
+		t.Fatalf("without pulling: error = %v, want llama3.2 missing", err)
+	}
+
+	if err := client.EnsureModels(ctx, []string{"nomic-embed-text", "llama3.2"}, true); err != nil {
+		t.Fatalf("pulling: %v", err)
+	}
+	if err := client.EnsureModels(ctx, []string{"llama3.2:latest"}, false); err != nil {
+		t.Errorf("after pulling: %v", err)
+	}
+
+	if err := client.EnsureModels(ctx, []string{"nope"}, true); err == nil {
+		t.Error("pulling an unknown model succeeded")
+	}
+}
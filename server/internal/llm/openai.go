@@ -0,0 +1,358 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+const (
+	// DefaultOpenAIBaseURL is the OpenAI API endpoint.
+	DefaultOpenAIBaseURL = "https://api.openai.com"
+
+	// EndpointChat selects /v1/chat/completions.
+	EndpointChat = "chat"
+
+	// EndpointCompletions selects the legacy /v1/completions, for servers
+	// or models without a chat template.
+	EndpointCompletions = "completions"
+)
+
+// OpenAICompatConfig holds configuration for an OpenAI-compatible server.
+type OpenAICompatConfig struct {
+	// Provider names the backend in errors (default: "openai").
+	Provider string
+
+	// BaseURL is the server root, without /v1 (default: https://api.openai.com).
+	BaseURL string
+
+	// APIKey is sent as a bearer token, if set. Local servers usually need none.
+	APIKey string
+
+	// Model is the default model.
+	Model string
+
+	// Endpoint is EndpointChat (default) or EndpointCompletions.
+	Endpoint string
+
+	// Timeout bounds non-streaming requests (default: 5 minutes).
+	Timeout time.Duration
+}
+
+// OpenAICompatClient implements the LLM interface for servers that speak the
+// OpenAI API: OpenAI itself, vLLM, llama.cpp server and TGI.
+type OpenAICompatClient struct {
+	provider   string
+	baseURL    string
+	apiKey     string
+	model      string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewOpenAICompatClient creates a client for an OpenAI-compatible server.
+func NewOpenAICompatClient(cfg OpenAICompatConfig) *OpenAICompatClient {
+	c := &OpenAICompatClient{
+		provider:   cfg.Provider,
+		baseURL:    strings.TrimSuffix(strings.TrimSuffix(cfg.BaseURL, "/"), "/v1"),
+		apiKey:     cfg.APIKey,
+		model:      cfg.Model,
+		endpoint:   cfg.Endpoint,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+	if c.provider == "" {
+		c.provider = "openai"
+	}
+	if c.baseURL == "" {
+		c.baseURL = DefaultOpenAIBaseURL
+	}
+	if c.endpoint == "" {
+		c.endpoint = EndpointChat
+	}
+	if c.httpClient.Timeout <= 0 {
+		c.httpClient.Timeout = 5 * time.Minute
+	}
+	return c
+}
+
+// openAIRequest represents the request body for both completion endpoints.
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages,omitempty"`
+	Prompt      string          `json:"prompt,omitempty"`
+	Temperature *float32        `json:"temperature,omitempty"`
+	MaxTokens   int             `json:"max_tokens,omitempty"`
+	Stream      bool            `json:"stream,omitempty"`
+
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+// openAIResponseFormat requests schema-constrained output (OpenAI structured
+// outputs; vLLM and llama.cpp server implement it with guided decoding).
+type openAIResponseFormat struct {
+	Type       string `json:"type"`
+	JSONSchema struct {
+		Name   string          `json:"name"`
+		Schema json.RawMessage `json:"schema"`
+	} `json:"json_schema"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"` // string, or content parts when images are attached
+}
+
+type openAIContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	ImageURL *openAIImageURL `json:"image_url,omitempty"`
+}
+
+type openAIImageURL struct {
+	URL string `json:"url"`
+}
+
+// openAIResponse covers responses and stream events from both endpoints.
+type openAIResponse struct {
+	Choices []struct {
+		Text    string `json:"text"`
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// text returns the generated text of the first choice.
+func (r *openAIResponse) text() string {
+	if len(r.Choices) == 0 {
+		return ""
+	}
+	choice := r.Choices[0]
+	return choice.Message.Content + choice.Delta.Content + choice.Text
+}
+
+// ModelName returns the default model used by the client.
+func (c *OpenAICompatClient) ModelName() string {
+	return c.model
+}
+
+// Ping checks that the server is reachable via /v1/models.
+func (c *OpenAICompatClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/models", nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{Provider: c.provider, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return nil
+}
+
+// Generate sends a prompt and returns the complete response.
+func (c *OpenAICompatClient) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	return c.generate(ctx, prompt, opts, nil)
+}
+
+// GenerateStructured requests schema-constrained output via response_format.
+func (c *OpenAICompatClient) GenerateStructured(ctx context.Context, prompt string, schema json.RawMessage, opts GenerateOptions) (json.RawMessage, error) {
+	return generateStructured(ctx, prompt, schema, func(ctx context.Context, prompt string) (string, error) {
+		return c.generate(ctx, schemaInstruction(prompt, schema), opts, schema)
+	})
+}
+
+// generate runs a non-streaming request, with output constrained to schema if set.
+func (c *OpenAICompatClient) generate(ctx context.Context, prompt string, opts GenerateOptions, schema json.RawMessage) (string, error) {
+	req, err := c.buildRequest(ctx, prompt, opts, false, schema)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", &APIError{Provider: c.provider, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	return result.text(), nil
+}
+
+// GenerateStream sends a prompt and streams the response over SSE.
+func (c *OpenAICompatClient) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan StreamChunk, error) {
+	req, err := c.buildRequest(ctx, prompt, opts, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+
+	// Create a client without timeout for streaming (context handles cancellation)
+	streamClient := &http.Client{}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &APIError{Provider: c.provider, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	chunks := make(chan StreamChunk)
+
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		send := func(chunk StreamChunk) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case chunks <- chunk:
+				return true
+			}
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if data == "[DONE]" {
+				send(StreamChunk{Done: true})
+				return
+			}
+
+			var event openAIResponse
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				send(StreamChunk{Error: fmt.Errorf("parsing stream event: %w", err), Done: true})
+				return
+			}
+			if token := event.text(); token != "" && !send(StreamChunk{Token: token}) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(StreamChunk{Error: fmt.Errorf("reading stream: %w", err), Done: true})
+			return
+		}
+		// Some servers close the stream without a [DONE] sentinel
+		send(StreamChunk{Done: true})
+	}()
+
+	return chunks, nil
+}
+
+// buildRequest constructs the HTTP request for the configured endpoint.
+func (c *OpenAICompatClient) buildRequest(ctx context.Context, prompt string, opts GenerateOptions, stream bool, schema json.RawMessage) (*http.Request, error) {
+	model := opts.Model
+	if model == "" {
+		model = c.model
+	}
+
+	reqBody := openAIRequest{
+		Model:     model,
+		MaxTokens: opts.MaxTokens,
+		Stream:    stream,
+	}
+	if opts.Temperature > 0 {
+		reqBody.Temperature = &opts.Temperature
+	}
+
+	path := "/v1/chat/completions"
+	if c.endpoint == EndpointCompletions {
+		path = "/v1/completions"
+		reqBody.Prompt = prompt
+		if opts.SystemPrompt != "" {
+			reqBody.Prompt = opts.SystemPrompt + "\n\n" + prompt
+		}
+	} else {
+		if opts.SystemPrompt != "" {
+			reqBody.Messages = append(reqBody.Messages, openAIMessage{Role: "system", Content: opts.SystemPrompt})
+		}
+		reqBody.Messages = append(reqBody.Messages, openAIMessage{Role: "user", Content: userContent(prompt, opts.Images)})
+	}
+	// The legacy completions endpoint has no response_format; the schema is in the prompt
+	if schema != nil && c.endpoint != EndpointCompletions {
+		reqBody.ResponseFormat = &openAIResponseFormat{Type: "json_schema"}
+		reqBody.ResponseFormat.JSONSchema.Name = "response"
+		reqBody.ResponseFormat.JSONSchema.Schema = schema
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	c.setAuth(req)
+
+	return req, nil
+}
+
+// userContent is the prompt string, or text and image parts for multimodal requests.
+func userContent(prompt string, images [][]byte) any {
+	if len(images) == 0 {
+		return prompt
+	}
+	parts := []openAIContentPart{{Type: "text", Text: prompt}}
+	for _, img := range images {
+		parts = append(parts, openAIContentPart{
+			Type: "image_url",
+			ImageURL: &openAIImageURL{
+				URL: "data:" + http.DetectContentType(img) + ";base64," + base64.StdEncoding.EncodeToString(img),
+			},
+		})
+	}
+	return parts
+}
+
+func (c *OpenAICompatClient) setAuth(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+}
+
+// Ensure OpenAICompatClient implements LLM interface.
+var _ LLM = (*OpenAICompatClient)(nil)
@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAICompatClient_Generate(t *testing.T) {
+	var got openAIRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("missing bearer token")
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		switch r.URL.Path {
+		case "/v1/chat/completions":
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"chat answer"}}]}`))
+		case "/v1/completions":
+			_, _ = w.Write([]byte(`{"choices":[{"text":"completion answer"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	chat := NewOpenAICompatClient(OpenAICompatConfig{BaseURL: server.URL + "/v1", APIKey: "secret", Model: "m"})
+	answer, err := chat.Generate(context.Background(), "question", GenerateOptions{SystemPrompt: "be brief"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if answer != "chat answer" {
+		t.Errorf("unexpected answer %q", answer)
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Model != "m" {
+		t.Errorf("unexpected chat request: %+v", got)
+	}
+
+	completions := NewOpenAICompatClient(OpenAICompatConfig{BaseURL: server.URL, APIKey: "secret", Endpoint: EndpointCompletions})
+	answer, err = completions.Generate(context.Background(), "question", GenerateOptions{SystemPrompt: "be brief"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if answer != "completion answer" || got.Prompt != "be brief\n\nquestion" {
+		t.Errorf("unexpected completion %q for prompt %q", answer, got.Prompt)
+	}
+}
+
+func TestOpenAICompatClient_GenerateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"Hel\"}}]}\n\n" +
+			": keep-alive\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"lo\"}}]}\n\n" +
+			"data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewOpenAICompatClient(OpenAICompatConfig{Provider: "vllm", BaseURL: server.URL})
+	stream, err := client.GenerateStream(context.Background(), "hi", GenerateOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+	done := false
+	for chunk := range stream {
+		if chunk.Error != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Error)
+		}
+		sb.WriteString(chunk.Token)
+		done = done || chunk.Done
+	}
+	if sb.String() != "Hello" || !done {
+		t.Errorf("expected streamed %q with done, got %q (done=%v)", "Hello", sb.String(), done)
+	}
+}
+
+func TestOpenAICompatClient_ServerErrorIsRetryable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model loading", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewOpenAICompatClient(OpenAICompatConfig{Provider: "vllm", BaseURL: server.URL})
+	_, err := client.Generate(context.Background(), "hi", GenerateOptions{})
+	if !IsRetryable(err) {
+		t.Errorf("expected retryable error, got %v", err)
+	}
+}
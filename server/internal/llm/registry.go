@@ -0,0 +1,338 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProviderConfig configures an LLM backend.
+type ProviderConfig struct {
+	// Kind selects the client implementation: "ollama", "anthropic", "openai"
+	// or "vllm" (any OpenAI-compatible server, e.g. vLLM, llama.cpp, TGI).
+	Kind string
+
+	// BaseURL is the API endpoint (default: the provider's public or local endpoint).
+	BaseURL string
+
+	// APIKey authenticates with hosted providers.
+	APIKey string
+
+	// Model is the default model when a request does not name one.
+	Model string
+
+	// Endpoint selects the OpenAI-compatible API: EndpointChat (default) or
+	// EndpointCompletions.
+	Endpoint string
+
+	// Timeout bounds non-streaming requests (default: 5 minutes).
+	Timeout time.Duration
+}
+
+// NewProvider creates an LLM client for a provider configuration.
+func NewProvider(cfg ProviderConfig) (LLM, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	switch cfg.Kind {
+	case "ollama":
+		opts := []OllamaOption{WithTimeout(timeout)}
+		if cfg.BaseURL != "" {
+			opts = append(opts, WithBaseURL(cfg.BaseURL))
+		}
+		if cfg.Model != "" {
+			opts = append(opts, WithModel(cfg.Model))
+		}
+		return NewOllamaClient(opts...), nil
+	case "anthropic":
+		if cfg.APIKey == "" {
+			return nil, errors.New("anthropic provider requires an API key")
+		}
+		return NewAnthropicClient(AnthropicConfig{
+			BaseURL: cfg.BaseURL,
+			APIKey:  cfg.APIKey,
+			Model:   cfg.Model,
+			Timeout: timeout,
+		}), nil
+	case "openai", "vllm":
+		if cfg.Kind == "openai" && cfg.APIKey == "" && cfg.BaseURL == "" {
+			return nil, errors.New("openai provider requires an API key")
+		}
+		if cfg.Kind == "vllm" && cfg.BaseURL == "" {
+			return nil, errors.New("vllm provider requires a base URL")
+		}
+		return NewOpenAICompatClient(OpenAICompatConfig{
+			Provider: cfg.Kind,
+			BaseURL:  cfg.BaseURL,
+			APIKey:   cfg.APIKey,
+			Model:    cfg.Model,
+			Endpoint: cfg.Endpoint,
+			Timeout:  timeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM provider kind: %s", cfg.Kind)
+	}
+}
+
+// Registry routes generation requests to named providers. Model references
+// take the form "provider/model" (e.g., "vllm/meta-llama/Llama-3.1-70B-Instruct");
+// references without a registered provider prefix go to the default provider.
+//
+// GenerateOptions.Fallbacks lists models tried in order when the primary fails
+// with a retryable error (5xx, 429, timeout, connection failure). Each provider
+// has a circuit breaker, so a failing backend is skipped for all tenants until
+// it recovers instead of adding a timeout to every request.
+type Registry struct {
+	mu              sync.RWMutex
+	providers       map[string]*registeredProvider
+	defaultProvider string
+	breakerConfig   BreakerConfig
+}
+
+// registeredProvider is a provider and its circuit breaker.
+type registeredProvider struct {
+	name    string
+	client  LLM
+	breaker *CircuitBreaker
+}
+
+// NewRegistry creates an empty registry whose providers use the given breaker configuration.
+func NewRegistry(breakerConfig BreakerConfig) *Registry {
+	return &Registry{
+		providers:     make(map[string]*registeredProvider),
+		breakerConfig: breakerConfig,
+	}
+}
+
+// Register adds a provider under name. The first provider registered is the default.
+func (r *Registry) Register(name string, client LLM) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[name] = &registeredProvider{
+		name:    name,
+		client:  client,
+		breaker: NewCircuitBreaker(r.breakerConfig),
+	}
+	if r.defaultProvider == "" {
+		r.defaultProvider = name
+	}
+}
+
+// SetDefault sets the provider for model references without a provider prefix.
+func (r *Registry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.providers[name]; !ok {
+		return fmt.Errorf("unknown LLM provider: %s", name)
+	}
+	r.defaultProvider = name
+	return nil
+}
+
+// BreakerStates returns the circuit breaker state of each provider.
+func (r *Registry) BreakerStates() map[string]BreakerState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make(map[string]BreakerState, len(r.providers))
+	for name, p := range r.providers {
+		states[name] = p.breaker.State()
+	}
+	return states
+}
+
+// Route returns the provider a model reference is sent to and the model it
+// names there; an empty model is the provider's default.
+func (r *Registry) Route(ref string) (provider, model string, err error) {
+	p, model, err := r.resolve(ref)
+	if err != nil {
+		return "", "", err
+	}
+	return p.name, model, nil
+}
+
+// resolve splits a model reference into its provider and model name.
+func (r *Registry) resolve(ref string) (*registeredProvider, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name, model, ok := strings.Cut(ref, "/"); ok {
+		if p, ok := r.providers[name]; ok {
+			return p, model, nil
+		}
+	}
+	p, ok := r.providers[r.defaultProvider]
+	if !ok {
+		return nil, "", errors.New("no LLM providers registered")
+	}
+	return p, ref, nil
+}
+
+// candidates returns the primary model followed by its fallbacks.
+func candidates(opts GenerateOptions) []string {
+	return append([]string{opts.Model}, opts.Fallbacks...)
+}
+
+// Generate sends the prompt to the primary model, failing over to fallbacks.
+func (r *Registry) Generate(ctx context.Context, prompt string, opts GenerateOptions) (string, error) {
+	return failover(ctx, r, opts, func(client LLM, attempt GenerateOptions) (string, error) {
+		return client.Generate(ctx, prompt, attempt)
+	})
+}
+
+// GenerateStructured requests schema-conforming JSON from the primary model,
+// failing over to fallbacks. Output that never validates is not a provider
+// failure, so ErrInvalidStructuredOutput is returned without failover.
+func (r *Registry) GenerateStructured(ctx context.Context, prompt string, schema json.RawMessage, opts GenerateOptions) (json.RawMessage, error) {
+	return failover(ctx, r, opts, func(client LLM, attempt GenerateOptions) (json.RawMessage, error) {
+		return client.GenerateStructured(ctx, prompt, schema, attempt)
+	})
+}
+
+// failover runs call against each candidate model until one succeeds or fails
+// with a non-retryable error.
+func failover[T any](ctx context.Context, r *Registry, opts GenerateOptions, call func(LLM, GenerateOptions) (T, error)) (T, error) {
+	var zero T
+	var lastErr error
+	for _, ref := range candidates(opts) {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		p, model, err := r.resolve(ref)
+		if err != nil {
+			return zero, err
+		}
+		if !p.breaker.Allow() {
+			lastErr = fmt.Errorf("%s: %w", p.name, ErrCircuitOpen)
+			continue
+		}
+
+		attempt := opts
+		attempt.Model = model
+		attempt.Fallbacks = nil
+		result, err := call(p.client, attempt)
+		if err == nil {
+			p.breaker.Success()
+			return result, nil
+		}
+		if !IsRetryable(err) {
+			// The backend answered; the request itself was bad
+			p.breaker.Success()
+			return zero, err
+		}
+
+		p.breaker.Failure()
+		slog.Warn("LLM provider failed, trying fallback", "provider", p.name, "model", model, "error", err)
+		lastErr = err
+	}
+	return zero, fmt.Errorf("all LLM providers failed: %w", lastErr)
+}
+
+// GenerateStream starts a stream on the primary model, failing over to
+// fallbacks if the stream cannot be started. Errors after streaming begins are
+// passed through, since tokens may already have reached the caller.
+func (r *Registry) GenerateStream(ctx context.Context, prompt string, opts GenerateOptions) (<-chan StreamChunk, error) {
+	var lastErr error
+	for _, ref := range candidates(opts) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		p, model, err := r.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		if !p.breaker.Allow() {
+			lastErr = fmt.Errorf("%s: %w", p.name, ErrCircuitOpen)
+			continue
+		}
+
+		attempt := opts
+		attempt.Model = model
+		attempt.Fallbacks = nil
+		stream, err := p.client.GenerateStream(ctx, prompt, attempt)
+		if err == nil {
+			return trackStream(ctx, stream, p.breaker), nil
+		}
+		if !IsRetryable(err) {
+			p.breaker.Success()
+			return nil, err
+		}
+
+		p.breaker.Failure()
+		slog.Warn("LLM provider failed to stream, trying fallback", "provider", p.name, "model", model, "error", err)
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all LLM providers failed: %w", lastErr)
+}
+
+// trackStream forwards a stream, recording its outcome on the breaker.
+func trackStream(ctx context.Context, in <-chan StreamChunk, breaker *CircuitBreaker) <-chan StreamChunk {
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		failed := false
+		for chunk := range in {
+			if chunk.Error != nil && IsRetryable(chunk.Error) {
+				failed = true
+			}
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// The caller went away; drain so the provider can finish. The
+				// backend was streaming, so it counts as healthy.
+				for range in {
+				}
+				breaker.Success()
+				return
+			}
+		}
+		if failed {
+			breaker.Failure()
+		} else {
+			breaker.Success()
+		}
+	}()
+	return out
+}
+
+// pinger is implemented by providers that support health checks.
+type pinger interface {
+	Ping(ctx context.Context) error
+	ModelName() string
+}
+
+// ModelName returns the default provider's model, qualified by provider name.
+func (r *Registry) ModelName() string {
+	p, _, err := r.resolve("")
+	if err != nil {
+		return ""
+	}
+	if pp, ok := p.client.(pinger); ok {
+		return p.name + "/" + pp.ModelName()
+	}
+	return p.name
+}
+
+// Ping checks the default provider.
+func (r *Registry) Ping(ctx context.Context) error {
+	p, _, err := r.resolve("")
+	if err != nil {
+		return err
+	}
+	if pp, ok := p.client.(pinger); ok {
+		return pp.Ping(ctx)
+	}
+	return nil
+}
+
+// Ensure Registry implements LLM interface.
+var _ LLM = (*Registry)(nil)
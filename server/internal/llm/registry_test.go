@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeLLM returns err, or echoes the model it was asked for
+type fakeLLM struct {
+	err   error
+	calls int
+}
+
+func (f *fakeLLM) Generate(_ context.Context, _ string, opts GenerateOptions) (string, error) {
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return opts.Model, nil
+}
+
+func (f *fakeLLM) GenerateStream(context.Context, string, GenerateOptions) (<-chan StreamChunk, error) {
+	return nil, f.err
+}
+
+func (f *fakeLLM) GenerateStructured(ctx context.Context, prompt string, _ json.RawMessage, opts GenerateOptions) (json.RawMessage, error) {
+	answer, err := f.Generate(ctx, prompt, opts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(answer)
+}
+
+func TestRegistry_Failover(t *testing.T) {
+	primary := &fakeLLM{err: &APIError{Provider: "ollama", StatusCode: 503, Body: "overloaded"}}
+	backup := &fakeLLM{}
+
+	registry := NewRegistry(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	registry.Register("ollama", primary)
+	registry.Register("backup", backup)
+
+	opts := GenerateOptions{Model: "llama3.2", Fallbacks: []string{"backup/big-model"}}
+	for i := 0; i < 3; i++ {
+		answer, err := registry.Generate(context.Background(), "hi", opts)
+		if err != nil {
+			t.Fatalf("attempt %d: unexpected error: %v", i, err)
+		}
+		if answer != "big-model" {
+			t.Errorf("expected fallback model without provider prefix, got %q", answer)
+		}
+	}
+
+	// The breaker opened after two failures, so the third request skipped the primary
+	if primary.calls != 2 {
+		t.Errorf("expected primary to be called twice, got %d", primary.calls)
+	}
+	if state := registry.BreakerStates()["ollama"]; state != BreakerOpen {
+		t.Errorf("expected open breaker, got %s", state)
+	}
+}
+
+func TestRegistry_NoFailoverOnClientError(t *testing.T) {
+	badRequest := &APIError{Provider: "ollama", StatusCode: 400, Body: "bad prompt"}
+	backup := &fakeLLM{}
+
+	registry := NewRegistry(BreakerConfig{})
+	registry.Register("ollama", &fakeLLM{err: badRequest})
+	registry.Register("backup", backup)
+
+	_, err := registry.Generate(context.Background(), "hi", GenerateOptions{Fallbacks: []string{"backup/m"}})
+	if !errors.Is(err, badRequest) {
+		t.Errorf("expected client error to be returned, got %v", err)
+	}
+	if backup.calls != 0 {
+		t.Error("expected no failover for client errors")
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	if b.Allow() {
+		t.Fatal("expected open breaker to reject requests")
+	}
+
+	now = now.Add(2 * time.Second)
+	if !b.Allow() {
+		t.Fatal("expected a trial request after the open timeout")
+	}
+	if b.Allow() {
+		t.Error("expected only one concurrent trial request")
+	}
+
+	b.Success()
+	if b.State() != BreakerClosed || !b.Allow() {
+		t.Error("expected successful trial to close the breaker")
+	}
+}
@@ -0,0 +1,192 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultStructuredAttempts is how many times GenerateStructured asks the model
+// before giving up on output that does not match the schema.
+const DefaultStructuredAttempts = 3
+
+// ErrInvalidStructuredOutput is returned when no attempt produced JSON
+// matching the schema.
+var ErrInvalidStructuredOutput = errors.New("model output does not match schema")
+
+// jsonGenerator produces raw JSON text for a prompt using a provider's native JSON mode.
+type jsonGenerator func(ctx context.Context, prompt string) (string, error)
+
+// generateStructured calls generate until its output parses and validates
+// against schema. Each retry tells the model what was wrong with its last answer.
+func generateStructured(ctx context.Context, prompt string, schema json.RawMessage, generate jsonGenerator) (json.RawMessage, error) {
+	var parsedSchema map[string]any
+	if err := json.Unmarshal(schema, &parsedSchema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	attemptPrompt := prompt
+	var lastErr error
+	for attempt := 0; attempt < DefaultStructuredAttempts; attempt++ {
+		output, err := generate(ctx, attemptPrompt)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := extractJSON(output)
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			lastErr = fmt.Errorf("invalid JSON: %w", err)
+		} else if err := validateSchema(value, parsedSchema, "$"); err != nil {
+			lastErr = err
+		} else {
+			return raw, nil
+		}
+
+		attemptPrompt = fmt.Sprintf("%s\n\nYour previous response was rejected (%v). Respond with only JSON matching this schema:\n%s",
+			prompt, lastErr, schema)
+	}
+	return nil, fmt.Errorf("%w: %v", ErrInvalidStructuredOutput, lastErr)
+}
+
+// extractJSON trims prose and markdown fences that models sometimes wrap
+// around JSON despite being asked not to.
+func extractJSON(s string) json.RawMessage {
+	s = strings.TrimSpace(s)
+	if json.Valid([]byte(s)) {
+		return json.RawMessage(s)
+	}
+	start := strings.IndexAny(s, "{[")
+	end := strings.LastIndexAny(s, "}]")
+	if start >= 0 && end > start {
+		return json.RawMessage(s[start : end+1])
+	}
+	return json.RawMessage(s)
+}
+
+// schemaInstruction is appended to prompts for providers without a native schema mode.
+func schemaInstruction(prompt string, schema json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, schema); err != nil {
+		compact.Write(schema)
+	}
+	return prompt + "\n\nRespond with only a JSON value matching this JSON schema, no explanation:\n" + compact.String()
+}
+
+// validateSchema checks a decoded JSON value against the commonly used subset
+// of JSON Schema: type, enum, properties, required, additionalProperties,
+// items, minItems/maxItems and minimum/maximum.
+func validateSchema(value any, schema map[string]any, path string) error {
+	if t, ok := schema["type"]; ok && !matchesType(value, t) {
+		return fmt.Errorf("%s: expected type %v, got %s", path, t, jsonType(value))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: value %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		required, _ := schema["required"].([]any)
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := v[name]; !present {
+					return fmt.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			propSchema, ok := properties[k].(map[string]any)
+			if !ok {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, k)
+				}
+				continue
+			}
+			if err := validateSchema(v[k], propSchema, path+"."+k); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if n, ok := schema["minItems"].(float64); ok && float64(len(v)) < n {
+			return fmt.Errorf("%s: expected at least %v items, got %d", path, n, len(v))
+		}
+		if n, ok := schema["maxItems"].(float64); ok && float64(len(v)) > n {
+			return fmt.Errorf("%s: expected at most %v items, got %d", path, n, len(v))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				if err := validateSchema(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			return fmt.Errorf("%s: %v is below minimum %v", path, v, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			return fmt.Errorf("%s: %v is above maximum %v", path, v, max)
+		}
+	}
+	return nil
+}
+
+// matchesType reports whether value has the schema type (a name or list of names).
+func matchesType(value any, t any) bool {
+	switch t := t.(type) {
+	case string:
+		actual := jsonType(value)
+		return actual == t || (t == "number" && actual == "integer")
+	case []any:
+		for _, name := range t {
+			if matchesType(value, name) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// jsonType names the JSON Schema type of a decoded value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "label": {"type": "string", "enum": ["yes", "no"]},
+    "confidence": {"type": "number", "minimum": 0, "maximum": 1}
+  },
+  "required": ["label", "confidence"]
+}`)
+
+func TestGenerateStructured_RetriesWithFeedback(t *testing.T) {
+	outputs := []string{
+		`{"label": "maybe", "confidence": 0.5}`,
+		"```json\n{\"label\": \"yes\", \"confidence\": 0.9}\n```",
+	}
+	var prompts []string
+	generate := func(_ context.Context, prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		out := outputs[0]
+		outputs = outputs[1:]
+		return out, nil
+	}
+
+	raw, err := generateStructured(context.Background(), "classify", testSchema, generate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(raw) != `{"label": "yes", "confidence": 0.9}` {
+		t.Errorf("unexpected output %s", raw)
+	}
+	if len(prompts) != 2 || !strings.Contains(prompts[1], "$.label") {
+		t.Errorf("expected retry prompt to include the validation error, got %q", prompts)
+	}
+}
+
+func TestGenerateStructured_GivesUp(t *testing.T) {
+	calls := 0
+	generate := func(context.Context, string) (string, error) {
+		calls++
+		return `{"label": "yes"}`, nil
+	}
+
+	_, err := generateStructured(context.Background(), "classify", testSchema, generate)
+	if !errors.Is(err, ErrInvalidStructuredOutput) {
+		t.Errorf("expected ErrInvalidStructuredOutput, got %v", err)
+	}
+	if calls != DefaultStructuredAttempts {
+		t.Errorf("expected %d attempts, got %d", DefaultStructuredAttempts, calls)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	var schema map[string]any
+	_ = json.Unmarshal([]byte(`{
+	  "type": "array",
+	  "maxItems": 2,
+	  "items": {"type": "object", "properties": {"n": {"type": "integer"}}, "additionalProperties": false}
+	}`), &schema)
+
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{`[{"n": 1}, {"n": 2}]`, false},
+		{`[{"n": 1.5}]`, true},
+		{`[{"n": 1, "extra": true}]`, true},
+		{`[{}, {}, {}]`, true},
+		{`{"n": 1}`, true},
+	}
+	for _, tt := range tests {
+		var value any
+		_ = json.Unmarshal([]byte(tt.input), &value)
+		if err := validateSchema(value, schema, "$"); (err != nil) != tt.wantErr {
+			t.Errorf("validateSchema(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+}
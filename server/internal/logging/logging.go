@@ -0,0 +1,219 @@
+// Package logging builds the service's structured logger: JSON or text
+// output, levels per component and for chosen tenants, request and tenant
+// IDs from the context, and redaction of API keys and document content.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+// Output formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// ComponentKey is the attribute naming the component a logger belongs to,
+// whose level Config.ComponentLevels may set.
+const ComponentKey = "component"
+
+// Config configures a logger.
+type Config struct {
+	// Format is FormatJSON (the default) or FormatText.
+	Format string
+
+	// Level is the lowest level logged, unless ComponentLevels sets another
+	// for the record's component.
+	Level slog.Level
+
+	// ComponentLevels are the levels of components, such as "grpc" or
+	// "http", that override Level.
+	ComponentLevels map[string]slog.Level
+
+	// DebugTenants are tenant IDs whose requests are logged at debug level
+	// whatever the other levels, to troubleshoot one tenant.
+	DebugTenants []string
+
+	// Redact replaces API keys, credentials and document content in
+	// records.
+	Redact bool
+}
+
+// New creates a logger writing to w. SetLevels may change its levels later.
+func New(w io.Writer, cfg Config) (*slog.Logger, error) {
+	// The base handler lets every level through; levels are applied per record
+	h := &handler{levels: new(atomic.Pointer[levels]), lowest: new(slog.LevelVar)}
+	h.setLevels(cfg)
+	opts := &slog.HandlerOptions{Level: h.lowest}
+	if cfg.Redact {
+		opts.ReplaceAttr = redactAttr
+	}
+
+	var base slog.Handler
+	switch cfg.Format {
+	case "", FormatJSON:
+		base = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		base = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q (valid: %s, %s)", cfg.Format, FormatJSON, FormatText)
+	}
+
+	h.next = base
+	return slog.New(h), nil
+}
+
+// SetLevels changes the level, component levels and debug tenants of a
+// logger created by New, and of the loggers derived from it, while they are
+// in use. The rest of cfg is ignored.
+func SetLevels(logger *slog.Logger, cfg Config) error {
+	h, ok := logger.Handler().(*handler)
+	if !ok {
+		return errors.New("logger was not created by logging.New")
+	}
+	h.setLevels(cfg)
+	return nil
+}
+
+// Component returns logger for a component, whose level
+// Config.ComponentLevels may set.
+func Component(logger *slog.Logger, name string) *slog.Logger {
+	return logger.With(ComponentKey, name)
+}
+
+// ParseLevel parses a level name: debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// ParseComponentLevels parses component levels such as
+// "grpc=warn,ratelimit=debug".
+func ParseComponentLevels(s string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		component, name, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(component) == "" {
+			return nil, fmt.Errorf("invalid component log level %q: must be component=level", part)
+		}
+		level, err := ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		levels[strings.TrimSpace(component)] = level
+	}
+	return levels, nil
+}
+
+// levels are the levels a logger and the loggers derived from it share
+type levels struct {
+	level        slog.Level
+	components   map[string]slog.Level
+	debugTenants map[string]bool
+}
+
+// handler applies component and tenant levels and adds the request and
+// tenant IDs of the record's context
+type handler struct {
+	next slog.Handler
+
+	// component is the handler's component, whose level may differ
+	component string
+
+	// levels and lowest, the lowest of them, which the base handler lets
+	// through, are shared with the handlers derived from this one
+	levels *atomic.Pointer[levels]
+	lowest *slog.LevelVar
+}
+
+// setLevels replaces the handler's levels with cfg's
+func (h *handler) setLevels(cfg Config) {
+	l := &levels{
+		level:        cfg.Level,
+		components:   cfg.ComponentLevels,
+		debugTenants: make(map[string]bool, len(cfg.DebugTenants)),
+	}
+	for _, id := range cfg.DebugTenants {
+		l.debugTenants[id] = true
+	}
+
+	lowest := cfg.Level
+	for _, level := range cfg.ComponentLevels {
+		lowest = min(lowest, level)
+	}
+	if len(cfg.DebugTenants) > 0 {
+		lowest = min(lowest, slog.LevelDebug)
+	}
+	h.levels.Store(l)
+	h.lowest.Set(lowest)
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	l := h.levels.Load()
+	least := l.level
+	if componentLevel, ok := l.components[h.component]; ok {
+		least = componentLevel
+	}
+	if level >= least {
+		return true
+	}
+	if len(l.debugTenants) > 0 && level >= slog.LevelDebug {
+		if tenantID, ok := auth.TenantIDFromContext(ctx); ok {
+			return l.debugTenants[tenantID.String()]
+		}
+	}
+	return false
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" && !hasAttr(r, "request_id") {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if tenantID, ok := auth.TenantIDFromContext(ctx); ok && !hasAttr(r, "tenant_id") {
+		r.AddAttrs(slog.String("tenant_id", tenantID.String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// hasAttr reports whether a record sets the attribute key itself
+func hasAttr(r slog.Record, key string) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == key
+		return !found
+	})
+	return found
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey {
+			clone.component = attr.Value.String()
+		}
+	}
+	return &clone
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	return &clone
+}
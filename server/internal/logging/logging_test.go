@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/requestid"
+)
+
+// records decodes the JSON records written to buf
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid record %q: %v", line, err)
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+func TestLogger_Levels(t *testing.T) {
+	debugTenant := uuid.New()
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{
+		Level:           slog.LevelInfo,
+		ComponentLevels: map[string]slog.Level{"grpc": slog.LevelWarn, "crawl": slog.LevelDebug},
+		DebugTenants:    []string{debugTenant.String()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Debug("default debug")
+	Component(logger, "grpc").Info("grpc info")
+	Component(logger, "grpc").Warn("grpc warn")
+	Component(logger, "crawl").Debug("crawl debug")
+
+	ctx := auth.WithTenant(context.Background(), &repository.Tenant{ID: debugTenant}, nil)
+	ctx = requestid.NewContext(ctx, "req-7")
+	logger.DebugContext(ctx, "tenant debug")
+
+	got := records(t, &buf)
+	var messages []string
+	for _, r := range got {
+		messages = append(messages, r["msg"].(string))
+	}
+	if want := "grpc warn,crawl debug,tenant debug"; strings.Join(messages, ",") != want {
+		t.Fatalf("logged %v, want %s", messages, want)
+	}
+	last := got[len(got)-1]
+	if last["request_id"] != "req-7" || last["tenant_id"] != debugTenant.String() {
+		t.Errorf("record = %v, want the context's request and tenant IDs", last)
+	}
+}
+
+func TestLogger_Redact(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{Level: slog.LevelInfo, Redact: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := "rag_0123456789abcdef0123456789abcdef"
+	logger.Info("request",
+		"api_key", key,
+		"content", "confidential text",
+		"error", errors.New("invalid key "+key),
+		"header", "Bearer eyJhbGciOi.payload.sig",
+		"source", "https://example.com")
+
+	r := records(t, &buf)[0]
+	if r["api_key"] != redacted || r["content"] != "[REDACTED 17 bytes]" {
+		t.Errorf("record = %v, want api_key and content redacted", r)
+	}
+	if strings.Contains(r["error"].(string), key) || strings.Contains(r["header"].(string), "eyJ") {
+		t.Errorf("record = %v, want embedded credentials masked", r)
+	}
+	if r["source"] != "https://example.com" {
+		t.Errorf("source = %v, want it kept", r["source"])
+	}
+}
+
+func TestParseComponentLevels(t *testing.T) {
+	levels, err := ParseComponentLevels("grpc=warn, http = error,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if levels["grpc"] != slog.LevelWarn || levels["http"] != slog.LevelError || len(levels) != 2 {
+		t.Errorf("levels = %v", levels)
+	}
+	for _, bad := range []string{"grpc", "=warn", "grpc=loud"} {
+		if _, err := ParseComponentLevels(bad); err == nil {
+			t.Errorf("ParseComponentLevels(%q) succeeded", bad)
+		}
+	}
+	if _, err := New(&bytes.Buffer{}, Config{Format: "xml"}); err == nil {
+		t.Error("New accepted an unknown format")
+	}
+}
+
+func TestSetLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, Config{Level: slog.LevelInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcLogger := Component(logger, "grpc")
+
+	logger.Debug("debug before")
+	if err := SetLevels(logger, Config{
+		Level:           slog.LevelDebug,
+		ComponentLevels: map[string]slog.Level{"grpc": slog.LevelError},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("debug after")
+	grpcLogger.Warn("grpc warn after")
+
+	var messages []string
+	for _, r := range records(t, &buf) {
+		messages = append(messages, r["msg"].(string))
+	}
+	if want := "debug after"; strings.Join(messages, ",") != want {
+		t.Errorf("logged %v, want %s", messages, want)
+	}
+
+	if err := SetLevels(slog.New(slog.NewJSONHandler(&buf, nil)), Config{}); err == nil {
+		t.Error("SetLevels on another logger's handler succeeded")
+	}
+}
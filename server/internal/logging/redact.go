@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces the values of credential attributes
+const redacted = "[REDACTED]"
+
+// secretKeys are attribute keys whose values are credentials
+var secretKeys = map[string]bool{
+	"api_key":       true,
+	"apikey":        true,
+	"x-api-key":     true,
+	"authorization": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// contentKeys are attribute keys whose values are document or chunk text
+var contentKeys = map[string]bool{
+	"content":       true,
+	"chunk_content": true,
+	"text":          true,
+	"data":          true,
+}
+
+// secretPattern matches API keys and bearer tokens inside other values,
+// such as error messages
+var secretPattern = regexp.MustCompile(`rag_[0-9a-fA-F]{8,}|(?i:bearer)\s+[A-Za-z0-9._~+/=-]+`)
+
+// redactAttr replaces credentials and document content in an attribute
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	switch {
+	case secretKeys[key]:
+		return slog.String(a.Key, redacted)
+	case contentKeys[key]:
+		// The length still tells documents apart
+		return slog.String(a.Key, fmt.Sprintf("[REDACTED %d bytes]", len(a.Value.String())))
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, redactString(a.Value.String()))
+	case slog.KindAny:
+		// Errors and other values are logged by their text
+		if err, ok := a.Value.Any().(error); ok && err != nil {
+			if text := err.Error(); secretPattern.MatchString(text) {
+				return slog.String(a.Key, redactString(text))
+			}
+		}
+	}
+	return a
+}
+
+// redactString masks the API keys and bearer tokens in s
+func redactString(s string) string {
+	return secretPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "rag_") {
+			return "rag_" + redacted
+		}
+		return "Bearer " + redacted
+	})
+}
@@ -2,29 +2,47 @@
 package memory
 
 import (
+	"sort"
 	"sync"
 	"time"
 )
 
 // Message represents a single message in a conversation.
 type Message struct {
-	Role      string    // "user" or "assistant"
+	Role      string // "user" or "assistant"
 	Content   string
 	Timestamp time.Time
 }
 
 // Conversation holds the message history for a session.
 type Conversation struct {
+	ID        string
+	TenantID  string
 	Messages  []Message
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Summary condenses the messages that preceded Messages, once they were
+	// folded into it with Fold.
+	Summary string
+
+	// Offset counts the messages dropped from the front of Messages, by
+	// folding or by the message limit.
+	Offset int
+}
+
+// sessionKey identifies a conversation. Session IDs are chosen by clients,
+// so they are only unique within a tenant.
+type sessionKey struct {
+	tenantID  string
+	sessionID string
 }
 
 // Store provides in-memory conversation storage.
 // For production, consider using Redis for persistence and TTL support.
 type Store struct {
 	mu            sync.RWMutex
-	conversations map[string]*Conversation
+	conversations map[sessionKey]*Conversation
 	maxMessages   int           // Max messages per conversation
 	ttl           time.Duration // Time-to-live for conversations
 }
@@ -32,7 +50,7 @@ type Store struct {
 // NewStore creates a new conversation memory store.
 func NewStore(maxMessages int, ttl time.Duration) *Store {
 	s := &Store{
-		conversations: make(map[string]*Conversation),
+		conversations: make(map[sessionKey]*Conversation),
 		maxMessages:   maxMessages,
 		ttl:           ttl,
 	}
@@ -43,34 +61,100 @@ func NewStore(maxMessages int, ttl time.Duration) *Store {
 	return s
 }
 
+// DefaultMaxMessages is the message limit of DefaultStore.
+const DefaultMaxMessages = 20
+
 // DefaultStore creates a store with sensible defaults.
 // - Max 20 messages per conversation (10 turns)
 // - 1 hour TTL (session expires after 1 hour of inactivity)
 func DefaultStore() *Store {
-	return NewStore(20, 1*time.Hour)
+	return NewStore(DefaultMaxMessages, 1*time.Hour)
+}
+
+// TTL returns how long a conversation lives after its last update.
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Create starts an empty conversation. It returns false, and leaves the
+// store unchanged, if the tenant already has a live session with the ID.
+func (s *Store) Create(tenantID, sessionID string) (Conversation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sessionKey{tenantID, sessionID}
+	if conv, exists := s.conversations[key]; exists && s.live(conv) {
+		return conv.snapshot(), false
+	}
+	now := time.Now()
+	conv := &Conversation{
+		ID:        sessionID,
+		TenantID:  tenantID,
+		Messages:  make([]Message, 0),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.conversations[key] = conv
+	return conv.snapshot(), true
+}
+
+// Get returns a copy of a live conversation.
+func (s *Store) Get(tenantID, sessionID string) (Conversation, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conv, exists := s.conversations[sessionKey{tenantID, sessionID}]
+	if !exists || !s.live(conv) {
+		return Conversation{}, false
+	}
+	return conv.snapshot(), true
+}
+
+// List returns copies of a tenant's live conversations, most recently
+// updated first.
+func (s *Store) List(tenantID string) []Conversation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var convs []Conversation
+	for key, conv := range s.conversations {
+		if key.tenantID == tenantID && s.live(conv) {
+			convs = append(convs, conv.snapshot())
+		}
+	}
+	sort.Slice(convs, func(i, j int) bool {
+		if !convs[i].UpdatedAt.Equal(convs[j].UpdatedAt) {
+			return convs[i].UpdatedAt.After(convs[j].UpdatedAt)
+		}
+		return convs[i].ID < convs[j].ID
+	})
+	return convs
 }
 
 // AddUserMessage adds a user message to the conversation.
-func (s *Store) AddUserMessage(sessionID, content string) {
-	s.addMessage(sessionID, "user", content)
+func (s *Store) AddUserMessage(tenantID, sessionID, content string) {
+	s.addMessage(tenantID, sessionID, "user", content)
 }
 
 // AddAssistantMessage adds an assistant message to the conversation.
-func (s *Store) AddAssistantMessage(sessionID, content string) {
-	s.addMessage(sessionID, "assistant", content)
+func (s *Store) AddAssistantMessage(tenantID, sessionID, content string) {
+	s.addMessage(tenantID, sessionID, "assistant", content)
 }
 
-func (s *Store) addMessage(sessionID, role, content string) {
+func (s *Store) addMessage(tenantID, sessionID, role, content string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	conv, exists := s.conversations[sessionID]
-	if !exists {
+	key := sessionKey{tenantID, sessionID}
+	conv, exists := s.conversations[key]
+	if !exists || !s.live(conv) {
 		conv = &Conversation{
+			ID:        sessionID,
+			TenantID:  tenantID,
 			Messages:  make([]Message, 0),
 			CreatedAt: time.Now(),
 		}
-		s.conversations[sessionID] = conv
+		s.conversations[key] = conv
 	}
 
 	conv.Messages = append(conv.Messages, Message{
@@ -82,41 +166,73 @@ func (s *Store) addMessage(sessionID, role, content string) {
 
 	// Trim old messages if exceeding max (keep recent ones)
 	if len(conv.Messages) > s.maxMessages {
+		conv.Offset += len(conv.Messages) - s.maxMessages
 		conv.Messages = conv.Messages[len(conv.Messages)-s.maxMessages:]
 	}
 }
 
+// Fold replaces a conversation's summary with one that also covers its
+// first n messages, and drops those messages. offset is the Offset of the
+// snapshot the summary was written from; if the conversation has changed
+// its front since, by another fold or by trimming, nothing is changed and
+// Fold returns false.
+func (s *Store) Fold(tenantID, sessionID string, offset, n int, summary string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conv, exists := s.conversations[sessionKey{tenantID, sessionID}]
+	if !exists || conv.Offset != offset || n > len(conv.Messages) {
+		return false
+	}
+	conv.Summary = summary
+	conv.Offset += n
+	conv.Messages = append([]Message(nil), conv.Messages[n:]...)
+	return true
+}
+
 // GetHistory returns the conversation history for a session.
 // Returns nil if session doesn't exist.
-func (s *Store) GetHistory(sessionID string) []Message {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	conv, exists := s.conversations[sessionID]
+func (s *Store) GetHistory(tenantID, sessionID string) []Message {
+	conv, exists := s.Get(tenantID, sessionID)
 	if !exists {
 		return nil
 	}
-
-	// Return a copy to avoid race conditions
-	messages := make([]Message, len(conv.Messages))
-	copy(messages, conv.Messages)
-	return messages
+	return conv.Messages
 }
 
 // GetRecentHistory returns the last N messages for context window management.
-func (s *Store) GetRecentHistory(sessionID string, n int) []Message {
-	history := s.GetHistory(sessionID)
+func (s *Store) GetRecentHistory(tenantID, sessionID string, n int) []Message {
+	history := s.GetHistory(tenantID, sessionID)
 	if history == nil || len(history) <= n {
 		return history
 	}
 	return history[len(history)-n:]
 }
 
-// ClearSession removes a conversation from memory.
-func (s *Store) ClearSession(sessionID string) {
+// ClearSession removes a conversation from memory. It returns false if the
+// tenant had no live session with the ID.
+func (s *Store) ClearSession(tenantID, sessionID string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	delete(s.conversations, sessionID)
+
+	key := sessionKey{tenantID, sessionID}
+	conv, exists := s.conversations[key]
+	delete(s.conversations, key)
+	return exists && s.live(conv)
+}
+
+// live reports whether a conversation has not expired. Expired ones are
+// only removed by the periodic cleanup, so reads check it themselves.
+func (s *Store) live(conv *Conversation) bool {
+	return time.Since(conv.UpdatedAt) <= s.ttl
+}
+
+// snapshot returns a copy of the conversation that is safe to use without the lock.
+func (c *Conversation) snapshot() Conversation {
+	cp := *c
+	cp.Messages = make([]Message, len(c.Messages))
+	copy(cp.Messages, c.Messages)
+	return cp
 }
 
 // cleanupLoop periodically removes expired conversations.
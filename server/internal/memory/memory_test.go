@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreSessionsAreTenantScoped(t *testing.T) {
+	s := NewStore(20, time.Hour)
+	s.AddUserMessage("tenant-a", "s1", "hello")
+
+	if got := s.GetHistory("tenant-b", "s1"); got != nil {
+		t.Errorf("tenant-b sees tenant-a's session: %v", got)
+	}
+	if _, created := s.Create("tenant-b", "s1"); !created {
+		t.Error("Create for another tenant's session ID reported existing")
+	}
+	if _, created := s.Create("tenant-a", "s1"); created {
+		t.Error("Create replaced a live session")
+	}
+	if got := s.GetHistory("tenant-a", "s1"); len(got) != 1 || got[0].Content != "hello" {
+		t.Errorf("history = %v", got)
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	s := NewStore(20, time.Hour)
+	s.Create("t", "old")
+	time.Sleep(time.Millisecond)
+	s.AddUserMessage("t", "new", "hi")
+	s.Create("other", "x")
+
+	convs := s.List("t")
+	if len(convs) != 2 || convs[0].ID != "new" || convs[1].ID != "old" {
+		t.Fatalf("List = %+v", convs)
+	}
+
+	if !s.ClearSession("t", "old") {
+		t.Error("ClearSession of a live session returned false")
+	}
+	if s.ClearSession("t", "old") {
+		t.Error("ClearSession of a cleared session returned true")
+	}
+	if convs := s.List("t"); len(convs) != 1 {
+		t.Errorf("List after clear = %+v", convs)
+	}
+}
+
+func TestStoreExpiredSessionsAreHidden(t *testing.T) {
+	s := NewStore(20, time.Millisecond)
+	s.AddUserMessage("t", "s", "hi")
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := s.Get("t", "s"); ok {
+		t.Error("Get returned an expired session")
+	}
+	if convs := s.List("t"); len(convs) != 0 {
+		t.Errorf("List returned expired sessions: %+v", convs)
+	}
+	if _, created := s.Create("t", "s"); !created {
+		t.Error("Create refused to replace an expired session")
+	}
+}
+
+func TestStoreFold(t *testing.T) {
+	s := NewStore(20, time.Hour)
+	for _, msg := range []string{"q1", "a1", "q2", "a2"} {
+		s.AddUserMessage("t", "s", msg)
+	}
+	conv, _ := s.Get("t", "s")
+
+	if !s.Fold("t", "s", conv.Offset, 2, "asked q1") {
+		t.Fatal("Fold failed")
+	}
+	conv, _ = s.Get("t", "s")
+	if conv.Summary != "asked q1" || conv.Offset != 2 || len(conv.Messages) != 2 || conv.Messages[0].Content != "q2" {
+		t.Errorf("after Fold: %+v", conv)
+	}
+
+	// A stale snapshot's summary must not replace the newer one
+	if s.Fold("t", "s", 0, 1, "stale") {
+		t.Error("Fold with a stale offset succeeded")
+	}
+}
@@ -0,0 +1,116 @@
+// Package monitor records recent failed and slow requests for the admin dashboard.
+package monitor
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCapacity is the default number of records kept per list
+	DefaultCapacity = 100
+
+	// DefaultSlowThreshold is the default duration above which a request is considered slow
+	DefaultSlowThreshold = 2 * time.Second
+
+	// maxQueryLength caps the stored query text
+	maxQueryLength = 200
+)
+
+// Record describes a single completed request
+type Record struct {
+	Method   string
+	Code     string
+	Duration time.Duration
+	Error    string
+	TenantID string
+	Query    string
+	Time     time.Time
+}
+
+// Recorder keeps bounded, in-memory lists of recent failed and slow requests.
+// Records are lost on restart; this is a dashboard aid, not an audit log.
+type Recorder struct {
+	mu            sync.RWMutex
+	capacity      int
+	slowThreshold time.Duration
+	errors        []Record
+	slow          []Record
+}
+
+// NewRecorder creates a new Recorder
+func NewRecorder(capacity int, slowThreshold time.Duration) *Recorder {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowThreshold
+	}
+	return &Recorder{
+		capacity:      capacity,
+		slowThreshold: slowThreshold,
+	}
+}
+
+// SlowThreshold returns the duration above which requests are recorded as slow
+func (r *Recorder) SlowThreshold() time.Duration {
+	return r.slowThreshold
+}
+
+// Record stores a request if it failed or exceeded the slow threshold
+func (r *Recorder) Record(rec Record) {
+	failed := rec.Error != ""
+	slow := rec.Duration >= r.slowThreshold
+	if !failed && !slow {
+		return
+	}
+
+	if len(rec.Query) > maxQueryLength {
+		rec.Query = rec.Query[:maxQueryLength] + "..."
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if failed {
+		r.errors = r.push(r.errors, rec)
+	}
+	if slow {
+		r.slow = r.push(r.slow, rec)
+	}
+}
+
+// RecentErrors returns up to limit failed requests, newest first
+func (r *Recorder) RecentErrors(limit int) []Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return newestFirst(r.errors, limit)
+}
+
+// SlowRequests returns up to limit slow requests, newest first
+func (r *Recorder) SlowRequests(limit int) []Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return newestFirst(r.slow, limit)
+}
+
+// push appends a record, dropping the oldest once capacity is reached
+func (r *Recorder) push(records []Record, rec Record) []Record {
+	records = append(records, rec)
+	if len(records) > r.capacity {
+		records = records[len(records)-r.capacity:]
+	}
+	return records
+}
+
+// newestFirst returns a reversed copy of the last limit records
+func newestFirst(records []Record, limit int) []Record {
+	if limit <= 0 || limit > len(records) {
+		limit = len(records)
+	}
+	result := make([]Record, limit)
+	for i := 0; i < limit; i++ {
+		result[i] = records[len(records)-1-i]
+	}
+	return result
+}
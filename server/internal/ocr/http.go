@@ -0,0 +1,101 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultHTTPTimeout is the default timeout for OCR service requests.
+const DefaultHTTPTimeout = 2 * time.Minute
+
+// HTTPConfig holds configuration for an HTTP OCR service.
+type HTTPConfig struct {
+	// URL is the endpoint the file is POSTed to, with its content type.
+	URL string
+
+	// APIKey is sent as a bearer token, if set.
+	APIKey string
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// HTTPEngine implements Engine against an OCR service. The service receives
+// the raw file and responds with {"pages": [{"text": ..., "confidence": ...}]},
+// confidence being between 0 and 1.
+type HTTPEngine struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// httpResponse represents the response from the OCR service.
+type httpResponse struct {
+	Pages []struct {
+		Number     int     `json:"number"`
+		Text       string  `json:"text"`
+		Confidence float64 `json:"confidence"`
+	} `json:"pages"`
+}
+
+// NewHTTPEngine creates an HTTP OCR engine with the given configuration.
+func NewHTTPEngine(cfg HTTPConfig) *HTTPEngine {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	return &HTTPEngine{
+		url:    cfg.URL,
+		apiKey: cfg.APIKey,
+		client: client,
+	}
+}
+
+// Name returns "http".
+func (e *HTTPEngine) Name() string {
+	return "http"
+}
+
+// Recognize sends the file to the OCR service.
+func (e *HTTPEngine) Recognize(ctx context.Context, data []byte, contentType string) ([]Page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("OCR service returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result httpResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	pages := make([]Page, len(result.Pages))
+	for i, p := range result.Pages {
+		number := p.Number
+		if number <= 0 {
+			number = i + 1
+		}
+		pages[i] = Page{Number: number, Text: p.Text, Confidence: p.Confidence}
+	}
+	return pages, nil
+}
@@ -0,0 +1,34 @@
+// Package ocr recognizes text in scanned documents and images.
+//
+// Two engines are provided: a local Tesseract binary (with pdftoppm to
+// rasterize PDFs) and a generic HTTP service for hosted OCR.
+package ocr
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnsupportedContentType is returned when an engine cannot read an input format.
+var ErrUnsupportedContentType = errors.New("unsupported content type for OCR")
+
+// Page is the text recognized on one page or image.
+type Page struct {
+	// Number is the 1-based page number.
+	Number int
+
+	// Text is the recognized text, with line and paragraph breaks preserved.
+	Text string
+
+	// Confidence is the mean word confidence between 0 and 1.
+	Confidence float64
+}
+
+// Engine recognizes text in images and scanned PDFs.
+type Engine interface {
+	// Recognize returns the text of each page. Images have a single page.
+	Recognize(ctx context.Context, data []byte, contentType string) ([]Page, error)
+
+	// Name identifies the engine in document metadata.
+	Name() string
+}
@@ -0,0 +1,204 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultTesseractPath is the default tesseract binary.
+	DefaultTesseractPath = "tesseract"
+
+	// DefaultRasterizerPath is the default pdftoppm binary used to render PDF pages.
+	DefaultRasterizerPath = "pdftoppm"
+
+	// DefaultLanguages is the default Tesseract language list.
+	DefaultLanguages = "eng"
+
+	// DefaultDPI is the default PDF rendering resolution.
+	DefaultDPI = 300
+)
+
+// TesseractConfig holds configuration for the Tesseract engine.
+type TesseractConfig struct {
+	// Path is the tesseract binary (default: tesseract).
+	Path string
+
+	// RasterizerPath is the pdftoppm binary (default: pdftoppm).
+	RasterizerPath string
+
+	// Languages is a "+"-separated Tesseract language list (default: eng).
+	Languages string
+
+	// DPI is the resolution PDF pages are rendered at (default: 300).
+	DPI int
+}
+
+// TesseractEngine implements Engine by running the tesseract CLI.
+type TesseractEngine struct {
+	path           string
+	rasterizerPath string
+	languages      string
+	dpi            int
+}
+
+// NewTesseractEngine creates a Tesseract engine with the given configuration.
+func NewTesseractEngine(cfg TesseractConfig) *TesseractEngine {
+	e := &TesseractEngine{
+		path:           cfg.Path,
+		rasterizerPath: cfg.RasterizerPath,
+		languages:      cfg.Languages,
+		dpi:            cfg.DPI,
+	}
+	if e.path == "" {
+		e.path = DefaultTesseractPath
+	}
+	if e.rasterizerPath == "" {
+		e.rasterizerPath = DefaultRasterizerPath
+	}
+	if e.languages == "" {
+		e.languages = DefaultLanguages
+	}
+	if e.dpi <= 0 {
+		e.dpi = DefaultDPI
+	}
+	return e
+}
+
+// Name returns "tesseract".
+func (e *TesseractEngine) Name() string {
+	return "tesseract"
+}
+
+// Recognize runs OCR on an image, or on every page of a PDF.
+func (e *TesseractEngine) Recognize(ctx context.Context, data []byte, contentType string) ([]Page, error) {
+	if contentType == "application/pdf" {
+		return e.recognizePDF(ctx, data)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	page, err := e.recognizeImage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	page.Number = 1
+	return []Page{page}, nil
+}
+
+// recognizePDF renders each page to PNG with pdftoppm and recognizes it.
+func (e *TesseractEngine) recognizePDF(ctx context.Context, data []byte) ([]Page, error) {
+	dir, err := os.MkdirTemp("", "rag-ocr-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, e.rasterizerPath, "-r", strconv.Itoa(e.dpi), "-png", input, filepath.Join(dir, "page"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	images, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	// pdftoppm zero-pads page numbers to the width of the page count
+	sort.Slice(images, func(i, j int) bool {
+		return renderedPageNumber(images[i]) < renderedPageNumber(images[j])
+	})
+
+	pages := make([]Page, 0, len(images))
+	for _, image := range images {
+		img, err := os.ReadFile(image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rendered page: %w", err)
+		}
+		page, err := e.recognizeImage(ctx, img)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", renderedPageNumber(image), err)
+		}
+		page.Number = renderedPageNumber(image)
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// recognizeImage runs tesseract on one image, reading word confidences from TSV output.
+func (e *TesseractEngine) recognizeImage(ctx context.Context, image []byte) (Page, error) {
+	cmd := exec.CommandContext(ctx, e.path, "stdin", "stdout", "-l", e.languages, "tsv")
+	cmd.Stdin = bytes.NewReader(image)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return Page{}, fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTSV(out), nil
+}
+
+// parseTSV rebuilds text from tesseract's TSV output. Words on the same line are
+// joined by spaces, and a new block or paragraph starts a new paragraph.
+func parseTSV(data []byte) Page {
+	var sb strings.Builder
+	var confidenceSum float64
+	words := 0
+	lastPara, lastLine := "", ""
+
+	for i, line := range strings.Split(string(data), "\n") {
+		fields := strings.Split(line, "\t")
+		// level page block par line word left top width height conf text
+		if i == 0 || len(fields) < 12 || fields[0] != "5" {
+			continue
+		}
+		text := strings.TrimSpace(fields[11])
+		conf, err := strconv.ParseFloat(fields[10], 64)
+		if text == "" || err != nil || conf < 0 {
+			continue
+		}
+
+		para := fields[2] + "." + fields[3]
+		lineKey := para + "." + fields[4]
+		switch {
+		case sb.Len() == 0:
+		case para != lastPara:
+			sb.WriteString("\n\n")
+		case lineKey != lastLine:
+			sb.WriteString("\n")
+		default:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(text)
+		lastPara, lastLine = para, lineKey
+
+		confidenceSum += conf / 100
+		words++
+	}
+
+	page := Page{Text: sb.String()}
+	if words > 0 {
+		page.Confidence = confidenceSum / float64(words)
+	}
+	return page
+}
+
+// renderedPageNumber parses N from page-N.png
+func renderedPageNumber(name string) int {
+	base := strings.TrimSuffix(filepath.Base(name), ".png")
+	n, _ := strconv.Atoi(strings.TrimPrefix(base, "page-"))
+	return n
+}
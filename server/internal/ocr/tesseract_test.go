@@ -0,0 +1,23 @@
+package ocr
+
+import "testing"
+
+func TestParseTSV(t *testing.T) {
+	tsv := "level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n" +
+		"1\t1\t0\t0\t0\t0\t0\t0\t100\t100\t-1\t\n" +
+		"5\t1\t1\t1\t1\t1\t0\t0\t10\t10\t90\tInvoice\n" +
+		"5\t1\t1\t1\t1\t2\t0\t0\t10\t10\t80\t#42\n" +
+		"5\t1\t1\t1\t2\t1\t0\t0\t10\t10\t70\tTotal\n" +
+		"5\t1\t2\t1\t1\t1\t0\t0\t10\t10\t60\tThanks\n" +
+		"5\t1\t2\t1\t1\t2\t0\t0\t10\t10\t-1\t \n"
+
+	page := parseTSV([]byte(tsv))
+
+	want := "Invoice #42\nTotal\n\nThanks"
+	if page.Text != want {
+		t.Errorf("unexpected text:\n%q\nwant:\n%q", page.Text, want)
+	}
+	if page.Confidence < 0.749 || page.Confidence > 0.751 {
+		t.Errorf("expected mean confidence 0.75, got %f", page.Confidence)
+	}
+}
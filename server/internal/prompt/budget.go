@@ -0,0 +1,155 @@
+package prompt
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EstimateTokens approximates how many tokens text uses. CJK characters
+// count one token each; other text about four characters or three-quarters
+// of a word per token, whichever count is higher, so estimates err on the
+// side of fitting.
+func EstimateTokens(text string) int {
+	var cjk, other, words int
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			cjk++
+			inWord = false
+		case unicode.IsSpace(r):
+			other++
+			inWord = false
+		default:
+			other++
+			if !inWord {
+				words++
+			}
+			inWord = true
+		}
+	}
+	byChars := (other + 3) / 4
+	byWords := (words*4 + 2) / 3
+	return cjk + max(byChars, byWords)
+}
+
+// Budget is the room a prompt must fit in.
+type Budget struct {
+	ContextLength int // The model's context window; 0 disables fitting
+	Reserved      int // Tokens kept free for the completion and text sent beside the prompt, such as the system prompt
+}
+
+// Truncation reports what Fit removed to make a prompt fit.
+type Truncation struct {
+	ChunksDropped   int  // Lowest-scoring chunks left out
+	ChunksTruncated int  // Chunks whose content was cut short
+	MessagesDropped int  // Oldest history messages left out
+	SummaryDropped  bool // Whether the history summary was left out
+	Tokens          int  // Estimated tokens of the final prompt
+	Overflow        bool // Whether the prompt is still over budget, e.g. because of the query alone
+}
+
+// Truncated reports whether anything was removed.
+func (t Truncation) Truncated() bool {
+	return t.ChunksDropped > 0 || t.ChunksTruncated > 0 || t.MessagesDropped > 0 || t.SummaryDropped
+}
+
+// truncationMarker ends content Fit cut short.
+const truncationMarker = " …"
+
+// Fit renders data, removing content until the prompt fits the budget: the
+// lowest-scoring chunks down to the last one, then the oldest history
+// messages and the summary, then the end of the remaining chunk. Chunks are
+// renumbered after one is dropped.
+func (t *Template) Fit(data Data, budget Budget) (string, Truncation, error) {
+	var tr Truncation
+	data.Chunks = append([]Chunk(nil), data.Chunks...)
+	data.Messages = append([]Message(nil), data.Messages...)
+	available := budget.ContextLength - budget.Reserved
+
+	render := func() (string, bool, error) {
+		text, err := t.Render(data)
+		if err != nil {
+			return "", false, err
+		}
+		tr.Tokens = EstimateTokens(text)
+		return text, budget.ContextLength <= 0 || tr.Tokens <= available, nil
+	}
+
+	text, fits, err := render()
+	for err == nil && !fits {
+		switch {
+		case len(data.Chunks) > 1:
+			data.Chunks = dropLowestScore(data.Chunks)
+			tr.ChunksDropped++
+		case len(data.Messages) > 0:
+			data.Messages = data.Messages[1:]
+			data.History = formatHistory(data.Messages)
+			tr.MessagesDropped++
+		case data.Summary != "":
+			data.Summary = ""
+			tr.SummaryDropped = true
+		case len(data.Chunks) == 1 && data.Chunks[0].Content != "":
+			content := data.Chunks[0].Content
+			data.Chunks[0].Content = cutTokens(content, tr.Tokens-available)
+			if !strings.HasSuffix(content, truncationMarker) {
+				tr.ChunksTruncated = 1
+			}
+		default:
+			tr.Overflow = true
+			return text, tr, nil
+		}
+		text, fits, err = render()
+	}
+	if err != nil {
+		return "", tr, err
+	}
+	return text, tr, nil
+}
+
+// dropLowestScore removes the last of the lowest-scoring chunks and renumbers the rest
+func dropLowestScore(chunks []Chunk) []Chunk {
+	lowest := len(chunks) - 1
+	for i := len(chunks) - 2; i >= 0; i-- {
+		if chunks[i].Score < chunks[lowest].Score {
+			lowest = i
+		}
+	}
+	chunks = append(chunks[:lowest], chunks[lowest+1:]...)
+	for i := range chunks {
+		chunks[i].Index = i + 1
+	}
+	return chunks
+}
+
+// cutTokens shortens content by about the given number of tokens, at a word
+// boundary where there is one, and marks the cut
+func cutTokens(content string, over int) string {
+	content = strings.TrimSuffix(content, truncationMarker)
+	runes := []rune(content)
+	tokens := EstimateTokens(content)
+	// Cut a little more than the overage, since the estimate is proportional
+	keep := len(runes) * (tokens - over - len(truncationMarker)) / max(tokens, 1)
+	if keep <= 0 {
+		return ""
+	}
+	cut := string(runes[:keep])
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > len(cut)/2 {
+		cut = cut[:i]
+	}
+	return cut + truncationMarker
+}
+
+// formatHistory formats messages as the History field presents them
+func formatHistory(messages []Message) string {
+	var b strings.Builder
+	for _, msg := range messages {
+		switch msg.Role {
+		case "user":
+			b.WriteString("User: " + msg.Content + "\n")
+		case "assistant":
+			b.WriteString("Assistant: " + msg.Content + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens(""); got != 0 {
+		t.Errorf("EstimateTokens(\"\") = %d", got)
+	}
+	// Three words, but long ones count by characters
+	if got := EstimateTokens("internationalization localization globalization"); got < 10 {
+		t.Errorf("long words estimated at %d tokens", got)
+	}
+	// CJK text has no spaces; each character counts
+	if got := EstimateTokens("東京都の人口"); got != 6 {
+		t.Errorf("EstimateTokens(CJK) = %d, want 6", got)
+	}
+}
+
+func budgetData() Data {
+	long := strings.Repeat("word ", 300)
+	return Data{
+		SystemPrompt: "Be brief.",
+		Summary:      "The user asked about pricing.",
+		History:      "User: hi\nAssistant: hello\n",
+		Messages:     []Message{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}},
+		Chunks: []Chunk{
+			{Index: 1, Content: "best " + long, Score: 0.9},
+			{Index: 2, Content: "worst " + long, Score: 0.5},
+			{Index: 3, Content: "middle " + long, Score: 0.7},
+		},
+		Query:  "What does Pro cost?",
+		Labels: english,
+	}
+}
+
+func TestFitWithinBudget(t *testing.T) {
+	text, tr, err := Default.Fit(budgetData(), Budget{ContextLength: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Truncated() || tr.Overflow {
+		t.Errorf("truncation = %+v for a prompt that fits", tr)
+	}
+	if tr.Tokens != EstimateTokens(text) {
+		t.Errorf("Tokens = %d, want %d", tr.Tokens, EstimateTokens(text))
+	}
+}
+
+func TestFitDropsLowestScoringChunks(t *testing.T) {
+	// Room for about two chunks
+	text, tr, err := Default.Fit(budgetData(), Budget{ContextLength: 1100, Reserved: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.ChunksDropped != 1 || tr.MessagesDropped != 0 {
+		t.Errorf("truncation = %+v, want one chunk dropped", tr)
+	}
+	if strings.Contains(text, "worst") || !strings.Contains(text, "middle") || !strings.Contains(text, "[Doc 2]") {
+		t.Errorf("expected the lowest-scoring chunk dropped and the rest renumbered")
+	}
+	if tr.Tokens > 1000 {
+		t.Errorf("prompt is %d tokens, over the 1000 available", tr.Tokens)
+	}
+}
+
+func TestFitTruncatesLastChunk(t *testing.T) {
+	text, tr, err := Default.Fit(budgetData(), Budget{ContextLength: 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.ChunksDropped != 2 || tr.MessagesDropped != 2 || !tr.SummaryDropped || tr.ChunksTruncated != 1 {
+		t.Errorf("truncation = %+v", tr)
+	}
+	if tr.Overflow || tr.Tokens > 300 {
+		t.Errorf("prompt is %d tokens, overflow %v", tr.Tokens, tr.Overflow)
+	}
+	if !strings.Contains(text, "best") || !strings.Contains(text, truncationMarker) || !strings.Contains(text, "What does Pro cost?") {
+		t.Errorf("expected the best chunk cut short and the query kept:\n%s", text)
+	}
+}
+
+func TestFitReportsOverflow(t *testing.T) {
+	data := budgetData()
+	data.Query = strings.Repeat("why ", 500)
+	_, tr, err := Default.Fit(data, Budget{ContextLength: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.Overflow {
+		t.Errorf("truncation = %+v, want overflow", tr)
+	}
+}
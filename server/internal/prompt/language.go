@@ -0,0 +1,78 @@
+package prompt
+
+import "strings"
+
+// Labels are the prompt scaffolding strings of one language.
+type Labels struct {
+	ConversationHistory string
+	HistoryNote         string
+	ContextDocuments    string
+	Doc                 string
+	Title               string
+	Source              string
+	Question            string
+	Answer              string
+	Instruction         string // Asks for an answer in the language; empty for English
+	NoContext           string // Answer when the documents hold nothing relevant
+	Blocked             string // Answer when a guardrail blocks the query or answer
+	EarlierSummary      string // Introduces the summary of older turns
+}
+
+// english is the built-in layout's scaffolding.
+var english = Labels{
+	ConversationHistory: "Conversation History",
+	HistoryNote:         "(Previous exchanges in this session for context)",
+	ContextDocuments:    "Context Documents",
+	Doc:                 "Doc",
+	Title:               "Title",
+	Source:              "Source",
+	Question:            "Question",
+	Answer:              "Answer (be brief and direct)",
+	NoContext:           "The documents don't cover this.",
+	Blocked:             "I can't help with that request.",
+	EarlierSummary:      "Summary of earlier exchanges",
+}
+
+// localizedLabels holds translated scaffolding by ISO 639-1 code.
+var localizedLabels = map[string]Labels{
+	"de": {"Gesprächsverlauf", "(Frühere Beiträge dieser Sitzung als Kontext)", "Kontextdokumente", "Dok", "Titel", "Quelle", "Frage", "Antwort (kurz und direkt)", "Antworte auf Deutsch.", "Die Dokumente behandeln dieses Thema nicht.", "Bei dieser Anfrage kann ich nicht helfen.", "Zusammenfassung früherer Beiträge"},
+	"fr": {"Historique de la conversation", "(Échanges précédents de cette session, pour le contexte)", "Documents de contexte", "Doc", "Titre", "Source", "Question", "Réponse (brève et directe)", "Réponds en français.", "Les documents ne traitent pas ce sujet.", "Je ne peux pas répondre à cette demande.", "Résumé des échanges précédents"},
+	"es": {"Historial de la conversación", "(Intercambios anteriores de esta sesión, como contexto)", "Documentos de contexto", "Doc", "Título", "Fuente", "Pregunta", "Respuesta (breve y directa)", "Responde en español.", "Los documentos no tratan este tema.", "No puedo ayudar con esa solicitud.", "Resumen de los intercambios anteriores"},
+	"it": {"Cronologia della conversazione", "(Scambi precedenti di questa sessione, come contesto)", "Documenti di contesto", "Doc", "Titolo", "Fonte", "Domanda", "Risposta (breve e diretta)", "Rispondi in italiano.", "I documenti non trattano questo argomento.", "Non posso aiutarti con questa richiesta.", "Riepilogo degli scambi precedenti"},
+	"pt": {"Histórico da conversa", "(Trocas anteriores desta sessão, como contexto)", "Documentos de contexto", "Doc", "Título", "Fonte", "Pergunta", "Resposta (breve e direta)", "Responda em português.", "Os documentos não abordam este assunto.", "Não posso ajudar com este pedido.", "Resumo das trocas anteriores"},
+	"nl": {"Gespreksgeschiedenis", "(Eerdere berichten in deze sessie, als context)", "Contextdocumenten", "Doc", "Titel", "Bron", "Vraag", "Antwoord (kort en direct)", "Antwoord in het Nederlands.", "De documenten behandelen dit onderwerp niet.", "Met dit verzoek kan ik niet helpen.", "Samenvatting van eerdere berichten"},
+	"ru": {"История разговора", "(Предыдущие сообщения этой сессии для контекста)", "Контекстные документы", "Док", "Заголовок", "Источник", "Вопрос", "Ответ (кратко и по существу)", "Отвечай на русском языке.", "В документах нет информации об этом.", "Я не могу помочь с этим запросом.", "Краткое содержание предыдущих сообщений"},
+	"ja": {"会話履歴", "（このセッションでの過去のやり取り）", "参考文書", "文書", "タイトル", "出典", "質問", "回答（簡潔かつ直接的に）", "日本語で回答してください。", "ドキュメントにはこの内容が含まれていません。", "このリクエストにはお応えできません。", "以前のやり取りの要約"},
+	"zh": {"对话历史", "（本次会话中之前的交流，供参考）", "参考文档", "文档", "标题", "来源", "问题", "回答（简洁直接）", "请用中文回答。", "文档中没有涉及这个问题。", "我无法处理这个请求。", "之前交流的摘要"},
+	"ko": {"대화 기록", "(이 세션의 이전 대화, 참고용)", "참고 문서", "문서", "제목", "출처", "질문", "답변 (간결하고 직접적으로)", "한국어로 답변하세요.", "문서에서 이 내용을 다루지 않습니다.", "이 요청은 도와드릴 수 없습니다.", "이전 대화 요약"},
+}
+
+// languageNames names every supported language, including those prompted
+// with English scaffolding.
+var languageNames = map[string]string{
+	"en": "English", "de": "German", "fr": "French", "es": "Spanish", "it": "Italian",
+	"pt": "Portuguese", "nl": "Dutch", "ru": "Russian", "ja": "Japanese", "zh": "Chinese",
+	"ko": "Korean", "ar": "Arabic", "el": "Greek", "he": "Hebrew", "th": "Thai", "hi": "Hindi",
+}
+
+// SupportedLanguage reports whether prompts can be localized for an
+// ISO 639-1 language code.
+func SupportedLanguage(code string) bool {
+	_, ok := languageNames[strings.ToLower(code)]
+	return ok
+}
+
+// LabelsFor returns the scaffolding for an ISO 639-1 language code.
+// Languages without translations get English scaffolding that asks for an
+// answer in the language; unknown codes get plain English.
+func LabelsFor(code string) Labels {
+	code = strings.ToLower(code)
+	if labels, ok := localizedLabels[code]; ok {
+		return labels
+	}
+	labels := english
+	if name, ok := languageNames[code]; ok && code != "en" {
+		labels.Instruction = "Answer in " + name + "."
+	}
+	return labels
+}
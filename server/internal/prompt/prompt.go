@@ -0,0 +1,306 @@
+// Package prompt renders RAG prompts from Go text/template layouts, so a
+// tenant can control the prompt's formatting, language and citation style.
+//
+// A template sees Data: the system prompt, the conversation history and a
+// summary of its older turns, the retrieved chunks, the query and the
+// scaffolding labels of the answer language. A minimal template:
+//
+//	{{.SystemPrompt}}
+//
+//	## Context Documents
+//
+//	{{range .Chunks}}[{{.Index}}] {{.Title}}
+//	{{.Content}}
+//	{{end}}
+//	## Question
+//	{{.Query}}
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// MaxTemplateSize is the largest template accepted, in bytes.
+const MaxTemplateSize = 64 * 1024
+
+// MaxPromptSize is the largest prompt a template may render, in bytes.
+const MaxPromptSize = 4 << 20
+
+// Templates are budgeted loop iterations and template calls, and time, so a
+// template such as {{range 1000000000000}}{{end}} fails instead of spinning.
+// Template calls also nest at most maxTemplateDepth deep, so recursion fails
+// before it builds a deep stack.
+const (
+	maxRenderSteps   = 100_000
+	maxTemplateDepth = 100
+)
+
+// renderTimeout is a var so tests can take the wall clock out of the budget.
+var renderTimeout = time.Second
+
+// stepFunc is the function Parse calls at the top of every range body and
+// template, to charge the render's budget. enterFunc and leaveFunc wrap every
+// template call, to track its depth.
+const (
+	stepFunc  = "_step"
+	enterFunc = "_enter"
+	leaveFunc = "_leave"
+)
+
+var (
+	errPromptTooLarge = fmt.Errorf("rendered prompt is over %d bytes", MaxPromptSize)
+	errTooManySteps   = fmt.Errorf("template runs over %d loop iterations", maxRenderSteps)
+	errTooDeep        = fmt.Errorf("template calls nest over %d deep", maxTemplateDepth)
+)
+
+// Data is what a template is executed with.
+type Data struct {
+	SystemPrompt string
+	Summary      string    // Summary of turns older than History; empty when none were summarized
+	History      string    // Conversation formatted as "User: ..." lines; empty without a session
+	Messages     []Message // The same conversation, one entry per message
+	Chunks       []Chunk
+	Query        string
+	Language     string // ISO 639-1 code of the answer language; empty for the model's default
+	Labels       Labels // Scaffolding in the answer language
+}
+
+// Message is one turn of the conversation history.
+type Message struct {
+	Role    string // "user" or "assistant"
+	Content string
+}
+
+// Chunk is a retrieved chunk as a template sees it.
+type Chunk struct {
+	Index    int // 1-based position, for citations
+	Title    string
+	Source   string
+	Content  string
+	Score    float32
+	Metadata map[string]string
+}
+
+// DefaultTemplate is the built-in prompt layout. Relevance scores are left
+// out so they do not bias the LLM.
+const DefaultTemplate = `{{.SystemPrompt}}
+
+{{if or .Summary .History}}## {{.Labels.ConversationHistory}}
+{{.Labels.HistoryNote}}
+
+{{with .Summary}}{{$.Labels.EarlierSummary}}: {{.}}
+
+{{end}}{{.History}}
+{{end}}## {{.Labels.ContextDocuments}}
+
+{{range .Chunks}}[{{$.Labels.Doc}} {{.Index}}]{{if .Title}} ({{$.Labels.Title}}: {{.Title}}){{end}}{{if .Source}} ({{$.Labels.Source}}: {{.Source}}){{end}}
+{{.Content}}
+
+{{end}}## {{.Labels.Question}}
+{{.Query}}
+
+## {{.Labels.Answer}}
+{{with .Labels.Instruction}}{{.}}
+{{end}}`
+
+// Template is a parsed prompt template.
+type Template struct {
+	tmpl *template.Template
+}
+
+// Default is the parsed DefaultTemplate.
+var Default = MustParse(DefaultTemplate)
+
+// Parse parses a prompt template. It does not check that the template
+// executes; use Validate for that.
+func Parse(text string) (*Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("template is empty")
+	}
+	if len(text) > MaxTemplateSize {
+		return nil, fmt.Errorf("template is %d bytes, the limit is %d", len(text), MaxTemplateSize)
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			chargeSteps(t.Tree.Root)
+		}
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// chargeSteps adds a call to stepFunc at the start of list and of every
+// range body within it, and wraps its template calls in enterFunc and
+// leaveFunc
+func chargeSteps(list *parse.ListNode) {
+	nodes := make([]parse.Node, 0, len(list.Nodes)+1)
+	nodes = append(nodes, funcCall(stepFunc))
+	for _, node := range list.Nodes {
+		if _, ok := node.(*parse.TemplateNode); ok {
+			nodes = append(nodes, funcCall(enterFunc), node, funcCall(leaveFunc))
+			continue
+		}
+		nodes = append(nodes, node)
+
+		var branch *parse.BranchNode
+		switch n := node.(type) {
+		case *parse.IfNode:
+			branch = &n.BranchNode
+		case *parse.WithNode:
+			branch = &n.BranchNode
+		case *parse.RangeNode:
+			branch = &n.BranchNode
+		default:
+			continue
+		}
+		if branch.List != nil {
+			chargeSteps(branch.List)
+		}
+		if branch.ElseList != nil {
+			chargeSteps(branch.ElseList)
+		}
+	}
+	list.Nodes = nodes
+}
+
+// funcCall is an action calling the function name without arguments
+func funcCall(name string) *parse.ActionNode {
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Cmds: []*parse.CommandNode{{
+				NodeType: parse.NodeCommand,
+				Args:     []parse.Node{&parse.IdentifierNode{NodeType: parse.NodeIdentifier, Ident: name}},
+			}},
+		},
+	}
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(text string) *Template {
+	t, err := Parse(text)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render executes the template with data. Renderings over MaxPromptSize, and
+// templates that loop too long, fail.
+func (t *Template) Render(data Data) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	b := &renderBudget{deadline: time.Now().Add(renderTimeout)}
+	tmpl.Funcs(template.FuncMap{stepFunc: b.step, enterFunc: b.enter, leaveFunc: b.leave})
+
+	w := &limitedBuffer{limit: MaxPromptSize}
+	if err := tmpl.Execute(w, data); err != nil {
+		return "", err
+	}
+	return w.buf.String(), nil
+}
+
+// renderBudget counts a render's steps against maxRenderSteps and its
+// deadline, and its template call depth against maxTemplateDepth
+type renderBudget struct {
+	steps    int
+	depth    int
+	deadline time.Time
+}
+
+func (b *renderBudget) step() (string, error) {
+	b.steps++
+	if b.steps > maxRenderSteps {
+		return "", errTooManySteps
+	}
+	if b.steps%1000 == 0 && time.Now().After(b.deadline) {
+		return "", fmt.Errorf("template took over %v to render", renderTimeout)
+	}
+	return "", nil
+}
+
+func (b *renderBudget) enter() (string, error) {
+	b.depth++
+	if b.depth > maxTemplateDepth {
+		return "", errTooDeep
+	}
+	return "", nil
+}
+
+func (b *renderBudget) leave() string {
+	b.depth--
+	return ""
+}
+
+// limitedBuffer is a bytes.Buffer that refuses to grow past limit
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedBuffer) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errPromptTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// SampleData is representative data for validating and previewing templates.
+var SampleData = Data{
+	SystemPrompt: "You are a helpful assistant. Answer using only the context documents.",
+	Summary:      "The user runs a five-person team and asked about plan pricing.",
+	History:      "User: What plans do you offer?\nAssistant: There are Basic and Pro plans.\n",
+	Messages: []Message{
+		{Role: "user", Content: "What plans do you offer?"},
+		{Role: "assistant", Content: "There are Basic and Pro plans."},
+	},
+	Chunks: []Chunk{
+		{
+			Index:    1,
+			Title:    "Pricing",
+			Source:   "https://example.com/pricing",
+			Content:  "The Pro plan costs $20 per month and includes priority support.",
+			Score:    0.82,
+			Metadata: map[string]string{"title": "Pricing", "source": "https://example.com/pricing"},
+		},
+		{
+			Index:    2,
+			Title:    "Support",
+			Content:  "Priority support answers within four business hours.",
+			Score:    0.71,
+			Metadata: map[string]string{"title": "Support"},
+		},
+	},
+	Query:    "How fast is Pro support?",
+	Language: "en",
+	Labels:   english,
+}
+
+// Validate parses a template and executes it with SampleData, returning the
+// rendered preview. Templates that leave out the query are rejected.
+func Validate(text string) (string, error) {
+	t, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	preview, err := t.Render(SampleData)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(preview, SampleData.Query) {
+		return "", errors.New("template does not include the query")
+	}
+	return preview, nil
+}
@@ -0,0 +1,133 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultTemplate(t *testing.T) {
+	got, err := Default.Render(Data{
+		SystemPrompt: "Be helpful.",
+		Chunks: []Chunk{
+			{Index: 1, Title: "Pricing", Source: "https://example.com", Content: "Pro costs $20."},
+			{Index: 2, Content: "No title here."},
+		},
+		Query:  "How much is Pro?",
+		Labels: LabelsFor("en"),
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := "Be helpful.\n\n" +
+		"## Context Documents\n\n" +
+		"[Doc 1] (Title: Pricing) (Source: https://example.com)\nPro costs $20.\n\n" +
+		"[Doc 2]\nNo title here.\n\n" +
+		"## Question\nHow much is Pro?\n\n" +
+		"## Answer (be brief and direct)\n"
+	if got != want {
+		t.Errorf("Render =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestDefaultTemplateHistory(t *testing.T) {
+	got, err := Default.Render(Data{History: "User: hi\n", Query: "q", Labels: LabelsFor("en")})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(got, "## Conversation History\n(Previous exchanges in this session for context)\n\nUser: hi\n\n## Context Documents") {
+		t.Errorf("history section missing or malformed:\n%s", got)
+	}
+}
+
+func TestDefaultTemplateLocalized(t *testing.T) {
+	got, err := Default.Render(Data{
+		Chunks: []Chunk{{Index: 1, Title: "Preise", Content: "Pro kostet 20 $."}},
+		Query:  "Was kostet Pro?",
+		Labels: LabelsFor("de"),
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	for _, want := range []string{"## Kontextdokumente", "[Dok 1] (Titel: Preise)", "## Frage\nWas kostet Pro?", "## Antwort (kurz und direkt)\nAntworte auf Deutsch.\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered prompt lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestLabelsFor(t *testing.T) {
+	if got := LabelsFor("en"); got.Instruction != "" || got.Question != "Question" {
+		t.Errorf("LabelsFor(en) = %+v", got)
+	}
+	if got := LabelsFor("FR").Answer; got != "Réponse (brève et directe)" {
+		t.Errorf("LabelsFor(FR).Answer = %q", got)
+	}
+	if got := LabelsFor("ar"); got.Question != "Question" || got.Instruction != "Answer in Arabic." {
+		t.Errorf("LabelsFor(ar) = %+v", got)
+	}
+	if got := LabelsFor("xx").Instruction; got != "" {
+		t.Errorf("LabelsFor(xx).Instruction = %q", got)
+	}
+	if SupportedLanguage("xx") || !SupportedLanguage("ja") {
+		t.Error("SupportedLanguage misreports")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{"default", DefaultTemplate, ""},
+		{"custom citations", "{{range .Chunks}}({{.Index}}) {{.Content}}\n{{end}}Frage: {{.Query}}", ""},
+		{"empty", "  ", "empty"},
+		{"syntax error", "{{.Query", "unclosed action"},
+		{"unknown field", "{{.Question}}", "can't evaluate field Question"},
+		{"no query", "{{.SystemPrompt}}", "does not include the query"},
+		{"too large", strings.Repeat("x", MaxTemplateSize) + "{{.Query}}", "limit"},
+		{"endless loop", "{{range 1000000000000}}{{end}}{{.Query}}", "loop iterations"},
+		{"nested loops", "{{range 100000}}{{range 100000}}{{end}}{{end}}{{.Query}}", "loop iterations"},
+		{"recursion", `{{define "r"}}{{template "r" .}}{{template "r" .}}{{end}}{{template "r" .}}{{.Query}}`, "nest over"},
+		{"wide template calls", `{{define "w"}}{{range 1000}}{{end}}{{end}}{{range 1000}}{{template "w"}}{{end}}{{.Query}}`, "loop iterations"},
+		{"huge output", "{{range 100000}}{{$.SystemPrompt}}{{end}}{{.Query}}", "rendered prompt is over"},
+	}
+
+	// The step and size budgets must fail these, however slow the machine
+	defer setRenderTimeout(time.Hour)()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := Validate(tt.template)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				if !strings.Contains(preview, SampleData.Query) {
+					t.Errorf("preview does not contain the sample query:\n%s", preview)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTimeout(t *testing.T) {
+	// Checked every 1000 steps, a zero timeout has passed at the first check
+	defer setRenderTimeout(0)()
+	_, err := Validate("{{range 1000000000000}}{{end}}{{.Query}}")
+	if err == nil || !strings.Contains(err.Error(), "to render") {
+		t.Errorf("Validate error = %v, want the render timeout", err)
+	}
+}
+
+// setRenderTimeout sets renderTimeout and returns a func restoring it
+func setRenderTimeout(d time.Duration) func() {
+	previous := renderTimeout
+	renderTimeout = d
+	return func() { renderTimeout = previous }
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// memorySweepInterval is how often full, idle buckets are dropped
+	memorySweepInterval = 5 * time.Minute
+	// memoryMaxBuckets caps the buckets kept between sweeps
+	memoryMaxBuckets = 100_000
+)
+
+// MemoryStore keeps token buckets in process memory
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+	max     int
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// refill is how long the bucket's rate takes to fill it from empty
+	refill time.Duration
+}
+
+// NewMemoryStore creates an in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+		max:     memoryMaxBuckets,
+		now:     time.Now,
+	}
+}
+
+// Take removes a token from the bucket for key
+func (s *MemoryStore) Take(_ context.Context, key string, rate Rate) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	burst := float64(rate.burst())
+	if now.Sub(s.swept) >= memorySweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= s.max {
+			s.sweep(now)
+			s.evict()
+		}
+		b = &bucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*rate.RPS)
+	b.updated = now
+	b.refill = time.Duration(burst / rate.RPS * float64(time.Second))
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	wait := time.Duration((1 - b.tokens) / rate.RPS * float64(time.Second))
+	return false, wait, nil
+}
+
+// sweep drops buckets that have refilled at their own rate, which behave
+// like new ones
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > b.refill {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
+
+// evict drops the least recently updated of a few buckets sampled at random
+// until there is room for a new one
+func (s *MemoryStore) evict() {
+	for len(s.buckets) >= s.max {
+		var oldest string
+		var oldestAt time.Time
+		sampled := 0
+		for key, b := range s.buckets {
+			if sampled == 0 || b.updated.Before(oldestAt) {
+				oldest, oldestAt = key, b.updated
+			}
+			if sampled++; sampled == 8 {
+				break
+			}
+		}
+		delete(s.buckets, oldest)
+	}
+}
@@ -0,0 +1,278 @@
+// Package ratelimit provides token-bucket rate limiting per API key and per tenant for the gRPC server.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/knoguchi/rag/internal/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterHeader is the response metadata key carrying the seconds until a request may be retried
+const RetryAfterHeader = "retry-after"
+
+// Rate is a token bucket: RPS tokens are added per second up to Burst
+type Rate struct {
+	RPS   float64
+	Burst int
+}
+
+// Enabled reports whether the rate limits anything
+func (r Rate) Enabled() bool {
+	return r.RPS > 0
+}
+
+func (r Rate) burst() int {
+	if r.Burst < 1 {
+		return int(math.Max(1, math.Ceil(r.RPS)))
+	}
+	return r.Burst
+}
+
+// Store holds token buckets
+type Store interface {
+	// Take removes a token from the bucket for key. When the bucket is empty it
+	// returns false and how long until a token is available.
+	Take(ctx context.Context, key string, rate Rate) (bool, time.Duration, error)
+}
+
+// Config configures a Limiter
+type Config struct {
+	// PerKey limits each authenticated credential, an API key or a bearer
+	// token, and each client address until its credential is authenticated
+	PerKey Rate
+	// PerTenant limits all of a tenant's credentials together
+	PerTenant Rate
+
+	// Store defaults to an in-memory store, which limits each replica separately
+	Store  Store
+	Logger *slog.Logger
+}
+
+// Limiter rate limits gRPC requests. Store errors let requests through, so an
+// unavailable Redis does not take the service down.
+type Limiter struct {
+	rates       atomic.Pointer[rates]
+	store       Store
+	logger      *slog.Logger
+	skipMethods map[string]bool
+}
+
+// New creates a Limiter
+func New(cfg Config) *Limiter {
+	store := cfg.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	l := &Limiter{
+		store:  store,
+		logger: logger,
+		skipMethods: map[string]bool{
+			"/grpc.health.v1.Health/Check": true,
+			"/grpc.health.v1.Health/Watch": true,
+		},
+	}
+	l.SetRates(cfg.PerKey, cfg.PerTenant)
+	return l
+}
+
+// rates are a Limiter's rates, which SetRates replaces together
+type rates struct {
+	perKey    Rate
+	perTenant Rate
+}
+
+// SetRates changes the per-credential and per-tenant rates while the
+// Limiter is in use. Buckets keep their tokens, up to the new burst.
+func (l *Limiter) SetRates(perKey, perTenant Rate) {
+	l.rates.Store(&rates{perKey: perKey, perTenant: perTenant})
+}
+
+// AddrUnaryInterceptor limits requests per client address. Install it
+// before authentication so floods of invalid keys are limited too; buckets
+// are not keyed on credentials until they are verified, so made-up ones
+// cannot each get a bucket.
+func (l *Limiter) AddrUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod, l.rates.Load().perKey, addrKey); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AddrStreamInterceptor limits streams per client address
+func (l *Limiter) AddrStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context(), info.FullMethod, l.rates.Load().perKey, addrKey); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// KeyUnaryInterceptor limits requests per credential. Install it after
+// authentication, which puts the verified tenant or admin in the context.
+func (l *Limiter) KeyUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod, l.rates.Load().perKey, credentialKey); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// KeyStreamInterceptor limits streams per credential
+func (l *Limiter) KeyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context(), info.FullMethod, l.rates.Load().perKey, credentialKey); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// TenantUnaryInterceptor limits requests per tenant. Install it after
+// authentication, which puts the tenant in the context.
+func (l *Limiter) TenantUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod, l.rates.Load().perTenant, tenantKey); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantStreamInterceptor limits streams per tenant
+func (l *Limiter) TenantStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context(), info.FullMethod, l.rates.Load().perTenant, tenantKey); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check takes a token from the bucket keyFn picks for the request
+func (l *Limiter) check(ctx context.Context, method string, rate Rate, keyFn func(context.Context) (string, bool)) error {
+	if !rate.Enabled() || l.skipMethods[method] {
+		return nil
+	}
+	key, ok := keyFn(ctx)
+	if !ok {
+		return nil
+	}
+
+	allowed, retryAfter, err := l.store.Take(ctx, key, rate)
+	if err != nil {
+		l.logger.Warn("rate limit store failed, allowing request", "error", err)
+		return nil
+	}
+	if allowed {
+		return nil
+	}
+	return exhausted(ctx, retryAfter)
+}
+
+// exhausted builds a ResourceExhausted error with the retry delay in the
+// retry-after header and a RetryInfo detail
+func exhausted(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds)))
+
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded; retry after "+strconv.Itoa(seconds)+"s")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// credentialKey identifies the request's credential once authentication has
+// accepted it. Credentials are hashed so they are never written to the store.
+func credentialKey(ctx context.Context) (string, bool) {
+	_, tenant := auth.TenantFromContext(ctx)
+	_, admin := auth.AdminFromContext(ctx)
+	if !tenant && !admin {
+		return "", false
+	}
+	if token, ok := auth.BearerToken(ctx); ok {
+		return "key:" + hash(token), true
+	}
+	if apiKey, err := auth.APIKeyFromContext(ctx); err == nil {
+		return "key:" + hash(apiKey), true
+	}
+	return "", false
+}
+
+// addrKey identifies the request's client address
+func addrKey(ctx context.Context) (string, bool) {
+	addr, ok := clientAddr(ctx)
+	if !ok {
+		return "", false
+	}
+	return "addr:" + addr, true
+}
+
+// clientAddr returns the client's IP. Requests from the HTTP gateway arrive
+// over loopback, so for those the address the gateway appended to
+// x-forwarded-for is used; other clients cannot spoof it.
+func clientAddr(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", false
+	}
+	host := hostOnly(p.Addr.String())
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+				hops := strings.Split(forwarded[len(forwarded)-1], ",")
+				return strings.TrimSpace(hops[len(hops)-1]), true
+			}
+		}
+	}
+	return host, true
+}
+
+// tenantKey identifies the authenticated tenant; admin requests are not limited per tenant
+func tenantKey(ctx context.Context) (string, bool) {
+	tenantID, ok := auth.TenantIDFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return "tenant:" + tenantID.String(), true
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
+
+// hostOnly strips the port so a client's connections share a bucket
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
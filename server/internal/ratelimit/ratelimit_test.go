@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	rate := Rate{RPS: 2, Burst: 3}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if ok, _, _ := s.Take(ctx, "k", rate); !ok {
+			t.Fatalf("request %d within burst rejected", i+1)
+		}
+	}
+	ok, wait, _ := s.Take(ctx, "k", rate)
+	if ok {
+		t.Fatal("request over burst allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("wait = %v, want 500ms at 2 rps", wait)
+	}
+	if ok, _, _ := s.Take(ctx, "other", rate); !ok {
+		t.Error("buckets are not independent")
+	}
+
+	now = now.Add(wait)
+	if ok, _, _ := s.Take(ctx, "k", rate); !ok {
+		t.Error("refilled token rejected")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	s := NewMemoryStore()
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A slow bucket outlives a sweep run by a request at a fast rate
+	s.Take(ctx, "slow", Rate{RPS: 0.001, Burst: 1})
+	now = now.Add(memorySweepInterval)
+	s.Take(ctx, "fast", Rate{RPS: 100, Burst: 1})
+	if ok, _, _ := s.Take(ctx, "slow", Rate{RPS: 0.001, Burst: 1}); ok {
+		t.Error("sweep dropped a bucket still refilling at its own rate")
+	}
+
+	s.max = 2
+	s.Take(ctx, "third", Rate{RPS: 1, Burst: 1})
+	if len(s.buckets) > s.max {
+		t.Errorf("buckets = %d, want at most %d", len(s.buckets), s.max)
+	}
+}
+
+func TestAddrInterceptor(t *testing.T) {
+	l := New(Config{PerKey: Rate{RPS: 1, Burst: 1}})
+	unary := l.AddrUnaryInterceptor()
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+
+	call := func(addr string, md ...string) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 40000}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(md...))
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/rag.v1.RAGService/Query"}, handler)
+		return err
+	}
+
+	if err := call("10.0.0.1", "x-api-key", "rag_a"); err != nil {
+		t.Fatal(err)
+	}
+	// Unverified credentials do not get buckets of their own
+	err := call("10.0.0.1", "x-api-key", "rag_made_up")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second request from the same address: %v, want ResourceExhausted", err)
+	}
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("missing RetryInfo, details = %v", status.Convert(err).Details())
+	}
+	if err := call("10.0.0.2", "x-api-key", "rag_a"); err != nil {
+		t.Errorf("different address limited: %v", err)
+	}
+
+	// Gateway requests are told apart by the forwarded client address
+	if err := call("127.0.0.1", "x-forwarded-for", "203.0.113.7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call("127.0.0.1", "x-forwarded-for", "203.0.113.8"); err != nil {
+		t.Errorf("different gateway client limited: %v", err)
+	}
+	// Direct clients cannot pick their bucket with x-forwarded-for
+	if err := call("10.0.0.9", "x-forwarded-for", "198.51.100.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call("10.0.0.9", "x-forwarded-for", "198.51.100.2"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("spoofed x-forwarded-for: %v, want ResourceExhausted", err)
+	}
+}
+
+func TestKeyInterceptor(t *testing.T) {
+	l := New(Config{PerKey: Rate{RPS: 1, Burst: 1}})
+	unary := l.KeyUnaryInterceptor()
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+	tenant := &repository.Tenant{ID: uuid.New(), Name: "acme"}
+
+	call := func(authenticated bool, md ...string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(md...))
+		if authenticated {
+			ctx = auth.WithTenant(ctx, tenant, nil)
+		}
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/rag.v1.RAGService/Query"}, handler)
+		return err
+	}
+
+	if err := call(true, "x-api-key", "rag_a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(true, "x-api-key", "rag_a"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second request with the same key: %v, want ResourceExhausted", err)
+	}
+	if err := call(true, "x-api-key", "rag_b"); err != nil {
+		t.Errorf("different key limited: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := call(false, "x-api-key", "rag_c"); err != nil {
+			t.Fatalf("unauthenticated request %d limited per key: %v", i+1, err)
+		}
+	}
+	if len(l.store.(*MemoryStore).buckets) != 2 {
+		t.Errorf("buckets = %d, want only the authenticated keys'", len(l.store.(*MemoryStore).buckets))
+	}
+}
+
+func TestSetRates(t *testing.T) {
+	l := New(Config{})
+	unary := l.KeyUnaryInterceptor()
+	handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+	call := func() error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "rag_a"))
+		ctx = auth.WithTenant(ctx, &repository.Tenant{ID: uuid.New()}, nil)
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/rag.v1.RAGService/Query"}, handler)
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := call(); err != nil {
+			t.Fatalf("request %d without limits: %v", i+1, err)
+		}
+	}
+	l.SetRates(Rate{RPS: 1, Burst: 1}, Rate{})
+	if err := call(); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("request over the new rate: code = %v, want ResourceExhausted", status.Code(err))
+	}
+}
@@ -0,0 +1,279 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redisKeyPrefix namespaces the buckets in a shared Redis
+	redisKeyPrefix = "rag:ratelimit:"
+	// redisPoolSize is the number of idle connections kept
+	redisPoolSize = 8
+	// redisTimeout bounds each command when the context has no deadline
+	redisTimeout = 500 * time.Millisecond
+)
+
+// takeScript refills and takes from a bucket atomically, using the Redis
+// server's clock so replicas agree. Returns {allowed, wait_ms}.
+const takeScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}
+`
+
+// RedisStore keeps token buckets in Redis so every replica shares them
+type RedisStore struct {
+	addr      string
+	password  string
+	username  string
+	db        int
+	tlsConfig *tls.Config
+	scriptSHA string
+	idle      chan *redisConn
+}
+
+// NewRedisStore creates a store from a URL of the form
+// redis://[user:password@]host:port[/db]; rediss:// connects with TLS
+func NewRedisStore(rawURL string) (*RedisStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	s := &RedisStore{
+		addr: u.Host,
+		idle: make(chan *redisConn, redisPoolSize),
+	}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		s.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: u.Hostname()}
+	default:
+		return nil, fmt.Errorf("unsupported Redis URL scheme %q", u.Scheme)
+	}
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		s.username = u.User.Username()
+		s.password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if s.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid Redis database %q", db)
+		}
+	}
+
+	sum := sha1.Sum([]byte(takeScript))
+	s.scriptSHA = hex.EncodeToString(sum[:])
+	return s, nil
+}
+
+// Take removes a token from the bucket for key
+func (s *RedisStore) Take(ctx context.Context, key string, rate Rate) (bool, time.Duration, error) {
+	args := []string{"1", redisKeyPrefix + key,
+		strconv.FormatFloat(rate.RPS, 'f', -1, 64), strconv.Itoa(rate.burst())}
+
+	reply, err := s.do(ctx, append([]string{"EVALSHA", s.scriptSHA}, args...)...)
+	var redisErr redisError
+	if errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+		// First use on this server; EVAL also caches the script
+		reply, err = s.do(ctx, append([]string{"EVAL", takeScript}, args...)...)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script reply %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	waitMS, _ := values[1].(int64)
+	return allowed == 1, time.Duration(waitMS) * time.Millisecond, nil
+}
+
+// Close closes idle connections
+func (s *RedisStore) Close() error {
+	for {
+		select {
+		case c := <-s.idle:
+			c.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do runs a command on a pooled connection
+func (s *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	c, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	c.conn.SetDeadline(deadline)
+
+	reply, err := c.command(args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection's state is unknown after an I/O error
+		c.conn.Close()
+		return nil, err
+	}
+	s.put(c)
+	return reply, err
+}
+
+func (s *RedisStore) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-s.idle:
+		return c, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	var conn net.Conn
+	var err error
+	if s.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	if s.password != "" {
+		auth := []string{"AUTH", s.password}
+		if s.username != "" {
+			auth = []string{"AUTH", s.username, s.password}
+		}
+		if _, err := c.command(auth...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("Redis AUTH failed: %w", err)
+		}
+	}
+	if s.db != 0 {
+		if _, err := c.command("SELECT", strconv.Itoa(s.db)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("Redis SELECT failed: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func (s *RedisStore) put(c *redisConn) {
+	select {
+	case s.idle <- c:
+	default:
+		c.conn.Close()
+	}
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+// redisConn speaks enough RESP for the rate limiting commands
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func (c *redisConn) command(args ...string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return c.reply()
+}
+
+func (c *redisConn) reply() (interface{}, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			value, err := c.reply()
+			var redisErr redisError
+			if errors.As(err, &redisErr) {
+				// Keep reading so the connection stays in sync
+				value, err = redisErr, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unexpected Redis reply %q", line)
+	}
+}
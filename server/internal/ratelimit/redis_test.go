@@ -0,0 +1,260 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis speaks enough RESP to stand in for Redis: it answers AUTH and
+// SELECT, rejects EVALSHA until EVAL has loaded the script, and allows the
+// first burst takes of each key
+type fakeRedis struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu       sync.Mutex
+	commands [][]string
+	loaded   bool
+	taken    map[string]int
+	conns    int
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeRedis{t: t, listener: listener, taken: make(map[string]int)}
+	t.Cleanup(func() { listener.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.conns++
+		f.mu.Unlock()
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.reply(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) reply(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, args)
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "EVALSHA":
+		if !f.loaded {
+			return "-NOSCRIPT No matching script\r\n"
+		}
+	case "EVAL":
+		f.loaded = true
+	default:
+		return "-ERR unknown command\r\n"
+	}
+
+	// EVAL[SHA] script numkeys key rate burst
+	key := args[3]
+	burst, _ := strconv.Atoi(args[5])
+	f.taken[key]++
+	if f.taken[key] <= burst {
+		return "*2\r\n:1\r\n:0\r\n"
+	}
+	return "*2\r\n:0\r\n:1500\r\n"
+}
+
+func (f *fakeRedis) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, len(f.commands))
+	for i, args := range f.commands {
+		names[i] = args[0]
+	}
+	return names
+}
+
+// readCommand reads a RESP array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readLength(r, '*')
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		size, err := readLength(r, '$')
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readLength(r *bufio.Reader, prefix byte) (int, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" || line[0] != prefix {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return strconv.Atoi(line[1:])
+}
+
+func TestRedisStore(t *testing.T) {
+	f := newFakeRedis(t)
+	s, err := NewRedisStore("redis://user:secret@" + f.listener.Addr().String() + "/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+	rate := Rate{RPS: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := s.Take(ctx, "k", rate)
+		if err != nil || !ok {
+			t.Fatalf("take %d within burst: ok = %v, err = %v", i+1, ok, err)
+		}
+	}
+	ok, wait, err := s.Take(ctx, "k", rate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || wait != 1500*time.Millisecond {
+		t.Errorf("take over burst: ok = %v, wait = %v, want false and 1.5s", ok, wait)
+	}
+
+	want := []string{"AUTH", "SELECT", "EVALSHA", "EVAL", "EVALSHA", "EVALSHA"}
+	if got := f.names(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("commands = %v, want %v", got, want)
+	}
+	f.mu.Lock()
+	auth, conns := f.commands[0], f.conns
+	key := f.commands[2][3]
+	f.mu.Unlock()
+	if strings.Join(auth, " ") != "AUTH user secret" {
+		t.Errorf("AUTH = %v, want the URL's user and password", auth)
+	}
+	if key != redisKeyPrefix+"k" {
+		t.Errorf("key = %q, want %q", key, redisKeyPrefix+"k")
+	}
+	if conns != 1 {
+		t.Errorf("connections = %d, want 1 reused", conns)
+	}
+}
+
+func TestRedisStoreErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			r := bufio.NewReader(conn)
+			if _, err := readCommand(r); err == nil {
+				io.WriteString(conn, "-WRONGPASS invalid username-password pair\r\n")
+			}
+			conn.Close()
+		}
+	}()
+
+	s, err := NewRedisStore("redis://:wrong@" + listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Take(context.Background(), "k", Rate{RPS: 1}); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("err = %v, want the AUTH error", err)
+	}
+
+	for _, raw := range []string{"http://localhost", "redis://localhost/db"} {
+		if _, err := NewRedisStore(raw); err == nil {
+			t.Errorf("NewRedisStore(%q) accepted", raw)
+		}
+	}
+}
+
+func TestRedisReply(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"+OK\r\n", "OK"},
+		{":42\r\n", "42"},
+		{"$5\r\nhello\r\n", "hello"},
+		{"*3\r\n:1\r\n$2\r\nhi\r\n-ERR nested\r\n", "[1 hi ERR nested]"},
+	}
+	for _, tt := range tests {
+		c := &redisConn{r: bufio.NewReader(strings.NewReader(tt.raw))}
+		got, err := c.reply()
+		if err != nil {
+			t.Errorf("%q: %v", tt.raw, err)
+			continue
+		}
+		if s := fmtReply(got); s != tt.want {
+			t.Errorf("%q = %s, want %s", tt.raw, s, tt.want)
+		}
+	}
+
+	c := &redisConn{r: bufio.NewReader(strings.NewReader("-NOSCRIPT missing\r\n"))}
+	if _, err := c.reply(); err == nil || err.Error() != "NOSCRIPT missing" {
+		t.Errorf("error reply: err = %v", err)
+	}
+}
+
+func fmtReply(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmtReply(e)
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case redisError:
+		return string(v)
+	case string:
+		return v
+	}
+	return "?"
+}
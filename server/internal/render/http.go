@@ -0,0 +1,115 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the render timeout used when none is given.
+const DefaultTimeout = 30 * time.Second
+
+// maxPageSize is the largest rendered page read from the service.
+const maxPageSize = 20 << 20
+
+// HTTPConfig holds configuration for a rendering service.
+type HTTPConfig struct {
+	// URL is the endpoint render requests are POSTed to.
+	URL string
+
+	// APIKey is sent as a bearer token, if set.
+	APIKey string
+
+	// UserAgent is the browser's user agent, if set.
+	UserAgent string
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// HTTPRenderer implements Renderer against a rendering service. The service
+// receives {"url": ..., "userAgent": ..., "gotoOptions": {"waitUntil":
+// "networkidle2", "timeout": ms}}, as Browserless' /content endpoint does,
+// and responds with the rendered HTML.
+type HTTPRenderer struct {
+	url       string
+	apiKey    string
+	userAgent string
+	client    *http.Client
+}
+
+// httpRequest represents a request to the rendering service.
+type httpRequest struct {
+	URL         string      `json:"url"`
+	UserAgent   string      `json:"userAgent,omitempty"`
+	GotoOptions gotoOptions `json:"gotoOptions"`
+}
+
+type gotoOptions struct {
+	WaitUntil string `json:"waitUntil"`
+	Timeout   int64  `json:"timeout"`
+}
+
+// NewHTTPRenderer creates an HTTP renderer with the given configuration.
+func NewHTTPRenderer(cfg HTTPConfig) *HTTPRenderer {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &HTTPRenderer{
+		url:       cfg.URL,
+		apiKey:    cfg.APIKey,
+		userAgent: cfg.UserAgent,
+		client:    client,
+	}
+}
+
+// Render asks the rendering service for the page's HTML.
+func (r *HTTPRenderer) Render(ctx context.Context, url string, timeout time.Duration) (*Page, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	body, err := json.Marshal(httpRequest{
+		URL:         url,
+		UserAgent:   r.userAgent,
+		GotoOptions: gotoOptions{WaitUntil: "networkidle2", Timeout: timeout.Milliseconds()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Leave the service time to report its own timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout+10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/html")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("rendering service returned status %d: %s", resp.StatusCode, string(msg))
+	}
+
+	html, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &Page{HTML: html}, nil
+}
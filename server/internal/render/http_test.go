@@ -0,0 +1,49 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPRenderer(t *testing.T) {
+	var got httpRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte("<html><body>rendered</body></html>"))
+	}))
+	defer server.Close()
+
+	renderer := NewHTTPRenderer(HTTPConfig{URL: server.URL, APIKey: "secret", UserAgent: "RAG-Service/1.0"})
+	page, err := renderer.Render(context.Background(), "https://example.com/app", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(string(page.HTML), "rendered") {
+		t.Errorf("HTML = %q", page.HTML)
+	}
+	if got.URL != "https://example.com/app" || got.UserAgent != "RAG-Service/1.0" || got.GotoOptions.Timeout != 5000 {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestHTTPRendererError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "navigation timeout", http.StatusRequestTimeout)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPRenderer(HTTPConfig{URL: server.URL}).Render(context.Background(), "https://example.com", 0)
+	if err == nil || !strings.Contains(err.Error(), "navigation timeout") {
+		t.Errorf("Render error = %v, want the service's message", err)
+	}
+}
@@ -0,0 +1,23 @@
+// Package render loads pages in a headless browser, so sites that build
+// their content with JavaScript can be ingested.
+//
+// Rendering is delegated to a browser service over HTTP, such as a
+// Browserless or Playwright server, rather than run in-process.
+package render
+
+import (
+	"context"
+	"time"
+)
+
+// Page is a rendered page.
+type Page struct {
+	// HTML is the page's DOM serialized once it finished loading.
+	HTML []byte
+}
+
+// Renderer loads a page in a headless browser.
+type Renderer interface {
+	// Render loads url, running its scripts, within timeout.
+	Render(ctx context.Context, url string, timeout time.Duration) (*Page, error)
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// APIKeyRepo implements repository.APIKeyRepository
+type APIKeyRepo struct {
+	db *DB
+}
+
+// NewAPIKeyRepo creates a new API key repository
+func NewAPIKeyRepo(db *DB) *APIKeyRepo {
+	return &APIKeyRepo{db: db}
+}
+
+const apiKeyColumns = `id, tenant_id, name, api_key, scopes, created_at, project_id`
+
+// Create creates a new scoped API key
+func (r *APIKeyRepo) Create(ctx context.Context, key *repository.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, tenant_id, name, api_key, scopes, created_at, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.conn(ctx).Exec(ctx, query,
+		key.ID, key.TenantID, key.Name, key.Key, key.Scopes, key.CreatedAt, key.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a scoped API key by ID
+func (r *APIKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id)
+}
+
+// GetByKey retrieves a scoped API key by its value
+func (r *APIKeyRepo) GetByKey(ctx context.Context, apiKey string) (*repository.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE api_key = $1`, apiKey)
+}
+
+func (r *APIKeyRepo) get(ctx context.Context, query string, arg any) (*repository.APIKey, error) {
+	var key repository.APIKey
+	err := r.db.conn(ctx).QueryRow(ctx, query, arg).Scan(
+		&key.ID, &key.TenantID, &key.Name, &key.Key, &key.Scopes, &key.CreatedAt, &key.ProjectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return &key, nil
+}
+
+// List retrieves a tenant's scoped API keys
+func (r *APIKeyRepo) List(ctx context.Context, tenantID uuid.UUID) ([]*repository.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.conn(ctx).Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*repository.APIKey
+	for rows.Next() {
+		var key repository.APIKey
+		if err := rows.Scan(&key.ID, &key.TenantID, &key.Name, &key.Key, &key.Scopes, &key.CreatedAt, &key.ProjectID); err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+	return keys, rows.Err()
+}
+
+// Delete deletes a scoped API key
+func (r *APIKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM api_keys WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Ensure APIKeyRepo implements the interface
+var _ repository.APIKeyRepository = (*APIKeyRepo)(nil)
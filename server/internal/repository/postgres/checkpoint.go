@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// SaveCheckpoint stores a document's ingestion checkpoint, replacing any saved before
+func (r *DocumentRepo) SaveCheckpoint(ctx context.Context, checkpoint *repository.IngestionCheckpoint) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO ingestion_checkpoints (document_id, chunks_total, chunks_embedded, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (document_id) DO UPDATE
+		SET chunks_total = EXCLUDED.chunks_total, chunks_embedded = EXCLUDED.chunks_embedded,
+		    updated_at = NOW()
+	`, checkpoint.DocumentID, checkpoint.ChunksTotal, checkpoint.ChunksEmbedded)
+	if err != nil {
+		return fmt.Errorf("failed to save ingestion checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ListCheckpoints lists every ingestion checkpoint, oldest first
+func (r *DocumentRepo) ListCheckpoints(ctx context.Context) ([]*repository.IngestionCheckpoint, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT document_id, chunks_total, chunks_embedded, updated_at
+		FROM ingestion_checkpoints
+		ORDER BY updated_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingestion checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var checkpoints []*repository.IngestionCheckpoint
+	for rows.Next() {
+		var checkpoint repository.IngestionCheckpoint
+		if err := rows.Scan(&checkpoint.DocumentID, &checkpoint.ChunksTotal, &checkpoint.ChunksEmbedded, &checkpoint.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan ingestion checkpoint: %w", err)
+		}
+		checkpoints = append(checkpoints, &checkpoint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list ingestion checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+// DeleteCheckpoint deletes a document's ingestion checkpoint
+func (r *DocumentRepo) DeleteCheckpoint(ctx context.Context, documentID uuid.UUID) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM ingestion_checkpoints WHERE document_id = $1`, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete ingestion checkpoint: %w", err)
+	}
+	return nil
+}
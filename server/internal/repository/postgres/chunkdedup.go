@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// ShareChunks counts a reference to each hashed chunk's content and points it
+// at the chunk that owns the hash's vector, claiming the hash for the chunk
+// itself when it is the first. Hash rows are locked in hash order, so
+// concurrent ingests sharing chunks can't deadlock.
+func (r *DocumentRepo) ShareChunks(ctx context.Context, tenantID uuid.UUID, chunks []*repository.DocumentChunk) error {
+	var hashed []*repository.DocumentChunk
+	for _, chunk := range chunks {
+		if chunk.ContentHash != "" {
+			hashed = append(hashed, chunk)
+		}
+	}
+	if len(hashed) == 0 {
+		return nil
+	}
+	// Stable, so the first of identical chunks claims the hash
+	slices.SortStableFunc(hashed, func(a, b *repository.DocumentChunk) int {
+		return cmp.Compare(a.ContentHash, b.ContentHash)
+	})
+
+	batch := &pgx.Batch{}
+	for _, chunk := range hashed {
+		batch.Queue(`
+			INSERT INTO chunk_hashes (tenant_id, content_hash, chunk_id, ref_count)
+			VALUES ($1, $2, $3, 1)
+			ON CONFLICT (tenant_id, content_hash) DO UPDATE
+			SET ref_count = chunk_hashes.ref_count + 1
+			RETURNING chunk_id
+		`, tenantID, chunk.ContentHash, chunk.ID)
+	}
+
+	results := r.db.conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+
+	for _, chunk := range hashed {
+		var owner uuid.UUID
+		if err := results.QueryRow().Scan(&owner); err != nil {
+			return fmt.Errorf("failed to share chunk: %w", err)
+		}
+		chunk.VectorChunkID = uuid.Nil
+		if owner != chunk.ID {
+			chunk.VectorChunkID = owner
+		}
+	}
+	return nil
+}
+
+// SharedHashes returns the content hashes whose vector is owned by a chunk of
+// a document other than documentID. It takes no locks.
+func (r *DocumentRepo) SharedHashes(ctx context.Context, tenantID, documentID uuid.UUID, hashes []string) (map[string]bool, error) {
+	shared := make(map[string]bool)
+	if len(hashes) == 0 {
+		return shared, nil
+	}
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT h.content_hash
+		FROM chunk_hashes h
+		JOIN document_chunks c ON c.id = h.chunk_id
+		WHERE h.tenant_id = $1 AND h.content_hash = ANY($2) AND c.document_id <> $3
+	`, tenantID, hashes, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shared chunk hashes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk hash: %w", err)
+		}
+		shared[hash] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find shared chunk hashes: %w", err)
+	}
+	return shared, nil
+}
+
+// SharedVectors returns the vectors a document's chunks own or use that
+// chunks of other documents share too, and the vectors with the given chunk
+// IDs, each with the union of the tags and collections of the documents
+// sharing it
+func (r *DocumentRepo) SharedVectors(ctx context.Context, documentID uuid.UUID, chunkIDs []uuid.UUID) ([]*repository.SharedVector, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		WITH vectors AS (
+			SELECT COALESCE(vector_chunk_id, id) AS chunk_id
+			FROM document_chunks
+			WHERE document_id = $1
+			UNION
+			SELECT unnest($2::uuid[])
+		), sharers AS (
+			SELECT id AS chunk_id, document_id FROM document_chunks
+			WHERE id IN (SELECT chunk_id FROM vectors)
+			UNION
+			SELECT vector_chunk_id, document_id FROM document_chunks
+			WHERE vector_chunk_id IN (SELECT chunk_id FROM vectors)
+		)
+		SELECT s.chunk_id,
+		       COALESCE(array_agg(DISTINCT t.tag ORDER BY t.tag) FILTER (WHERE t.tag IS NOT NULL), '{}'),
+		       COALESCE(array_agg(DISTINCT cd.collection_id ORDER BY cd.collection_id) FILTER (WHERE cd.collection_id IS NOT NULL), '{}')
+		FROM sharers s
+		LEFT JOIN document_tags t ON t.document_id = s.document_id
+		LEFT JOIN collection_documents cd ON cd.document_id = s.document_id
+		GROUP BY s.chunk_id
+		HAVING COUNT(DISTINCT s.document_id) > 1 OR s.chunk_id = ANY($2)
+		ORDER BY s.chunk_id
+	`, documentID, chunkIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared vectors: %w", err)
+	}
+	defer rows.Close()
+
+	var vectors []*repository.SharedVector
+	for rows.Next() {
+		var v repository.SharedVector
+		if err := rows.Scan(&v.ChunkID, &v.Tags, &v.CollectionIDs); err != nil {
+			return nil, fmt.Errorf("failed to scan shared vector: %w", err)
+		}
+		vectors = append(vectors, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get shared vectors: %w", err)
+	}
+	return vectors, nil
+}
+
+// ReleaseChunks drops a document's chunk hash references and its chunks'
+// use of other documents' vectors, returning those vectors, and hands each
+// vector it owned to the oldest identical chunk of another document
+func (r *DocumentRepo) ReleaseChunks(ctx context.Context, documentID uuid.UUID) ([]*repository.DocumentChunk, []uuid.UUID, error) {
+	conn := r.db.conn(ctx)
+
+	_, err := conn.Exec(ctx, `
+		UPDATE chunk_hashes h
+		SET ref_count = h.ref_count - c.n
+		FROM (
+			SELECT d.tenant_id, ch.content_hash, COUNT(*) AS n
+			FROM document_chunks ch
+			JOIN documents d ON d.id = ch.document_id
+			WHERE ch.document_id = $1 AND ch.content_hash IS NOT NULL
+			GROUP BY d.tenant_id, ch.content_hash
+		) c
+		WHERE h.tenant_id = c.tenant_id AND h.content_hash = c.content_hash
+	`, documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to release chunk hashes: %w", err)
+	}
+	_, err = conn.Exec(ctx, `
+		DELETE FROM chunk_hashes h
+		USING documents d
+		WHERE d.id = $1 AND h.tenant_id = d.tenant_id AND h.ref_count <= 0
+	`, documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete unused chunk hashes: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `
+		UPDATE document_chunks c
+		SET vector_chunk_id = NULL
+		FROM (
+			SELECT id, vector_chunk_id FROM document_chunks
+			WHERE document_id = $1 AND vector_chunk_id IS NOT NULL
+		) u
+		WHERE c.id = u.id
+		RETURNING u.vector_chunk_id
+	`, documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to release shared vectors: %w", err)
+	}
+	used, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to release shared vectors: %w", err)
+	}
+
+	// The oldest chunk of another document pointing at each of this
+	// document's vectors becomes its owner
+	rows, err = conn.Query(ctx, `
+		SELECT DISTINCT ON (c.vector_chunk_id) c.vector_chunk_id, c.id
+		FROM document_chunks c
+		WHERE c.document_id <> $1
+		  AND c.vector_chunk_id IN (SELECT id FROM document_chunks WHERE document_id = $1)
+		ORDER BY c.vector_chunk_id, c.created_at, c.id
+	`, documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find shared chunks: %w", err)
+	}
+	owners := make(map[uuid.UUID]uuid.UUID)
+	for rows.Next() {
+		var previous, owner uuid.UUID
+		if err := rows.Scan(&previous, &owner); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("failed to scan shared chunk: %w", err)
+		}
+		owners[previous] = owner
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to find shared chunks: %w", err)
+	}
+	if len(owners) == 0 {
+		return nil, used, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(owners))
+	for previous, owner := range owners {
+		_, err := conn.Exec(ctx, `
+			UPDATE document_chunks
+			SET vector_chunk_id = CASE WHEN id = $2 THEN NULL ELSE $2 END
+			WHERE vector_chunk_id = $1 AND document_id <> $3
+		`, previous, owner, documentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reassign shared chunks: %w", err)
+		}
+		if _, err := conn.Exec(ctx, `UPDATE chunk_hashes SET chunk_id = $2 WHERE chunk_id = $1`, previous, owner); err != nil {
+			return nil, nil, fmt.Errorf("failed to reassign chunk hash: %w", err)
+		}
+		ids = append(ids, owner)
+	}
+
+	rows, err = conn.Query(ctx, `
+		SELECT `+chunkColumns+`
+		FROM document_chunks
+		WHERE id = ANY($1)
+		ORDER BY document_id, chunk_index
+	`, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get shared chunks: %w", err)
+	}
+	defer rows.Close()
+
+	inherited, err := scanChunks(rows)
+	return inherited, used, err
+}
+
+// SharedChunkDocuments returns, for each chunk ID, the documents of the
+// chunks whose vector it owns
+func (r *DocumentRepo) SharedChunkDocuments(ctx context.Context, chunkIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT DISTINCT vector_chunk_id, document_id
+		FROM document_chunks
+		WHERE vector_chunk_id = ANY($1)
+		ORDER BY vector_chunk_id, document_id
+	`, chunkIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared chunk documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents := make(map[uuid.UUID][]uuid.UUID)
+	for rows.Next() {
+		var chunkID, documentID uuid.UUID
+		if err := rows.Scan(&chunkID, &documentID); err != nil {
+			return nil, fmt.Errorf("failed to scan shared chunk document: %w", err)
+		}
+		documents[chunkID] = append(documents[chunkID], documentID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get shared chunk documents: %w", err)
+	}
+	return documents, nil
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// CollectionRepo implements repository.CollectionRepository
+type CollectionRepo struct {
+	db *DB
+}
+
+// NewCollectionRepo creates a new collection repository
+func NewCollectionRepo(db *DB) *CollectionRepo {
+	return &CollectionRepo{db: db}
+}
+
+const collectionColumns = `id, tenant_id, name, description,
+		(SELECT COUNT(*) FROM collection_documents cd WHERE cd.collection_id = collections.id),
+		created_at, updated_at`
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// Create creates a new collection
+func (r *CollectionRepo) Create(ctx context.Context, collection *repository.Collection) error {
+	query := `
+		INSERT INTO collections (id, tenant_id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.conn(ctx).Exec(ctx, query,
+		collection.ID, collection.TenantID, collection.Name, collection.Description,
+		collection.CreatedAt, collection.UpdatedAt)
+	if err != nil {
+		return collectionWriteError("failed to create collection", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a collection by ID
+func (r *CollectionRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Collection, error) {
+	query := `SELECT ` + collectionColumns + ` FROM collections WHERE id = $1`
+
+	collection, err := scanCollection(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get collection: %w", err)
+	}
+	return collection, nil
+}
+
+// List retrieves collections for a tenant by name, with pagination
+func (r *CollectionRepo) List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*repository.Collection, int, error) {
+	var total int
+	err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM collections WHERE tenant_id = $1`, tenantID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count collections: %w", err)
+	}
+
+	query := `
+		SELECT ` + collectionColumns + `
+		FROM collections
+		WHERE tenant_id = $1
+		ORDER BY name
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.conn(ctx).Query(ctx, query, tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list collections: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []*repository.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate collections: %w", err)
+	}
+
+	return collections, total, nil
+}
+
+// Update updates a collection's name and description
+func (r *CollectionRepo) Update(ctx context.Context, collection *repository.Collection) error {
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE collections SET name = $2, description = $3 WHERE id = $1`,
+		collection.ID, collection.Name, collection.Description)
+	if err != nil {
+		return collectionWriteError("failed to update collection", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete deletes a collection and its memberships
+func (r *CollectionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM collections WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// AddDocuments adds documents to a collection; members are ignored
+func (r *CollectionRepo) AddDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO collection_documents (collection_id, document_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, collectionID, documentIDs)
+	if err != nil {
+		return fmt.Errorf("failed to add documents to collection: %w", err)
+	}
+	return nil
+}
+
+// RemoveDocuments removes documents from a collection; non-members are ignored
+func (r *CollectionRepo) RemoveDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error {
+	_, err := r.db.conn(ctx).Exec(ctx,
+		`DELETE FROM collection_documents WHERE collection_id = $1 AND document_id = ANY($2)`,
+		collectionID, documentIDs)
+	if err != nil {
+		return fmt.Errorf("failed to remove documents from collection: %w", err)
+	}
+	return nil
+}
+
+// DocumentIDs returns the IDs of a collection's documents
+func (r *CollectionRepo) DocumentIDs(ctx context.Context, collectionID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.conn(ctx).Query(ctx,
+		`SELECT document_id FROM collection_documents WHERE collection_id = $1`, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection documents: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collection documents: %w", err)
+	}
+	return ids, nil
+}
+
+// scanCollection scans a single collection row
+func scanCollection(row pgx.Row) (*repository.Collection, error) {
+	var c repository.Collection
+	err := row.Scan(&c.ID, &c.TenantID, &c.Name, &c.Description, &c.DocumentCount, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// collectionWriteError maps a duplicate name to repository.ErrAlreadyExists
+func collectionWriteError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repository.ErrAlreadyExists
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// Ensure CollectionRepo implements the interface
+var _ repository.CollectionRepository = (*CollectionRepo)(nil)
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// SaveContent stores a document's original content, replacing any stored before
+func (r *DocumentRepo) SaveContent(ctx context.Context, content *repository.DocumentContent) error {
+	compressed, err := compress(content.Data)
+	if err != nil {
+		return fmt.Errorf("failed to compress document content: %w", err)
+	}
+
+	_, err = r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO document_contents (document_id, content_type, filename, size, data)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (document_id) DO UPDATE
+		SET content_type = EXCLUDED.content_type, filename = EXCLUDED.filename,
+		    size = EXCLUDED.size, data = EXCLUDED.data, created_at = NOW()
+	`, content.DocumentID, content.ContentType, content.Filename, len(content.Data), compressed)
+	if err != nil {
+		return fmt.Errorf("failed to save document content: %w", err)
+	}
+	return nil
+}
+
+// GetContent retrieves a document's original content
+func (r *DocumentRepo) GetContent(ctx context.Context, documentID uuid.UUID) (*repository.DocumentContent, error) {
+	content := repository.DocumentContent{DocumentID: documentID}
+	var compressed []byte
+	var size int64
+
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		SELECT content_type, filename, size, data, created_at
+		FROM document_contents
+		WHERE document_id = $1
+	`, documentID).Scan(&content.ContentType, &content.Filename, &size, &compressed, &content.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get document content: %w", err)
+	}
+
+	content.Data, err = decompress(compressed, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress document content: %w", err)
+	}
+	return &content, nil
+}
+
+// compress gzips data
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress gunzips data that was size bytes before compression
+func decompress(data []byte, size int64) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	out := bytes.NewBuffer(make([]byte, 0, size))
+	if _, err := io.Copy(out, io.LimitReader(zr, size)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompressRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("original document content\n"), 100)
+	compressed, err := compress(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compressed) >= len(data) {
+		t.Errorf("compressed %d bytes to %d", len(data), len(compressed))
+	}
+
+	got, err := decompress(compressed, int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("decompressed content differs from the original")
+	}
+}
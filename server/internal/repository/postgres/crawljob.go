@@ -11,6 +11,11 @@ import (
 	"github.com/knoguchi/rag/internal/repository"
 )
 
+// crawlJobColumns are the columns scanned into a repository.CrawlJob, with
+// the size of the job's frontier counted from its pending pages
+const crawlJobColumns = `id, tenant_id, type, status, root_url, config, pages_crawled, pages_total, pages_failed, error_message, created_at, started_at, completed_at,
+	(SELECT COUNT(*) FROM crawled_pages p WHERE p.job_id = crawl_jobs.id AND p.status = 'PENDING'), project_id`
+
 // CrawlJobRepo implements repository.CrawlJobRepository
 type CrawlJobRepo struct {
 	db *DB
@@ -29,13 +34,13 @@ func (r *CrawlJobRepo) Create(ctx context.Context, job *repository.CrawlJob) err
 	}
 
 	query := `
-		INSERT INTO crawl_jobs (id, tenant_id, type, status, root_url, config, pages_crawled, pages_total, pages_failed, error_message, created_at, started_at, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO crawl_jobs (id, tenant_id, type, status, root_url, config, pages_crawled, pages_total, pages_failed, error_message, created_at, started_at, completed_at, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
-	_, err = r.db.Pool.Exec(ctx, query,
+	_, err = r.db.conn(ctx).Exec(ctx, query,
 		job.ID, job.TenantID, job.Type, job.Status, job.RootURL, configJSON,
 		job.PagesCrawled, job.PagesTotal, job.PagesFailed, job.ErrorMessage,
-		job.CreatedAt, job.StartedAt, job.CompletedAt)
+		job.CreatedAt, job.StartedAt, job.CompletedAt, job.ProjectID)
 	if err != nil {
 		return fmt.Errorf("failed to create crawl job: %w", err)
 	}
@@ -45,21 +50,21 @@ func (r *CrawlJobRepo) Create(ctx context.Context, job *repository.CrawlJob) err
 // GetByID retrieves a crawl job by ID
 func (r *CrawlJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.CrawlJob, error) {
 	query := `
-		SELECT id, tenant_id, type, status, root_url, config, pages_crawled, pages_total, pages_failed, error_message, created_at, started_at, completed_at
+		SELECT ` + crawlJobColumns + `
 		FROM crawl_jobs
 		WHERE id = $1
 	`
 	var job repository.CrawlJob
 	var configJSON []byte
 
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, id).Scan(
 		&job.ID, &job.TenantID, &job.Type, &job.Status, &job.RootURL, &configJSON,
 		&job.PagesCrawled, &job.PagesTotal, &job.PagesFailed, &job.ErrorMessage,
-		&job.CreatedAt, &job.StartedAt, &job.CompletedAt,
+		&job.CreatedAt, &job.StartedAt, &job.CompletedAt, &job.FrontierSize, &job.ProjectID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+			return nil, repository.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get crawl job: %w", err)
 	}
@@ -71,35 +76,40 @@ func (r *CrawlJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.C
 	return &job, nil
 }
 
-// List retrieves crawl jobs for a tenant with pagination
-func (r *CrawlJobRepo) List(ctx context.Context, tenantID uuid.UUID, status string, limit, offset int) ([]*repository.CrawlJob, int, error) {
+// List retrieves crawl jobs for a tenant, or one of its projects, with pagination
+func (r *CrawlJobRepo) List(ctx context.Context, tenantID, projectID uuid.UUID, status string, limit, offset int) ([]*repository.CrawlJob, int, error) {
 	// Build query with optional status filter
 	countQuery := `SELECT COUNT(*) FROM crawl_jobs WHERE tenant_id = $1`
 	listQuery := `
-		SELECT id, tenant_id, type, status, root_url, config, pages_crawled, pages_total, pages_failed, error_message, created_at, started_at, completed_at
+		SELECT ` + crawlJobColumns + `
 		FROM crawl_jobs
 		WHERE tenant_id = $1
 	`
 	args := []any{tenantID}
 
 	if status != "" {
-		countQuery += ` AND status = $2`
-		listQuery += ` AND status = $2`
 		args = append(args, status)
+		countQuery += fmt.Sprintf(` AND status = $%d`, len(args))
+		listQuery += fmt.Sprintf(` AND status = $%d`, len(args))
+	}
+	if projectID != uuid.Nil {
+		args = append(args, projectID)
+		countQuery += fmt.Sprintf(` AND project_id = $%d`, len(args))
+		listQuery += fmt.Sprintf(` AND project_id = $%d`, len(args))
 	}
 
 	listQuery += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
 
 	// Get total count
 	var total int
-	err := r.db.Pool.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := r.db.conn(ctx).QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count crawl jobs: %w", err)
 	}
 
 	// Get jobs
 	args = append(args, limit, offset)
-	rows, err := r.db.Pool.Query(ctx, listQuery, args...)
+	rows, err := r.db.conn(ctx).Query(ctx, listQuery, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list crawl jobs: %w", err)
 	}
@@ -111,7 +121,7 @@ func (r *CrawlJobRepo) List(ctx context.Context, tenantID uuid.UUID, status stri
 		var configJSON []byte
 		if err := rows.Scan(&job.ID, &job.TenantID, &job.Type, &job.Status, &job.RootURL, &configJSON,
 			&job.PagesCrawled, &job.PagesTotal, &job.PagesFailed, &job.ErrorMessage,
-			&job.CreatedAt, &job.StartedAt, &job.CompletedAt); err != nil {
+			&job.CreatedAt, &job.StartedAt, &job.CompletedAt, &job.FrontierSize, &job.ProjectID); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan crawl job: %w", err)
 		}
 		if err := json.Unmarshal(configJSON, &job.Config); err != nil {
@@ -136,7 +146,7 @@ func (r *CrawlJobRepo) Update(ctx context.Context, job *repository.CrawlJob) err
 		    pages_failed = $6, error_message = $7, started_at = $8, completed_at = $9
 		WHERE id = $1
 	`
-	result, err := r.db.Pool.Exec(ctx, query,
+	result, err := r.db.conn(ctx).Exec(ctx, query,
 		job.ID, job.Status, configJSON, job.PagesCrawled, job.PagesTotal,
 		job.PagesFailed, job.ErrorMessage, job.StartedAt, job.CompletedAt)
 	if err != nil {
@@ -151,12 +161,12 @@ func (r *CrawlJobRepo) Update(ctx context.Context, job *repository.CrawlJob) err
 // CreatePage creates a new crawled page
 func (r *CrawlJobRepo) CreatePage(ctx context.Context, page *repository.CrawledPage) error {
 	query := `
-		INSERT INTO crawled_pages (id, job_id, url, title, status, error_message, document_id, content_length, depth, crawled_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO crawled_pages (id, job_id, url, title, status, error_message, document_id, content_length, depth, parent_url, anchor_text, crawled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
-	_, err := r.db.Pool.Exec(ctx, query,
+	_, err := r.db.conn(ctx).Exec(ctx, query,
 		page.ID, page.JobID, page.URL, page.Title, page.Status, page.ErrorMessage,
-		page.DocumentID, page.ContentLength, page.Depth, page.CrawledAt)
+		page.DocumentID, page.ContentLength, page.Depth, page.ParentURL, page.AnchorText, page.CrawledAt)
 	if err != nil {
 		return fmt.Errorf("failed to create crawled page: %w", err)
 	}
@@ -171,7 +181,7 @@ func (r *CrawlJobRepo) UpdatePage(ctx context.Context, page *repository.CrawledP
 		    content_length = $6, crawled_at = $7
 		WHERE id = $1
 	`
-	result, err := r.db.Pool.Exec(ctx, query,
+	result, err := r.db.conn(ctx).Exec(ctx, query,
 		page.ID, page.Title, page.Status, page.ErrorMessage, page.DocumentID,
 		page.ContentLength, page.CrawledAt)
 	if err != nil {
@@ -188,7 +198,7 @@ func (r *CrawlJobRepo) GetPages(ctx context.Context, jobID uuid.UUID, status str
 	// Build query with optional status filter
 	countQuery := `SELECT COUNT(*) FROM crawled_pages WHERE job_id = $1`
 	listQuery := `
-		SELECT id, job_id, url, title, status, error_message, document_id, content_length, depth, crawled_at
+		SELECT id, job_id, url, title, status, error_message, document_id, content_length, depth, parent_url, anchor_text, crawled_at
 		FROM crawled_pages
 		WHERE job_id = $1
 	`
@@ -204,14 +214,14 @@ func (r *CrawlJobRepo) GetPages(ctx context.Context, jobID uuid.UUID, status str
 
 	// Get total count
 	var total int
-	err := r.db.Pool.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := r.db.conn(ctx).QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count crawled pages: %w", err)
 	}
 
 	// Get pages
 	args = append(args, limit, offset)
-	rows, err := r.db.Pool.Query(ctx, listQuery, args...)
+	rows, err := r.db.conn(ctx).Query(ctx, listQuery, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list crawled pages: %w", err)
 	}
@@ -221,7 +231,7 @@ func (r *CrawlJobRepo) GetPages(ctx context.Context, jobID uuid.UUID, status str
 	for rows.Next() {
 		var page repository.CrawledPage
 		if err := rows.Scan(&page.ID, &page.JobID, &page.URL, &page.Title, &page.Status,
-			&page.ErrorMessage, &page.DocumentID, &page.ContentLength, &page.Depth, &page.CrawledAt); err != nil {
+			&page.ErrorMessage, &page.DocumentID, &page.ContentLength, &page.Depth, &page.ParentURL, &page.AnchorText, &page.CrawledAt); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan crawled page: %w", err)
 		}
 		pages = append(pages, &page)
@@ -230,5 +240,92 @@ func (r *CrawlJobRepo) GetPages(ctx context.Context, jobID uuid.UUID, status str
 	return pages, total, nil
 }
 
+// ListUnfinished retrieves the crawl jobs of all tenants that are pending or
+// running, oldest first
+func (r *CrawlJobRepo) ListUnfinished(ctx context.Context) ([]*repository.CrawlJob, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT `+crawlJobColumns+`
+		FROM crawl_jobs
+		WHERE status IN ('PENDING', 'RUNNING')
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unfinished crawl jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*repository.CrawlJob
+	for rows.Next() {
+		var job repository.CrawlJob
+		var configJSON []byte
+		if err := rows.Scan(&job.ID, &job.TenantID, &job.Type, &job.Status, &job.RootURL, &configJSON,
+			&job.PagesCrawled, &job.PagesTotal, &job.PagesFailed, &job.ErrorMessage,
+			&job.CreatedAt, &job.StartedAt, &job.CompletedAt, &job.FrontierSize, &job.ProjectID); err != nil {
+			return nil, fmt.Errorf("failed to scan crawl job: %w", err)
+		}
+		if err := json.Unmarshal(configJSON, &job.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, rows.Err()
+}
+
+// EnqueuePages adds pages to their job's frontier, skipping URLs the job has
+// already seen, and returns how many were added. A page keeps the parent and
+// anchor text it was first discovered through.
+func (r *CrawlJobRepo) EnqueuePages(ctx context.Context, pages []*repository.CrawledPage) (int, error) {
+	batch := &pgx.Batch{}
+	for _, page := range pages {
+		batch.Queue(`
+			INSERT INTO crawled_pages (id, job_id, url, title, status, error_message, depth, parent_url, anchor_text)
+			VALUES ($1, $2, $3, '', 'PENDING', '', $4, $5, $6)
+			ON CONFLICT (job_id, url) DO NOTHING
+		`, page.ID, page.JobID, page.URL, page.Depth, page.ParentURL, page.AnchorText)
+	}
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	results := r.db.conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+
+	added := 0
+	for range pages {
+		tag, err := results.Exec()
+		if err != nil {
+			return added, fmt.Errorf("failed to enqueue page: %w", err)
+		}
+		added += int(tag.RowsAffected())
+	}
+	return added, nil
+}
+
+// NextPages retrieves a job's pending pages, shallowest and longest queued first
+func (r *CrawlJobRepo) NextPages(ctx context.Context, jobID uuid.UUID, limit int) ([]*repository.CrawledPage, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT id, job_id, url, title, status, error_message, document_id, content_length, depth, parent_url, anchor_text, crawled_at
+		FROM crawled_pages
+		WHERE job_id = $1 AND status = 'PENDING'
+		ORDER BY depth, queued_at, id
+		LIMIT $2
+	`, jobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []*repository.CrawledPage
+	for rows.Next() {
+		var page repository.CrawledPage
+		if err := rows.Scan(&page.ID, &page.JobID, &page.URL, &page.Title, &page.Status,
+			&page.ErrorMessage, &page.DocumentID, &page.ContentLength, &page.Depth, &page.ParentURL, &page.AnchorText, &page.CrawledAt); err != nil {
+			return nil, fmt.Errorf("failed to scan crawled page: %w", err)
+		}
+		pages = append(pages, &page)
+	}
+	return pages, rows.Err()
+}
+
 // Ensure CrawlJobRepo implements the interface
 var _ repository.CrawlJobRepository = (*CrawlJobRepo)(nil)
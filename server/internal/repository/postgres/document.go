@@ -5,12 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/knoguchi/rag/internal/repository"
 )
 
+// documentColumns are the columns scanned into a repository.Document, with
+// the document's tags and collections aggregated from their tables
+const documentColumns = `id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, chunker_config, created_at, updated_at,
+	ARRAY(SELECT tag FROM document_tags t WHERE t.document_id = documents.id ORDER BY tag),
+	ARRAY(SELECT collection_id FROM collection_documents cd WHERE cd.document_id = documents.id ORDER BY collection_id),
+	COALESCE(simhash, 0), near_duplicate_of, COALESCE(source_key, ''), COALESCE(etag, ''), COALESCE(last_modified, ''), project_id`
+
 // DocumentRepo implements repository.DocumentRepository
 type DocumentRepo struct {
 	db *DB
@@ -27,15 +35,20 @@ func (r *DocumentRepo) Create(ctx context.Context, doc *repository.Document) err
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	chunkerJSON, err := marshalChunker(doc.Chunker)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		INSERT INTO documents (id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO documents (id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, chunker_config, created_at, updated_at,
+		                       source_key, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14)
 	`
-	_, err = r.db.Pool.Exec(ctx, query,
+	_, err = r.db.conn(ctx).Exec(ctx, query,
 		doc.ID, doc.TenantID, doc.Source, doc.Title, doc.ContentHash,
-		doc.ChunkCount, doc.Status, doc.ErrorMessage, metadataJSON,
-		doc.CreatedAt, doc.UpdatedAt)
+		doc.ChunkCount, doc.Status, doc.ErrorMessage, metadataJSON, chunkerJSON,
+		doc.CreatedAt, doc.UpdatedAt, doc.SourceKey, doc.ProjectID)
 	if err != nil {
 		return fmt.Errorf("failed to create document: %w", err)
 	}
@@ -45,7 +58,7 @@ func (r *DocumentRepo) Create(ctx context.Context, doc *repository.Document) err
 // GetByID retrieves a document by ID
 func (r *DocumentRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Document, error) {
 	query := `
-		SELECT id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE id = $1
 	`
@@ -55,21 +68,36 @@ func (r *DocumentRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.D
 // GetByHash retrieves a document by content hash for a tenant
 func (r *DocumentRepo) GetByHash(ctx context.Context, tenantID uuid.UUID, hash string) (*repository.Document, error) {
 	query := `
-		SELECT id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE tenant_id = $1 AND content_hash = $2
 	`
 	return r.scanDocument(ctx, query, tenantID, hash)
 }
 
+// GetBySourceKey retrieves the most recent document for a source key among a
+// project's documents, or the tenant-wide ones for a nil projectID
+func (r *DocumentRepo) GetBySourceKey(ctx context.Context, tenantID uuid.UUID, projectID *uuid.UUID, key string) (*repository.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE tenant_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND source_key = $3
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.scanDocument(ctx, query, tenantID, projectID, key)
+}
+
 func (r *DocumentRepo) scanDocument(ctx context.Context, query string, args ...any) (*repository.Document, error) {
 	var doc repository.Document
-	var metadataJSON []byte
+	var metadataJSON, chunkerJSON []byte
+	var simHash int64
 
-	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, args...).Scan(
 		&doc.ID, &doc.TenantID, &doc.Source, &doc.Title, &doc.ContentHash,
-		&doc.ChunkCount, &doc.Status, &doc.ErrorMessage, &metadataJSON,
-		&doc.CreatedAt, &doc.UpdatedAt,
+		&doc.ChunkCount, &doc.Status, &doc.ErrorMessage, &metadataJSON, &chunkerJSON,
+		&doc.CreatedAt, &doc.UpdatedAt, &doc.Tags, &doc.CollectionIDs,
+		&simHash, &doc.NearDuplicateOf, &doc.SourceKey, &doc.ETag, &doc.LastModified, &doc.ProjectID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -77,44 +105,66 @@ func (r *DocumentRepo) scanDocument(ctx context.Context, query string, args ...a
 		}
 		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
+	doc.SimHash = uint64(simHash)
 
+	if err := unmarshalDocumentJSON(&doc, metadataJSON, chunkerJSON); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// unmarshalDocumentJSON decodes a document's metadata and chunker columns
+func unmarshalDocumentJSON(doc *repository.Document, metadataJSON, chunkerJSON []byte) error {
 	doc.Metadata = make(map[string]string)
 	if err := json.Unmarshal(metadataJSON, &doc.Metadata); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		return fmt.Errorf("failed to unmarshal metadata: %w", err)
 	}
-
-	return &doc, nil
+	if chunkerJSON != nil {
+		doc.Chunker = &repository.ChunkerConfig{}
+		if err := json.Unmarshal(chunkerJSON, doc.Chunker); err != nil {
+			return fmt.Errorf("failed to unmarshal chunker config: %w", err)
+		}
+	}
+	return nil
 }
 
-// List retrieves documents for a tenant with pagination
-func (r *DocumentRepo) List(ctx context.Context, tenantID uuid.UUID, status string, limit, offset int) ([]*repository.Document, int, error) {
-	// Build query with optional status filter
-	countQuery := `SELECT COUNT(*) FROM documents WHERE tenant_id = $1`
-	listQuery := `
-		SELECT id, tenant_id, source, title, content_hash, chunk_count, status, error_message, metadata, created_at, updated_at
-		FROM documents
-		WHERE tenant_id = $1
-	`
-	args := []any{tenantID}
+// marshalChunker encodes a chunker override, with nil stored as NULL
+func marshalChunker(config *repository.ChunkerConfig) ([]byte, error) {
+	if config == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chunker config: %w", err)
+	}
+	return data, nil
+}
 
-	if status != "" {
-		countQuery += ` AND status = $2`
-		listQuery += ` AND status = $2`
-		args = append(args, status)
+// List retrieves documents for a tenant matching a filter, with pagination
+func (r *DocumentRepo) List(ctx context.Context, tenantID uuid.UUID, filter repository.DocumentFilter, limit, offset int) ([]*repository.Document, int, error) {
+	where, args, err := documentFilterSQL(tenantID, filter)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	listQuery += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
+	countQuery := `SELECT COUNT(*) FROM documents WHERE ` + where
+	listQuery := `
+		SELECT ` + documentColumns + `
+		FROM documents
+		WHERE ` + where + `
+		ORDER BY ` + documentOrderSQL(filter) +
+		` LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
 
 	// Get total count
 	var total int
-	err := r.db.Pool.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err = r.db.conn(ctx).QueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count documents: %w", err)
 	}
 
 	// Get documents
 	args = append(args, limit, offset)
-	rows, err := r.db.Pool.Query(ctx, listQuery, args...)
+	rows, err := r.db.conn(ctx).Query(ctx, listQuery, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list documents: %w", err)
 	}
@@ -123,15 +173,17 @@ func (r *DocumentRepo) List(ctx context.Context, tenantID uuid.UUID, status stri
 	var docs []*repository.Document
 	for rows.Next() {
 		var doc repository.Document
-		var metadataJSON []byte
+		var metadataJSON, chunkerJSON []byte
+		var simHash int64
 		if err := rows.Scan(&doc.ID, &doc.TenantID, &doc.Source, &doc.Title, &doc.ContentHash,
-			&doc.ChunkCount, &doc.Status, &doc.ErrorMessage, &metadataJSON,
-			&doc.CreatedAt, &doc.UpdatedAt); err != nil {
+			&doc.ChunkCount, &doc.Status, &doc.ErrorMessage, &metadataJSON, &chunkerJSON,
+			&doc.CreatedAt, &doc.UpdatedAt, &doc.Tags, &doc.CollectionIDs,
+			&simHash, &doc.NearDuplicateOf, &doc.SourceKey, &doc.ETag, &doc.LastModified, &doc.ProjectID); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan document: %w", err)
 		}
-		doc.Metadata = make(map[string]string)
-		if err := json.Unmarshal(metadataJSON, &doc.Metadata); err != nil {
-			return nil, 0, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		doc.SimHash = uint64(simHash)
+		if err := unmarshalDocumentJSON(&doc, metadataJSON, chunkerJSON); err != nil {
+			return nil, 0, err
 		}
 		docs = append(docs, &doc)
 	}
@@ -139,34 +191,159 @@ func (r *DocumentRepo) List(ctx context.Context, tenantID uuid.UUID, status stri
 	return docs, total, nil
 }
 
+// documentFilterSQL builds the WHERE clause for a document filter
+func documentFilterSQL(tenantID uuid.UUID, filter repository.DocumentFilter) (string, []any, error) {
+	conditions := []string{"tenant_id = $1"}
+	args := []any{tenantID}
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.SourcePrefix != "" {
+		add(`source LIKE $%d ESCAPE '\'`, escapeLike(filter.SourcePrefix)+"%")
+	}
+	if filter.SourceGlob != "" {
+		add(`source LIKE $%d ESCAPE '\'`, globToLike(filter.SourceGlob))
+	}
+	if filter.TitleContains != "" {
+		add(`title ILIKE $%d ESCAPE '\'`, "%"+escapeLike(filter.TitleContains)+"%")
+	}
+	if !filter.CreatedAfter.IsZero() {
+		add("created_at >= $%d", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		add("created_at < $%d", filter.CreatedBefore)
+	}
+	if len(filter.Tags) > 0 {
+		add("id IN (SELECT document_id FROM document_tags WHERE tag = ANY($%d))", filter.Tags)
+	}
+	if filter.CollectionID != uuid.Nil {
+		add("id IN (SELECT document_id FROM collection_documents WHERE collection_id = $%d)", filter.CollectionID)
+	}
+	if filter.ProjectID != uuid.Nil {
+		add("project_id = $%d", filter.ProjectID)
+	}
+	if len(filter.Metadata) > 0 {
+		metadataJSON, err := json.Marshal(filter.Metadata)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal metadata filter: %w", err)
+		}
+		add("metadata @> $%d", metadataJSON)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// documentOrderSQL builds the ORDER BY clause; id breaks ties so pages don't
+// overlap
+func documentOrderSQL(filter repository.DocumentFilter) string {
+	column := "created_at"
+	switch filter.SortBy {
+	case repository.DocumentSortUpdatedAt:
+		column = "updated_at"
+	case repository.DocumentSortTitle:
+		column = "title"
+	case repository.DocumentSortSource:
+		column = "source"
+	}
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+	return column + " " + direction + " NULLS LAST, id " + direction
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// globToLike converts a glob with * and ? wildcards to a LIKE pattern
+func globToLike(glob string) string {
+	return strings.NewReplacer("*", "%", "?", "_").Replace(escapeLike(glob))
+}
+
 // Update updates a document
 func (r *DocumentRepo) Update(ctx context.Context, doc *repository.Document) error {
+	updated, err := r.update(ctx, doc, "")
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return fmt.Errorf("document not found")
+	}
+	return nil
+}
+
+// UpdateIfStatus updates a document only if its stored status is still
+// expected, reporting whether it did
+func (r *DocumentRepo) UpdateIfStatus(ctx context.Context, doc *repository.Document, expected string) (bool, error) {
+	return r.update(ctx, doc, expected)
+}
+
+// update updates a document, only if its stored status is expectedStatus
+// when that is set
+func (r *DocumentRepo) update(ctx context.Context, doc *repository.Document, expectedStatus string) (bool, error) {
 	metadataJSON, err := json.Marshal(doc.Metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return false, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	chunkerJSON, err := marshalChunker(doc.Chunker)
+	if err != nil {
+		return false, err
 	}
 
 	query := `
 		UPDATE documents
 		SET source = $2, title = $3, content_hash = $4, chunk_count = $5,
-		    status = $6, error_message = $7, metadata = $8, updated_at = NOW()
-		WHERE id = $1
+		    status = $6, error_message = $7, metadata = $8, chunker_config = $9,
+		    simhash = NULLIF($10::bigint, 0), near_duplicate_of = $11, source_key = NULLIF($12, ''),
+		    etag = NULLIF($13, ''), last_modified = NULLIF($14, ''), updated_at = NOW()
+		WHERE id = $1 AND ($15 = '' OR status = $15)
 	`
-	result, err := r.db.Pool.Exec(ctx, query,
+	result, err := r.db.conn(ctx).Exec(ctx, query,
 		doc.ID, doc.Source, doc.Title, doc.ContentHash,
-		doc.ChunkCount, doc.Status, doc.ErrorMessage, metadataJSON)
+		doc.ChunkCount, doc.Status, doc.ErrorMessage, metadataJSON, chunkerJSON,
+		int64(doc.SimHash), doc.NearDuplicateOf, doc.SourceKey, doc.ETag, doc.LastModified, expectedStatus)
 	if err != nil {
-		return fmt.Errorf("failed to update document: %w", err)
+		return false, fmt.Errorf("failed to update document: %w", err)
 	}
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("document not found")
+	return result.RowsAffected() > 0, nil
+}
+
+// FindNearDuplicate finds the ready document of the project, or the
+// tenant-wide one for a nil projectID, whose SimHash is closest to simHash,
+// within maxDistance bits, preferring older documents
+func (r *DocumentRepo) FindNearDuplicate(ctx context.Context, tenantID uuid.UUID, projectID *uuid.UUID, simHash uint64, maxDistance int, excludeID uuid.UUID) (*repository.NearDuplicate, error) {
+	var match repository.NearDuplicate
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		SELECT id, distance
+		FROM (
+			SELECT id, created_at, bit_count((simhash # $2)::bit(64)) AS distance
+			FROM documents
+			WHERE tenant_id = $1 AND project_id IS NOT DISTINCT FROM $5
+			  AND simhash IS NOT NULL AND status = 'READY' AND id <> $3
+		) d
+		WHERE distance <= $4
+		ORDER BY distance, created_at
+		LIMIT 1
+	`, tenantID, int64(simHash), excludeID, maxDistance, projectID).Scan(&match.DocumentID, &match.Distance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find near-duplicate document: %w", err)
 	}
-	return nil
+	return &match, nil
 }
 
 // Delete deletes a document
 func (r *DocumentRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.Pool.Exec(ctx, `DELETE FROM documents WHERE id = $1`, id)
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM documents WHERE id = $1`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete document: %w", err)
 	}
@@ -188,13 +365,23 @@ func (r *DocumentRepo) CreateChunks(ctx context.Context, chunks []*repository.Do
 		if err != nil {
 			return fmt.Errorf("failed to marshal chunk metadata: %w", err)
 		}
+		var contentHash *string
+		if chunk.ContentHash != "" {
+			contentHash = &chunk.ContentHash
+		}
+		var vectorChunkID *uuid.UUID
+		if chunk.VectorChunkID != uuid.Nil {
+			vectorChunkID = &chunk.VectorChunkID
+		}
 		batch.Queue(`
-			INSERT INTO document_chunks (id, document_id, chunk_index, content, metadata, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`, chunk.ID, chunk.DocumentID, chunk.ChunkIndex, chunk.Content, metadataJSON, chunk.CreatedAt)
+			INSERT INTO document_chunks (id, document_id, chunk_index, content, metadata, parent_start, parent_end, created_at,
+			                             content_hash, vector_chunk_id, search_config)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::regconfig)
+		`, chunk.ID, chunk.DocumentID, chunk.ChunkIndex, chunk.Content, metadataJSON,
+			chunk.ParentStart, chunk.ParentEnd, chunk.CreatedAt, contentHash, vectorChunkID, searchConfigOrSimple(chunk.SearchConfig))
 	}
 
-	results := r.db.Pool.SendBatch(ctx, batch)
+	results := r.db.conn(ctx).SendBatch(ctx, batch)
 	defer results.Close()
 
 	for range chunks {
@@ -206,47 +393,242 @@ func (r *DocumentRepo) CreateChunks(ctx context.Context, chunks []*repository.Do
 	return nil
 }
 
+const chunkColumns = `id, document_id, chunk_index, content, metadata,
+		       COALESCE(parent_start, chunk_index), COALESCE(parent_end, chunk_index), created_at,
+		       COALESCE(content_hash, ''), vector_chunk_id`
+
 // GetChunks retrieves chunks for a document
 func (r *DocumentRepo) GetChunks(ctx context.Context, documentID uuid.UUID, limit, offset int) ([]*repository.DocumentChunk, error) {
 	query := `
-		SELECT id, document_id, chunk_index, content, metadata, created_at
+		SELECT ` + chunkColumns + `
 		FROM document_chunks
 		WHERE document_id = $1
 		ORDER BY chunk_index
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.Pool.Query(ctx, query, documentID, limit, offset)
+	rows, err := r.db.conn(ctx).Query(ctx, query, documentID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chunks: %w", err)
 	}
 	defer rows.Close()
 
+	return scanChunks(rows)
+}
+
+// GetChunkRange retrieves the chunks of a document whose index falls within [startIndex, endIndex]
+func (r *DocumentRepo) GetChunkRange(ctx context.Context, documentID uuid.UUID, startIndex, endIndex int) ([]*repository.DocumentChunk, error) {
+	query := `
+		SELECT ` + chunkColumns + `
+		FROM document_chunks
+		WHERE document_id = $1 AND chunk_index BETWEEN $2 AND $3
+		ORDER BY chunk_index
+	`
+	rows, err := r.db.conn(ctx).Query(ctx, query, documentID, startIndex, endIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunk range: %w", err)
+	}
+	defer rows.Close()
+
+	return scanChunks(rows)
+}
+
+// GetChunkWindow retrieves a chunk together with up to `before` preceding and `after` following chunks
+func (r *DocumentRepo) GetChunkWindow(ctx context.Context, documentID uuid.UUID, chunkIndex, before, after int) ([]*repository.DocumentChunk, error) {
+	start := chunkIndex - before
+	if start < 0 {
+		start = 0
+	}
+	return r.GetChunkRange(ctx, documentID, start, chunkIndex+after)
+}
+
+// SearchChunks finds a tenant's chunks matching a keyword query, best first.
+// The query takes web search syntax: quoted phrases, OR, and -word, and is
+// parsed with searchConfig, which should match the chunks'.
+func (r *DocumentRepo) SearchChunks(ctx context.Context, tenantID uuid.UUID, query, searchConfig string, filter repository.ChunkFilter, limit int) ([]*repository.ChunkMatch, error) {
+	args := []any{tenantID, query, limit, searchConfigOrSimple(searchConfig)}
+	var conditions string
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		conditions += fmt.Sprintf(" AND d.id IN (SELECT document_id FROM document_tags WHERE tag = ANY($%d))", len(args))
+	}
+	if len(filter.CollectionIDs) > 0 {
+		args = append(args, filter.CollectionIDs)
+		conditions += fmt.Sprintf(" AND d.id IN (SELECT document_id FROM collection_documents WHERE collection_id = ANY($%d))", len(args))
+	}
+	if len(filter.Languages) > 0 {
+		args = append(args, filter.Languages)
+		conditions += fmt.Sprintf(" AND c.metadata->>'language' = ANY($%d)", len(args))
+	}
+	if filter.ProjectID != uuid.Nil {
+		args = append(args, filter.ProjectID)
+		conditions += fmt.Sprintf(" AND d.project_id = $%d", len(args))
+	}
+
+	sql := `
+		SELECT c.id, c.document_id, c.chunk_index, c.content, c.metadata,
+		       COALESCE(c.parent_start, c.chunk_index), COALESCE(c.parent_end, c.chunk_index), c.created_at,
+		       COALESCE(d.title, ''), d.source, ts_rank_cd(c.search_vector, q)
+		FROM document_chunks c
+		JOIN documents d ON d.id = c.document_id,
+		     websearch_to_tsquery($4::regconfig, $2) q
+		WHERE d.tenant_id = $1 AND c.search_vector @@ q` + conditions + `
+		ORDER BY ts_rank_cd(c.search_vector, q) DESC, c.id
+		LIMIT $3
+	`
+	rows, err := r.db.conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*repository.ChunkMatch
+	for rows.Next() {
+		var chunk repository.DocumentChunk
+		var match repository.ChunkMatch
+		var metadataJSON []byte
+		if err := rows.Scan(&chunk.ID, &chunk.DocumentID, &chunk.ChunkIndex, &chunk.Content,
+			&metadataJSON, &chunk.ParentStart, &chunk.ParentEnd, &chunk.CreatedAt,
+			&match.Title, &match.Source, &match.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk: %w", err)
+		}
+		chunk.Metadata = make(map[string]string)
+		if err := json.Unmarshal(metadataJSON, &chunk.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+		match.Chunk = &chunk
+		matches = append(matches, &match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate chunks: %w", err)
+	}
+
+	return matches, nil
+}
+
+// SetSearchConfig re-indexes a tenant's chunks for keyword search with searchConfig
+func (r *DocumentRepo) SetSearchConfig(ctx context.Context, tenantID uuid.UUID, searchConfig string) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE document_chunks c
+		SET search_config = $2::regconfig
+		FROM documents d
+		WHERE d.id = c.document_id AND d.tenant_id = $1 AND c.search_config <> $2::regconfig
+	`, tenantID, searchConfigOrSimple(searchConfig))
+	if err != nil {
+		return fmt.Errorf("failed to set search config: %w", err)
+	}
+	return nil
+}
+
+// searchConfigOrSimple defaults a text search configuration to "simple"
+func searchConfigOrSimple(searchConfig string) string {
+	if searchConfig == "" {
+		return "simple"
+	}
+	return searchConfig
+}
+
+func scanChunks(rows pgx.Rows) ([]*repository.DocumentChunk, error) {
 	var chunks []*repository.DocumentChunk
 	for rows.Next() {
 		var chunk repository.DocumentChunk
 		var metadataJSON []byte
+		var vectorChunkID *uuid.UUID
 		if err := rows.Scan(&chunk.ID, &chunk.DocumentID, &chunk.ChunkIndex, &chunk.Content,
-			&metadataJSON, &chunk.CreatedAt); err != nil {
+			&metadataJSON, &chunk.ParentStart, &chunk.ParentEnd, &chunk.CreatedAt,
+			&chunk.ContentHash, &vectorChunkID); err != nil {
 			return nil, fmt.Errorf("failed to scan chunk: %w", err)
 		}
+		if vectorChunkID != nil {
+			chunk.VectorChunkID = *vectorChunkID
+		}
 		chunk.Metadata = make(map[string]string)
 		if err := json.Unmarshal(metadataJSON, &chunk.Metadata); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
 		}
 		chunks = append(chunks, &chunk)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate chunks: %w", err)
+	}
 
 	return chunks, nil
 }
 
-// DeleteChunks deletes all chunks for a document
+// DeleteChunks deletes all chunks for a document and the summary tree built from them
 func (r *DocumentRepo) DeleteChunks(ctx context.Context, documentID uuid.UUID) error {
-	_, err := r.db.Pool.Exec(ctx, `DELETE FROM document_chunks WHERE document_id = $1`, documentID)
+	_, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM document_chunks WHERE document_id = $1`, documentID)
 	if err != nil {
 		return fmt.Errorf("failed to delete chunks: %w", err)
 	}
+	_, err = r.db.conn(ctx).Exec(ctx, `DELETE FROM document_summaries WHERE document_id = $1`, documentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete summaries: %w", err)
+	}
+	return nil
+}
+
+// AddTags tags a document; tags it already has are ignored
+func (r *DocumentRepo) AddTags(ctx context.Context, documentID uuid.UUID, tags []string) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO document_tags (document_id, tag)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING
+	`, documentID, tags)
+	if err != nil {
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+	return nil
+}
+
+// RemoveTags removes tags from a document; tags it doesn't have are ignored
+func (r *DocumentRepo) RemoveTags(ctx context.Context, documentID uuid.UUID, tags []string) error {
+	_, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM document_tags WHERE document_id = $1 AND tag = ANY($2)`, documentID, tags)
+	if err != nil {
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
 	return nil
 }
 
+// GetTags returns a document's tags in alphabetical order
+func (r *DocumentRepo) GetTags(ctx context.Context, documentID uuid.UUID) ([]string, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `SELECT tag FROM document_tags WHERE document_id = $1 ORDER BY tag`, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	return tags, nil
+}
+
+// Stats returns document and chunk counts across all tenants
+func (r *DocumentRepo) Stats(ctx context.Context) (*repository.DocumentStats, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `SELECT status, COUNT(*) FROM documents GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &repository.DocumentStats{ByStatus: make(map[string]int)}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan document count: %w", err)
+		}
+		stats.ByStatus[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate document counts: %w", err)
+	}
+
+	if err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM document_chunks`).Scan(&stats.ChunkCount); err != nil {
+		return nil, fmt.Errorf("failed to count chunks: %w", err)
+	}
+
+	return stats, nil
+}
+
 // Ensure DocumentRepo implements the interface
 var _ repository.DocumentRepository = (*DocumentRepo)(nil)
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+func TestGlobToLike(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/blog/*": "https://example.com/blog/%",
+		"*/blog/?.html":              "%/blog/_.html",
+		"100%_done":                  `100\%\_done`,
+		`C:\docs\*`:                  `C:\\docs\\%`,
+	}
+	for glob, want := range tests {
+		if got := globToLike(glob); got != want {
+			t.Errorf("globToLike(%q) = %q, want %q", glob, got, want)
+		}
+	}
+}
+
+func TestDocumentFilterSQL(t *testing.T) {
+	where, args, err := documentFilterSQL(uuid.New(), repository.DocumentFilter{
+		Status:       "READY",
+		SourceGlob:   "*/blog/*",
+		CreatedAfter: time.Now().Add(-7 * 24 * time.Hour),
+		Metadata:     map[string]string{"lang": "en"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `tenant_id = $1 AND status = $2 AND source LIKE $3 ESCAPE '\' AND created_at >= $4 AND metadata @> $5`
+	if where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 5 {
+		t.Errorf("got %d args, want 5", len(args))
+	}
+
+	order := documentOrderSQL(repository.DocumentFilter{SortBy: repository.DocumentSortTitle, Ascending: true})
+	if order != "title ASC NULLS LAST, id ASC" {
+		t.Errorf("order = %q", order)
+	}
+}
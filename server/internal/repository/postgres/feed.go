@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// FeedRepo implements repository.FeedRepository
+type FeedRepo struct {
+	db *DB
+}
+
+// NewFeedRepo creates a new feed repository
+func NewFeedRepo(db *DB) *FeedRepo {
+	return &FeedRepo{db: db}
+}
+
+const feedColumns = `id, tenant_id, url, COALESCE(title, ''), poll_interval_seconds, metadata,
+		last_polled_at, last_item_at, COALESCE(last_error, ''), item_count, created_at, project_id`
+
+// Create creates a new feed
+func (r *FeedRepo) Create(ctx context.Context, feed *repository.Feed) error {
+	metadataJSON, err := json.Marshal(feed.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO feeds (id, tenant_id, url, title, poll_interval_seconds, metadata, last_polled_at, last_item_at, last_error, item_count, created_at, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err = r.db.conn(ctx).Exec(ctx, query,
+		feed.ID, feed.TenantID, feed.URL, feed.Title, feed.PollIntervalSeconds, metadataJSON,
+		feed.LastPolledAt, feed.LastItemAt, feed.LastError, feed.ItemCount, feed.CreatedAt, feed.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to create feed: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a feed by ID
+func (r *FeedRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Feed, error) {
+	query := `SELECT ` + feedColumns + ` FROM feeds WHERE id = $1`
+
+	feed, err := scanFeed(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	return feed, nil
+}
+
+// List retrieves feeds for a tenant, or one of its projects, with pagination
+func (r *FeedRepo) List(ctx context.Context, tenantID, projectID uuid.UUID, limit, offset int) ([]*repository.Feed, int, error) {
+	where := `tenant_id = $1`
+	args := []any{tenantID}
+	if projectID != uuid.Nil {
+		where += ` AND project_id = $2`
+		args = append(args, projectID)
+	}
+
+	var total int
+	err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM feeds WHERE `+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count feeds: %w", err)
+	}
+
+	query := `
+		SELECT ` + feedColumns + `
+		FROM feeds
+		WHERE ` + where + `
+		ORDER BY created_at DESC
+		LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
+	feeds, err := r.queryFeeds(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return feeds, total, nil
+}
+
+// ListDue retrieves feeds whose poll interval has elapsed
+func (r *FeedRepo) ListDue(ctx context.Context, now time.Time) ([]*repository.Feed, error) {
+	query := `
+		SELECT ` + feedColumns + `
+		FROM feeds
+		WHERE last_polled_at IS NULL
+		   OR last_polled_at + make_interval(secs => poll_interval_seconds) <= $1
+		ORDER BY last_polled_at NULLS FIRST
+	`
+	return r.queryFeeds(ctx, query, now)
+}
+
+func (r *FeedRepo) queryFeeds(ctx context.Context, query string, args ...any) ([]*repository.Feed, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	defer rows.Close()
+
+	var feeds []*repository.Feed
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed: %w", err)
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// scanFeed scans a single feed row
+func scanFeed(row pgx.Row) (*repository.Feed, error) {
+	var feed repository.Feed
+	var metadataJSON []byte
+
+	err := row.Scan(
+		&feed.ID, &feed.TenantID, &feed.URL, &feed.Title, &feed.PollIntervalSeconds, &metadataJSON,
+		&feed.LastPolledAt, &feed.LastItemAt, &feed.LastError, &feed.ItemCount, &feed.CreatedAt, &feed.ProjectID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Metadata = make(map[string]string)
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &feed.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	return &feed, nil
+}
+
+// Update updates a feed's title and sync state
+func (r *FeedRepo) Update(ctx context.Context, feed *repository.Feed) error {
+	query := `
+		UPDATE feeds
+		SET title = $2, last_polled_at = $3, last_item_at = $4, last_error = $5, item_count = $6
+		WHERE id = $1
+	`
+	result, err := r.db.conn(ctx).Exec(ctx, query,
+		feed.ID, feed.Title, feed.LastPolledAt, feed.LastItemAt, feed.LastError, feed.ItemCount)
+	if err != nil {
+		return fmt.Errorf("failed to update feed: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete deletes a feed and its item records
+func (r *FeedRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM feeds WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete feed: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// HasItem reports whether a feed item has already been ingested
+func (r *FeedRepo) HasItem(ctx context.Context, feedID uuid.UUID, guid string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM feed_items WHERE feed_id = $1 AND guid = $2)`
+	if err := r.db.conn(ctx).QueryRow(ctx, query, feedID, guid).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check feed item: %w", err)
+	}
+	return exists, nil
+}
+
+// RecordItem records an ingested feed item
+func (r *FeedRepo) RecordItem(ctx context.Context, item *repository.FeedItem) error {
+	query := `
+		INSERT INTO feed_items (feed_id, guid, document_id, published_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (feed_id, guid) DO NOTHING
+	`
+	_, err := r.db.conn(ctx).Exec(ctx, query, item.FeedID, item.GUID, item.DocumentID, item.PublishedAt, item.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record feed item: %w", err)
+	}
+	return nil
+}
+
+// Ensure FeedRepo implements the interface
+var _ repository.FeedRepository = (*FeedRepo)(nil)
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// GraphRepo implements repository.GraphRepository
+type GraphRepo struct {
+	db *DB
+}
+
+// NewGraphRepo creates a new knowledge graph repository
+func NewGraphRepo(db *DB) *GraphRepo {
+	return &GraphRepo{db: db}
+}
+
+// ReplaceDocumentGraph replaces the mentions and relations extracted from a
+// document's chunks in one transaction
+func (r *GraphRepo) ReplaceDocumentGraph(ctx context.Context, tenantID, documentID uuid.UUID, mentions []*repository.GraphMention, relations []*repository.GraphRelation) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		conn := r.db.conn(ctx)
+		if _, err := conn.Exec(ctx, `DELETE FROM graph_mentions WHERE document_id = $1`, documentID); err != nil {
+			return fmt.Errorf("failed to delete graph mentions: %w", err)
+		}
+		if _, err := conn.Exec(ctx, `DELETE FROM graph_relations WHERE document_id = $1`, documentID); err != nil {
+			return fmt.Errorf("failed to delete graph relations: %w", err)
+		}
+		if len(mentions) == 0 && len(relations) == 0 {
+			return nil
+		}
+
+		batch := &pgx.Batch{}
+		for _, m := range mentions {
+			batch.Queue(`
+				INSERT INTO graph_mentions (tenant_id, document_id, chunk_id, entity_key, name, entity_type)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, tenantID, documentID, m.ChunkID, m.EntityKey, m.Name, m.EntityType)
+		}
+		for _, rel := range relations {
+			batch.Queue(`
+				INSERT INTO graph_relations (tenant_id, document_id, chunk_id, source_key, source_name, relation, target_key, target_name)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			`, tenantID, documentID, rel.ChunkID, rel.SourceKey, rel.Source, rel.Relation, rel.TargetKey, rel.Target)
+		}
+		results := conn.SendBatch(ctx, batch)
+		defer results.Close()
+		for range len(mentions) + len(relations) {
+			if _, err := results.Exec(); err != nil {
+				return fmt.Errorf("failed to store graph: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindEntities returns those of the entity keys mentioned in the tenant's
+// documents, only the project's unless projectID is uuid.Nil
+func (r *GraphRepo) FindEntities(ctx context.Context, tenantID, projectID uuid.UUID, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	args := []any{tenantID, keys}
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT DISTINCT entity_key FROM graph_mentions
+		WHERE tenant_id = $1 AND entity_key = ANY($2)`+projectCondition(&args, projectID), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entities: %w", err)
+	}
+	found, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entities: %w", err)
+	}
+	return found, nil
+}
+
+// Relations returns up to limit relations involving the entities,
+// aggregated over chunks, those stated most often first. Only relations from
+// the project's documents are returned unless projectID is uuid.Nil.
+func (r *GraphRepo) Relations(ctx context.Context, tenantID, projectID uuid.UUID, keys []string, limit int) ([]*repository.GraphRelation, error) {
+	if len(keys) == 0 || limit <= 0 {
+		return nil, nil
+	}
+	args := []any{tenantID, keys, limit}
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT source_key, MIN(source_name), relation, target_key, MIN(target_name), COUNT(*)
+		FROM graph_relations
+		WHERE tenant_id = $1 AND (source_key = ANY($2) OR target_key = ANY($2))`+projectCondition(&args, projectID)+`
+		GROUP BY source_key, relation, target_key
+		ORDER BY COUNT(*) DESC, source_key, relation, target_key
+		LIMIT $3
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graph relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []*repository.GraphRelation
+	for rows.Next() {
+		var rel repository.GraphRelation
+		if err := rows.Scan(&rel.SourceKey, &rel.Source, &rel.Relation, &rel.TargetKey, &rel.Target, &rel.Chunks); err != nil {
+			return nil, fmt.Errorf("failed to scan graph relation: %w", err)
+		}
+		relations = append(relations, &rel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list graph relations: %w", err)
+	}
+	return relations, nil
+}
+
+// projectCondition restricts graph rows to the documents of a project,
+// adding its argument to args. It is empty for uuid.Nil.
+func projectCondition(args *[]any, projectID uuid.UUID) string {
+	if projectID == uuid.Nil {
+		return ""
+	}
+	*args = append(*args, projectID)
+	return fmt.Sprintf(" AND document_id IN (SELECT id FROM documents WHERE project_id = $%d)", len(*args))
+}
+
+// Ensure GraphRepo implements the interface
+var _ repository.GraphRepository = (*GraphRepo)(nil)
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock held while migrating, so replicas
+// starting together do not run the same migration twice
+const migrationLockID = 0x7261675f6d6967 // "rag_mig"
+
+// ErrDirtyMigration is returned when a previous migration failed partway
+// (possible only with tools that do not run migrations in a transaction)
+var ErrDirtyMigration = errors.New("database is marked dirty by a failed migration; fix the schema and run `ragd migrate force <version>`")
+
+// Migration is a numbered schema change embedded in the binary.
+// The version table is the one golang-migrate uses, so `make migrate-up`
+// and `ragd migrate` can be used interchangeably.
+type Migration struct {
+	Version uint
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus describes the schema version and what remains to apply
+type MigrationStatus struct {
+	Version uint // 0 when no migration has been applied
+	Dirty   bool
+	Applied []Migration
+	Pending []Migration
+}
+
+// Migrations returns the embedded migrations in version order
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, e := range entries {
+		// NNN_name.up.sql / NNN_name.down.sql
+		base, direction, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".sql"), ".")
+		num, name, hasName := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(num, 10, 32)
+		if !ok || !hasName || err != nil || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("unexpected migration file name %q", e.Name())
+		}
+		sql, err := migrationFiles.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[uint(version)]
+		if m == nil {
+			m = &Migration{Version: uint(version), Name: name}
+			byVersion[uint(version)] = m
+		}
+		if direction == "up" {
+			m.up = string(sql)
+		} else {
+			m.down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrationStatus reports the current schema version
+func (db *DB) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	var status *MigrationStatus
+	err := db.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		var err error
+		status, err = migrationStatus(ctx, conn)
+		return err
+	})
+	return status, err
+}
+
+// MigrateUp applies all pending migrations, each in its own transaction,
+// and returns the ones applied
+func (db *DB) MigrateUp(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := db.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		status, err := migrationStatus(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if status.Dirty {
+			return ErrDirtyMigration
+		}
+		for _, m := range status.Pending {
+			if err := runMigration(ctx, conn, m.up, m.Version); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+			}
+			applied = append(applied, m)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// MigrateDown reverts the given number of most recent migrations and
+// returns the ones reverted
+func (db *DB) MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := db.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		status, err := migrationStatus(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if status.Dirty {
+			return ErrDirtyMigration
+		}
+		for i := len(status.Applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+			m := status.Applied[i]
+			if m.down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+			}
+			var previous uint
+			if i > 0 {
+				previous = status.Applied[i-1].Version
+			}
+			if err := runMigration(ctx, conn, m.down, previous); err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", m.Version, m.Name, err)
+			}
+			reverted = append(reverted, m)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// ForceMigrationVersion records version as applied without running anything
+// and clears the dirty flag. Use it to adopt a schema created out-of-band.
+func (db *DB) ForceMigrationVersion(ctx context.Context, version uint) error {
+	return db.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		if err := setMigrationVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	})
+}
+
+// withMigrationLock runs fn on one connection holding the migration lock
+func (db *DB) withMigrationLock(ctx context.Context, fn func(*pgxpool.Conn) error) error {
+	conn, err := db.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)
+	`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+func migrationStatus(ctx context.Context, conn *pgxpool.Conn) (*MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var status MigrationStatus
+	var version int64
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &status.Dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	status.Version = uint(version)
+
+	for _, m := range migrations {
+		if m.Version <= status.Version {
+			status.Applied = append(status.Applied, m)
+		} else {
+			status.Pending = append(status.Pending, m)
+		}
+	}
+	return &status, nil
+}
+
+// runMigration executes a migration and records the resulting version atomically
+func runMigration(ctx context.Context, conn *pgxpool.Conn, sql string, version uint) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// No arguments, so pgx sends it with the simple protocol, which allows
+	// several statements per file
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+	if err := setMigrationVersion(ctx, tx, version); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// setMigrationVersion records the version the way golang-migrate does: a
+// single row, or none once every migration has been reverted
+func setMigrationVersion(ctx context.Context, tx pgx.Tx, version uint) error {
+	if _, err := tx.Exec(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return fmt.Errorf("failed to update schema version: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, int64(version)); err != nil {
+		return fmt.Errorf("failed to update schema version: %w", err)
+	}
+	return nil
+}
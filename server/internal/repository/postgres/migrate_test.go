@@ -0,0 +1,21 @@
+package postgres
+
+import "testing"
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, m := range migrations {
+		if m.Version != uint(i+1) {
+			t.Errorf("migration %d_%s: versions must be contiguous from 1", m.Version, m.Name)
+		}
+		if m.down == "" {
+			t.Errorf("migration %d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_document_chunks_document_index;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS parent_end;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS parent_start;
@@ -0,0 +1,7 @@
+-- Parent section spans for contextual (parent-document) retrieval.
+-- A chunk's parent span is the inclusive chunk_index range of the section it belongs to.
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS parent_start INT;
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS parent_end INT;
+
+-- Adjacent chunk lookups by position
+CREATE INDEX IF NOT EXISTS idx_document_chunks_document_index ON document_chunks(document_id, chunk_index);
@@ -0,0 +1,18 @@
+-- Reindex jobs re-embed a tenant's chunks into a new collection (embedding model migration)
+CREATE TABLE IF NOT EXISTS reindex_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    status VARCHAR(20) DEFAULT 'PENDING',
+    embedding_model VARCHAR(255) NOT NULL,
+    dimension INT NOT NULL,
+    collection_version VARCHAR(64) NOT NULL,
+    chunks_total INT DEFAULT 0,
+    chunks_done INT DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reindex_jobs_tenant_id ON reindex_jobs(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_reindex_jobs_status ON reindex_jobs(status);
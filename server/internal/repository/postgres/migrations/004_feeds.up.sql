@@ -0,0 +1,27 @@
+-- RSS/Atom feeds polled per tenant
+CREATE TABLE IF NOT EXISTS feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    title VARCHAR(512),
+    poll_interval_seconds INT NOT NULL DEFAULT 3600,
+    metadata JSONB DEFAULT '{}',
+    last_polled_at TIMESTAMPTZ,
+    last_item_at TIMESTAMPTZ,
+    last_error TEXT,
+    item_count INT DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(tenant_id, url)
+);
+
+-- Items already ingested from a feed (deduplication by guid/link)
+CREATE TABLE IF NOT EXISTS feed_items (
+    feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+    guid TEXT NOT NULL,
+    document_id UUID REFERENCES documents(id) ON DELETE SET NULL,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (feed_id, guid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feeds_tenant_id ON feeds(tenant_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_document_chunks_search_vector;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,7 @@
+-- Keyword search over chunk text, used by keyword and hybrid retrieval and
+-- as a fallback when the vector store is unavailable. The 'simple'
+-- configuration does no stemming or stop words, so it works for any language.
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_document_chunks_search_vector ON document_chunks USING GIN (search_vector);
@@ -0,0 +1,9 @@
+-- Document tags, used to list documents and to scope retrieval
+CREATE TABLE IF NOT EXISTS document_tags (
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    tag VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (document_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_tags_tag ON document_tags(tag);
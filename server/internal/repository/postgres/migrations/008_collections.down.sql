@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS collection_documents;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,2 @@
+-- Per-document chunker settings that override the tenant's; NULL uses the tenant's
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS chunker_config JSONB;
@@ -0,0 +1,10 @@
+-- The original content each document was ingested from, gzip-compressed, so
+-- documents can be re-chunked without resubmitting them
+CREATE TABLE IF NOT EXISTS document_contents (
+    document_id UUID PRIMARY KEY REFERENCES documents(id) ON DELETE CASCADE,
+    content_type VARCHAR(255) NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
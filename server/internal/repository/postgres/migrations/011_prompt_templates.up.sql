@@ -0,0 +1,10 @@
+-- Versions of a tenant's prompt template; versions are never edited, so a
+-- tenant can roll back by activating an earlier one
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    template TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, version)
+);
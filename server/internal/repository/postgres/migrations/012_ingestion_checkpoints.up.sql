@@ -0,0 +1,8 @@
+-- How far a large document's processing got; its chunks are committed before
+-- embedding starts, so an interrupted run resumes from chunks_embedded
+CREATE TABLE IF NOT EXISTS ingestion_checkpoints (
+    document_id UUID PRIMARY KEY REFERENCES documents(id) ON DELETE CASCADE,
+    chunks_total INT NOT NULL,
+    chunks_embedded INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
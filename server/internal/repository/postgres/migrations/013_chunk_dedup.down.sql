@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS chunk_hashes;
+DROP INDEX IF EXISTS idx_document_chunks_vector_chunk_id;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS vector_chunk_id;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,16 @@
+-- Identical chunks of a tenant's documents share one vector: the first
+-- chunk with a content hash owns it, later ones point at it with
+-- vector_chunk_id, and ref_count counts every chunk with the hash
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS vector_chunk_id UUID;
+CREATE INDEX IF NOT EXISTS idx_document_chunks_vector_chunk_id
+    ON document_chunks(vector_chunk_id) WHERE vector_chunk_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS chunk_hashes (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    content_hash VARCHAR(64) NOT NULL,
+    chunk_id UUID NOT NULL,
+    ref_count INT NOT NULL,
+    PRIMARY KEY (tenant_id, content_hash)
+);
+CREATE INDEX IF NOT EXISTS idx_chunk_hashes_chunk_id ON chunk_hashes(chunk_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_documents_tenant_simhash;
+ALTER TABLE documents DROP COLUMN IF EXISTS near_duplicate_of;
+ALTER TABLE documents DROP COLUMN IF EXISTS simhash;
@@ -0,0 +1,5 @@
+-- SimHash fingerprints of document text for near-duplicate detection, and
+-- the earlier document a near-duplicate was flagged against
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS simhash BIGINT;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS near_duplicate_of UUID REFERENCES documents(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_tenant_simhash ON documents(tenant_id) WHERE simhash IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_documents_tenant_source_key;
+ALTER TABLE documents DROP COLUMN IF EXISTS source_key;
@@ -0,0 +1,5 @@
+-- Canonical URLs of web documents without their scheme, so re-ingesting a
+-- page under another address finds the existing document; documents
+-- ingested before canonicalization have none
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS source_key TEXT;
+CREATE INDEX IF NOT EXISTS idx_documents_tenant_source_key ON documents(tenant_id, source_key) WHERE source_key IS NOT NULL;
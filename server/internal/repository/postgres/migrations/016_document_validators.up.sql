@@ -0,0 +1,4 @@
+-- HTTP validators of the last fetch of a web document, sent back on
+-- re-ingest so an unchanged page is not fetched and processed again
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS etag TEXT;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS last_modified TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_crawled_pages_frontier;
+ALTER TABLE crawled_pages DROP COLUMN IF EXISTS queued_at;
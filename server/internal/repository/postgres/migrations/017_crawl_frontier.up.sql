@@ -0,0 +1,5 @@
+-- crawled_pages doubles as a crawl job's frontier: PENDING pages are still
+-- to be fetched, and every row is a URL the job has seen, so a job resumed
+-- after a restart neither starts over from its root nor revisits pages
+ALTER TABLE crawled_pages ADD COLUMN IF NOT EXISTS queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+CREATE INDEX IF NOT EXISTS idx_crawled_pages_frontier ON crawled_pages(job_id, depth, queued_at) WHERE status = 'PENDING';
@@ -0,0 +1,2 @@
+ALTER TABLE crawled_pages DROP COLUMN IF EXISTS anchor_text;
+ALTER TABLE crawled_pages DROP COLUMN IF EXISTS parent_url;
@@ -0,0 +1,4 @@
+-- The link each crawled page was discovered through: the page linking to it
+-- (empty for a job's root URL) and the link's anchor text
+ALTER TABLE crawled_pages ADD COLUMN IF NOT EXISTS parent_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE crawled_pages ADD COLUMN IF NOT EXISTS anchor_text TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,26 @@
+-- Entities and relations extracted from chunks of tenants with a knowledge
+-- graph. Keys are the matched form of entity names; names are as written.
+CREATE TABLE IF NOT EXISTS graph_mentions (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    chunk_id UUID NOT NULL,
+    entity_key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    entity_type TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_graph_mentions_tenant_key ON graph_mentions(tenant_id, entity_key);
+CREATE INDEX IF NOT EXISTS idx_graph_mentions_document_id ON graph_mentions(document_id);
+
+CREATE TABLE IF NOT EXISTS graph_relations (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    chunk_id UUID NOT NULL,
+    source_key TEXT NOT NULL,
+    source_name TEXT NOT NULL,
+    relation TEXT NOT NULL,
+    target_key TEXT NOT NULL,
+    target_name TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_graph_relations_tenant_source ON graph_relations(tenant_id, source_key);
+CREATE INDEX IF NOT EXISTS idx_graph_relations_tenant_target ON graph_relations(tenant_id, target_key);
+CREATE INDEX IF NOT EXISTS idx_graph_relations_document_id ON graph_relations(document_id);
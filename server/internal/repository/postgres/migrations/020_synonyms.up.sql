@@ -0,0 +1,10 @@
+-- Tenants' synonym dictionaries, applied to keyword and sparse queries.
+-- Terms are stored normalized (see synonym.Normalize); synonyms as entered.
+CREATE TABLE IF NOT EXISTS synonyms (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    term TEXT NOT NULL,
+    synonyms TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, term)
+);
@@ -0,0 +1,10 @@
+-- Tenants' query counts per calendar month (UTC), added to in batches by the
+-- servers. The current month's row is the tenant's query_count_month.
+CREATE TABLE IF NOT EXISTS tenant_query_usage (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    query_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, month)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_query_usage_month ON tenant_query_usage(month);
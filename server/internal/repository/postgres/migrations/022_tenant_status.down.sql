@@ -0,0 +1,3 @@
+ALTER TABLE tenants DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE tenants DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE tenants DROP COLUMN IF EXISTS status;
@@ -0,0 +1,5 @@
+-- Tenant lifecycle: ACTIVE, READ_ONLY, SUSPENDED or ARCHIVED. Requests are
+-- checked against the status; data is kept in every status.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'ACTIVE';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_documents_project_id;
+ALTER TABLE crawl_jobs DROP COLUMN IF EXISTS project_id;
+ALTER TABLE feeds DROP COLUMN IF EXISTS project_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS project_id;
+ALTER TABLE documents DROP COLUMN IF EXISTS project_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,12 @@
+-- Summary tree nodes of documents with a summary index, kept beside their
+-- vectors so a reindex can re-embed them without summarizing again
+CREATE TABLE IF NOT EXISTS document_summaries (
+    id UUID PRIMARY KEY,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    node_index INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    summarized_count INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(document_id, node_index)
+);
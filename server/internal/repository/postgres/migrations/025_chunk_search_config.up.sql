@@ -0,0 +1,11 @@
+-- Keyword search with the text search configuration of the tenant's
+-- language, such as 'english', which drops stop words and stems. Chunks keep
+-- the configuration they were stored with until the tenant is reindexed.
+ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS search_config regconfig NOT NULL DEFAULT 'simple';
+
+DROP INDEX IF EXISTS idx_document_chunks_search_vector;
+ALTER TABLE document_chunks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE document_chunks ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(search_config, content)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_document_chunks_search_vector ON document_chunks USING GIN (search_vector);
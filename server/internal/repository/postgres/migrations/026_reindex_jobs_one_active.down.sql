@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_reindex_jobs_active_tenant;
@@ -0,0 +1,13 @@
+-- At most one pending or running reindex job per tenant, so concurrent
+-- ReindexTenant calls cannot both start one. Older duplicates are failed first.
+UPDATE reindex_jobs SET status = 'FAILED', error_message = 'superseded by a newer reindex job', completed_at = NOW()
+WHERE status IN ('PENDING', 'RUNNING')
+  AND id NOT IN (
+    SELECT DISTINCT ON (tenant_id) id
+    FROM reindex_jobs
+    WHERE status IN ('PENDING', 'RUNNING')
+    ORDER BY tenant_id, created_at DESC
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reindex_jobs_active_tenant
+    ON reindex_jobs(tenant_id) WHERE status IN ('PENDING', 'RUNNING');
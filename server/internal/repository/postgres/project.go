@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// foreignKeyViolation is the Postgres error code for a foreign key violation
+const foreignKeyViolation = "23503"
+
+const projectColumns = `id, tenant_id, name, retrieval, created_at, updated_at`
+
+// ProjectRepo implements repository.ProjectRepository
+type ProjectRepo struct {
+	db *DB
+}
+
+// NewProjectRepo creates a new project repository
+func NewProjectRepo(db *DB) *ProjectRepo {
+	return &ProjectRepo{db: db}
+}
+
+// Create creates a new project
+func (r *ProjectRepo) Create(ctx context.Context, project *repository.Project) error {
+	retrievalJSON, err := json.Marshal(project.Retrieval)
+	if err != nil {
+		return fmt.Errorf("failed to marshal retrieval config: %w", err)
+	}
+	_, err = r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO projects (id, tenant_id, name, retrieval, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, project.ID, project.TenantID, project.Name, retrievalJSON, project.CreatedAt, project.UpdatedAt)
+	if err != nil {
+		return collectionWriteError("failed to create project", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a project by ID
+func (r *ProjectRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
+
+	project, err := scanProject(r.db.conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// List retrieves a tenant's projects by name
+func (r *ProjectRepo) List(ctx context.Context, tenantID uuid.UUID) ([]*repository.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE tenant_id = $1 ORDER BY name`
+	rows, err := r.db.conn(ctx).Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*repository.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate projects: %w", err)
+	}
+	return projects, nil
+}
+
+// Update updates a project's name and retrieval config
+func (r *ProjectRepo) Update(ctx context.Context, project *repository.Project) error {
+	retrievalJSON, err := json.Marshal(project.Retrieval)
+	if err != nil {
+		return fmt.Errorf("failed to marshal retrieval config: %w", err)
+	}
+	result, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE projects SET name = $2, retrieval = $3, updated_at = $4 WHERE id = $1`,
+		project.ID, project.Name, retrievalJSON, project.UpdatedAt)
+	if err != nil {
+		return collectionWriteError("failed to update project", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// Delete deletes a project with its API keys, feeds and crawl jobs. A project
+// that still has documents is not deleted.
+func (r *ProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return repository.ErrProjectNotEmpty
+		}
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// scanProject scans a single project row
+func scanProject(row pgx.Row) (*repository.Project, error) {
+	var p repository.Project
+	var retrievalJSON []byte
+	if err := row.Scan(&p.ID, &p.TenantID, &p.Name, &retrievalJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(retrievalJSON, &p.Retrieval); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retrieval config: %w", err)
+	}
+	return &p, nil
+}
+
+// Ensure ProjectRepo implements the interface
+var _ repository.ProjectRepository = (*ProjectRepo)(nil)
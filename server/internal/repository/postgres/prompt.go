@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// PromptTemplateRepo implements repository.PromptTemplateRepository
+type PromptTemplateRepo struct {
+	db *DB
+}
+
+// NewPromptTemplateRepo creates a new prompt template repository
+func NewPromptTemplateRepo(db *DB) *PromptTemplateRepo {
+	return &PromptTemplateRepo{db: db}
+}
+
+// Create stores a template as the tenant's next version. Concurrent creates
+// for one tenant can pick the same version; the loser gets
+// repository.ErrAlreadyExists.
+func (r *PromptTemplateRepo) Create(ctx context.Context, t *repository.PromptTemplate) error {
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		INSERT INTO prompt_templates (tenant_id, version, template, description)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3
+		FROM prompt_templates
+		WHERE tenant_id = $1
+		RETURNING version, created_at
+	`, t.TenantID, t.Template, t.Description).Scan(&t.Version, &t.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create prompt template: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves one version of a tenant's template
+func (r *PromptTemplateRepo) Get(ctx context.Context, tenantID uuid.UUID, version int) (*repository.PromptTemplate, error) {
+	t := repository.PromptTemplate{TenantID: tenantID, Version: version}
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		SELECT template, description, created_at
+		FROM prompt_templates
+		WHERE tenant_id = $1 AND version = $2
+	`, tenantID, version).Scan(&t.Template, &t.Description, &t.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+	return &t, nil
+}
+
+// List retrieves a tenant's template versions, newest first, with pagination
+func (r *PromptTemplateRepo) List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*repository.PromptTemplate, int, error) {
+	var total int
+	err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM prompt_templates WHERE tenant_id = $1`, tenantID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count prompt templates: %w", err)
+	}
+
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT version, template, description, created_at
+		FROM prompt_templates
+		WHERE tenant_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`, tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*repository.PromptTemplate
+	for rows.Next() {
+		t := repository.PromptTemplate{TenantID: tenantID}
+		if err := rows.Scan(&t.Version, &t.Template, &t.Description, &t.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan prompt template: %w", err)
+		}
+		templates = append(templates, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate prompt templates: %w", err)
+	}
+
+	return templates, total, nil
+}
+
+// Ensure PromptTemplateRepo implements the interface
+var _ repository.PromptTemplateRepository = (*PromptTemplateRepo)(nil)
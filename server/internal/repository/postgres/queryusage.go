@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// QueryUsageRepo implements repository.QueryUsageRepository
+type QueryUsageRepo struct {
+	db *DB
+}
+
+// NewQueryUsageRepo creates a new query usage repository
+func NewQueryUsageRepo(db *DB) *QueryUsageRepo {
+	return &QueryUsageRepo{db: db}
+}
+
+// Add adds query counts to tenants' monthly counts in one statement
+func (r *QueryUsageRepo) Add(ctx context.Context, usage []repository.QueryUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	tenantIDs := make([]uuid.UUID, len(usage))
+	months := make([]time.Time, len(usage))
+	counts := make([]int64, len(usage))
+	for i, u := range usage {
+		tenantIDs[i], months[i], counts[i] = u.TenantID, u.Month, u.QueryCount
+	}
+
+	// Counts of tenants deleted since their queries are dropped
+	_, err := r.db.conn(ctx).Exec(ctx, `
+		INSERT INTO tenant_query_usage (tenant_id, month, query_count)
+		SELECT u.tenant_id, u.month, u.query_count
+		FROM unnest($1::uuid[], $2::date[], $3::bigint[]) AS u(tenant_id, month, query_count)
+		WHERE EXISTS (SELECT 1 FROM tenants t WHERE t.id = u.tenant_id)
+		ON CONFLICT (tenant_id, month) DO UPDATE
+		SET query_count = tenant_query_usage.query_count + EXCLUDED.query_count
+	`, tenantIDs, months, counts)
+	if err != nil {
+		return fmt.Errorf("failed to add query counts: %w", err)
+	}
+	return nil
+}
+
+// ListSince returns the counts of months from since on, by month then tenant
+func (r *QueryUsageRepo) ListSince(ctx context.Context, since time.Time) ([]*repository.QueryUsage, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT tenant_id, month, query_count
+		FROM tenant_query_usage
+		WHERE month >= $1
+		ORDER BY month, tenant_id
+	`, repository.QueryMonth(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list query counts: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []*repository.QueryUsage
+	for rows.Next() {
+		var u repository.QueryUsage
+		if err := rows.Scan(&u.TenantID, &u.Month, &u.QueryCount); err != nil {
+			return nil, fmt.Errorf("failed to scan query count: %w", err)
+		}
+		u.Month = repository.QueryMonth(u.Month)
+		usage = append(usage, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list query counts: %w", err)
+	}
+	return usage, nil
+}
+
+// DeleteBefore deletes the counts of months before month
+func (r *QueryUsageRepo) DeleteBefore(ctx context.Context, month time.Time) (int64, error) {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM tenant_query_usage WHERE month < $1`, repository.QueryMonth(month))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete query counts: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// Ensure QueryUsageRepo implements the interface
+var _ repository.QueryUsageRepository = (*QueryUsageRepo)(nil)
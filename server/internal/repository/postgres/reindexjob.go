@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// ReindexJobRepo implements repository.ReindexJobRepository
+type ReindexJobRepo struct {
+	db *DB
+}
+
+// NewReindexJobRepo creates a new reindex job repository
+func NewReindexJobRepo(db *DB) *ReindexJobRepo {
+	return &ReindexJobRepo{db: db}
+}
+
+const reindexJobColumns = `id, tenant_id, status, embedding_model, dimension, collection_version,
+		chunks_total, chunks_done, COALESCE(error_message, ''), created_at, started_at, completed_at`
+
+// Create creates a new reindex job
+func (r *ReindexJobRepo) Create(ctx context.Context, job *repository.ReindexJob) error {
+	query := `
+		INSERT INTO reindex_jobs (id, tenant_id, status, embedding_model, dimension, collection_version, chunks_total, chunks_done, error_message, created_at, started_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.conn(ctx).Exec(ctx, query,
+		job.ID, job.TenantID, job.Status, job.EmbeddingModel, job.Dimension, job.CollectionVersion,
+		job.ChunksTotal, job.ChunksDone, job.ErrorMessage,
+		job.CreatedAt, job.StartedAt, job.CompletedAt)
+	if err != nil {
+		// The tenant already has a pending or running job
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return repository.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create reindex job: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a reindex job by ID
+func (r *ReindexJobRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.ReindexJob, error) {
+	query := `SELECT ` + reindexJobColumns + ` FROM reindex_jobs WHERE id = $1`
+	return r.scanJob(ctx, query, id)
+}
+
+// GetActive retrieves the pending or running reindex job for a tenant
+func (r *ReindexJobRepo) GetActive(ctx context.Context, tenantID uuid.UUID) (*repository.ReindexJob, error) {
+	query := `
+		SELECT ` + reindexJobColumns + `
+		FROM reindex_jobs
+		WHERE tenant_id = $1 AND status IN ('PENDING', 'RUNNING')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	return r.scanJob(ctx, query, tenantID)
+}
+
+func (r *ReindexJobRepo) scanJob(ctx context.Context, query string, args ...any) (*repository.ReindexJob, error) {
+	var job repository.ReindexJob
+	err := r.db.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&job.ID, &job.TenantID, &job.Status, &job.EmbeddingModel, &job.Dimension, &job.CollectionVersion,
+		&job.ChunksTotal, &job.ChunksDone, &job.ErrorMessage,
+		&job.CreatedAt, &job.StartedAt, &job.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get reindex job: %w", err)
+	}
+	return &job, nil
+}
+
+// Update updates a reindex job
+func (r *ReindexJobRepo) Update(ctx context.Context, job *repository.ReindexJob) error {
+	query := `
+		UPDATE reindex_jobs
+		SET status = $2, chunks_total = $3, chunks_done = $4, error_message = $5,
+		    started_at = $6, completed_at = $7
+		WHERE id = $1
+	`
+	result, err := r.db.conn(ctx).Exec(ctx, query,
+		job.ID, job.Status, job.ChunksTotal, job.ChunksDone, job.ErrorMessage,
+		job.StartedAt, job.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update reindex job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CountActive counts pending or running reindex jobs across all tenants
+func (r *ReindexJobRepo) CountActive(ctx context.Context) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM reindex_jobs WHERE status IN ('PENDING', 'RUNNING')`
+	if err := r.db.conn(ctx).QueryRow(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reindex jobs: %w", err)
+	}
+	return count, nil
+}
+
+// FailInterrupted marks jobs left pending or running by a previous process as failed
+func (r *ReindexJobRepo) FailInterrupted(ctx context.Context) error {
+	query := `
+		UPDATE reindex_jobs
+		SET status = 'FAILED', error_message = 'interrupted by server restart', completed_at = NOW()
+		WHERE status IN ('PENDING', 'RUNNING')
+	`
+	if _, err := r.db.conn(ctx).Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to fail interrupted reindex jobs: %w", err)
+	}
+	return nil
+}
+
+// Ensure ReindexJobRepo implements the interface
+var _ repository.ReindexJobRepository = (*ReindexJobRepo)(nil)
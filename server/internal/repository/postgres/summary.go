@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// SaveSummaries replaces a document's summary tree nodes
+func (r *DocumentRepo) SaveSummaries(ctx context.Context, documentID uuid.UUID, summaries []*repository.DocumentSummary) error {
+	batch := &pgx.Batch{}
+	batch.Queue(`DELETE FROM document_summaries WHERE document_id = $1`, documentID)
+	for _, summary := range summaries {
+		batch.Queue(`
+			INSERT INTO document_summaries (id, document_id, node_index, level, content, summarized_count)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, summary.ID, documentID, summary.NodeIndex, summary.Level, summary.Content, summary.SummarizedCount)
+	}
+
+	results := r.db.conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+	for i := 0; i < batch.Len(); i++ {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("failed to save summaries: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetSummaries returns a document's summary tree nodes in build order
+func (r *DocumentRepo) GetSummaries(ctx context.Context, documentID uuid.UUID) ([]*repository.DocumentSummary, error) {
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT id, document_id, node_index, level, content, summarized_count
+		FROM document_summaries
+		WHERE document_id = $1
+		ORDER BY node_index
+	`, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*repository.DocumentSummary
+	for rows.Next() {
+		var summary repository.DocumentSummary
+		if err := rows.Scan(&summary.ID, &summary.DocumentID, &summary.NodeIndex, &summary.Level, &summary.Content, &summary.SummarizedCount); err != nil {
+			return nil, fmt.Errorf("failed to scan summary: %w", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get summaries: %w", err)
+	}
+	return summaries, nil
+}
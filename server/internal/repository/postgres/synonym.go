@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// SynonymRepo implements repository.SynonymRepository
+type SynonymRepo struct {
+	db *DB
+}
+
+// NewSynonymRepo creates a new synonym dictionary repository
+func NewSynonymRepo(db *DB) *SynonymRepo {
+	return &SynonymRepo{db: db}
+}
+
+// Set creates or replaces the entry of a term
+func (r *SynonymRepo) Set(ctx context.Context, s *repository.Synonym) error {
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		INSERT INTO synonyms (tenant_id, term, synonyms)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, term) DO UPDATE SET synonyms = EXCLUDED.synonyms, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, s.TenantID, s.Term, s.Synonyms).Scan(&s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set synonyms: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves the entry of a term
+func (r *SynonymRepo) Get(ctx context.Context, tenantID uuid.UUID, term string) (*repository.Synonym, error) {
+	s := repository.Synonym{TenantID: tenantID, Term: term}
+	err := r.db.conn(ctx).QueryRow(ctx, `
+		SELECT synonyms, created_at, updated_at
+		FROM synonyms
+		WHERE tenant_id = $1 AND term = $2
+	`, tenantID, term).Scan(&s.Synonyms, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get synonyms: %w", err)
+	}
+	return &s, nil
+}
+
+// Delete deletes the entry of a term
+func (r *SynonymRepo) Delete(ctx context.Context, tenantID uuid.UUID, term string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM synonyms WHERE tenant_id = $1 AND term = $2`, tenantID, term)
+	if err != nil {
+		return fmt.Errorf("failed to delete synonyms: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves a tenant's entries by term, with pagination
+func (r *SynonymRepo) List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*repository.Synonym, int, error) {
+	var total int
+	err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM synonyms WHERE tenant_id = $1`, tenantID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count synonyms: %w", err)
+	}
+
+	rows, err := r.db.conn(ctx).Query(ctx, `
+		SELECT term, synonyms, created_at, updated_at
+		FROM synonyms
+		WHERE tenant_id = $1
+		ORDER BY term
+		LIMIT $2 OFFSET $3
+	`, tenantID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list synonyms: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*repository.Synonym
+	for rows.Next() {
+		s := repository.Synonym{TenantID: tenantID}
+		if err := rows.Scan(&s.Term, &s.Synonyms, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan synonyms: %w", err)
+		}
+		entries = append(entries, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate synonyms: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// Ensure SynonymRepo implements the interface
+var _ repository.SynonymRepository = (*SynonymRepo)(nil)
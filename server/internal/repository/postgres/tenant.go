@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -29,11 +30,14 @@ func (r *TenantRepo) Create(ctx context.Context, tenant *repository.Tenant) erro
 	}
 
 	query := `
-		INSERT INTO tenants (id, name, api_key, config, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO tenants (id, name, api_key, config, created_at, updated_at, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
-	_, err = r.db.Pool.Exec(ctx, query,
-		tenant.ID, tenant.Name, tenant.APIKey, configJSON, tenant.CreatedAt, tenant.UpdatedAt)
+	if tenant.Status == "" {
+		tenant.Status = repository.TenantActive
+	}
+	_, err = r.db.conn(ctx).Exec(ctx, query,
+		tenant.ID, tenant.Name, tenant.APIKey, configJSON, tenant.CreatedAt, tenant.UpdatedAt, tenant.Status)
 	if err != nil {
 		return fmt.Errorf("failed to create tenant: %w", err)
 	}
@@ -43,7 +47,7 @@ func (r *TenantRepo) Create(ctx context.Context, tenant *repository.Tenant) erro
 // GetByID retrieves a tenant by ID
 func (r *TenantRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Tenant, error) {
 	query := `
-		SELECT id, name, api_key, config, created_at, updated_at
+		SELECT id, name, api_key, config, created_at, updated_at, status, status_reason, status_changed_at
 		FROM tenants
 		WHERE id = $1
 	`
@@ -53,7 +57,7 @@ func (r *TenantRepo) GetByID(ctx context.Context, id uuid.UUID) (*repository.Ten
 // GetByAPIKey retrieves a tenant by API key
 func (r *TenantRepo) GetByAPIKey(ctx context.Context, apiKey string) (*repository.Tenant, error) {
 	query := `
-		SELECT id, name, api_key, config, created_at, updated_at
+		SELECT id, name, api_key, config, created_at, updated_at, status, status_reason, status_changed_at
 		FROM tenants
 		WHERE api_key = $1
 	`
@@ -64,9 +68,10 @@ func (r *TenantRepo) scanTenant(ctx context.Context, query string, args ...any)
 	var tenant repository.Tenant
 	var configJSON []byte
 
-	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err := r.db.conn(ctx).QueryRow(ctx, query, args...).Scan(
 		&tenant.ID, &tenant.Name, &tenant.APIKey, &configJSON,
 		&tenant.CreatedAt, &tenant.UpdatedAt,
+		&tenant.Status, &tenant.StatusReason, &tenant.StatusChangedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -93,7 +98,7 @@ func (r *TenantRepo) getUsage(ctx context.Context, tenantID uuid.UUID) (*reposit
 	var usage repository.TenantUsage
 
 	// Count documents
-	err := r.db.Pool.QueryRow(ctx, `
+	err := r.db.conn(ctx).QueryRow(ctx, `
 		SELECT COUNT(*) FROM documents WHERE tenant_id = $1
 	`, tenantID).Scan(&usage.DocumentCount)
 	if err != nil {
@@ -101,13 +106,21 @@ func (r *TenantRepo) getUsage(ctx context.Context, tenantID uuid.UUID) (*reposit
 	}
 
 	// Count chunks
-	err = r.db.Pool.QueryRow(ctx, `
+	err = r.db.conn(ctx).QueryRow(ctx, `
 		SELECT COALESCE(SUM(chunk_count), 0) FROM documents WHERE tenant_id = $1
 	`, tenantID).Scan(&usage.ChunkCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count chunks: %w", err)
 	}
 
+	// Queries of the current month; earlier months are kept as history
+	err = r.db.conn(ctx).QueryRow(ctx, `
+		SELECT COALESCE(SUM(query_count), 0) FROM tenant_query_usage WHERE tenant_id = $1 AND month = $2
+	`, tenantID, repository.QueryMonth(time.Now())).Scan(&usage.QueryCountMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count queries: %w", err)
+	}
+
 	return &usage, nil
 }
 
@@ -115,18 +128,18 @@ func (r *TenantRepo) getUsage(ctx context.Context, tenantID uuid.UUID) (*reposit
 func (r *TenantRepo) List(ctx context.Context, limit, offset int) ([]*repository.Tenant, int, error) {
 	// Get total count
 	var total int
-	err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM tenants`).Scan(&total)
+	err := r.db.conn(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM tenants`).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count tenants: %w", err)
 	}
 
 	query := `
-		SELECT id, name, api_key, config, created_at, updated_at
+		SELECT id, name, api_key, config, created_at, updated_at, status, status_reason, status_changed_at
 		FROM tenants
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`
-	rows, err := r.db.Pool.Query(ctx, query, limit, offset)
+	rows, err := r.db.conn(ctx).Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list tenants: %w", err)
 	}
@@ -137,7 +150,8 @@ func (r *TenantRepo) List(ctx context.Context, limit, offset int) ([]*repository
 		var tenant repository.Tenant
 		var configJSON []byte
 		if err := rows.Scan(&tenant.ID, &tenant.Name, &tenant.APIKey, &configJSON,
-			&tenant.CreatedAt, &tenant.UpdatedAt); err != nil {
+			&tenant.CreatedAt, &tenant.UpdatedAt,
+			&tenant.Status, &tenant.StatusReason, &tenant.StatusChangedAt); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan tenant: %w", err)
 		}
 		if err := json.Unmarshal(configJSON, &tenant.Config); err != nil {
@@ -161,7 +175,7 @@ func (r *TenantRepo) Update(ctx context.Context, tenant *repository.Tenant) erro
 		SET name = $2, config = $3, updated_at = NOW()
 		WHERE id = $1
 	`
-	result, err := r.db.Pool.Exec(ctx, query, tenant.ID, tenant.Name, configJSON)
+	result, err := r.db.conn(ctx).Exec(ctx, query, tenant.ID, tenant.Name, configJSON)
 	if err != nil {
 		return fmt.Errorf("failed to update tenant: %w", err)
 	}
@@ -173,7 +187,7 @@ func (r *TenantRepo) Update(ctx context.Context, tenant *repository.Tenant) erro
 
 // Delete deletes a tenant
 func (r *TenantRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.Pool.Exec(ctx, `DELETE FROM tenants WHERE id = $1`, id)
+	result, err := r.db.conn(ctx).Exec(ctx, `DELETE FROM tenants WHERE id = $1`, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete tenant: %w", err)
 	}
@@ -185,7 +199,7 @@ func (r *TenantRepo) Delete(ctx context.Context, id uuid.UUID) error {
 
 // UpdateAPIKey updates a tenant's API key
 func (r *TenantRepo) UpdateAPIKey(ctx context.Context, id uuid.UUID, newAPIKey string) error {
-	result, err := r.db.Pool.Exec(ctx,
+	result, err := r.db.conn(ctx).Exec(ctx,
 		`UPDATE tenants SET api_key = $2, updated_at = NOW() WHERE id = $1`,
 		id, newAPIKey)
 	if err != nil {
@@ -199,8 +213,24 @@ func (r *TenantRepo) UpdateAPIKey(ctx context.Context, id uuid.UUID, newAPIKey s
 
 // UpdateUsage updates tenant usage statistics (called periodically)
 func (r *TenantRepo) UpdateUsage(ctx context.Context, id uuid.UUID, usage repository.TenantUsage) error {
-	// Usage is calculated on-the-fly from documents table, so this is a no-op
-	// Could be used for caching or storing query counts
+	// Usage is calculated on-the-fly from the documents and tenant_query_usage
+	// tables, so this is a no-op; queries are counted by QueryUsageRepo.Add
+	return nil
+}
+
+// UpdateStatus sets a tenant's lifecycle status
+func (r *TenantRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status, reason string) error {
+	result, err := r.db.conn(ctx).Exec(ctx, `
+		UPDATE tenants
+		SET status = $2, status_reason = $3, status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id, status, reason)
+	if err != nil {
+		return fmt.Errorf("failed to update tenant status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
 	return nil
 }
 
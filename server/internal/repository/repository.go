@@ -12,6 +12,12 @@ import (
 // ErrNotFound is returned when a requested entity does not exist
 var ErrNotFound = errors.New("not found")
 
+// ErrAlreadyExists is returned when an entity conflicts with a unique name
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrProjectNotEmpty is returned when deleting a project that has documents
+var ErrProjectNotEmpty = errors.New("project has documents")
+
 // Tenant represents a tenant in the system
 type Tenant struct {
 	ID        uuid.UUID
@@ -21,25 +27,203 @@ type Tenant struct {
 	Usage     TenantUsage
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Lifecycle status; empty is TenantActive
+	Status          string
+	StatusReason    string     // why an admin set the status, shown in rejected requests' errors
+	StatusChangedAt *time.Time // nil until the status first changes
 }
 
+// Tenant lifecycle statuses. Data is kept in every status.
+const (
+	TenantActive    = "ACTIVE"    // every request is allowed
+	TenantReadOnly  = "READ_ONLY" // queries and reads are allowed, ingestion and changes are not
+	TenantSuspended = "SUSPENDED" // every request is rejected, e.g. for nonpayment
+	TenantArchived  = "ARCHIVED"  // every request is rejected; the tenant is retired
+)
+
+// TenantStatuses lists the valid tenant statuses
+var TenantStatuses = []string{TenantActive, TenantReadOnly, TenantSuspended, TenantArchived}
+
 // TenantConfig holds tenant-specific configuration
 type TenantConfig struct {
-	EmbeddingModel   string        `json:"embedding_model"`
-	LLMModel         string        `json:"llm_model"`
-	Chunker          ChunkerConfig `json:"chunker"`
-	TopK             int           `json:"top_k"`
-	MinScore         float32       `json:"min_score"`
-	SystemPrompt     string        `json:"system_prompt"`
-	RerankerEnabled  bool          `json:"reranker_enabled"`  // Enable LLM-based reranking (slower but more accurate)
+	EmbeddingModel     string        `json:"embedding_model"`
+	EmbeddingDimension int           `json:"embedding_dimension,omitempty"` // Vector size of the tenant's collection (0 for tenants created before it was stored)
+	LLMModel           string        `json:"llm_model"`
+	LLMFallbackModels  []string      `json:"llm_fallback_models,omitempty"` // Tried in order when LLMModel's provider fails
+	Chunker            ChunkerConfig `json:"chunker"`
+	TopK               int           `json:"top_k"`
+	MinScore           float32       `json:"min_score"`
+	SystemPrompt       string        `json:"system_prompt"`
+	RerankerEnabled    bool          `json:"reranker_enabled"`        // Enable reranking with Rerank.Provider (slower but more accurate)
+	StoreContent       *bool         `json:"store_content,omitempty"` // Keep original document content; nil uses the server default
+
+	PromptTemplateVersion int    `json:"prompt_template_version,omitempty"` // Active prompt template; 0 uses the built-in layout
+	Language              string `json:"language,omitempty"`                // ISO 639-1 answer language; empty detects it from the query
+
+	VectorStorage VectorStorageConfig `json:"vector_storage,omitempty"`
+	ScoreBoost    ScoreBoostConfig    `json:"score_boost,omitempty"`
+	NoAnswer      NoAnswerConfig      `json:"no_answer,omitempty"`
+	Guardrails    GuardrailConfig     `json:"guardrails,omitempty"`
+	PII           PIIConfig           `json:"pii,omitempty"`
+	History       HistoryConfig       `json:"history,omitempty"`
+	Agent         AgentConfig         `json:"agent,omitempty"`
+	Tools         []ToolDefinition    `json:"tools,omitempty"`
+
+	DedupChunks    bool                `json:"dedup_chunks,omitempty"` // Identical chunks share one vector
+	NearDuplicates NearDuplicateConfig `json:"near_duplicates,omitempty"`
+	Headless       HeadlessConfig      `json:"headless,omitempty"`
+	Rerank         RerankConfig        `json:"rerank,omitempty"`
+
+	DuplicateResults DuplicateResultConfig `json:"duplicate_results,omitempty"`
+	KnowledgeGraph   KnowledgeGraphConfig  `json:"knowledge_graph,omitempty"`
+	SummaryIndex     SummaryIndexConfig    `json:"summary_index,omitempty"`
+	TextAnalysis     TextAnalysisConfig    `json:"text_analysis,omitempty"`
+}
+
+// TextAnalysisConfig controls how the text of a tenant's documents and
+// queries is split into terms for sparse vectors
+type TextAnalysisConfig struct {
+	Language        string   `json:"language,omitempty"`         // ISO 639-1 language the rules are for; empty analyzes any language alike
+	RemoveStopwords bool     `json:"remove_stopwords,omitempty"` // drop the language's stopwords
+	Stopwords       []string `json:"stopwords,omitempty"`        // dropped in addition to the language's
+	Stemming        bool     `json:"stemming,omitempty"`         // reduce words to stems (en, de)
+	CaseSensitive   bool     `json:"case_sensitive,omitempty"`   // keep letter case instead of lowercasing
+}
+
+// SummaryIndexConfig controls the trees of chunk summaries stored beside a
+// tenant's chunks, which broad questions retrieve
+type SummaryIndexConfig struct {
+	Enabled     bool `json:"enabled,omitempty"`      // build trees for documents ingested from now on
+	ClusterSize int  `json:"cluster_size,omitempty"` // nodes summarized together; 0 uses the server default
+	MaxLevels   int  `json:"max_levels,omitempty"`   // levels of summaries; 0 uses the server default
+}
+
+// KnowledgeGraphConfig controls extraction of entities and relations from a
+// tenant's chunks, and how graph-strategy queries expand through them
+type KnowledgeGraphConfig struct {
+	Enabled          bool `json:"enabled,omitempty"`            // extract a graph from documents ingested from now on
+	MaxRelations     int  `json:"max_relations,omitempty"`      // relations a graph query is expanded with; 0 uses the default
+	MaxQueryEntities int  `json:"max_query_entities,omitempty"` // entities of the query expanded from; 0 uses the default
+}
+
+// Similarity measures for DuplicateResultConfig
+const (
+	DuplicateResultsJaccard   = ""          // word-set overlap
+	DuplicateResultsEmbedding = "embedding" // cosine similarity of chunk vectors
+	DuplicateResultsOff       = "off"
+)
+
+// DuplicateResultConfig controls how retrieved chunks repeating a
+// higher-ranked one are found and dropped before prompting
+type DuplicateResultConfig struct {
+	Method    string  `json:"method,omitempty"`    // one of the DuplicateResults constants
+	Threshold float64 `json:"threshold,omitempty"` // similarity at which chunks are duplicates; 0 uses the method's default
+}
+
+// RerankConfig calibrates reranker scores and drops chunks below a relevance threshold
+type RerankConfig struct {
+	Provider      string  `json:"provider,omitempty"`       // "" uses the server default
+	Calibration   string  `json:"calibration,omitempty"`    // "", min_max or sigmoid
+	SigmoidCenter float64 `json:"sigmoid_center,omitempty"` // raw score mapped to 0.5
+	SigmoidScale  float64 `json:"sigmoid_scale,omitempty"`  // 0 uses 1
+	Threshold     float64 `json:"threshold,omitempty"`      // calibrated score below which chunks are dropped; 0 keeps all
+}
+
+// HeadlessConfig controls headless-browser rendering of a tenant's URL ingestion
+type HeadlessConfig struct {
+	Enabled        bool `json:"enabled,omitempty"`
+	TimeoutSeconds int  `json:"timeout_seconds,omitempty"` // 0 uses 30
+}
+
+// Near-duplicate modes for NearDuplicateConfig
+const (
+	NearDuplicateOff  = ""
+	NearDuplicateFlag = "flag" // ingest, recording the document it duplicates
+	NearDuplicateSkip = "skip" // fail without indexing
+)
+
+// NearDuplicateConfig controls detection of documents nearly identical to
+// one already ingested
+type NearDuplicateConfig struct {
+	Mode        string `json:"mode,omitempty"`         // one of the NearDuplicate constants
+	MaxDistance int    `json:"max_distance,omitempty"` // SimHash bits that may differ; 0 uses 3
+}
+
+// ToolDefinition describes an action the LLM may ask a tenant's client app to take
+type ToolDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  string `json:"parameters,omitempty"` // JSON schema of the arguments, an object
+}
+
+// AgentConfig bounds agentic queries
+type AgentConfig struct {
+	MaxSteps int `json:"max_steps,omitempty"` // retrieval tool calls before answering; 0 uses 3
+}
+
+// HistoryConfig controls how much of a session's conversation a prompt includes
+type HistoryConfig struct {
+	Window    int  `json:"window,omitempty"`    // most recent messages; 0 uses 10
+	Summarize bool `json:"summarize,omitempty"` // fold older messages into an LLM summary
+}
+
+// PIIConfig controls PII detection in ingested chunks
+type PIIConfig struct {
+	Mode  string   `json:"mode,omitempty"`  // "" (off), tag, redact
+	Kinds []string `json:"kinds,omitempty"` // email, phone, ssn, credit_card; empty detects all
+}
+
+// GuardrailConfig names the built-in guardrail filters run at each stage of a query
+type GuardrailConfig struct {
+	Input          []string `json:"input,omitempty"`   // run on the query
+	Context        []string `json:"context,omitempty"` // run on each retrieved chunk
+	Output         []string `json:"output,omitempty"`  // run on the answer
+	BannedTopics   []string `json:"banned_topics,omitempty"`
+	BlockedMessage string   `json:"blocked_message,omitempty"` // empty uses a localized default
+}
+
+// NoAnswerConfig controls queries that retrieval finds no relevant context for
+type NoAnswerConfig struct {
+	MinTopScore float32 `json:"min_top_score,omitempty"` // best score below this counts as no context
+	SkipLLM     bool    `json:"skip_llm,omitempty"`      // answer with Message instead of calling the LLM
+	Message     string  `json:"message,omitempty"`       // empty uses a localized default
+}
+
+// ScoreBoostConfig re-scores retrieval results by document age and priority
+type ScoreBoostConfig struct {
+	RecencyHalfLifeDays float64 `json:"recency_half_life_days,omitempty"` // 0 disables recency decay
+	RecencyFloor        float64 `json:"recency_floor,omitempty"`          // share of the score that never decays
+	DateField           string  `json:"date_field,omitempty"`             // default published_at, then ingested_at
+	PriorityField       string  `json:"priority_field,omitempty"`         // numeric metadata key
+	PriorityWeight      float64 `json:"priority_weight,omitempty"`
+	DepthWeight         float64 `json:"depth_weight,omitempty"`       // favors crawled pages near the root
+	PreferredLanguage   string  `json:"preferred_language,omitempty"` // ISO 639-1
+	LanguageWeight      float64 `json:"language_weight,omitempty"`    // penalizes chunks in other languages
+}
+
+// VectorStorageConfig holds vector quantization and on-disk storage options for a tenant's collection
+type VectorStorageConfig struct {
+	Quantization  string `json:"quantization,omitempty"` // "", scalar, binary
+	OnDiskVectors bool   `json:"on_disk_vectors,omitempty"`
+	OnDiskPayload bool   `json:"on_disk_payload,omitempty"`
 }
 
 // ChunkerConfig holds chunking configuration
 type ChunkerConfig struct {
-	Method     string `json:"method"`      // semantic, fixed, sentence
-	TargetSize int    `json:"target_size"` // target tokens per chunk
-	MaxSize    int    `json:"max_size"`    // max tokens per chunk
-	Overlap    int    `json:"overlap"`     // overlap tokens
+	Method       string `json:"method"`                   // semantic, fixed, sentence, tabular
+	TargetSize   int    `json:"target_size"`              // target tokens per chunk
+	MaxSize      int    `json:"max_size"`                 // max tokens per chunk
+	Overlap      int    `json:"overlap"`                  // overlap tokens
+	RowsPerChunk int    `json:"rows_per_chunk,omitempty"` // rows per chunk for tabular content
+
+	Language      string   `json:"language,omitempty"`      // ISO 639-1 language whose abbreviations don't end sentences; empty is English
+	Abbreviations []string `json:"abbreviations,omitempty"` // more abbreviations that don't end sentences
+
+	OverlapPercent int    `json:"overlap_percent,omitempty"` // overlap as a percentage of target size, instead of Overlap
+	TableFormat    string `json:"table_format,omitempty"`    // markdown or key_value rendering of tables in semantic chunks; empty is markdown
+
+	ContextTemplate string `json:"context_template,omitempty"` // {{.field}} template over chunk metadata prepended to each chunk, replacing the "[Section: ...]" prefix
 }
 
 // TenantUsage holds tenant usage statistics
@@ -49,19 +233,157 @@ type TenantUsage struct {
 	QueryCountMonth int64 `json:"query_count_month"`
 }
 
+// QueryUsage is a tenant's query count in a calendar month
+type QueryUsage struct {
+	TenantID   uuid.UUID
+	Month      time.Time // first day of the month, UTC; see QueryMonth
+	QueryCount int64
+}
+
+// QueryMonth returns the month query counts of time t are kept under: its
+// first day, at midnight UTC
+func QueryMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
 // Document represents an ingested document
 type Document struct {
-	ID           uuid.UUID
-	TenantID     uuid.UUID
-	Source       string
-	Title        string
-	ContentHash  string
-	ChunkCount   int
-	Status       string
-	ErrorMessage string
-	Metadata     map[string]string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	Source        string
+	SourceKey     string // canonical URL without its scheme, for web documents
+	ETag          string // validators of the last fetch of a web document
+	LastModified  string
+	Title         string
+	ContentHash   string
+	ChunkCount    int
+	Status        string
+	ErrorMessage  string
+	Metadata      map[string]string
+	Tags          []string
+	CollectionIDs []uuid.UUID
+	Chunker       *ChunkerConfig // overrides the tenant's non-zero chunker settings; nil for none
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	SimHash         uint64     // fingerprint of the document's text; 0 when not computed
+	NearDuplicateOf *uuid.UUID // the earlier document this one was flagged as a near-duplicate of
+
+	ProjectID *uuid.UUID // the project the document belongs to; nil for the whole tenant
+}
+
+// NearDuplicate is a document found to nearly match another's text
+type NearDuplicate struct {
+	DocumentID uuid.UUID
+	Distance   int // bits the SimHashes differ in
+}
+
+// Document sort fields for DocumentFilter
+const (
+	DocumentSortCreatedAt = "created_at"
+	DocumentSortUpdatedAt = "updated_at"
+	DocumentSortTitle     = "title"
+	DocumentSortSource    = "source"
+)
+
+// DocumentFilter selects and orders documents when listing. Zero fields
+// don't filter; the default order is newest first.
+type DocumentFilter struct {
+	Status        string
+	SourcePrefix  string
+	SourceGlob    string // * matches any run of characters, ? a single one
+	TitleContains string // case-insensitive
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Metadata      map[string]string // all pairs must be present
+	Tags          []string          // at least one must be present
+	CollectionID  uuid.UUID         // uuid.Nil for any
+	ProjectID     uuid.UUID         // uuid.Nil for any
+
+	SortBy    string // one of the DocumentSort constants; default DocumentSortCreatedAt
+	Ascending bool
+}
+
+// DocumentContent is the original content a document was ingested from
+type DocumentContent struct {
+	DocumentID  uuid.UUID
+	ContentType string // MIME type; text/plain for ingested text, text/html for fetched URLs
+	Filename    string // set for uploaded files
+	Data        []byte
+	CreatedAt   time.Time
+}
+
+// IngestionCheckpoint records how far a document's processing got after its
+// chunks were committed, so an interrupted run resumes embedding at
+// ChunksEmbedded instead of starting over
+type IngestionCheckpoint struct {
+	DocumentID     uuid.UUID
+	ChunksTotal    int
+	ChunksEmbedded int // Chunks with a vector of their own, in index order, whose vectors are stored
+	UpdatedAt      time.Time
+}
+
+// DocumentSummary is a node of a document's summary tree, stored so a
+// reindex can re-embed it
+type DocumentSummary struct {
+	ID              uuid.UUID
+	DocumentID      uuid.UUID
+	NodeIndex       int
+	Level           int
+	Content         string
+	SummarizedCount int
+}
+
+// Collection is a named group of a tenant's documents
+type Collection struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	Name          string
+	Description   string
+	DocumentCount int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// PromptTemplate is one version of a tenant's prompt template
+type PromptTemplate struct {
+	TenantID    uuid.UUID
+	Version     int
+	Template    string
+	Description string
+	CreatedAt   time.Time
+}
+
+// Synonym is an entry of a tenant's synonym dictionary
+type Synonym struct {
+	TenantID  uuid.UUID
+	Term      string   // normalized; see synonym.Normalize
+	Synonyms  []string // terms a query containing Term also matches
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Project is a workspace of a tenant, between the tenant and its documents.
+// A project has its own documents, API keys, feeds and crawls, and retrieval
+// settings over the tenant's; usage and billing stay with the tenant.
+type Project struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Name      string
+	Retrieval ProjectRetrievalConfig
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProjectRetrievalConfig overrides the tenant's retrieval settings for a
+// project's queries. Zero fields keep the tenant's.
+type ProjectRetrievalConfig struct {
+	TopK            int     `json:"top_k,omitempty"`
+	MinScore        float32 `json:"min_score,omitempty"`
+	SystemPrompt    string  `json:"system_prompt,omitempty"`
+	LLMModel        string  `json:"llm_model,omitempty"`
+	RerankerEnabled *bool   `json:"reranker_enabled,omitempty"`
 }
 
 // DocumentChunk represents a chunk of a document
@@ -71,7 +393,48 @@ type DocumentChunk struct {
 	ChunkIndex  int
 	Content     string
 	Metadata    map[string]string
+	ParentStart int // first chunk index of the enclosing parent section
+	ParentEnd   int // last chunk index of the enclosing parent section (inclusive)
 	CreatedAt   time.Time
+
+	// SearchConfig is the Postgres text search configuration keyword search
+	// indexes the chunk with, such as "english"; "" for "simple"
+	SearchConfig string
+
+	// Set for tenants that deduplicate chunks
+	ContentHash   string
+	VectorChunkID uuid.UUID // the identical chunk whose vector stands for this one; uuid.Nil when it has its own
+}
+
+// SharedVector is a chunk's vector that chunks of other documents share,
+// with the tags and collections of every document sharing it
+type SharedVector struct {
+	ChunkID       uuid.UUID
+	Tags          []string
+	CollectionIDs []uuid.UUID
+}
+
+// ChunkFilter restricts keyword search to some of a tenant's documents
+type ChunkFilter struct {
+	Tags          []string    // documents with at least one of these tags
+	CollectionIDs []uuid.UUID // documents in at least one of these collections
+	Languages     []string    // chunks whose "language" metadata is one of these
+	ProjectID     uuid.UUID   // documents of this project; uuid.Nil for any
+}
+
+// ChunkMatch is a chunk found by keyword search, with the fields of its
+// document that retrieval results carry
+type ChunkMatch struct {
+	Chunk  *DocumentChunk
+	Title  string
+	Source string
+	Rank   float32 // ts_rank_cd relevance; higher is better, not bounded to 1
+}
+
+// DocumentStats holds system-wide document counts
+type DocumentStats struct {
+	ByStatus   map[string]int
+	ChunkCount int
 }
 
 // CrawlJob represents a web crawling job
@@ -85,10 +448,13 @@ type CrawlJob struct {
 	PagesCrawled int
 	PagesTotal   int
 	PagesFailed  int
+	FrontierSize int // pages queued but not yet crawled
 	ErrorMessage string
 	CreatedAt    time.Time
 	StartedAt    *time.Time
 	CompletedAt  *time.Time
+
+	ProjectID *uuid.UUID // project crawled pages are ingested into; nil for the whole tenant
 }
 
 // SpiderConfig holds spider configuration
@@ -117,9 +483,65 @@ type CrawledPage struct {
 	DocumentID    *uuid.UUID
 	ContentLength int
 	Depth         int
+	ParentURL     string // page the URL was discovered on; empty for the root
+	AnchorText    string // text of the link it was discovered through
 	CrawledAt     *time.Time
 }
 
+// ReindexJob tracks re-embedding of a tenant's chunks into a new vector collection
+type ReindexJob struct {
+	ID                uuid.UUID
+	TenantID          uuid.UUID
+	Status            string
+	EmbeddingModel    string
+	Dimension         int
+	CollectionVersion string // suffix of the collection being built
+	ChunksTotal       int
+	ChunksDone        int
+	ErrorMessage      string
+	CreatedAt         time.Time
+	StartedAt         *time.Time
+	CompletedAt       *time.Time
+}
+
+// Feed represents an RSS/Atom feed polled for a tenant
+type Feed struct {
+	ID                  uuid.UUID
+	TenantID            uuid.UUID
+	URL                 string
+	Title               string
+	PollIntervalSeconds int
+	Metadata            map[string]string // added to every ingested document
+	LastPolledAt        *time.Time
+	LastItemAt          *time.Time // newest item publication date seen
+	LastError           string
+	ItemCount           int
+	CreatedAt           time.Time
+
+	ProjectID *uuid.UUID // project items are ingested into; nil for the whole tenant
+}
+
+// APIKey is an additional tenant API key limited to a set of scopes
+type APIKey struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Name      string
+	Key       string
+	Scopes    []string // read, ingest, admin
+	CreatedAt time.Time
+
+	ProjectID *uuid.UUID // limits the key to a project; nil for the whole tenant
+}
+
+// FeedItem records a feed entry that has already been ingested
+type FeedItem struct {
+	FeedID      uuid.UUID
+	GUID        string
+	DocumentID  *uuid.UUID
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+}
+
 // TenantRepository defines operations for tenant persistence
 type TenantRepository interface {
 	Create(ctx context.Context, tenant *Tenant) error
@@ -130,6 +552,73 @@ type TenantRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateAPIKey(ctx context.Context, id uuid.UUID, newAPIKey string) error
 	UpdateUsage(ctx context.Context, id uuid.UUID, usage TenantUsage) error
+	// UpdateStatus sets a tenant's lifecycle status and reason, and when it changed
+	UpdateStatus(ctx context.Context, id uuid.UUID, status, reason string) error
+}
+
+// APIKeyRepository defines operations for scoped API key persistence
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	GetByKey(ctx context.Context, apiKey string) (*APIKey, error)
+	List(ctx context.Context, tenantID uuid.UUID) ([]*APIKey, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CollectionRepository defines operations for collection persistence
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *Collection) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Collection, error)
+	List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*Collection, int, error)
+	Update(ctx context.Context, collection *Collection) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Membership operations
+	AddDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error
+	RemoveDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error
+	DocumentIDs(ctx context.Context, collectionID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// PromptTemplateRepository defines operations for prompt template persistence.
+// Versions are immutable once created.
+type PromptTemplateRepository interface {
+	// Create stores a template as the tenant's next version, setting its
+	// Version and CreatedAt
+	Create(ctx context.Context, t *PromptTemplate) error
+	Get(ctx context.Context, tenantID uuid.UUID, version int) (*PromptTemplate, error)
+	// List returns a tenant's versions, newest first
+	List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*PromptTemplate, int, error)
+}
+
+// SynonymRepository defines operations for synonym dictionary persistence
+type SynonymRepository interface {
+	// Set creates or replaces the entry of a term, setting its timestamps
+	Set(ctx context.Context, s *Synonym) error
+	Get(ctx context.Context, tenantID uuid.UUID, term string) (*Synonym, error)
+	Delete(ctx context.Context, tenantID uuid.UUID, term string) error
+	// List returns a tenant's entries by term
+	List(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*Synonym, int, error)
+}
+
+// ProjectRepository defines operations for project persistence
+type ProjectRepository interface {
+	Create(ctx context.Context, project *Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Project, error)
+	// List returns a tenant's projects by name
+	List(ctx context.Context, tenantID uuid.UUID) ([]*Project, error)
+	Update(ctx context.Context, project *Project) error
+	// Delete returns ErrProjectNotEmpty while the project has documents
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// QueryUsageRepository defines operations for tenants' monthly query counts
+type QueryUsageRepository interface {
+	// Add adds counts to tenants' counts of their months
+	Add(ctx context.Context, usage []QueryUsage) error
+	// ListSince returns the counts of months from since's on, by month then tenant
+	ListSince(ctx context.Context, since time.Time) ([]*QueryUsage, error)
+	// DeleteBefore deletes the counts of months before month's, returning how many
+	DeleteBefore(ctx context.Context, month time.Time) (int64, error)
 }
 
 // DocumentRepository defines operations for document persistence
@@ -137,25 +626,153 @@ type DocumentRepository interface {
 	Create(ctx context.Context, doc *Document) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Document, error)
 	GetByHash(ctx context.Context, tenantID uuid.UUID, hash string) (*Document, error)
-	List(ctx context.Context, tenantID uuid.UUID, status string, limit, offset int) ([]*Document, int, error)
+	// GetBySourceKey looks in a project's documents, or the tenant-wide ones for a nil projectID
+	GetBySourceKey(ctx context.Context, tenantID uuid.UUID, projectID *uuid.UUID, key string) (*Document, error)
+	List(ctx context.Context, tenantID uuid.UUID, filter DocumentFilter, limit, offset int) ([]*Document, int, error)
 	Update(ctx context.Context, doc *Document) error
+	// UpdateIfStatus updates a document only if its stored status is still
+	// expected, and reports whether it did, so concurrent callers claim it once
+	UpdateIfStatus(ctx context.Context, doc *Document, expected string) (bool, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// FindNearDuplicate returns ErrNotFound when no ready document of the
+	// project, or tenant-wide one for a nil projectID, is within maxDistance
+	FindNearDuplicate(ctx context.Context, tenantID uuid.UUID, projectID *uuid.UUID, simHash uint64, maxDistance int, excludeID uuid.UUID) (*NearDuplicate, error)
+
 	// Chunk operations
 	CreateChunks(ctx context.Context, chunks []*DocumentChunk) error
 	GetChunks(ctx context.Context, documentID uuid.UUID, limit, offset int) ([]*DocumentChunk, error)
+	GetChunkRange(ctx context.Context, documentID uuid.UUID, startIndex, endIndex int) ([]*DocumentChunk, error)
+	GetChunkWindow(ctx context.Context, documentID uuid.UUID, chunkIndex, before, after int) ([]*DocumentChunk, error)
+	SearchChunks(ctx context.Context, tenantID uuid.UUID, query, searchConfig string, filter ChunkFilter, limit int) ([]*ChunkMatch, error)
+	SetSearchConfig(ctx context.Context, tenantID uuid.UUID, searchConfig string) error
+
+	// Tag operations
+	AddTags(ctx context.Context, documentID uuid.UUID, tags []string) error
+	RemoveTags(ctx context.Context, documentID uuid.UUID, tags []string) error
+	GetTags(ctx context.Context, documentID uuid.UUID) ([]string, error)
 	DeleteChunks(ctx context.Context, documentID uuid.UUID) error
+
+	// Original content operations; GetContent returns ErrNotFound when none was stored
+	SaveContent(ctx context.Context, content *DocumentContent) error
+	GetContent(ctx context.Context, documentID uuid.UUID) (*DocumentContent, error)
+
+	// Chunk deduplication. ShareChunks counts a reference to each chunk's
+	// content hash and points the chunk at the vector of an earlier identical
+	// one, before the chunks are created. ReleaseChunks drops a document's
+	// references before its chunks are deleted, and returns chunks of other
+	// documents that took over a vector the document owned and so need it
+	// stored again, and the vectors of other documents it used.
+	// SharedChunkDocuments maps chunk IDs to the other
+	// documents whose chunks share their vectors. SharedHashes reports which
+	// content hashes have a vector owned by another document's chunk.
+	// SharedVectors lists the shared vectors a document's chunks own or use,
+	// and those of chunkIDs, with the lists their sharers filter on.
+	ShareChunks(ctx context.Context, tenantID uuid.UUID, chunks []*DocumentChunk) error
+	SharedHashes(ctx context.Context, tenantID, documentID uuid.UUID, hashes []string) (map[string]bool, error)
+	SharedVectors(ctx context.Context, documentID uuid.UUID, chunkIDs []uuid.UUID) ([]*SharedVector, error)
+	ReleaseChunks(ctx context.Context, documentID uuid.UUID) (inherited []*DocumentChunk, used []uuid.UUID, err error)
+	SharedChunkDocuments(ctx context.Context, chunkIDs []uuid.UUID) (map[uuid.UUID][]uuid.UUID, error)
+
+	// Summary tree operations. SaveSummaries replaces a document's nodes;
+	// DeleteChunks deletes them too, as they summarize the chunks.
+	SaveSummaries(ctx context.Context, documentID uuid.UUID, summaries []*DocumentSummary) error
+	GetSummaries(ctx context.Context, documentID uuid.UUID) ([]*DocumentSummary, error)
+
+	// Ingestion checkpoint operations
+	SaveCheckpoint(ctx context.Context, checkpoint *IngestionCheckpoint) error
+	ListCheckpoints(ctx context.Context) ([]*IngestionCheckpoint, error)
+	DeleteCheckpoint(ctx context.Context, documentID uuid.UUID) error
+
+	// Stats returns document and chunk counts across all tenants
+	Stats(ctx context.Context) (*DocumentStats, error)
 }
 
 // CrawlJobRepository defines operations for crawl job persistence
 type CrawlJobRepository interface {
 	Create(ctx context.Context, job *CrawlJob) error
 	GetByID(ctx context.Context, id uuid.UUID) (*CrawlJob, error)
-	List(ctx context.Context, tenantID uuid.UUID, status string, limit, offset int) ([]*CrawlJob, int, error)
+	// List returns all the tenant's jobs for a projectID of uuid.Nil
+	List(ctx context.Context, tenantID, projectID uuid.UUID, status string, limit, offset int) ([]*CrawlJob, int, error)
 	Update(ctx context.Context, job *CrawlJob) error
 
 	// Page operations
 	CreatePage(ctx context.Context, page *CrawledPage) error
 	UpdatePage(ctx context.Context, page *CrawledPage) error
 	GetPages(ctx context.Context, jobID uuid.UUID, status string, limit, offset int) ([]*CrawledPage, int, error)
+
+	// Frontier operations: pending pages are the URLs still to crawl
+	ListUnfinished(ctx context.Context) ([]*CrawlJob, error)
+	EnqueuePages(ctx context.Context, pages []*CrawledPage) (int, error)
+	NextPages(ctx context.Context, jobID uuid.UUID, limit int) ([]*CrawledPage, error)
+}
+
+// ReindexJobRepository defines operations for reindex job persistence
+type ReindexJobRepository interface {
+	// Create returns ErrAlreadyExists when the tenant has an active job
+	Create(ctx context.Context, job *ReindexJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ReindexJob, error)
+	GetActive(ctx context.Context, tenantID uuid.UUID) (*ReindexJob, error)
+	Update(ctx context.Context, job *ReindexJob) error
+	CountActive(ctx context.Context) (int, error)
+
+	// FailInterrupted marks jobs left pending or running by a previous process as failed
+	FailInterrupted(ctx context.Context) error
+}
+
+// FeedRepository defines operations for feed persistence
+type FeedRepository interface {
+	Create(ctx context.Context, feed *Feed) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Feed, error)
+	// List returns all the tenant's feeds for a projectID of uuid.Nil
+	List(ctx context.Context, tenantID, projectID uuid.UUID, limit, offset int) ([]*Feed, int, error)
+	ListDue(ctx context.Context, now time.Time) ([]*Feed, error)
+	Update(ctx context.Context, feed *Feed) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Item operations
+	HasItem(ctx context.Context, feedID uuid.UUID, guid string) (bool, error)
+	RecordItem(ctx context.Context, item *FeedItem) error
+}
+
+// GraphMention is an entity a chunk mentions
+type GraphMention struct {
+	ChunkID    uuid.UUID
+	EntityKey  string // the name as matched; see ingestion.EntityKey
+	Name       string
+	EntityType string
+}
+
+// GraphRelation is a relation between two entities, stated by a chunk
+type GraphRelation struct {
+	ChunkID   uuid.UUID // uuid.Nil when aggregated over chunks
+	SourceKey string
+	Source    string
+	Relation  string
+	TargetKey string
+	Target    string
+	Chunks    int // chunks stating it, when aggregated
+}
+
+// GraphRepository defines operations for knowledge graph persistence. A
+// document's mentions and relations are deleted with it.
+type GraphRepository interface {
+	// ReplaceDocumentGraph replaces the mentions and relations extracted from a document's chunks
+	ReplaceDocumentGraph(ctx context.Context, tenantID, documentID uuid.UUID, mentions []*GraphMention, relations []*GraphRelation) error
+
+	// FindEntities returns those of the entity keys mentioned in the tenant's
+	// documents, only the project's unless projectID is uuid.Nil
+	FindEntities(ctx context.Context, tenantID, projectID uuid.UUID, keys []string) ([]string, error)
+
+	// Relations returns up to limit relations involving the entities,
+	// aggregated over chunks, those stated most often first. Only relations
+	// from the project's documents are returned unless projectID is uuid.Nil.
+	Relations(ctx context.Context, tenantID, projectID uuid.UUID, keys []string, limit int) ([]*GraphRelation, error)
+}
+
+// UnitOfWork groups repository writes so they commit or roll back together
+type UnitOfWork interface {
+	// WithinTx runs fn in a transaction, committing when it returns nil.
+	// Repository calls must use the context passed to fn to take part.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,80 @@
+// Package requestid carries the ID of the request being served in its
+// context, from the HTTP gateway through gRPC handlers, so logs and errors
+// can quote it.
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the HTTP header carrying request IDs, in requests and responses.
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key carrying request IDs.
+const MetadataKey = "x-request-id"
+
+// maxLength is the longest request ID accepted from a client; longer ones
+// are replaced so logs cannot be flooded through the header
+const maxLength = 128
+
+type contextKey struct{}
+
+// New generates a request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// NewContext returns a context carrying the request ID id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID of ctx, or "" if it has none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// SetHeader sets the request ID of ctx, if it has one, on the header of an
+// outbound HTTP request, so services such as the embedder can log it.
+func SetHeader(ctx context.Context, h http.Header) {
+	if id := FromContext(ctx); id != "" {
+		h.Set(Header, id)
+	}
+}
+
+// FromIncoming returns the request ID of a gRPC request's metadata, as the
+// gateway or a client sent it, or a new one if it has none or an invalid one.
+func FromIncoming(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(MetadataKey); len(values) > 0 && valid(values[0]) {
+		return values[0]
+	}
+	return New()
+}
+
+// FromRequest returns the request ID of an HTTP request's header, or a new
+// one if it has none or an invalid one.
+func FromRequest(r *http.Request) string {
+	if id := r.Header.Get(Header); valid(id) {
+		return id
+	}
+	return New()
+}
+
+// valid reports whether a client's request ID is short printable ASCII
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
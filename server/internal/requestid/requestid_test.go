@@ -0,0 +1,25 @@
+package requestid
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFromIncoming(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "abc-123"))
+	if id := FromIncoming(ctx); id != "abc-123" {
+		t.Errorf("FromIncoming = %q, want the client's ID", id)
+	}
+	if id := FromIncoming(context.Background()); id == "" {
+		t.Error("FromIncoming without an ID should generate one")
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(Header, "bad id\n")
+	if id := FromRequest(r); id == "bad id\n" || id == "" {
+		t.Errorf("FromRequest = %q, want a new ID in place of an invalid one", id)
+	}
+}
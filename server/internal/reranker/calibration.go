@@ -0,0 +1,78 @@
+package reranker
+
+import (
+	"math"
+	"sort"
+)
+
+// Calibration methods.
+const (
+	// CalibrationNone keeps the reranker's scores as they are.
+	CalibrationNone = ""
+
+	// CalibrationMinMax rescales each result set so its best score is 1 and
+	// its worst 0. It suits rerankers whose scores have no fixed range, at
+	// the cost of always ranking the worst result at 0.
+	CalibrationMinMax = "min_max"
+
+	// CalibrationSigmoid maps scores through a logistic curve, turning the
+	// unbounded logits of cross-encoders into probabilities.
+	CalibrationSigmoid = "sigmoid"
+)
+
+// Calibration maps raw reranker scores onto 0-1, so that one relevance
+// threshold means the same whatever the query.
+type Calibration struct {
+	Method string
+
+	// Center is the raw score the sigmoid maps to 0.5.
+	Center float64
+
+	// Scale is how far a raw score must move from Center to reach about
+	// 0.73 (or 0.27); 0 uses 1. Smaller values sharpen the curve.
+	Scale float64
+}
+
+// Apply rewrites the results' reranker scores in place.
+func (c Calibration) Apply(results []ScoredResult) {
+	switch c.Method {
+	case CalibrationMinMax:
+		if len(results) == 0 {
+			return
+		}
+		lo, hi := results[0].RerankerScore, results[0].RerankerScore
+		for _, r := range results[1:] {
+			lo = min(lo, r.RerankerScore)
+			hi = max(hi, r.RerankerScore)
+		}
+		for i := range results {
+			if hi == lo {
+				// Nothing to tell them apart; keep them all
+				results[i].RerankerScore = 1
+				continue
+			}
+			results[i].RerankerScore = (results[i].RerankerScore - lo) / (hi - lo)
+		}
+	case CalibrationSigmoid:
+		scale := c.Scale
+		if scale <= 0 {
+			scale = 1
+		}
+		for i := range results {
+			x := (float64(results[i].RerankerScore) - c.Center) / scale
+			results[i].RerankerScore = float32(1 / (1 + math.Exp(-x)))
+		}
+	}
+}
+
+// AboveThreshold returns the results scoring at least threshold, best first,
+// reusing the slice.
+func AboveThreshold(results []ScoredResult, threshold float32) []ScoredResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RerankerScore > results[j].RerankerScore
+	})
+	n := sort.Search(len(results), func(i int) bool {
+		return results[i].RerankerScore < threshold
+	})
+	return results[:n]
+}
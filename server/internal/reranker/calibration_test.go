@@ -0,0 +1,73 @@
+package reranker
+
+import (
+	"math"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+func scored(scores ...float32) []ScoredResult {
+	results := make([]ScoredResult, len(scores))
+	for i, s := range scores {
+		results[i] = ScoredResult{
+			SearchResult:  vectorstore.SearchResult{ID: string(rune('a' + i))},
+			RerankerScore: s,
+		}
+	}
+	return results
+}
+
+func scoresOf(results []ScoredResult) []float32 {
+	scores := make([]float32, len(results))
+	for i, r := range results {
+		scores[i] = r.RerankerScore
+	}
+	return scores
+}
+
+func closeTo(got, want []float32) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(float64(got[i]-want[i])) > 1e-4 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCalibrationApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      Calibration
+		scores []float32
+		want   []float32
+	}{
+		{"none", Calibration{}, []float32{3, -1}, []float32{3, -1}},
+		{"min-max", Calibration{Method: CalibrationMinMax}, []float32{4, -2, 1}, []float32{1, 0, 0.5}},
+		{"min-max ties", Calibration{Method: CalibrationMinMax}, []float32{0.2, 0.2}, []float32{1, 1}},
+		{"sigmoid", Calibration{Method: CalibrationSigmoid}, []float32{0, 2, -2}, []float32{0.5, 0.8808, 0.1192}},
+		{"sigmoid centered", Calibration{Method: CalibrationSigmoid, Center: 0.5, Scale: 0.1}, []float32{0.5, 0.6}, []float32{0.5, 0.7311}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := scored(tt.scores...)
+			tt.c.Apply(results)
+			if got := scoresOf(results); !closeTo(got, tt.want) {
+				t.Errorf("scores = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAboveThreshold(t *testing.T) {
+	results := AboveThreshold(scored(0.2, 0.9, 0.5, 0.4), 0.4)
+	if got, want := scoresOf(results), []float32{0.9, 0.5, 0.4}; !closeTo(got, want) {
+		t.Errorf("scores = %v, want %v", got, want)
+	}
+	if results := AboveThreshold(scored(0.1, 0.2), 0.5); len(results) != 0 {
+		t.Errorf("got %d results, want none", len(results))
+	}
+}
@@ -0,0 +1,164 @@
+package reranker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/knoguchi/rag/internal/requestid"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+const (
+	// DefaultCohereBaseURL is the Cohere API endpoint.
+	DefaultCohereBaseURL = "https://api.cohere.com"
+
+	// DefaultCohereModel is the default Cohere rerank model.
+	DefaultCohereModel = "rerank-v3.5"
+)
+
+// CohereConfig holds configuration for the Cohere reranker.
+type CohereConfig struct {
+	// BaseURL is the API endpoint (default: https://api.cohere.com).
+	BaseURL string
+
+	// APIKey is the Cohere API key.
+	APIKey string
+
+	// Model is the rerank model (default: rerank-v3.5).
+	Model string
+
+	// Timeout bounds each request (default: 10 seconds).
+	Timeout time.Duration
+
+	// HTTPClient is an optional custom HTTP client.
+	HTTPClient *http.Client
+}
+
+// CohereReranker scores query-document pairs with Cohere's hosted v2 Rerank
+// API, a cross-encoder that answers in a fraction of an LLM call's time.
+// Its relevance scores are between 0 and 1.
+type CohereReranker struct {
+	baseURL string
+	apiKey  string
+	model   string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// cohereRerankRequest represents the request body for the rerank API.
+type cohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+// cohereRerankResponse represents the response from the rerank API.
+type cohereRerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float32 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// NewCohereReranker creates a new Cohere reranker with the given configuration.
+func NewCohereReranker(cfg CohereConfig) *CohereReranker {
+	r := &CohereReranker{
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		apiKey:  cfg.APIKey,
+		model:   cfg.Model,
+		timeout: cfg.Timeout,
+		client:  cfg.HTTPClient,
+	}
+	if r.baseURL == "" {
+		r.baseURL = DefaultCohereBaseURL
+	}
+	if r.model == "" {
+		r.model = DefaultCohereModel
+	}
+	if r.timeout <= 0 {
+		r.timeout = 10 * time.Second
+	}
+	if r.client == nil {
+		r.client = http.DefaultClient
+	}
+	return r
+}
+
+// Rerank asks the Rerank API for the topK results most relevant to the query.
+func (r *CohereReranker) Rerank(ctx context.Context, query string, results []vectorstore.SearchResult, topK int) ([]ScoredResult, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	if topK <= 0 || topK > len(results) {
+		topK = len(results)
+	}
+
+	documents := make([]string, len(results))
+	for i, result := range results {
+		documents[i] = result.Content
+	}
+	jsonBody, err := json.Marshal(cohereRerankRequest{
+		Model:     r.model,
+		Query:     query,
+		Documents: documents,
+		TopN:      topK,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/v2/rerank", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	requestid.SetHeader(ctx, req.Header)
+	req.Header.Set("Authorization", "Bearer "+r.apiKey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("cohere rerank failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var rerankResp cohereRerankResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rerankResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	scored := make([]ScoredResult, 0, len(rerankResp.Results))
+	for _, res := range rerankResp.Results {
+		if res.Index < 0 || res.Index >= len(results) {
+			return nil, fmt.Errorf("cohere rerank returned index %d for %d documents", res.Index, len(results))
+		}
+		scored = append(scored, ScoredResult{
+			SearchResult:  results[res.Index],
+			RerankerScore: res.RelevanceScore,
+		})
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].RerankerScore > scored[j].RerankerScore
+	})
+	if len(scored) > topK {
+		scored = scored[:topK]
+	}
+	return scored, nil
+}
+
+// Ensure CohereReranker implements Reranker interface.
+var _ Reranker = (*CohereReranker)(nil)
@@ -0,0 +1,50 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCohereReranker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/rerank" || r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req cohereRerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Model != DefaultCohereModel || req.Query != "q" || len(req.Documents) != 3 || req.TopN != 2 {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"results": [{"index": 2, "relevance_score": 0.91}, {"index": 0, "relevance_score": 0.12}]}`))
+	}))
+	defer server.Close()
+
+	r := NewCohereReranker(CohereConfig{BaseURL: server.URL, APIKey: "key"})
+	reranked, err := r.Rerank(context.Background(), "q", searchResults("a", "b", "c"), 2)
+	if err != nil {
+		t.Fatalf("Rerank: %v", err)
+	}
+	if len(reranked) != 2 || reranked[0].ID != "2" || reranked[0].RerankerScore != 0.91 || reranked[1].ID != "0" {
+		t.Errorf("Rerank = %+v, want docs 2 then 0", reranked)
+	}
+}
+
+func TestCohereRerankerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	r := NewCohereReranker(CohereConfig{BaseURL: server.URL, APIKey: "key"})
+	if _, err := r.Rerank(context.Background(), "q", searchResults("a"), 1); err == nil {
+		t.Error("Rerank succeeded on a 429")
+	}
+}
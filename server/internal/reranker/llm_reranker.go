@@ -3,9 +3,12 @@ package reranker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/knoguchi/rag/internal/llm"
 	"github.com/knoguchi/rag/internal/vectorstore"
@@ -14,9 +17,16 @@ import (
 // LLMReranker uses an LLM to re-score query-document pairs for improved relevance.
 // This implements a cross-encoder-like approach where the model sees both
 // query and document together, enabling more accurate relevance assessment.
+//
+// By default every document is scored in one prompt. With WithBatchSize,
+// documents are scored in batches of that size, several at a time, which
+// keeps prompts small when there are many or long documents.
 type LLMReranker struct {
-	llmClient llm.LLM
-	model     string
+	llmClient   llm.LLM
+	model       string
+	batchSize   int           // documents per prompt; 0 scores all in one
+	concurrency int           // batches scored at once
+	timeout     time.Duration // caps a whole Rerank call; 0 for none
 }
 
 // LLMRerankerOption is a functional option for configuring LLMReranker.
@@ -29,11 +39,37 @@ func WithModel(model string) LLMRerankerOption {
 	}
 }
 
+// WithBatchSize scores documents in batches of n per prompt, in parallel.
+// 0 (the default) scores them all in one prompt.
+func WithBatchSize(n int) LLMRerankerOption {
+	return func(r *LLMReranker) {
+		r.batchSize = n
+	}
+}
+
+// WithConcurrency sets how many batches are scored at once (default 4).
+func WithConcurrency(n int) LLMRerankerOption {
+	return func(r *LLMReranker) {
+		if n > 0 {
+			r.concurrency = n
+		}
+	}
+}
+
+// WithTimeout caps how long Rerank takes. Documents not scored by then keep
+// their vector score.
+func WithTimeout(d time.Duration) LLMRerankerOption {
+	return func(r *LLMReranker) {
+		r.timeout = d
+	}
+}
+
 // NewLLMReranker creates a new LLM-based reranker.
 func NewLLMReranker(llmClient llm.LLM, opts ...LLMRerankerOption) *LLMReranker {
 	r := &LLMReranker{
-		llmClient: llmClient,
-		model:     "llama3.2", // Default model
+		llmClient:   llmClient,
+		model:       "llama3.2", // Default model
+		concurrency: 4,
 	}
 
 	for _, opt := range opts {
@@ -54,6 +90,26 @@ type rerankResponse struct {
 	Scores []relevanceScore `json:"scores"`
 }
 
+// rerankSchema constrains the LLM output to a rerankResponse.
+var rerankSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "scores": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "doc_index": {"type": "integer", "minimum": 0},
+          "score": {"type": "number", "minimum": 0, "maximum": 1},
+          "reason": {"type": "string"}
+        },
+        "required": ["doc_index", "score"]
+      }
+    }
+  },
+  "required": ["scores"]
+}`)
+
 // Rerank uses the LLM to score each document's relevance to the query.
 func (r *LLMReranker) Rerank(ctx context.Context, query string, results []vectorstore.SearchResult, topK int) ([]ScoredResult, error) {
 	if len(results) == 0 {
@@ -65,25 +121,29 @@ func (r *LLMReranker) Rerank(ctx context.Context, query string, results []vector
 		topK = len(results)
 	}
 
-	// Build the reranking prompt
-	prompt := r.buildRerankPrompt(query, results)
-
-	opts := llm.GenerateOptions{
-		Model:       r.model,
-		Temperature: 0.0, // Deterministic scoring
-		MaxTokens:   1024,
+	scoreCtx := ctx
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		scoreCtx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
 	}
 
-	response, err := r.llmClient.Generate(ctx, prompt, opts)
-	if err != nil {
-		return nil, fmt.Errorf("LLM reranking failed: %w", err)
+	var scores []float32
+	var err error
+	if r.batchSize > 0 && len(results) > r.batchSize {
+		scores, err = r.scoreBatches(scoreCtx, query, results)
+	} else {
+		scores, err = r.scoreBatch(scoreCtx, query, results)
+		if err != nil && ctx.Err() == nil && (errors.Is(err, errUnscored) || scoreCtx.Err() != nil) {
+			// Fallback: return original results with their vector scores
+			return r.fallbackScoring(results, topK), nil
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
 	}
-
-	// Parse the response
-	scores, err := r.parseRerankResponse(response, len(results))
 	if err != nil {
-		// Fallback: return original results with their vector scores
-		return r.fallbackScoring(results, topK), nil
+		return nil, err
 	}
 
 	// Build scored results
@@ -108,6 +168,85 @@ func (r *LLMReranker) Rerank(ctx context.Context, query string, results []vector
 	return scoredResults, nil
 }
 
+// errUnscored is returned by scoreBatch when the LLM answered without usable scores
+var errUnscored = errors.New("LLM returned no usable scores")
+
+// scoreBatch scores documents in one prompt
+func (r *LLMReranker) scoreBatch(ctx context.Context, query string, results []vectorstore.SearchResult) ([]float32, error) {
+	prompt := r.buildRerankPrompt(query, results)
+
+	opts := llm.GenerateOptions{
+		Model:       r.model,
+		Temperature: 0.0, // Deterministic scoring
+		MaxTokens:   1024,
+	}
+
+	response, err := r.llmClient.GenerateStructured(ctx, prompt, rerankSchema, opts)
+	if errors.Is(err, llm.ErrInvalidStructuredOutput) {
+		return nil, errUnscored
+	}
+	if err != nil {
+		return nil, fmt.Errorf("LLM reranking failed: %w", err)
+	}
+
+	scores, err := r.parseRerankResponse(response, len(results))
+	if err != nil {
+		return nil, errUnscored
+	}
+	return scores, nil
+}
+
+// scoreBatches scores documents in batches of r.batchSize, r.concurrency at
+// a time. Documents of batches that fail or miss the deadline keep their
+// vector score. It fails only when every batch failed with an LLM error
+// before ctx ended.
+func (r *LLMReranker) scoreBatches(ctx context.Context, query string, results []vectorstore.SearchResult) ([]float32, error) {
+	scores := make([]float32, len(results))
+	for i, result := range results {
+		scores[i] = result.Score
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		scored    int
+		firstErr  error
+		semaphore = make(chan struct{}, r.concurrency)
+	)
+	for start := 0; start < len(results); start += r.batchSize {
+		end := min(start+r.batchSize, len(results))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				return
+			}
+
+			batch, err := r.scoreBatch(ctx, query, results[start:end])
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil && !errors.Is(err, errUnscored) && ctx.Err() == nil {
+					firstErr = err
+				}
+				return
+			}
+			copy(scores[start:end], batch)
+			scored++
+		}(start, end)
+	}
+	wg.Wait()
+
+	if scored == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return scores, nil
+}
+
 // buildRerankPrompt constructs the prompt for LLM-based reranking.
 func (r *LLMReranker) buildRerankPrompt(query string, results []vectorstore.SearchResult) string {
 	var sb strings.Builder
@@ -127,38 +266,18 @@ func (r *LLMReranker) buildRerankPrompt(query string, results []vectorstore.Sear
 		sb.WriteString(fmt.Sprintf("[Doc %d]: %s\n\n", i, content))
 	}
 
-	sb.WriteString(`Score each document from 0.0 to 1.0 based on relevance to the query.
-Output ONLY valid JSON in this exact format:
+	sb.WriteString(`Score each document from 0.0 to 1.0 based on relevance to the query, for example:
 {"scores": [{"doc_index": 0, "score": 0.9}, {"doc_index": 1, "score": 0.3}, ...]}
 
-Be strict: irrelevant documents should score below 0.3, somewhat relevant 0.3-0.7, highly relevant above 0.7.
-Output only JSON, no explanation:`)
+Be strict: irrelevant documents should score below 0.3, somewhat relevant 0.3-0.7, highly relevant above 0.7.`)
 
 	return sb.String()
 }
 
-// parseRerankResponse extracts scores from the LLM response.
-func (r *LLMReranker) parseRerankResponse(response string, numResults int) ([]float32, error) {
-	// Try to find JSON in the response
-	response = strings.TrimSpace(response)
-
-	// Try to extract JSON from markdown code blocks if present
-	if idx := strings.Index(response, "```json"); idx != -1 {
-		start := idx + 7
-		if end := strings.Index(response[start:], "```"); end != -1 {
-			response = response[start : start+end]
-		}
-	} else if idx := strings.Index(response, "```"); idx != -1 {
-		start := idx + 3
-		if end := strings.Index(response[start:], "```"); end != -1 {
-			response = response[start : start+end]
-		}
-	}
-
-	response = strings.TrimSpace(response)
-
+// parseRerankResponse maps the schema-validated LLM response to per-document scores.
+func (r *LLMReranker) parseRerankResponse(response json.RawMessage, numResults int) ([]float32, error) {
 	var parsed rerankResponse
-	if err := json.Unmarshal([]byte(response), &parsed); err != nil {
+	if err := json.Unmarshal(response, &parsed); err != nil {
 		return nil, fmt.Errorf("failed to parse rerank response: %w", err)
 	}
 
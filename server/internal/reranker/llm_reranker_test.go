@@ -0,0 +1,133 @@
+package reranker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+var docPattern = regexp.MustCompile(`\[Doc (\d+)\]: (\S+)`)
+
+// scoringLLM scores each document with the number it contains, taking
+// delay per prompt or hanging until canceled on documents saying "hang"
+type scoringLLM struct {
+	delay    time.Duration
+	err      error
+	calls    atomic.Int32
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (m *scoringLLM) Generate(context.Context, string, llm.GenerateOptions) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *scoringLLM) GenerateStream(context.Context, string, llm.GenerateOptions) (<-chan llm.StreamChunk, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *scoringLLM) GenerateStructured(ctx context.Context, prompt string, _ json.RawMessage, _ llm.GenerateOptions) (json.RawMessage, error) {
+	m.calls.Add(1)
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	if strings.Contains(prompt, "hang") {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var resp rerankResponse
+	for _, match := range docPattern.FindAllStringSubmatch(prompt, -1) {
+		index, _ := strconv.Atoi(match[1])
+		score, _ := strconv.ParseFloat(match[2], 32)
+		resp.Scores = append(resp.Scores, relevanceScore{DocIndex: index, Score: float32(score)})
+	}
+	return json.Marshal(resp)
+}
+
+func searchResults(contents ...string) []vectorstore.SearchResult {
+	results := make([]vectorstore.SearchResult, len(contents))
+	for i, content := range contents {
+		results[i] = vectorstore.SearchResult{ID: fmt.Sprint(i), Content: content, Score: 0.01}
+	}
+	return results
+}
+
+func TestLLMRerankerBatches(t *testing.T) {
+	client := &scoringLLM{delay: 20 * time.Millisecond}
+	r := NewLLMReranker(client, WithBatchSize(2), WithConcurrency(2))
+
+	results := searchResults("0.1", "0.9", "0.5", "0.3", "0.7")
+	reranked, err := r.Rerank(context.Background(), "q", results, 3)
+	if err != nil {
+		t.Fatalf("Rerank: %v", err)
+	}
+
+	var ids []string
+	for _, s := range reranked {
+		ids = append(ids, s.ID)
+	}
+	if got, want := strings.Join(ids, ","), "1,4,2"; got != want {
+		t.Errorf("top IDs = %s, want %s", got, want)
+	}
+	if got := client.calls.Load(); got != 3 {
+		t.Errorf("LLM called %d times, want 3 batches", got)
+	}
+	if got := client.peak.Load(); got != 2 {
+		t.Errorf("%d batches in flight at once, want 2", got)
+	}
+}
+
+func TestLLMRerankerDeadlineKeepsPartialScores(t *testing.T) {
+	client := &scoringLLM{}
+	r := NewLLMReranker(client, WithBatchSize(2), WithTimeout(50*time.Millisecond))
+
+	// The second batch hangs past the deadline and keeps its vector scores
+	results := searchResults("0.8", "0.6", "hang", "0.9")
+	reranked, err := r.Rerank(context.Background(), "q", results, 4)
+	if err != nil {
+		t.Fatalf("Rerank: %v", err)
+	}
+
+	scores := make(map[string]float32)
+	for _, s := range reranked {
+		scores[s.ID] = s.RerankerScore
+	}
+	want := map[string]float32{"0": 0.8, "1": 0.6, "2": 0.01, "3": 0.01}
+	for id, score := range want {
+		if scores[id] != score {
+			t.Errorf("doc %s scored %v, want %v", id, scores[id], score)
+		}
+	}
+}
+
+func TestLLMRerankerAllBatchesFail(t *testing.T) {
+	r := NewLLMReranker(&scoringLLM{err: errors.New("model unavailable")}, WithBatchSize(1))
+	if _, err := r.Rerank(context.Background(), "q", searchResults("0.1", "0.2"), 2); err == nil {
+		t.Error("Rerank succeeded with every batch failing")
+	}
+}
@@ -0,0 +1,81 @@
+package reranker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Provider names of the built-in rerankers.
+const (
+	ProviderLLM    = "llm"
+	ProviderCohere = "cohere"
+)
+
+// ErrUnknownProvider is returned by Registry.Get for a provider that was
+// not registered.
+var ErrUnknownProvider = errors.New("unknown reranker provider")
+
+// Registry holds the rerankers tenants can choose from, by provider name.
+type Registry struct {
+	mu              sync.RWMutex
+	rerankers       map[string]Reranker
+	defaultProvider string
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{rerankers: make(map[string]Reranker)}
+}
+
+// Register adds a reranker under name. The first one registered is the default.
+func (r *Registry) Register(name string, reranker Reranker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rerankers[name] = reranker
+	if r.defaultProvider == "" {
+		r.defaultProvider = name
+	}
+}
+
+// SetDefault sets the reranker used when a tenant doesn't name one.
+func (r *Registry) SetDefault(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rerankers[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	r.defaultProvider = name
+	return nil
+}
+
+// Get returns the reranker registered under name, or the default for "".
+func (r *Registry) Get(name string) (Reranker, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		name = r.defaultProvider
+	}
+	reranker, ok := r.rerankers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return reranker, nil
+}
+
+// Names returns the registered provider names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.rerankers))
+	for name := range r.rerankers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,27 @@
+package reranker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	llmReranker := NewLLMReranker(&scoringLLM{})
+	cohere := NewCohereReranker(CohereConfig{APIKey: "key"})
+	reg.Register(ProviderLLM, llmReranker)
+	reg.Register(ProviderCohere, cohere)
+
+	if got, err := reg.Get(""); err != nil || got != Reranker(llmReranker) {
+		t.Errorf("Get(\"\") = %v, %v; want the first registered", got, err)
+	}
+	if err := reg.SetDefault(ProviderCohere); err != nil {
+		t.Fatalf("SetDefault: %v", err)
+	}
+	if got, _ := reg.Get(""); got != Reranker(cohere) {
+		t.Errorf("Get(\"\") after SetDefault = %v, want cohere", got)
+	}
+	if _, err := reg.Get("voyage"); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("Get(voyage) error = %v, want ErrUnknownProvider", err)
+	}
+}
@@ -5,12 +5,18 @@
 //
 // # Trade-offs
 //
-// Reranking is a per-tenant configuration option (TenantConfig.RerankerEnabled).
+// Reranking is a per-tenant configuration option (TenantConfig.RerankerEnabled),
+// with the reranker chosen by provider name from a Registry.
+//
+// With the LLM reranker:
 //
 //   - Latency: Adds 1-3 seconds per query (extra LLM call to score each result)
 //   - Quality: Significantly better relevance when top-k vector results have similar scores
 //   - Cost: Roughly doubles LLM token usage per query
 //
+// The Cohere reranker calls a hosted cross-encoder instead, typically in
+// a few hundred milliseconds, at a per-search API cost.
+//
 // Enable reranking for use cases where accuracy matters more than speed.
 // Disable for high-throughput or latency-sensitive applications.
 package reranker
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults used when CORSConfig leaves a field empty
+var (
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	defaultCORSHeaders = []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Request-ID", "X-API-Key"}
+)
+
+// CORSConfig controls cross-origin access to the HTTP API
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins, "*" for any origin, or wildcard
+	// subdomains such as "https://*.example.com". Empty allows any origin.
+	AllowedOrigins []string
+
+	// AllowedHeaders are the request headers browsers may send (default: the
+	// headers the API uses)
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers scripts may read
+	ExposedHeaders []string
+
+	// AllowCredentials lets browsers send cookies and Authorization headers.
+	// The matching origin is echoed back instead of "*", as the spec requires.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for a request
+// origin, or "" if the origin is not allowed
+func (c CORSConfig) allowOrigin(origin string) string {
+	if len(c.AllowedOrigins) == 0 {
+		return c.anyOrigin(origin)
+	}
+	for _, pattern := range c.AllowedOrigins {
+		if pattern == "*" {
+			return c.anyOrigin(origin)
+		}
+		if origin != "" && matchOrigin(pattern, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// anyOrigin is the header value when every origin is allowed
+func (c CORSConfig) anyOrigin(origin string) string {
+	if c.AllowCredentials && origin != "" {
+		return origin
+	}
+	return "*"
+}
+
+// matchOrigin compares an origin against an exact or wildcard-subdomain pattern.
+// "https://*.example.com" matches "https://api.example.com" and
+// "https://a.b.example.com" but not "https://example.com".
+func matchOrigin(pattern, origin string) bool {
+	if strings.EqualFold(pattern, origin) {
+		return true
+	}
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok || !strings.HasPrefix(suffix, ".") {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	return len(origin) > len(prefix)+len(suffix) &&
+		strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
+
+// corsMiddleware handles CORS headers with the config current, which may
+// change while the server runs
+func corsMiddleware(current *atomic.Pointer[CORSConfig]) func(http.Handler) http.Handler {
+	allowMethods := strings.Join(defaultCORSMethods, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := current.Load()
+			headers := cfg.AllowedHeaders
+			if len(headers) == 0 {
+				headers = defaultCORSHeaders
+			}
+			allowHeaders := strings.Join(headers, ", ")
+			exposeHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+
+			// The response depends on the origin, so caches must key on it
+			w.Header().Add("Vary", "Origin")
+
+			if allowed := cfg.allowOrigin(r.Header.Get("Origin")); allowed != "" {
+				w.Header().Set("Access-Control-Allow-Origin", allowed)
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				if exposeHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+				}
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+				if cfg.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+				}
+			}
+
+			// Handle preflight requests
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSAllowOrigin(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    CORSConfig
+		origin string
+		want   string
+	}{
+		{"any origin", CORSConfig{AllowedOrigins: []string{"*"}}, "https://app.test", "*"},
+		{"any origin with credentials echoes", CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, "https://app.test", "https://app.test"},
+		{"exact match", CORSConfig{AllowedOrigins: []string{"https://app.test"}}, "https://app.test", "https://app.test"},
+		{"exact mismatch", CORSConfig{AllowedOrigins: []string{"https://app.test"}}, "https://evil.test", ""},
+		{"wildcard subdomain", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}, "https://api.example.com", "https://api.example.com"},
+		{"wildcard nested subdomain", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}, "https://a.b.example.com", "https://a.b.example.com"},
+		{"wildcard excludes apex", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}, "https://example.com", ""},
+		{"wildcard checks scheme", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}, "http://api.example.com", ""},
+		{"wildcard rejects lookalike", CORSConfig{AllowedOrigins: []string{"https://*.example.com"}}, "https://api.example.com.evil.test", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.allowOrigin(tt.origin); got != tt.want {
+				t.Errorf("allowOrigin(%q) = %q, want %q", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetCORSOrigins(t *testing.T) {
+	s, err := NewHTTPServer(HTTPServerConfig{CORS: CORSConfig{AllowedOrigins: []string{"https://old.test"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := func(origin string) string {
+		req := httptest.NewRequest(http.MethodOptions, "/v1/query", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		s.GetRouter().ServeHTTP(rec, req)
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	if got := allowed("https://new.test"); got != "" {
+		t.Fatalf("new origin allowed before the change: %q", got)
+	}
+	s.SetCORSOrigins([]string{"https://new.test"})
+	if got := allowed("https://new.test"); got != "https://new.test" {
+		t.Errorf("new origin: Access-Control-Allow-Origin = %q, want it allowed", got)
+	}
+	if got := allowed("https://old.test"); got != "" {
+		t.Errorf("old origin still allowed: %q", got)
+	}
+}
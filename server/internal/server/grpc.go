@@ -5,13 +5,19 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"net"
 	"runtime/debug"
 	"time"
 
 	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/monitor"
+	"github.com/knoguchi/rag/internal/ratelimit"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
@@ -22,19 +28,44 @@ type GRPCServer struct {
 	listener net.Listener
 	logger   *slog.Logger
 	port     int
+	certs    *certReloader // nil without TLS
 }
 
 // GRPCServerConfig holds configuration for the gRPC server
 type GRPCServerConfig struct {
-	Port   int
-	Logger *slog.Logger
+	Port     int
+	Logger   *slog.Logger
+	Recorder *monitor.Recorder // Optional: records failed and slow requests for AdminService
+	TLS      TLSConfig         // Optional: serve TLS, or mTLS with a client CA
+
+	// Auth authenticates tenant and admin API keys; nil leaves every method open
+	Auth *auth.APIKeyInterceptor
+
+	// RateLimit limits requests per credential and per tenant; nil disables it
+	RateLimit *ratelimit.Limiter
+
+	// Transport sets message size limits, keepalive and compression
+	Transport TransportConfig
+
+	// RequestLogSampleRate is the fraction of successful requests logged;
+	// failed ones always are. Zero logs every request.
+	RequestLogSampleRate float64
 }
 
 // Services holds all gRPC service implementations
 type Services struct {
-	TenantService   ragv1.TenantServiceServer
-	DocumentService ragv1.DocumentServiceServer
-	RAGService      ragv1.RAGServiceServer
+	TenantService     ragv1.TenantServiceServer
+	DocumentService   ragv1.DocumentServiceServer
+	CollectionService ragv1.CollectionServiceServer
+	PromptService     ragv1.PromptServiceServer
+	SynonymService    ragv1.SynonymServiceServer
+	ProjectService    ragv1.ProjectServiceServer
+	SessionService    ragv1.SessionServiceServer
+	RAGService        ragv1.RAGServiceServer
+	AdminService      ragv1.AdminServiceServer
+	FeedService       ragv1.FeedServiceServer
+	CrawlService      ragv1.CrawlServiceServer
+	AuthService       ragv1.AuthServiceServer
 }
 
 // NewGRPCServer creates a new gRPC server with interceptors
@@ -44,17 +75,57 @@ func NewGRPCServer(cfg GRPCServerConfig, services Services) (*GRPCServer, error)
 		logger = slog.Default()
 	}
 
+	// The request ID comes first so every later log and error has it
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		requestIDUnaryInterceptor(),
+		recoveryUnaryInterceptor(logger),
+		loggingUnaryInterceptor(logger, cfg.RequestLogSampleRate),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		requestIDStreamInterceptor(),
+		recoveryStreamInterceptor(logger),
+		loggingStreamInterceptor(logger, cfg.RequestLogSampleRate),
+	}
+	if cfg.Recorder != nil {
+		unaryInterceptors = append(unaryInterceptors, monitorUnaryInterceptor(cfg.Recorder))
+		streamInterceptors = append(streamInterceptors, monitorStreamInterceptor(cfg.Recorder))
+	}
+	// Limit client addresses before authenticating so invalid keys cannot flood the database
+	if cfg.RateLimit != nil {
+		unaryInterceptors = append(unaryInterceptors, cfg.RateLimit.AddrUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, cfg.RateLimit.AddrStreamInterceptor())
+	}
+	// Authenticate last so rejected requests are still logged and recorded
+	if cfg.Auth != nil {
+		unaryInterceptors = append(unaryInterceptors, cfg.Auth.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, cfg.Auth.StreamInterceptor())
+	}
+	// Credential and tenant limits need the credential authentication accepted
+	if cfg.RateLimit != nil {
+		unaryInterceptors = append(unaryInterceptors, cfg.RateLimit.KeyUnaryInterceptor(), cfg.RateLimit.TenantUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, cfg.RateLimit.KeyStreamInterceptor(), cfg.RateLimit.TenantStreamInterceptor())
+	}
+
+	if err := cfg.Transport.validate(); err != nil {
+		return nil, err
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	opts = append(opts, cfg.Transport.serverOptions()...)
+
+	var certs *certReloader
+	if cfg.TLS.Enabled() {
+		var err error
+		if certs, err = newCertReloader(cfg.TLS, logger); err != nil {
+			return nil, fmt.Errorf("gRPC TLS: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certs.serverConfig())))
+	}
+
 	// Create gRPC server with interceptors
-	server := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			recoveryUnaryInterceptor(logger),
-			loggingUnaryInterceptor(logger),
-		),
-		grpc.ChainStreamInterceptor(
-			recoveryStreamInterceptor(logger),
-			loggingStreamInterceptor(logger),
-		),
-	)
+	server := grpc.NewServer(opts...)
 
 	// Register services
 	if services.TenantService != nil {
@@ -67,11 +138,56 @@ func NewGRPCServer(cfg GRPCServerConfig, services Services) (*GRPCServer, error)
 		logger.Info("registered DocumentService")
 	}
 
+	if services.CollectionService != nil {
+		ragv1.RegisterCollectionServiceServer(server, services.CollectionService)
+		logger.Info("registered CollectionService")
+	}
+
+	if services.PromptService != nil {
+		ragv1.RegisterPromptServiceServer(server, services.PromptService)
+		logger.Info("registered PromptService")
+	}
+
+	if services.SynonymService != nil {
+		ragv1.RegisterSynonymServiceServer(server, services.SynonymService)
+		logger.Info("registered SynonymService")
+	}
+
+	if services.ProjectService != nil {
+		ragv1.RegisterProjectServiceServer(server, services.ProjectService)
+		logger.Info("registered ProjectService")
+	}
+
+	if services.SessionService != nil {
+		ragv1.RegisterSessionServiceServer(server, services.SessionService)
+		logger.Info("registered SessionService")
+	}
+
 	if services.RAGService != nil {
 		ragv1.RegisterRAGServiceServer(server, services.RAGService)
 		logger.Info("registered RAGService")
 	}
 
+	if services.AdminService != nil {
+		ragv1.RegisterAdminServiceServer(server, services.AdminService)
+		logger.Info("registered AdminService")
+	}
+
+	if services.FeedService != nil {
+		ragv1.RegisterFeedServiceServer(server, services.FeedService)
+		logger.Info("registered FeedService")
+	}
+
+	if services.CrawlService != nil {
+		ragv1.RegisterCrawlServiceServer(server, services.CrawlService)
+		logger.Info("registered CrawlService")
+	}
+
+	if services.AuthService != nil {
+		ragv1.RegisterAuthServiceServer(server, services.AuthService)
+		logger.Info("registered AuthService")
+	}
+
 	// Enable reflection for development/debugging
 	reflection.Register(server)
 
@@ -79,9 +195,19 @@ func NewGRPCServer(cfg GRPCServerConfig, services Services) (*GRPCServer, error)
 		server: server,
 		logger: logger,
 		port:   cfg.Port,
+		certs:  certs,
 	}, nil
 }
 
+// GatewayCredentials returns the transport credentials the HTTP gateway needs
+// to connect to this server
+func (s *GRPCServer) GatewayCredentials() credentials.TransportCredentials {
+	if s.certs == nil {
+		return insecure.NewCredentials()
+	}
+	return s.certs.gatewayCredentials()
+}
+
 // Start starts the gRPC server
 func (s *GRPCServer) Start() error {
 	addr := fmt.Sprintf(":%d", s.port)
@@ -91,7 +217,7 @@ func (s *GRPCServer) Start() error {
 	}
 	s.listener = listener
 
-	s.logger.Info("starting gRPC server", "address", addr)
+	s.logger.Info("starting gRPC server", "address", addr, "tls", s.certs != nil)
 
 	if err := s.server.Serve(listener); err != nil {
 		return fmt.Errorf("gRPC server error: %w", err)
@@ -128,8 +254,15 @@ func (s *GRPCServer) GetServer() *grpc.Server {
 	return s.server
 }
 
-// loggingUnaryInterceptor logs unary RPC calls
-func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+// sampled reports whether a successful request is logged, for the fraction
+// rate of requests; rates of 0 and from 1 log every request
+func sampled(rate float64) bool {
+	return rate <= 0 || rate >= 1 || rand.Float64() < rate
+}
+
+// loggingUnaryInterceptor logs unary RPC calls, and a sample of the
+// successful ones
+func loggingUnaryInterceptor(logger *slog.Logger, sampleRate float64) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -139,6 +272,9 @@ func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 		start := time.Now()
 
 		resp, err := handler(ctx, req)
+		if err == nil && !sampled(sampleRate) {
+			return resp, nil
+		}
 
 		duration := time.Since(start)
 		code := codes.OK
@@ -151,7 +287,7 @@ func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 		}
 
 		// Log the request
-		logger.Info("gRPC request",
+		logger.InfoContext(ctx, "gRPC request",
 			"method", info.FullMethod,
 			"code", code.String(),
 			"duration", duration,
@@ -162,8 +298,9 @@ func loggingUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
-// loggingStreamInterceptor logs streaming RPC calls
-func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+// loggingStreamInterceptor logs failed streaming RPC calls, and a sample of
+// the successful ones
+func loggingStreamInterceptor(logger *slog.Logger, sampleRate float64) grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
@@ -173,6 +310,9 @@ func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor
 		start := time.Now()
 
 		err := handler(srv, ss)
+		if err == nil && !sampled(sampleRate) {
+			return nil
+		}
 
 		duration := time.Since(start)
 		code := codes.OK
@@ -184,7 +324,7 @@ func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor
 			}
 		}
 
-		logger.Info("gRPC stream",
+		logger.InfoContext(ss.Context(), "gRPC stream",
 			"method", info.FullMethod,
 			"code", code.String(),
 			"duration", duration,
@@ -195,6 +335,76 @@ func loggingStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor
 	}
 }
 
+// monitorUnaryInterceptor records failed and slow unary calls
+func monitorUnaryInterceptor(recorder *monitor.Recorder) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		recorder.Record(newMonitorRecord(info.FullMethod, req, start, err))
+		return resp, err
+	}
+}
+
+// monitorStreamInterceptor records failed and slow streaming calls
+func monitorStreamInterceptor(recorder *monitor.Recorder) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		rs := &recordingServerStream{ServerStream: ss}
+
+		err := handler(srv, rs)
+
+		recorder.Record(newMonitorRecord(info.FullMethod, rs.req, start, err))
+		return err
+	}
+}
+
+// recordingServerStream keeps the first received message so the request can be described
+type recordingServerStream struct {
+	grpc.ServerStream
+	req interface{}
+}
+
+// RecvMsg records the first message received on the stream
+func (s *recordingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+// newMonitorRecord builds a monitor record, pulling tenant and query from the request when present
+func newMonitorRecord(method string, req interface{}, start time.Time, err error) monitor.Record {
+	rec := monitor.Record{
+		Method:   method,
+		Code:     status.Code(err).String(),
+		Duration: time.Since(start),
+		Time:     start,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if r, ok := req.(interface{ GetTenantId() string }); ok {
+		rec.TenantID = r.GetTenantId()
+	}
+	if r, ok := req.(interface{ GetQuery() string }); ok {
+		rec.Query = r.GetQuery()
+	}
+	return rec
+}
+
 // recoveryUnaryInterceptor recovers from panics in unary handlers
 func recoveryUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	return func(
@@ -206,7 +416,7 @@ func recoveryUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 		defer func() {
 			if r := recover(); r != nil {
 				stack := debug.Stack()
-				logger.Error("panic recovered in gRPC handler",
+				logger.ErrorContext(ctx, "panic recovered in gRPC handler",
 					"method", info.FullMethod,
 					"panic", r,
 					"stack", string(stack),
@@ -230,7 +440,7 @@ func recoveryStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor
 		defer func() {
 			if r := recover(); r != nil {
 				stack := debug.Stack()
-				logger.Error("panic recovered in gRPC stream handler",
+				logger.ErrorContext(ss.Context(), "panic recovered in gRPC stream handler",
 					"method", info.FullMethod,
 					"panic", r,
 					"stack", string(stack),
@@ -6,34 +6,59 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/ratelimit"
+	"github.com/knoguchi/rag/internal/requestid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // HTTPServer wraps an HTTP server with grpc-gateway integration
 type HTTPServer struct {
-	server     *http.Server
-	router     *chi.Mux
-	gwMux      *runtime.ServeMux
-	logger     *slog.Logger
-	port       int
-	grpcAddr   string
-	grpcConn   *grpc.ClientConn
+	server    *http.Server
+	router    *chi.Mux
+	gwMux     *runtime.ServeMux
+	logger    *slog.Logger
+	port      int
+	grpcAddr  string
+	grpcConn  *grpc.ClientConn
+	grpcCreds credentials.TransportCredentials
+	grpcOpts  []grpc.DialOption
+	tls       bool
+
+	// cors is the CORS config in effect; SetCORSOrigins changes it
+	cors *atomic.Pointer[CORSConfig]
 }
 
 // HTTPServerConfig holds configuration for the HTTP server
 type HTTPServerConfig struct {
-	Port           int
-	GRPCAddr       string // Address of the gRPC server (e.g., "localhost:9090")
-	Logger         *slog.Logger
-	AllowedOrigins []string // CORS allowed origins
+	Port     int
+	GRPCAddr string // Address of the gRPC server (e.g., "localhost:9090")
+	Logger   *slog.Logger
+	CORS     CORSConfig
+	TLS      TLSConfig // Optional: serve HTTPS, or require client certificates with a client CA
+
+	// GRPCCredentials are used to reach the gRPC server; nil means plaintext.
+	// Use GRPCServer.GatewayCredentials when the gRPC server has TLS.
+	GRPCCredentials credentials.TransportCredentials
+
+	// GRPCTransport matches the gRPC server's message size limits and
+	// keepalive policy, and sets the gateway's request compression
+	GRPCTransport TransportConfig
+
+	// RequestLogSampleRate is the fraction of successful requests logged;
+	// failed ones always are. Zero logs every request.
+	RequestLogSampleRate float64
 }
 
 // NewHTTPServer creates a new HTTP server with grpc-gateway
@@ -47,14 +72,19 @@ func NewHTTPServer(cfg HTTPServerConfig) (*HTTPServer, error) {
 	router := chi.NewRouter()
 
 	// Add middleware
-	router.Use(middleware.RequestID)
+	router.Use(requestIDMiddleware)
 	router.Use(middleware.RealIP)
-	router.Use(requestLoggingMiddleware(logger))
+	router.Use(requestLoggingMiddleware(logger, cfg.RequestLogSampleRate))
 	router.Use(middleware.Recoverer)
-	router.Use(corsMiddleware(cfg.AllowedOrigins))
+	cors := new(atomic.Pointer[CORSConfig])
+	cors.Store(&cfg.CORS)
+	router.Use(corsMiddleware(cors))
 
 	// Create grpc-gateway mux with JSON marshaler options
 	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeaderMatcher),
+		runtime.WithMetadata(gatewayRequestID),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 			MarshalOptions: protojson.MarshalOptions{
 				UseProtoNames:   true,
@@ -81,22 +111,50 @@ func NewHTTPServer(cfg HTTPServerConfig) (*HTTPServer, error) {
 		IdleTimeout:  120 * time.Second,
 	}
 
+	if cfg.TLS.Enabled() {
+		certs, err := newCertReloader(cfg.TLS, logger)
+		if err != nil {
+			return nil, fmt.Errorf("HTTP TLS: %w", err)
+		}
+		server.TLSConfig = certs.serverConfig("h2", "http/1.1")
+	}
+
+	grpcCreds := cfg.GRPCCredentials
+	if grpcCreds == nil {
+		grpcCreds = insecure.NewCredentials()
+	}
+	if err := cfg.GRPCTransport.validate(); err != nil {
+		return nil, err
+	}
+
 	return &HTTPServer{
-		server:   server,
-		router:   router,
-		gwMux:    gwMux,
-		logger:   logger,
-		port:     cfg.Port,
-		grpcAddr: cfg.GRPCAddr,
+		server:    server,
+		router:    router,
+		gwMux:     gwMux,
+		logger:    logger,
+		port:      cfg.Port,
+		grpcAddr:  cfg.GRPCAddr,
+		grpcCreds: grpcCreds,
+		grpcOpts:  cfg.GRPCTransport.dialOptions(),
+		tls:       cfg.TLS.Enabled(),
+		cors:      cors,
 	}, nil
 }
 
+// SetCORSOrigins changes the origins allowed to call the API while the
+// server runs.
+func (s *HTTPServer) SetCORSOrigins(origins []string) {
+	cfg := *s.cors.Load()
+	cfg.AllowedOrigins = origins
+	s.cors.Store(&cfg)
+}
+
 // RegisterHandlers registers grpc-gateway handlers by connecting to the gRPC server
 func (s *HTTPServer) RegisterHandlers(ctx context.Context) error {
 	// Connect to gRPC server
 	conn, err := grpc.NewClient(
 		s.grpcAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		append([]grpc.DialOption{grpc.WithTransportCredentials(s.grpcCreds)}, s.grpcOpts...)...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to connect to gRPC server: %w", err)
@@ -112,21 +170,74 @@ func (s *HTTPServer) RegisterHandlers(ctx context.Context) error {
 	if err := ragv1.RegisterDocumentServiceHandler(ctx, s.gwMux, conn); err != nil {
 		return fmt.Errorf("failed to register DocumentService handler: %w", err)
 	}
+	s.router.Get("/v1/documents/{id}/events", documentEventsHandler(ragv1.NewDocumentServiceClient(conn), s.logger))
 	s.logger.Info("registered DocumentService HTTP handler")
 
+	if err := ragv1.RegisterCollectionServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register CollectionService handler: %w", err)
+	}
+	s.logger.Info("registered CollectionService HTTP handler")
+
+	if err := ragv1.RegisterPromptServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register PromptService handler: %w", err)
+	}
+	s.logger.Info("registered PromptService HTTP handler")
+
+	if err := ragv1.RegisterSynonymServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register SynonymService handler: %w", err)
+	}
+	s.logger.Info("registered SynonymService HTTP handler")
+
+	if err := ragv1.RegisterProjectServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register ProjectService handler: %w", err)
+	}
+	s.logger.Info("registered ProjectService HTTP handler")
+
+	if err := ragv1.RegisterSessionServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register SessionService handler: %w", err)
+	}
+	s.logger.Info("registered SessionService HTTP handler")
+
 	if err := ragv1.RegisterRAGServiceHandler(ctx, s.gwMux, conn); err != nil {
 		return fmt.Errorf("failed to register RAGService handler: %w", err)
 	}
 	s.logger.Info("registered RAGService HTTP handler")
 
+	if err := ragv1.RegisterAdminServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register AdminService handler: %w", err)
+	}
+	s.logger.Info("registered AdminService HTTP handler")
+
+	if err := ragv1.RegisterFeedServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register FeedService handler: %w", err)
+	}
+	s.logger.Info("registered FeedService HTTP handler")
+
+	if err := ragv1.RegisterCrawlServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register CrawlService handler: %w", err)
+	}
+	s.logger.Info("registered CrawlService HTTP handler")
+
+	if err := ragv1.RegisterAuthServiceHandler(ctx, s.gwMux, conn); err != nil {
+		return fmt.Errorf("failed to register AuthService handler: %w", err)
+	}
+	s.logger.Info("registered AuthService HTTP handler")
+
 	return nil
 }
 
 // Start starts the HTTP server
 func (s *HTTPServer) Start() error {
-	s.logger.Info("starting HTTP server", "address", s.server.Addr)
-
-	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	s.logger.Info("starting HTTP server", "address", s.server.Addr, "tls", s.tls)
+
+	var err error
+	if s.tls {
+		// Certificates come from TLSConfig, which reloads them
+		err = s.server.ListenAndServeTLS("", "")
+	} else {
+		err = s.server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("HTTP server error: %w", err)
 	}
 
@@ -157,8 +268,9 @@ func (s *HTTPServer) GetRouter() *chi.Mux {
 	return s.router
 }
 
-// requestLoggingMiddleware logs HTTP requests
-func requestLoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+// requestLoggingMiddleware logs failed HTTP requests, and a sample of the
+// successful ones
+func requestLoggingMiddleware(logger *slog.Logger, sampleRate float64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -167,6 +279,9 @@ func requestLoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handl
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 			next.ServeHTTP(ww, r)
+			if ww.Status() < http.StatusBadRequest && !sampled(sampleRate) {
+				return
+			}
 
 			duration := time.Since(start)
 
@@ -177,50 +292,32 @@ func requestLoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handl
 				"bytes", ww.BytesWritten(),
 				"duration", duration,
 				"remote_addr", r.RemoteAddr,
-				"request_id", middleware.GetReqID(r.Context()),
+				"request_id", requestid.FromContext(r.Context()),
 			)
 		})
 	}
 }
 
-// corsMiddleware handles CORS headers
-func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
-
-			// Check if origin is allowed
-			allowed := false
-			if len(allowedOrigins) == 0 {
-				// If no origins specified, allow all in development
-				allowed = true
-				origin = "*"
-			} else {
-				for _, o := range allowedOrigins {
-					if o == "*" || o == origin {
-						allowed = true
-						break
-					}
-				}
-			}
-
-			if allowed {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Request-ID, X-API-Key")
-				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Max-Age", "86400")
-			}
-
-			// Handle preflight requests
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusNoContent)
-				return
-			}
+// gatewayHeaderMatcher forwards the API key header to gRPC along with the
+// gateway's default headers (Authorization and Grpc-Metadata-*)
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, auth.APIKeyHeader) {
+		return auth.APIKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
 
-			next.ServeHTTP(w, r)
-		})
+// gatewayOutgoingHeaderMatcher passes the rate limiter's retry-after through
+// as the standard Retry-After header, alongside the gateway's default
+// Grpc-Metadata-* headers. The request ID is already in X-Request-ID.
+func gatewayOutgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, ratelimit.RetryAfterHeader) {
+		return "Retry-After", true
+	}
+	if strings.EqualFold(key, requestid.MetadataKey) {
+		return "", false
 	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
 }
 
 // healthCheckHandler returns a handler for the /healthz endpoint
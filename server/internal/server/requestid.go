@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/knoguchi/rag/internal/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMiddleware gives each HTTP request an ID, the client's X-Request-ID
+// or a new one, returned in the response's X-Request-ID header. The gateway
+// passes it on to gRPC.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestid.FromRequest(r)
+		w.Header().Set(requestid.Header, id)
+
+		// chi's middleware read the ID under its own key
+		ctx := context.WithValue(r.Context(), middleware.RequestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(ctx, id)))
+	})
+}
+
+// gatewayRequestID sends the HTTP request's ID to gRPC as metadata
+func gatewayRequestID(_ context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(requestid.MetadataKey, requestid.FromContext(r.Context()))
+}
+
+// requestIDUnaryInterceptor puts the request's ID, from its metadata or new,
+// in its context and response header, and adds it to errors as RequestInfo
+func requestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		id := requestid.FromIncoming(ctx)
+		ctx = requestid.NewContext(ctx, id)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, withRequestInfo(err, id)
+		}
+		return resp, nil
+	}
+}
+
+// requestIDStreamInterceptor is requestIDUnaryInterceptor for streams
+func requestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		id := requestid.FromIncoming(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+
+		err := handler(srv, &requestIDServerStream{ServerStream: ss, ctx: requestid.NewContext(ss.Context(), id)})
+		if err != nil {
+			return withRequestInfo(err, id)
+		}
+		return nil
+	}
+}
+
+// requestIDServerStream gives handlers the context carrying the request ID
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the request ID
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withRequestInfo adds a RequestInfo detail with the request ID to an error's
+// status, so clients can quote it when reporting the error
+func withRequestInfo(err error, id string) error {
+	st := status.Convert(err)
+	detailed, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if detailErr != nil {
+		return err
+	}
+	return detailed.Err()
+}
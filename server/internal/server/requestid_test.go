@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/knoguchi/rag/internal/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(requestIDUnaryInterceptor()))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// An unknown service fails, so the error carries the ID as well
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.MetadataKey, "req-42")
+	var header metadata.MD
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "missing"}, grpc.Header(&header))
+	if err == nil {
+		t.Fatal("Check of an unknown service succeeded")
+	}
+	if got := header.Get(requestid.MetadataKey); len(got) != 1 || got[0] != "req-42" {
+		t.Errorf("response header = %v, want the request ID", got)
+	}
+	var info *errdetails.RequestInfo
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(*errdetails.RequestInfo); ok {
+			info = d
+		}
+	}
+	if info == nil || info.RequestId != "req-42" {
+		t.Errorf("error details lack the request ID: %v", status.Convert(err).Details())
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/requestid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sseMarshaler encodes server-sent event data like the gateway's JSON
+var sseMarshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+const (
+	// accessTokenParam is the query parameter carrying a bearer token for
+	// clients that cannot set headers, such as browsers' EventSource
+	accessTokenParam = "access_token"
+	// maxQueryTokenLifetime is the longest a token in a URL, which proxies
+	// and browser history may keep, can have left before it expires
+	maxQueryTokenLifetime = 15 * time.Minute
+)
+
+// documentEventsHandler serves WatchDocument as server-sent events, for
+// browsers' EventSource. Since EventSource cannot send headers, a token from
+// POST /v1/auth/token with a ttl of at most 15 minutes may be passed in the
+// access_token query parameter instead. Each DocumentProgress is a
+// "progress" event; the stream ends with a "done" event, or an "error" event
+// if the watch fails after it started.
+func documentEventsHandler(client ragv1.DocumentServiceClient, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		md := forwardedMetadata(r)
+		if token := r.URL.Query().Get(accessTokenParam); token != "" && len(md.Get("authorization")) == 0 {
+			if !shortLived(token, time.Now()) {
+				writeStatusError(w, status.Errorf(codes.Unauthenticated, "%s must be a token expiring within %s", accessTokenParam, maxQueryTokenLifetime))
+				return
+			}
+			md.Set("authorization", "Bearer "+token)
+		}
+		ctx := metadata.NewOutgoingContext(r.Context(), md)
+		stream, err := client.WatchDocument(ctx, &ragv1.WatchDocumentRequest{Id: chi.URLParam(r, "id")})
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+
+		// Errors before the first message, such as an unknown document, are
+		// answered with an HTTP status rather than an event
+		progress, err := stream.Recv()
+		if err != nil {
+			writeStatusError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no") // Keep proxies from buffering events
+		w.WriteHeader(http.StatusOK)
+
+		for {
+			data, err := sseMarshaler.Marshal(progress)
+			if err != nil {
+				logger.Warn("failed to encode document progress", "error", err)
+				return
+			}
+			fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data)
+			flusher.Flush()
+
+			progress, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				fmt.Fprint(w, "event: done\ndata: {}\n\n")
+				flusher.Flush()
+				return
+			}
+			if err != nil {
+				if r.Context().Err() == nil {
+					data, _ := sseMarshaler.Marshal(status.Convert(err).Proto())
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+					flusher.Flush()
+				}
+				return
+			}
+		}
+	}
+}
+
+// forwardedMetadata passes a request's headers to gRPC as the gateway does,
+// including Authorization under its own name, the request ID, and the client
+// address appended to X-Forwarded-For for the rate limiter
+func forwardedMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		if name, ok := gatewayHeaderMatcher(key); ok {
+			md.Append(name, values...)
+		}
+		if strings.EqualFold(key, "Authorization") {
+			md.Append("authorization", values...)
+		}
+	}
+	md.Set(requestid.MetadataKey, requestid.FromContext(r.Context()))
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		forwarded := host
+		if prior := r.Header.Get("X-Forwarded-For"); prior != "" {
+			forwarded = prior + ", " + host
+		}
+		md.Set("x-forwarded-for", forwarded)
+	}
+	return md
+}
+
+// shortLived reports whether a JWT expires within maxQueryTokenLifetime of
+// now. The claim is read without verifying the token, which authentication
+// does; a forged expiry fails there.
+func shortLived(token string, now time.Time) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return false
+	}
+	return time.Unix(claims.ExpiresAt, 0).Sub(now) <= maxQueryTokenLifetime
+}
+
+// writeStatusError answers with the HTTP status and JSON body the gateway
+// uses for a gRPC error
+func writeStatusError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	data, _ := sseMarshaler.Marshal(st.Proto())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	w.Write(data)
+}
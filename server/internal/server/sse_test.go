@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchClient answers WatchDocument with fixed messages, then err
+type fakeWatchClient struct {
+	ragv1.DocumentServiceClient
+	messages []*ragv1.DocumentProgress
+	err      error
+	md       metadata.MD
+}
+
+func (c *fakeWatchClient) WatchDocument(ctx context.Context, req *ragv1.WatchDocumentRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[ragv1.DocumentProgress], error) {
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	return &fakeWatchStream{messages: c.messages, err: c.err}, nil
+}
+
+type fakeWatchStream struct {
+	grpc.ClientStream
+	messages []*ragv1.DocumentProgress
+	err      error
+}
+
+func (s *fakeWatchStream) Recv() (*ragv1.DocumentProgress, error) {
+	if len(s.messages) == 0 {
+		return nil, s.err
+	}
+	m := s.messages[0]
+	s.messages = s.messages[1:]
+	return m, nil
+}
+
+func serveEvents(client ragv1.DocumentServiceClient) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Get("/v1/documents/{id}/events", documentEventsHandler(client, slog.Default()))
+	req := httptest.NewRequest(http.MethodGet, "/v1/documents/doc-1/events", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDocumentEvents(t *testing.T) {
+	client := &fakeWatchClient{
+		messages: []*ragv1.DocumentProgress{
+			{DocumentId: "doc-1", Status: ragv1.DocumentStatus_DOCUMENT_STATUS_PROCESSING, Stage: ragv1.DocumentStage_DOCUMENT_STAGE_EMBEDDING, ChunksTotal: 4, ChunksEmbedded: 2},
+			{DocumentId: "doc-1", Status: ragv1.DocumentStatus_DOCUMENT_STATUS_READY},
+		},
+		err: io.EOF,
+	}
+	rec := serveEvents(client)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if n := strings.Count(body, "event: progress\n"); n != 2 {
+		t.Errorf("got %d progress events:\n%s", n, body)
+	}
+	if !strings.Contains(body, `"chunks_embedded":2`) || !strings.HasSuffix(body, "event: done\ndata: {}\n\n") {
+		t.Errorf("unexpected body:\n%s", body)
+	}
+	if got := client.md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("authorization metadata = %v", got)
+	}
+}
+
+func TestDocumentEventsNotFound(t *testing.T) {
+	rec := serveEvents(&fakeWatchClient{err: status.Error(codes.NotFound, "document not found")})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+// testToken is an unsigned JWT expiring at exp; authentication, not the
+// handler, checks signatures
+func testToken(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJIUzI1NiJ9." + payload + ".sig"
+}
+
+func TestDocumentEventsQueryToken(t *testing.T) {
+	serve := func(token string) (*httptest.ResponseRecorder, *fakeWatchClient) {
+		client := &fakeWatchClient{messages: []*ragv1.DocumentProgress{{DocumentId: "doc-1"}}, err: io.EOF}
+		router := chi.NewRouter()
+		router.Get("/v1/documents/{id}/events", documentEventsHandler(client, slog.Default()))
+		req := httptest.NewRequest(http.MethodGet, "/v1/documents/doc-1/events?access_token="+token, nil)
+		req.RemoteAddr = "203.0.113.7:51000"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec, client
+	}
+
+	token := testToken(time.Now().Add(5 * time.Minute))
+	rec, client := serve(token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("short-lived token: status = %d", rec.Code)
+	}
+	if got := client.md.Get("authorization"); len(got) != 1 || got[0] != "Bearer "+token {
+		t.Errorf("authorization metadata = %v", got)
+	}
+	if got := client.md.Get("x-forwarded-for"); len(got) != 1 || got[0] != "203.0.113.7" {
+		t.Errorf("x-forwarded-for metadata = %v, want the client address", got)
+	}
+
+	for name, token := range map[string]string{
+		"long-lived": testToken(time.Now().Add(24 * time.Hour)),
+		"malformed":  "rag_api_key",
+	} {
+		if rec, client := serve(token); rec.Code != http.StatusUnauthorized || client.md != nil {
+			t.Errorf("%s token: status = %d, want 401 without a watch", name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// defaultTLSReloadInterval is how often certificate files are checked for changes
+const defaultTLSReloadInterval = time.Minute
+
+// TLSConfig enables TLS on a server. Certificate, key and client CA files are
+// re-read when they change, so rotated certificates are picked up without a restart.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile enables mTLS: clients must present a certificate signed by one of these CAs
+	ClientCAFile string
+
+	// ReloadInterval is how often the files are checked for changes (default 1m)
+	ReloadInterval time.Duration
+}
+
+// Enabled reports whether TLS is configured
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// certReloader serves the current certificate and client CAs, reloading them
+// from disk when their modification times change
+type certReloader struct {
+	cfg    TLSConfig
+	logger *slog.Logger
+
+	mu        sync.Mutex
+	checked   time.Time
+	modTimes  []time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newCertReloader(cfg TLSConfig, logger *slog.Logger) (*certReloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("TLS requires both a certificate and a key file")
+	}
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = defaultTLSReloadInterval
+	}
+	r := &certReloader{cfg: cfg, logger: logger}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.checked = time.Now()
+	return r, nil
+}
+
+// files returns the watched paths
+func (r *certReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// statFiles returns the modification times of the watched files
+func (r *certReloader) statFiles() ([]time.Time, error) {
+	files := r.files()
+	times := make([]time.Time, len(files))
+	for i, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		times[i] = info.ModTime()
+	}
+	return times, nil
+}
+
+// load reads the certificate, key and client CAs
+func (r *certReloader) load() error {
+	modTimes, err := r.statFiles()
+	if err != nil {
+		return fmt.Errorf("failed to stat TLS files: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return fmt.Errorf("failed to parse TLS certificate: %w", err)
+		}
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in client CA file %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+	return nil
+}
+
+// current returns the certificate and client CAs, reloading them if the
+// files changed since the last check. A failed reload keeps the previous ones,
+// since a rotation may be caught halfway through writing the files.
+func (r *certReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) >= r.cfg.ReloadInterval {
+		r.checked = time.Now()
+		if times, err := r.statFiles(); err == nil && !equalTimes(times, r.modTimes) {
+			if err := r.load(); err != nil {
+				r.logger.Warn("failed to reload TLS certificate, keeping the previous one", "cert", r.cfg.CertFile, "error", err)
+			} else {
+				r.logger.Info("reloaded TLS certificate", "cert", r.cfg.CertFile, "expires", r.cert.Leaf.NotAfter)
+			}
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// serverConfig returns a TLS config that picks up reloaded files on each handshake
+func (r *certReloader) serverConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := r.current()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if clientCAs != nil {
+				// Verified by hand so the gateway, which presents the server's own
+				// certificate, is accepted alongside certificates from the client CAs
+				cfg.ClientAuth = tls.RequireAnyClientCert
+				cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+					return verifyClient(rawCerts, cert, clientCAs)
+				}
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// verifyClient accepts the server's own certificate or one that chains to the client CAs
+func verifyClient(rawCerts [][]byte, own *tls.Certificate, clientCAs *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("client certificate required")
+	}
+	if bytes.Equal(rawCerts[0], own.Certificate[0]) {
+		return nil
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
+
+// gatewayCredentials are used by the HTTP gateway to reach the gRPC server
+// over loopback. The server is trusted by pinning its current certificate,
+// which is also presented as the client certificate for mTLS.
+func (r *certReloader) gatewayCredentials() credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The loopback address rarely matches the certificate's names; the
+		// certificate itself is checked below
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			cert, _ := r.current()
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], cert.Certificate[0]) {
+				return errors.New("gRPC server certificate does not match the configured certificate")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+	})
+}
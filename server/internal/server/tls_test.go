@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate and key and returns the DER certificate
+func writeCert(t *testing.T, certFile, keyFile string, serial int64) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "rag-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := writeCert(t, certFile, keyFile, 1)
+
+	r, err := newCertReloader(TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Nanosecond}, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert, _ := r.current(); !bytes.Equal(cert.Certificate[0], first) {
+		t.Fatal("initial certificate not loaded")
+	}
+
+	// Rotate; bump the modification time in case the filesystem's resolution is coarse
+	second := writeCert(t, certFile, keyFile, 2)
+	later := time.Now().Add(time.Minute)
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, _ := r.current()
+	if !bytes.Equal(cert.Certificate[0], second) {
+		t.Fatal("rotated certificate not reloaded")
+	}
+
+	// A half-written rotation keeps the previous certificate
+	if err := os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	evenLater := later.Add(time.Minute)
+	if err := os.Chtimes(keyFile, evenLater, evenLater); err != nil {
+		t.Fatal(err)
+	}
+	if cert, _ := r.current(); !bytes.Equal(cert.Certificate[0], second) {
+		t.Fatal("failed reload replaced the certificate")
+	}
+
+	// The gateway's own certificate passes client verification without a CA match
+	if err := verifyClient([][]byte{second}, cert, x509.NewCertPool()); err != nil {
+		t.Errorf("own certificate rejected: %v", err)
+	}
+	if err := verifyClient([][]byte{first}, cert, x509.NewCertPool()); err == nil {
+		t.Error("untrusted certificate accepted")
+	}
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Compression names of TransportConfig.Compression
+const (
+	CompressionNone = ""
+	CompressionGzip = gzip.Name
+)
+
+// TransportConfig holds the message size, keepalive and compression settings
+// of the gRPC server and of the HTTP gateway's connection to it. Zero values
+// keep gRPC's defaults.
+type TransportConfig struct {
+	// MaxRecvMsgSize and MaxSendMsgSize are the largest messages, in bytes,
+	// the server receives and sends; gRPC receives at most 4 MiB by default,
+	// too little for large IngestDocument and UploadDocument payloads
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// KeepaliveTime is how long a connection is idle before it is pinged,
+	// and KeepaliveTimeout how long a ping may go unanswered before the
+	// connection is closed
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+
+	// KeepaliveMinTime is how often clients may ping the server; a client
+	// pinging more often is disconnected. PermitKeepaliveWithoutStream lets
+	// clients ping connections without active streams.
+	KeepaliveMinTime             time.Duration
+	PermitKeepaliveWithoutStream bool
+
+	// MaxConnectionIdle closes connections without streams for this long
+	MaxConnectionIdle time.Duration
+
+	// Compression is the gateway's request compression, CompressionGzip or
+	// none. The server always accepts gzip and answers in kind.
+	Compression string
+}
+
+// validate checks the settings
+func (c TransportConfig) validate() error {
+	if c.MaxRecvMsgSize < 0 || c.MaxSendMsgSize < 0 {
+		return fmt.Errorf("gRPC message sizes cannot be negative")
+	}
+	if c.Compression != CompressionNone && c.Compression != CompressionGzip {
+		return fmt.Errorf("unknown gRPC compression %q (valid: %s)", c.Compression, CompressionGzip)
+	}
+	return nil
+}
+
+// serverOptions returns the gRPC server options of the settings
+func (c TransportConfig) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if c.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(c.MaxSendMsgSize))
+	}
+	opts = append(opts,
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              c.KeepaliveTime,
+			Timeout:           c.KeepaliveTimeout,
+			MaxConnectionIdle: c.MaxConnectionIdle,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.KeepaliveMinTime,
+			PermitWithoutStream: c.PermitKeepaliveWithoutStream,
+		}),
+	)
+	return opts
+}
+
+// dialOptions returns the options of a client connection, such as the
+// gateway's, that the server's settings accept
+func (c TransportConfig) dialOptions() []grpc.DialOption {
+	// The client sends what the server receives and receives what it sends
+	var callOpts []grpc.CallOption
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxSendMsgSize))
+	}
+	if c.Compression != CompressionNone {
+		callOpts = append(callOpts, grpc.UseCompressor(c.Compression))
+	}
+
+	var opts []grpc.DialOption
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if c.KeepaliveTime > 0 {
+		// Pinging more often than the server allows gets the connection closed
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                max(c.KeepaliveTime, c.KeepaliveMinTime),
+			Timeout:             c.KeepaliveTimeout,
+			PermitWithoutStream: c.PermitKeepaliveWithoutStream,
+		}))
+	}
+	return opts
+}
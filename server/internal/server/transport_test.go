@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// checkLargeHealth sends a health check larger than gRPC's default 4 MiB limit
+func checkLargeHealth(t *testing.T, cfg TransportConfig) error {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(cfg.serverOptions()...)
+	healthServer := health.NewServer()
+	service := strings.Repeat("x", 5<<20)
+	healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, cfg.dialOptions()...)
+	conn, err := grpc.NewClient(listener.Addr().String(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	return err
+}
+
+func TestTransportConfig_MessageSize(t *testing.T) {
+	if err := checkLargeHealth(t, TransportConfig{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("default limits: error = %v, want ResourceExhausted", err)
+	}
+
+	cfg := TransportConfig{MaxRecvMsgSize: 16 << 20, MaxSendMsgSize: 16 << 20, Compression: CompressionGzip}
+	if err := checkLargeHealth(t, cfg); err != nil {
+		t.Errorf("raised limits with gzip: %v", err)
+	}
+}
+
+func TestTransportConfig_Validate(t *testing.T) {
+	if err := (TransportConfig{Compression: "brotli"}).validate(); err == nil {
+		t.Error("validate accepted an unknown compression")
+	}
+	if err := (TransportConfig{MaxRecvMsgSize: -1}).validate(); err == nil {
+		t.Error("validate accepted a negative message size")
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/config"
+	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/monitor"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// healthCheckTimeout bounds each model health probe
+	healthCheckTimeout = 10 * time.Second
+
+	// adminTenantPageSize is the page size used when walking tenants for vector counts
+	adminTenantPageSize = 100
+
+	// defaultRecordLimit is the number of records returned when no limit is given
+	defaultRecordLimit = 50
+
+	// defaultQueryMonths and maxQueryMonths bound the months of query counts
+	// in system stats
+	defaultQueryMonths = 6
+	maxQueryMonths     = 120
+)
+
+// pinger is implemented by LLM clients that support a lightweight health check
+type pinger interface {
+	Ping(ctx context.Context) error
+	ModelName() string
+}
+
+// AdminService implements ragv1.AdminServiceServer
+type AdminService struct {
+	ragv1.UnimplementedAdminServiceServer
+
+	tenantRepo  repository.TenantRepository
+	docRepo     repository.DocumentRepository
+	jobRepo     repository.ReindexJobRepository
+	vectorStore vectorstore.VectorStore
+	embedder    embedder.Embedder
+	llmClient   llm.LLM
+	recorder    *monitor.Recorder
+	adminAPIKey string
+
+	queries *QueryCounter // Optional: tenants' monthly query counts
+	configs *config.Bus   // Optional: reloads the configuration
+}
+
+// NewAdminService creates a new AdminService
+func NewAdminService(
+	tenantRepo repository.TenantRepository,
+	docRepo repository.DocumentRepository,
+	jobRepo repository.ReindexJobRepository,
+	vectorStore vectorstore.VectorStore,
+	embedder embedder.Embedder,
+	llmClient llm.LLM,
+	recorder *monitor.Recorder,
+	queries *QueryCounter,
+	configs *config.Bus,
+	adminAPIKey string,
+) *AdminService {
+	return &AdminService{
+		tenantRepo:  tenantRepo,
+		docRepo:     docRepo,
+		jobRepo:     jobRepo,
+		vectorStore: vectorStore,
+		embedder:    embedder,
+		llmClient:   llmClient,
+		recorder:    recorder,
+		adminAPIKey: adminAPIKey,
+		queries:     queries,
+		configs:     configs,
+	}
+}
+
+// GetSystemStats returns system-wide counts, queue depth and model health
+func (s *AdminService) GetSystemStats(ctx context.Context, req *ragv1.GetSystemStatsRequest) (*ragv1.SystemStats, error) {
+	if err := auth.VerifyAdminKey(ctx, s.adminAPIKey); err != nil {
+		return nil, err
+	}
+
+	docStats, err := s.docRepo.Stats(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get document stats: %v", err)
+	}
+
+	activeJobs, err := s.jobRepo.CountActive(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count reindex jobs: %v", err)
+	}
+
+	stats := &ragv1.SystemStats{
+		ChunkCount:        int32(docStats.ChunkCount),
+		DocumentsByStatus: make(map[string]int32, len(docStats.ByStatus)),
+		ActiveReindexJobs: int32(activeJobs),
+		GeneratedAt:       timestamppb.Now(),
+	}
+	for docStatus, count := range docStats.ByStatus {
+		stats.DocumentsByStatus[docStatus] = int32(count)
+		stats.DocumentCount += int32(count)
+	}
+	stats.QueueDepth = int32(docStats.ByStatus["PENDING"]+docStats.ByStatus["PROCESSING"]) + stats.ActiveReindexJobs
+
+	var tenantQueries map[uuid.UUID][]*ragv1.MonthlyQueryCount
+	if s.queries != nil {
+		months := min(int(req.QueryMonths), maxQueryMonths)
+		if months <= 0 {
+			months = defaultQueryMonths
+		}
+		stats.QueryCounts, tenantQueries, err = s.queryCounts(ctx, months)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get query counts: %v", err)
+		}
+	}
+
+	// Walk all tenants for per-collection vector counts
+	for offset := 0; ; offset += adminTenantPageSize {
+		tenants, total, err := s.tenantRepo.List(ctx, adminTenantPageSize, offset)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list tenants: %v", err)
+		}
+		stats.TenantCount = int32(total)
+
+		for _, t := range tenants {
+			ts := &ragv1.TenantVectorStats{
+				TenantId:    t.ID.String(),
+				Name:        t.Name,
+				QueryCounts: tenantQueries[t.ID],
+			}
+			count, err := s.vectorStore.CountVectors(ctx, t.ID.String())
+			if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+				ts.Error = err.Error()
+			} else {
+				ts.VectorCount = int64(count)
+				stats.VectorCount += int64(count)
+			}
+			stats.Tenants = append(stats.Tenants, ts)
+		}
+
+		if len(tenants) == 0 || offset+len(tenants) >= total {
+			break
+		}
+	}
+
+	stats.Models = s.checkModels(ctx)
+
+	return stats, nil
+}
+
+// queryCounts returns all tenants' query counts of the last months months,
+// and each tenant's, oldest first. Months without queries count zero.
+func (s *AdminService) queryCounts(ctx context.Context, months int) ([]*ragv1.MonthlyQueryCount, map[uuid.UUID][]*ragv1.MonthlyQueryCount, error) {
+	usage, err := s.queries.Monthly(ctx, months)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first := repository.QueryMonth(time.Now()).AddDate(0, 1-months, 0)
+	series := func() []*ragv1.MonthlyQueryCount {
+		counts := make([]*ragv1.MonthlyQueryCount, months)
+		for i := range counts {
+			counts[i] = &ragv1.MonthlyQueryCount{Month: first.AddDate(0, i, 0).Format("2006-01")}
+		}
+		return counts
+	}
+
+	total := series()
+	byTenant := make(map[uuid.UUID][]*ragv1.MonthlyQueryCount)
+	for _, u := range usage {
+		i := (u.Month.Year()-first.Year())*12 + int(u.Month.Month()-first.Month())
+		if i < 0 || i >= months {
+			continue
+		}
+		counts, ok := byTenant[u.TenantID]
+		if !ok {
+			counts = series()
+			byTenant[u.TenantID] = counts
+		}
+		counts[i].QueryCount += u.QueryCount
+		total[i].QueryCount += u.QueryCount
+	}
+	return total, byTenant, nil
+}
+
+// ListRecentErrors returns the most recent failed requests
+func (s *AdminService) ListRecentErrors(ctx context.Context, req *ragv1.ListRecentErrorsRequest) (*ragv1.ListRecentErrorsResponse, error) {
+	if err := auth.VerifyAdminKey(ctx, s.adminAPIKey); err != nil {
+		return nil, err
+	}
+
+	return &ragv1.ListRecentErrorsResponse{
+		Errors: recordsToProto(s.recorder.RecentErrors(recordLimit(req.Limit))),
+	}, nil
+}
+
+// ListSlowQueries returns the most recent requests that exceeded the slow request threshold
+func (s *AdminService) ListSlowQueries(ctx context.Context, req *ragv1.ListSlowQueriesRequest) (*ragv1.ListSlowQueriesResponse, error) {
+	if err := auth.VerifyAdminKey(ctx, s.adminAPIKey); err != nil {
+		return nil, err
+	}
+
+	return &ragv1.ListSlowQueriesResponse{
+		Queries: recordsToProto(s.recorder.SlowRequests(recordLimit(req.Limit))),
+	}, nil
+}
+
+// ReloadConfig reloads the configuration and reports the settings that changed
+func (s *AdminService) ReloadConfig(ctx context.Context, req *ragv1.ReloadConfigRequest) (*ragv1.ReloadConfigResponse, error) {
+	if err := auth.VerifyAdminKey(ctx, s.adminAPIKey); err != nil {
+		return nil, err
+	}
+	if s.configs == nil {
+		return nil, status.Error(codes.Unimplemented, "configuration reload is not enabled")
+	}
+
+	changes, err := s.configs.Reload()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to reload configuration: %v", err)
+	}
+
+	resp := &ragv1.ReloadConfigResponse{Changes: make([]*ragv1.ConfigChange, len(changes))}
+	for i, c := range changes {
+		resp.Changes[i] = &ragv1.ConfigChange{
+			Key:      c.Key,
+			OldValue: c.OldValue,
+			NewValue: c.NewValue,
+			Applied:  c.Applied,
+		}
+	}
+	return resp, nil
+}
+
+// checkModels probes the embedding model and LLM
+func (s *AdminService) checkModels(ctx context.Context) []*ragv1.ModelHealth {
+	var models []*ragv1.ModelHealth
+
+	if s.embedder != nil {
+		models = append(models, probeModel(ctx, s.embedder.ModelName(), "embedding", func(ctx context.Context) error {
+			_, err := s.embedder.Embed(ctx, "health check")
+			return err
+		}))
+	}
+
+	if p, ok := s.llmClient.(pinger); ok {
+		models = append(models, probeModel(ctx, p.ModelName(), "llm", p.Ping))
+	}
+
+	return models
+}
+
+// probeModel runs a single health check with a timeout and records its latency
+func probeModel(ctx context.Context, name, kind string, check func(context.Context) error) *ragv1.ModelHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+
+	health := &ragv1.ModelHealth{
+		Name:      name,
+		Kind:      kind,
+		Healthy:   err == nil,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// recordLimit applies the default record limit
+func recordLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultRecordLimit
+	}
+	return int(limit)
+}
+
+// recordsToProto converts monitor records to proto RequestRecords
+func recordsToProto(records []monitor.Record) []*ragv1.RequestRecord {
+	result := make([]*ragv1.RequestRecord, len(records))
+	for i, r := range records {
+		result[i] = &ragv1.RequestRecord{
+			Method:     r.Method,
+			Code:       r.Code,
+			DurationMs: r.Duration.Milliseconds(),
+			Error:      r.Error,
+			TenantId:   r.TenantID,
+			Query:      r.Query,
+			Time:       timestamppb.New(r.Time),
+		}
+	}
+	return result
+}
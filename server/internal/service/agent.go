@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultAgentSteps and maxAgentSteps bound an agentic query's tool calls
+	defaultAgentSteps = 3
+	maxAgentSteps     = 10
+
+	// maxAgentChunks caps the context an agentic query gathers
+	maxAgentChunks = 24
+
+	// agentExcerptRunes is how much of each chunk the LLM sees when choosing
+	// its next step; the answer is generated from whole chunks
+	agentExcerptRunes = 400
+)
+
+// Agent actions: the retrieval tools, and ending the loop
+const (
+	agentToolSearch        = "search"
+	agentToolFetchDocument = "fetch_document"
+	agentActionAnswer      = "answer"
+)
+
+// agentSystemPrompt instructs the LLM choosing an agentic query's steps
+const agentSystemPrompt = `You gather context from a document collection so a question can be answered. You do not answer it yourself.
+Choose the next action:
+- "search": search the collection with "query", e.g. a rephrasing, a narrower question or a term found in the context
+- "fetch_document": read a whole document, given the "document_id" shown in the context
+- "answer": stop, because the context answers the question or more steps would not help
+Reply with JSON only.`
+
+// agentActionSchema constrains the LLM's choice of action
+var agentActionSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "action": {"type": "string", "enum": ["search", "fetch_document", "answer"]},
+    "query": {"type": "string"},
+    "document_id": {"type": "string"}
+  },
+  "required": ["action"]
+}`)
+
+// agentAction is the LLM's choice of next step
+type agentAction struct {
+	Action     string `json:"action"`
+	Query      string `json:"query"`
+	DocumentID string `json:"document_id"`
+}
+
+// agentRun is what an agentic query's tool calls did
+type agentRun struct {
+	steps []*ragv1.AgentStep
+	usage generationUsage // of choosing the steps
+}
+
+// agentMaxSteps returns how many tool calls a tenant's agentic queries may make
+func agentMaxSteps(tenant *repository.Tenant) int {
+	if tenant.Config.Agent.MaxSteps > 0 {
+		return tenant.Config.Agent.MaxSteps
+	}
+	return defaultAgentSteps
+}
+
+// runAgent lets the LLM refine retrieval before the answer: at each step it
+// searches with a new query, reads a whole document, or stops. Chunks found
+// are appended to r's results after the context guardrails screen them, and
+// onStep, when set, sees each call as it completes. A failed LLM call ends
+// the loop, leaving the answer to what was gathered.
+func (s *RAGService) runAgent(ctx context.Context, tenant *repository.Tenant, query string, options queryOptions, filter vectorstore.Filter, boost repository.ScoreBoostConfig, r *queryRetrieval, onStep func(*ragv1.AgentStep) error) (agentRun, error) {
+	var run agentRun
+	info := s.modelInfo(options.model)
+	seen := make(map[string]bool, len(r.results))
+	for _, result := range r.results {
+		seen[result.ID] = true
+	}
+
+	maxSteps := agentMaxSteps(tenant)
+	for len(run.steps) < maxSteps && len(r.results) < maxAgentChunks {
+		if err := ctx.Err(); err != nil {
+			return run, status.FromContextError(err).Err()
+		}
+
+		agentPrompt := buildAgentPrompt(query, r.results, run.steps, maxSteps-len(run.steps))
+		raw, err := s.llmClient.GenerateStructured(ctx, agentPrompt, agentActionSchema, llm.GenerateOptions{
+			Model:        options.model,
+			Fallbacks:    tenant.Config.LLMFallbackModels,
+			SystemPrompt: agentSystemPrompt,
+			MaxTokens:    256,
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "agent step failed, answering from gathered context", "tenant_id", tenant.ID, "error", err)
+			break
+		}
+		run.usage = run.usage.plus(newUsage(info, prompt.EstimateTokens(agentSystemPrompt+agentPrompt), prompt.EstimateTokens(string(raw))))
+
+		var action agentAction
+		if err := json.Unmarshal(raw, &action); err != nil || action.Action == agentActionAnswer {
+			break
+		}
+
+		step := &ragv1.AgentStep{Step: int32(len(run.steps) + 1), Tool: action.Action}
+		var found []vectorstore.SearchResult
+		var events []*ragv1.GuardrailEvent
+		switch action.Action {
+		case agentToolSearch:
+			step.Query = action.Query
+			found, events, err = s.agentSearch(ctx, tenant, action.Query, options, filter, boost)
+		case agentToolFetchDocument:
+			step.DocumentId = action.DocumentID
+			found, events, err = s.agentFetchDocument(ctx, tenant, action.DocumentID, options, filter)
+		default:
+			err = fmt.Errorf("unknown tool %q", action.Action)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return run, status.FromContextError(ctx.Err()).Err()
+			}
+			step.Error = status.Convert(err).Message()
+		}
+
+		r.guardEvents = append(r.guardEvents, events...)
+		for _, result := range found {
+			if seen[result.ID] || len(r.results) >= maxAgentChunks {
+				continue
+			}
+			seen[result.ID] = true
+			r.results = append(r.results, result)
+			step.ChunksAdded++
+		}
+
+		run.steps = append(run.steps, step)
+		if onStep != nil {
+			if err := onStep(step); err != nil {
+				return run, err
+			}
+		}
+	}
+	return run, nil
+}
+
+// agentSearch runs the query's retrieval for a refined query
+func (s *RAGService) agentSearch(ctx context.Context, tenant *repository.Tenant, query string, options queryOptions, filter vectorstore.Filter, boost repository.ScoreBoostConfig) ([]vectorstore.SearchResult, []*ragv1.GuardrailEvent, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, fmt.Errorf("query is required")
+	}
+	retrieval, err := s.retrieveForQuery(ctx, tenant, query, options, filter, boost, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return retrieval.results, retrieval.guardEvents, nil
+}
+
+// agentFetchDocument returns the first chunks of one of the tenant's
+// documents that the query's filter allows, screened by the context
+// guardrails. The chunks carry no similarity score, so they are the first
+// left out when the prompt must shrink to fit.
+func (s *RAGService) agentFetchDocument(ctx context.Context, tenant *repository.Tenant, id string, options queryOptions, filter vectorstore.Filter) ([]vectorstore.SearchResult, []*ragv1.GuardrailEvent, error) {
+	docID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid document ID %q", id)
+	}
+	doc, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil || doc.TenantID != tenant.ID || !documentMatchesFilter(doc, filter) {
+		return nil, nil, fmt.Errorf("document not found: %s", id)
+	}
+	chunks, err := s.docRepo.GetChunks(ctx, docID, maxParentChunks, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get chunks: %v", err)
+	}
+
+	results := make([]vectorstore.SearchResult, len(chunks))
+	for i, chunk := range chunks {
+		results[i] = vectorstore.SearchResult{
+			ID:         chunk.ID.String(),
+			DocumentID: chunk.DocumentID.String(),
+			Content:    chunk.Content,
+			Metadata:   chunkMetadata(chunk, doc.Title, doc.Source),
+		}
+	}
+	return guardChunks(ctx, options.guardrails, results)
+}
+
+// documentMatchesFilter reports whether a document has one of the filter's
+// tags, is in one of its collections, is in one of its languages and belongs
+// to its project, where the filter names any
+func documentMatchesFilter(doc *repository.Document, filter vectorstore.Filter) bool {
+	if len(filter.Tags) > 0 && !slices.ContainsFunc(doc.Tags, func(tag string) bool {
+		return slices.Contains(filter.Tags, tag)
+	}) {
+		return false
+	}
+	if len(filter.CollectionIDs) > 0 && !slices.ContainsFunc(doc.CollectionIDs, func(id uuid.UUID) bool {
+		return slices.Contains(filter.CollectionIDs, id.String())
+	}) {
+		return false
+	}
+	if len(filter.Languages) > 0 && !slices.Contains(filter.Languages, doc.Metadata[ingestion.MetadataLanguage]) {
+		return false
+	}
+	if filter.ProjectID != "" && projectIDString(doc.ProjectID) != filter.ProjectID {
+		return false
+	}
+	return true
+}
+
+// buildAgentPrompt shows the LLM the question, excerpts of the context
+// gathered so far and the steps already taken
+func buildAgentPrompt(query string, results []vectorstore.SearchResult, steps []*ragv1.AgentStep, stepsLeft int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Question: %s\n\nContext so far:\n", query)
+	if len(results) == 0 {
+		b.WriteString("(nothing found)\n")
+	}
+	for i, result := range results {
+		content := result.Content
+		if runes := []rune(content); len(runes) > agentExcerptRunes {
+			content = string(runes[:agentExcerptRunes]) + " …"
+		}
+		fmt.Fprintf(&b, "[%d] document_id: %s, title: %q\n%s\n\n", i+1, result.DocumentID, result.Metadata["title"], content)
+	}
+
+	if len(steps) > 0 {
+		b.WriteString("Steps taken:\n")
+		for _, step := range steps {
+			switch step.Tool {
+			case agentToolSearch:
+				fmt.Fprintf(&b, "%d. search %q", step.Step, step.Query)
+			default:
+				fmt.Fprintf(&b, "%d. %s %s", step.Step, step.Tool, step.DocumentId)
+			}
+			if step.Error != "" {
+				fmt.Fprintf(&b, ": failed: %s\n", step.Error)
+			} else {
+				fmt.Fprintf(&b, ": %d new chunks\n", step.ChunksAdded)
+			}
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Steps left: %d", stepsLeft)
+	return b.String()
+}
+
+// agentFromProto converts proto AgentConfig to repository AgentConfig
+func agentFromProto(p *ragv1.AgentConfig) repository.AgentConfig {
+	return repository.AgentConfig{MaxSteps: int(p.GetMaxSteps())}
+}
+
+// agentToProto converts a repository AgentConfig to proto AgentConfig
+func agentToProto(c repository.AgentConfig) *ragv1.AgentConfig {
+	return &ragv1.AgentConfig{MaxSteps: int32(c.MaxSteps)}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/analysis"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// maxTenantStopwords caps a tenant's own stopwords
+const maxTenantStopwords = 500
+
+// cachedVectorizer is a tenant's sparse vectorizer and the settings it was
+// built with
+type cachedVectorizer struct {
+	cfg        repository.TextAnalysisConfig
+	vectorizer SparseVectorizer
+}
+
+// sparseVectorizer returns the vectorizer of a tenant's sparse vectors: one
+// built with its text analysis settings, or the server's without them
+func (s *RAGService) sparseVectorizer(tenant *repository.Tenant) SparseVectorizer {
+	cfg := tenant.Config.TextAnalysis
+	if textAnalysisUnset(cfg) {
+		return s.sparseModel
+	}
+	if cached, ok := s.analyzerCache.Load(tenant.ID); ok && textAnalysisEqual(cached.(cachedVectorizer).cfg, cfg) {
+		return cached.(cachedVectorizer).vectorizer
+	}
+	v := analysis.NewVectorizer(analysis.Config{
+		Language:        cfg.Language,
+		RemoveStopwords: cfg.RemoveStopwords,
+		Stopwords:       cfg.Stopwords,
+		Stemming:        cfg.Stemming,
+		CaseSensitive:   cfg.CaseSensitive,
+	})
+	s.analyzerCache.Store(tenant.ID, cachedVectorizer{cfg: cfg, vectorizer: v})
+	return v
+}
+
+// textSearchConfig returns the Postgres text search configuration of a
+// tenant's keyword search: the language's, which drops stopwords and stems,
+// when either is asked for and Postgres has one, and "" for "simple"
+// otherwise. Tenant stopwords and case sensitivity apply to sparse vectors only.
+func textSearchConfig(cfg repository.TextAnalysisConfig) string {
+	if !cfg.RemoveStopwords && !cfg.Stemming {
+		return ""
+	}
+	switch cfg.Language {
+	case analysis.English:
+		return "english"
+	case analysis.German:
+		return "german"
+	}
+	return ""
+}
+
+// textAnalysisUnset reports whether a tenant keeps the server's sparse vectorizer
+func textAnalysisUnset(cfg repository.TextAnalysisConfig) bool {
+	return textAnalysisEqual(cfg, repository.TextAnalysisConfig{})
+}
+
+func textAnalysisEqual(a, b repository.TextAnalysisConfig) bool {
+	return a.Language == b.Language && a.RemoveStopwords == b.RemoveStopwords &&
+		a.Stemming == b.Stemming && a.CaseSensitive == b.CaseSensitive &&
+		slices.Equal(a.Stopwords, b.Stopwords)
+}
+
+// validateTextAnalysis checks a tenant's text analysis settings
+func validateTextAnalysis(cfg repository.TextAnalysisConfig) error {
+	if cfg.Language != "" && !validLanguageCode(cfg.Language) {
+		return fmt.Errorf("text_analysis language %q must be an ISO 639-1 code", cfg.Language)
+	}
+	if cfg.Stemming && !analysis.SupportsStemming(cfg.Language) {
+		return fmt.Errorf("text_analysis stemming is available for languages %q and %q", analysis.English, analysis.German)
+	}
+	if len(cfg.Stopwords) > maxTenantStopwords {
+		return fmt.Errorf("text_analysis allows at most %d stopwords", maxTenantStopwords)
+	}
+	for _, w := range cfg.Stopwords {
+		if strings.TrimSpace(w) == "" {
+			return fmt.Errorf("text_analysis stopwords cannot be empty")
+		}
+	}
+	return nil
+}
+
+// textAnalysisFromProto converts a proto TextAnalysisConfig
+func textAnalysisFromProto(p *ragv1.TextAnalysisConfig) repository.TextAnalysisConfig {
+	return repository.TextAnalysisConfig{
+		Language:        strings.ToLower(strings.TrimSpace(p.Language)),
+		RemoveStopwords: p.RemoveStopwords,
+		Stopwords:       p.Stopwords,
+		Stemming:        p.Stemming,
+		CaseSensitive:   p.CaseSensitive,
+	}
+}
+
+// textAnalysisToProto converts a repository TextAnalysisConfig to proto TextAnalysisConfig
+func textAnalysisToProto(c repository.TextAnalysisConfig) *ragv1.TextAnalysisConfig {
+	return &ragv1.TextAnalysisConfig{
+		Language:        c.Language,
+		RemoveStopwords: c.RemoveStopwords,
+		Stopwords:       c.Stopwords,
+		Stemming:        c.Stemming,
+		CaseSensitive:   c.CaseSensitive,
+	}
+}
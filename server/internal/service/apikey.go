@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// apiKeyPrefixLen is how much of a scoped key ListAPIKeys shows
+const apiKeyPrefixLen = 8
+
+// CreateAPIKey creates a scoped API key for a tenant, bound to one of its
+// projects when one is given
+func (s *TenantService) CreateAPIKey(ctx context.Context, req *ragv1.CreateAPIKeyRequest) (*ragv1.APIKey, error) {
+	if s.apiKeyRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "scoped API keys are not enabled")
+	}
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown scope %q; valid scopes are %v", scope, auth.Scopes)
+		}
+	}
+
+	if _, err := s.repo.GetByID(ctx, tenantID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if project != nil && slices.Contains(req.Scopes, auth.ScopeAdmin) {
+		return nil, status.Error(codes.InvalidArgument, "project API keys can't have the admin scope")
+	}
+
+	apiKey, err := generateAPIKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate API key: %v", err)
+	}
+
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	key := &repository.APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      req.Name,
+		Key:       apiKey,
+		Scopes:    slices.Compact(scopes),
+		ProjectID: projectID(project),
+		CreatedAt: time.Now(),
+	}
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create API key: %v", err)
+	}
+
+	// The key is only ever returned here
+	resp := apiKeyToProto(key)
+	resp.ApiKey = key.Key
+	return resp, nil
+}
+
+// ListAPIKeys lists a tenant's scoped API keys
+func (s *TenantService) ListAPIKeys(ctx context.Context, req *ragv1.ListAPIKeysRequest) (*ragv1.ListAPIKeysResponse, error) {
+	if s.apiKeyRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "scoped API keys are not enabled")
+	}
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.List(ctx, tenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list API keys: %v", err)
+	}
+
+	resp := &ragv1.ListAPIKeysResponse{ApiKeys: make([]*ragv1.APIKey, len(keys))}
+	for i, key := range keys {
+		resp.ApiKeys[i] = apiKeyToProto(key)
+	}
+	return resp, nil
+}
+
+// DeleteAPIKey revokes a scoped API key
+func (s *TenantService) DeleteAPIKey(ctx context.Context, req *ragv1.DeleteAPIKeyRequest) (*ragv1.DeleteAPIKeyResponse, error) {
+	if s.apiKeyRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "scoped API keys are not enabled")
+	}
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid API key ID format")
+	}
+
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "API key not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get API key: %v", err)
+	}
+	if key.TenantID != tenantID {
+		return nil, status.Error(codes.NotFound, "API key not found")
+	}
+
+	if err := s.apiKeyRepo.Delete(ctx, id); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to delete API key: %v", err)
+	}
+
+	return &ragv1.DeleteAPIKeyResponse{
+		Success: true,
+	}, nil
+}
+
+// apiKeyToProto converts a scoped key without the key itself
+func apiKeyToProto(key *repository.APIKey) *ragv1.APIKey {
+	prefix := key.Key
+	if len(prefix) > apiKeyPrefixLen {
+		prefix = prefix[:apiKeyPrefixLen]
+	}
+	return &ragv1.APIKey{
+		Id:        key.ID.String(),
+		TenantId:  key.TenantID.String(),
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		KeyPrefix: prefix,
+		ProjectId: projectIDString(key.ProjectID),
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+}
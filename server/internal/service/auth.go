@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// requestTenantID returns the tenant a request acts on. When the request was
+// authenticated with a tenant API key the tenant comes from the key, and a
+// tenant_id in the request must agree with it; otherwise the field is used as is.
+func requestTenantID(ctx context.Context, requested string) (uuid.UUID, error) {
+	if tenant, ok := auth.TenantFromContext(ctx); ok {
+		if requested != "" && requested != tenant.ID.String() {
+			return uuid.Nil, status.Error(codes.PermissionDenied, "tenant_id does not match API key")
+		}
+		return tenant.ID, nil
+	}
+
+	if requested == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+	tenantID, err := uuid.Parse(requested)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+	}
+	return tenantID, nil
+}
+
+// canAccess reports whether the caller may act on a resource owned by tenantID.
+// Only tenant-authenticated callers are restricted; callers should answer
+// NotFound rather than PermissionDenied so other tenants' IDs are not confirmed.
+func canAccess(ctx context.Context, tenantID uuid.UUID) bool {
+	tenant, ok := auth.TenantFromContext(ctx)
+	return !ok || tenant.ID == tenantID
+}
+
+// AuthService implements ragv1.AuthServiceServer
+type AuthService struct {
+	ragv1.UnimplementedAuthServiceServer
+
+	tenantRepo repository.TenantRepository
+	apiKeyRepo repository.APIKeyRepository
+	jwt        *auth.JWTManager
+}
+
+// NewAuthService creates a new AuthService. Tokens exchanged for a scoped
+// API key carry that key's scopes.
+func NewAuthService(tenantRepo repository.TenantRepository, apiKeyRepo repository.APIKeyRepository, jwt *auth.JWTManager) *AuthService {
+	return &AuthService{
+		tenantRepo: tenantRepo,
+		apiKeyRepo: apiKeyRepo,
+		jwt:        jwt,
+	}
+}
+
+// IssueToken exchanges a tenant API key for a JWT
+func (s *AuthService) IssueToken(ctx context.Context, req *ragv1.IssueTokenRequest) (*ragv1.TokenResponse, error) {
+	apiKey := req.ApiKey
+	if apiKey == "" {
+		var err error
+		if apiKey, err = auth.APIKeyFromContext(ctx); err != nil {
+			return nil, status.Error(codes.Unauthenticated, "api_key is required")
+		}
+	}
+	if req.TtlSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds must not be negative")
+	}
+
+	tenant, key, err := auth.ResolveAPIKey(ctx, s.tenantRepo, s.apiKeyRepo, apiKey)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to validate API key: %v", err)
+	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeRead); err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.jwt.IssueTenantToken(tenant, key, time.Duration(req.TtlSeconds)*time.Second)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to issue token: %v", err)
+	}
+
+	return tokenResponse(token, expiresAt, tenant.ID), nil
+}
+
+// RefreshToken issues a new JWT for a valid token or one that recently expired
+func (s *AuthService) RefreshToken(ctx context.Context, req *ragv1.RefreshTokenRequest) (*ragv1.TokenResponse, error) {
+	token := req.Token
+	if token == "" {
+		var ok bool
+		if token, ok = auth.BearerToken(ctx); !ok {
+			return nil, status.Error(codes.Unauthenticated, "token is required")
+		}
+	}
+
+	claims, err := s.jwt.ValidateForRefresh(token)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, "token expired too long ago; issue a new one with the API key")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	// The tenant must still exist with the key the token was issued for
+	tenant, key, err := auth.TenantForClaims(ctx, s.tenantRepo, s.apiKeyRepo, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeRead); err != nil {
+		return nil, err
+	}
+
+	refreshed, expiresAt, err := s.jwt.IssueTenantToken(tenant, key, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to issue token: %v", err)
+	}
+
+	return tokenResponse(refreshed, expiresAt, tenant.ID), nil
+}
+
+func tokenResponse(token string, expiresAt time.Time, tenantID uuid.UUID) *ragv1.TokenResponse {
+	return &ragv1.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   timestamppb.New(expiresAt),
+		TenantId:    tenantID.String(),
+	}
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ingestedAtField is the chunk metadata key holding when the chunk was stored
+const ingestedAtField = "ingested_at"
+
+// resolveScoreBoost returns the request's score boost if it sets one and the
+// tenant's otherwise
+func resolveScoreBoost(tenant *repository.Tenant, override *ragv1.ScoreBoostConfig) (repository.ScoreBoostConfig, error) {
+	if override == nil {
+		return tenant.Config.ScoreBoost, nil
+	}
+	boost := scoreBoostFromProto(override)
+	if err := validateScoreBoost(boost); err != nil {
+		return boost, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return boost, nil
+}
+
+// validateScoreBoost checks a score boost's ranges
+func validateScoreBoost(b repository.ScoreBoostConfig) error {
+	if b.RecencyHalfLifeDays < 0 {
+		return fmt.Errorf("score_boost recency_half_life_days cannot be negative")
+	}
+	if b.RecencyFloor < 0 || b.RecencyFloor > 1 {
+		return fmt.Errorf("score_boost recency_floor must be between 0 and 1")
+	}
+	if b.DepthWeight < 0 {
+		return fmt.Errorf("score_boost depth_weight cannot be negative")
+	}
+	if b.PreferredLanguage != "" && !validLanguageCode(b.PreferredLanguage) {
+		return fmt.Errorf("score_boost preferred_language must be an ISO 639-1 code")
+	}
+	if b.LanguageWeight < 0 {
+		return fmt.Errorf("score_boost language_weight cannot be negative")
+	}
+	return nil
+}
+
+// boostEnabled reports whether a score boost changes any score
+func boostEnabled(b repository.ScoreBoostConfig) bool {
+	return b.RecencyHalfLifeDays > 0 || (b.PriorityField != "" && b.PriorityWeight != 0) || b.DepthWeight > 0 ||
+		(b.PreferredLanguage != "" && b.LanguageWeight > 0)
+}
+
+// applyScoreBoost re-scores results by document age, priority, crawl depth
+// and language and sorts them by the new scores. Results without a usable
+// date, priority, depth or language keep that part of their score.
+func applyScoreBoost(results []vectorstore.SearchResult, b repository.ScoreBoostConfig, now time.Time) []vectorstore.SearchResult {
+	if !boostEnabled(b) || len(results) == 0 {
+		return results
+	}
+
+	for i := range results {
+		factor := 1.0
+		if b.RecencyHalfLifeDays > 0 {
+			if date, ok := resultDate(results[i].Metadata, b.DateField); ok {
+				ageDays := max(now.Sub(date).Hours()/24, 0)
+				decay := math.Pow(0.5, ageDays/b.RecencyHalfLifeDays)
+				factor *= b.RecencyFloor + (1-b.RecencyFloor)*decay
+			}
+		}
+		if b.PriorityField != "" && b.PriorityWeight != 0 {
+			if priority, err := strconv.ParseFloat(results[i].Metadata[b.PriorityField], 64); err == nil {
+				factor *= max(1+b.PriorityWeight*priority, 0)
+			}
+		}
+		if b.DepthWeight > 0 {
+			if depth, err := strconv.Atoi(results[i].Metadata[crawlDepthField]); err == nil && depth > 0 {
+				factor /= 1 + b.DepthWeight*float64(depth)
+			}
+		}
+		if b.PreferredLanguage != "" && b.LanguageWeight > 0 {
+			if lang := results[i].Metadata[ingestion.MetadataLanguage]; lang != "" && lang != b.PreferredLanguage {
+				factor /= 1 + b.LanguageWeight
+			}
+		}
+		results[i].Score = float32(float64(results[i].Score) * factor)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// resultDate reads a result's document date from metadata
+func resultDate(metadata map[string]string, field string) (time.Time, bool) {
+	fields := []string{field}
+	if field == "" {
+		fields = []string{ingestion.MetadataPublishedAt, ingestedAtField}
+	}
+	for _, f := range fields {
+		value := metadata[f]
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+		if t, err := time.Parse(time.DateOnly, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// scoreBoostFromProto converts a proto ScoreBoostConfig
+func scoreBoostFromProto(p *ragv1.ScoreBoostConfig) repository.ScoreBoostConfig {
+	if p == nil {
+		return repository.ScoreBoostConfig{}
+	}
+	return repository.ScoreBoostConfig{
+		RecencyHalfLifeDays: float64(p.RecencyHalfLifeDays),
+		RecencyFloor:        float64(p.RecencyFloor),
+		DateField:           p.DateField,
+		PriorityField:       p.PriorityField,
+		PriorityWeight:      float64(p.PriorityWeight),
+		DepthWeight:         float64(p.DepthWeight),
+		PreferredLanguage:   strings.ToLower(strings.TrimSpace(p.PreferredLanguage)),
+		LanguageWeight:      float64(p.LanguageWeight),
+	}
+}
+
+// scoreBoostToProto converts a repository ScoreBoostConfig to proto ScoreBoostConfig
+func scoreBoostToProto(b repository.ScoreBoostConfig) *ragv1.ScoreBoostConfig {
+	return &ragv1.ScoreBoostConfig{
+		RecencyHalfLifeDays: float32(b.RecencyHalfLifeDays),
+		RecencyFloor:        float32(b.RecencyFloor),
+		DateField:           b.DateField,
+		PriorityField:       b.PriorityField,
+		PriorityWeight:      float32(b.PriorityWeight),
+		DepthWeight:         float32(b.DepthWeight),
+		PreferredLanguage:   b.PreferredLanguage,
+		LanguageWeight:      float32(b.LanguageWeight),
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+// WithCheckpointing commits the chunks of documents with at least minChunks
+// chunks before embedding them, and records after each embedding batch how
+// many have vectors, so ResumeIngestion can finish them after a crash. Such
+// documents are not ingested atomically: their first vectors are searchable
+// before they are READY. Zero disables checkpointing.
+func WithCheckpointing(minChunks int) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.checkpointMinChunks = minChunks
+	}
+}
+
+// checkpointed reports whether a document's processing should be checkpointed;
+// re-chunked documents keep their old chunks until the new ones are ready,
+// so they are always replaced in one transaction
+func (s *DocumentService) checkpointed(chunks, previousChunks int) bool {
+	return s.checkpointMinChunks > 0 && chunks >= s.checkpointMinChunks && previousChunks == 0
+}
+
+// processCheckpointed stores a large document's chunks, then embeds and
+// upserts them a batch at a time from checkpoint.ChunksEmbedded, saving the
+// checkpoint after each batch
+func (s *DocumentService) processCheckpointed(ctx context.Context, doc *repository.Document, docChunks []*repository.DocumentChunk, checkpoint *repository.IngestionCheckpoint, tenant *repository.Tenant) {
+	// Chunks and their checkpoint commit together, so a checkpoint always
+	// has its chunks
+	if checkpoint == nil {
+		checkpoint = &repository.IngestionCheckpoint{DocumentID: doc.ID, ChunksTotal: len(docChunks)}
+		var failure string
+		err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+			if err := s.shareChunks(ctx, tenant, doc.ProjectID, docChunks); err != nil {
+				failure = fmt.Sprintf("failed to share chunks: %v", err)
+				return err
+			}
+			if err := s.docRepo.CreateChunks(ctx, docChunks); err != nil {
+				failure = fmt.Sprintf("failed to store chunks: %v", err)
+				return err
+			}
+			if err := s.docRepo.SaveCheckpoint(ctx, checkpoint); err != nil {
+				failure = fmt.Sprintf("failed to save checkpoint: %v", err)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			if failure == "" {
+				failure = err.Error()
+			}
+			doc.ChunkCount = 0
+			s.markDocumentFailed(ctx, doc, failure)
+			return
+		}
+	}
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_CHUNKED, len(docChunks), 0)
+
+	emb := s.embedderFor(tenant)
+	own := ownVectors(docChunks)
+	shared := len(docChunks) - len(own)
+	remaining := own[checkpoint.ChunksEmbedded:]
+	contents := make([]string, len(remaining))
+	for i, chunk := range remaining {
+		contents[i] = chunk.Content
+	}
+	var storeFailure error
+	err := s.embedInBatches(ctx, emb, contents, func(start int, embeddings [][]float32) error {
+		batch := remaining[start : start+len(embeddings)]
+
+		// Tags and collections may change while a large document processes
+		if current, err := s.docRepo.GetByID(ctx, doc.ID); err == nil {
+			doc.Tags = current.Tags
+			doc.CollectionIDs = current.CollectionIDs
+		}
+		if err := s.vectorDB.Upsert(ctx, doc.TenantID.String(), buildVectorChunks(doc, batch, embeddings)); err != nil {
+			storeFailure = err
+			return err
+		}
+
+		checkpoint.ChunksEmbedded += len(batch)
+		if err := s.docRepo.SaveCheckpoint(ctx, checkpoint); err != nil {
+			// The batch is only re-embedded if the server stops before the next save
+			slog.WarnContext(ctx, "failed to save ingestion checkpoint", "document_id", doc.ID, "error", err)
+		}
+		s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_EMBEDDING, len(docChunks), shared+checkpoint.ChunksEmbedded)
+		return nil
+	})
+	if storeFailure != nil {
+		s.failCheckpointed(ctx, doc, tenant, fmt.Sprintf("vector storage failed: %v", storeFailure))
+		return
+	}
+	if err != nil {
+		s.failCheckpointed(ctx, doc, tenant, fmt.Sprintf("embedding failed: %v", err))
+		return
+	}
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED, len(docChunks), len(docChunks))
+
+	var failure string
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		doc.Status = "READY"
+		doc.ChunkCount = len(docChunks)
+		doc.UpdatedAt = time.Now()
+		if err := s.docRepo.Update(ctx, doc); err != nil {
+			failure = fmt.Sprintf("failed to update document: %v", err)
+			return err
+		}
+		usage := repository.TenantUsage{DocumentCount: 1, ChunkCount: len(docChunks)}
+		if err := s.tenantRepo.UpdateUsage(ctx, doc.TenantID, usage); err != nil {
+			failure = fmt.Sprintf("failed to update usage: %v", err)
+			return err
+		}
+		if tenant.Config.DedupChunks {
+			if err := syncSharedLists(ctx, s.docRepo, s.vectorDB, doc.TenantID, doc.ID, nil); err != nil {
+				failure = err.Error()
+				return err
+			}
+		}
+		return s.docRepo.DeleteCheckpoint(ctx, doc.ID)
+	})
+	if err != nil {
+		if failure == "" {
+			failure = err.Error()
+		}
+		s.failCheckpointed(ctx, doc, tenant, failure)
+		return
+	}
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED, doc.ChunkCount, doc.ChunkCount)
+	s.extractGraph(ctx, doc, docChunks, tenant)
+	s.indexSummaries(ctx, doc, docChunks, nil, tenant)
+}
+
+// failCheckpointed removes a checkpointed document's stored chunks, vectors
+// and checkpoint, and marks it failed
+func (s *DocumentService) failCheckpointed(ctx context.Context, doc *repository.Document, tenant *repository.Tenant, failure string) {
+	if interrupted(ctx) {
+		return // Its checkpoint resumes it
+	}
+	err := s.vectorDB.Delete(ctx, doc.TenantID.String(), doc.ID.String())
+	if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+		slog.WarnContext(ctx, "failed to delete vectors of failed document", "document_id", doc.ID, "error", err)
+	}
+	if err := s.releaseChunks(ctx, tenant, doc.ID); err != nil {
+		slog.WarnContext(ctx, "failed to release shared chunks", "document_id", doc.ID, "error", err)
+	}
+	_ = s.docRepo.DeleteChunks(ctx, doc.ID)
+	_ = s.docRepo.DeleteCheckpoint(ctx, doc.ID)
+	doc.ChunkCount = 0
+	s.markDocumentFailed(ctx, doc, failure)
+}
+
+// ResumeIngestion continues processing every checkpointed document left
+// unfinished by a previous process, in the background, and restarts those
+// Drain suspended before their chunks were stored. It is meant to be called
+// once at startup, before any new ingestion.
+func (s *DocumentService) ResumeIngestion(ctx context.Context) error {
+	checkpoints, err := s.docRepo.ListCheckpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, checkpoint := range checkpoints {
+		// Uploads are suspended while still PENDING extraction
+		restart := checkpoint.ChunksTotal == 0
+		doc, err := s.docRepo.GetByID(ctx, checkpoint.DocumentID)
+		if err != nil || (doc.Status != "PROCESSING" && !(restart && doc.Status == "PENDING")) {
+			_ = s.docRepo.DeleteCheckpoint(ctx, checkpoint.DocumentID)
+			continue
+		}
+		tenant, err := s.tenantRepo.GetByID(ctx, doc.TenantID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to resume ingestion", "document_id", doc.ID, "error", err)
+			continue
+		}
+		if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+			// The checkpoint is kept, to resume once the tenant is active again
+			slog.InfoContext(ctx, "not resuming ingestion", "document_id", doc.ID, "tenant_id", tenant.ID, "status", tenant.Status)
+			continue
+		}
+		if restart {
+			// Suspended by Drain before its chunks were stored
+			s.restartIngestion(context.Background(), doc, tenant)
+			continue
+		}
+		docChunks, err := s.docRepo.GetChunks(ctx, doc.ID, checkpoint.ChunksTotal, 0)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to resume ingestion", "document_id", doc.ID, "error", err)
+			continue
+		}
+
+		bg := context.Background()
+		if len(docChunks) != checkpoint.ChunksTotal {
+			s.failCheckpointed(bg, doc, tenant, "interrupted ingestion lost chunks")
+			continue
+		}
+		slog.InfoContext(ctx, "resuming ingestion", "document_id", doc.ID,
+			"chunks_embedded", checkpoint.ChunksEmbedded, "chunks_total", checkpoint.ChunksTotal)
+		s.goIngest(doc, tenant, func(ctx context.Context) {
+			s.processCheckpointed(ctx, doc, docChunks, checkpoint, tenant)
+		})
+	}
+	return nil
+}
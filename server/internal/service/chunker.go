@@ -0,0 +1,115 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mergeChunkerConfig returns base with the positive fields and method of
+// override applied
+func mergeChunkerConfig(base, override repository.ChunkerConfig) repository.ChunkerConfig {
+	if override.Method != "" {
+		base.Method = override.Method
+	}
+	if override.TargetSize > 0 {
+		base.TargetSize = override.TargetSize
+	}
+	if override.MaxSize > 0 {
+		base.MaxSize = override.MaxSize
+	}
+	// Overlap and OverlapPercent are alternatives: setting one clears the
+	// other, and OverlapPercent wins when both are set
+	if override.Overlap > 0 {
+		base.Overlap = override.Overlap
+		base.OverlapPercent = 0
+	}
+	if override.OverlapPercent > 0 {
+		base.OverlapPercent = override.OverlapPercent
+		base.Overlap = 0
+	}
+	if override.RowsPerChunk > 0 {
+		base.RowsPerChunk = override.RowsPerChunk
+	}
+	if override.Language != "" {
+		base.Language = override.Language
+	}
+	if len(override.Abbreviations) > 0 {
+		base.Abbreviations = override.Abbreviations
+	}
+	if override.TableFormat != "" {
+		base.TableFormat = override.TableFormat
+	}
+	if override.ContextTemplate != "" {
+		base.ContextTemplate = override.ContextTemplate
+	}
+	return base
+}
+
+// documentChunker is the chunker config for a document: the tenant's, with
+// the document's override applied
+func documentChunker(doc *repository.Document, tenant *repository.Tenant) repository.ChunkerConfig {
+	if doc.Chunker == nil {
+		return tenant.Config.Chunker
+	}
+	return mergeChunkerConfig(tenant.Config.Chunker, *doc.Chunker)
+}
+
+// chunkerOverride validates a request's chunker override against the
+// tenant's config. It returns nil when the request sets nothing.
+func chunkerOverride(req *ragv1.ChunkerConfig, tenant *repository.Tenant) (*repository.ChunkerConfig, error) {
+	override := chunkerFromProto(req)
+	if reflect.DeepEqual(override, repository.ChunkerConfig{}) {
+		return nil, nil
+	}
+	if err := ingestion.ValidateChunkerConfig(mergeChunkerConfig(tenant.Config.Chunker, override)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chunker config: %v", err)
+	}
+	return &override, nil
+}
+
+// chunkerFromProto converts a proto ChunkerConfig; nil is the zero config
+func chunkerFromProto(c *ragv1.ChunkerConfig) repository.ChunkerConfig {
+	if c == nil {
+		return repository.ChunkerConfig{}
+	}
+	return repository.ChunkerConfig{
+		Method:       c.Method,
+		TargetSize:   int(c.TargetSize),
+		MaxSize:      int(c.MaxSize),
+		Overlap:      int(c.Overlap),
+		RowsPerChunk: int(c.RowsPerChunk),
+
+		Language:      strings.ToLower(strings.TrimSpace(c.Language)),
+		Abbreviations: c.Abbreviations,
+
+		OverlapPercent: int(c.OverlapPercent),
+		TableFormat:    c.TableFormat,
+
+		ContextTemplate: c.ContextTemplate,
+	}
+}
+
+// chunkerToProto converts a repository ChunkerConfig to proto ChunkerConfig
+func chunkerToProto(c repository.ChunkerConfig) *ragv1.ChunkerConfig {
+	return &ragv1.ChunkerConfig{
+		Method:       c.Method,
+		TargetSize:   int32(c.TargetSize),
+		MaxSize:      int32(c.MaxSize),
+		Overlap:      int32(c.Overlap),
+		RowsPerChunk: int32(c.RowsPerChunk),
+
+		Language:      c.Language,
+		Abbreviations: c.Abbreviations,
+
+		OverlapPercent: int32(c.OverlapPercent),
+		TableFormat:    c.TableFormat,
+
+		ContextTemplate: c.ContextTemplate,
+	}
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxCollectionNameLength matches the collections.name column
+	maxCollectionNameLength = 255
+	// maxDocumentsPerRequest bounds AddDocuments and RemoveDocuments requests
+	maxDocumentsPerRequest = 100
+)
+
+// CollectionService implements ragv1.CollectionServiceServer
+type CollectionService struct {
+	ragv1.UnimplementedCollectionServiceServer
+
+	collectionRepo repository.CollectionRepository
+	docRepo        repository.DocumentRepository
+	vectorDB       vectorstore.VectorStore
+	uow            repository.UnitOfWork
+}
+
+// NewCollectionService creates a new CollectionService. Membership changes
+// and the collection IDs stored with each document's vectors are written in
+// one transaction of uow.
+func NewCollectionService(collectionRepo repository.CollectionRepository, docRepo repository.DocumentRepository, vectorDB vectorstore.VectorStore, uow repository.UnitOfWork) *CollectionService {
+	if uow == nil {
+		uow = noTx{}
+	}
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		docRepo:        docRepo,
+		vectorDB:       vectorDB,
+		uow:            uow,
+	}
+}
+
+// CreateCollection creates a new, empty collection
+func (s *CollectionService) CreateCollection(ctx context.Context, req *ragv1.CreateCollectionRequest) (*ragv1.Collection, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	collection := &repository.Collection{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		Name:        name,
+		Description: req.Description,
+	}
+	if err := s.collectionRepo.Create(ctx, collection); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "collection %q already exists", name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create collection: %v", err)
+	}
+
+	return collectionToProto(collection), nil
+}
+
+// GetCollection retrieves a collection by ID
+func (s *CollectionService) GetCollection(ctx context.Context, req *ragv1.GetCollectionRequest) (*ragv1.Collection, error) {
+	collection, err := s.getCollection(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return collectionToProto(collection), nil
+}
+
+// ListCollections lists a tenant's collections by name
+func (s *CollectionService) ListCollections(ctx context.Context, req *ragv1.ListCollectionsRequest) (*ragv1.ListCollectionsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	collections, total, err := s.collectionRepo.List(ctx, tenantID, pageSize, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list collections: %v", err)
+	}
+
+	protoCollections := make([]*ragv1.Collection, len(collections))
+	for i, collection := range collections {
+		protoCollections[i] = collectionToProto(collection)
+	}
+
+	var nextPageToken string
+	if offset+len(collections) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(collections))
+	}
+
+	return &ragv1.ListCollectionsResponse{
+		Collections:   protoCollections,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(total),
+	}, nil
+}
+
+// UpdateCollection renames a collection or changes its description
+func (s *CollectionService) UpdateCollection(ctx context.Context, req *ragv1.UpdateCollectionRequest) (*ragv1.Collection, error) {
+	collection, err := s.getCollection(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		collection.Name = name
+	}
+	if req.Description != "" {
+		collection.Description = req.Description
+	}
+
+	if err := s.collectionRepo.Update(ctx, collection); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "collection %q already exists", collection.Name)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apierr.ErrCollectionMissing.New("", "collection_id", collection.ID.String())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update collection: %v", err)
+	}
+
+	return s.GetCollection(ctx, &ragv1.GetCollectionRequest{Id: req.Id})
+}
+
+// DeleteCollection deletes a collection; its documents are kept
+func (s *CollectionService) DeleteCollection(ctx context.Context, req *ragv1.DeleteCollectionRequest) (*ragv1.DeleteCollectionResponse, error) {
+	collection, err := s.getCollection(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		members, err := s.collectionRepo.DocumentIDs(ctx, collection.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.collectionRepo.Delete(ctx, collection.ID); err != nil {
+			return err
+		}
+		return s.syncVectorCollections(ctx, members)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apierr.ErrCollectionMissing.New("", "collection_id", collection.ID.String())
+		}
+		return nil, searchError(err, "failed to delete collection")
+	}
+
+	return &ragv1.DeleteCollectionResponse{Success: true}, nil
+}
+
+// AddDocuments adds documents to a collection
+func (s *CollectionService) AddDocuments(ctx context.Context, req *ragv1.AddCollectionDocumentsRequest) (*ragv1.Collection, error) {
+	collection, docIDs, err := s.membershipRequest(ctx, req.CollectionId, req.DocumentIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateMembership(ctx, collection, docIDs, func(ctx context.Context) error {
+		return s.collectionRepo.AddDocuments(ctx, collection.ID, docIDs)
+	})
+}
+
+// RemoveDocuments removes documents from a collection
+func (s *CollectionService) RemoveDocuments(ctx context.Context, req *ragv1.RemoveCollectionDocumentsRequest) (*ragv1.Collection, error) {
+	collection, docIDs, err := s.membershipRequest(ctx, req.CollectionId, req.DocumentIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateMembership(ctx, collection, docIDs, func(ctx context.Context) error {
+		return s.collectionRepo.RemoveDocuments(ctx, collection.ID, docIDs)
+	})
+}
+
+// getCollection loads a collection the caller's tenant owns
+func (s *CollectionService) getCollection(ctx context.Context, rawID string) (*repository.Collection, error) {
+	if rawID == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid collection id format")
+	}
+
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, apierr.ErrCollectionMissing.New("", "collection_id", rawID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get collection: %v", err)
+	}
+	if !canAccess(ctx, collection.TenantID) {
+		return nil, apierr.ErrCollectionMissing.New("", "collection_id", rawID)
+	}
+	return collection, nil
+}
+
+// membershipRequest validates a membership change. Every document must
+// belong to the collection's tenant.
+func (s *CollectionService) membershipRequest(ctx context.Context, collectionID string, rawDocIDs []string) (*repository.Collection, []uuid.UUID, error) {
+	collection, err := s.getCollection(ctx, collectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rawDocIDs) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "document_ids are required")
+	}
+	if len(rawDocIDs) > maxDocumentsPerRequest {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "at most %d documents per request", maxDocumentsPerRequest)
+	}
+
+	docIDs := make([]uuid.UUID, 0, len(rawDocIDs))
+	for _, raw := range rawDocIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid document ID %q", raw)
+		}
+		doc, err := s.docRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, nil, status.Errorf(codes.NotFound, "document %s not found", raw)
+			}
+			return nil, nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
+		}
+		if doc.TenantID != collection.TenantID || !canAccessProject(ctx, doc.ProjectID) {
+			return nil, nil, status.Errorf(codes.NotFound, "document %s not found", raw)
+		}
+		docIDs = append(docIDs, id)
+	}
+	return collection, docIDs, nil
+}
+
+// updateMembership applies a membership change and copies the documents'
+// resulting collections to their vectors in one transaction
+func (s *CollectionService) updateMembership(ctx context.Context, collection *repository.Collection, docIDs []uuid.UUID, change func(ctx context.Context) error) (*ragv1.Collection, error) {
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		return s.syncVectorCollections(ctx, docIDs)
+	})
+	if err != nil {
+		return nil, searchError(err, "failed to update collection")
+	}
+
+	return s.GetCollection(ctx, &ragv1.GetCollectionRequest{Id: collection.ID.String()})
+}
+
+// syncVectorCollections copies documents' current collections to their
+// vectors. A document not yet in the vector store picks its collections up
+// when it is stored.
+func (s *CollectionService) syncVectorCollections(ctx context.Context, docIDs []uuid.UUID) error {
+	for _, id := range docIDs {
+		doc, err := s.docRepo.GetByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = s.vectorDB.SetDocumentCollections(ctx, doc.TenantID.String(), doc.ID.String(), uuidStrings(doc.CollectionIDs))
+		if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return fmt.Errorf("failed to update vector collections: %w", err)
+		}
+		if err := syncSharedLists(ctx, s.docRepo, s.vectorDB, doc.TenantID, doc.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectionName trims a collection name and checks its length
+func collectionName(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if len(name) > maxCollectionNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "name is longer than %d bytes", maxCollectionNameLength)
+	}
+	return name, nil
+}
+
+// collectionToProto converts a repository Collection to proto Collection
+func collectionToProto(collection *repository.Collection) *ragv1.Collection {
+	return &ragv1.Collection{
+		Id:            collection.ID.String(),
+		TenantId:      collection.TenantID.String(),
+		Name:          collection.Name,
+		Description:   collection.Description,
+		DocumentCount: int32(collection.DocumentCount),
+		CreatedAt:     timestamppb.New(collection.CreatedAt),
+		UpdatedAt:     timestamppb.New(collection.UpdatedAt),
+	}
+}
+
+// uuidStrings formats IDs as strings
+func uuidStrings(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
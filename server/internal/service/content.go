@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// contentChunkSize is how many bytes of content each GetDocumentContent
+// message carries
+const contentChunkSize = 64 * 1024
+
+// GetDocumentContent streams back the original content a document was ingested from
+func (s *DocumentService) GetDocumentContent(req *ragv1.GetDocumentContentRequest, stream grpc.ServerStreamingServer[ragv1.DocumentContentChunk]) error {
+	ctx := stream.Context()
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid document ID format")
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return status.Error(codes.NotFound, "document not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get document: %v", err)
+	}
+	if !canAccessDocument(ctx, doc) {
+		return status.Error(codes.NotFound, "document not found")
+	}
+
+	content, err := s.docRepo.GetContent(ctx, doc.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return status.Error(codes.NotFound, "document has no stored content")
+		}
+		return status.Errorf(codes.Internal, "failed to get document content: %v", err)
+	}
+
+	// The first message describes the content, even when it is empty
+	first := &ragv1.DocumentContentChunk{
+		ContentType: content.ContentType,
+		Filename:    content.Filename,
+		Size:        int64(len(content.Data)),
+	}
+	data := content.Data
+	msg := first
+	for {
+		n := min(len(data), contentChunkSize)
+		msg.Data, data = data[:n], data[n:]
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		msg = &ragv1.DocumentContentChunk{}
+	}
+}
+
+// saveContent stores a document's original content if the tenant keeps it
+func (s *DocumentService) saveContent(ctx context.Context, tenant *repository.Tenant, content *repository.DocumentContent) error {
+	if !s.keepsContent(tenant) {
+		return nil
+	}
+	return s.docRepo.SaveContent(ctx, content)
+}
+
+// keepsContent reports whether a tenant's original content is stored
+func (s *DocumentService) keepsContent(tenant *repository.Tenant) bool {
+	if tenant.Config.StoreContent != nil {
+		return *tenant.Config.StoreContent
+	}
+	return s.storeContent
+}
+
+// RechunkDocument re-chunks and re-embeds a document from its stored original
+// content. The override in the request, if any, replaces the document's.
+func (s *DocumentService) RechunkDocument(ctx context.Context, req *ragv1.RechunkDocumentRequest) (*ragv1.IngestDocumentResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid document ID format")
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "document not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
+	}
+	if !canAccessDocument(ctx, doc) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+	if doc.Status == "PENDING" || doc.Status == "PROCESSING" {
+		return nil, status.Error(codes.FailedPrecondition, "document is still being processed")
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, doc.TenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	chunker, err := chunkerOverride(req.Chunker, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.docRepo.GetContent(ctx, doc.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, "document has no stored content to re-chunk")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get document content: %v", err)
+	}
+
+	if chunker != nil {
+		doc.Chunker = chunker
+	}
+	// Claim the document only if no concurrent request has since
+	expected := doc.Status
+	doc.Status = "PROCESSING"
+	doc.ErrorMessage = ""
+	claimed, err := s.docRepo.UpdateIfStatus(ctx, doc, expected)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update document: %v", err)
+	}
+	if !claimed {
+		return nil, status.Error(codes.FailedPrecondition, "document is still being processed")
+	}
+
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.reprocess(ctx, doc, content, tenant)
+	})
+
+	return &ragv1.IngestDocumentResponse{
+		DocumentId: doc.ID.String(),
+		Status:     ragv1.DocumentStatus_DOCUMENT_STATUS_PROCESSING,
+	}, nil
+}
+
+// reprocess runs stored content through the path it was first ingested by
+func (s *DocumentService) reprocess(ctx context.Context, doc *repository.Document, content *repository.DocumentContent, tenant *repository.Tenant) {
+	switch {
+	case content.Filename != "":
+		extractor, _, err := s.extractors.Lookup(content.ContentType, content.Filename, content.Data)
+		if err != nil {
+			s.markDocumentFailed(ctx, doc, fmt.Sprintf("extraction failed: %v", err))
+			return
+		}
+		s.processUpload(ctx, doc, extractor, content.Data, tenant)
+	case content.ContentType == "text/html":
+		s.processHTML(ctx, doc, content.Data, tenant)
+	default:
+		s.processDocument(ctx, doc, string(content.Data), tenant)
+	}
+}
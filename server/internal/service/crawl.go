@@ -0,0 +1,609 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/crawl"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Crawl job statuses
+	crawlPending   = "PENDING"
+	crawlRunning   = "RUNNING"
+	crawlCompleted = "COMPLETED"
+	crawlFailed    = "FAILED"
+
+	// Crawled page statuses; pending pages make up a job's frontier
+	pagePending = "PENDING"
+	pageDone    = "DONE"
+	pageFailed  = "FAILED"
+
+	// crawlBatchSize is the number of frontier pages read per step
+	crawlBatchSize = 20
+
+	// Metadata keys recording how a crawled page was found, for "found via"
+	// breadcrumbs in citations and the score boost's depth weight
+	crawlDepthField      = "crawl_depth"
+	crawlParentURLField  = "crawl_parent_url"
+	crawlAnchorTextField = "crawl_anchor_text"
+
+	// maxAnchorTextLength caps the anchor text kept for a link, in runes
+	maxAnchorTextLength = 200
+
+	// Crawl limits applied when the request sets none, and the most allowed
+	defaultCrawlMaxDepth = 3
+	defaultCrawlMaxPages = 100
+	maxCrawlDepth        = 10
+	maxCrawlPages        = 10000
+)
+
+// CrawlService implements ragv1.CrawlServiceServer
+type CrawlService struct {
+	ragv1.UnimplementedCrawlServiceServer
+
+	crawlRepo  repository.CrawlJobRepository
+	tenantRepo repository.TenantRepository
+	documents  *DocumentService
+}
+
+// NewCrawlService creates a new CrawlService. Crawled pages are ingested through documents.
+func NewCrawlService(
+	crawlRepo repository.CrawlJobRepository,
+	tenantRepo repository.TenantRepository,
+	documents *DocumentService,
+) *CrawlService {
+	return &CrawlService{
+		crawlRepo:  crawlRepo,
+		tenantRepo: tenantRepo,
+		documents:  documents,
+	}
+}
+
+// StartCrawl starts crawling a site in the background
+func (s *CrawlService) StartCrawl(ctx context.Context, req *ragv1.StartCrawlRequest) (*ragv1.CrawlJob, error) {
+	if req.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := crawl.Canonicalize(req.Url)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+
+	config, err := crawlConfigFromProto(req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	if config.UseHeadless {
+		if s.documents.renderer == nil {
+			return nil, status.Error(codes.FailedPrecondition, "headless rendering is not configured")
+		}
+		if !tenant.Config.Headless.Enabled {
+			return nil, status.Error(codes.FailedPrecondition, "headless rendering is not enabled for this tenant")
+		}
+	}
+	project, err := requestProject(ctx, s.documents.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &repository.CrawlJob{
+		ID:           uuid.New(),
+		TenantID:     tenantID,
+		Type:         "spider",
+		Status:       crawlPending,
+		RootURL:      root,
+		Config:       config,
+		PagesTotal:   1,
+		FrontierSize: 1,
+		CreatedAt:    time.Now(),
+		ProjectID:    projectID(project),
+	}
+	if err := s.crawlRepo.Create(ctx, job); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create crawl job: %v", err)
+	}
+	if _, err := s.crawlRepo.EnqueuePages(ctx, []*repository.CrawledPage{{
+		ID:     uuid.New(),
+		JobID:  job.ID,
+		URL:    root,
+		Status: pagePending,
+	}}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to enqueue root URL: %v", err)
+	}
+
+	go s.runCrawl(context.Background(), job, tenant)
+
+	return crawlJobToProto(job), nil
+}
+
+// GetCrawlJob returns a crawl job's progress
+func (s *CrawlService) GetCrawlJob(ctx context.Context, req *ragv1.GetCrawlJobRequest) (*ragv1.CrawlJob, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid crawl job ID format")
+	}
+
+	job, err := s.crawlRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "crawl job not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get crawl job: %v", err)
+	}
+	if !canAccess(ctx, job.TenantID) || !canAccessProject(ctx, job.ProjectID) {
+		return nil, status.Error(codes.NotFound, "crawl job not found")
+	}
+
+	return crawlJobToProto(job), nil
+}
+
+// ListCrawlJobs lists crawl jobs for a tenant
+func (s *CrawlService) ListCrawlJobs(ctx context.Context, req *ragv1.ListCrawlJobsRequest) (*ragv1.ListCrawlJobsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	project, err := requestProject(ctx, s.documents.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	var listed uuid.UUID
+	if project != nil {
+		listed = project.ID
+	}
+
+	jobs, total, err := s.crawlRepo.List(ctx, tenantID, listed, crawlStatusToString(req.Status), pageSize, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list crawl jobs: %v", err)
+	}
+
+	protoJobs := make([]*ragv1.CrawlJob, len(jobs))
+	for i, job := range jobs {
+		protoJobs[i] = crawlJobToProto(job)
+	}
+
+	var nextPageToken string
+	if offset+len(jobs) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(jobs))
+	}
+
+	return &ragv1.ListCrawlJobsResponse{
+		Jobs:          protoJobs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ResumeCrawls continues every crawl job left pending or running by a
+// previous process from its persisted frontier
+func (s *CrawlService) ResumeCrawls(ctx context.Context) error {
+	jobs, err := s.crawlRepo.ListUnfinished(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		tenant, err := s.tenantRepo.GetByID(ctx, job.TenantID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to resume crawl", "job_id", job.ID, "error", err)
+			continue
+		}
+		if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+			// Left running, to resume once the tenant is active again
+			slog.InfoContext(ctx, "not resuming crawl", "job_id", job.ID, "tenant_id", tenant.ID, "status", tenant.Status)
+			continue
+		}
+		slog.InfoContext(ctx, "resuming crawl", "job_id", job.ID, "root_url", job.RootURL,
+			"pages_crawled", job.PagesCrawled, "frontier_size", job.FrontierSize)
+		go s.runCrawl(ctx, job, tenant)
+	}
+	return nil
+}
+
+// runCrawl crawls a job's frontier, shallowest pages first, until it is
+// empty or the job's page limit is reached. A cancelled crawl is left
+// running, to be resumed.
+func (s *CrawlService) runCrawl(ctx context.Context, job *repository.CrawlJob, tenant *repository.Tenant) {
+	if job.Status == crawlPending {
+		started := time.Now()
+		job.Status = crawlRunning
+		job.StartedAt = &started
+		_ = s.crawlRepo.Update(ctx, job)
+	}
+
+	root, err := url.Parse(job.RootURL)
+	if err != nil {
+		s.finishCrawl(ctx, job, fmt.Sprintf("invalid root URL: %v", err))
+		return
+	}
+
+	for job.PagesCrawled+job.PagesFailed < job.Config.MaxPages {
+		pages, err := s.crawlRepo.NextPages(ctx, job.ID, crawlBatchSize)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			s.finishCrawl(ctx, job, fmt.Sprintf("failed to read frontier: %v", err))
+			return
+		}
+		if len(pages) == 0 {
+			break
+		}
+
+		for _, page := range pages {
+			if ctx.Err() != nil {
+				return
+			}
+			if job.PagesCrawled+job.PagesFailed >= job.Config.MaxPages {
+				break
+			}
+			if err := s.crawlPage(ctx, job, tenant, root, page); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				s.finishCrawl(ctx, job, err.Error())
+				return
+			}
+		}
+	}
+
+	s.finishCrawl(ctx, job, "")
+}
+
+// crawlPage ingests one frontier page and enqueues the links it follows.
+// Failing to ingest the page fails only the page; failing to record it
+// fails the job.
+func (s *CrawlService) crawlPage(ctx context.Context, job *repository.CrawlJob, tenant *repository.Tenant, root *url.URL, page *repository.CrawledPage) error {
+	metadata := map[string]string{
+		"connector":     "crawl",
+		"crawl_job_id":  job.ID.String(),
+		crawlDepthField: strconv.Itoa(page.Depth),
+	}
+	if page.ParentURL != "" {
+		metadata[crawlParentURLField] = page.ParentURL
+	}
+	if page.AnchorText != "" {
+		metadata[crawlAnchorTextField] = page.AnchorText
+	}
+	doc, busy, err := s.documents.crawlDocument(ctx, tenant, job.ProjectID, page.URL, metadata, page.DocumentID)
+	var body []byte
+	if err == nil && !busy {
+		// Record the document first, so a crawl resumed mid-page finishes it
+		page.DocumentID = &doc.ID
+		if err := s.crawlRepo.UpdatePage(ctx, page); err != nil {
+			return err
+		}
+		body, err = s.documents.crawlProcess(ctx, doc, job.Config.UseHeadless, tenant)
+	}
+
+	crawled := time.Now()
+	page.CrawledAt = &crawled
+	page.ContentLength = len(body)
+	if doc != nil {
+		page.DocumentID = &doc.ID
+		page.Title = doc.Title
+	}
+	if err != nil {
+		page.Status = pageFailed
+		page.ErrorMessage = err.Error()
+		job.PagesFailed++
+	} else {
+		page.Status = pageDone
+		page.ErrorMessage = ""
+		job.PagesCrawled++
+	}
+
+	if body != nil && page.Depth < job.Config.MaxDepth {
+		added, err := s.followLinks(ctx, job, root, page, body)
+		if err != nil {
+			return err
+		}
+		job.PagesTotal += added
+	}
+	if err := s.crawlRepo.UpdatePage(ctx, page); err != nil {
+		return err
+	}
+	return s.crawlRepo.Update(ctx, job)
+}
+
+// followLinks enqueues the links of a page that stay on the root URL's host
+// and match the job's patterns, one level deeper
+func (s *CrawlService) followLinks(ctx context.Context, job *repository.CrawlJob, root *url.URL, page *repository.CrawledPage, body []byte) (int, error) {
+	links, err := ingestion.ExtractLinks(string(body))
+	if err != nil {
+		// A page that can't be parsed has no links to follow
+		return 0, nil
+	}
+
+	seen := make(map[string]bool, len(links))
+	var next []*repository.CrawledPage
+	for _, link := range links {
+		target, err := crawl.Resolve(page.URL, link.Href)
+		if err != nil || seen[target] {
+			continue
+		}
+		seen[target] = true
+		u, err := url.Parse(target)
+		if err != nil || u.Hostname() != root.Hostname() || !followsPath(job.Config, u.Path) {
+			continue
+		}
+		next = append(next, &repository.CrawledPage{
+			ID:         uuid.New(),
+			JobID:      job.ID,
+			URL:        target,
+			Status:     pagePending,
+			Depth:      page.Depth + 1,
+			ParentURL:  page.URL,
+			AnchorText: truncateRunes(link.Text, maxAnchorTextLength),
+		})
+	}
+	return s.crawlRepo.EnqueuePages(ctx, next)
+}
+
+// finishCrawl marks a crawl job completed, or failed with an error message
+func (s *CrawlService) finishCrawl(ctx context.Context, job *repository.CrawlJob, errorMsg string) {
+	completed := time.Now()
+	job.Status = crawlCompleted
+	if errorMsg != "" {
+		job.Status = crawlFailed
+		slog.WarnContext(ctx, "crawl failed", "job_id", job.ID, "root_url", job.RootURL, "error", errorMsg)
+	}
+	job.ErrorMessage = errorMsg
+	job.CompletedAt = &completed
+	_ = s.crawlRepo.Update(ctx, job)
+}
+
+// crawlDocument returns the document a crawled page is ingested into: the
+// one with its source key in the project or a new one. A document being
+// ingested by something else is returned busy, unless it is the resumed
+// crawl's own.
+func (s *DocumentService) crawlDocument(ctx context.Context, tenant *repository.Tenant, projectID *uuid.UUID, rawURL string, metadata map[string]string, resumed *uuid.UUID) (*repository.Document, bool, error) {
+	source, err := crawl.Canonicalize(rawURL)
+	if err != nil {
+		return nil, false, err
+	}
+	sourceKey := crawl.SourceKey(source)
+
+	doc, err := s.docRepo.GetBySourceKey(ctx, tenant.ID, projectID, sourceKey)
+	if errors.Is(err, repository.ErrNotFound) {
+		now := time.Now()
+		doc = &repository.Document{
+			ID:        uuid.New(),
+			TenantID:  tenant.ID,
+			Source:    source,
+			SourceKey: sourceKey,
+			Status:    "PENDING",
+			Metadata:  metadata,
+			CreatedAt: now,
+			UpdatedAt: now,
+			ProjectID: projectID,
+		}
+		if err := s.docRepo.Create(ctx, doc); err != nil {
+			return nil, false, fmt.Errorf("failed to create document: %v", err)
+		}
+		return doc, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up document: %v", err)
+	}
+
+	if (doc.Status == "PENDING" || doc.Status == "PROCESSING") && (resumed == nil || *resumed != doc.ID) {
+		return doc, true, nil
+	}
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string)
+	}
+	for k, v := range metadata {
+		doc.Metadata[k] = v
+	}
+	return doc, false, nil
+}
+
+// crawlProcess fetches and ingests a crawled page into its document, waiting
+// for processing to finish, and returns the page for link discovery. An
+// unchanged page is read back from stored content.
+func (s *DocumentService) crawlProcess(ctx context.Context, doc *repository.Document, useHeadless bool, tenant *repository.Tenant) ([]byte, error) {
+	if !s.keepsContent(tenant) {
+		// Without stored content an unchanged page has no links to follow
+		doc.ETag = ""
+		doc.LastModified = ""
+	}
+	doc.ErrorMessage = ""
+
+	body := s.processURL(ctx, doc, doc.Source, useHeadless, tenant)
+	if body == nil && doc.Status == "READY" {
+		if content, err := s.docRepo.GetContent(ctx, doc.ID); err == nil {
+			body = content.Data
+		}
+	}
+	if doc.Status == "FAILED" {
+		return body, errors.New(doc.ErrorMessage)
+	}
+	return body, nil
+}
+
+// crawlConfigFromProto validates a crawl config, applying defaults
+func crawlConfigFromProto(pb *ragv1.CrawlConfig) (repository.SpiderConfig, error) {
+	config := repository.SpiderConfig{
+		MaxDepth:      defaultCrawlMaxDepth,
+		MaxPages:      defaultCrawlMaxPages,
+		RespectRobots: true,
+	}
+	if pb == nil {
+		return config, nil
+	}
+
+	if pb.MaxDepth < 0 || pb.MaxDepth > maxCrawlDepth {
+		return config, status.Errorf(codes.InvalidArgument, "max_depth must be between 0 and %d", maxCrawlDepth)
+	}
+	if pb.MaxPages < 0 || pb.MaxPages > maxCrawlPages {
+		return config, status.Errorf(codes.InvalidArgument, "max_pages must be between 0 and %d", maxCrawlPages)
+	}
+	for _, pattern := range append(pb.IncludePatterns, pb.ExcludePatterns...) {
+		if !strings.HasPrefix(pattern, "/") {
+			return config, status.Errorf(codes.InvalidArgument, "pattern %q must be a URL path starting with /", pattern)
+		}
+	}
+	if pb.MaxDepth > 0 {
+		config.MaxDepth = int(pb.MaxDepth)
+	}
+	if pb.MaxPages > 0 {
+		config.MaxPages = int(pb.MaxPages)
+	}
+	config.IncludePatterns = pb.IncludePatterns
+	config.ExcludePatterns = pb.ExcludePatterns
+	config.UseHeadless = pb.UseHeadless
+	return config, nil
+}
+
+// followsPath reports whether a crawl follows links to a URL path: it must
+// match an include pattern, if there are any, and no exclude pattern
+func followsPath(config repository.SpiderConfig, path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	for _, pattern := range config.ExcludePatterns {
+		if globMatch(pattern, path) {
+			return false
+		}
+	}
+	if len(config.IncludePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range config.IncludePatterns {
+		if globMatch(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch matches a path against a pattern where "*" is any run of
+// characters, slashes included
+func globMatch(pattern, path string) bool {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	matched, _ := regexp.MatchString("^"+expr+"$", path)
+	return matched
+}
+
+// truncateRunes shortens s to at most n runes
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// crawlJobToProto converts a repository CrawlJob to proto CrawlJob
+func crawlJobToProto(job *repository.CrawlJob) *ragv1.CrawlJob {
+	pb := &ragv1.CrawlJob{
+		Id:       job.ID.String(),
+		TenantId: job.TenantID.String(),
+		RootUrl:  job.RootURL,
+		Status:   convertCrawlStatus(job.Status),
+		Config: &ragv1.CrawlConfig{
+			MaxDepth:        int32(job.Config.MaxDepth),
+			MaxPages:        int32(job.Config.MaxPages),
+			IncludePatterns: job.Config.IncludePatterns,
+			ExcludePatterns: job.Config.ExcludePatterns,
+			UseHeadless:     job.Config.UseHeadless,
+		},
+		PagesCrawled: int32(job.PagesCrawled),
+		PagesFailed:  int32(job.PagesFailed),
+		PagesTotal:   int32(job.PagesTotal),
+		FrontierSize: int32(job.FrontierSize),
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    timestamppb.New(job.CreatedAt),
+		ProjectId:    projectIDString(job.ProjectID),
+	}
+	if job.StartedAt != nil {
+		pb.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.CompletedAt != nil {
+		pb.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+	return pb
+}
+
+// convertCrawlStatus converts a crawl job status string to proto enum
+func convertCrawlStatus(status string) ragv1.CrawlStatus {
+	switch status {
+	case crawlPending:
+		return ragv1.CrawlStatus_CRAWL_STATUS_PENDING
+	case crawlRunning:
+		return ragv1.CrawlStatus_CRAWL_STATUS_RUNNING
+	case crawlCompleted:
+		return ragv1.CrawlStatus_CRAWL_STATUS_COMPLETED
+	case crawlFailed:
+		return ragv1.CrawlStatus_CRAWL_STATUS_FAILED
+	default:
+		return ragv1.CrawlStatus_CRAWL_STATUS_UNSPECIFIED
+	}
+}
+
+// crawlStatusToString converts a proto CrawlStatus to string, or "" for any status
+func crawlStatusToString(status ragv1.CrawlStatus) string {
+	switch status {
+	case ragv1.CrawlStatus_CRAWL_STATUS_PENDING:
+		return crawlPending
+	case ragv1.CrawlStatus_CRAWL_STATUS_RUNNING:
+		return crawlRunning
+	case ragv1.CrawlStatus_CRAWL_STATUS_COMPLETED:
+		return crawlCompleted
+	case ragv1.CrawlStatus_CRAWL_STATUS_FAILED:
+		return crawlFailed
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+// chunkContentHash hashes a chunk's content with whitespace collapsed, so
+// boilerplate differing only in layout is shared
+func chunkContentHash(content string) string {
+	return hashContent(strings.Join(strings.Fields(content), " "))
+}
+
+// dedupHash is the hash a chunk shares a vector under: its content's, in
+// the document's project and, when detected, its language, so chunks the
+// tenant's analyzer would index differently are not shared
+func dedupHash(projectID *uuid.UUID, chunk *repository.DocumentChunk) string {
+	hash := chunkContentHash(chunk.Content)
+	if lang := chunk.Metadata[ingestion.MetadataLanguage]; lang != "" {
+		hash = hashContent(lang + "\n" + hash)
+	}
+	return projectHash(projectID, hash)
+}
+
+// shareChunks hashes a new document's chunks and points those identical to
+// an earlier chunk of the tenant's, in the document's project, at its
+// vector, for tenants that deduplicate chunks
+func (s *DocumentService) shareChunks(ctx context.Context, tenant *repository.Tenant, projectID *uuid.UUID, docChunks []*repository.DocumentChunk) error {
+	if !tenant.Config.DedupChunks {
+		return nil
+	}
+	for _, chunk := range docChunks {
+		chunk.ContentHash = dedupHash(projectID, chunk)
+	}
+	return s.docRepo.ShareChunks(ctx, tenant.ID, docChunks)
+}
+
+// expectOwnVectors returns the chunks of a document expected to need a
+// vector of their own: for tenants that deduplicate chunks, the first of
+// each content no other document's chunk has. It reads without locking, so
+// chunks can be embedded before shareChunks, which decides.
+func (s *DocumentService) expectOwnVectors(ctx context.Context, tenant *repository.Tenant, doc *repository.Document, docChunks []*repository.DocumentChunk) ([]*repository.DocumentChunk, error) {
+	if !tenant.Config.DedupChunks {
+		return docChunks, nil
+	}
+	hashes := make([]string, len(docChunks))
+	for i, chunk := range docChunks {
+		hashes[i] = dedupHash(doc.ProjectID, chunk)
+	}
+	shared, err := s.docRepo.SharedHashes(ctx, tenant.ID, doc.ID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make([]*repository.DocumentChunk, 0, len(docChunks))
+	for i, chunk := range docChunks {
+		if !shared[hashes[i]] {
+			shared[hashes[i]] = true
+			own = append(own, chunk)
+		}
+	}
+	return own, nil
+}
+
+// ownVectors returns the chunks that need a vector of their own
+func ownVectors(docChunks []*repository.DocumentChunk) []*repository.DocumentChunk {
+	own := make([]*repository.DocumentChunk, 0, len(docChunks))
+	for _, chunk := range docChunks {
+		if chunk.VectorChunkID == uuid.Nil {
+			own = append(own, chunk)
+		}
+	}
+	return own
+}
+
+// releaseChunks drops a document's shared chunk references before its
+// chunks are deleted, and stores vectors for the chunks of other documents
+// that took over vectors it owned
+func (s *DocumentService) releaseChunks(ctx context.Context, tenant *repository.Tenant, documentID uuid.UUID) error {
+	inherited, used, err := s.docRepo.ReleaseChunks(ctx, documentID)
+	if err != nil || len(inherited)+len(used) == 0 {
+		return err
+	}
+
+	byDocument := make(map[uuid.UUID][]*repository.DocumentChunk)
+	for _, chunk := range inherited {
+		byDocument[chunk.DocumentID] = append(byDocument[chunk.DocumentID], chunk)
+	}
+
+	emb := s.embedderFor(tenant)
+	for docID, chunks := range byDocument {
+		doc, err := s.docRepo.GetByID(ctx, docID)
+		if err != nil {
+			return fmt.Errorf("failed to get document sharing chunks: %w", err)
+		}
+		contents := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			contents[i] = chunk.Content
+		}
+		embeddings, err := embedder.EmbedAll(ctx, emb, contents, embedRetryRounds)
+		if err != nil {
+			return fmt.Errorf("failed to embed shared chunks: %w", err)
+		}
+		if err := s.vectorDB.Upsert(ctx, tenant.ID.String(), buildVectorChunks(doc, chunks, embeddings)); err != nil {
+			return fmt.Errorf("failed to store shared chunks: %w", err)
+		}
+	}
+
+	// The vectors it used, and those it handed over, lose its lists
+	for _, chunk := range inherited {
+		used = append(used, chunk.ID)
+	}
+	return syncSharedLists(ctx, s.docRepo, s.vectorDB, tenant.ID, uuid.Nil, used)
+}
+
+// syncSharedLists stores with each vector that several documents share the
+// tags and collections of all of them, so retrieval filtered by any of
+// theirs finds it: the vectors a document's chunks own or use, when
+// documentID is set, and the vectors of chunkIDs
+func syncSharedLists(ctx context.Context, docRepo repository.DocumentRepository, vectorDB vectorstore.VectorStore, tenantID, documentID uuid.UUID, chunkIDs []uuid.UUID) error {
+	vectors, err := docRepo.SharedVectors(ctx, documentID, chunkIDs)
+	if err != nil || len(vectors) == 0 {
+		return err
+	}
+
+	// Vectors shared by the same documents get the same lists in one update
+	type sharedLists struct {
+		tags, collectionIDs, chunkIDs []string
+	}
+	var groups []*sharedLists
+	byLists := make(map[string]*sharedLists)
+	for _, v := range vectors {
+		collectionIDs := uuidStrings(v.CollectionIDs)
+		key := strings.Join(v.Tags, "\x00") + "\x01" + strings.Join(collectionIDs, "\x00")
+		group, ok := byLists[key]
+		if !ok {
+			group = &sharedLists{tags: v.Tags, collectionIDs: collectionIDs}
+			byLists[key] = group
+			groups = append(groups, group)
+		}
+		group.chunkIDs = append(group.chunkIDs, v.ChunkID.String())
+	}
+	for _, group := range groups {
+		err := vectorDB.SetChunkLists(ctx, tenantID.String(), group.chunkIDs, group.tags, group.collectionIDs)
+		if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return fmt.Errorf("failed to update shared vectors: %w", err)
+		}
+	}
+	return nil
+}
+
+// attachSharedDocuments lists the other documents containing each retrieved
+// chunk, for tenants that deduplicate chunks. A failed lookup leaves them out.
+func (s *RAGService) attachSharedDocuments(ctx context.Context, tenant *repository.Tenant, chunks []*ragv1.RetrievedChunk) {
+	if !tenant.Config.DedupChunks || len(chunks) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(chunks))
+	for _, chunk := range chunks {
+		if id, err := uuid.Parse(chunk.ChunkId); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	shared, err := s.docRepo.SharedChunkDocuments(ctx, ids)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to look up shared chunks", "tenant_id", tenant.ID, "error", err)
+		return
+	}
+	for _, chunk := range chunks {
+		id, err := uuid.Parse(chunk.ChunkId)
+		if err != nil {
+			continue
+		}
+		for _, docID := range shared[id] {
+			if docID.String() != chunk.DocumentId {
+				chunk.SharedDocumentIds = append(chunk.SharedDocumentIds, docID.String())
+			}
+		}
+	}
+}
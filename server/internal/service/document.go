@@ -7,15 +7,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"regexp"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/crawl"
 	"github.com/knoguchi/rag/internal/embedder"
 	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/render"
 	"github.com/knoguchi/rag/internal/repository"
 	"github.com/knoguchi/rag/internal/vectorstore"
 	"google.golang.org/grpc/codes"
@@ -23,15 +29,199 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// untitledDocument is the title of ingested text that was given none
+const untitledDocument = "Untitled Document"
+
+// embedRetryRounds is how many times chunks that failed to embed (after the
+// embedder's own per-request retries) are re-sent before ingestion fails
+const embedRetryRounds = 1
+
+// defaultMaxDownloadSize is the largest page URL ingestion downloads unless
+// configured otherwise
+const defaultMaxDownloadSize = 50 << 20
+
+// fetchUserAgent identifies the service to the sites it fetches pages from,
+// and names its group in their robots.txt
+const fetchUserAgent = "RAG-Service/1.0"
+
 // DocumentService implements ragv1.DocumentServiceServer
 type DocumentService struct {
 	ragv1.UnimplementedDocumentServiceServer
 
 	docRepo    repository.DocumentRepository
 	tenantRepo repository.TenantRepository
+	uow        repository.UnitOfWork
 	embedder   embedder.Embedder
 	vectorDB   vectorstore.VectorStore
+	embedders  *embedder.Pool // Optional: per-tenant embedding models
+	extractors *ingestion.ExtractorRegistry
+	captioner  *ingestion.ImageCaptioner // Optional: describes images as extra chunks
+	enricher   ingestion.Enricher        // Optional: detects language, dates, author and entities
 	httpClient *http.Client
+	robots     *crawl.Robots // Optional: robots.txt rules and crawl delays for IngestURL
+	scheduler  *crawl.Scheduler
+	renderer   render.Renderer // Optional: headless browser for IngestURL use_headless
+	progress   *progressHub    // Processing progress for WatchDocument
+	workers    *ingestionWorkers
+
+	// graphExtractor, when set, extracts knowledge graphs into graphRepo for
+	// tenants that enable them
+	graphExtractor *ingestion.GraphExtractor
+	graphRepo      repository.GraphRepository
+
+	// summaryTree, when set, builds summary trees for tenants that enable them
+	summaryTree *ingestion.SummaryTreeBuilder
+
+	// stages, when set, returns the custom ingestion stages of a tenant
+	stages func(tenant *repository.Tenant) []ingestion.Stage
+
+	// fetchLimits are the politeness limits of fetching pages
+	fetchLimits crawl.SchedulerConfig
+
+	// maxDownloadSize is the largest page fetched, in bytes
+	maxDownloadSize int64
+
+	// checkpointMinChunks is the chunk count from which processing is
+	// checkpointed; 0 disables checkpointing
+	checkpointMinChunks int
+
+	// storeContent keeps original content for tenants that don't set StoreContent
+	storeContent bool
+
+	// embedBatchSize and embedConcurrency are how many chunks are embedded
+	// per batch and how many batches at once; see WithEmbedBatching
+	embedBatchSize   int
+	embedConcurrency int
+
+	// projectRepo, when set, resolves the projects documents are ingested into
+	projectRepo repository.ProjectRepository
+}
+
+// DocumentServiceOption is a functional option for configuring DocumentService.
+type DocumentServiceOption func(*DocumentService)
+
+// WithDocumentEmbedderPool embeds chunks with each tenant's configured embedding model.
+func WithDocumentEmbedderPool(pool *embedder.Pool) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.embedders = pool
+	}
+}
+
+// WithExtractors sets the registry used to extract text from uploaded files.
+func WithExtractors(extractors *ingestion.ExtractorRegistry) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.extractors = extractors
+	}
+}
+
+// WithImageCaptioner ingests a caption chunk for each image in fetched pages and
+// uploaded HTML, described by a multimodal model. Images inside PDFs and other
+// files are not captioned; those go through text extraction or OCR only.
+func WithImageCaptioner(captioner *ingestion.ImageCaptioner) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.captioner = captioner
+	}
+}
+
+// WithEnricher adds detected metadata, such as language and publication date,
+// to each document and its chunks. Metadata set by the client is kept.
+func WithEnricher(enricher ingestion.Enricher) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.enricher = enricher
+	}
+}
+
+// WithGraphExtraction extracts the entities and relations of each chunk into
+// the knowledge graph, for tenants with knowledge_graph enabled.
+func WithGraphExtraction(extractor *ingestion.GraphExtractor, repo repository.GraphRepository) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.graphExtractor = extractor
+		s.graphRepo = repo
+	}
+}
+
+// WithSummaryIndex stores a tree of summaries of each document's chunks
+// beside them, for tenants with summary_index enabled.
+func WithSummaryIndex(builder *ingestion.SummaryTreeBuilder) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.summaryTree = builder
+	}
+}
+
+// WithIngestionStages adds custom stages, such as tenant-specific cleaning
+// or enrichment, to each document's ingestion pipeline. stages is called
+// per document with its tenant and may return none.
+func WithIngestionStages(stages func(tenant *repository.Tenant) []ingestion.Stage) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.stages = stages
+	}
+}
+
+// WithDocumentProjects lets documents be ingested into and listed by the
+// tenant's projects.
+func WithDocumentProjects(repo repository.ProjectRepository) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.projectRepo = repo
+	}
+}
+
+// WithUnitOfWork stores a document's chunks, status and usage in one
+// transaction, so a failed ingestion leaves no partial chunks behind.
+func WithUnitOfWork(uow repository.UnitOfWork) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.uow = uow
+	}
+}
+
+// WithContentStorage sets whether original document content is kept for
+// tenants that don't choose for themselves. It is kept by default.
+func WithContentStorage(enabled bool) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.storeContent = enabled
+	}
+}
+
+// WithRobotsTxt makes URL ingestion obey robots.txt: pages it disallows fail,
+// and fetches from a host are spaced by at least its Crawl-delay. Each
+// host's rules are cached for ttl.
+func WithRobotsTxt(ttl time.Duration) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.robots = crawl.NewRobots(s.httpClient, fetchUserAgent, ttl)
+	}
+}
+
+// WithFetchLimits sets the per-host and global concurrency, delay and
+// backoff of fetching pages. By default one page of a host is fetched at a
+// time, without delay.
+func WithFetchLimits(limits crawl.SchedulerConfig) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.fetchLimits = limits
+	}
+}
+
+// WithMaxDownloadSize sets the largest page, in bytes, URL ingestion
+// downloads; larger pages fail. The default is 50 MiB.
+func WithMaxDownloadSize(size int64) DocumentServiceOption {
+	return func(s *DocumentService) {
+		if size > 0 {
+			s.maxDownloadSize = size
+		}
+	}
+}
+
+// WithRenderer renders IngestURL requests with use_headless in a headless
+// browser, for tenants that enable it. Without a renderer such requests fail.
+func WithRenderer(renderer render.Renderer) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.renderer = renderer
+	}
+}
+
+// noTx runs writes directly, for repositories without transactions
+type noTx struct{}
+
+func (noTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
 }
 
 // NewDocumentService creates a new DocumentService
@@ -40,28 +230,50 @@ func NewDocumentService(
 	tenantRepo repository.TenantRepository,
 	embedder embedder.Embedder,
 	vectorDB vectorstore.VectorStore,
+	opts ...DocumentServiceOption,
 ) *DocumentService {
-	return &DocumentService{
+	s := &DocumentService{
 		docRepo:    docRepo,
 		tenantRepo: tenantRepo,
+		uow:        noTx{},
 		embedder:   embedder,
 		vectorDB:   vectorDB,
+		extractors: ingestion.DefaultExtractors(),
 		httpClient: &http.Client{Timeout: 30 * time.Second},
+		progress:   newProgressHub(),
+		workers:    newIngestionWorkers(),
+
+		maxDownloadSize: defaultMaxDownloadSize,
+		storeContent:    true,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.fetchLimits.Robots = s.robots
+	s.scheduler = crawl.NewScheduler(s.fetchLimits)
+
+	return s
+}
+
+// embedderFor returns the embedder for a tenant's configured model and dimension
+func (s *DocumentService) embedderFor(tenant *repository.Tenant) embedder.Embedder {
+	emb := s.embedder
+	if s.embedders != nil {
+		emb = s.embedders.Get(tenant.Config.EmbeddingModel)
 	}
+	return embedder.Truncate(emb, tenant.Config.EmbeddingDimension)
 }
 
 // IngestDocument ingests raw text content
 func (s *DocumentService) IngestDocument(ctx context.Context, req *ragv1.IngestDocumentRequest) (*ragv1.IngestDocumentResponse, error) {
-	if req.TenantId == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 	if req.Content == "" {
 		return nil, status.Error(codes.InvalidArgument, "content is required")
 	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return nil, err
 	}
 
 	// Verify tenant exists and get config
@@ -72,19 +284,35 @@ func (s *DocumentService) IngestDocument(ctx context.Context, req *ragv1.IngestD
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
 	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	chunker, err := chunkerOverride(req.Chunker, tenant)
+	if err != nil {
+		return nil, err
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
 
 	// Calculate content hash for deduplication
-	// Include source URL in hash so different pages with similar content are not deduplicated
-	contentHash := hashContent(req.Source + "\n" + req.Content)
+	// Include source URL in hash so different pages with similar content are not deduplicated;
+	// a URL is canonicalized so other addresses of the same page hash the same
+	source, sourceKey := canonicalSource(req.Source)
+	hashSource := source
+	if sourceKey != "" {
+		hashSource = sourceKey
+	}
+	contentHash := projectHash(projectID(project), hashContent(hashSource+"\n"+req.Content))
 
-	// Debug logging
-	fmt.Printf("[IngestDocument] tenant=%s source=%s contentLen=%d hash=%s\n",
-		tenantID.String(), req.Source, len(req.Content), contentHash[:16])
+	slog.DebugContext(ctx, "ingesting document",
+		"tenant_id", tenantID, "source", req.Source, "content_length", len(req.Content), "content_hash", contentHash[:16])
 
 	// Check for duplicate document (same source + content = true duplicate)
 	existingDoc, err := s.docRepo.GetByHash(ctx, tenantID, contentHash)
 	if err == nil && existingDoc != nil {
-		fmt.Printf("[IngestDocument] DUPLICATE FOUND: existing doc_id=%s\n", existingDoc.ID.String())
+		slog.DebugContext(ctx, "document already ingested", "tenant_id", tenantID, "document_id", existingDoc.ID)
 		return &ragv1.IngestDocumentResponse{
 			DocumentId: existingDoc.ID.String(),
 			Status:     convertStatus(existingDoc.Status),
@@ -97,28 +325,44 @@ func (s *DocumentService) IngestDocument(ctx context.Context, req *ragv1.IngestD
 	doc := &repository.Document{
 		ID:          docID,
 		TenantID:    tenantID,
-		Source:      req.Source,
+		Source:      source,
+		SourceKey:   sourceKey,
 		Title:       req.Title,
 		ContentHash: contentHash,
 		Status:      "PROCESSING",
 		Metadata:    req.Metadata,
+		Chunker:     chunker,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		ProjectID:   projectID(project),
 	}
 
 	if doc.Title == "" {
-		doc.Title = "Untitled Document"
+		doc.Title = untitledDocument
 	}
 	if doc.Source == "" {
 		doc.Source = "direct-upload"
 	}
 
-	if err := s.docRepo.Create(ctx, doc); err != nil {
+	// Keep the original text for re-chunking and GetDocumentContent
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.docRepo.Create(ctx, doc); err != nil {
+			return err
+		}
+		return s.saveContent(ctx, tenant, &repository.DocumentContent{
+			DocumentID:  doc.ID,
+			ContentType: "text/plain",
+			Data:        []byte(req.Content),
+		})
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create document: %v", err)
 	}
 
 	// Process document asynchronously
-	go s.processDocument(context.Background(), doc, req.Content, tenant)
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.processDocument(ctx, doc, req.Content, tenant)
+	})
 
 	return &ragv1.IngestDocumentResponse{
 		DocumentId: docID.String(),
@@ -128,16 +372,13 @@ func (s *DocumentService) IngestDocument(ctx context.Context, req *ragv1.IngestD
 
 // IngestURL fetches and ingests content from a URL
 func (s *DocumentService) IngestURL(ctx context.Context, req *ragv1.IngestURLRequest) (*ragv1.IngestDocumentResponse, error) {
-	if req.TenantId == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 	if req.Url == "" {
 		return nil, status.Error(codes.InvalidArgument, "url is required")
 	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return nil, err
 	}
 
 	// Verify tenant exists and get config
@@ -148,6 +389,37 @@ func (s *DocumentService) IngestURL(ctx context.Context, req *ragv1.IngestURLReq
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
 	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	chunker, err := chunkerOverride(req.Chunker, tenant)
+	if err != nil {
+		return nil, err
+	}
+	source, err := crawl.Canonicalize(req.Url)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+	if req.UseHeadless {
+		if s.renderer == nil {
+			return nil, status.Error(codes.FailedPrecondition, "headless rendering is not configured")
+		}
+		if !tenant.Config.Headless.Enabled {
+			return nil, status.Error(codes.FailedPrecondition, "headless rendering is not enabled for this tenant")
+		}
+	}
+	sourceKey := crawl.SourceKey(source)
+	project, err := requestProject(ctx, s.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another address of an ingested page refreshes its document
+	if existing, err := s.docRepo.GetBySourceKey(ctx, tenantID, projectID(project), sourceKey); err == nil {
+		return s.refreshURL(ctx, existing, req, chunker, tenant)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to look up document: %v", err)
+	}
 
 	// Create document record first with PENDING status
 	now := time.Now()
@@ -155,11 +427,14 @@ func (s *DocumentService) IngestURL(ctx context.Context, req *ragv1.IngestURLReq
 	doc := &repository.Document{
 		ID:        docID,
 		TenantID:  tenantID,
-		Source:    req.Url,
+		Source:    source,
+		SourceKey: sourceKey,
 		Status:    "PENDING",
 		Metadata:  req.Metadata,
+		Chunker:   chunker,
 		CreatedAt: now,
 		UpdatedAt: now,
+		ProjectID: projectID(project),
 	}
 
 	if err := s.docRepo.Create(ctx, doc); err != nil {
@@ -167,7 +442,148 @@ func (s *DocumentService) IngestURL(ctx context.Context, req *ragv1.IngestURLReq
 	}
 
 	// Fetch and process URL asynchronously
-	go s.processURL(context.Background(), doc, req.Url, req.UseHeadless, tenant)
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.processURL(ctx, doc, source, req.UseHeadless, tenant)
+	})
+
+	return &ragv1.IngestDocumentResponse{
+		DocumentId: docID.String(),
+		Status:     ragv1.DocumentStatus_DOCUMENT_STATUS_PENDING,
+	}, nil
+}
+
+// refreshURL re-fetches the page of an existing document into it, replacing
+// its chunks; a document still being processed is returned as it is
+func (s *DocumentService) refreshURL(ctx context.Context, doc *repository.Document, req *ragv1.IngestURLRequest, chunker *repository.ChunkerConfig, tenant *repository.Tenant) (*ragv1.IngestDocumentResponse, error) {
+	if doc.Status == "PENDING" || doc.Status == "PROCESSING" {
+		return &ragv1.IngestDocumentResponse{
+			DocumentId: doc.ID.String(),
+			Status:     convertStatus(doc.Status),
+		}, nil
+	}
+
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string)
+	}
+	for k, v := range req.Metadata {
+		doc.Metadata[k] = v
+	}
+	if chunker != nil {
+		// New chunker settings apply even to an unchanged page
+		doc.Chunker = chunker
+		doc.ETag = ""
+		doc.LastModified = ""
+	}
+	doc.Status = "PENDING"
+	doc.ErrorMessage = ""
+	doc.UpdatedAt = time.Now()
+	if err := s.docRepo.Update(ctx, doc); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update document: %v", err)
+	}
+
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.processURL(ctx, doc, doc.Source, req.UseHeadless, tenant)
+	})
+
+	return &ragv1.IngestDocumentResponse{
+		DocumentId: doc.ID.String(),
+		Status:     ragv1.DocumentStatus_DOCUMENT_STATUS_PENDING,
+	}, nil
+}
+
+// UploadDocument ingests a file, extracting its text with the registered extractor
+func (s *DocumentService) UploadDocument(ctx context.Context, req *ragv1.UploadDocumentRequest) (*ragv1.IngestDocumentResponse, error) {
+	if req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "data is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	extractor, contentType, err := s.extractors.Lookup(req.ContentType, req.Filename, req.Data)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Verify tenant exists and get config
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	chunker, err := chunkerOverride(req.Chunker, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := requestProject(ctx, s.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same file under the same name is a duplicate
+	contentHash := projectHash(projectID(project), hashContent(req.Filename+"\n"+string(req.Data)))
+	existingDoc, err := s.docRepo.GetByHash(ctx, tenantID, contentHash)
+	if err == nil && existingDoc != nil {
+		return &ragv1.IngestDocumentResponse{
+			DocumentId: existingDoc.ID.String(),
+			Status:     convertStatus(existingDoc.Status),
+		}, nil
+	}
+
+	metadata := make(map[string]string, len(req.Metadata)+2)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata["filename"] = req.Filename
+	metadata["content_type"] = contentType
+
+	now := time.Now()
+	docID := uuid.New()
+	doc := &repository.Document{
+		ID:          docID,
+		TenantID:    tenantID,
+		Source:      req.Filename,
+		Title:       req.Title,
+		ContentHash: contentHash,
+		Status:      "PENDING",
+		Metadata:    metadata,
+		Chunker:     chunker,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ProjectID:   projectID(project),
+	}
+
+	// Keep the original file for re-chunking and GetDocumentContent
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.docRepo.Create(ctx, doc); err != nil {
+			return err
+		}
+		return s.saveContent(ctx, tenant, &repository.DocumentContent{
+			DocumentID:  doc.ID,
+			ContentType: contentType,
+			Filename:    req.Filename,
+			Data:        req.Data,
+		})
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create document: %v", err)
+	}
+
+	// Extract and process asynchronously
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.processUpload(ctx, doc, extractor, req.Data, tenant)
+	})
 
 	return &ragv1.IngestDocumentResponse{
 		DocumentId: docID.String(),
@@ -193,19 +609,19 @@ func (s *DocumentService) GetDocument(ctx context.Context, req *ragv1.GetDocumen
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
 	}
+	if !canAccessDocument(ctx, doc) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
 
 	return s.documentToProto(doc), nil
 }
 
 // ListDocuments lists documents for a tenant
 func (s *DocumentService) ListDocuments(ctx context.Context, req *ragv1.ListDocumentsRequest) (*ragv1.ListDocumentsResponse, error) {
-	if req.TenantId == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return nil, err
 	}
 
 	pageSize := int(req.PageSize)
@@ -223,13 +639,19 @@ func (s *DocumentService) ListDocuments(ctx context.Context, req *ragv1.ListDocu
 		}
 	}
 
-	// Convert status filter
-	statusFilter := ""
-	if req.StatusFilter != ragv1.DocumentStatus_DOCUMENT_STATUS_UNSPECIFIED {
-		statusFilter = statusToString(req.StatusFilter)
+	filter, err := documentFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	if project != nil {
+		filter.ProjectID = project.ID
 	}
 
-	docs, total, err := s.docRepo.List(ctx, tenantID, statusFilter, pageSize, offset)
+	docs, total, err := s.docRepo.List(ctx, tenantID, filter, pageSize, offset)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list documents: %v", err)
 	}
@@ -251,6 +673,53 @@ func (s *DocumentService) ListDocuments(ctx context.Context, req *ragv1.ListDocu
 	}, nil
 }
 
+// documentFilter converts ListDocuments filters and sort options
+func documentFilter(req *ragv1.ListDocumentsRequest) (repository.DocumentFilter, error) {
+	filter := repository.DocumentFilter{
+		SourcePrefix:  req.SourcePrefix,
+		SourceGlob:    req.SourceGlob,
+		TitleContains: req.TitleContains,
+		Metadata:      req.Metadata,
+		Ascending:     req.Ascending,
+	}
+	if req.StatusFilter != ragv1.DocumentStatus_DOCUMENT_STATUS_UNSPECIFIED {
+		filter.Status = statusToString(req.StatusFilter)
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return filter, err
+	}
+	filter.Tags = tags
+	if req.CollectionId != "" {
+		if filter.CollectionID, err = uuid.Parse(req.CollectionId); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid collection_id format")
+		}
+	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return filter, status.Error(codes.InvalidArgument, "created_after must be before created_before")
+	}
+
+	switch req.SortBy {
+	case ragv1.DocumentSortField_DOCUMENT_SORT_FIELD_UNSPECIFIED, ragv1.DocumentSortField_DOCUMENT_SORT_FIELD_CREATED_AT:
+		filter.SortBy = repository.DocumentSortCreatedAt
+	case ragv1.DocumentSortField_DOCUMENT_SORT_FIELD_UPDATED_AT:
+		filter.SortBy = repository.DocumentSortUpdatedAt
+	case ragv1.DocumentSortField_DOCUMENT_SORT_FIELD_TITLE:
+		filter.SortBy = repository.DocumentSortTitle
+	case ragv1.DocumentSortField_DOCUMENT_SORT_FIELD_SOURCE:
+		filter.SortBy = repository.DocumentSortSource
+	default:
+		return filter, status.Error(codes.InvalidArgument, "unknown sort field")
+	}
+	return filter, nil
+}
+
 // DeleteDocument deletes a document and its chunks
 func (s *DocumentService) DeleteDocument(ctx context.Context, req *ragv1.DeleteDocumentRequest) (*ragv1.DeleteDocumentResponse, error) {
 	if req.Id == "" {
@@ -270,6 +739,9 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, req *ragv1.DeleteD
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
 	}
+	if !canAccessDocument(ctx, doc) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
 
 	// Delete vectors from vector store
 	if err := s.vectorDB.Delete(ctx, doc.TenantID.String(), doc.ID.String()); err != nil {
@@ -277,14 +749,22 @@ func (s *DocumentService) DeleteDocument(ctx context.Context, req *ragv1.DeleteD
 		_ = err
 	}
 
-	// Delete chunks from repository
-	if err := s.docRepo.DeleteChunks(ctx, id); err != nil {
-		// Log error but continue with deletion
-		_ = err
+	// Delete chunks and the document together, handing vectors shared with
+	// other documents over to them
+	tenant, err := s.tenantRepo.GetByID(ctx, doc.TenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
 	}
-
-	// Delete document
-	if err := s.docRepo.Delete(ctx, id); err != nil {
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.releaseChunks(ctx, tenant, id); err != nil {
+			return err
+		}
+		if err := s.docRepo.DeleteChunks(ctx, id); err != nil {
+			return err
+		}
+		return s.docRepo.Delete(ctx, id)
+	})
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete document: %v", err)
 	}
 
@@ -319,6 +799,17 @@ func (s *DocumentService) GetDocumentChunks(ctx context.Context, req *ragv1.GetD
 		}
 	}
 
+	doc, err := s.docRepo.GetByID(ctx, docID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "document not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
+	}
+	if !canAccessDocument(ctx, doc) {
+		return nil, status.Error(codes.NotFound, "document not found")
+	}
+
 	chunks, err := s.docRepo.GetChunks(ctx, docID, pageSize, offset)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get chunks: %v", err)
@@ -326,7 +817,7 @@ func (s *DocumentService) GetDocumentChunks(ctx context.Context, req *ragv1.GetD
 
 	protoChunks := make([]*ragv1.DocumentChunk, len(chunks))
 	for i, chunk := range chunks {
-		protoChunks[i] = s.chunkToProto(chunk)
+		protoChunks[i] = chunkToProto(chunk)
 	}
 
 	var nextPageToken string
@@ -342,22 +833,28 @@ func (s *DocumentService) GetDocumentChunks(ctx context.Context, req *ragv1.GetD
 
 // processDocument processes a document asynchronously
 func (s *DocumentService) processDocument(ctx context.Context, doc *repository.Document, content string, tenant *repository.Tenant) {
+	s.processSections(ctx, doc, []ingestion.Section{{Content: content}}, tenant)
+}
+
+// processSections chunks, embeds and stores a document made of one or more
+// sections, replacing the chunks of a document being re-chunked
+func (s *DocumentService) processSections(ctx context.Context, doc *repository.Document, sections []ingestion.Section, tenant *repository.Tenant) {
+	previousChunks := doc.ChunkCount
+
 	// Update status to PROCESSING
 	doc.Status = "PROCESSING"
 	doc.UpdatedAt = time.Now()
 	_ = s.docRepo.Update(ctx, doc)
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_CHUNKING, 0, 0)
 
-	// Create ingestion pipeline with tenant config
-	pipeline := ingestion.NewPipeline(ingestion.PipelineConfig{
-		Chunker: tenant.Config.Chunker,
-		DefaultMetadata: map[string]string{
-			"source": doc.Source,
-			"title":  doc.Title,
-		},
-	})
+	text := joinSections(sections)
+	s.enrichDocument(ctx, doc, text)
+	if previousChunks == 0 && s.flagNearDuplicate(ctx, doc, text, tenant) {
+		return
+	}
 
 	// Process content into chunks
-	result, err := pipeline.ProcessWithMetadata(ctx, content, doc.Metadata)
+	result, err := s.documentPipeline(doc, tenant).ProcessSections(ctx, sections, doc.Metadata)
 	if err != nil {
 		s.markDocumentFailed(ctx, doc, fmt.Sprintf("chunking failed: %v", err))
 		return
@@ -365,114 +862,448 @@ func (s *DocumentService) processDocument(ctx context.Context, doc *repository.D
 
 	// Convert chunks for storage
 	docChunks := ingestion.ChunksToDocumentChunks(result.Chunks, doc.ID)
-
-	// Store chunks in repository
-	if err := s.docRepo.CreateChunks(ctx, docChunks); err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to store chunks: %v", err))
+	searchConfig := textSearchConfig(tenant.Config.TextAnalysis)
+	for _, chunk := range docChunks {
+		chunk.SearchConfig = searchConfig
+	}
+	if s.checkpointed(len(docChunks), previousChunks) {
+		s.processCheckpointed(ctx, doc, docChunks, nil, tenant)
 		return
 	}
 
-	// Generate embeddings for all chunks
-	chunkContents := make([]string, len(result.Chunks))
-	for i, chunk := range result.Chunks {
-		chunkContents[i] = chunk.Content
+	// Embed first, so no connection or row lock is held during the embedding
+	// calls. Chunks expected to share another document's vector are skipped.
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_CHUNKED, len(docChunks), 0)
+	emb := s.embedderFor(tenant)
+	embeddings := make(map[uuid.UUID][]float32)
+	expected, err := s.expectOwnVectors(ctx, tenant, doc, docChunks)
+	if err != nil {
+		s.failProcessing(ctx, doc, tenant, previousChunks, false, fmt.Sprintf("failed to share chunks: %v", err))
+		return
 	}
-
-	embeddings, err := s.embedder.EmbedBatch(ctx, chunkContents)
+	shared := len(docChunks) - len(expected)
+	err = s.embedChunks(ctx, emb, expected, embeddings, func() {
+		s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_EMBEDDING, len(docChunks), shared+len(embeddings))
+	})
 	if err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("embedding failed: %v", err))
+		s.failProcessing(ctx, doc, tenant, previousChunks, false, fmt.Sprintf("embedding failed: %v", err))
 		return
 	}
 
-	// Store vectors in vector store
-	vectorChunks := make([]vectorstore.Chunk, len(docChunks))
-	for i, chunk := range docChunks {
-		metadata := make(map[string]string)
-		for k, v := range chunk.Metadata {
-			metadata[k] = v
+	// Chunks, their vectors, the READY status and usage then commit together
+	// in a short transaction; a failure rolls back the chunks and deletes the
+	// vectors before the document is failed
+	var failure string
+	var vectorChunks []vectorstore.Chunk
+	var upserted bool
+	err = s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if previousChunks > 0 {
+			if err := s.releaseChunks(ctx, tenant, doc.ID); err != nil {
+				failure = fmt.Sprintf("failed to release shared chunks: %v", err)
+				return err
+			}
+			if err := s.docRepo.DeleteChunks(ctx, doc.ID); err != nil {
+				failure = fmt.Sprintf("failed to replace chunks: %v", err)
+				return err
+			}
+		}
+		if err := s.shareChunks(ctx, tenant, doc.ProjectID, docChunks); err != nil {
+			failure = fmt.Sprintf("failed to share chunks: %v", err)
+			return err
+		}
+		if err := s.docRepo.CreateChunks(ctx, docChunks); err != nil {
+			failure = fmt.Sprintf("failed to store chunks: %v", err)
+			return err
 		}
-		metadata["document_id"] = doc.ID.String()
-		metadata["title"] = doc.Title
-		metadata["source"] = doc.Source
 
-		vectorChunks[i] = vectorstore.Chunk{
-			ID:         chunk.ID.String(),
-			DocumentID: doc.ID.String(),
-			TenantID:   doc.TenantID.String(),
-			Content:    chunk.Content,
-			Vector:     embeddings[i],
-			Metadata:   metadata,
+		// A chunk expected to share a vector whose owner was deleted since
+		// needs one of its own after all
+		own := ownVectors(docChunks)
+		if err := s.embedChunks(ctx, emb, own, embeddings, nil); err != nil {
+			failure = fmt.Sprintf("embedding failed: %v", err)
+			return err
+		}
+		ownEmbeddings := make([][]float32, len(own))
+		for i, chunk := range own {
+			ownEmbeddings[i] = embeddings[chunk.ID]
+		}
+
+		// Store vectors in vector store, with tags and collections added while
+		// the document was processing
+		if current, err := s.docRepo.GetByID(ctx, doc.ID); err == nil {
+			doc.Tags = current.Tags
+			doc.CollectionIDs = current.CollectionIDs
+		}
+		if previousChunks > 0 {
+			err := s.vectorDB.Delete(ctx, doc.TenantID.String(), doc.ID.String())
+			if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+				failure = fmt.Sprintf("failed to replace vectors: %v", err)
+				return err
+			}
+		}
+		vectorChunks = buildVectorChunks(doc, own, ownEmbeddings)
+		upserted = true
+		if err := s.vectorDB.Upsert(ctx, doc.TenantID.String(), vectorChunks); err != nil {
+			failure = fmt.Sprintf("vector storage failed: %v", err)
+			return err
+		}
+		if tenant.Config.DedupChunks {
+			if err := syncSharedLists(ctx, s.docRepo, s.vectorDB, doc.TenantID, doc.ID, nil); err != nil {
+				failure = err.Error()
+				return err
+			}
+		}
+		s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED, len(docChunks), len(docChunks))
+
+		// Mark document as ready
+		doc.Status = "READY"
+		doc.ChunkCount = len(docChunks)
+		doc.UpdatedAt = time.Now()
+		if err := s.docRepo.Update(ctx, doc); err != nil {
+			failure = fmt.Sprintf("failed to update document: %v", err)
+			return err
 		}
-	}
 
-	if err := s.vectorDB.Upsert(ctx, doc.TenantID.String(), vectorChunks); err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("vector storage failed: %v", err))
+		// Update tenant usage; a re-chunked document is already counted
+		usage := repository.TenantUsage{ChunkCount: len(docChunks) - previousChunks}
+		if previousChunks == 0 {
+			usage.DocumentCount = 1 // Increment
+		}
+		if err := s.tenantRepo.UpdateUsage(ctx, doc.TenantID, usage); err != nil {
+			failure = fmt.Sprintf("failed to update usage: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if failure == "" {
+			failure = err.Error()
+		}
+		s.failProcessing(ctx, doc, tenant, previousChunks, upserted, failure)
 		return
 	}
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED, doc.ChunkCount, doc.ChunkCount)
+	s.extractGraph(ctx, doc, docChunks, tenant)
+	s.indexSummaries(ctx, doc, docChunks, vectorChunks, tenant)
+}
 
-	// Mark document as ready
-	doc.Status = "READY"
-	doc.ChunkCount = len(docChunks)
-	doc.UpdatedAt = time.Now()
-	_ = s.docRepo.Update(ctx, doc)
+// failProcessing cleans up after processSections fails, even when the
+// ingestion was interrupted, and marks the document failed. upserted is set
+// once the new vectors may have been written.
+func (s *DocumentService) failProcessing(ctx context.Context, doc *repository.Document, tenant *repository.Tenant, previousChunks int, upserted bool, failure string) {
+	cleanup := context.WithoutCancel(ctx)
+	if upserted || previousChunks > 0 || interrupted(ctx) {
+		// The vectors were written or replaced but their chunks were rolled back
+		_ = s.vectorDB.Delete(cleanup, doc.TenantID.String(), doc.ID.String())
+	}
+	if previousChunks > 0 {
+		// The old chunks are back but no longer have vectors
+		_ = s.releaseChunks(cleanup, tenant, doc.ID)
+		_ = s.docRepo.DeleteChunks(cleanup, doc.ID)
+	}
+	doc.ChunkCount = 0
+	s.markDocumentFailed(ctx, doc, failure)
+}
 
-	// Update tenant usage
-	_ = s.tenantRepo.UpdateUsage(ctx, doc.TenantID, repository.TenantUsage{
-		DocumentCount: 1, // Increment
-		ChunkCount:    len(docChunks),
+// documentPipeline creates the ingestion pipeline of a document, with the
+// tenant's config, the document's override and the tenant's custom stages
+func (s *DocumentService) documentPipeline(doc *repository.Document, tenant *repository.Tenant) *ingestion.Pipeline {
+	pipeline := ingestion.NewPipeline(ingestion.PipelineConfig{
+		Chunker: documentChunker(doc, tenant),
+		DefaultMetadata: map[string]string{
+			"source": doc.Source,
+			"title":  doc.Title,
+		},
+		PII: tenant.Config.PII,
 	})
+	if s.stages != nil {
+		for _, stage := range s.stages(tenant) {
+			if err := pipeline.AddStage(stage); err != nil {
+				slog.Warn("skipping ingestion stage", "tenant_id", tenant.ID, "error", err)
+			}
+		}
+	}
+	return pipeline
+}
+
+// joinSections returns the text of a document's sections
+func joinSections(sections []ingestion.Section) string {
+	texts := make([]string, len(sections))
+	for i, section := range sections {
+		texts[i] = section.Content
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// enrichDocument adds detected metadata to a document, keeping any keys it
+// already has, and titles documents ingested without one. The language is
+// detected even without an enricher, so queries can filter on it.
+func (s *DocumentService) enrichDocument(ctx context.Context, doc *repository.Document, text string) {
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string)
+	}
+	if _, exists := doc.Metadata[ingestion.MetadataLanguage]; !exists {
+		if lang := ingestion.DetectLanguage(text); lang != "" {
+			doc.Metadata[ingestion.MetadataLanguage] = lang
+		}
+	}
+	if s.enricher == nil {
+		return
+	}
+
+	if doc.Title == "" || doc.Title == untitledDocument {
+		if title := ingestion.DetectTitle(text); title != "" {
+			doc.Title = title
+		}
+	}
+	for k, v := range s.enricher.Enrich(ctx, text) {
+		if _, exists := doc.Metadata[k]; !exists {
+			doc.Metadata[k] = v
+		}
+	}
+}
+
+// buildVectorChunks pairs stored chunks with their embeddings and the payload metadata used at query time
+func buildVectorChunks(doc *repository.Document, docChunks []*repository.DocumentChunk, embeddings [][]float32) []vectorstore.Chunk {
+	vectorChunks := make([]vectorstore.Chunk, len(docChunks))
+	for i, chunk := range docChunks {
+		vectorChunks[i] = vectorstore.Chunk{
+			ID:            chunk.ID.String(),
+			DocumentID:    doc.ID.String(),
+			TenantID:      doc.TenantID.String(),
+			Content:       chunk.Content,
+			Vector:        embeddings[i],
+			Metadata:      chunkMetadata(chunk, doc.Title, doc.Source),
+			Tags:          doc.Tags,
+			CollectionIDs: uuidStrings(doc.CollectionIDs),
+			ProjectID:     projectIDString(doc.ProjectID),
+		}
+	}
+	return vectorChunks
+}
+
+// chunkMetadata is a chunk's metadata plus the document and position fields
+// retrieval results carry
+func chunkMetadata(chunk *repository.DocumentChunk, title, source string) map[string]string {
+	metadata := make(map[string]string)
+	for k, v := range chunk.Metadata {
+		metadata[k] = v
+	}
+	metadata["document_id"] = chunk.DocumentID.String()
+	metadata["title"] = title
+	metadata["source"] = source
+	metadata["chunk_index"] = strconv.Itoa(chunk.ChunkIndex)
+	metadata["parent_start"] = strconv.Itoa(chunk.ParentStart)
+	if !chunk.CreatedAt.IsZero() {
+		metadata[ingestedAtField] = chunk.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	metadata["parent_end"] = strconv.Itoa(chunk.ParentEnd)
+	return metadata
 }
 
-// processURL fetches a URL and processes its content
-func (s *DocumentService) processURL(ctx context.Context, doc *repository.Document, url string, useHeadless bool, tenant *repository.Tenant) {
+// errNotModified is returned by fetchPage for a page unchanged since it was
+// last fetched
+var errNotModified = errors.New("not modified")
+
+// processURL fetches a URL, or renders it in the headless browser, and
+// processes its content. It returns the page fetched, or nil if it could not
+// be fetched or is unchanged.
+func (s *DocumentService) processURL(ctx context.Context, doc *repository.Document, url string, useHeadless bool, tenant *repository.Tenant) []byte {
 	// Update status to PROCESSING
 	doc.Status = "PROCESSING"
 	doc.UpdatedAt = time.Now()
 	_ = s.docRepo.Update(ctx, doc)
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_FETCHING, 0, 0)
+
+	if s.robots != nil {
+		if err := s.robots.Check(ctx, url); err != nil {
+			s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to fetch URL: %v", err))
+			return nil
+		}
+	}
+
+	var body []byte
+	var bodyHash string
+	contentType := ingestion.ContentTypeHTML
+	if useHeadless && s.renderer != nil {
+		slot, err := s.scheduler.Acquire(ctx, url)
+		if err != nil {
+			s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to render URL: %v", err))
+			return nil
+		}
+		page, err := s.renderer.Render(ctx, url, headlessTimeout(tenant.Config.Headless))
+		slot.Release(nil)
+		if err != nil {
+			s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to render URL: %v", err))
+			return nil
+		}
+		body = page.HTML
+		// Scripts can change a page its validators say is unchanged
+		doc.ETag = ""
+		doc.LastModified = ""
+	} else {
+		page, err := s.fetchPage(ctx, doc, url)
+		if errors.Is(err, errNotModified) {
+			s.markDocumentUnchanged(ctx, doc)
+			return nil
+		}
+		if err != nil {
+			s.markDocumentFailed(ctx, doc, err.Error())
+			return nil
+		}
+		body, bodyHash = page.body, page.hash
+		contentType = ingestion.SniffContentType(page.contentType, body)
+	}
+
+	if !ingestion.IsHTML(contentType) {
+		s.processFetchedFile(ctx, doc, url, contentType, body, bodyHash, tenant)
+		return body
+	}
+
+	// Keep the fetched page for re-chunking and GetDocumentContent
+	if err := s.saveContent(ctx, tenant, &repository.DocumentContent{
+		DocumentID:  doc.ID,
+		ContentType: "text/html",
+		Data:        body,
+	}); err != nil {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to store content: %v", err))
+		return nil
+	}
+
+	s.processHTML(ctx, doc, body, tenant)
+	return body
+}
 
-	// Note: useHeadless is ignored - for JS-heavy sites, use the standalone Playwright crawler
-	// and submit content via IngestDocument instead
+// fetchedPage is a downloaded page
+type fetchedPage struct {
+	body        []byte
+	contentType string // as declared by the server
+	hash        string // SHA-256 of body, as hashContent computes it
+}
 
-	// Fetch URL content with simple HTTP GET
+// fetchPage downloads a page with a simple HTTP GET and records its
+// validators. A page fetched before is conditionally requested, and
+// errNotModified returned if it is unchanged. Pages of a type no extractor
+// reads, or larger than the download limit, are not downloaded.
+func (s *DocumentService) fetchPage(ctx context.Context, doc *repository.Document, url string) (*fetchedPage, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to create request: %v", err))
-		return
+		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	req.Header.Set("User-Agent", "RAG-Service/1.0")
+	req.Header.Set("User-Agent", fetchUserAgent)
 
-	resp, err := s.httpClient.Do(req)
+	if doc.ChunkCount > 0 {
+		if doc.ETag != "" {
+			req.Header.Set("If-None-Match", doc.ETag)
+		}
+		if doc.LastModified != "" {
+			req.Header.Set("If-Modified-Since", doc.LastModified)
+		}
+	}
+
+	resp, err := s.scheduler.Do(s.httpClient, req)
 	if err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to fetch URL: %v", err))
-		return
+		return nil, fmt.Errorf("failed to fetch URL: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && doc.ChunkCount > 0 {
+		return nil, errNotModified
+	}
 	if resp.StatusCode != http.StatusOK {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("HTTP %d: %s", resp.StatusCode, resp.Status))
-		return
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	declared := resp.Header.Get("Content-Type")
+	if !s.acceptsContentType(ingestion.MediaType(declared)) {
+		return nil, fmt.Errorf("unsupported content type %s", ingestion.MediaType(declared))
+	}
+	if resp.ContentLength > s.maxDownloadSize {
+		return nil, fmt.Errorf("page is %d bytes, over the %d-byte download limit", resp.ContentLength, s.maxDownloadSize)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// Hash while reading, and stop a byte past the limit
+	hasher := sha256.New()
+	body, err := io.ReadAll(io.TeeReader(io.LimitReader(resp.Body, s.maxDownloadSize+1), hasher))
 	if err != nil {
-		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to read response: %v", err))
-		return
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	if int64(len(body)) > s.maxDownloadSize {
+		return nil, fmt.Errorf("page is over the %d-byte download limit", s.maxDownloadSize)
 	}
+	doc.ETag = resp.Header.Get("ETag")
+	doc.LastModified = resp.Header.Get("Last-Modified")
+	return &fetchedPage{
+		body:        body,
+		contentType: declared,
+		hash:        hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
 
-	content := string(body)
+// acceptsContentType reports whether a page declared as a media type can be
+// ingested. Missing and generic types are decided once the page is sniffed.
+func (s *DocumentService) acceptsContentType(mediaType string) bool {
+	if mediaType == "" || mediaType == "application/octet-stream" || ingestion.IsHTML(mediaType) {
+		return true
+	}
+	_, _, err := s.extractors.Lookup(mediaType, "", nil)
+	return err == nil
+}
+
+// processHTML extracts a fetched page's main content and processes it
+func (s *DocumentService) processHTML(ctx context.Context, doc *repository.Document, body []byte, tenant *repository.Tenant) {
+	url := doc.Source
+
+	// Keep only the main content; nav bars, footers and banners pollute chunks.
+	// Markdown keeps headings, tables and code blocks for the semantic chunker.
+	extracted, err := ingestion.ExtractHTML(string(body))
+	if err != nil {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to extract content: %v", err))
+		return
+	}
+	content := extracted.Markdown
 
-	// Extract title from HTML if present
-	title := extractTitle(content)
-	if title != "" {
-		doc.Title = title
+	if extracted.Title != "" {
+		doc.Title = extracted.Title
 	} else {
 		doc.Title = url
 	}
 
-	// Strip HTML tags for plain text content
-	content = stripHTML(content)
+	// Honor the page's canonical link, unless another document already has it
+	if doc.SourceKey != "" && extracted.Canonical != "" {
+		if canonical, err := crawl.Resolve(url, extracted.Canonical); err == nil && canonical != doc.Source {
+			key := crawl.SourceKey(canonical)
+			if other, err := s.docRepo.GetBySourceKey(ctx, doc.TenantID, doc.ProjectID, key); err == nil && other.ID != doc.ID {
+				s.markDocumentFailed(ctx, doc, fmt.Sprintf("canonical URL %s is document %s", canonical, other.ID))
+				return
+			}
+			doc.Source = canonical
+			doc.SourceKey = key
+		}
+	}
+
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string)
+	}
+	for k, v := range extracted.Quality.Metadata() {
+		doc.Metadata[k] = v
+	}
+	// Declared author, dates, type and language; metadata set by the client is kept
+	structured := extracted.Structured.Metadata()
+	if image, ok := structured[ingestion.MetadataImageURL]; ok {
+		if resolved, err := crawl.Resolve(url, image); err == nil {
+			structured[ingestion.MetadataImageURL] = resolved
+		} else {
+			delete(structured, ingestion.MetadataImageURL)
+		}
+	}
+	for k, v := range structured {
+		if _, exists := doc.Metadata[k]; !exists {
+			doc.Metadata[k] = v
+		}
+	}
 
-	doc.ContentHash = hashContent(content)
+	doc.ContentHash = projectHash(doc.ProjectID, hashContent(content))
 
 	// Check for duplicate by hash
 	existingDoc, err := s.docRepo.GetByHash(ctx, doc.TenantID, doc.ContentHash)
@@ -481,43 +1312,125 @@ func (s *DocumentService) processURL(ctx context.Context, doc *repository.Docume
 		return
 	}
 
-	// Process the fetched content
-	s.processDocument(ctx, doc, content, tenant)
+	// Process the fetched content, with image captions as separate sections
+	sections := []ingestion.Section{{Content: content}}
+	sections = append(sections, s.captionImages(ctx, extracted.Images, url)...)
+	s.processSections(ctx, doc, sections, tenant)
 }
 
-// extractTitle extracts the title from HTML content
-func extractTitle(html string) string {
-	re := regexp.MustCompile(`(?i)<title[^>]*>([^<]+)</title>`)
-	matches := re.FindStringSubmatch(html)
-	if len(matches) > 1 {
-		return strings.TrimSpace(matches[1])
+// processFetchedFile extracts a fetched page that is not HTML, such as a PDF
+// or plain text, with the extractor for its content type
+func (s *DocumentService) processFetchedFile(ctx context.Context, doc *repository.Document, pageURL, contentType string, body []byte, bodyHash string, tenant *repository.Tenant) {
+	filename := fetchedFilename(pageURL)
+	extractor, resolved, err := s.extractors.Lookup(contentType, filename, body)
+	if err != nil {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("unsupported content type %s", contentType))
+		return
+	}
+
+	doc.ContentHash = projectHash(doc.ProjectID, bodyHash)
+	existingDoc, err := s.docRepo.GetByHash(ctx, doc.TenantID, doc.ContentHash)
+	if err == nil && existingDoc != nil && existingDoc.ID != doc.ID {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("duplicate content exists in document %s", existingDoc.ID.String()))
+		return
+	}
+
+	// Keep the file, under its name so re-chunking extracts it again
+	if err := s.saveContent(ctx, tenant, &repository.DocumentContent{
+		DocumentID:  doc.ID,
+		ContentType: resolved,
+		Filename:    filename,
+		Data:        body,
+	}); err != nil {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("failed to store content: %v", err))
+		return
+	}
+
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string)
 	}
-	return ""
+	doc.Metadata["content_type"] = resolved
+	s.processUpload(ctx, doc, extractor, body, tenant)
 }
 
-// stripHTML removes HTML tags and returns plain text
-func stripHTML(html string) string {
-	// Remove script and style elements
-	re := regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</\1>`)
-	text := re.ReplaceAllString(html, "")
+// fetchedFilename names a fetched file after the last segment of its URL path
+func fetchedFilename(pageURL string) string {
+	if u, err := url.Parse(pageURL); err == nil {
+		if name := path.Base(u.Path); name != "/" && name != "." {
+			return name
+		}
+	}
+	return "download"
+}
 
-	// Remove all HTML tags
-	re = regexp.MustCompile(`<[^>]+>`)
-	text = re.ReplaceAllString(text, " ")
+// captionImages describes images with the captioner, if one is configured
+func (s *DocumentService) captionImages(ctx context.Context, images []ingestion.ImageRef, baseURL string) []ingestion.Section {
+	if s.captioner == nil || len(images) == 0 {
+		return nil
+	}
+	return s.captioner.CaptionSections(ctx, images, baseURL)
+}
 
-	// Clean up whitespace
-	re = regexp.MustCompile(`\s+`)
-	text = re.ReplaceAllString(text, " ")
+// processUpload extracts an uploaded file and processes its sections
+func (s *DocumentService) processUpload(ctx context.Context, doc *repository.Document, extractor ingestion.Extractor, data []byte, tenant *repository.Tenant) {
+	extracted, err := extractor.Extract(ctx, data)
+	if err != nil {
+		s.markDocumentFailed(ctx, doc, fmt.Sprintf("extraction failed: %v", err))
+		return
+	}
+	if extracted.Text() == "" {
+		s.markDocumentFailed(ctx, doc, "no text could be extracted from the file")
+		return
+	}
+
+	if doc.Title == "" {
+		doc.Title = extracted.Title
+	}
+	if doc.Title == "" {
+		doc.Title = doc.Source
+	}
+	for k, v := range extracted.Metadata {
+		if _, exists := doc.Metadata[k]; !exists {
+			doc.Metadata[k] = v
+		}
+	}
 
-	return strings.TrimSpace(text)
+	// Uploads have no base URL; only absolute and data: image sources resolve
+	sections := append(extracted.Sections, s.captionImages(ctx, extracted.Images, "")...)
+	s.processSections(ctx, doc, sections, tenant)
 }
 
 // markDocumentFailed marks a document as failed with an error message
+// unless it was interrupted, to be resumed
 func (s *DocumentService) markDocumentFailed(ctx context.Context, doc *repository.Document, errorMsg string) {
+	if interrupted(ctx) {
+		return
+	}
 	doc.Status = "FAILED"
 	doc.ErrorMessage = errorMsg
 	doc.UpdatedAt = time.Now()
 	_ = s.docRepo.Update(ctx, doc)
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_UNSPECIFIED, 0, 0)
+}
+
+// markDocumentUnchanged returns a re-fetched document whose page has not
+// changed to ready, keeping its chunks and vectors
+func (s *DocumentService) markDocumentUnchanged(ctx context.Context, doc *repository.Document) {
+	doc.Status = "READY"
+	doc.ErrorMessage = ""
+	doc.UpdatedAt = time.Now()
+	_ = s.docRepo.Update(ctx, doc)
+	s.publishProgress(doc, ragv1.DocumentStage_DOCUMENT_STAGE_VECTORS_UPSERTED, doc.ChunkCount, doc.ChunkCount)
+}
+
+// canonicalSource canonicalizes a source that is a web URL and returns it
+// with its source key; other sources are returned as given, without a key
+func canonicalSource(source string) (string, string) {
+	canonical, err := crawl.Canonicalize(source)
+	if err != nil {
+		return source, ""
+	}
+	return canonical, crawl.SourceKey(canonical)
 }
 
 // hashContent generates a SHA-256 hash of content
@@ -528,23 +1441,33 @@ func hashContent(content string) string {
 
 // documentToProto converts a repository Document to proto Document
 func (s *DocumentService) documentToProto(doc *repository.Document) *ragv1.Document {
-	return &ragv1.Document{
-		Id:           doc.ID.String(),
-		TenantId:     doc.TenantID.String(),
-		Source:       doc.Source,
-		Title:        doc.Title,
-		ContentHash:  doc.ContentHash,
-		ChunkCount:   int32(doc.ChunkCount),
-		Status:       convertStatus(doc.Status),
-		ErrorMessage: doc.ErrorMessage,
-		Metadata:     doc.Metadata,
-		CreatedAt:    timestamppb.New(doc.CreatedAt),
-		UpdatedAt:    timestamppb.New(doc.UpdatedAt),
+	pd := &ragv1.Document{
+		Id:            doc.ID.String(),
+		TenantId:      doc.TenantID.String(),
+		Source:        doc.Source,
+		Title:         doc.Title,
+		ContentHash:   doc.ContentHash,
+		ChunkCount:    int32(doc.ChunkCount),
+		Status:        convertStatus(doc.Status),
+		ErrorMessage:  doc.ErrorMessage,
+		Metadata:      doc.Metadata,
+		Tags:          doc.Tags,
+		CollectionIds: uuidStrings(doc.CollectionIDs),
+		CreatedAt:     timestamppb.New(doc.CreatedAt),
+		UpdatedAt:     timestamppb.New(doc.UpdatedAt),
+		ProjectId:     projectIDString(doc.ProjectID),
+	}
+	if doc.Chunker != nil {
+		pd.Chunker = chunkerToProto(*doc.Chunker)
+	}
+	if doc.NearDuplicateOf != nil {
+		pd.NearDuplicateOf = doc.NearDuplicateOf.String()
 	}
+	return pd
 }
 
 // chunkToProto converts a repository DocumentChunk to proto DocumentChunk
-func (s *DocumentService) chunkToProto(chunk *repository.DocumentChunk) *ragv1.DocumentChunk {
+func chunkToProto(chunk *repository.DocumentChunk) *ragv1.DocumentChunk {
 	return &ragv1.DocumentChunk{
 		Id:         chunk.ID.String(),
 		DocumentId: chunk.DocumentID.String(),
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// errIngestionDrained is the cause of the context of ingestions Drain
+// interrupts
+var errIngestionDrained = errors.New("ingestion interrupted by shutdown")
+
+// drainGrace is how long Drain waits for interrupted ingestions to stop,
+// and to suspend them
+const drainGrace = 5 * time.Second
+
+// ingestionWorkers tracks the goroutines processing documents, so shutdown
+// can wait for them
+type ingestionWorkers struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	active atomic.Int64
+}
+
+func newIngestionWorkers() *ingestionWorkers {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &ingestionWorkers{ctx: ctx, cancel: cancel}
+}
+
+// interrupted reports whether ctx is of an ingestion Drain interrupted; its
+// document is suspended for ResumeIngestion instead of failed
+func interrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errIngestionDrained)
+}
+
+// goIngest processes a document in the background, suspending it if Drain
+// interrupts it
+func (s *DocumentService) goIngest(doc *repository.Document, tenant *repository.Tenant, process func(ctx context.Context)) {
+	s.workers.wg.Add(1)
+	s.workers.active.Add(1)
+	go func() {
+		defer s.workers.wg.Done()
+		defer s.workers.active.Add(-1)
+		ctx := s.workers.ctx
+		process(ctx)
+		if interrupted(ctx) {
+			s.suspendIngestion(doc)
+		}
+	}()
+}
+
+// Drain waits for the documents being processed to finish, until ctx is
+// done, then interrupts the rest. Interrupted documents with a checkpoint
+// or stored content are resumed by ResumeIngestion at the next start;
+// others fail. It is meant to be called once at shutdown, after the servers
+// stop taking requests.
+func (s *DocumentService) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.workers.wg.Wait()
+		close(done)
+	}()
+
+	if n := s.workers.active.Load(); n > 0 {
+		slog.InfoContext(ctx, "waiting for in-flight ingestions", "documents", n)
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	slog.WarnContext(ctx, "ingestion drain timed out, interrupting in-flight documents", "documents", s.workers.active.Load())
+	s.workers.cancel(errIngestionDrained)
+	select {
+	case <-done:
+	case <-time.After(drainGrace):
+		slog.WarnContext(ctx, "interrupted ingestions did not stop in time", "documents", s.workers.active.Load())
+	}
+	return ctx.Err()
+}
+
+// suspendIngestion leaves an interrupted document for ResumeIngestion: a
+// checkpointed one continues from its checkpoint, and one with stored
+// content is restarted from it, marked by a checkpoint without chunks
+func (s *DocumentService) suspendIngestion(doc *repository.Document) {
+	ctx, cancel := context.WithTimeout(context.Background(), drainGrace)
+	defer cancel()
+
+	current, err := s.docRepo.GetByID(ctx, doc.ID)
+	if err != nil || (current.Status != "PENDING" && current.Status != "PROCESSING") {
+		return // Finished before it was interrupted, or deleted
+	}
+	checkpoints, err := s.docRepo.ListCheckpoints(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to suspend interrupted ingestion", "document_id", doc.ID, "error", err)
+		return
+	}
+	for _, checkpoint := range checkpoints {
+		if checkpoint.DocumentID == doc.ID {
+			slog.InfoContext(ctx, "suspended checkpointed ingestion", "document_id", doc.ID,
+				"chunks_embedded", checkpoint.ChunksEmbedded, "chunks_total", checkpoint.ChunksTotal)
+			return
+		}
+	}
+
+	if _, err := s.docRepo.GetContent(ctx, doc.ID); err != nil {
+		doc.ChunkCount = 0
+		s.markDocumentFailed(ctx, doc, errIngestionDrained.Error()+"; ingest the document again")
+		return
+	}
+	if err := s.docRepo.SaveCheckpoint(ctx, &repository.IngestionCheckpoint{DocumentID: doc.ID}); err != nil {
+		slog.WarnContext(ctx, "failed to suspend interrupted ingestion", "document_id", doc.ID, "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "suspended ingestion to restart from its content", "document_id", doc.ID)
+}
+
+// restartIngestion reprocesses a document whose ingestion was suspended
+// before its chunks were stored, from its stored content
+func (s *DocumentService) restartIngestion(ctx context.Context, doc *repository.Document, tenant *repository.Tenant) {
+	content, err := s.docRepo.GetContent(ctx, doc.ID)
+	if err != nil {
+		_ = s.docRepo.DeleteCheckpoint(ctx, doc.ID)
+		s.markDocumentFailed(ctx, doc, errIngestionDrained.Error()+"; ingest the document again")
+		return
+	}
+	slog.InfoContext(ctx, "restarting interrupted ingestion", "document_id", doc.ID)
+	s.goIngest(doc, tenant, func(ctx context.Context) {
+		s.reprocess(ctx, doc, content, tenant)
+		if !interrupted(ctx) {
+			// Checkpointed processing replaced the marker and removed it itself
+			_ = s.docRepo.DeleteCheckpoint(ctx, doc.ID)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+const (
+	// defaultJaccardThreshold is the word-set overlap at which retrieved
+	// chunks are duplicates
+	defaultJaccardThreshold = 0.7
+
+	// defaultEmbeddingThreshold is the cosine similarity at which retrieved
+	// chunks are duplicates; paraphrases of one passage typically score
+	// above it, related passages below
+	defaultEmbeddingThreshold = 0.95
+)
+
+// duplicateMeasure returns how two of the results are compared for
+// deduplication, and the similarity at which they are duplicates. The
+// embedding measure falls back to Jaccard for results without vectors.
+func duplicateMeasure(results []vectorstore.SearchResult, cfg repository.DuplicateResultConfig) (func(i, j int) float64, float64) {
+	wordSets := make([]map[string]struct{}, len(results))
+	jaccard := func(i, j int) float64 {
+		for _, k := range []int{i, j} {
+			if wordSets[k] == nil {
+				wordSets[k] = tokenize(results[k].Content)
+			}
+		}
+		return jaccardSimilarity(wordSets[i], wordSets[j])
+	}
+
+	measure, threshold := jaccard, defaultJaccardThreshold
+	if cfg.Method == repository.DuplicateResultsEmbedding {
+		measure = func(i, j int) float64 {
+			a, b := results[i].Vector, results[j].Vector
+			if len(a) == 0 || len(a) != len(b) {
+				return jaccard(i, j)
+			}
+			return cosineSimilarity(a, b)
+		}
+		threshold = defaultEmbeddingThreshold
+	}
+	if cfg.Threshold > 0 {
+		threshold = cfg.Threshold
+	}
+	return measure, threshold
+}
+
+// validateDuplicateResults checks a tenant's result deduplication settings
+func validateDuplicateResults(cfg repository.DuplicateResultConfig) error {
+	switch cfg.Method {
+	case repository.DuplicateResultsJaccard, repository.DuplicateResultsEmbedding, repository.DuplicateResultsOff:
+	default:
+		return fmt.Errorf("duplicate_results method must be empty, %q or %q", repository.DuplicateResultsEmbedding, repository.DuplicateResultsOff)
+	}
+	if cfg.Threshold < 0 || cfg.Threshold > 1 {
+		return fmt.Errorf("duplicate_results threshold must be between 0 and 1")
+	}
+	return nil
+}
+
+// duplicateResultsFromProto converts a proto DuplicateResultConfig
+func duplicateResultsFromProto(p *ragv1.DuplicateResultConfig) repository.DuplicateResultConfig {
+	return repository.DuplicateResultConfig{
+		Method:    p.Method,
+		Threshold: float64(p.Threshold),
+	}
+}
+
+// duplicateResultsToProto converts a repository DuplicateResultConfig to proto DuplicateResultConfig
+func duplicateResultsToProto(c repository.DuplicateResultConfig) *ragv1.DuplicateResultConfig {
+	return &ragv1.DuplicateResultConfig{
+		Method:    c.Method,
+		Threshold: float32(c.Threshold),
+	}
+}
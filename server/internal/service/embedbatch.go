@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// defaultEmbedBatchSize is how many chunks are embedded per batch, and
+// between progress reports, unless WithEmbedBatching sets it
+const defaultEmbedBatchSize = 32
+
+// embedBatchAttempts is how many times a batch is embedded before the
+// document fails; a failed batch is retried alone, not the whole document
+const embedBatchAttempts = 3
+
+// embedBatchBackoff is the wait before a batch's second attempt, doubled
+// for each attempt after it
+const embedBatchBackoff = time.Second
+
+// WithEmbedBatching embeds a document's chunks batchSize at a time, with up
+// to concurrency batches in flight. Zero or negative values keep the
+// defaults of 32 chunks and one batch at a time.
+func WithEmbedBatching(batchSize, concurrency int) DocumentServiceOption {
+	return func(s *DocumentService) {
+		s.embedBatchSize = batchSize
+		s.embedConcurrency = concurrency
+	}
+}
+
+// embedInBatches embeds texts a batch at a time, retrying each failed batch
+// on its own. It calls done in order with each batch's offset in texts and
+// embeddings, so callers can store and checkpoint batches as they finish;
+// an error from embedding or done stops it.
+func (s *DocumentService) embedInBatches(ctx context.Context, emb embedder.Embedder, texts []string, done func(start int, embeddings [][]float32) error) error {
+	batchSize := s.embedBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultEmbedBatchSize
+	}
+	concurrency := max(s.embedConcurrency, 1)
+
+	// Batches are embedded a wave of up to concurrency at a time, so at most
+	// that many batches of vectors are held before done stores them
+	for wave := 0; wave < len(texts); wave += batchSize * concurrency {
+		var starts []int
+		for start := wave; start < len(texts) && start < wave+batchSize*concurrency; start += batchSize {
+			starts = append(starts, start)
+		}
+
+		results := make([][][]float32, len(starts))
+		errs := make([]error, len(starts))
+		var wg sync.WaitGroup
+		for i, start := range starts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = embedBatch(ctx, emb, texts[start:min(start+batchSize, len(texts))])
+			}()
+		}
+		wg.Wait()
+
+		for i, start := range starts {
+			if errs[i] != nil {
+				return errs[i]
+			}
+			if err := done(start, results[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// embedChunks embeds those of chunks missing from embeddings in batches,
+// adding their embeddings by chunk ID and calling progress, when given,
+// after each batch
+func (s *DocumentService) embedChunks(ctx context.Context, emb embedder.Embedder, chunks []*repository.DocumentChunk, embeddings map[uuid.UUID][]float32, progress func()) error {
+	var missing []*repository.DocumentChunk
+	var contents []string
+	for _, chunk := range chunks {
+		if _, ok := embeddings[chunk.ID]; !ok {
+			missing = append(missing, chunk)
+			contents = append(contents, chunk.Content)
+		}
+	}
+	return s.embedInBatches(ctx, emb, contents, func(start int, batch [][]float32) error {
+		for i, embedding := range batch {
+			embeddings[missing[start+i].ID] = embedding
+		}
+		if progress != nil {
+			progress()
+		}
+		return nil
+	})
+}
+
+// embedBatch embeds one batch, attempting it up to embedBatchAttempts times
+func embedBatch(ctx context.Context, emb embedder.Embedder, texts []string) ([][]float32, error) {
+	backoff := embedBatchBackoff
+	for attempt := 1; ; attempt++ {
+		embeddings, err := embedder.EmbedAll(ctx, emb, texts, embedRetryRounds)
+		if err == nil || attempt == embedBatchAttempts || ctx.Err() != nil {
+			return embeddings, err
+		}
+		slog.WarnContext(ctx, "embedding batch failed, retrying", "chunks", len(texts), "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+const (
+	// Context expansion modes
+	expansionNeighbors = "neighbors"
+	expansionParent    = "parent"
+
+	// maxExpansionWindow caps neighbor expansion on each side of a hit
+	maxExpansionWindow = 5
+
+	// maxParentChunks caps parent expansion so one huge section cannot flood the prompt
+	maxParentChunks = 12
+)
+
+// contextExpansion holds resolved parent-document retrieval settings
+type contextExpansion struct {
+	mode   string // "" (disabled), "neighbors", "parent"
+	window int    // neighbors on each side (neighbors mode)
+}
+
+// resolveContextExpansion converts request options into context expansion settings
+func resolveContextExpansion(opts *ragv1.ContextExpansion) contextExpansion {
+	switch strings.ToLower(opts.Mode) {
+	case expansionNeighbors:
+		window := int(opts.Window)
+		if window <= 0 {
+			window = 1
+		}
+		if window > maxExpansionWindow {
+			window = maxExpansionWindow
+		}
+		return contextExpansion{mode: expansionNeighbors, window: window}
+	case expansionParent:
+		return contextExpansion{mode: expansionParent}
+	default:
+		return contextExpansion{}
+	}
+}
+
+// buildChunkContexts converts search results into prompt contexts. When expansion is
+// enabled, each hit's content is replaced by its neighboring or parent-section chunks,
+// and hits whose expanded range is already covered by an earlier hit are dropped.
+func (s *RAGService) buildChunkContexts(ctx context.Context, results []vectorstore.SearchResult, exp contextExpansion) []chunkContext {
+	contexts := make([]chunkContext, 0, len(results))
+	covered := make(map[string][][2]int) // document ID -> expanded ranges already in context
+
+	for _, result := range results {
+		cc := chunkContext{
+			Content:  result.Content,
+			Source:   result.Metadata["source"],
+			Title:    result.Metadata["title"],
+			Score:    result.Score,
+			Metadata: result.Metadata,
+		}
+
+		start, end, ok := expansionRange(result, exp)
+		if !ok {
+			contexts = append(contexts, cc)
+			continue
+		}
+
+		if rangeCovered(covered[result.DocumentID], start, end) {
+			continue
+		}
+
+		docID, err := uuid.Parse(result.DocumentID)
+		if err != nil {
+			contexts = append(contexts, cc)
+			continue
+		}
+
+		chunks, err := s.docRepo.GetChunkRange(ctx, docID, start, end)
+		if err != nil || len(chunks) == 0 {
+			// Fall back to the matched chunk alone
+			contexts = append(contexts, cc)
+			continue
+		}
+
+		cc.Content = joinChunkContents(chunks)
+		covered[result.DocumentID] = append(covered[result.DocumentID], [2]int{start, end})
+		contexts = append(contexts, cc)
+	}
+
+	return contexts
+}
+
+// attachNeighbors loads the chunks adjacent to a hit and attaches them to the response chunk.
+// Hits without position metadata are left unchanged.
+func (s *RAGService) attachNeighbors(ctx context.Context, chunk *ragv1.RetrievedChunk, result vectorstore.SearchResult, before, after int) {
+	index, err := strconv.Atoi(result.Metadata["chunk_index"])
+	if err != nil {
+		return
+	}
+	docID, err := uuid.Parse(result.DocumentID)
+	if err != nil {
+		return
+	}
+
+	window, err := s.docRepo.GetChunkWindow(ctx, docID, index, before, after)
+	if err != nil {
+		return
+	}
+
+	for _, c := range window {
+		switch {
+		case c.ChunkIndex < index:
+			chunk.NeighborsBefore = append(chunk.NeighborsBefore, chunkToProto(c))
+		case c.ChunkIndex > index:
+			chunk.NeighborsAfter = append(chunk.NeighborsAfter, chunkToProto(c))
+		}
+	}
+}
+
+// expansionRange returns the chunk index range to load for a hit.
+// Returns false when expansion is disabled or the hit lacks position metadata
+// (e.g., vectors ingested before chunk positions were stored).
+func expansionRange(result vectorstore.SearchResult, exp contextExpansion) (int, int, bool) {
+	if exp.mode == "" {
+		return 0, 0, false
+	}
+
+	index, err := strconv.Atoi(result.Metadata["chunk_index"])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	switch exp.mode {
+	case expansionNeighbors:
+		start := index - exp.window
+		if start < 0 {
+			start = 0
+		}
+		return start, index + exp.window, true
+	case expansionParent:
+		start, errStart := strconv.Atoi(result.Metadata["parent_start"])
+		end, errEnd := strconv.Atoi(result.Metadata["parent_end"])
+		if errStart != nil || errEnd != nil || start > index || end < index {
+			return 0, 0, false
+		}
+		// Keep the window centered on the hit when the section is too large
+		if end-start+1 > maxParentChunks {
+			half := maxParentChunks / 2
+			start = max(start, index-half)
+			end = min(end, start+maxParentChunks-1)
+		}
+		return start, end, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// rangeCovered reports whether [start, end] lies within one of the given ranges
+func rangeCovered(ranges [][2]int, start, end int) bool {
+	for _, r := range ranges {
+		if start >= r[0] && end <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// joinChunkContents concatenates adjacent chunks, dropping the overlap prefix that
+// semantic chunking copies from the previous chunk so text is not repeated.
+func joinChunkContents(chunks []*repository.DocumentChunk) string {
+	parts := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		content := chunk.Content
+		if i > 0 && chunk.Metadata["has_overlap"] == "true" && strings.HasPrefix(content, "[...] ") {
+			if idx := strings.Index(content, "\n\n"); idx != -1 {
+				content = content[idx+2:]
+			}
+		}
+		parts = append(parts, content)
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/guardrail"
+	"github.com/knoguchi/rag/internal/memory"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExplainQuery runs a query's retrieval and prompt building without calling
+// the LLM, and reports every candidate's scores and what became of it. The
+// session history is read but the query is not added to it.
+func (s *RAGService) ExplainQuery(ctx context.Context, req *ragv1.QueryRequest) (*ragv1.ExplainQueryResponse, error) {
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "tenant not found: %v", err)
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenant.ID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	tenant = withProjectRetrieval(tenant, project)
+
+	options := s.buildQueryOptions(tenant, req.Options)
+	filter, err := searchFilter(project, req.Options.GetTags(), req.Options.GetCollectionIds(), req.Options.GetLanguages())
+	if err != nil {
+		return nil, err
+	}
+	boost, err := resolveScoreBoost(tenant, req.Options.GetScoreBoost())
+	if err != nil {
+		return nil, err
+	}
+	tmpl, templateVersion, err := s.promptTemplate(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	options.language, err = queryLanguage(tenant, req.Options.GetLanguage(), req.Query)
+	if err != nil {
+		return nil, err
+	}
+	options.guardrails, err = s.tenantGuardrails(tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	query, blocked, guardEvents, err := guardText(ctx, options.guardrails, guardrail.StageInput, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return &ragv1.ExplainQueryResponse{
+			Model:                 options.model,
+			PromptTemplateVersion: int32(templateVersion),
+			Language:              options.language,
+			AnswerType:            ragv1.AnswerType_ANSWER_TYPE_BLOCKED,
+			GuardrailEvents:       guardEvents,
+		}, nil
+	}
+
+	retrievalStart := time.Now()
+	hybrid := s.useHybrid && s.sparseModel != nil
+	retrieval, err := s.retrieveForQuery(ctx, tenant, query, options, filter, boost, hybrid)
+	if err != nil {
+		return nil, err
+	}
+	retrievalTime := time.Since(retrievalStart)
+
+	sources := make([]*ragv1.RetrievedChunk, len(retrieval.results))
+	for i, result := range retrieval.results {
+		sources[i] = &ragv1.RetrievedChunk{
+			DocumentId: result.DocumentID,
+			ChunkId:    result.ID,
+			Content:    result.Content,
+			Score:      result.Score,
+			Source:     result.Metadata["source"],
+			Title:      result.Metadata["title"],
+			Metadata:   result.Metadata,
+		}
+	}
+	s.attachSharedDocuments(ctx, tenant, sources)
+
+	chunkContexts, err := s.promptContexts(ctx, retrieval, options)
+	if err != nil {
+		return nil, err
+	}
+	var history sessionHistory
+	if req.SessionId != "" {
+		history = s.sessionHistory(tenant, req.SessionId)
+	}
+	budget := s.promptBudget(options, tenant.Config.LLMFallbackModels)
+	rendered, fit, err := s.buildRAGPrompt(tmpl, options.systemPrompt, options.language, chunkContexts, query, history, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parts are counted before any were left out to fit
+	tokens := &ragv1.ExplainTokenCounts{
+		SystemPrompt:  int32(prompt.EstimateTokens(options.systemPrompt)),
+		Query:         int32(prompt.EstimateTokens(query)),
+		Prompt:        int32(fit.Tokens),
+		MaxCompletion: int32(options.maxTokens),
+	}
+	for _, c := range chunkContexts {
+		tokens.Context += int32(prompt.EstimateTokens(c.Content))
+	}
+	tokens.History = int32(prompt.EstimateTokens(history.summary + "\n" + memory.FormatForPrompt(history.messages)))
+
+	return &ragv1.ExplainQueryResponse{
+		QueryEmbeddingNorm:      float32(vectorNorm(retrieval.queryVector)),
+		QueryEmbeddingDimension: int32(len(retrieval.queryVector)),
+		Hybrid:                  retrieval.sparseVector != nil,
+		Candidates:              s.explainCandidates(retrieval),
+		Reranked:                retrieval.rerankScores != nil,
+		Sources:                 sources,
+		SystemPrompt:            options.systemPrompt,
+		Prompt:                  rendered,
+		TokenCounts:             tokens,
+		Model:                   options.model,
+		RetrievalTimeMs:         retrievalTime.Milliseconds(),
+		PromptTemplateVersion:   int32(templateVersion),
+		Language:                options.language,
+		AnswerType:              classifyAnswer(retrieval, tenant.Config.NoAnswer),
+		GuardrailEvents:         append(guardEvents, retrieval.guardEvents...),
+		Truncation:              truncationToProto(fit, budget),
+		SearchText:              retrieval.searchText,
+	}, nil
+}
+
+// explainCandidates traces each vector store candidate through deduplication,
+// reranking and the final cut
+func (s *RAGService) explainCandidates(r *queryRetrieval) []*ragv1.ExplainCandidate {
+	finalRank := make(map[string]int, len(r.results))
+	finalScore := make(map[string]float32, len(r.results))
+	for i, result := range r.results {
+		finalRank[result.ID] = i + 1
+		finalScore[result.ID] = result.Score
+	}
+
+	candidates := make([]*ragv1.ExplainCandidate, len(r.candidates))
+	for i, c := range r.candidates {
+		candidate := &ragv1.ExplainCandidate{
+			ChunkId:    c.ID,
+			DocumentId: c.DocumentID,
+			Title:      c.Metadata["title"],
+			DenseScore: c.Score,
+			FinalRank:  int32(finalRank[c.ID]),
+			FinalScore: finalScore[c.ID],
+		}
+
+		// Hybrid search ranks by fused score, so recompute the dense and
+		// sparse similarities it fused
+		if r.sparseVector != nil {
+			fused := c.Score
+			candidate.FusedScore = &fused
+			candidate.DenseScore = float32(cosineSimilarity(r.queryVector, c.Vector))
+			sparse := float32(sparseDot(r.sparseVector, r.sparseModel.Vectorize(c.Content)))
+			candidate.SparseScore = &sparse
+		}
+
+		if dup := r.duplicates[i]; dup.of >= 0 {
+			candidate.DuplicateOf = r.candidates[dup.of].ID
+			candidate.DuplicateSimilarity = float32(dup.similarity)
+		}
+		if score, ok := r.rerankScores[c.ID]; ok {
+			candidate.RerankerScore = &score
+			candidate.BelowRerankThreshold = score < r.rerankThreshold
+		}
+		candidates[i] = candidate
+	}
+	return candidates
+}
+
+// vectorNorm returns the Euclidean norm of v
+func vectorNorm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0
+// when either is empty or their lengths differ
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	norms := vectorNorm(a) * vectorNorm(b)
+	if norms == 0 {
+		return 0
+	}
+	return dot / norms
+}
+
+// sparseDot returns the dot product of two sparse vectors
+func sparseDot(a, b *vectorstore.SparseVector) float64 {
+	if a == nil || b == nil {
+		return 0
+	}
+	values := make(map[uint32]float32, len(b.Indices))
+	for i, idx := range b.Indices {
+		values[idx] = b.Values[i]
+	}
+	var dot float64
+	for i, idx := range a.Indices {
+		dot += float64(a.Values[i]) * float64(values[idx])
+	}
+	return dot
+}
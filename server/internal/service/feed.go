@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/connector"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// minFeedPollInterval prevents tenants from hammering feed hosts
+const minFeedPollInterval = 5 * time.Minute
+
+// FeedService implements ragv1.FeedServiceServer
+type FeedService struct {
+	ragv1.UnimplementedFeedServiceServer
+
+	feedRepo            repository.FeedRepository
+	tenantRepo          repository.TenantRepository
+	documents           *DocumentService
+	httpClient          *http.Client
+	defaultPollInterval time.Duration
+}
+
+// NewFeedService creates a new FeedService. Feed items are ingested through documents.
+func NewFeedService(
+	feedRepo repository.FeedRepository,
+	tenantRepo repository.TenantRepository,
+	documents *DocumentService,
+	defaultPollInterval time.Duration,
+) *FeedService {
+	if defaultPollInterval < minFeedPollInterval {
+		defaultPollInterval = minFeedPollInterval
+	}
+	return &FeedService{
+		feedRepo:            feedRepo,
+		tenantRepo:          tenantRepo,
+		documents:           documents,
+		httpClient:          &http.Client{Timeout: 30 * time.Second},
+		defaultPollInterval: defaultPollInterval,
+	}
+}
+
+// CreateFeed subscribes a tenant to a feed and runs an initial sync in the background
+func (s *FeedService) CreateFeed(ctx context.Context, req *ragv1.CreateFeedRequest) (*ragv1.Feed, error) {
+	if req.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http(s) URL")
+	}
+
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+
+	interval := time.Duration(req.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = s.defaultPollInterval
+	}
+	if interval < minFeedPollInterval {
+		return nil, status.Errorf(codes.InvalidArgument, "poll_interval_seconds must be at least %d", int(minFeedPollInterval.Seconds()))
+	}
+	project, err := requestProject(ctx, s.documents.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &repository.Feed{
+		ID:                  uuid.New(),
+		TenantID:            tenantID,
+		URL:                 u.String(),
+		PollIntervalSeconds: int(interval.Seconds()),
+		Metadata:            req.Metadata,
+		CreatedAt:           time.Now(),
+		ProjectID:           projectID(project),
+	}
+
+	if err := s.feedRepo.Create(ctx, feed); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create feed: %v", err)
+	}
+
+	// Run the first sync asynchronously
+	go func() {
+		_, _, _ = s.syncFeed(context.Background(), feed)
+	}()
+
+	return feedToProto(feed), nil
+}
+
+// ListFeeds lists feeds for a tenant
+func (s *FeedService) ListFeeds(ctx context.Context, req *ragv1.ListFeedsRequest) (*ragv1.ListFeedsResponse, error) {
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	project, err := requestProject(ctx, s.documents.projectRepo, tenantID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	var listed uuid.UUID
+	if project != nil {
+		listed = project.ID
+	}
+
+	feeds, total, err := s.feedRepo.List(ctx, tenantID, listed, pageSize, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list feeds: %v", err)
+	}
+
+	protoFeeds := make([]*ragv1.Feed, len(feeds))
+	for i, f := range feeds {
+		protoFeeds[i] = feedToProto(f)
+	}
+
+	var nextPageToken string
+	if offset+len(feeds) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(feeds))
+	}
+
+	return &ragv1.ListFeedsResponse{
+		Feeds:         protoFeeds,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// DeleteFeed stops polling a feed; documents ingested from it are kept
+func (s *FeedService) DeleteFeed(ctx context.Context, req *ragv1.DeleteFeedRequest) (*ragv1.DeleteFeedResponse, error) {
+	id, err := parseFeedID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.feedRepo.GetByID(ctx, id)
+	if err == nil && (!canAccess(ctx, feed.TenantID) || !canAccessProject(ctx, feed.ProjectID)) {
+		err = repository.ErrNotFound
+	}
+	if err == nil {
+		err = s.feedRepo.Delete(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "feed not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete feed: %v", err)
+	}
+
+	return &ragv1.DeleteFeedResponse{
+		Success: true,
+	}, nil
+}
+
+// SyncFeed polls a feed immediately and ingests new items
+func (s *FeedService) SyncFeed(ctx context.Context, req *ragv1.SyncFeedRequest) (*ragv1.SyncFeedResponse, error) {
+	id, err := parseFeedID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.feedRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "feed not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get feed: %v", err)
+	}
+	if !canAccess(ctx, feed.TenantID) || !canAccessProject(ctx, feed.ProjectID) {
+		return nil, status.Error(codes.NotFound, "feed not found")
+	}
+
+	found, ingested, err := s.syncFeed(ctx, feed)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to sync feed: %v", err)
+	}
+
+	return &ragv1.SyncFeedResponse{
+		ItemsFound:    int32(found),
+		ItemsIngested: int32(ingested),
+		Feed:          feedToProto(feed),
+	}, nil
+}
+
+// Run checks for due feeds every checkInterval until ctx is cancelled
+func (s *FeedService) Run(ctx context.Context, checkInterval time.Duration) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		s.pollDueFeeds(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollDueFeeds syncs every feed whose poll interval has elapsed
+func (s *FeedService) pollDueFeeds(ctx context.Context) {
+	feeds, err := s.feedRepo.ListDue(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list due feeds", "error", err)
+		return
+	}
+
+	for _, feed := range feeds {
+		if ctx.Err() != nil {
+			return
+		}
+		// Feeds of tenants that cannot ingest wait until they can
+		tenant, err := s.tenantRepo.GetByID(ctx, feed.TenantID)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to get feed tenant", "feed_id", feed.ID, "error", err)
+			continue
+		}
+		if auth.CheckTenantStatus(tenant, auth.ScopeIngest) != nil {
+			continue
+		}
+		if _, _, err := s.syncFeed(ctx, feed); err != nil {
+			slog.WarnContext(ctx, "feed sync failed", "feed_id", feed.ID, "url", feed.URL, "error", err)
+		}
+	}
+}
+
+// syncFeed fetches a feed, ingests items not seen before and records sync state.
+// Items are deduplicated by guid (falling back to link, then title + pubDate).
+func (s *FeedService) syncFeed(ctx context.Context, feed *repository.Feed) (int, int, error) {
+	now := time.Now()
+	feed.LastPolledAt = &now
+
+	parsed, err := connector.FetchFeed(ctx, s.httpClient, feed.URL)
+	if err != nil {
+		feed.LastError = err.Error()
+		_ = s.feedRepo.Update(ctx, feed)
+		return 0, 0, err
+	}
+
+	if feed.Title == "" {
+		feed.Title = parsed.Title
+	}
+
+	ingested := 0
+	var itemErr error
+	for _, item := range parsed.Items {
+		key := item.Key()
+		seen, err := s.feedRepo.HasItem(ctx, feed.ID, key)
+		if err != nil {
+			itemErr = err
+			break
+		}
+		if seen {
+			continue
+		}
+
+		docID, err := s.ingestItem(ctx, feed, item, key)
+		if err != nil {
+			itemErr = err
+			continue
+		}
+
+		if err := s.feedRepo.RecordItem(ctx, &repository.FeedItem{
+			FeedID:      feed.ID,
+			GUID:        key,
+			DocumentID:  &docID,
+			PublishedAt: item.Published,
+			CreatedAt:   time.Now(),
+		}); err != nil {
+			itemErr = err
+			continue
+		}
+
+		ingested++
+		if item.Published != nil && (feed.LastItemAt == nil || item.Published.After(*feed.LastItemAt)) {
+			feed.LastItemAt = item.Published
+		}
+	}
+
+	feed.ItemCount += ingested
+	feed.LastError = ""
+	if itemErr != nil {
+		feed.LastError = itemErr.Error()
+	}
+	_ = s.feedRepo.Update(ctx, feed)
+
+	return len(parsed.Items), ingested, nil
+}
+
+// ingestItem ingests a single feed item as a document
+func (s *FeedService) ingestItem(ctx context.Context, feed *repository.Feed, item connector.FeedItem, key string) (uuid.UUID, error) {
+	content := ingestion.HTMLToMarkdown(item.Content)
+	if content == "" {
+		content = item.Title
+	}
+
+	source := item.Link
+	if source == "" {
+		source = feed.URL + "#" + key
+	}
+
+	metadata := make(map[string]string, len(feed.Metadata)+4)
+	for k, v := range feed.Metadata {
+		metadata[k] = v
+	}
+	metadata["connector"] = "feed"
+	metadata["feed_id"] = feed.ID.String()
+	metadata["feed_guid"] = key
+	if item.Published != nil {
+		metadata["published_at"] = item.Published.UTC().Format(time.RFC3339)
+	}
+
+	resp, err := s.documents.IngestDocument(ctx, &ragv1.IngestDocumentRequest{
+		TenantId:  feed.TenantID.String(),
+		Content:   content,
+		Title:     item.Title,
+		Source:    source,
+		Metadata:  metadata,
+		ProjectId: projectIDString(feed.ProjectID),
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to ingest item %q: %w", key, err)
+	}
+
+	return uuid.Parse(resp.DocumentId)
+}
+
+// parseFeedID validates and parses a feed ID
+func parseFeedID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid feed ID format")
+	}
+	return parsed, nil
+}
+
+// feedToProto converts a repository Feed to proto Feed
+func feedToProto(f *repository.Feed) *ragv1.Feed {
+	pb := &ragv1.Feed{
+		Id:                  f.ID.String(),
+		TenantId:            f.TenantID.String(),
+		Url:                 f.URL,
+		Title:               f.Title,
+		PollIntervalSeconds: int32(f.PollIntervalSeconds),
+		LastError:           f.LastError,
+		ItemCount:           int32(f.ItemCount),
+		Metadata:            f.Metadata,
+		CreatedAt:           timestamppb.New(f.CreatedAt),
+		ProjectId:           projectIDString(f.ProjectID),
+	}
+	if f.LastPolledAt != nil {
+		pb.LastPolledAt = timestamppb.New(*f.LastPolledAt)
+	}
+	if f.LastItemAt != nil {
+		pb.LastItemAt = timestamppb.New(*f.LastItemAt)
+	}
+	return pb
+}
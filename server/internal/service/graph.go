@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Retrieval strategies of QueryOptions.strategy
+const (
+	strategyVector = "vector"
+	strategyGraph  = "graph"
+)
+
+const (
+	// defaultGraphRelations is how many relations a graph query is expanded with
+	defaultGraphRelations = 10
+
+	// defaultGraphQueryEntities is how many of the query's entities it is expanded from
+	defaultGraphQueryEntities = 5
+
+	// maxEntityWords is the longest run of query words looked up as an entity
+	maxEntityWords = 4
+)
+
+// graphSearchText returns the query followed by the knowledge graph's
+// relations of the entities it names, so the vector search also finds
+// chunks about entities related to them. Queries restricted to a project are
+// expanded from its documents only. A graph that cannot be read leaves the
+// query as it is.
+func (s *RAGService) graphSearchText(ctx context.Context, tenant *repository.Tenant, projectID uuid.UUID, query string) (string, error) {
+	cfg := tenant.Config.KnowledgeGraph
+	if s.graphRepo == nil || !cfg.Enabled {
+		return "", status.Error(codes.FailedPrecondition, "strategy graph needs the tenant's knowledge_graph enabled")
+	}
+	maxEntities, maxRelations := cfg.MaxQueryEntities, cfg.MaxRelations
+	if maxEntities <= 0 {
+		maxEntities = defaultGraphQueryEntities
+	}
+	if maxRelations <= 0 {
+		maxRelations = defaultGraphRelations
+	}
+
+	candidates := entityCandidates(query)
+	found, err := s.graphRepo.FindEntities(ctx, tenant.ID, projectID, candidates)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to find query entities, searching without the graph", "tenant_id", tenant.ID, "error", err)
+		return query, nil
+	}
+	entities := queryEntities(candidates, found, maxEntities)
+	if len(entities) == 0 {
+		return query, nil
+	}
+	relations, err := s.graphRepo.Relations(ctx, tenant.ID, projectID, entities, maxRelations)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read graph relations, searching without the graph", "tenant_id", tenant.ID, "error", err)
+		return query, nil
+	}
+	if len(relations) == 0 {
+		return query, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(query)
+	b.WriteString("\n")
+	for _, rel := range relations {
+		fmt.Fprintf(&b, "\n%s %s %s.", rel.Source, rel.Relation, rel.Target)
+	}
+	return b.String(), nil
+}
+
+// entityCandidates returns the runs of up to maxEntityWords query words, as
+// entity keys, longest first
+func entityCandidates(query string) []string {
+	words := strings.Fields(ingestion.EntityKey(query))
+	seen := make(map[string]bool)
+	var candidates []string
+	for n := min(maxEntityWords, len(words)); n >= 1; n-- {
+		for i := 0; i+n <= len(words); i++ {
+			key := strings.Join(words[i:i+n], " ")
+			if !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, key)
+			}
+		}
+	}
+	return candidates
+}
+
+// queryEntities picks up to limit of the candidates found in the graph,
+// longest first, skipping those inside a longer one picked: "acme" is not
+// expanded from when "acme corp" is
+func queryEntities(candidates, found []string, limit int) []string {
+	inGraph := make(map[string]bool, len(found))
+	for _, key := range found {
+		inGraph[key] = true
+	}
+	var picked []string
+	for _, key := range candidates {
+		if !inGraph[key] {
+			continue
+		}
+		contained := false
+		for _, p := range picked {
+			if strings.Contains(" "+p+" ", " "+key+" ") {
+				contained = true
+				break
+			}
+		}
+		if contained {
+			continue
+		}
+		picked = append(picked, key)
+		if len(picked) == limit {
+			break
+		}
+	}
+	return picked
+}
+
+// extractGraph replaces a document's knowledge graph with the entities and
+// relations of its chunks, for tenants with a knowledge graph. It runs after
+// the document is ready; chunks that cannot be read are logged and skipped.
+func (s *DocumentService) extractGraph(ctx context.Context, doc *repository.Document, chunks []*repository.DocumentChunk, tenant *repository.Tenant) {
+	if s.graphExtractor == nil || !tenant.Config.KnowledgeGraph.Enabled {
+		return
+	}
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.Content
+	}
+	graphs, err := s.graphExtractor.ExtractAll(ctx, texts)
+	if err != nil {
+		slog.WarnContext(ctx, "knowledge graph extraction failed for some chunks", "document_id", doc.ID, "error", err)
+	}
+
+	var mentions []*repository.GraphMention
+	var relations []*repository.GraphRelation
+	for i, graph := range graphs {
+		chunkID := chunks[i].ID
+		for _, entity := range graph.Entities {
+			mentions = append(mentions, &repository.GraphMention{
+				ChunkID:    chunkID,
+				EntityKey:  ingestion.EntityKey(entity.Name),
+				Name:       entity.Name,
+				EntityType: entity.Type,
+			})
+		}
+		for _, rel := range graph.Relations {
+			relations = append(relations, &repository.GraphRelation{
+				ChunkID:   chunkID,
+				SourceKey: ingestion.EntityKey(rel.Source),
+				Source:    rel.Source,
+				Relation:  rel.Relation,
+				TargetKey: ingestion.EntityKey(rel.Target),
+				Target:    rel.Target,
+			})
+		}
+	}
+	if err := s.graphRepo.ReplaceDocumentGraph(ctx, doc.TenantID, doc.ID, mentions, relations); err != nil {
+		slog.WarnContext(ctx, "failed to store knowledge graph", "document_id", doc.ID, "error", err)
+		return
+	}
+	slog.DebugContext(ctx, "extracted knowledge graph", "document_id", doc.ID, "entities", len(mentions), "relations", len(relations))
+}
+
+// validateKnowledgeGraph checks a tenant's knowledge graph settings
+func validateKnowledgeGraph(cfg repository.KnowledgeGraphConfig) error {
+	if cfg.MaxRelations < 0 || cfg.MaxRelations > 50 {
+		return fmt.Errorf("knowledge_graph max_relations must be between 0 and 50")
+	}
+	if cfg.MaxQueryEntities < 0 || cfg.MaxQueryEntities > 20 {
+		return fmt.Errorf("knowledge_graph max_query_entities must be between 0 and 20")
+	}
+	return nil
+}
+
+// knowledgeGraphFromProto converts a proto KnowledgeGraphConfig
+func knowledgeGraphFromProto(p *ragv1.KnowledgeGraphConfig) repository.KnowledgeGraphConfig {
+	return repository.KnowledgeGraphConfig{
+		Enabled:          p.Enabled,
+		MaxRelations:     int(p.MaxRelations),
+		MaxQueryEntities: int(p.MaxQueryEntities),
+	}
+}
+
+// knowledgeGraphToProto converts a repository KnowledgeGraphConfig to proto KnowledgeGraphConfig
+func knowledgeGraphToProto(c repository.KnowledgeGraphConfig) *ragv1.KnowledgeGraphConfig {
+	return &ragv1.KnowledgeGraphConfig{
+		Enabled:          c.Enabled,
+		MaxRelations:     int32(c.MaxRelations),
+		MaxQueryEntities: int32(c.MaxQueryEntities),
+	}
+}
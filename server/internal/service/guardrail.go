@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/guardrail"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tenantGuardrails builds the pipeline for a tenant's queries: its configured
+// built-in filters followed by the server's
+func (s *RAGService) tenantGuardrails(tenant *repository.Tenant) (guardrail.Pipeline, error) {
+	cfg := tenant.Config.Guardrails
+	var p guardrail.Pipeline
+	var err error
+	if p.Input, err = builtinFilters(cfg.Input, cfg.BannedTopics); err != nil {
+		return p, status.Errorf(codes.Internal, "invalid guardrail config: %v", err)
+	}
+	if p.Context, err = builtinFilters(cfg.Context, cfg.BannedTopics); err != nil {
+		return p, status.Errorf(codes.Internal, "invalid guardrail config: %v", err)
+	}
+	if p.Output, err = builtinFilters(cfg.Output, cfg.BannedTopics); err != nil {
+		return p, status.Errorf(codes.Internal, "invalid guardrail config: %v", err)
+	}
+	return p.Append(s.guardrails), nil
+}
+
+// builtinFilters looks up built-in filters by name
+func builtinFilters(names, bannedTopics []string) ([]guardrail.Filter, error) {
+	filters := make([]guardrail.Filter, 0, len(names))
+	for _, name := range names {
+		f, err := guardrail.Builtin(name, bannedTopics)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// guardText runs a guardrail stage on a query or answer
+func guardText(ctx context.Context, p guardrail.Pipeline, stage guardrail.Stage, text string) (string, bool, []*ragv1.GuardrailEvent, error) {
+	out, blocked, events, err := p.Run(ctx, stage, text)
+	if err != nil {
+		return "", false, nil, status.Errorf(codes.Internal, "guardrail check failed: %v", err)
+	}
+	return out, blocked, guardrailEventsToProto(events, ""), nil
+}
+
+// guardChunks runs the context guardrails on each retrieved chunk, dropping
+// blocked chunks and keeping rewritten content
+func guardChunks(ctx context.Context, p guardrail.Pipeline, results []vectorstore.SearchResult) ([]vectorstore.SearchResult, []*ragv1.GuardrailEvent, error) {
+	if p.Empty(guardrail.StageContext) {
+		return results, nil, nil
+	}
+	var events []*ragv1.GuardrailEvent
+	kept := make([]vectorstore.SearchResult, 0, len(results))
+	for _, result := range results {
+		content, blocked, chunkEvents, err := p.Run(ctx, guardrail.StageContext, result.Content)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "guardrail check failed: %v", err)
+		}
+		events = append(events, guardrailEventsToProto(chunkEvents, result.ID)...)
+		if blocked {
+			continue
+		}
+		result.Content = content
+		kept = append(kept, result)
+	}
+	return kept, events, nil
+}
+
+// promptContexts builds the chunk contexts a query's prompt is rendered
+// from. Context expansion pulls in chunks retrieval never saw, so widened
+// content is screened by the context guardrails again.
+func (s *RAGService) promptContexts(ctx context.Context, r *queryRetrieval, options queryOptions) ([]chunkContext, error) {
+	contexts := s.buildChunkContexts(ctx, r.results, options.contextExpansion)
+	if options.contextExpansion.mode == "" || options.guardrails.Empty(guardrail.StageContext) {
+		return contexts, nil
+	}
+
+	kept := contexts[:0]
+	for _, cc := range contexts {
+		content, blocked, events, err := options.guardrails.Run(ctx, guardrail.StageContext, cc.Content)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "guardrail check failed: %v", err)
+		}
+		for _, e := range guardrailEventsToProto(events, "") {
+			e.Reason = "expanded context: " + e.Reason
+			r.guardEvents = append(r.guardEvents, e)
+		}
+		if blocked {
+			continue
+		}
+		cc.Content = content
+		kept = append(kept, cc)
+	}
+	return kept, nil
+}
+
+// blockedMessage is the answer given when a guardrail blocks a query or answer
+func blockedMessage(cfg repository.GuardrailConfig, language string) string {
+	if cfg.BlockedMessage != "" {
+		return cfg.BlockedMessage
+	}
+	return prompt.LabelsFor(language).Blocked
+}
+
+// validateGuardrails checks that every configured filter is a known built-in
+func validateGuardrails(cfg repository.GuardrailConfig) error {
+	usesTopics := false
+	for _, names := range [][]string{cfg.Input, cfg.Context, cfg.Output} {
+		for _, name := range names {
+			if _, err := guardrail.Builtin(name, nil); err != nil {
+				return err
+			}
+			usesTopics = usesTopics || name == guardrail.FilterTopics
+		}
+	}
+	if usesTopics && len(cfg.BannedTopics) == 0 {
+		return fmt.Errorf("guardrails use the %q filter but banned_topics is empty", guardrail.FilterTopics)
+	}
+	return nil
+}
+
+// guardrailEventsToProto converts guardrail events, attributing them to a chunk if given
+func guardrailEventsToProto(events []guardrail.Event, chunkID string) []*ragv1.GuardrailEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	protoEvents := make([]*ragv1.GuardrailEvent, len(events))
+	for i, e := range events {
+		protoEvents[i] = &ragv1.GuardrailEvent{
+			Stage:   string(e.Stage),
+			Filter:  e.Filter,
+			Action:  e.Action,
+			Reason:  e.Reason,
+			ChunkId: chunkID,
+		}
+	}
+	return protoEvents
+}
+
+// guardrailsFromProto converts a proto GuardrailConfig
+func guardrailsFromProto(p *ragv1.GuardrailConfig) repository.GuardrailConfig {
+	return repository.GuardrailConfig{
+		Input:          p.GetInput(),
+		Context:        p.GetContext(),
+		Output:         p.GetOutput(),
+		BannedTopics:   p.GetBannedTopics(),
+		BlockedMessage: p.GetBlockedMessage(),
+	}
+}
+
+// guardrailsToProto converts a repository GuardrailConfig to proto GuardrailConfig
+func guardrailsToProto(c repository.GuardrailConfig) *ragv1.GuardrailConfig {
+	return &ragv1.GuardrailConfig{
+		Input:          c.Input,
+		Context:        c.Context,
+		Output:         c.Output,
+		BannedTopics:   c.BannedTopics,
+		BlockedMessage: c.BlockedMessage,
+	}
+}
+
+// sendGuardrailEvents streams guardrail events to a QueryStream client
+func sendGuardrailEvents(stream grpc.ServerStreamingServer[ragv1.QueryStreamResponse], events []*ragv1.GuardrailEvent) error {
+	for _, e := range events {
+		if err := stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_Guardrail{Guardrail: e},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
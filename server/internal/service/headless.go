@@ -0,0 +1,36 @@
+package service
+
+import (
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/render"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// maxHeadlessTimeoutSeconds bounds how long a tenant may let a page render
+const maxHeadlessTimeoutSeconds = 120
+
+// headlessTimeout returns how long a tenant's pages may take to render
+func headlessTimeout(cfg repository.HeadlessConfig) time.Duration {
+	if cfg.TimeoutSeconds <= 0 {
+		return render.DefaultTimeout
+	}
+	return time.Duration(cfg.TimeoutSeconds) * time.Second
+}
+
+// headlessFromProto converts a proto HeadlessConfig
+func headlessFromProto(p *ragv1.HeadlessConfig) repository.HeadlessConfig {
+	return repository.HeadlessConfig{
+		Enabled:        p.Enabled,
+		TimeoutSeconds: int(p.TimeoutSeconds),
+	}
+}
+
+// headlessToProto converts a repository HeadlessConfig to proto HeadlessConfig
+func headlessToProto(c repository.HeadlessConfig) *ragv1.HeadlessConfig {
+	return &ragv1.HeadlessConfig{
+		Enabled:        c.Enabled,
+		TimeoutSeconds: int32(c.TimeoutSeconds),
+	}
+}
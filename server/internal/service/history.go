@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/memory"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// defaultHistoryWindow is how many recent messages a prompt includes (5 turns)
+const defaultHistoryWindow = 10
+
+// summaryTimeout bounds a background summarization
+const summaryTimeout = 2 * time.Minute
+
+// summarySystemPrompt instructs the LLM that condenses older turns
+const summarySystemPrompt = `You condense conversations between a user and an assistant so later turns can use them as context.
+Keep every fact, name, number, date, decision, preference and open question either side stated. Drop greetings and filler.
+Write plain prose of at most 200 words, in the language of the conversation. Output only the summary.`
+
+// sessionHistory is what a prompt sees of a session
+type sessionHistory struct {
+	summary  string           // condenses the turns before messages
+	messages []memory.Message // the most recent messages
+}
+
+// historyWindow returns how many recent messages a tenant's prompts include
+func historyWindow(tenant *repository.Tenant) int {
+	if tenant.Config.History.Window > 0 {
+		return tenant.Config.History.Window
+	}
+	return defaultHistoryWindow
+}
+
+// sessionHistory returns the summary and recent messages of a session
+func (s *RAGService) sessionHistory(tenant *repository.Tenant, sessionID string) sessionHistory {
+	conv, ok := s.memory.Get(tenant.ID.String(), sessionID)
+	if !ok {
+		return sessionHistory{}
+	}
+	messages := conv.Messages
+	if window := historyWindow(tenant); len(messages) > window {
+		messages = messages[len(messages)-window:]
+	}
+	return sessionHistory{summary: conv.Summary, messages: messages}
+}
+
+// recordAnswer adds an answer to its session and, for tenants that
+// summarize history, folds turns that left the window into the summary
+func (s *RAGService) recordAnswer(tenant *repository.Tenant, sessionID, answer string) {
+	s.memory.AddAssistantMessage(tenant.ID.String(), sessionID, answer)
+	if !tenant.Config.History.Summarize {
+		return
+	}
+	key := tenant.ID.String() + "/" + sessionID
+	if _, running := s.summarizing.LoadOrStore(key, struct{}{}); running {
+		return // the next answer catches up
+	}
+	go func() {
+		defer s.summarizing.Delete(key)
+		ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+		defer cancel()
+		s.summarizeSession(ctx, tenant, sessionID)
+	}()
+}
+
+// summarizeSession folds the messages older than a tenant's history window
+// into the session's summary
+func (s *RAGService) summarizeSession(ctx context.Context, tenant *repository.Tenant, sessionID string) {
+	conv, ok := s.memory.Get(tenant.ID.String(), sessionID)
+	if !ok {
+		return
+	}
+	n := len(conv.Messages) - historyWindow(tenant)
+	if n <= 0 {
+		return
+	}
+
+	var b strings.Builder
+	if conv.Summary != "" {
+		b.WriteString("Summary so far:\n")
+		b.WriteString(conv.Summary)
+		b.WriteString("\n\nConversation that followed:\n")
+	} else {
+		b.WriteString("Conversation:\n")
+	}
+	b.WriteString(memory.FormatForPrompt(conv.Messages[:n]))
+	b.WriteString("\nWrite the updated summary.")
+
+	summary, err := s.llmClient.Generate(ctx, b.String(), llm.GenerateOptions{
+		Model:        tenant.Config.LLMModel,
+		Fallbacks:    tenant.Config.LLMFallbackModels,
+		SystemPrompt: summarySystemPrompt,
+		Temperature:  0.1,
+		MaxTokens:    512,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to summarize session history", "tenant_id", tenant.ID, "session_id", sessionID, "error", err)
+		return
+	}
+	if summary = strings.TrimSpace(summary); summary == "" {
+		return
+	}
+	s.memory.Fold(tenant.ID.String(), sessionID, conv.Offset, n, summary)
+}
+
+// historyFromProto converts a proto HistoryConfig
+func historyFromProto(p *ragv1.HistoryConfig) repository.HistoryConfig {
+	return repository.HistoryConfig{
+		Window:    int(p.GetWindow()),
+		Summarize: p.GetSummarize(),
+	}
+}
+
+// historyToProto converts a repository HistoryConfig to proto HistoryConfig
+func historyToProto(c repository.HistoryConfig) *ragv1.HistoryConfig {
+	return &ragv1.HistoryConfig{
+		Window:    int32(c.Window),
+		Summarize: c.Summarize,
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+const (
+	// defaultNearDuplicateDistance is how many SimHash bits may differ when
+	// a tenant doesn't set max_distance
+	defaultNearDuplicateDistance = 3
+
+	// maxNearDuplicateDistance bounds max_distance; beyond it unrelated
+	// documents start to match
+	maxNearDuplicateDistance = 16
+)
+
+// flagNearDuplicate fingerprints a new document's text and, for tenants that
+// detect near-duplicates, records the ready document it nearly matches. It
+// reports whether the document should be skipped; a skipped document has
+// been marked failed.
+func (s *DocumentService) flagNearDuplicate(ctx context.Context, doc *repository.Document, text string, tenant *repository.Tenant) bool {
+	doc.SimHash = ingestion.SimHash(text)
+	cfg := tenant.Config.NearDuplicates
+	if cfg.Mode == repository.NearDuplicateOff || doc.SimHash == 0 {
+		return false
+	}
+
+	distance := cfg.MaxDistance
+	if distance == 0 {
+		distance = defaultNearDuplicateDistance
+	}
+	match, err := s.docRepo.FindNearDuplicate(ctx, doc.TenantID, doc.ProjectID, doc.SimHash, distance, doc.ID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			slog.WarnContext(ctx, "near-duplicate lookup failed", "document_id", doc.ID, "error", err)
+		}
+		return false
+	}
+
+	doc.NearDuplicateOf = &match.DocumentID
+	if cfg.Mode != repository.NearDuplicateSkip {
+		return false
+	}
+	s.markDocumentFailed(ctx, doc, fmt.Sprintf("near-duplicate of document %s (%d bits apart)", match.DocumentID, match.Distance))
+	return true
+}
+
+// validateNearDuplicates checks a tenant's near-duplicate settings
+func validateNearDuplicates(cfg repository.NearDuplicateConfig) error {
+	switch cfg.Mode {
+	case repository.NearDuplicateOff, repository.NearDuplicateFlag, repository.NearDuplicateSkip:
+	default:
+		return fmt.Errorf("near_duplicates mode must be empty, %q or %q", repository.NearDuplicateFlag, repository.NearDuplicateSkip)
+	}
+	if cfg.MaxDistance < 0 || cfg.MaxDistance > maxNearDuplicateDistance {
+		return fmt.Errorf("near_duplicates max_distance must be between 0 and %d", maxNearDuplicateDistance)
+	}
+	return nil
+}
+
+// nearDuplicatesFromProto converts a proto NearDuplicateConfig
+func nearDuplicatesFromProto(p *ragv1.NearDuplicateConfig) repository.NearDuplicateConfig {
+	return repository.NearDuplicateConfig{
+		Mode:        p.Mode,
+		MaxDistance: int(p.MaxDistance),
+	}
+}
+
+// nearDuplicatesToProto converts a repository NearDuplicateConfig to proto NearDuplicateConfig
+func nearDuplicatesToProto(c repository.NearDuplicateConfig) *ragv1.NearDuplicateConfig {
+	return &ragv1.NearDuplicateConfig{
+		Mode:        c.Mode,
+		MaxDistance: int32(c.MaxDistance),
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// classifyAnswer reports whether a query's retrieval found context to answer
+// from. The best score is taken before reranking, so the threshold is on
+// the vector store's scale.
+func classifyAnswer(r *queryRetrieval, cfg repository.NoAnswerConfig) ragv1.AnswerType {
+	if len(r.results) == 0 {
+		return ragv1.AnswerType_ANSWER_TYPE_NO_CONTEXT
+	}
+	var top float32
+	for _, c := range r.candidates {
+		top = max(top, c.Score)
+	}
+	if top < cfg.MinTopScore {
+		return ragv1.AnswerType_ANSWER_TYPE_NO_CONTEXT
+	}
+	return ragv1.AnswerType_ANSWER_TYPE_ANSWERED
+}
+
+// noAnswerMessage is the answer given instead of calling the LLM
+func noAnswerMessage(cfg repository.NoAnswerConfig, language string) string {
+	if cfg.Message != "" {
+		return cfg.Message
+	}
+	return prompt.LabelsFor(language).NoContext
+}
+
+// noAnswerFromProto converts a proto NoAnswerConfig
+func noAnswerFromProto(p *ragv1.NoAnswerConfig) repository.NoAnswerConfig {
+	return repository.NoAnswerConfig{
+		MinTopScore: p.GetMinTopScore(),
+		SkipLLM:     p.GetSkipLlm(),
+		Message:     p.GetMessage(),
+	}
+}
+
+// noAnswerToProto converts a repository NoAnswerConfig to proto NoAnswerConfig
+func noAnswerToProto(c repository.NoAnswerConfig) *ragv1.NoAnswerConfig {
+	return &ragv1.NoAnswerConfig{
+		MinTopScore: c.MinTopScore,
+		SkipLlm:     c.SkipLLM,
+		Message:     c.Message,
+	}
+}
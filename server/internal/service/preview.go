@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PreviewChunks chunks content as IngestDocument would, without storing or
+// embedding anything
+func (s *DocumentService) PreviewChunks(ctx context.Context, req *ragv1.PreviewChunksRequest) (*ragv1.PreviewChunksResponse, error) {
+	if req.Content == "" {
+		return nil, status.Error(codes.InvalidArgument, "content is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	chunker, err := chunkerOverride(req.Chunker, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// The document IngestDocument would create
+	source, _ := canonicalSource(req.Source)
+	doc := &repository.Document{
+		TenantID: tenantID,
+		Source:   source,
+		Title:    req.Title,
+		Metadata: req.Metadata,
+		Chunker:  chunker,
+	}
+	if doc.Title == "" {
+		doc.Title = untitledDocument
+	}
+	if doc.Source == "" {
+		doc.Source = "direct-upload"
+	}
+
+	result, err := s.documentPipeline(doc, tenant).ProcessSections(ctx, []ingestion.Section{{Content: req.Content}}, doc.Metadata)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "chunking failed: %v", err)
+	}
+
+	chunks := make([]*ragv1.PreviewChunk, len(result.Chunks))
+	for i, chunk := range result.Chunks {
+		// Nothing is stored, so there is no document to refer to
+		delete(chunk.Metadata, "document_id")
+		chunks[i] = &ragv1.PreviewChunk{
+			ChunkIndex:  int32(chunk.Index),
+			Content:     chunk.Content,
+			Metadata:    chunk.Metadata,
+			WordCount:   int32(ingestion.CountWords(chunk.Content)),
+			TokenCount:  int32(ingestion.EstimateTokens(chunk.Content)),
+			ParentStart: int32(chunk.ParentStart),
+			ParentEnd:   int32(chunk.ParentEnd),
+		}
+	}
+
+	stats := result.Stats
+	return &ragv1.PreviewChunksResponse{
+		Chunks: chunks,
+		Stats: &ragv1.ChunkingStats{
+			OriginalLength:    int32(stats.OriginalLength),
+			OriginalWordCount: int32(stats.OriginalWordCount),
+			ChunkCount:        int32(stats.ChunkCount),
+			TotalChunkWords:   int32(stats.TotalChunkWords),
+			AvgChunkWords:     int32(stats.AvgChunkWords),
+			ProcessingTimeMs:  stats.ProcessingTime.Milliseconds(),
+		},
+		Chunker: chunkerToProto(documentChunker(doc, tenant)),
+	}, nil
+}
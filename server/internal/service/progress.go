@@ -0,0 +1,151 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// watchPollInterval is how often WatchDocument re-reads a document, to see
+// status changes made by other server instances
+const watchPollInterval = 2 * time.Second
+
+// watchBuffer is how many progress messages a slow watcher may fall behind
+// by before further ones are dropped
+const watchBuffer = 32
+
+// progressHub fans out document processing progress to WatchDocument
+// streams on this instance
+type progressHub struct {
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[chan *ragv1.DocumentProgress]struct{}
+}
+
+// newProgressHub creates a hub without watchers
+func newProgressHub() *progressHub {
+	return &progressHub{watchers: make(map[uuid.UUID]map[chan *ragv1.DocumentProgress]struct{})}
+}
+
+// subscribe returns a channel of a document's progress and a function that
+// ends the subscription
+func (h *progressHub) subscribe(id uuid.UUID) (<-chan *ragv1.DocumentProgress, func()) {
+	ch := make(chan *ragv1.DocumentProgress, watchBuffer)
+	h.mu.Lock()
+	if h.watchers[id] == nil {
+		h.watchers[id] = make(map[chan *ragv1.DocumentProgress]struct{})
+	}
+	h.watchers[id][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.watchers[id], ch)
+		if len(h.watchers[id]) == 0 {
+			delete(h.watchers, id)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// publish sends progress to a document's watchers without blocking; a
+// watcher that is behind misses it, and catches up on status by polling
+func (h *progressHub) publish(id uuid.UUID, p *ragv1.DocumentProgress) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.watchers[id] {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+// publishProgress reports a document's status and processing stage to its watchers
+func (s *DocumentService) publishProgress(doc *repository.Document, stage ragv1.DocumentStage, total, embedded int) {
+	s.progress.publish(doc.ID, &ragv1.DocumentProgress{
+		DocumentId:     doc.ID.String(),
+		Status:         convertStatus(doc.Status),
+		Stage:          stage,
+		ChunksTotal:    int32(total),
+		ChunksEmbedded: int32(embedded),
+		ErrorMessage:   doc.ErrorMessage,
+		UpdatedAt:      timestamppb.Now(),
+	})
+}
+
+// WatchDocument streams a document's processing progress until it is ready or failed
+func (s *DocumentService) WatchDocument(req *ragv1.WatchDocumentRequest, stream grpc.ServerStreamingServer[ragv1.DocumentProgress]) error {
+	ctx := stream.Context()
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid document ID format")
+	}
+
+	// Subscribe before reading the document, so no progress in between is missed
+	events, unsubscribe := s.progress.subscribe(id)
+	defer unsubscribe()
+
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil || !canAccessDocument(ctx, doc) {
+		return status.Error(codes.NotFound, "document not found")
+	}
+
+	last := documentProgress(doc)
+	if err := stream.Send(last); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for !processingDone(last.Status) {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case p := <-events:
+			last = p
+		case <-ticker.C:
+			doc, err := s.docRepo.GetByID(ctx, id)
+			if err != nil {
+				return status.Error(codes.NotFound, "document not found")
+			}
+			if convertStatus(doc.Status) == last.Status {
+				continue
+			}
+			last = documentProgress(doc)
+		}
+		if err := stream.Send(last); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// documentProgress reports a stored document's status, without a stage
+func documentProgress(doc *repository.Document) *ragv1.DocumentProgress {
+	p := &ragv1.DocumentProgress{
+		DocumentId:   doc.ID.String(),
+		Status:       convertStatus(doc.Status),
+		ErrorMessage: doc.ErrorMessage,
+		UpdatedAt:    timestamppb.New(doc.UpdatedAt),
+	}
+	if p.Status == ragv1.DocumentStatus_DOCUMENT_STATUS_READY {
+		p.ChunksTotal = int32(doc.ChunkCount)
+		p.ChunksEmbedded = int32(doc.ChunkCount)
+	}
+	return p
+}
+
+// processingDone reports whether a document status is final
+func processingDone(s ragv1.DocumentStatus) bool {
+	return s == ragv1.DocumentStatus_DOCUMENT_STATUS_READY || s == ragv1.DocumentStatus_DOCUMENT_STATUS_FAILED
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxProjectNameLength caps a project's name, in bytes
+const maxProjectNameLength = 255
+
+// ProjectService implements ragv1.ProjectServiceServer
+type ProjectService struct {
+	ragv1.UnimplementedProjectServiceServer
+
+	projectRepo repository.ProjectRepository
+	tenantRepo  repository.TenantRepository
+}
+
+// NewProjectService creates a new ProjectService
+func NewProjectService(projectRepo repository.ProjectRepository, tenantRepo repository.TenantRepository) *ProjectService {
+	return &ProjectService{
+		projectRepo: projectRepo,
+		tenantRepo:  tenantRepo,
+	}
+}
+
+// CreateProject creates a project
+func (s *ProjectService) CreateProject(ctx context.Context, req *ragv1.CreateProjectRequest) (*ragv1.Project, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectManager(ctx); err != nil {
+		return nil, err
+	}
+	name, err := projectName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	retrieval, err := convertProjectRetrieval(req.Retrieval)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+
+	now := time.Now()
+	project := &repository.Project{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      name,
+		Retrieval: retrieval,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "project %q already exists", name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create project: %v", err)
+	}
+	return projectToProto(project), nil
+}
+
+// GetProject gets a project
+func (s *ProjectService) GetProject(ctx context.Context, req *ragv1.GetProjectRequest) (*ragv1.Project, error) {
+	project, err := s.getProject(ctx, req.TenantId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return projectToProto(project), nil
+}
+
+// ListProjects lists a tenant's projects by name. Credentials bound to a
+// project only see that one.
+func (s *ProjectService) ListProjects(ctx context.Context, req *ragv1.ListProjectsRequest) (*ragv1.ListProjectsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.projectRepo.List(ctx, tenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list projects: %v", err)
+	}
+	resp := &ragv1.ListProjectsResponse{}
+	for _, p := range projects {
+		if canAccessProject(ctx, &p.ID) {
+			resp.Projects = append(resp.Projects, projectToProto(p))
+		}
+	}
+	return resp, nil
+}
+
+// UpdateProject renames a project or replaces its retrieval settings
+func (s *ProjectService) UpdateProject(ctx context.Context, req *ragv1.UpdateProjectRequest) (*ragv1.Project, error) {
+	if err := checkProjectManager(ctx); err != nil {
+		return nil, err
+	}
+	project, err := s.getProject(ctx, req.TenantId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := projectName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		project.Name = name
+	}
+	if req.Retrieval != nil {
+		if project.Retrieval, err = convertProjectRetrieval(req.Retrieval); err != nil {
+			return nil, err
+		}
+	}
+	project.UpdatedAt = time.Now()
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "project %q already exists", name)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update project: %v", err)
+	}
+	return projectToProto(project), nil
+}
+
+// DeleteProject deletes a project with its API keys, feeds and crawl jobs,
+// unless it still has documents
+func (s *ProjectService) DeleteProject(ctx context.Context, req *ragv1.DeleteProjectRequest) (*ragv1.DeleteProjectResponse, error) {
+	if err := checkProjectManager(ctx); err != nil {
+		return nil, err
+	}
+	project, err := s.getProject(ctx, req.TenantId, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.projectRepo.Delete(ctx, project.ID); err != nil {
+		if errors.Is(err, repository.ErrProjectNotEmpty) {
+			return nil, status.Error(codes.FailedPrecondition, "project has documents; delete them first")
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete project: %v", err)
+	}
+	return &ragv1.DeleteProjectResponse{Success: true}, nil
+}
+
+// getProject loads a project of the request's tenant that the caller may see
+func (s *ProjectService) getProject(ctx context.Context, requestedTenant, rawID string) (*repository.Project, error) {
+	tenantID, err := requestTenantID(ctx, requestedTenant)
+	if err != nil {
+		return nil, err
+	}
+	if rawID == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid project ID format")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get project: %v", err)
+	}
+	if project.TenantID != tenantID || !canAccessProject(ctx, &project.ID) {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return project, nil
+}
+
+// checkProjectManager rejects credentials bound to a project, which may not
+// create, change or delete projects
+func checkProjectManager(ctx context.Context) error {
+	if tenant, ok := auth.TenantFromContext(ctx); ok && tenant.ProjectID != nil {
+		return status.Error(codes.PermissionDenied, "project API keys can't manage projects")
+	}
+	return nil
+}
+
+// requestProject returns the project a request works in: the one its
+// credentials are bound to, or else the requested one, which must be the
+// tenant's. It returns nil for requests over the whole tenant. A requested
+// project must agree with the credentials' one.
+func requestProject(ctx context.Context, projects repository.ProjectRepository, tenantID uuid.UUID, requested string) (*repository.Project, error) {
+	var id uuid.UUID
+	if requested != "" {
+		parsed, err := uuid.Parse(requested)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid project_id format")
+		}
+		id = parsed
+	}
+	if tenant, ok := auth.TenantFromContext(ctx); ok && tenant.ProjectID != nil {
+		if id != uuid.Nil && id != *tenant.ProjectID {
+			return nil, status.Error(codes.PermissionDenied, "project_id does not match API key")
+		}
+		id = *tenant.ProjectID
+	}
+	if id == uuid.Nil {
+		return nil, nil
+	}
+	if projects == nil {
+		return nil, status.Error(codes.FailedPrecondition, "projects are not configured")
+	}
+
+	project, err := projects.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get project: %v", err)
+	}
+	if project.TenantID != tenantID {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return project, nil
+}
+
+// projectID returns a project's ID, or nil for no project
+func projectID(project *repository.Project) *uuid.UUID {
+	if project == nil {
+		return nil
+	}
+	return &project.ID
+}
+
+// projectIDString returns a project's ID as the vector store and protos
+// take it, or "" for no project
+func projectIDString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// credentialProject returns the project the request's credentials are bound to, or nil
+func credentialProject(ctx context.Context) *uuid.UUID {
+	if tenant, ok := auth.TenantFromContext(ctx); ok {
+		return tenant.ProjectID
+	}
+	return nil
+}
+
+// canAccessProject reports whether the caller may act on a resource of a
+// project, or of the whole tenant for a nil projectID. Only credentials
+// bound to a project are restricted, to that project's resources.
+func canAccessProject(ctx context.Context, projectID *uuid.UUID) bool {
+	bound := credentialProject(ctx)
+	return bound == nil || (projectID != nil && *projectID == *bound)
+}
+
+// canAccessDocument reports whether the caller may act on a document, as
+// canAccess and canAccessProject do
+func canAccessDocument(ctx context.Context, doc *repository.Document) bool {
+	return canAccess(ctx, doc.TenantID) && canAccessProject(ctx, doc.ProjectID)
+}
+
+// projectHash salts a deduplication hash with the project it is computed
+// in, so identical content in two projects makes separate documents and
+// chunks. Hashes outside projects are kept as they are.
+func projectHash(projectID *uuid.UUID, hash string) string {
+	if projectID == nil {
+		return hash
+	}
+	return hashContent(projectID.String() + "\n" + hash)
+}
+
+// withProjectRetrieval returns the tenant with a project's retrieval
+// settings over its own, for the project's queries
+func withProjectRetrieval(tenant *repository.Tenant, project *repository.Project) *repository.Tenant {
+	if project == nil {
+		return tenant
+	}
+	overlaid := *tenant
+	r := project.Retrieval
+	if r.TopK > 0 {
+		overlaid.Config.TopK = r.TopK
+	}
+	if r.MinScore > 0 {
+		overlaid.Config.MinScore = r.MinScore
+	}
+	if r.SystemPrompt != "" {
+		overlaid.Config.SystemPrompt = r.SystemPrompt
+	}
+	if r.LLMModel != "" {
+		overlaid.Config.LLMModel = r.LLMModel
+	}
+	if r.RerankerEnabled != nil {
+		overlaid.Config.RerankerEnabled = *r.RerankerEnabled
+	}
+	return &overlaid
+}
+
+// projectName trims a request's project name
+func projectName(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if len(name) > maxProjectNameLength {
+		return "", status.Errorf(codes.InvalidArgument, "name must be at most %d bytes", maxProjectNameLength)
+	}
+	return name, nil
+}
+
+// convertProjectRetrieval validates and converts a project's retrieval settings
+func convertProjectRetrieval(pb *ragv1.ProjectRetrievalConfig) (repository.ProjectRetrievalConfig, error) {
+	var r repository.ProjectRetrievalConfig
+	if pb == nil {
+		return r, nil
+	}
+	if pb.TopK < 0 {
+		return r, status.Error(codes.InvalidArgument, "retrieval.top_k must not be negative")
+	}
+	if pb.MinScore < 0 || pb.MinScore > 1 {
+		return r, status.Error(codes.InvalidArgument, "retrieval.min_score must be between 0 and 1")
+	}
+	r.TopK = int(pb.TopK)
+	r.MinScore = pb.MinScore
+	r.SystemPrompt = pb.SystemPrompt
+	r.LLMModel = pb.LlmModel
+	r.RerankerEnabled = pb.RerankerEnabled
+	return r, nil
+}
+
+// projectToProto converts a project to its proto
+func projectToProto(p *repository.Project) *ragv1.Project {
+	return &ragv1.Project{
+		Id:       p.ID.String(),
+		TenantId: p.TenantID.String(),
+		Name:     p.Name,
+		Retrieval: &ragv1.ProjectRetrievalConfig{
+			TopK:            int32(p.Retrieval.TopK),
+			MinScore:        p.Retrieval.MinScore,
+			SystemPrompt:    p.Retrieval.SystemPrompt,
+			LlmModel:        p.Retrieval.LLMModel,
+			RerankerEnabled: p.Retrieval.RerankerEnabled,
+		},
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+}
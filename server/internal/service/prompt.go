@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PromptService implements ragv1.PromptServiceServer
+type PromptService struct {
+	ragv1.UnimplementedPromptServiceServer
+
+	promptRepo repository.PromptTemplateRepository
+	tenantRepo repository.TenantRepository
+}
+
+// NewPromptService creates a new PromptService
+func NewPromptService(promptRepo repository.PromptTemplateRepository, tenantRepo repository.TenantRepository) *PromptService {
+	return &PromptService{
+		promptRepo: promptRepo,
+		tenantRepo: tenantRepo,
+	}
+}
+
+// CreatePromptTemplate validates a template and stores it as the tenant's next version
+func (s *PromptService) CreatePromptTemplate(ctx context.Context, req *ragv1.CreatePromptTemplateRequest) (*ragv1.PromptTemplate, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := prompt.Validate(req.Template); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template: %v", err)
+	}
+
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &repository.PromptTemplate{
+		TenantID:    tenantID,
+		Template:    req.Template,
+		Description: req.Description,
+	}
+	if err := s.promptRepo.Create(ctx, t); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Error(codes.Aborted, "another template version was created concurrently; retry")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create prompt template: %v", err)
+	}
+
+	if req.Activate {
+		if err := s.activate(ctx, tenant, t.Version); err != nil {
+			return nil, err
+		}
+	}
+	return promptTemplateToProto(t, tenant.Config.PromptTemplateVersion), nil
+}
+
+// GetPromptTemplate retrieves a version of a tenant's template
+func (s *PromptService) GetPromptTemplate(ctx context.Context, req *ragv1.GetPromptTemplateRequest) (*ragv1.PromptTemplate, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version must be positive")
+	}
+
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.promptRepo.Get(ctx, tenantID, int(req.Version))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "prompt template version not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get prompt template: %v", err)
+	}
+	return promptTemplateToProto(t, tenant.Config.PromptTemplateVersion), nil
+}
+
+// ListPromptTemplates lists a tenant's template versions, newest first
+func (s *PromptService) ListPromptTemplates(ctx context.Context, req *ragv1.ListPromptTemplatesRequest) (*ragv1.ListPromptTemplatesResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, total, err := s.promptRepo.List(ctx, tenantID, pageSize, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list prompt templates: %v", err)
+	}
+
+	protoTemplates := make([]*ragv1.PromptTemplate, len(templates))
+	for i, t := range templates {
+		protoTemplates[i] = promptTemplateToProto(t, tenant.Config.PromptTemplateVersion)
+	}
+
+	var nextPageToken string
+	if offset+len(templates) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(templates))
+	}
+
+	return &ragv1.ListPromptTemplatesResponse{
+		Templates:     protoTemplates,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(total),
+	}, nil
+}
+
+// ActivatePromptTemplate makes a version the one the tenant's queries are
+// rendered with; version 0 restores the built-in layout
+func (s *PromptService) ActivatePromptTemplate(ctx context.Context, req *ragv1.ActivatePromptTemplateRequest) (*ragv1.ActivatePromptTemplateResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version < 0 {
+		return nil, status.Error(codes.InvalidArgument, "version cannot be negative")
+	}
+
+	tenant, err := s.getTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Version > 0 {
+		if _, err := s.promptRepo.Get(ctx, tenantID, int(req.Version)); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, status.Error(codes.NotFound, "prompt template version not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get prompt template: %v", err)
+		}
+	}
+
+	if err := s.activate(ctx, tenant, int(req.Version)); err != nil {
+		return nil, err
+	}
+	return &ragv1.ActivatePromptTemplateResponse{ActiveVersion: req.Version}, nil
+}
+
+// ValidatePromptTemplate checks a template and renders it with sample data
+func (s *PromptService) ValidatePromptTemplate(ctx context.Context, req *ragv1.ValidatePromptTemplateRequest) (*ragv1.ValidatePromptTemplateResponse, error) {
+	preview, err := prompt.Validate(req.Template)
+	if err != nil {
+		return &ragv1.ValidatePromptTemplateResponse{Error: err.Error()}, nil
+	}
+	return &ragv1.ValidatePromptTemplateResponse{Valid: true, Preview: preview}, nil
+}
+
+// getTenant loads the tenant a request is for
+func (s *PromptService) getTenant(ctx context.Context, id uuid.UUID) (*repository.Tenant, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	return tenant, nil
+}
+
+// activate records the tenant's active template version
+func (s *PromptService) activate(ctx context.Context, tenant *repository.Tenant, version int) error {
+	tenant.Config.PromptTemplateVersion = version
+	if err := s.tenantRepo.Update(ctx, tenant); err != nil {
+		return status.Errorf(codes.Internal, "failed to update tenant: %v", err)
+	}
+	return nil
+}
+
+// cachedPromptTemplate is a parsed template version. Versions never change,
+// so a cached version is valid until the tenant activates another.
+type cachedPromptTemplate struct {
+	version int
+	tmpl    *prompt.Template
+}
+
+// promptTemplate returns the template a tenant's prompts are rendered with
+// and its version, 0 for the built-in layout
+func (s *RAGService) promptTemplate(ctx context.Context, tenant *repository.Tenant) (*prompt.Template, int, error) {
+	version := tenant.Config.PromptTemplateVersion
+	if version == 0 || s.promptRepo == nil {
+		return prompt.Default, 0, nil
+	}
+	if cached, ok := s.promptCache.Load(tenant.ID); ok && cached.(cachedPromptTemplate).version == version {
+		return cached.(cachedPromptTemplate).tmpl, version, nil
+	}
+
+	t, err := s.promptRepo.Get(ctx, tenant.ID, version)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "failed to get prompt template version %d: %v", version, err)
+	}
+	tmpl, err := prompt.Parse(t.Template)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "prompt template version %d is invalid: %v", version, err)
+	}
+	s.promptCache.Store(tenant.ID, cachedPromptTemplate{version: version, tmpl: tmpl})
+	return tmpl, version, nil
+}
+
+// languageAuto asks for the answer language to be detected from each query
+const languageAuto = "auto"
+
+// tenantLanguage normalizes a configured language code; "auto" is stored as empty
+func tenantLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == languageAuto {
+		return ""
+	}
+	return code
+}
+
+// queryLanguage resolves the language to answer a query in: the request's,
+// else the tenant's, else the one detected in the query. It is empty when
+// none is set or detected.
+func queryLanguage(tenant *repository.Tenant, requested, query string) (string, error) {
+	lang := tenant.Config.Language
+	if requested != "" {
+		lang = tenantLanguage(requested)
+		if lang != "" && !prompt.SupportedLanguage(lang) {
+			return "", status.Errorf(codes.InvalidArgument, "unsupported language %q", requested)
+		}
+	}
+	if lang == "" {
+		lang = ingestion.DetectLanguage(query)
+	}
+	return lang, nil
+}
+
+// promptTemplateToProto converts a repository PromptTemplate to proto PromptTemplate
+func promptTemplateToProto(t *repository.PromptTemplate, activeVersion int) *ragv1.PromptTemplate {
+	return &ragv1.PromptTemplate{
+		TenantId:    t.TenantID.String(),
+		Version:     int32(t.Version),
+		Template:    t.Template,
+		Description: t.Description,
+		Active:      t.Version == activeVersion,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+	}
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultQuestionCount is how many questions GenerateQuestions drafts
+	// when the request does not say
+	defaultQuestionCount = 10
+
+	// maxQuestionCount caps the questions of one call
+	maxQuestionCount = 100
+
+	// questionsPerPassage is how many questions are asked of each passage
+	// when there are more passages than needed
+	questionsPerPassage = 2
+
+	// maxQuestionsPerPassage caps them when there are fewer
+	maxQuestionsPerPassage = 5
+
+	// questionPassageTokens caps the adjacent chunks shown together, so an
+	// answer may span a chunk boundary
+	questionPassageTokens = 1500
+)
+
+// questionSystemPrompt instructs the LLM that drafts questions
+const questionSystemPrompt = `You write questions that readers of a document would ask, with the answers the document gives.
+
+Rules:
+- Each question must be answered by the numbered excerpts alone; do not use outside knowledge.
+- Ask about substantive facts, procedures and reasons, not about the excerpts themselves or their formatting.
+- Phrase questions the way a reader would, without referring to "the excerpt" or "the text".
+- Keep answers short and faithful to the excerpts.
+- List the numbers of the excerpts each answer is drawn from.`
+
+// questionSchema constrains the drafted questions
+var questionSchema = json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "questions": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "question": {"type": "string"},
+          "answer": {"type": "string"},
+          "excerpts": {"type": "array", "items": {"type": "integer"}}
+        },
+        "required": ["question", "answer", "excerpts"]
+      }
+    }
+  },
+  "required": ["questions"]
+}`)
+
+// draftedQuestion is one question as the LLM returns it
+type draftedQuestion struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+	Excerpts []int  `json:"excerpts"`
+}
+
+// questionPassage is adjacent chunks of one document that questions are
+// drawn from
+type questionPassage struct {
+	doc    *repository.Document
+	chunks []*repository.DocumentChunk
+}
+
+// GenerateQuestions drafts questions spread across documents: it picks
+// passages evenly through them, asks the LLM what each answers, and keeps
+// the questions whose answers cite the passage's chunks
+func (s *RAGService) GenerateQuestions(ctx context.Context, req *ragv1.GenerateQuestionsRequest) (*ragv1.GenerateQuestionsResponse, error) {
+	startTime := time.Now()
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "tenant not found: %v", err)
+	}
+
+	count := int(req.MaxQuestions)
+	if count < 0 || count > maxQuestionCount {
+		return nil, status.Errorf(codes.InvalidArgument, "max_questions must be between 0 and %d", maxQuestionCount)
+	}
+	if count == 0 {
+		count = defaultQuestionCount
+	}
+
+	docs, err := s.selectDocuments(ctx, tenant, req.DocumentIds, req.Tags, req.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+	var passages []questionPassage
+	var sample strings.Builder
+	chunkCount := 0
+	for _, doc := range docs {
+		chunks, err := s.documentChunks(ctx, doc.ID)
+		if err != nil {
+			return nil, err
+		}
+		chunkCount += len(chunks)
+		if chunkCount > maxSelectedChunks {
+			return nil, status.Errorf(codes.InvalidArgument, "the documents have over %d chunks; select fewer at a time", maxSelectedChunks)
+		}
+		for _, chunk := range chunks {
+			if sample.Len() < 2000 {
+				sample.WriteString(chunk.Content)
+				sample.WriteString("\n")
+			}
+		}
+		passages = append(passages, questionPassages(doc, chunks)...)
+	}
+	if len(passages) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the documents have no content to draw questions from")
+	}
+
+	lang, err := queryLanguage(tenant, req.Language, sample.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Spread the passages asked evenly through the documents
+	perPassage := questionsPerPassage
+	asked := (count + perPassage - 1) / perPassage
+	if asked > len(passages) {
+		asked = len(passages)
+		perPassage = min((count+asked-1)/asked, maxQuestionsPerPassage)
+	}
+	picked := make([]questionPassage, asked)
+	for i := range picked {
+		picked[i] = passages[i*len(passages)/asked]
+	}
+
+	opts := llm.GenerateOptions{
+		Model:        tenant.Config.LLMModel,
+		Fallbacks:    tenant.Config.LLMFallbackModels,
+		SystemPrompt: questionSystemPrompt,
+		Temperature:  0.4,
+		MaxTokens:    256 * perPassage,
+	}
+	drafts := make([][]*ragv1.GeneratedQuestion, len(picked))
+	errs := make([]error, len(picked))
+	semaphore := make(chan struct{}, documentLLMConcurrency)
+	done := make(chan struct{})
+	for i, passage := range picked {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			drafts[i], errs[i] = s.draftQuestions(ctx, passage, perPassage, lang, opts)
+		}()
+	}
+	for range picked {
+		<-done
+	}
+
+	questions := make([]*ragv1.GeneratedQuestion, 0, count)
+	seen := make(map[string]bool)
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			slog.WarnContext(ctx, "question generation failed for a passage", "tenant_id", tenant.ID, "document_id", picked[i].doc.ID, "error", err)
+			failed++
+			continue
+		}
+		for _, q := range drafts[i] {
+			key := strings.ToLower(strings.TrimSpace(q.Question))
+			if seen[key] || len(questions) >= count {
+				continue
+			}
+			seen[key] = true
+			questions = append(questions, q)
+		}
+	}
+	if failed == len(picked) {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, generationError(errs[0], "failed to generate questions")
+	}
+
+	return &ragv1.GenerateQuestionsResponse{
+		Questions:   questions,
+		ChunkCount:  int32(chunkCount),
+		Model:       tenant.Config.LLMModel,
+		Language:    lang,
+		TotalTimeMs: time.Since(startTime).Milliseconds(),
+	}, nil
+}
+
+// questionPassages groups a document's chunks into passages of at most
+// questionPassageTokens
+func questionPassages(doc *repository.Document, chunks []*repository.DocumentChunk) []questionPassage {
+	var passages []questionPassage
+	var current []*repository.DocumentChunk
+	tokens := 0
+	for _, chunk := range chunks {
+		if strings.TrimSpace(chunk.Content) == "" {
+			continue
+		}
+		n := prompt.EstimateTokens(chunk.Content)
+		if len(current) > 0 && tokens+n > questionPassageTokens {
+			passages = append(passages, questionPassage{doc: doc, chunks: current})
+			current, tokens = nil, 0
+		}
+		current = append(current, chunk)
+		tokens += n
+	}
+	if len(current) > 0 {
+		passages = append(passages, questionPassage{doc: doc, chunks: current})
+	}
+	return passages
+}
+
+// draftQuestions asks the LLM for up to n questions a passage answers. Drafts
+// without an answer or a valid excerpt number are dropped.
+func (s *RAGService) draftQuestions(ctx context.Context, passage questionPassage, n int, lang string, opts llm.GenerateOptions) ([]*ragv1.GeneratedQuestion, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Excerpts of %q:\n\n", passage.doc.Title)
+	for i, chunk := range passage.chunks {
+		fmt.Fprintf(&b, "[%d] %s\n\n", i+1, chunk.Content)
+	}
+	fmt.Fprintf(&b, "Write up to %d questions these excerpts answer, with their answers.", n)
+	if labels := prompt.LabelsFor(lang); labels.Instruction != "" {
+		b.WriteString(" ")
+		b.WriteString(labels.Instruction)
+	}
+
+	raw, err := s.llmClient.GenerateStructured(ctx, b.String(), questionSchema, opts)
+	if err != nil {
+		return nil, err
+	}
+	var reply struct {
+		Questions []draftedQuestion `json:"questions"`
+	}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return nil, fmt.Errorf("failed to parse questions: %w", err)
+	}
+
+	questions := make([]*ragv1.GeneratedQuestion, 0, len(reply.Questions))
+	for _, draft := range reply.Questions {
+		question, answer := strings.TrimSpace(draft.Question), strings.TrimSpace(draft.Answer)
+		if question == "" || answer == "" {
+			continue
+		}
+		var sources []*ragv1.RetrievedChunk
+		cited := make(map[int]bool)
+		for _, excerpt := range draft.Excerpts {
+			if excerpt < 1 || excerpt > len(passage.chunks) || cited[excerpt] {
+				continue
+			}
+			cited[excerpt] = true
+			chunk := passage.chunks[excerpt-1]
+			sources = append(sources, &ragv1.RetrievedChunk{
+				DocumentId: passage.doc.ID.String(),
+				ChunkId:    chunk.ID.String(),
+				Content:    chunk.Content,
+				Source:     passage.doc.Source,
+				Title:      passage.doc.Title,
+				Metadata:   chunk.Metadata,
+			})
+		}
+		if len(sources) == 0 {
+			continue
+		}
+		questions = append(questions, &ragv1.GeneratedQuestion{
+			Question: question,
+			Answer:   answer,
+			Sources:  sources,
+		})
+		if len(questions) == n {
+			break
+		}
+	}
+	return questions, nil
+}
@@ -2,17 +2,25 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log/slog"
+	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/auth"
 	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/guardrail"
+	"github.com/knoguchi/rag/internal/ingestion"
 	"github.com/knoguchi/rag/internal/llm"
 	"github.com/knoguchi/rag/internal/memory"
-	"github.com/knoguchi/rag/internal/reranker"
+	"github.com/knoguchi/rag/internal/prompt"
 	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/reranker"
 	"github.com/knoguchi/rag/internal/vectorstore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -28,13 +36,31 @@ type RAGService struct {
 	embedder    embedder.Embedder
 	vectorDB    vectorstore.VectorStore
 	llmClient   llm.LLM
-	reranker    reranker.Reranker // Optional: if set, results will be reranked
-	useHybrid   bool              // If true, uses hybrid search (dense + sparse)
-	sparseModel SparseVectorizer  // Optional: converts text to sparse vectors
-	memory      *memory.Store     // Conversation memory for session-based context
+	reranker    reranker.Reranker  // Optional: if set, results will be reranked
+	useHybrid   bool               // If true, uses hybrid search (dense + sparse)
+	sparseModel SparseVectorizer   // Optional: converts text to sparse vectors
+	memory      *memory.Store      // Conversation memory for session-based context
+	embedders   *embedder.Pool     // Optional: per-tenant embedding models
+	rerankers   *reranker.Registry // Optional: rerankers tenants choose from
+
+	graphRepo   repository.GraphRepository          // Optional: tenants' knowledge graphs, for strategy=graph
+	promptRepo  repository.PromptTemplateRepository // Optional: tenants' prompt templates
+	promptCache sync.Map                            // tenant ID -> cachedPromptTemplate
+	guardrails  guardrail.Pipeline                  // Server-wide filters, run after each tenant's
+	summarizing sync.Map                            // tenant ID/session ID -> struct{} while summarizing
+
+	synonymRepo  repository.SynonymRepository // Optional: tenants' synonym dictionaries
+	synonymCache sync.Map                     // tenant ID -> cachedSynonyms
+
+	analyzerCache sync.Map // tenant ID -> cachedVectorizer, for tenants with text analysis settings
+
+	queryCounter *QueryCounter // Optional: counts tenants' monthly queries
+
+	projectRepo repository.ProjectRepository // Optional: tenants' projects, whose queries search only their documents
 }
 
-// SparseVectorizer converts text to sparse vectors for hybrid search
+// SparseVectorizer converts text to sparse vectors for hybrid search.
+// Tenants with text analysis settings use an analysis.Vectorizer instead.
 type SparseVectorizer interface {
 	Vectorize(text string) *vectorstore.SparseVector
 }
@@ -49,6 +75,30 @@ func WithReranker(r reranker.Reranker) RAGServiceOption {
 	}
 }
 
+// WithRerankers lets tenants choose their reranker by provider name,
+// taking precedence over WithReranker.
+func WithRerankers(r *reranker.Registry) RAGServiceOption {
+	return func(s *RAGService) {
+		s.rerankers = r
+	}
+}
+
+// WithKnowledgeGraph lets queries of tenants with a knowledge graph use
+// strategy=graph.
+func WithKnowledgeGraph(repo repository.GraphRepository) RAGServiceOption {
+	return func(s *RAGService) {
+		s.graphRepo = repo
+	}
+}
+
+// WithSynonyms expands keyword and sparse queries with each tenant's
+// synonym dictionary.
+func WithSynonyms(repo repository.SynonymRepository) RAGServiceOption {
+	return func(s *RAGService) {
+		s.synonymRepo = repo
+	}
+}
+
 // WithHybridSearch enables hybrid search with the given sparse vectorizer.
 func WithHybridSearch(sparseModel SparseVectorizer) RAGServiceOption {
 	return func(s *RAGService) {
@@ -57,6 +107,52 @@ func WithHybridSearch(sparseModel SparseVectorizer) RAGServiceOption {
 	}
 }
 
+// WithEmbedderPool embeds queries with each tenant's configured embedding model.
+func WithEmbedderPool(pool *embedder.Pool) RAGServiceOption {
+	return func(s *RAGService) {
+		s.embedders = pool
+	}
+}
+
+// WithPromptTemplates renders each tenant's prompts with its active template.
+func WithPromptTemplates(repo repository.PromptTemplateRepository) RAGServiceOption {
+	return func(s *RAGService) {
+		s.promptRepo = repo
+	}
+}
+
+// WithMemoryStore keeps conversation sessions in the given store, so a
+// SessionService can share it.
+func WithMemoryStore(store *memory.Store) RAGServiceOption {
+	return func(s *RAGService) {
+		s.memory = store
+	}
+}
+
+// WithGuardrails runs the pipeline's filters on every tenant's queries, after
+// the built-in filters the tenant configures.
+func WithGuardrails(p guardrail.Pipeline) RAGServiceOption {
+	return func(s *RAGService) {
+		s.guardrails = p
+	}
+}
+
+// WithQueryCounter counts each tenant's Query, QueryStream and Retrieve
+// requests with the counter.
+func WithQueryCounter(c *QueryCounter) RAGServiceOption {
+	return func(s *RAGService) {
+		s.queryCounter = c
+	}
+}
+
+// WithProjects lets queries search one of the tenant's projects, with the
+// project's retrieval settings over the tenant's.
+func WithProjects(repo repository.ProjectRepository) RAGServiceOption {
+	return func(s *RAGService) {
+		s.projectRepo = repo
+	}
+}
+
 // NewRAGService creates a new RAGService
 func NewRAGService(
 	tenantRepo repository.TenantRepository,
@@ -82,20 +178,43 @@ func NewRAGService(
 	return s
 }
 
+// embedderFor returns the embedder for a tenant's configured model and dimension
+func (s *RAGService) embedderFor(tenant *repository.Tenant) embedder.Embedder {
+	emb := s.embedder
+	if s.embedders != nil {
+		emb = s.embedders.Get(tenant.Config.EmbeddingModel)
+	}
+	return embedder.Truncate(emb, tenant.Config.EmbeddingDimension)
+}
+
+// rerankerFor returns the reranker a tenant's config selects, or nil when it
+// doesn't enable reranking or its provider isn't available
+func (s *RAGService) rerankerFor(tenant *repository.Tenant) reranker.Reranker {
+	if !tenant.Config.RerankerEnabled {
+		return nil
+	}
+	if s.rerankers == nil {
+		return s.reranker
+	}
+	r, err := s.rerankers.Get(tenant.Config.Rerank.Provider)
+	if err != nil {
+		slog.Warn("skipping rerank", "tenant_id", tenant.ID, "error", err)
+		return nil
+	}
+	return r
+}
+
 // Query retrieves context and generates an LLM response
 func (s *RAGService) Query(ctx context.Context, req *ragv1.QueryRequest) (*ragv1.QueryResponse, error) {
 	startTime := time.Now()
 
-	if req.TenantId == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 	if req.Query == "" {
 		return nil, status.Error(codes.InvalidArgument, "query is required")
 	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return nil, err
 	}
 
 	// Get tenant config
@@ -103,61 +222,77 @@ func (s *RAGService) Query(ctx context.Context, req *ragv1.QueryRequest) (*ragv1
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "tenant not found: %v", err)
 	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeRead); err != nil {
+		return nil, err
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenant.ID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	tenant = withProjectRetrieval(tenant, project)
+	s.queryCounter.Add(tenant.ID)
 
 	// Build query options from tenant config and request options
 	options := s.buildQueryOptions(tenant, req.Options)
-
-	// Step 1: Embed the query
-	retrievalStart := time.Now()
-	queryVector, err := s.embedder.Embed(ctx, req.Query)
+	filter, err := searchFilter(project, req.Options.GetTags(), req.Options.GetCollectionIds(), req.Options.GetLanguages())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to embed query: %v", err)
+		return nil, err
 	}
-
-	// Step 2: Search for relevant chunks
-	var searchResults []vectorstore.SearchResult
-
-	if s.useHybrid && s.sparseModel != nil {
-		// Use hybrid search (combines dense + sparse vectors with RRF)
-		sparseVector := s.sparseModel.Vectorize(req.Query)
-		searchResults, err = s.vectorDB.HybridSearch(ctx, tenantID.String(), queryVector, sparseVector, options.topK*3, options.minScore)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to perform hybrid search: %v", err)
-		}
-	} else {
-		// Dense vector-only search (retrieve extra for deduplication and reranking)
-		searchResults, err = s.vectorDB.Search(ctx, tenantID.String(), queryVector, options.topK*3, options.minScore)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to search vectors: %v", err)
-		}
+	boost, err := resolveScoreBoost(tenant, req.Options.GetScoreBoost())
+	if err != nil {
+		return nil, err
+	}
+	tmpl, _, err := s.promptTemplate(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	options.language, err = queryLanguage(tenant, req.Options.GetLanguage(), req.Query)
+	if err != nil {
+		return nil, err
+	}
+	options.guardrails, err = s.tenantGuardrails(tenant)
+	if err != nil {
+		return nil, err
 	}
 
-	// Step 2.5: Deduplicate similar chunks (70% Jaccard threshold)
-	searchResults = deduplicateResults(searchResults, 0.7)
+	// Step 0: Screen the query; filters may rewrite it, e.g. to redact PII
+	query, blocked, guardEvents, err := guardText(ctx, options.guardrails, guardrail.StageInput, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return &ragv1.QueryResponse{
+			Answer:          blockedMessage(tenant.Config.Guardrails, options.language),
+			AnswerType:      ragv1.AnswerType_ANSWER_TYPE_BLOCKED,
+			GuardrailEvents: guardEvents,
+			Metadata: &ragv1.QueryMetadata{
+				TotalTimeMs: time.Since(startTime).Milliseconds(),
+				Model:       options.model,
+				Language:    options.language,
+			},
+		}, nil
+	}
 
-	// Step 2.6: Rerank if enabled for this tenant
-	if s.reranker != nil && tenant.Config.RerankerEnabled && len(searchResults) > 0 {
-		reranked, err := s.reranker.Rerank(ctx, req.Query, searchResults, options.topK)
-		if err == nil && len(reranked) > 0 {
-			// Convert reranked results back to search results with updated scores
-			searchResults = make([]vectorstore.SearchResult, len(reranked))
-			for i, r := range reranked {
-				searchResults[i] = r.SearchResult
-				searchResults[i].Score = r.RerankerScore // Use reranker score
-			}
-		}
-		// On error, continue with original results
+	// Step 1-2: Embed the query and retrieve relevant chunks
+	retrievalStart := time.Now()
+	retrieval, err := s.retrieveForQuery(ctx, tenant, query, options, filter, boost, false)
+	if err != nil {
+		return nil, err
 	}
 
-	// Limit to topK after deduplication/reranking
-	if len(searchResults) > options.topK {
-		searchResults = searchResults[:options.topK]
+	// Step 2.9: In agentic mode, let the LLM search further before answering
+	var agent agentRun
+	if options.agentic {
+		agent, err = s.runAgent(ctx, tenant, query, options, filter, boost, retrieval, nil)
+		if err != nil {
+			return nil, err
+		}
 	}
+	searchResults := retrieval.results
 	retrievalTime := time.Since(retrievalStart)
 
 	// Convert search results to retrieved chunks
 	sources := make([]*ragv1.RetrievedChunk, len(searchResults))
-	chunkContexts := make([]chunkContext, len(searchResults))
 	for i, result := range searchResults {
 		sources[i] = &ragv1.RetrievedChunk{
 			DocumentId: result.DocumentID,
@@ -168,59 +303,113 @@ func (s *RAGService) Query(ctx context.Context, req *ragv1.QueryRequest) (*ragv1
 			Title:      result.Metadata["title"],
 			Metadata:   result.Metadata,
 		}
-		chunkContexts[i] = chunkContext{
-			Content:  result.Content,
-			Source:   result.Metadata["source"],
-			Title:    result.Metadata["title"],
-			Score:    result.Score,
-			Metadata: result.Metadata,
-		}
 	}
+	s.attachSharedDocuments(ctx, tenant, sources)
+
+	// Build LLM context, widening hits with neighbor/parent chunks if requested
+	chunkContexts, err := s.promptContexts(ctx, retrieval, options)
+	if err != nil {
+		return nil, err
+	}
+	guardEvents = append(guardEvents, retrieval.guardEvents...)
 
 	// Step 3: Get conversation history if session ID provided
-	var history []memory.Message
+	var history sessionHistory
 	if req.SessionId != "" {
-		history = s.memory.GetRecentHistory(req.SessionId, 10) // Last 10 messages (5 turns)
-		s.memory.AddUserMessage(req.SessionId, req.Query)
+		history = s.sessionHistory(tenant, req.SessionId)
+		s.memory.AddUserMessage(tenant.ID.String(), req.SessionId, query)
 	}
 
-	// Step 4: Build prompt and call LLM
+	// Step 4: Build prompt and call LLM, unless there is no context and the
+	// tenant answers those queries without it
 	generationStart := time.Now()
-	prompt := s.buildRAGPrompt(options.systemPrompt, chunkContexts, req.Query, history)
+	answerType := classifyAnswer(retrieval, tenant.Config.NoAnswer)
+	var answer string
+	var toolCall *ragv1.ToolCall
+	var usage generationUsage
+	var truncation *ragv1.PromptTruncation
+	if answerType == ragv1.AnswerType_ANSWER_TYPE_NO_CONTEXT && tenant.Config.NoAnswer.SkipLLM {
+		answer = noAnswerMessage(tenant.Config.NoAnswer, options.language)
+	} else {
+		budget := s.promptBudget(options, tenant.Config.LLMFallbackModels)
+		prompt, fit, err := s.buildRAGPrompt(tmpl, options.systemPrompt, options.language, chunkContexts, query, history, budget)
+		if err != nil {
+			return nil, err
+		}
+		truncation = truncationToProto(fit, budget)
+
+		llmOpts := llm.GenerateOptions{
+			Model:        options.model,
+			Fallbacks:    tenant.Config.LLMFallbackModels,
+			SystemPrompt: options.systemPrompt,
+			Temperature:  options.temperature,
+			MaxTokens:    options.maxTokens,
+		}
 
-	llmOpts := llm.GenerateOptions{
-		Model:        options.model,
-		SystemPrompt: options.systemPrompt,
-		Temperature:  options.temperature,
-		MaxTokens:    options.maxTokens,
+		if len(options.tools) > 0 {
+			answer, toolCall, err = s.generateWithTools(ctx, prompt, llmOpts, options.tools)
+		} else {
+			answer, err = s.llmClient.Generate(ctx, prompt, llmOpts)
+		}
+		if err != nil {
+			return nil, generationError(err, "failed to generate response")
+		}
+		usage = s.estimateUsage(options, fit, completionText(answer, toolCall))
 	}
+	generationTime := time.Since(generationStart)
+	usage = usage.plus(agent.usage)
 
-	answer, err := s.llmClient.Generate(ctx, prompt, llmOpts)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate response: %v", err)
+	// Step 5: Screen the answer; tool calls go to the client app, which
+	// validates their arguments itself
+	if toolCall != nil {
+		answerType = ragv1.AnswerType_ANSWER_TYPE_TOOL_CALL
+	} else {
+		var outputEvents []*ragv1.GuardrailEvent
+		answer, blocked, outputEvents, err = guardText(ctx, options.guardrails, guardrail.StageOutput, answer)
+		if err != nil {
+			return nil, err
+		}
+		guardEvents = append(guardEvents, outputEvents...)
+		if blocked {
+			answer = blockedMessage(tenant.Config.Guardrails, options.language)
+			answerType = ragv1.AnswerType_ANSWER_TYPE_BLOCKED
+		}
 	}
-	generationTime := time.Since(generationStart)
 
 	// Store assistant response in memory
 	if req.SessionId != "" {
-		s.memory.AddAssistantMessage(req.SessionId, answer)
+		if toolCall != nil {
+			s.recordAnswer(tenant, req.SessionId, toolCallText(toolCall))
+		} else {
+			s.recordAnswer(tenant, req.SessionId, answer)
+		}
 	}
 
 	totalTime := time.Since(startTime)
 
-	return &ragv1.QueryResponse{
-		Answer:  answer,
-		Sources: sources,
+	resp := &ragv1.QueryResponse{
+		Answer:          answer,
+		Sources:         sources,
+		AnswerType:      answerType,
+		GuardrailEvents: guardEvents,
+		AgentSteps:      agent.steps,
 		Metadata: &ragv1.QueryMetadata{
 			RetrievalTimeMs:  retrievalTime.Milliseconds(),
 			GenerationTimeMs: generationTime.Milliseconds(),
 			TotalTimeMs:      totalTime.Milliseconds(),
 			ChunksRetrieved:  int32(len(sources)),
 			Model:            options.model,
-			PromptTokens:     0, // TODO: Implement token counting
-			CompletionTokens: 0, // TODO: Implement token counting
+			PromptTokens:     int32(usage.promptTokens),
+			CompletionTokens: int32(usage.completionTokens),
+			Language:         options.language,
+			Truncation:       truncation,
+			EstimatedCostUsd: usage.cost,
 		},
-	}, nil
+	}
+	if toolCall != nil {
+		resp.Action = &ragv1.QueryResponse_ToolCall{ToolCall: toolCall}
+	}
+	return resp, nil
 }
 
 // QueryStream streams the LLM response for interactive use
@@ -228,16 +417,13 @@ func (s *RAGService) QueryStream(req *ragv1.QueryRequest, stream grpc.ServerStre
 	ctx := stream.Context()
 	startTime := time.Now()
 
-	if req.TenantId == "" {
-		return status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 	if req.Query == "" {
 		return status.Error(codes.InvalidArgument, "query is required")
 	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return err
 	}
 
 	// Get tenant config
@@ -245,62 +431,92 @@ func (s *RAGService) QueryStream(req *ragv1.QueryRequest, stream grpc.ServerStre
 	if err != nil {
 		return status.Errorf(codes.NotFound, "tenant not found: %v", err)
 	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeRead); err != nil {
+		return err
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenant.ID, req.ProjectId)
+	if err != nil {
+		return err
+	}
+	tenant = withProjectRetrieval(tenant, project)
+	s.queryCounter.Add(tenant.ID)
 
 	// Build query options from tenant config and request options
 	options := s.buildQueryOptions(tenant, req.Options)
-
-	// Step 1: Embed the query
-	retrievalStart := time.Now()
-	queryVector, err := s.embedder.Embed(ctx, req.Query)
+	filter, err := searchFilter(project, req.Options.GetTags(), req.Options.GetCollectionIds(), req.Options.GetLanguages())
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to embed query: %v", err)
+		return err
+	}
+	boost, err := resolveScoreBoost(tenant, req.Options.GetScoreBoost())
+	if err != nil {
+		return err
+	}
+	tmpl, _, err := s.promptTemplate(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	options.language, err = queryLanguage(tenant, req.Options.GetLanguage(), req.Query)
+	if err != nil {
+		return err
+	}
+	options.guardrails, err = s.tenantGuardrails(tenant)
+	if err != nil {
+		return err
 	}
 
-	// Step 2: Search for relevant chunks
-	var searchResults []vectorstore.SearchResult
-
-	if s.useHybrid && s.sparseModel != nil {
-		// Use hybrid search (combines dense + sparse vectors with RRF)
-		sparseVector := s.sparseModel.Vectorize(req.Query)
-		searchResults, err = s.vectorDB.HybridSearch(ctx, tenantID.String(), queryVector, sparseVector, options.topK*3, options.minScore)
-		if err != nil {
-			return status.Errorf(codes.Internal, "failed to perform hybrid search: %v", err)
-		}
-	} else {
-		// Dense vector-only search (retrieve extra for deduplication and reranking)
-		searchResults, err = s.vectorDB.Search(ctx, tenantID.String(), queryVector, options.topK*3, options.minScore)
-		if err != nil {
-			return status.Errorf(codes.Internal, "failed to search vectors: %v", err)
+	// Step 0: Screen the query; a blocked query is answered with the
+	// tenant's blocked message
+	query, blocked, guardEvents, err := guardText(ctx, options.guardrails, guardrail.StageInput, req.Query)
+	if err != nil {
+		return err
+	}
+	if err := sendGuardrailEvents(stream, guardEvents); err != nil {
+		return err
+	}
+	if blocked {
+		if err := stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_Token{Token: blockedMessage(tenant.Config.Guardrails, options.language)},
+		}); err != nil {
+			return err
 		}
+		return stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_Metadata{
+				Metadata: &ragv1.QueryMetadata{
+					TotalTimeMs: time.Since(startTime).Milliseconds(),
+					Model:       options.model,
+					Language:    options.language,
+				},
+			},
+		})
 	}
 
-	// Step 2.5: Deduplicate similar chunks (70% Jaccard threshold)
-	searchResults = deduplicateResults(searchResults, 0.7)
-
-	// Step 2.6: Rerank if enabled for this tenant
-	if s.reranker != nil && tenant.Config.RerankerEnabled && len(searchResults) > 0 {
-		reranked, err := s.reranker.Rerank(ctx, req.Query, searchResults, options.topK)
-		if err == nil && len(reranked) > 0 {
-			// Convert reranked results back to search results with updated scores
-			searchResults = make([]vectorstore.SearchResult, len(reranked))
-			for i, r := range reranked {
-				searchResults[i] = r.SearchResult
-				searchResults[i].Score = r.RerankerScore // Use reranker score
-			}
-		}
-		// On error, continue with original results
+	// Step 1-2: Embed the query and retrieve relevant chunks
+	retrievalStart := time.Now()
+	retrieval, err := s.retrieveForQuery(ctx, tenant, query, options, filter, boost, false)
+	if err != nil {
+		return err
 	}
 
-	// Limit to topK after deduplication/reranking
-	if len(searchResults) > options.topK {
-		searchResults = searchResults[:options.topK]
+	// Step 2.9: In agentic mode, let the LLM search further, streaming
+	// each tool call as it completes
+	var agent agentRun
+	if options.agentic {
+		agent, err = s.runAgent(ctx, tenant, query, options, filter, boost, retrieval, func(step *ragv1.AgentStep) error {
+			return stream.Send(&ragv1.QueryStreamResponse{
+				Event: &ragv1.QueryStreamResponse_AgentStep{AgentStep: step},
+			})
+		})
+		if err != nil {
+			return err
+		}
 	}
+	searchResults := retrieval.results
 	retrievalTime := time.Since(retrievalStart)
 
 	// Step 3: Stream sources first
-	chunkContexts := make([]chunkContext, len(searchResults))
+	sources := make([]*ragv1.RetrievedChunk, len(searchResults))
 	for i, result := range searchResults {
-		source := &ragv1.RetrievedChunk{
+		sources[i] = &ragv1.RetrievedChunk{
 			DocumentId: result.DocumentID,
 			ChunkId:    result.ID,
 			Content:    result.Content,
@@ -309,78 +525,155 @@ func (s *RAGService) QueryStream(req *ragv1.QueryRequest, stream grpc.ServerStre
 			Title:      result.Metadata["title"],
 			Metadata:   result.Metadata,
 		}
-
+	}
+	s.attachSharedDocuments(ctx, tenant, sources)
+	for _, source := range sources {
 		if err := stream.Send(&ragv1.QueryStreamResponse{
 			Event: &ragv1.QueryStreamResponse_Source{Source: source},
 		}); err != nil {
 			return err
 		}
+	}
 
-		chunkContexts[i] = chunkContext{
-			Content:  result.Content,
-			Source:   result.Metadata["source"],
-			Title:    result.Metadata["title"],
-			Score:    result.Score,
-			Metadata: result.Metadata,
-		}
+	// Build LLM context, widening hits with neighbor/parent chunks if requested
+	chunkContexts, err := s.promptContexts(ctx, retrieval, options)
+	if err != nil {
+		return err
+	}
+	if err := sendGuardrailEvents(stream, retrieval.guardEvents); err != nil {
+		return err
 	}
 
 	// Step 4: Get conversation history if session ID provided
-	var history []memory.Message
+	var history sessionHistory
 	if req.SessionId != "" {
-		history = s.memory.GetRecentHistory(req.SessionId, 10) // Last 10 messages (5 turns)
-		s.memory.AddUserMessage(req.SessionId, req.Query)
+		history = s.sessionHistory(tenant, req.SessionId)
+		s.memory.AddUserMessage(tenant.ID.String(), req.SessionId, query)
 	}
 
-	// Step 5: Build prompt and stream LLM response
+	// Step 4.5: Tell the client when there is no context to answer from,
+	// answering with the tenant's message if it skips the LLM
 	generationStart := time.Now()
-	prompt := s.buildRAGPrompt(options.systemPrompt, chunkContexts, req.Query, history)
+	if classifyAnswer(retrieval, tenant.Config.NoAnswer) == ragv1.AnswerType_ANSWER_TYPE_NO_CONTEXT {
+		message := noAnswerMessage(tenant.Config.NoAnswer, options.language)
+		skip := tenant.Config.NoAnswer.SkipLLM
+		if err := stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_NoContext{NoContext: &ragv1.NoContext{Message: message, LlmSkipped: skip}},
+		}); err != nil {
+			return err
+		}
+		if skip {
+			if err := stream.Send(&ragv1.QueryStreamResponse{
+				Event: &ragv1.QueryStreamResponse_Token{Token: message},
+			}); err != nil {
+				return err
+			}
+			if req.SessionId != "" {
+				s.recordAnswer(tenant, req.SessionId, message)
+			}
+			return stream.Send(&ragv1.QueryStreamResponse{
+				Event: &ragv1.QueryStreamResponse_Metadata{
+					Metadata: &ragv1.QueryMetadata{
+						RetrievalTimeMs: retrievalTime.Milliseconds(),
+						TotalTimeMs:     time.Since(startTime).Milliseconds(),
+						ChunksRetrieved: int32(len(searchResults)),
+						Model:           options.model,
+						Language:        options.language,
+					},
+				},
+			})
+		}
+	}
+
+	// Step 5: Build prompt and stream LLM response
+	budget := s.promptBudget(options, tenant.Config.LLMFallbackModels)
+	prompt, fit, err := s.buildRAGPrompt(tmpl, options.systemPrompt, options.language, chunkContexts, query, history, budget)
+	if err != nil {
+		return err
+	}
 
 	llmOpts := llm.GenerateOptions{
 		Model:        options.model,
+		Fallbacks:    tenant.Config.LLMFallbackModels,
 		SystemPrompt: options.systemPrompt,
 		Temperature:  options.temperature,
 		MaxTokens:    options.maxTokens,
 	}
 
-	tokenChan, err := s.llmClient.GenerateStream(ctx, prompt, llmOpts)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to start streaming: %v", err)
-	}
-
-	// Collect full response for memory
+	// Collect full response for memory. Output guardrails must see the whole
+	// answer before the client does, so with any configured it is held back
+	// and sent as one token once checked.
 	var fullResponse strings.Builder
+	var toolCall *ragv1.ToolCall
+	holdAnswer := !options.guardrails.Empty(guardrail.StageOutput)
+
+	if len(options.tools) > 0 {
+		// Whether the LLM answers or calls a tool is known only once it
+		// finishes, so the answer is held back too
+		holdAnswer = true
+		answer, call, err := s.generateWithTools(ctx, prompt, llmOpts, options.tools)
+		if err != nil {
+			return sendGenerationError(stream, err)
+		}
+		fullResponse.WriteString(answer)
+		toolCall = call
+	} else {
+		tokenChan, err := s.llmClient.GenerateStream(ctx, prompt, llmOpts)
+		if err != nil {
+			return generationError(err, "failed to start streaming")
+		}
 
-	// Stream tokens
-	for chunk := range tokenChan {
-		if chunk.Error != nil {
-			// Send error and stop streaming
-			if err := stream.Send(&ragv1.QueryStreamResponse{
-				Event: &ragv1.QueryStreamResponse_Error{
-					Error: &ragv1.StreamError{
-						Code:    "generation_error",
-						Message: chunk.Error.Error(),
-					},
-				},
-			}); err != nil {
-				return err
+		// Stream tokens
+		for chunk := range tokenChan {
+			if chunk.Error != nil {
+				// Send error and stop streaming
+				return sendGenerationError(stream, chunk.Error)
 			}
-			return nil
-		}
 
-		if chunk.Token != "" {
-			fullResponse.WriteString(chunk.Token) // Collect for memory
-			if err := stream.Send(&ragv1.QueryStreamResponse{
-				Event: &ragv1.QueryStreamResponse_Token{Token: chunk.Token},
-			}); err != nil {
-				return err
+			if chunk.Token != "" {
+				fullResponse.WriteString(chunk.Token) // Collect for memory
+				if holdAnswer {
+					continue
+				}
+				if err := stream.Send(&ragv1.QueryStreamResponse{
+					Event: &ragv1.QueryStreamResponse_Token{Token: chunk.Token},
+				}); err != nil {
+					return err
+				}
 			}
 		}
 	}
 
+	answer := fullResponse.String()
+	usage := s.estimateUsage(options, fit, completionText(answer, toolCall)).plus(agent.usage)
+	if toolCall != nil {
+		if err := stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_ToolCall{ToolCall: toolCall},
+		}); err != nil {
+			return err
+		}
+		answer = toolCallText(toolCall)
+	} else if holdAnswer {
+		answer, blocked, guardEvents, err = guardText(ctx, options.guardrails, guardrail.StageOutput, answer)
+		if err != nil {
+			return err
+		}
+		if err := sendGuardrailEvents(stream, guardEvents); err != nil {
+			return err
+		}
+		if blocked {
+			answer = blockedMessage(tenant.Config.Guardrails, options.language)
+		}
+		if err := stream.Send(&ragv1.QueryStreamResponse{
+			Event: &ragv1.QueryStreamResponse_Token{Token: answer},
+		}); err != nil {
+			return err
+		}
+	}
+
 	// Store assistant response in memory
 	if req.SessionId != "" {
-		s.memory.AddAssistantMessage(req.SessionId, fullResponse.String())
+		s.recordAnswer(tenant, req.SessionId, answer)
 	}
 
 	generationTime := time.Since(generationStart)
@@ -395,8 +688,11 @@ func (s *RAGService) QueryStream(req *ragv1.QueryRequest, stream grpc.ServerStre
 				TotalTimeMs:      totalTime.Milliseconds(),
 				ChunksRetrieved:  int32(len(searchResults)),
 				Model:            options.model,
-				PromptTokens:     0,
-				CompletionTokens: 0,
+				PromptTokens:     int32(usage.promptTokens),
+				CompletionTokens: int32(usage.completionTokens),
+				Language:         options.language,
+				Truncation:       truncationToProto(fit, budget),
+				EstimatedCostUsd: usage.cost,
 			},
 		},
 	}); err != nil {
@@ -406,20 +702,30 @@ func (s *RAGService) QueryStream(req *ragv1.QueryRequest, stream grpc.ServerStre
 	return nil
 }
 
+// sendGenerationError tells a streaming client that generation failed,
+// ending the stream
+func sendGenerationError(stream grpc.ServerStreamingServer[ragv1.QueryStreamResponse], err error) error {
+	return stream.Send(&ragv1.QueryStreamResponse{
+		Event: &ragv1.QueryStreamResponse_Error{
+			Error: &ragv1.StreamError{
+				Code:    "generation_error",
+				Message: err.Error(),
+			},
+		},
+	})
+}
+
 // Retrieve only retrieves relevant chunks without LLM generation
 func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (*ragv1.RetrieveResponse, error) {
 	startTime := time.Now()
 
-	if req.TenantId == "" {
-		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
-	}
 	if req.Query == "" {
 		return nil, status.Error(codes.InvalidArgument, "query is required")
 	}
 
-	tenantID, err := uuid.Parse(req.TenantId)
+	tenantID, err := requestTenantID(ctx, req.TenantId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid tenant_id format")
+		return nil, err
 	}
 
 	// Get tenant config
@@ -427,6 +733,15 @@ func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "tenant not found: %v", err)
 	}
+	if err := auth.CheckTenantStatus(tenant, auth.ScopeRead); err != nil {
+		return nil, err
+	}
+	project, err := requestProject(ctx, s.projectRepo, tenant.ID, req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	tenant = withProjectRetrieval(tenant, project)
+	s.queryCounter.Add(tenant.ID)
 
 	// Build retrieval options
 	topK := tenant.Config.TopK
@@ -441,16 +756,27 @@ func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (
 		}
 	}
 
-	// Embed the query
-	queryVector, err := s.embedder.Embed(ctx, req.Query)
+	filter, err := searchFilter(project, req.Options.GetTags(), req.Options.GetCollectionIds(), req.Options.GetLanguages())
+	if err != nil {
+		return nil, err
+	}
+	boost, err := resolveScoreBoost(tenant, req.Options.GetScoreBoost())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to embed query: %v", err)
+		return nil, err
 	}
 
-	// Search for relevant chunks
-	searchResults, err := s.vectorDB.Search(ctx, tenantID.String(), queryVector, topK, minScore)
+	// Search for relevant chunks, with extra candidates for boosting to promote
+	fetchK := topK
+	if boostEnabled(boost) {
+		fetchK = topK * 3
+	}
+	searchResults, mode, err := s.retrieve(ctx, tenant, req.Query, req.Mode, fetchK, minScore, filter)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to search vectors: %v", err)
+		return nil, err
+	}
+	searchResults = applyScoreBoost(searchResults, boost, time.Now())
+	if len(searchResults) > topK {
+		searchResults = searchResults[:topK]
 	}
 
 	// Filter by document IDs if specified
@@ -469,6 +795,13 @@ func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (
 		searchResults = filtered
 	}
 
+	// Neighbor window for display (capped to keep responses small)
+	var before, after int
+	if req.Options != nil {
+		before = min(max(int(req.Options.NeighborsBefore), 0), maxExpansionWindow)
+		after = min(max(int(req.Options.NeighborsAfter), 0), maxExpansionWindow)
+	}
+
 	// Convert search results to retrieved chunks
 	chunks := make([]*ragv1.RetrievedChunk, len(searchResults))
 	for i, result := range searchResults {
@@ -481,7 +814,11 @@ func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (
 			Title:      result.Metadata["title"],
 			Metadata:   result.Metadata,
 		}
+		if before > 0 || after > 0 {
+			s.attachNeighbors(ctx, chunks[i], result, before, after)
+		}
 	}
+	s.attachSharedDocuments(ctx, tenant, chunks)
 
 	retrievalTime := time.Since(startTime)
 
@@ -491,10 +828,115 @@ func (s *RAGService) Retrieve(ctx context.Context, req *ragv1.RetrieveRequest) (
 			RetrievalTimeMs:     retrievalTime.Milliseconds(),
 			ChunksRetrieved:     int32(len(chunks)),
 			TotalChunksSearched: 0, // TODO: Get from vector store if available
+			Mode:                mode,
 		},
 	}, nil
 }
 
+// queryRetrieval records what each stage of a query's retrieval produced
+type queryRetrieval struct {
+	searchText      string // what was embedded: the query, or its graph expansion
+	queryVector     []float32
+	sparseVector    *vectorstore.SparseVector  // nil unless hybrid search was used
+	sparseModel     SparseVectorizer           // what sparseVector was built with
+	candidates      []vectorstore.SearchResult // as returned by the vector store
+	duplicates      []duplicate                // one per candidate
+	rerankScores    map[string]float32         // calibrated, by chunk ID; nil when not reranked
+	rerankThreshold float32                    // calibrated score below which reranked chunks were dropped
+	guardEvents     []*ragv1.GuardrailEvent    // what the context guardrails did
+	results         []vectorstore.SearchResult // what the prompt is built from
+}
+
+// retrieveForQuery embeds and searches for a query, then deduplicates,
+// reranks, boosts and screens the candidates down to topK. withVectors also fetches
+// the candidates' vectors, for explaining their scores.
+func (s *RAGService) retrieveForQuery(ctx context.Context, tenant *repository.Tenant, query string, options queryOptions, filter vectorstore.Filter, boost repository.ScoreBoostConfig, withVectors bool) (*queryRetrieval, error) {
+	// Step 0: Expand the query through the knowledge graph for strategy=graph
+	searchText := query
+	switch options.strategy {
+	case "", strategyVector:
+	case strategyGraph:
+		// The filter's project was resolved from the request's credentials
+		projectID, _ := uuid.Parse(filter.ProjectID)
+		var err error
+		if searchText, err = s.graphSearchText(ctx, tenant, projectID, query); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown strategy %q: must be %q or %q", options.strategy, strategyVector, strategyGraph)
+	}
+
+	// Step 1: Embed the query
+	queryVector, err := s.embedderFor(tenant).EmbedQuery(ctx, searchText)
+	if err != nil {
+		return nil, apierr.ErrEmbeddingFailed.Wrap(err, "failed to embed query")
+	}
+	r := &queryRetrieval{searchText: searchText, queryVector: queryVector}
+
+	// Step 2: Search for relevant chunks (retrieve extra for deduplication and reranking)
+	// Embedding deduplication compares the candidates' vectors
+	withVectors = withVectors || tenant.Config.DuplicateResults.Method == repository.DuplicateResultsEmbedding
+	searchOpts := vectorstore.SearchOptions{Filter: filter, WithVectors: withVectors}
+	if s.useHybrid && s.sparseModel != nil {
+		// Use hybrid search (combines dense + sparse vectors with RRF)
+		r.sparseModel = s.sparseVectorizer(tenant)
+		r.sparseVector = r.sparseModel.Vectorize(s.sparseQuery(ctx, tenant, searchText))
+		r.candidates, err = s.vectorDB.HybridSearch(ctx, tenant.ID.String(), queryVector, r.sparseVector, options.topK*3, options.minScore, searchOpts)
+		if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return nil, searchError(err, "failed to perform hybrid search")
+		}
+	} else {
+		r.candidates, err = s.vectorDB.Search(ctx, tenant.ID.String(), queryVector, options.topK*3, options.minScore, searchOpts)
+		if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return nil, searchError(err, "failed to search vectors")
+		}
+	}
+
+	// Step 2.5: Deduplicate similar chunks
+	r.duplicates = findDuplicates(r.candidates, tenant.Config.DuplicateResults)
+	results := dropDuplicates(r.candidates, r.duplicates)
+
+	// Step 2.6: Rerank if enabled for this tenant, dropping chunks below
+	// its relevance threshold
+	if rr := s.rerankerFor(tenant); rr != nil && len(results) > 0 {
+		reranked, err := rr.Rerank(ctx, query, results, options.topK)
+		if err == nil && len(reranked) > 0 {
+			rerankCalibration(tenant.Config.Rerank).Apply(reranked)
+			r.rerankScores = make(map[string]float32, len(reranked))
+			for _, scored := range reranked {
+				r.rerankScores[scored.ID] = scored.RerankerScore
+			}
+			r.rerankThreshold = float32(tenant.Config.Rerank.Threshold)
+			kept := reranker.AboveThreshold(reranked, r.rerankThreshold)
+
+			// Convert reranked results back to search results with updated scores
+			results = make([]vectorstore.SearchResult, len(kept))
+			for i, scored := range kept {
+				results[i] = scored.SearchResult
+				results[i].Score = scored.RerankerScore // Use reranker score
+			}
+		}
+		// On error, continue with original results
+	}
+
+	// Step 2.7: Boost fresh and high-priority documents
+	results = applyScoreBoost(results, boost, time.Now())
+
+	// Step 2.8: Screen chunks with the context guardrails before the cut, so
+	// dropped chunks make room for the next candidates
+	results, r.guardEvents, err = guardChunks(ctx, options.guardrails, results)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit to topK after deduplication/reranking/boosting
+	if len(results) > options.topK {
+		results = results[:options.topK]
+	}
+	r.results = results
+	return r, nil
+}
+
 // queryOptions holds resolved options for a query
 type queryOptions struct {
 	topK         int
@@ -503,6 +945,13 @@ type queryOptions struct {
 	temperature  float32
 	maxTokens    int
 	model        string
+	language     string // ISO 639-1 answer language; empty for the model's default
+	guardrails   guardrail.Pipeline
+	agentic      bool                        // let the LLM make retrieval tool calls before answering
+	tools        []repository.ToolDefinition // the tenant's tools the LLM may call instead
+	strategy     string                      // how chunks are retrieved; one of the strategy constants
+
+	contextExpansion contextExpansion
 }
 
 // buildQueryOptions builds query options from tenant config and request options
@@ -511,7 +960,7 @@ func (s *RAGService) buildQueryOptions(tenant *repository.Tenant, opts *ragv1.Qu
 		topK:         tenant.Config.TopK,
 		minScore:     tenant.Config.MinScore,
 		systemPrompt: tenant.Config.SystemPrompt,
-		temperature:  0.3, // Low temperature for factual, deterministic RAG responses
+		temperature:  0.3,  // Low temperature for factual, deterministic RAG responses
 		maxTokens:    2048, // Default max tokens
 		model:        tenant.Config.LLMModel,
 	}
@@ -544,6 +993,17 @@ func (s *RAGService) buildQueryOptions(tenant *repository.Tenant, opts *ragv1.Qu
 		if opts.MaxTokens > 0 {
 			options.maxTokens = int(opts.MaxTokens)
 		}
+		if opts.ContextExpansion != nil {
+			options.contextExpansion = resolveContextExpansion(opts.ContextExpansion)
+		}
+		options.agentic = opts.Agentic
+		options.strategy = opts.Strategy
+	}
+
+	// The LLM learns of the tenant's tools from the system prompt
+	if len(tenant.Config.Tools) > 0 {
+		options.tools = tenant.Config.Tools
+		options.systemPrompt += "\n\n" + toolInstructions(tenant.Config.Tools)
 	}
 
 	return options
@@ -558,105 +1018,200 @@ type chunkContext struct {
 	Metadata map[string]string
 }
 
-// buildRAGPrompt constructs the RAG prompt with metadata, conversation history, and chain-of-thought structure
-func (s *RAGService) buildRAGPrompt(systemPrompt string, chunks []chunkContext, query string, history []memory.Message) string {
-	var sb strings.Builder
+// modelNamer is implemented by LLM clients that report their default model
+type modelNamer interface {
+	ModelName() string
+}
 
-	// System instructions
-	sb.WriteString(systemPrompt)
-	sb.WriteString("\n\n")
+// promptBudget returns the room a query's prompt has: the smallest context
+// window among the model and its fallbacks, since any of them may answer,
+// less the completion and the system prompt sent beside the prompt
+func (s *RAGService) promptBudget(options queryOptions, fallbacks []string) prompt.Budget {
+	contextLength := s.modelInfo(options.model).ContextLength
+	for _, fallback := range fallbacks {
+		contextLength = min(contextLength, llm.GetModelInfo(fallback).ContextLength)
+	}
+	return prompt.Budget{
+		ContextLength: contextLength,
+		Reserved:      options.maxTokens + prompt.EstimateTokens(options.systemPrompt),
+	}
+}
 
-	// Conversation history (if any)
-	if len(history) > 0 {
-		sb.WriteString("## Conversation History\n")
-		sb.WriteString("(Previous exchanges in this session for context)\n\n")
-		sb.WriteString(memory.FormatForPrompt(history))
-		sb.WriteString("\n")
+// modelInfo returns what is known about a model, or about the LLM client's
+// default model when none is named
+func (s *RAGService) modelInfo(model string) llm.ModelInfo {
+	if model == "" {
+		if namer, ok := s.llmClient.(modelNamer); ok {
+			model = namer.ModelName()
+		}
 	}
+	return llm.GetModelInfo(model)
+}
 
-	// Context section with metadata (relevance scores omitted to avoid biasing LLM)
-	sb.WriteString("## Context Documents\n\n")
-	for i, chunk := range chunks {
-		sb.WriteString(fmt.Sprintf("[Doc %d]", i+1))
+// generationUsage is a generation's estimated token counts and price
+type generationUsage struct {
+	promptTokens     int
+	completionTokens int
+	cost             float64 // USD at the model's list price
+}
 
-		// Add metadata if available
-		if chunk.Title != "" {
-			sb.WriteString(fmt.Sprintf(" (Title: %s)", chunk.Title))
-		}
-		if chunk.Source != "" {
-			sb.WriteString(fmt.Sprintf(" (Source: %s)", chunk.Source))
-		}
-		sb.WriteString("\n")
-		sb.WriteString(chunk.Content)
-		sb.WriteString("\n\n")
+// estimateUsage estimates a generation's prompt tokens, counting the system
+// prompt sent beside the prompt, and completion tokens
+func (s *RAGService) estimateUsage(options queryOptions, fit prompt.Truncation, answer string) generationUsage {
+	return newUsage(s.modelInfo(options.model), fit.Tokens+prompt.EstimateTokens(options.systemPrompt), prompt.EstimateTokens(answer))
+}
+
+// newUsage prices a generation's token counts for a model
+func newUsage(info llm.ModelInfo, promptTokens, completionTokens int) generationUsage {
+	return generationUsage{
+		promptTokens:     promptTokens,
+		completionTokens: completionTokens,
+		cost:             info.Cost(promptTokens, completionTokens),
 	}
+}
 
-	// Question
-	sb.WriteString("## Question\n")
-	sb.WriteString(query)
-	sb.WriteString("\n\n")
+// plus returns the combined usage of two generations
+func (u generationUsage) plus(v generationUsage) generationUsage {
+	return generationUsage{
+		promptTokens:     u.promptTokens + v.promptTokens,
+		completionTokens: u.completionTokens + v.completionTokens,
+		cost:             u.cost + v.cost,
+	}
+}
 
-	// Direct answer prompt (no chain-of-thought to keep responses concise)
-	sb.WriteString("## Answer (be brief and direct)\n")
+// truncationToProto converts a prompt fit report
+func truncationToProto(t prompt.Truncation, budget prompt.Budget) *ragv1.PromptTruncation {
+	return &ragv1.PromptTruncation{
+		Truncated:              t.Truncated(),
+		ContextLength:          int32(budget.ContextLength),
+		ChunksDropped:          int32(t.ChunksDropped),
+		ChunksTruncated:        int32(t.ChunksTruncated),
+		HistoryMessagesDropped: int32(t.MessagesDropped),
+		SummaryDropped:         t.SummaryDropped,
+		Overflow:               t.Overflow,
+	}
+}
 
-	return sb.String()
+// buildRAGPrompt renders the RAG prompt from the tenant's template with the
+// system prompt, conversation history, context chunks and query, with
+// headings in the answer language, leaving content out to fit the budget
+func (s *RAGService) buildRAGPrompt(tmpl *prompt.Template, systemPrompt, language string, chunks []chunkContext, query string, history sessionHistory, budget prompt.Budget) (string, prompt.Truncation, error) {
+	data := prompt.Data{
+		SystemPrompt: systemPrompt,
+		Summary:      history.summary,
+		History:      memory.FormatForPrompt(history.messages),
+		Messages:     make([]prompt.Message, len(history.messages)),
+		Chunks:       make([]prompt.Chunk, len(chunks)),
+		Query:        query,
+		Language:     language,
+		Labels:       prompt.LabelsFor(language),
+	}
+	for i, msg := range history.messages {
+		data.Messages[i] = prompt.Message{Role: msg.Role, Content: msg.Content}
+	}
+	for i, chunk := range chunks {
+		data.Chunks[i] = prompt.Chunk{
+			Index:    i + 1,
+			Title:    chunk.Title,
+			Source:   chunk.Source,
+			Content:  chunk.Content,
+			Score:    chunk.Score,
+			Metadata: chunk.Metadata,
+		}
+	}
+
+	text, truncation, err := tmpl.Fit(data, budget)
+	if err != nil {
+		return "", truncation, status.Errorf(codes.Internal, "failed to render prompt template: %v", err)
+	}
+	return text, truncation, nil
 }
 
-// deduplicateResults removes chunks with highly similar content to reduce redundancy.
-// It uses Jaccard similarity on word sets with a threshold of 0.7 (70% overlap).
-func deduplicateResults(results []vectorstore.SearchResult, threshold float64) []vectorstore.SearchResult {
-	if len(results) <= 1 {
-		return results
+// searchError maps a vector search failure to a gRPC status. Callers treat a
+// missing collection as an empty result instead, so a tenant whose collection
+// was never created gets a no-context answer rather than an error.
+func searchError(err error, msg string) error {
+	if errors.Is(err, vectorstore.ErrUnavailable) {
+		return status.Errorf(codes.Unavailable, "%s: %v", msg, err)
 	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
 
-	// Build word sets for each result
-	wordSets := make([]map[string]struct{}, len(results))
-	for i, result := range results {
-		wordSets[i] = tokenize(result.Content)
+// generationError maps an LLM failure to a gRPC status, distinguishing
+// models that timed out
+func generationError(err error, msg string) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return apierr.ErrLLMTimeout.Wrap(err, msg)
 	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// deduplicateResults removes chunks with highly similar content to reduce redundancy,
+// compared as the tenant's duplicate_results config asks.
+func deduplicateResults(results []vectorstore.SearchResult, cfg repository.DuplicateResultConfig) []vectorstore.SearchResult {
+	return dropDuplicates(results, findDuplicates(results, cfg))
+}
+
+// duplicate records which earlier result a result repeats
+type duplicate struct {
+	of         int     // index of the kept result it repeats, or -1 when kept
+	similarity float64 // Jaccard or cosine similarity to that result
+}
 
-	// Keep track of which results to include
-	keep := make([]bool, len(results))
-	for i := range keep {
-		keep[i] = true
+// findDuplicates compares each result to the higher-ranked results kept
+// before it and marks it a duplicate of the first at or above the
+// threshold of cfg's similarity measure
+func findDuplicates(results []vectorstore.SearchResult, cfg repository.DuplicateResultConfig) []duplicate {
+	dups := make([]duplicate, len(results))
+	for i := range dups {
+		dups[i].of = -1
 	}
+	if len(results) <= 1 || cfg.Method == repository.DuplicateResultsOff {
+		return dups
+	}
+	similarityOf, threshold := duplicateMeasure(results, cfg)
 
 	// Compare each pair and mark duplicates (keep higher-scored one)
 	for i := 0; i < len(results); i++ {
-		if !keep[i] {
+		if dups[i].of >= 0 {
 			continue
 		}
 		for j := i + 1; j < len(results); j++ {
-			if !keep[j] {
+			if dups[j].of >= 0 {
 				continue
 			}
-			similarity := jaccardSimilarity(wordSets[i], wordSets[j])
+			similarity := similarityOf(i, j)
 			if similarity >= threshold {
-				// Keep the one with higher score (results are typically sorted by score descending)
-				// Since i < j and results are sorted, keep[i] stays true, mark j as duplicate
-				keep[j] = false
+				// Results are sorted by score descending, so i is kept and j
+				// is marked as its duplicate
+				dups[j] = duplicate{of: i, similarity: similarity}
 			}
 		}
 	}
 
-	// Build deduplicated result list
+	return dups
+}
+
+// dropDuplicates returns the results findDuplicates kept
+func dropDuplicates(results []vectorstore.SearchResult, dups []duplicate) []vectorstore.SearchResult {
 	deduplicated := make([]vectorstore.SearchResult, 0, len(results))
 	for i, result := range results {
-		if keep[i] {
+		if dups[i].of < 0 {
 			deduplicated = append(deduplicated, result)
 		}
 	}
-
 	return deduplicated
 }
 
-// tokenize converts content into a set of lowercase words for similarity comparison.
+// tokenize converts content into a set of lowercase words for similarity
+// comparison. CJK text is split into characters, see ingestion.Words.
 func tokenize(content string) map[string]struct{} {
-	words := strings.Fields(strings.ToLower(content))
+	words := ingestion.Words(strings.ToLower(content))
 	wordSet := make(map[string]struct{}, len(words))
 	for _, word := range words {
 		// Remove common punctuation
-		word = strings.Trim(word, ".,!?;:\"'()[]{}=<>")
+		word = strings.Trim(word, ".,!?;:\"'()[]{}=<>。、，！？；：「」『』（）")
 		if len(word) > 2 { // Skip very short tokens
 			wordSet[word] = struct{}{}
 		}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Reindex job statuses
+	reindexPending   = "PENDING"
+	reindexRunning   = "RUNNING"
+	reindexCompleted = "COMPLETED"
+	reindexFailed    = "FAILED"
+
+	// reindexBatchSize is the number of chunks embedded and upserted per step
+	reindexBatchSize = 64
+
+	// reindexDocPageSize is the page size used when listing documents to reindex
+	reindexDocPageSize = 100
+)
+
+// ReindexTenant re-embeds all stored chunks with a new embedding model in the background
+func (s *TenantService) ReindexTenant(ctx context.Context, req *ragv1.ReindexTenantRequest) (*ragv1.ReindexJob, error) {
+	if s.jobRepo == nil || s.docRepo == nil || s.embedders == nil {
+		return nil, status.Error(codes.Unimplemented, "reindexing is not enabled")
+	}
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid tenant ID format")
+	}
+
+	tenant, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+
+	// Only one reindex per tenant at a time
+	active, err := s.jobRepo.GetActive(ctx, id)
+	if err == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "reindex job %s is already in progress", active.ID)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Errorf(codes.Internal, "failed to check reindex jobs: %v", err)
+	}
+
+	model := req.EmbeddingModel
+	if model == "" {
+		model = tenant.Config.EmbeddingModel
+	}
+	emb := s.embedders.Get(model)
+
+	// Keep a truncated dimension across reindexes of the same model
+	dimension := int(req.EmbeddingDimension)
+	if dimension == 0 && model == tenant.Config.EmbeddingModel {
+		dimension = tenant.Config.EmbeddingDimension
+	}
+	if dimension < 0 {
+		return nil, status.Error(codes.InvalidArgument, "embedding_dimension cannot be negative")
+	}
+	if err := validateEmbeddingDimension(model, dimension); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	emb = embedder.Truncate(emb, dimension)
+
+	storage := tenant.Config.VectorStorage
+	if req.VectorStorage != nil {
+		storage = vectorStorageFromProto(req.VectorStorage)
+		if err := validateVectorStorage(storage); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	jobID := uuid.New()
+	job := &repository.ReindexJob{
+		ID:                jobID,
+		TenantID:          id,
+		Status:            reindexPending,
+		EmbeddingModel:    model,
+		Dimension:         emb.Dimension(),
+		CollectionVersion: jobID.String()[:8],
+		CreatedAt:         time.Now(),
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		// A concurrent call started one since the check above
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, status.Error(codes.FailedPrecondition, "a reindex job is already in progress")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create reindex job: %v", err)
+	}
+
+	go s.runReindex(context.Background(), job, emb, storage)
+
+	return reindexJobToProto(job), nil
+}
+
+// GetReindexJob retrieves the progress of a reindex job
+func (s *TenantService) GetReindexJob(ctx context.Context, req *ragv1.GetReindexJobRequest) (*ragv1.ReindexJob, error) {
+	if s.jobRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "reindexing is not enabled")
+	}
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid job_id format")
+	}
+
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "reindex job not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get reindex job: %v", err)
+	}
+	if job.TenantID != tenantID {
+		return nil, status.Error(codes.NotFound, "reindex job not found")
+	}
+
+	return reindexJobToProto(job), nil
+}
+
+// runReindex builds a new collection version from stored chunks and switches the tenant to it
+func (s *TenantService) runReindex(ctx context.Context, job *repository.ReindexJob, emb embedder.Embedder, storage repository.VectorStorageConfig) {
+	now := time.Now()
+	job.Status = reindexRunning
+	job.StartedAt = &now
+	s.saveReindexJob(ctx, job)
+
+	tenantID := job.TenantID.String()
+	if err := s.vectorStore.CreateCollectionVersion(ctx, tenantID, job.CollectionVersion, job.Dimension, storageConfig(storage)); err != nil {
+		s.failReindex(ctx, job, fmt.Sprintf("failed to create collection: %v", err))
+		return
+	}
+
+	// Documents ingested, re-ingested or deleted while the job runs change
+	// the current collection, so keep passing over the document list until
+	// the version has caught up with it
+	indexed := make(map[uuid.UUID]time.Time)
+	if err := s.catchUpReindex(ctx, job, emb, indexed, job.CollectionVersion); err != nil {
+		s.abortReindex(ctx, job, err.Error())
+		return
+	}
+
+	if err := s.vectorStore.SwitchCollectionVersion(ctx, tenantID, job.CollectionVersion); err != nil {
+		s.abortReindex(ctx, job, fmt.Sprintf("failed to switch collection: %v", err))
+		return
+	}
+
+	// Point ingestion and queries at the new model
+	tenant, err := s.repo.GetByID(ctx, job.TenantID)
+	if err != nil {
+		s.failReindex(ctx, job, fmt.Sprintf("collection switched but failed to load tenant: %v", err))
+		return
+	}
+	tenant.Config.EmbeddingModel = job.EmbeddingModel
+	tenant.Config.EmbeddingDimension = job.Dimension
+	tenant.Config.VectorStorage = storage
+	tenant.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, tenant); err != nil {
+		s.failReindex(ctx, job, fmt.Sprintf("collection switched but failed to update tenant: %v", err))
+		return
+	}
+	// Keyword search parses queries with the tenant's current text analysis
+	if err := s.docRepo.SetSearchConfig(ctx, job.TenantID, textSearchConfig(tenant.Config.TextAnalysis)); err != nil {
+		s.failReindex(ctx, job, fmt.Sprintf("collection switched but failed to re-index keyword search: %v", err))
+		return
+	}
+
+	// Changes since the last pass went to the previous collection, or to this
+	// one with the previous model; redo them in the live collection
+	if err := s.catchUpReindex(ctx, job, emb, indexed, ""); err != nil {
+		s.failReindex(ctx, job, fmt.Sprintf("collection switched but failed to catch up: %v", err))
+		return
+	}
+
+	completed := time.Now()
+	job.Status = reindexCompleted
+	job.CompletedAt = &completed
+	s.saveReindexJob(ctx, job)
+}
+
+// catchUpReindex indexes the tenant's ready documents that are not in
+// indexed, or have changed since, into a collection version, or the live
+// collection when version is empty, and removes the documents no longer
+// ready, until a pass finds nothing to do
+func (s *TenantService) catchUpReindex(ctx context.Context, job *repository.ReindexJob, emb embedder.Embedder, indexed map[uuid.UUID]time.Time, version string) error {
+	tenantID := job.TenantID.String()
+	for {
+		docs, err := s.listReadyDocuments(ctx, job.TenantID)
+		if err != nil {
+			return err
+		}
+
+		ready := make(map[uuid.UUID]bool, len(docs))
+		var pending []*repository.Document
+		for _, doc := range docs {
+			ready[doc.ID] = true
+			if at, ok := indexed[doc.ID]; !ok || !at.Equal(doc.UpdatedAt) {
+				pending = append(pending, doc)
+				job.ChunksTotal += doc.ChunkCount
+			}
+		}
+		var removed []uuid.UUID
+		for id := range indexed {
+			if !ready[id] {
+				removed = append(removed, id)
+			}
+		}
+		if len(pending) == 0 && len(removed) == 0 {
+			return nil
+		}
+		s.saveReindexJob(ctx, job)
+
+		for _, id := range removed {
+			// Live vectors are only removed once the document is deleted,
+			// since an ingestion in progress may have just stored them
+			if version == "" {
+				if _, err := s.docRepo.GetByID(ctx, id); !errors.Is(err, repository.ErrNotFound) {
+					if err != nil {
+						return fmt.Errorf("document %s: failed to get document: %w", id, err)
+					}
+					delete(indexed, id)
+					continue
+				}
+			}
+			if err := s.removeReindexed(ctx, tenantID, version, id); err != nil {
+				return fmt.Errorf("document %s: %w", id, err)
+			}
+			delete(indexed, id)
+		}
+		for _, doc := range pending {
+			if _, ok := indexed[doc.ID]; ok {
+				// Re-ingested documents have new chunks
+				if err := s.removeReindexed(ctx, tenantID, version, doc.ID); err != nil {
+					return fmt.Errorf("document %s: %w", doc.ID, err)
+				}
+			}
+			if err := s.reindexDocument(ctx, job, doc, emb, version); err != nil {
+				return fmt.Errorf("document %s: %w", doc.ID, err)
+			}
+			indexed[doc.ID] = doc.UpdatedAt
+		}
+	}
+}
+
+// removeReindexed removes a document's vectors from a collection version,
+// or from the live collection when version is empty
+func (s *TenantService) removeReindexed(ctx context.Context, tenantID, version string, documentID uuid.UUID) error {
+	if version != "" {
+		return s.vectorStore.DeleteVersionDocument(ctx, tenantID, version, documentID.String())
+	}
+	err := s.vectorStore.Delete(ctx, tenantID, documentID.String())
+	if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+		return fmt.Errorf("failed to delete vectors: %w", err)
+	}
+	return nil
+}
+
+// reindexDocument re-embeds one document's chunks and summary tree nodes
+// into a collection version, or into the live collection when version is empty
+func (s *TenantService) reindexDocument(ctx context.Context, job *repository.ReindexJob, doc *repository.Document, emb embedder.Embedder, version string) error {
+	if err := s.reindexChunks(ctx, job, doc, emb, version); err != nil {
+		return err
+	}
+
+	summaries, err := s.docRepo.GetSummaries(ctx, doc.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load summaries: %w", err)
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	contents := make([]string, len(summaries))
+	for i, summary := range summaries {
+		contents[i] = summary.Content
+	}
+	embeddings, err := embedder.EmbedAll(ctx, emb, contents, embedRetryRounds)
+	if err != nil {
+		return fmt.Errorf("embedding summaries failed: %w", err)
+	}
+	return s.storeReindexed(ctx, job, version, buildSummaryChunks(doc, summaries, embeddings))
+}
+
+// storeReindexed stores reindexed chunks in a collection version, or in the
+// live collection when version is empty
+func (s *TenantService) storeReindexed(ctx context.Context, job *repository.ReindexJob, version string, vectorChunks []vectorstore.Chunk) error {
+	var err error
+	if version == "" {
+		err = s.vectorStore.Upsert(ctx, job.TenantID.String(), vectorChunks)
+	} else {
+		err = s.vectorStore.UpsertVersion(ctx, job.TenantID.String(), version, vectorChunks)
+	}
+	if err != nil {
+		return fmt.Errorf("vector storage failed: %w", err)
+	}
+	return nil
+}
+
+// reindexChunks re-embeds one document's chunks
+func (s *TenantService) reindexChunks(ctx context.Context, job *repository.ReindexJob, doc *repository.Document, emb embedder.Embedder, version string) error {
+	for offset := 0; ; offset += reindexBatchSize {
+		chunks, err := s.docRepo.GetChunks(ctx, doc.ID, reindexBatchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to load chunks: %w", err)
+		}
+		if len(chunks) == 0 {
+			return nil
+		}
+
+		// Chunks sharing another's vector get it when their owner is reindexed
+		if own := ownVectors(chunks); len(own) > 0 {
+			contents := make([]string, len(own))
+			for i, chunk := range own {
+				contents[i] = chunk.Content
+			}
+
+			embeddings, err := embedder.EmbedAll(ctx, emb, contents, embedRetryRounds)
+			if err != nil {
+				return fmt.Errorf("embedding failed: %w", err)
+			}
+
+			if err := s.storeReindexed(ctx, job, version, buildVectorChunks(doc, own, embeddings)); err != nil {
+				return err
+			}
+		}
+
+		job.ChunksDone += len(chunks)
+		s.saveReindexJob(ctx, job)
+
+		if len(chunks) < reindexBatchSize {
+			return nil
+		}
+	}
+}
+
+// listReadyDocuments returns all of a tenant's successfully ingested documents
+func (s *TenantService) listReadyDocuments(ctx context.Context, tenantID uuid.UUID) ([]*repository.Document, error) {
+	var docs []*repository.Document
+	for offset := 0; ; offset += reindexDocPageSize {
+		page, total, err := s.docRepo.List(ctx, tenantID, repository.DocumentFilter{Status: "READY"}, reindexDocPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list documents: %w", err)
+		}
+		docs = append(docs, page...)
+		if len(page) == 0 || offset+len(page) >= total {
+			return docs, nil
+		}
+	}
+}
+
+// abortReindex drops the partially built collection and marks the job failed
+func (s *TenantService) abortReindex(ctx context.Context, job *repository.ReindexJob, errorMsg string) {
+	if err := s.vectorStore.DeleteCollectionVersion(ctx, job.TenantID.String(), job.CollectionVersion); err != nil {
+		slog.ErrorContext(ctx, "failed to delete reindex collection version", "job_id", job.ID, "version", job.CollectionVersion, "error", err)
+	}
+	s.failReindex(ctx, job, errorMsg)
+}
+
+// failReindex marks a reindex job as failed
+func (s *TenantService) failReindex(ctx context.Context, job *repository.ReindexJob, errorMsg string) {
+	completed := time.Now()
+	job.Status = reindexFailed
+	job.ErrorMessage = errorMsg
+	job.CompletedAt = &completed
+	s.saveReindexJob(ctx, job)
+}
+
+// saveReindexJob stores a job's progress. The job carries on when the save
+// fails, with its last saved progress reported until a later save succeeds.
+func (s *TenantService) saveReindexJob(ctx context.Context, job *repository.ReindexJob) {
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "failed to save reindex job", "job_id", job.ID, "status", job.Status, "error", err)
+	}
+}
+
+// reindexJobToProto converts a repository ReindexJob to proto ReindexJob
+func reindexJobToProto(job *repository.ReindexJob) *ragv1.ReindexJob {
+	pb := &ragv1.ReindexJob{
+		Id:             job.ID.String(),
+		TenantId:       job.TenantID.String(),
+		Status:         convertReindexStatus(job.Status),
+		EmbeddingModel: job.EmbeddingModel,
+		Dimension:      int32(job.Dimension),
+		ChunksTotal:    int32(job.ChunksTotal),
+		ChunksDone:     int32(job.ChunksDone),
+		ErrorMessage:   job.ErrorMessage,
+		CreatedAt:      timestamppb.New(job.CreatedAt),
+	}
+	if job.StartedAt != nil {
+		pb.StartedAt = timestamppb.New(*job.StartedAt)
+	}
+	if job.CompletedAt != nil {
+		pb.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+	return pb
+}
+
+// convertReindexStatus converts a reindex job status string to proto enum
+func convertReindexStatus(status string) ragv1.ReindexStatus {
+	switch status {
+	case reindexPending:
+		return ragv1.ReindexStatus_REINDEX_STATUS_PENDING
+	case reindexRunning:
+		return ragv1.ReindexStatus_REINDEX_STATUS_RUNNING
+	case reindexCompleted:
+		return ragv1.ReindexStatus_REINDEX_STATUS_COMPLETED
+	case reindexFailed:
+		return ragv1.ReindexStatus_REINDEX_STATUS_FAILED
+	default:
+		return ragv1.ReindexStatus_REINDEX_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/reranker"
+)
+
+// rerankCalibration returns the calibration a tenant's rerank config asks for
+func rerankCalibration(cfg repository.RerankConfig) reranker.Calibration {
+	return reranker.Calibration{
+		Method: cfg.Calibration,
+		Center: cfg.SigmoidCenter,
+		Scale:  cfg.SigmoidScale,
+	}
+}
+
+// validateRerank checks a tenant's rerank settings
+func validateRerank(cfg repository.RerankConfig) error {
+	switch cfg.Provider {
+	case "", reranker.ProviderLLM, reranker.ProviderCohere:
+	default:
+		return fmt.Errorf("rerank provider must be empty, %q or %q", reranker.ProviderLLM, reranker.ProviderCohere)
+	}
+	switch cfg.Calibration {
+	case reranker.CalibrationNone, reranker.CalibrationMinMax, reranker.CalibrationSigmoid:
+	default:
+		return fmt.Errorf("rerank calibration must be empty, %q or %q", reranker.CalibrationMinMax, reranker.CalibrationSigmoid)
+	}
+	if cfg.SigmoidScale < 0 {
+		return fmt.Errorf("rerank sigmoid_scale cannot be negative")
+	}
+	if cfg.Threshold < 0 || cfg.Threshold > 1 {
+		return fmt.Errorf("rerank threshold must be between 0 and 1")
+	}
+	return nil
+}
+
+// rerankFromProto converts a proto RerankConfig
+func rerankFromProto(p *ragv1.RerankConfig) repository.RerankConfig {
+	return repository.RerankConfig{
+		Provider:      p.Provider,
+		Calibration:   p.Calibration,
+		SigmoidCenter: float64(p.SigmoidCenter),
+		SigmoidScale:  float64(p.SigmoidScale),
+		Threshold:     float64(p.Threshold),
+	}
+}
+
+// rerankToProto converts a repository RerankConfig to proto RerankConfig
+func rerankToProto(c repository.RerankConfig) *ragv1.RerankConfig {
+	return &ragv1.RerankConfig{
+		Provider:      c.Provider,
+		Calibration:   c.Calibration,
+		SigmoidCenter: float32(c.SigmoidCenter),
+		SigmoidScale:  float32(c.SigmoidScale),
+		Threshold:     float32(c.Threshold),
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rrfK dampens the advantage of top ranks in reciprocal rank fusion; 60 is
+// the constant from the original paper and what Qdrant uses
+const rrfK = 60
+
+// retrieve finds chunks in the requested mode and returns the mode used.
+// Vector and hybrid retrieval fall back to keyword search when the vector
+// store is unavailable, so retrieval keeps working while Qdrant is down.
+func (s *RAGService) retrieve(ctx context.Context, tenant *repository.Tenant, query string, mode ragv1.RetrievalMode, topK int, minScore float32, filter vectorstore.Filter) ([]vectorstore.SearchResult, ragv1.RetrievalMode, error) {
+	if mode == ragv1.RetrievalMode_RETRIEVAL_MODE_KEYWORD {
+		results, err := s.keywordSearch(ctx, tenant, query, topK, filter)
+		return results, mode, err
+	}
+
+	var results []vectorstore.SearchResult
+	var err error
+	if mode == ragv1.RetrievalMode_RETRIEVAL_MODE_HYBRID {
+		results, err = s.hybridRetrieve(ctx, tenant, query, topK, minScore, filter)
+	} else {
+		mode = ragv1.RetrievalMode_RETRIEVAL_MODE_VECTOR
+		results, err = s.vectorSearch(ctx, tenant, query, topK, minScore, filter)
+	}
+	if errors.Is(err, vectorstore.ErrUnavailable) {
+		slog.WarnContext(ctx, "vector store unavailable, falling back to keyword search", "tenant_id", tenant.ID, "error", err)
+		results, err = s.keywordSearch(ctx, tenant, query, topK, filter)
+		return results, ragv1.RetrievalMode_RETRIEVAL_MODE_KEYWORD, err
+	}
+	if err != nil {
+		return nil, mode, searchError(err, "failed to search vectors")
+	}
+	return results, mode, nil
+}
+
+// hybridRetrieve uses the vector store's sparse vectors when configured and
+// otherwise fuses dense results with Postgres full-text matches
+func (s *RAGService) hybridRetrieve(ctx context.Context, tenant *repository.Tenant, query string, topK int, minScore float32, filter vectorstore.Filter) ([]vectorstore.SearchResult, error) {
+	if s.useHybrid && s.sparseModel != nil {
+		queryVector, err := s.embedderFor(tenant).EmbedQuery(ctx, query)
+		if err != nil {
+			return nil, apierr.ErrEmbeddingFailed.Wrap(err, "failed to embed query")
+		}
+		results, err := s.vectorDB.HybridSearch(ctx, tenant.ID.String(), queryVector, s.sparseVectorizer(tenant).Vectorize(s.sparseQuery(ctx, tenant, query)), topK, minScore, vectorstore.SearchOptions{Filter: filter})
+		if errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return nil, nil
+		}
+		return results, err
+	}
+
+	dense, err := s.vectorSearch(ctx, tenant, query, topK, minScore, filter)
+	if err != nil {
+		return nil, err
+	}
+	keyword, err := s.keywordSearch(ctx, tenant, query, topK, filter)
+	if err != nil {
+		// Dense results alone are still a useful answer
+		slog.WarnContext(ctx, "keyword search failed, using vector results only", "tenant_id", tenant.ID, "error", err)
+		return dense, nil
+	}
+	return fuseResults(topK, dense, keyword), nil
+}
+
+// vectorSearch embeds the query and searches the tenant's collection. A
+// missing collection is an empty result.
+func (s *RAGService) vectorSearch(ctx context.Context, tenant *repository.Tenant, query string, topK int, minScore float32, filter vectorstore.Filter) ([]vectorstore.SearchResult, error) {
+	queryVector, err := s.embedderFor(tenant).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, apierr.ErrEmbeddingFailed.Wrap(err, "failed to embed query")
+	}
+	results, err := s.vectorDB.Search(ctx, tenant.ID.String(), queryVector, topK, minScore, vectorstore.SearchOptions{Filter: filter})
+	if errors.Is(err, vectorstore.ErrCollectionNotFound) {
+		return nil, nil
+	}
+	return results, err
+}
+
+// keywordSearch runs a Postgres full-text search expanded with the tenant's
+// synonyms, shaping matches like vector store results so the rest of
+// retrieval treats them the same
+func (s *RAGService) keywordSearch(ctx context.Context, tenant *repository.Tenant, query string, topK int, filter vectorstore.Filter) ([]vectorstore.SearchResult, error) {
+	chunkFilter := repository.ChunkFilter{Tags: filter.Tags, Languages: filter.Languages}
+	for _, id := range filter.CollectionIDs {
+		// searchFilter has already validated the IDs
+		chunkFilter.CollectionIDs = append(chunkFilter.CollectionIDs, uuid.MustParse(id))
+	}
+	if filter.ProjectID != "" {
+		chunkFilter.ProjectID = uuid.MustParse(filter.ProjectID)
+	}
+	matches, err := s.docRepo.SearchChunks(ctx, tenant.ID, s.keywordQuery(ctx, tenant, query), textSearchConfig(tenant.Config.TextAnalysis), chunkFilter, topK)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search chunks: %v", err)
+	}
+
+	results := make([]vectorstore.SearchResult, len(matches))
+	for i, m := range matches {
+		results[i] = vectorstore.SearchResult{
+			ID:         m.Chunk.ID.String(),
+			DocumentID: m.Chunk.DocumentID.String(),
+			Content:    m.Chunk.Content,
+			Score:      m.Rank,
+			Metadata:   chunkMetadata(m.Chunk, m.Title, m.Source),
+		}
+	}
+	return results, nil
+}
+
+// searchFilter builds the search filter for a request's project, tags,
+// collections and languages
+func searchFilter(project *repository.Project, tags, collectionIDs, languages []string) (vectorstore.Filter, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return vectorstore.Filter{}, err
+	}
+	filter := vectorstore.Filter{Tags: tags, ProjectID: projectIDString(projectID(project))}
+	for _, raw := range collectionIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return filter, status.Errorf(codes.InvalidArgument, "invalid collection ID %q", raw)
+		}
+		filter.CollectionIDs = append(filter.CollectionIDs, id.String())
+	}
+	for _, raw := range languages {
+		lang := strings.ToLower(strings.TrimSpace(raw))
+		if !validLanguageCode(lang) {
+			return filter, status.Errorf(codes.InvalidArgument, "invalid language %q: must be an ISO 639-1 code", raw)
+		}
+		if !slices.Contains(filter.Languages, lang) {
+			filter.Languages = append(filter.Languages, lang)
+		}
+	}
+	return filter, nil
+}
+
+// validLanguageCode reports whether code is two lowercase letters, the form
+// of an ISO 639-1 code
+func validLanguageCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'a' && code[0] <= 'z' && code[1] >= 'a' && code[1] <= 'z'
+}
+
+// fuseResults merges ranked lists by reciprocal rank fusion. A chunk's score
+// is the sum of 1/(rrfK+rank) over the lists it appears in.
+func fuseResults(topK int, lists ...[]vectorstore.SearchResult) []vectorstore.SearchResult {
+	scores := make(map[string]float32)
+	byID := make(map[string]vectorstore.SearchResult)
+	var order []string
+	for _, list := range lists {
+		for rank, result := range list {
+			if _, seen := byID[result.ID]; !seen {
+				byID[result.ID] = result
+				order = append(order, result.ID)
+			}
+			scores[result.ID] += 1 / float32(rrfK+rank+1)
+		}
+	}
+
+	// Stable, so ties keep the order of the first list
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+	if len(order) > topK {
+		order = order[:topK]
+	}
+
+	fused := make([]vectorstore.SearchResult, len(order))
+	for i, id := range order {
+		fused[i] = byID[id]
+		fused[i].Score = scores[id]
+	}
+	return fused
+}
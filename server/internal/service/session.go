@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/memory"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSessionIDLength bounds client-chosen session IDs
+const maxSessionIDLength = 128
+
+// SessionService implements ragv1.SessionServiceServer
+type SessionService struct {
+	ragv1.UnimplementedSessionServiceServer
+
+	memory     *memory.Store
+	tenantRepo repository.TenantRepository
+}
+
+// NewSessionService creates a new SessionService over the conversation
+// store RAGService queries use
+func NewSessionService(store *memory.Store, tenantRepo repository.TenantRepository) *SessionService {
+	return &SessionService{
+		memory:     store,
+		tenantRepo: tenantRepo,
+	}
+}
+
+// CreateSession starts an empty session
+func (s *SessionService) CreateSession(ctx context.Context, req *ragv1.CreateSessionRequest) (*ragv1.Session, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.SessionId) > maxSessionIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "session_id cannot exceed %d characters", maxSessionIDLength)
+	}
+
+	if _, err := s.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+
+	sessionID := req.SessionId
+	if sessionID == "" {
+		sessionID = uuid.NewString()
+	}
+	conv, created := s.memory.Create(tenantID.String(), sessionID)
+	if !created {
+		return nil, status.Error(codes.AlreadyExists, "session already exists")
+	}
+	return s.sessionToProto(conv), nil
+}
+
+// GetSessionHistory returns a session and its messages, oldest first
+func (s *SessionService) GetSessionHistory(ctx context.Context, req *ragv1.GetSessionHistoryRequest) (*ragv1.SessionHistory, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	conv, ok := s.memory.Get(tenantID.String(), req.SessionId)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "session not found or expired")
+	}
+
+	messages := make([]*ragv1.SessionMessage, len(conv.Messages))
+	for i, msg := range conv.Messages {
+		messages[i] = &ragv1.SessionMessage{
+			Role:      msg.Role,
+			Content:   msg.Content,
+			CreatedAt: timestamppb.New(msg.Timestamp),
+		}
+	}
+	return &ragv1.SessionHistory{
+		Session:  s.sessionToProto(conv),
+		Messages: messages,
+		Summary:  conv.Summary,
+	}, nil
+}
+
+// ClearSession deletes a session and its messages
+func (s *SessionService) ClearSession(ctx context.Context, req *ragv1.ClearSessionRequest) (*ragv1.ClearSessionResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	if !s.memory.ClearSession(tenantID.String(), req.SessionId) {
+		return nil, status.Error(codes.NotFound, "session not found or expired")
+	}
+	return &ragv1.ClearSessionResponse{Success: true}, nil
+}
+
+// ListSessions lists a tenant's live sessions, most recently active first
+func (s *SessionService) ListSessions(ctx context.Context, req *ragv1.ListSessionsRequest) (*ragv1.ListSessionsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	convs := s.memory.List(tenantID.String())
+	total := len(convs)
+	page := convs[min(offset, total):min(offset+pageSize, total)]
+
+	sessions := make([]*ragv1.Session, len(page))
+	for i, conv := range page {
+		sessions[i] = s.sessionToProto(conv)
+	}
+
+	var nextPageToken string
+	if offset+len(page) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(page))
+	}
+
+	return &ragv1.ListSessionsResponse{
+		Sessions:      sessions,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(total),
+	}, nil
+}
+
+// sessionToProto converts a memory Conversation to proto Session
+func (s *SessionService) sessionToProto(conv memory.Conversation) *ragv1.Session {
+	return &ragv1.Session{
+		Id:           conv.ID,
+		TenantId:     conv.TenantID,
+		MessageCount: int32(len(conv.Messages)),
+		CreatedAt:    timestamppb.New(conv.CreatedAt),
+		UpdatedAt:    timestamppb.New(conv.UpdatedAt),
+		ExpiresAt:    timestamppb.New(conv.UpdatedAt.Add(s.memory.TTL())),
+	}
+}
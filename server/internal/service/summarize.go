@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/prompt"
+	"github.com/knoguchi/rag/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxSelectedDocuments caps the documents one Summarize or
+	// GenerateQuestions call covers
+	maxSelectedDocuments = 50
+
+	// maxSelectedChunks caps their chunks, keeping a call to a few hundred
+	// LLM calls at most
+	maxSelectedChunks = 2000
+
+	// documentLLMConcurrency is how many LLM calls Summarize and
+	// GenerateQuestions make at once
+	documentLLMConcurrency = 4
+
+	// maxSummaryPartTokens caps the text summarized in one LLM call, which
+	// models summarize better than they do a full context window
+	maxSummaryPartTokens = 6000
+
+	// partSummaryTokens bounds the summary of one part
+	partSummaryTokens = 400
+
+	// summarizeChunkPage is how many chunks are read from the database at a time
+	summarizeChunkPage = 500
+)
+
+// summaryPartSystemPrompt instructs the LLM that summarizes parts of documents
+const summaryPartSystemPrompt = `You summarize parts of documents so the summaries can be combined into one summary of the whole.
+
+Keep facts, names, numbers, dates and conclusions; leave out filler and repetition. Do not add anything the text does not say. Output only the summary of at most 200 words.`
+
+// documentSummarySystemPrompt instructs the LLM that writes the final summary
+// of documents
+const documentSummarySystemPrompt = `You write summaries of documents for readers who have not read them.
+
+Cover the main points in proportion to their weight in the documents. Do not add anything the text does not say. Output only the summary.`
+
+// summaryStyles holds the instruction of each summary style
+var summaryStyles = map[string]string{
+	"paragraph": "Write a summary of one to three paragraphs.",
+	"bullets":   "Write the summary as a bulleted list of the key points, one line each.",
+	"brief":     "Write a summary of one or two sentences.",
+	"detailed":  "Write a detailed summary organized under short headings, covering every main point.",
+}
+
+// summaryPart is text summarized in one LLM call: chunks of one document,
+// or summaries of several parts
+type summaryPart struct {
+	title string
+	text  string
+}
+
+// Summarize map-reduces documents through the LLM: their chunks are grouped
+// into parts, each part summarized, and the summaries summarized again until
+// they fit one prompt, from which the final summary is streamed
+func (s *RAGService) Summarize(req *ragv1.SummarizeRequest, stream grpc.ServerStreamingServer[ragv1.SummarizeResponse]) error {
+	ctx := stream.Context()
+	startTime := time.Now()
+
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return err
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "tenant not found: %v", err)
+	}
+
+	style := req.Style
+	if style == "" {
+		style = "paragraph"
+	}
+	instruction, ok := summaryStyles[style]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown style %q: must be paragraph, bullets, brief or detailed", req.Style)
+	}
+
+	docs, err := s.selectDocuments(ctx, tenant, req.DocumentIds, req.Tags, req.CollectionId)
+	if err != nil {
+		return err
+	}
+
+	// Split each document's chunks into parts that fit one prompt
+	budget := s.promptBudget(queryOptions{model: tenant.Config.LLMModel, maxTokens: partSummaryTokens, systemPrompt: summaryPartSystemPrompt}, tenant.Config.LLMFallbackModels)
+	partTokens := maxSummaryPartTokens
+	if budget.ContextLength > 0 {
+		partTokens = max(min(budget.ContextLength-budget.Reserved-200, partTokens), 500)
+	}
+	var parts []summaryPart
+	var sample strings.Builder
+	chunkCount := 0
+	for _, doc := range docs {
+		chunks, err := s.documentChunks(ctx, doc.ID)
+		if err != nil {
+			return err
+		}
+		chunkCount += len(chunks)
+		if chunkCount > maxSelectedChunks {
+			return status.Errorf(codes.InvalidArgument, "the documents have over %d chunks; select fewer at a time", maxSelectedChunks)
+		}
+		for _, chunk := range chunks {
+			if sample.Len() < 2000 {
+				sample.WriteString(chunk.Content)
+				sample.WriteString("\n")
+			}
+		}
+		parts = append(parts, documentParts(doc, chunks, partTokens)...)
+	}
+	if len(parts) == 0 {
+		return status.Error(codes.FailedPrecondition, "the documents have no content to summarize")
+	}
+
+	lang, err := queryLanguage(tenant, req.Language, sample.String())
+	if err != nil {
+		return err
+	}
+
+	opts := llm.GenerateOptions{
+		Model:        tenant.Config.LLMModel,
+		Fallbacks:    tenant.Config.LLMFallbackModels,
+		SystemPrompt: summaryPartSystemPrompt,
+		Temperature:  0.1,
+		MaxTokens:    partSummaryTokens,
+	}
+	calls := 0
+
+	// Map, then reduce while the summaries are too long for one prompt. A
+	// single part that fits is summarized directly by the final prompt.
+	stage := ragv1.SummarizeStage_SUMMARIZE_STAGE_MAP
+	for len(parts) > 1 || (stage == ragv1.SummarizeStage_SUMMARIZE_STAGE_MAP && prompt.EstimateTokens(parts[0].text) > partTokens) {
+		summaries, err := s.summarizeParts(ctx, stream, stage, parts, opts)
+		calls += len(parts)
+		if err != nil {
+			return sendSummarizeError(stream, err)
+		}
+		parts = combineSummaries(summaries, partTokens)
+		stage = ragv1.SummarizeStage_SUMMARIZE_STAGE_REDUCE
+	}
+
+	if err := sendSummarizeProgress(stream, ragv1.SummarizeStage_SUMMARIZE_STAGE_FINAL, 0, 1); err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(parts[0].text)
+	b.WriteString("\n\n")
+	b.WriteString(instruction)
+	if len(docs) > 1 {
+		b.WriteString(" The text covers several documents; summarize them together, noting where they differ.")
+	}
+	if labels := prompt.LabelsFor(lang); labels.Instruction != "" {
+		b.WriteString(" ")
+		b.WriteString(labels.Instruction)
+	}
+
+	opts.SystemPrompt = documentSummarySystemPrompt
+	opts.MaxTokens = 2048
+	tokenChan, err := s.llmClient.GenerateStream(ctx, b.String(), opts)
+	if err != nil {
+		return generationError(err, "failed to start streaming")
+	}
+	calls++
+	for chunk := range tokenChan {
+		if chunk.Error != nil {
+			return sendSummarizeError(stream, chunk.Error)
+		}
+		if chunk.Token != "" {
+			if err := stream.Send(&ragv1.SummarizeResponse{
+				Event: &ragv1.SummarizeResponse_Token{Token: chunk.Token},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	docIDs := make([]string, len(docs))
+	for i, doc := range docs {
+		docIDs[i] = doc.ID.String()
+	}
+	return stream.Send(&ragv1.SummarizeResponse{
+		Event: &ragv1.SummarizeResponse_Metadata{
+			Metadata: &ragv1.SummarizeMetadata{
+				DocumentIds: docIDs,
+				ChunkCount:  int32(chunkCount),
+				LlmCalls:    int32(calls),
+				Model:       tenant.Config.LLMModel,
+				Language:    lang,
+				TotalTimeMs: time.Since(startTime).Milliseconds(),
+			},
+		},
+	})
+}
+
+// selectDocuments returns the ready documents a request names, or else those
+// matching its tags and collection
+func (s *RAGService) selectDocuments(ctx context.Context, tenant *repository.Tenant, documentIDs, tags []string, collectionID string) ([]*repository.Document, error) {
+	if len(documentIDs) > 0 {
+		if len(documentIDs) > maxSelectedDocuments {
+			return nil, status.Errorf(codes.InvalidArgument, "at most %d documents can be used at once", maxSelectedDocuments)
+		}
+		docs := make([]*repository.Document, 0, len(documentIDs))
+		for _, raw := range documentIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid document ID %q", raw)
+			}
+			doc, err := s.docRepo.GetByID(ctx, id)
+			if err != nil || doc.TenantID != tenant.ID || !canAccessDocument(ctx, doc) {
+				return nil, status.Errorf(codes.NotFound, "document not found: %s", raw)
+			}
+			if doc.Status != "READY" {
+				return nil, status.Errorf(codes.FailedPrecondition, "document %s is %s, not READY", raw, doc.Status)
+			}
+			docs = append(docs, doc)
+		}
+		return docs, nil
+	}
+
+	if len(tags) == 0 && collectionID == "" {
+		return nil, status.Error(codes.InvalidArgument, "document_ids, tags or collection_id is required")
+	}
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.DocumentFilter{Status: "READY", Tags: tags}
+	if bound := credentialProject(ctx); bound != nil {
+		filter.ProjectID = *bound
+	}
+	if collectionID != "" {
+		if filter.CollectionID, err = uuid.Parse(collectionID); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid collection ID %q", collectionID)
+		}
+	}
+	docs, total, err := s.docRepo.List(ctx, tenant.ID, filter, maxSelectedDocuments, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list documents: %v", err)
+	}
+	if total > maxSelectedDocuments {
+		return nil, status.Errorf(codes.InvalidArgument, "%d documents match; at most %d can be used at once", total, maxSelectedDocuments)
+	}
+	if len(docs) == 0 {
+		return nil, status.Error(codes.NotFound, "no ready documents match")
+	}
+	return docs, nil
+}
+
+// documentChunks returns all of a document's chunks in order
+func (s *RAGService) documentChunks(ctx context.Context, docID uuid.UUID) ([]*repository.DocumentChunk, error) {
+	var chunks []*repository.DocumentChunk
+	for {
+		page, err := s.docRepo.GetChunks(ctx, docID, summarizeChunkPage, len(chunks))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get chunks: %v", err)
+		}
+		chunks = append(chunks, page...)
+		if len(page) < summarizeChunkPage {
+			return chunks, nil
+		}
+	}
+}
+
+// documentParts groups a document's chunks into parts of at most maxTokens
+func documentParts(doc *repository.Document, chunks []*repository.DocumentChunk, maxTokens int) []summaryPart {
+	var parts []summaryPart
+	var b strings.Builder
+	tokens := 0
+	for _, chunk := range chunks {
+		n := prompt.EstimateTokens(chunk.Content)
+		if tokens > 0 && tokens+n > maxTokens {
+			parts = append(parts, summaryPart{title: doc.Title, text: b.String()})
+			b.Reset()
+			tokens = 0
+		}
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "Document: %s\n\n", doc.Title)
+		}
+		b.WriteString(chunk.Content)
+		b.WriteString("\n\n")
+		tokens += n
+	}
+	if tokens > 0 {
+		parts = append(parts, summaryPart{title: doc.Title, text: b.String()})
+	}
+	return parts
+}
+
+// combineSummaries groups part summaries, labeled by document, into parts of
+// at most maxTokens for the next round. Each part holds at least two
+// summaries so that every round shrinks the text.
+func combineSummaries(summaries []summaryPart, maxTokens int) []summaryPart {
+	var parts []summaryPart
+	var b strings.Builder
+	tokens, count := 0, 0
+	for _, summary := range summaries {
+		text := fmt.Sprintf("Summary of part of %s:\n%s\n\n", summary.title, summary.text)
+		n := prompt.EstimateTokens(text)
+		if count >= 2 && tokens+n > maxTokens {
+			parts = append(parts, summaryPart{title: "the documents", text: b.String()})
+			b.Reset()
+			tokens, count = 0, 0
+		}
+		b.WriteString(text)
+		tokens += n
+		count++
+	}
+	if tokens > 0 {
+		parts = append(parts, summaryPart{title: "the documents", text: b.String()})
+	}
+	return parts
+}
+
+// summarizeParts summarizes parts, documentLLMConcurrency at a time, sending
+// progress as each finishes. It stops at the first failure.
+func (s *RAGService) summarizeParts(ctx context.Context, stream grpc.ServerStreamingServer[ragv1.SummarizeResponse], stage ragv1.SummarizeStage, parts []summaryPart, opts llm.GenerateOptions) ([]summaryPart, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		index   int
+		summary string
+		err     error
+	}
+	results := make(chan result)
+	semaphore := make(chan struct{}, documentLLMConcurrency)
+	for i, part := range parts {
+		go func() {
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				results <- result{index: i, err: ctx.Err()}
+				return
+			}
+			summary, err := s.llmClient.Generate(ctx, part.text+"\nSummarize this text.", opts)
+			results <- result{index: i, summary: strings.TrimSpace(summary), err: err}
+		}()
+	}
+
+	if err := sendSummarizeProgress(stream, stage, 0, len(parts)); err != nil {
+		// Drain so the goroutines can exit
+		cancel()
+		for range parts {
+			<-results
+		}
+		return nil, err
+	}
+	summaries := make([]summaryPart, len(parts))
+	var firstErr error
+	for done := 1; done <= len(parts); done++ {
+		r := <-results
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+				cancel()
+			}
+			continue
+		}
+		summaries[r.index] = summaryPart{title: parts[r.index].title, text: r.summary}
+		if firstErr == nil {
+			if err := sendSummarizeProgress(stream, stage, done, len(parts)); err != nil {
+				firstErr = err
+				cancel()
+			}
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return summaries, nil
+}
+
+// sendSummarizeProgress reports how far a stage has come
+func sendSummarizeProgress(stream grpc.ServerStreamingServer[ragv1.SummarizeResponse], stage ragv1.SummarizeStage, completed, total int) error {
+	return stream.Send(&ragv1.SummarizeResponse{
+		Event: &ragv1.SummarizeResponse_Progress{
+			Progress: &ragv1.SummarizeProgress{
+				Stage:     stage,
+				Completed: int32(completed),
+				Total:     int32(total),
+			},
+		},
+	})
+}
+
+// sendSummarizeError reports an LLM failure as the stream's last event; a
+// canceled stream just ends
+func sendSummarizeError(stream grpc.ServerStreamingServer[ragv1.SummarizeResponse], err error) error {
+	if errors.Is(err, context.Canceled) && stream.Context().Err() != nil {
+		return stream.Context().Err()
+	}
+	return stream.Send(&ragv1.SummarizeResponse{
+		Event: &ragv1.SummarizeResponse_Error{
+			Error: &ragv1.StreamError{
+				Code:    "generation_error",
+				Message: err.Error(),
+			},
+		},
+	})
+}
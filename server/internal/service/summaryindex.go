@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+)
+
+// summaryChunkType is the "type" metadata of summary nodes in the vector store
+const summaryChunkType = "summary"
+
+// indexSummaries builds a summary tree of a ready document's chunks and
+// stores its nodes beside them in the vector store, and in the database for
+// reindexes, for tenants with a summary index. vectors holds the chunk vectors just stored, if known.
+// Failures are logged; the document stays searchable by its chunks.
+func (s *DocumentService) indexSummaries(ctx context.Context, doc *repository.Document, chunks []*repository.DocumentChunk, vectors []vectorstore.Chunk, tenant *repository.Tenant) {
+	cfg := tenant.Config.SummaryIndex
+	if s.summaryTree == nil || !cfg.Enabled || len(chunks) < 2 {
+		return
+	}
+
+	known := make(map[string][]float32, len(vectors))
+	for _, v := range vectors {
+		known[v.ID] = v.Vector
+	}
+	texts := make([]string, len(chunks))
+	leafVectors := make([][]float32, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.Content
+		leafVectors[i] = known[chunk.ID.String()]
+	}
+
+	emb := s.embedderFor(tenant)
+	embed := func(ctx context.Context, texts []string) ([][]float32, error) {
+		return embedder.EmbedAll(ctx, emb, texts, embedRetryRounds)
+	}
+	nodes, err := s.summaryTree.Build(ctx, texts, leafVectors, embed, cfg.ClusterSize, cfg.MaxLevels)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to build summary tree", "document_id", doc.ID, "error", err)
+		return
+	}
+	if len(nodes) == 0 {
+		return
+	}
+
+	summaries := make([]*repository.DocumentSummary, len(nodes))
+	summaryVectors := make([][]float32, len(nodes))
+	for i, node := range nodes {
+		summaries[i] = &repository.DocumentSummary{
+			ID:              uuid.NewSHA1(doc.ID, []byte(fmt.Sprintf("summary/%d", i))),
+			DocumentID:      doc.ID,
+			NodeIndex:       i,
+			Level:           node.Level,
+			Content:         node.Content,
+			SummarizedCount: len(node.Children),
+		}
+		summaryVectors[i] = node.Vector
+	}
+	if err := s.docRepo.SaveSummaries(ctx, doc.ID, summaries); err != nil {
+		slog.WarnContext(ctx, "failed to save summary tree", "document_id", doc.ID, "error", err)
+		return
+	}
+	summaryChunks := buildSummaryChunks(doc, summaries, summaryVectors)
+	if err := s.vectorDB.Upsert(ctx, doc.TenantID.String(), summaryChunks); err != nil {
+		slog.WarnContext(ctx, "failed to store summary tree", "document_id", doc.ID, "error", err)
+		return
+	}
+	slog.DebugContext(ctx, "stored summary tree", "document_id", doc.ID, "nodes", len(summaryChunks))
+}
+
+// buildSummaryChunks converts a document's summary tree nodes and their
+// vectors to vector store chunks
+func buildSummaryChunks(doc *repository.Document, summaries []*repository.DocumentSummary, vectors [][]float32) []vectorstore.Chunk {
+	summaryChunks := make([]vectorstore.Chunk, len(summaries))
+	for i, summary := range summaries {
+		metadata := make(map[string]string, len(doc.Metadata)+6)
+		for k, v := range doc.Metadata {
+			metadata[k] = v
+		}
+		metadata["document_id"] = doc.ID.String()
+		metadata["title"] = doc.Title
+		metadata["source"] = doc.Source
+		metadata["type"] = summaryChunkType
+		metadata[ingestion.MetadataSummaryLevel] = strconv.Itoa(summary.Level)
+		metadata["summarized_count"] = strconv.Itoa(summary.SummarizedCount)
+
+		summaryChunks[i] = vectorstore.Chunk{
+			ID:            summary.ID.String(),
+			DocumentID:    doc.ID.String(),
+			TenantID:      doc.TenantID.String(),
+			Content:       summary.Content,
+			Vector:        vectors[i],
+			Metadata:      metadata,
+			Tags:          doc.Tags,
+			CollectionIDs: uuidStrings(doc.CollectionIDs),
+			ProjectID:     projectIDString(doc.ProjectID),
+		}
+	}
+	return summaryChunks
+}
+
+// validateSummaryIndex checks a tenant's summary index settings
+func validateSummaryIndex(cfg repository.SummaryIndexConfig) error {
+	if cfg.ClusterSize != 0 && (cfg.ClusterSize < 2 || cfg.ClusterSize > 20) {
+		return fmt.Errorf("summary_index cluster_size must be between 2 and 20")
+	}
+	if cfg.MaxLevels < 0 || cfg.MaxLevels > 5 {
+		return fmt.Errorf("summary_index max_levels must be between 0 and 5")
+	}
+	return nil
+}
+
+// summaryIndexFromProto converts a proto SummaryIndexConfig
+func summaryIndexFromProto(p *ragv1.SummaryIndexConfig) repository.SummaryIndexConfig {
+	return repository.SummaryIndexConfig{
+		Enabled:     p.Enabled,
+		ClusterSize: int(p.ClusterSize),
+		MaxLevels:   int(p.MaxLevels),
+	}
+}
+
+// summaryIndexToProto converts a repository SummaryIndexConfig to proto SummaryIndexConfig
+func summaryIndexToProto(c repository.SummaryIndexConfig) *ragv1.SummaryIndexConfig {
+	return &ragv1.SummaryIndexConfig{
+		Enabled:     c.Enabled,
+		ClusterSize: int32(c.ClusterSize),
+		MaxLevels:   int32(c.MaxLevels),
+	}
+}
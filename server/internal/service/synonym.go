@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/apierr"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/synonym"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxSynonymEntries caps a tenant's dictionary, which queries load whole
+	maxSynonymEntries = 10000
+
+	// maxSynonymsPerTerm caps the synonyms of one term
+	maxSynonymsPerTerm = 20
+
+	// maxSynonymLength caps a term or synonym, in bytes
+	maxSynonymLength = 100
+
+	// maxKeywordVariants caps the alternatives a keyword query is expanded to
+	maxKeywordVariants = 8
+
+	// synonymCacheTTL is how long queries use a loaded dictionary, so
+	// changes reach every server within it
+	synonymCacheTTL = 30 * time.Second
+)
+
+// SynonymService implements ragv1.SynonymServiceServer
+type SynonymService struct {
+	ragv1.UnimplementedSynonymServiceServer
+
+	synonymRepo repository.SynonymRepository
+	tenantRepo  repository.TenantRepository
+}
+
+// NewSynonymService creates a new SynonymService
+func NewSynonymService(synonymRepo repository.SynonymRepository, tenantRepo repository.TenantRepository) *SynonymService {
+	return &SynonymService{
+		synonymRepo: synonymRepo,
+		tenantRepo:  tenantRepo,
+	}
+}
+
+// SetSynonyms creates or replaces the entry of a term
+func (s *SynonymService) SetSynonyms(ctx context.Context, req *ragv1.SetSynonymsRequest) (*ragv1.SynonymEntry, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	term, err := synonymTerm(req.Term)
+	if err != nil {
+		return nil, err
+	}
+	synonyms, err := validateSynonyms(term, req.Synonyms)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.synonymRepo.Get(ctx, tenantID, term); errors.Is(err, repository.ErrNotFound) {
+		_, total, err := s.synonymRepo.List(ctx, tenantID, 1, 0)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to count synonyms: %v", err)
+		}
+		if total >= maxSynonymEntries {
+			return nil, apierr.ErrQuotaExceeded.New(fmt.Sprintf("the synonym dictionary holds the maximum of %d terms", maxSynonymEntries),
+				"quota", "synonym_terms", "limit", strconv.Itoa(maxSynonymEntries))
+		}
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get synonyms: %v", err)
+	}
+
+	entry := &repository.Synonym{TenantID: tenantID, Term: term, Synonyms: synonyms}
+	if err := s.synonymRepo.Set(ctx, entry); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set synonyms: %v", err)
+	}
+	return synonymToProto(entry), nil
+}
+
+// ListSynonyms lists a tenant's entries by term
+func (s *SynonymService) ListSynonyms(ctx context.Context, req *ragv1.ListSynonymsRequest) (*ragv1.ListSynonymsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		if _, err := fmt.Sscanf(req.PageToken, "%d", &offset); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	if err := s.checkTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	entries, total, err := s.synonymRepo.List(ctx, tenantID, pageSize, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list synonyms: %v", err)
+	}
+
+	protoEntries := make([]*ragv1.SynonymEntry, len(entries))
+	for i, e := range entries {
+		protoEntries[i] = synonymToProto(e)
+	}
+
+	var nextPageToken string
+	if offset+len(entries) < total {
+		nextPageToken = fmt.Sprintf("%d", offset+len(entries))
+	}
+
+	return &ragv1.ListSynonymsResponse{
+		Entries:       protoEntries,
+		NextPageToken: nextPageToken,
+		TotalCount:    int32(total),
+	}, nil
+}
+
+// DeleteSynonyms deletes the entry of a term
+func (s *SynonymService) DeleteSynonyms(ctx context.Context, req *ragv1.DeleteSynonymsRequest) (*ragv1.DeleteSynonymsResponse, error) {
+	tenantID, err := requestTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+	term, err := synonymTerm(req.Term)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.synonymRepo.Delete(ctx, tenantID, term); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "term not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete synonyms: %v", err)
+	}
+	return &ragv1.DeleteSynonymsResponse{Success: true}, nil
+}
+
+// checkTenant verifies the tenant a request is for exists
+func (s *SynonymService) checkTenant(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.tenantRepo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return status.Error(codes.NotFound, "tenant not found")
+		}
+		return status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	return nil
+}
+
+// synonymTerm normalizes a request's term
+func synonymTerm(raw string) (string, error) {
+	if len(raw) > maxSynonymLength {
+		return "", status.Errorf(codes.InvalidArgument, "term must be at most %d bytes", maxSynonymLength)
+	}
+	term := synonym.Normalize(raw)
+	if term == "" {
+		return "", status.Error(codes.InvalidArgument, "term must contain a letter or digit")
+	}
+	if strings.Count(term, " ")+1 > synonym.MaxTermWords {
+		return "", status.Errorf(codes.InvalidArgument, "term must be at most %d words", synonym.MaxTermWords)
+	}
+	return term, nil
+}
+
+// validateSynonyms trims a term's synonyms, dropping duplicates and the term itself
+func validateSynonyms(term string, raw []string) ([]string, error) {
+	var synonyms []string
+	seen := map[string]bool{term: true}
+	for _, syn := range raw {
+		syn = strings.TrimSpace(syn)
+		if len(syn) > maxSynonymLength {
+			return nil, fmt.Errorf("synonyms must be at most %d bytes", maxSynonymLength)
+		}
+		key := synonym.Normalize(syn)
+		if key == "" {
+			return nil, fmt.Errorf("synonyms must contain a letter or digit")
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		synonyms = append(synonyms, syn)
+	}
+	if len(synonyms) == 0 {
+		return nil, fmt.Errorf("at least one synonym other than the term is required")
+	}
+	if len(synonyms) > maxSynonymsPerTerm {
+		return nil, fmt.Errorf("at most %d synonyms per term are allowed", maxSynonymsPerTerm)
+	}
+	return synonyms, nil
+}
+
+// synonymToProto converts a repository Synonym to proto SynonymEntry
+func synonymToProto(s *repository.Synonym) *ragv1.SynonymEntry {
+	return &ragv1.SynonymEntry{
+		TenantId:  s.TenantID.String(),
+		Term:      s.Term,
+		Synonyms:  s.Synonyms,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
+
+// cachedSynonyms is a tenant's dictionary as loaded at a time
+type cachedSynonyms struct {
+	dict   *synonym.Dictionary
+	loaded time.Time
+}
+
+// synonyms returns a tenant's synonym dictionary, nil when it has none. A
+// dictionary that cannot be loaded is logged and queries go unexpanded.
+func (s *RAGService) synonyms(ctx context.Context, tenant *repository.Tenant) *synonym.Dictionary {
+	if s.synonymRepo == nil {
+		return nil
+	}
+	if cached, ok := s.synonymCache.Load(tenant.ID); ok && time.Since(cached.(cachedSynonyms).loaded) < synonymCacheTTL {
+		return cached.(cachedSynonyms).dict
+	}
+
+	entries, _, err := s.synonymRepo.List(ctx, tenant.ID, maxSynonymEntries, 0)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load synonyms, searching without them", "tenant_id", tenant.ID, "error", err)
+		return nil
+	}
+	var dict *synonym.Dictionary
+	if len(entries) > 0 {
+		dictEntries := make([]synonym.Entry, len(entries))
+		for i, e := range entries {
+			dictEntries[i] = synonym.Entry{Term: e.Term, Synonyms: e.Synonyms}
+		}
+		dict = synonym.NewDictionary(dictEntries)
+	}
+	s.synonymCache.Store(tenant.ID, cachedSynonyms{dict: dict, loaded: time.Now()})
+	return dict
+}
+
+// sparseQuery returns the text a query's sparse vector is built from: the
+// query followed by the synonyms of the tenant's terms in it
+func (s *RAGService) sparseQuery(ctx context.Context, tenant *repository.Tenant, query string) string {
+	return s.synonyms(ctx, tenant).Expand(query)
+}
+
+// keywordQuery returns a query's full-text search, also matching it with
+// the tenant's terms replaced by their synonyms
+func (s *RAGService) keywordQuery(ctx context.Context, tenant *repository.Tenant, query string) string {
+	return s.synonyms(ctx, tenant).KeywordQuery(query, maxKeywordVariants)
+}
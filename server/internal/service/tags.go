@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/repository"
+	"github.com/knoguchi/rag/internal/vectorstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxTagLength matches the document_tags column
+	maxTagLength = 100
+	// maxTagsPerRequest bounds AddTags and RemoveTags requests
+	maxTagsPerRequest = 50
+)
+
+// AddTags tags a document and updates the tags stored with its vectors
+func (s *DocumentService) AddTags(ctx context.Context, req *ragv1.AddTagsRequest) (*ragv1.DocumentTagsResponse, error) {
+	doc, tags, err := s.tagRequest(ctx, req.DocumentId, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTags(ctx, doc, func(ctx context.Context) error {
+		return s.docRepo.AddTags(ctx, doc.ID, tags)
+	})
+}
+
+// RemoveTags removes tags from a document and its vectors
+func (s *DocumentService) RemoveTags(ctx context.Context, req *ragv1.RemoveTagsRequest) (*ragv1.DocumentTagsResponse, error) {
+	doc, tags, err := s.tagRequest(ctx, req.DocumentId, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTags(ctx, doc, func(ctx context.Context) error {
+		return s.docRepo.RemoveTags(ctx, doc.ID, tags)
+	})
+}
+
+// ListDocumentsByTag lists a tenant's documents with a tag
+func (s *DocumentService) ListDocumentsByTag(ctx context.Context, req *ragv1.ListDocumentsByTagRequest) (*ragv1.ListDocumentsResponse, error) {
+	tags, err := normalizeTags([]string{req.Tag})
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+
+	return s.ListDocuments(ctx, &ragv1.ListDocumentsRequest{
+		TenantId:  req.TenantId,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Tags:      tags,
+	})
+}
+
+// tagRequest validates a tag change and loads the document it applies to
+func (s *DocumentService) tagRequest(ctx context.Context, documentID string, rawTags []string) (*repository.Document, []string, error) {
+	if documentID == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "document_id is required")
+	}
+	id, err := uuid.Parse(documentID)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "invalid document_id format")
+	}
+
+	tags, err := normalizeTags(rawTags)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tags) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "tags are required")
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, status.Error(codes.NotFound, "document not found")
+		}
+		return nil, nil, status.Errorf(codes.Internal, "failed to get document: %v", err)
+	}
+	if !canAccessDocument(ctx, doc) {
+		return nil, nil, status.Error(codes.NotFound, "document not found")
+	}
+	return doc, tags, nil
+}
+
+// updateTags applies a tag change and copies the resulting tags to the
+// document's vectors inside its transaction, so a failed vector update rolls
+// the change back. The vector store is not part of the transaction: a commit
+// failing after the vectors were updated leaves them ahead until the next
+// change. A document not yet in the vector store picks its tags up when it
+// is stored.
+func (s *DocumentService) updateTags(ctx context.Context, doc *repository.Document, change func(ctx context.Context) error) (*ragv1.DocumentTagsResponse, error) {
+	var tags []string
+	err := s.uow.WithinTx(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		var err error
+		if tags, err = s.docRepo.GetTags(ctx, doc.ID); err != nil {
+			return err
+		}
+		err = s.vectorDB.SetDocumentTags(ctx, doc.TenantID.String(), doc.ID.String(), tags)
+		if err != nil && !errors.Is(err, vectorstore.ErrCollectionNotFound) {
+			return fmt.Errorf("failed to update vector tags: %w", err)
+		}
+		return syncSharedLists(ctx, s.docRepo, s.vectorDB, doc.TenantID, doc.ID, nil)
+	})
+	if err != nil {
+		return nil, searchError(err, "failed to update tags")
+	}
+
+	return &ragv1.DocumentTagsResponse{
+		DocumentId: doc.ID.String(),
+		Tags:       tags,
+	}, nil
+}
+
+// normalizeTags trims and lowercases tags and removes duplicates
+func normalizeTags(raw []string) ([]string, error) {
+	if len(raw) > maxTagsPerRequest {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d tags per request", maxTagsPerRequest)
+	}
+
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "tag %q is longer than %d bytes", tag, maxTagLength)
+		}
+		if strings.IndexFunc(tag, unicode.IsControl) >= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "tag %q contains control characters", tag)
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
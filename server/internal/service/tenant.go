@@ -7,12 +7,20 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/auth"
 	"github.com/knoguchi/rag/internal/config"
 	"github.com/knoguchi/rag/internal/embedder"
+	"github.com/knoguchi/rag/internal/ingestion"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/memory"
+	"github.com/knoguchi/rag/internal/prompt"
 	"github.com/knoguchi/rag/internal/repository"
 	"github.com/knoguchi/rag/internal/vectorstore"
 	"google.golang.org/grpc/codes"
@@ -26,16 +34,64 @@ type TenantService struct {
 
 	repo        repository.TenantRepository
 	vectorStore vectorstore.VectorStore
-	cfg         *config.Config
+	cfg         atomic.Pointer[config.Config] // SetConfig replaces it
+
+	// Reindexing (optional)
+	docRepo   repository.DocumentRepository
+	jobRepo   repository.ReindexJobRepository
+	embedders *embedder.Pool
+
+	// Scoped API keys (optional)
+	apiKeyRepo repository.APIKeyRepository
+
+	// Project API keys (optional)
+	projectRepo repository.ProjectRepository
+}
+
+// TenantServiceOption is a functional option for configuring TenantService.
+type TenantServiceOption func(*TenantService)
+
+// WithReindexing enables ReindexTenant using the given repositories and embedders.
+func WithReindexing(docRepo repository.DocumentRepository, jobRepo repository.ReindexJobRepository, embedders *embedder.Pool) TenantServiceOption {
+	return func(s *TenantService) {
+		s.docRepo = docRepo
+		s.jobRepo = jobRepo
+		s.embedders = embedders
+	}
+}
+
+// WithAPIKeys enables CreateAPIKey, ListAPIKeys and DeleteAPIKey.
+func WithAPIKeys(repo repository.APIKeyRepository) TenantServiceOption {
+	return func(s *TenantService) {
+		s.apiKeyRepo = repo
+	}
+}
+
+// WithAPIKeyProjects lets CreateAPIKey bind keys to projects.
+func WithAPIKeyProjects(repo repository.ProjectRepository) TenantServiceOption {
+	return func(s *TenantService) {
+		s.projectRepo = repo
+	}
 }
 
 // NewTenantService creates a new TenantService
-func NewTenantService(repo repository.TenantRepository, vectorStore vectorstore.VectorStore, cfg *config.Config) *TenantService {
-	return &TenantService{
+func NewTenantService(repo repository.TenantRepository, vectorStore vectorstore.VectorStore, cfg *config.Config, opts ...TenantServiceOption) *TenantService {
+	s := &TenantService{
 		repo:        repo,
 		vectorStore: vectorStore,
-		cfg:         cfg,
 	}
+	s.cfg.Store(cfg)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SetConfig replaces the configuration new tenants' defaults come from
+func (s *TenantService) SetConfig(cfg *config.Config) {
+	s.cfg.Store(cfg)
 }
 
 // CreateTenant creates a new tenant with default configuration
@@ -85,10 +141,13 @@ func (s *TenantService) CreateTenant(ctx context.Context, req *ragv1.CreateTenan
 		return nil, status.Errorf(codes.Internal, "failed to create tenant: %v", err)
 	}
 
-	// Create vector collection for the tenant
-	// The dimension depends on the embedding model; nomic-embed-text uses 768 dimensions
-	dimension := 768
-	if err := s.vectorStore.CreateCollection(ctx, tenant.ID.String(), dimension); err != nil {
+	// Create vector collection for the tenant, sized for its embedding model
+	if err := s.vectorStore.CreateCollection(ctx, tenant.ID.String(), tenant.Config.EmbeddingDimension, storageConfig(tenant.Config.VectorStorage)); err != nil {
+		// A shared collection cannot hold this tenant's vectors at all
+		if errors.Is(err, vectorstore.ErrDimensionMismatch) {
+			_ = s.repo.Delete(ctx, tenant.ID)
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
 		// Log error but don't fail - collection can be created later
 		// In production, this should be handled more gracefully
 		_ = err
@@ -107,6 +166,9 @@ func (s *TenantService) GetTenant(ctx context.Context, req *ragv1.GetTenantReque
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid tenant ID format")
 	}
+	if !canAccess(ctx, id) {
+		return nil, status.Error(codes.NotFound, "tenant not found")
+	}
 
 	tenant, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -116,7 +178,12 @@ func (s *TenantService) GetTenant(ctx context.Context, req *ragv1.GetTenantReque
 		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
 	}
 
-	return s.tenantToProto(tenant), nil
+	resp := s.tenantToProto(tenant)
+	// Keys limited to read or ingest must not reveal the tenant's full key
+	if caller, ok := auth.TenantFromContext(ctx); ok && !caller.HasScope(auth.ScopeAdmin) {
+		resp.ApiKey = ""
+	}
+	return resp, nil
 }
 
 // ListTenants lists all tenants (admin only)
@@ -168,6 +235,9 @@ func (s *TenantService) UpdateTenant(ctx context.Context, req *ragv1.UpdateTenan
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid tenant ID format")
 	}
+	if !canAccess(ctx, id) {
+		return nil, status.Error(codes.NotFound, "tenant not found")
+	}
 
 	tenant, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -184,6 +254,17 @@ func (s *TenantService) UpdateTenant(ctx context.Context, req *ragv1.UpdateTenan
 
 	// Update config if provided
 	if req.Config != nil {
+		// Existing vectors were built with the current model; switching requires a reindex
+		if req.Config.EmbeddingModel != "" && req.Config.EmbeddingModel != tenant.Config.EmbeddingModel {
+			return nil, status.Error(codes.FailedPrecondition, "embedding_model cannot be changed in place; use ReindexTenant")
+		}
+		if req.Config.EmbeddingDimension > 0 && int(req.Config.EmbeddingDimension) != tenant.Config.EmbeddingDimension {
+			return nil, status.Error(codes.FailedPrecondition, "embedding_dimension cannot be changed in place; use ReindexTenant")
+		}
+		if req.Config.VectorStorage != nil && vectorStorageFromProto(req.Config.VectorStorage) != tenant.Config.VectorStorage {
+			return nil, status.Error(codes.FailedPrecondition, "vector_storage cannot be changed in place; use ReindexTenant")
+		}
+
 		newConfig := s.mergeConfig(tenant.Config, req.Config)
 		if err := s.validateTenantConfig(newConfig); err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid config: %v", err)
@@ -260,6 +341,70 @@ func (s *TenantService) RegenerateAPIKey(ctx context.Context, req *ragv1.Regener
 	}, nil
 }
 
+// SetTenantStatus sets a tenant's lifecycle status
+func (s *TenantService) SetTenantStatus(ctx context.Context, req *ragv1.SetTenantStatusRequest) (*ragv1.Tenant, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid tenant ID format")
+	}
+
+	tenantStatus := tenantStatusToString(req.Status)
+	if tenantStatus == "" {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+
+	if err := s.repo.UpdateStatus(ctx, id, tenantStatus, req.Reason); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "tenant not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update tenant status: %v", err)
+	}
+
+	tenant, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get tenant: %v", err)
+	}
+	slog.InfoContext(ctx, "set tenant status", "tenant_id", id, "status", tenantStatus, "reason", req.Reason)
+
+	return s.tenantToProto(tenant), nil
+}
+
+// convertTenantStatus converts a tenant status string to proto enum
+func convertTenantStatus(tenantStatus string) ragv1.TenantStatus {
+	switch tenantStatus {
+	case repository.TenantActive, "":
+		return ragv1.TenantStatus_TENANT_STATUS_ACTIVE
+	case repository.TenantReadOnly:
+		return ragv1.TenantStatus_TENANT_STATUS_READ_ONLY
+	case repository.TenantSuspended:
+		return ragv1.TenantStatus_TENANT_STATUS_SUSPENDED
+	case repository.TenantArchived:
+		return ragv1.TenantStatus_TENANT_STATUS_ARCHIVED
+	default:
+		return ragv1.TenantStatus_TENANT_STATUS_UNSPECIFIED
+	}
+}
+
+// tenantStatusToString converts a proto TenantStatus to string, or "" if unspecified
+func tenantStatusToString(tenantStatus ragv1.TenantStatus) string {
+	switch tenantStatus {
+	case ragv1.TenantStatus_TENANT_STATUS_ACTIVE:
+		return repository.TenantActive
+	case ragv1.TenantStatus_TENANT_STATUS_READ_ONLY:
+		return repository.TenantReadOnly
+	case ragv1.TenantStatus_TENANT_STATUS_SUSPENDED:
+		return repository.TenantSuspended
+	case ragv1.TenantStatus_TENANT_STATUS_ARCHIVED:
+		return repository.TenantArchived
+	default:
+		return ""
+	}
+}
+
 // generateAPIKey generates a new API key with format "rag_" + 32 random hex chars
 func generateAPIKey() (string, error) {
 	bytes := make([]byte, 16) // 16 bytes = 32 hex chars
@@ -272,7 +417,8 @@ func generateAPIKey() (string, error) {
 // buildTenantConfig builds a tenant config with defaults from the provided proto config
 func (s *TenantService) buildTenantConfig(protoConfig *ragv1.TenantConfig) repository.TenantConfig {
 	// Determine which embedding model to use
-	embeddingModel := s.cfg.OllamaEmbeddingModel
+	cfg := s.cfg.Load()
+	embeddingModel := cfg.OllamaEmbeddingModel
 	if protoConfig != nil && protoConfig.EmbeddingModel != "" {
 		embeddingModel = protoConfig.EmbeddingModel
 	}
@@ -281,16 +427,17 @@ func (s *TenantService) buildTenantConfig(protoConfig *ragv1.TenantConfig) repos
 	modelCfg := embedder.GetModelConfig(embeddingModel)
 
 	config := repository.TenantConfig{
-		EmbeddingModel: embeddingModel,
-		LLMModel:       s.cfg.OllamaLLMModel,
+		EmbeddingModel:     embeddingModel,
+		EmbeddingDimension: s.embeddingDimension(embeddingModel),
+		LLMModel:           cfg.OllamaLLMModel,
 		Chunker: repository.ChunkerConfig{
-			Method:     s.cfg.DefaultChunkMethod,
+			Method:     cfg.DefaultChunkMethod,
 			TargetSize: modelCfg.TargetChunkWords, // Use model-specific limit
 			MaxSize:    modelCfg.MaxChunkWords,    // Use model-specific limit
-			Overlap:    s.cfg.DefaultChunkOverlap,
+			Overlap:    cfg.DefaultChunkOverlap,
 		},
-		TopK:         s.cfg.DefaultTopK,
-		MinScore:     s.cfg.DefaultMinScore,
+		TopK:         cfg.DefaultTopK,
+		MinScore:     cfg.DefaultMinScore,
 		SystemPrompt: defaultSystemPrompt,
 	}
 
@@ -299,9 +446,63 @@ func (s *TenantService) buildTenantConfig(protoConfig *ragv1.TenantConfig) repos
 	}
 
 	// Note: EmbeddingModel is already set above with model-specific chunk config
+	if protoConfig.EmbeddingDimension > 0 {
+		config.EmbeddingDimension = int(protoConfig.EmbeddingDimension)
+	}
 	if protoConfig.LlmModel != "" {
 		config.LLMModel = protoConfig.LlmModel
 	}
+	if len(protoConfig.LlmFallbackModels) > 0 {
+		config.LLMFallbackModels = protoConfig.LlmFallbackModels
+	}
+	if protoConfig.VectorStorage != nil {
+		config.VectorStorage = vectorStorageFromProto(protoConfig.VectorStorage)
+	}
+	config.StoreContent = protoConfig.StoreContent
+	if protoConfig.ScoreBoost != nil {
+		config.ScoreBoost = scoreBoostFromProto(protoConfig.ScoreBoost)
+	}
+	config.Language = tenantLanguage(protoConfig.Language)
+	if protoConfig.NoAnswer != nil {
+		config.NoAnswer = noAnswerFromProto(protoConfig.NoAnswer)
+	}
+	if protoConfig.Guardrails != nil {
+		config.Guardrails = guardrailsFromProto(protoConfig.Guardrails)
+	}
+	if protoConfig.Pii != nil {
+		config.PII = piiFromProto(protoConfig.Pii)
+	}
+	if protoConfig.History != nil {
+		config.History = historyFromProto(protoConfig.History)
+	}
+	if protoConfig.Agent != nil {
+		config.Agent = agentFromProto(protoConfig.Agent)
+	}
+	config.DedupChunks = protoConfig.GetDedupChunks()
+	if protoConfig.NearDuplicates != nil {
+		config.NearDuplicates = nearDuplicatesFromProto(protoConfig.NearDuplicates)
+	}
+	if protoConfig.Headless != nil {
+		config.Headless = headlessFromProto(protoConfig.Headless)
+	}
+	if protoConfig.Rerank != nil {
+		config.Rerank = rerankFromProto(protoConfig.Rerank)
+	}
+	if protoConfig.DuplicateResults != nil {
+		config.DuplicateResults = duplicateResultsFromProto(protoConfig.DuplicateResults)
+	}
+	if protoConfig.KnowledgeGraph != nil {
+		config.KnowledgeGraph = knowledgeGraphFromProto(protoConfig.KnowledgeGraph)
+	}
+	if protoConfig.SummaryIndex != nil {
+		config.SummaryIndex = summaryIndexFromProto(protoConfig.SummaryIndex)
+	}
+	if protoConfig.TextAnalysis != nil {
+		config.TextAnalysis = textAnalysisFromProto(protoConfig.TextAnalysis)
+	}
+	if len(protoConfig.Tools) > 0 {
+		config.Tools = toolsFromProto(protoConfig.Tools)
+	}
 	if protoConfig.TopK > 0 {
 		config.TopK = int(protoConfig.TopK)
 	}
@@ -312,24 +513,77 @@ func (s *TenantService) buildTenantConfig(protoConfig *ragv1.TenantConfig) repos
 		config.SystemPrompt = protoConfig.SystemPrompt
 	}
 
-	if protoConfig.Chunker != nil {
-		if protoConfig.Chunker.Method != "" {
-			config.Chunker.Method = protoConfig.Chunker.Method
-		}
-		if protoConfig.Chunker.TargetSize > 0 {
-			config.Chunker.TargetSize = int(protoConfig.Chunker.TargetSize)
-		}
-		if protoConfig.Chunker.MaxSize > 0 {
-			config.Chunker.MaxSize = int(protoConfig.Chunker.MaxSize)
-		}
-		if protoConfig.Chunker.Overlap > 0 {
-			config.Chunker.Overlap = int(protoConfig.Chunker.Overlap)
-		}
-	}
+	config.Chunker = mergeChunkerConfig(config.Chunker, chunkerFromProto(protoConfig.Chunker))
 
 	return config
 }
 
+// embeddingDimension resolves the vector dimension for an embedding model,
+// preferring the embedder itself over the static model table
+func (s *TenantService) embeddingDimension(model string) int {
+	if s.embedders != nil {
+		return s.embedders.Get(model).Dimension()
+	}
+	return embedder.GetModelConfig(model).Dimension
+}
+
+// validateEmbeddingDimension rejects dimensions a known model cannot produce.
+// Smaller dimensions truncate its vectors; unknown models are taken at their word.
+func validateEmbeddingDimension(model string, dimension int) error {
+	if modelCfg, ok := embedder.LookupModelConfig(model); ok && dimension > modelCfg.Dimension {
+		return fmt.Errorf("embedding_dimension %d exceeds %s's dimension %d", dimension, model, modelCfg.Dimension)
+	}
+	return nil
+}
+
+// validateVectorStorage checks the quantization mode
+func validateVectorStorage(storage repository.VectorStorageConfig) error {
+	switch storage.Quantization {
+	case vectorstore.QuantizationNone, vectorstore.QuantizationScalar, vectorstore.QuantizationBinary:
+		return nil
+	default:
+		return fmt.Errorf("invalid vector_storage quantization: %s", storage.Quantization)
+	}
+}
+
+// vectorStorageFromProto converts proto vector storage options
+func vectorStorageFromProto(p *ragv1.VectorStorageConfig) repository.VectorStorageConfig {
+	return repository.VectorStorageConfig{
+		Quantization:  p.Quantization,
+		OnDiskVectors: p.OnDiskVectors,
+		OnDiskPayload: p.OnDiskPayload,
+	}
+}
+
+// piiFromProto converts a proto PIIConfig; mode "off" is stored as empty
+func piiFromProto(p *ragv1.PIIConfig) repository.PIIConfig {
+	mode := strings.ToLower(strings.TrimSpace(p.GetMode()))
+	if mode == "off" {
+		mode = ingestion.PIIModeOff
+	}
+	return repository.PIIConfig{
+		Mode:  mode,
+		Kinds: p.GetKinds(),
+	}
+}
+
+// piiToProto converts a repository PIIConfig to proto PIIConfig
+func piiToProto(c repository.PIIConfig) *ragv1.PIIConfig {
+	return &ragv1.PIIConfig{
+		Mode:  c.Mode,
+		Kinds: c.Kinds,
+	}
+}
+
+// storageConfig converts a tenant's vector storage options for the vector store
+func storageConfig(c repository.VectorStorageConfig) vectorstore.StorageConfig {
+	return vectorstore.StorageConfig{
+		Quantization:  c.Quantization,
+		OnDiskVectors: c.OnDiskVectors,
+		OnDiskPayload: c.OnDiskPayload,
+	}
+}
+
 // mergeConfig merges existing config with proto updates
 func (s *TenantService) mergeConfig(existing repository.TenantConfig, protoConfig *ragv1.TenantConfig) repository.TenantConfig {
 	if protoConfig.EmbeddingModel != "" {
@@ -338,6 +592,9 @@ func (s *TenantService) mergeConfig(existing repository.TenantConfig, protoConfi
 	if protoConfig.LlmModel != "" {
 		existing.LLMModel = protoConfig.LlmModel
 	}
+	if len(protoConfig.LlmFallbackModels) > 0 {
+		existing.LLMFallbackModels = protoConfig.LlmFallbackModels
+	}
 	if protoConfig.TopK > 0 {
 		existing.TopK = int(protoConfig.TopK)
 	}
@@ -347,21 +604,59 @@ func (s *TenantService) mergeConfig(existing repository.TenantConfig, protoConfi
 	if protoConfig.SystemPrompt != "" {
 		existing.SystemPrompt = protoConfig.SystemPrompt
 	}
-
-	if protoConfig.Chunker != nil {
-		if protoConfig.Chunker.Method != "" {
-			existing.Chunker.Method = protoConfig.Chunker.Method
-		}
-		if protoConfig.Chunker.TargetSize > 0 {
-			existing.Chunker.TargetSize = int(protoConfig.Chunker.TargetSize)
-		}
-		if protoConfig.Chunker.MaxSize > 0 {
-			existing.Chunker.MaxSize = int(protoConfig.Chunker.MaxSize)
-		}
-		if protoConfig.Chunker.Overlap > 0 {
-			existing.Chunker.Overlap = int(protoConfig.Chunker.Overlap)
-		}
+	if protoConfig.StoreContent != nil {
+		existing.StoreContent = protoConfig.StoreContent
+	}
+	if protoConfig.ScoreBoost != nil {
+		existing.ScoreBoost = scoreBoostFromProto(protoConfig.ScoreBoost)
+	}
+	if protoConfig.Language != "" {
+		existing.Language = tenantLanguage(protoConfig.Language)
+	}
+	if protoConfig.NoAnswer != nil {
+		existing.NoAnswer = noAnswerFromProto(protoConfig.NoAnswer)
+	}
+	if protoConfig.Guardrails != nil {
+		existing.Guardrails = guardrailsFromProto(protoConfig.Guardrails)
+	}
+	if protoConfig.Pii != nil {
+		existing.PII = piiFromProto(protoConfig.Pii)
+	}
+	if protoConfig.History != nil {
+		existing.History = historyFromProto(protoConfig.History)
+	}
+	if protoConfig.Agent != nil {
+		existing.Agent = agentFromProto(protoConfig.Agent)
+	}
+	if protoConfig.DedupChunks != nil {
+		existing.DedupChunks = *protoConfig.DedupChunks
+	}
+	if protoConfig.NearDuplicates != nil {
+		existing.NearDuplicates = nearDuplicatesFromProto(protoConfig.NearDuplicates)
+	}
+	if protoConfig.Headless != nil {
+		existing.Headless = headlessFromProto(protoConfig.Headless)
+	}
+	if protoConfig.Rerank != nil {
+		existing.Rerank = rerankFromProto(protoConfig.Rerank)
+	}
+	if protoConfig.DuplicateResults != nil {
+		existing.DuplicateResults = duplicateResultsFromProto(protoConfig.DuplicateResults)
+	}
+	if protoConfig.KnowledgeGraph != nil {
+		existing.KnowledgeGraph = knowledgeGraphFromProto(protoConfig.KnowledgeGraph)
+	}
+	if protoConfig.SummaryIndex != nil {
+		existing.SummaryIndex = summaryIndexFromProto(protoConfig.SummaryIndex)
 	}
+	if protoConfig.TextAnalysis != nil {
+		existing.TextAnalysis = textAnalysisFromProto(protoConfig.TextAnalysis)
+	}
+	if len(protoConfig.Tools) > 0 {
+		existing.Tools = toolsFromProto(protoConfig.Tools)
+	}
+
+	existing.Chunker = mergeChunkerConfig(existing.Chunker, chunkerFromProto(protoConfig.Chunker))
 
 	return existing
 }
@@ -373,13 +668,29 @@ func (s *TenantService) validateTenantConfig(config repository.TenantConfig) err
 		return fmt.Errorf("embedding_model is required")
 	}
 
+	if config.EmbeddingDimension < 0 {
+		return fmt.Errorf("embedding_dimension cannot be negative")
+	}
+	if err := validateEmbeddingDimension(config.EmbeddingModel, config.EmbeddingDimension); err != nil {
+		return err
+	}
+
+	if err := validateVectorStorage(config.VectorStorage); err != nil {
+		return err
+	}
+
 	// Validate LLM model
 	if config.LLMModel == "" {
 		return fmt.Errorf("llm_model is required")
 	}
+	for _, model := range append([]string{config.LLMModel}, config.LLMFallbackModels...) {
+		if err := llm.ValidateModel(model); err != nil {
+			return err
+		}
+	}
 
 	// Validate chunker config
-	validMethods := map[string]bool{"fixed": true, "semantic": true, "sentence": true}
+	validMethods := map[string]bool{"fixed": true, "semantic": true, "sentence": true, "tabular": true}
 	if config.Chunker.Method != "" && !validMethods[config.Chunker.Method] {
 		return fmt.Errorf("invalid chunker method: %s", config.Chunker.Method)
 	}
@@ -400,6 +711,76 @@ func (s *TenantService) validateTenantConfig(config repository.TenantConfig) err
 		return fmt.Errorf("chunker overlap cannot be negative")
 	}
 
+	if config.Chunker.OverlapPercent < 0 || config.Chunker.OverlapPercent > ingestion.MaxOverlapPercent {
+		return fmt.Errorf("chunker overlap_percent must be between 0 and %d", ingestion.MaxOverlapPercent)
+	}
+
+	if f := config.Chunker.TableFormat; f != "" && f != ingestion.TableFormatMarkdown && f != ingestion.TableFormatKeyValue {
+		return fmt.Errorf("invalid chunker table_format: %s", f)
+	}
+
+	if config.Chunker.RowsPerChunk < 0 {
+		return fmt.Errorf("chunker rows_per_chunk cannot be negative")
+	}
+
+	if config.Chunker.Language != "" && !validLanguageCode(config.Chunker.Language) {
+		return fmt.Errorf("chunker language %q must be an ISO 639-1 code", config.Chunker.Language)
+	}
+
+	if err := ingestion.ValidateAbbreviations(config.Chunker.Abbreviations); err != nil {
+		return fmt.Errorf("chunker %v", err)
+	}
+
+	if err := ingestion.ValidateContextTemplate(config.Chunker.ContextTemplate); err != nil {
+		return fmt.Errorf("chunker %v", err)
+	}
+
+	if err := validateScoreBoost(config.ScoreBoost); err != nil {
+		return err
+	}
+	if config.Language != "" && !prompt.SupportedLanguage(config.Language) {
+		return fmt.Errorf("unsupported language %q", config.Language)
+	}
+	if config.NoAnswer.MinTopScore < 0 {
+		return fmt.Errorf("no_answer min_top_score cannot be negative")
+	}
+	if err := validateGuardrails(config.Guardrails); err != nil {
+		return err
+	}
+	if err := ingestion.ValidatePIIConfig(config.PII); err != nil {
+		return err
+	}
+	if config.History.Window < 0 || config.History.Window > memory.DefaultMaxMessages {
+		return fmt.Errorf("history window must be between 0 and %d", memory.DefaultMaxMessages)
+	}
+	if config.Agent.MaxSteps < 0 || config.Agent.MaxSteps > maxAgentSteps {
+		return fmt.Errorf("agent max_steps must be between 0 and %d", maxAgentSteps)
+	}
+	if err := validateTools(config.Tools); err != nil {
+		return err
+	}
+	if err := validateNearDuplicates(config.NearDuplicates); err != nil {
+		return err
+	}
+	if config.Headless.TimeoutSeconds < 0 || config.Headless.TimeoutSeconds > maxHeadlessTimeoutSeconds {
+		return fmt.Errorf("headless timeout_seconds must be between 0 and %d", maxHeadlessTimeoutSeconds)
+	}
+	if err := validateRerank(config.Rerank); err != nil {
+		return err
+	}
+	if err := validateDuplicateResults(config.DuplicateResults); err != nil {
+		return err
+	}
+	if err := validateKnowledgeGraph(config.KnowledgeGraph); err != nil {
+		return err
+	}
+	if err := validateSummaryIndex(config.SummaryIndex); err != nil {
+		return err
+	}
+	if err := validateTextAnalysis(config.TextAnalysis); err != nil {
+		return err
+	}
+
 	// Validate retrieval config
 	if config.TopK < 0 {
 		return fmt.Errorf("top_k cannot be negative")
@@ -414,22 +795,42 @@ func (s *TenantService) validateTenantConfig(config repository.TenantConfig) err
 
 // tenantToProto converts a repository Tenant to proto Tenant
 func (s *TenantService) tenantToProto(t *repository.Tenant) *ragv1.Tenant {
-	return &ragv1.Tenant{
+	pb := &ragv1.Tenant{
 		Id:     t.ID.String(),
 		Name:   t.Name,
 		ApiKey: t.APIKey,
 		Config: &ragv1.TenantConfig{
-			EmbeddingModel: t.Config.EmbeddingModel,
-			LlmModel:       t.Config.LLMModel,
-			Chunker: &ragv1.ChunkerConfig{
-				Method:     t.Config.Chunker.Method,
-				TargetSize: int32(t.Config.Chunker.TargetSize),
-				MaxSize:    int32(t.Config.Chunker.MaxSize),
-				Overlap:    int32(t.Config.Chunker.Overlap),
+			EmbeddingModel:     t.Config.EmbeddingModel,
+			EmbeddingDimension: int32(t.Config.EmbeddingDimension),
+			LlmModel:           t.Config.LLMModel,
+			LlmFallbackModels:  t.Config.LLMFallbackModels,
+			Chunker:            chunkerToProto(t.Config.Chunker),
+			TopK:               int32(t.Config.TopK),
+			MinScore:           t.Config.MinScore,
+			SystemPrompt:       t.Config.SystemPrompt,
+			VectorStorage: &ragv1.VectorStorageConfig{
+				Quantization:  t.Config.VectorStorage.Quantization,
+				OnDiskVectors: t.Config.VectorStorage.OnDiskVectors,
+				OnDiskPayload: t.Config.VectorStorage.OnDiskPayload,
 			},
-			TopK:         int32(t.Config.TopK),
-			MinScore:     t.Config.MinScore,
-			SystemPrompt: t.Config.SystemPrompt,
+			StoreContent:          t.Config.StoreContent,
+			ScoreBoost:            scoreBoostToProto(t.Config.ScoreBoost),
+			PromptTemplateVersion: int32(t.Config.PromptTemplateVersion),
+			Language:              t.Config.Language,
+			NoAnswer:              noAnswerToProto(t.Config.NoAnswer),
+			Guardrails:            guardrailsToProto(t.Config.Guardrails),
+			Pii:                   piiToProto(t.Config.PII),
+			History:               historyToProto(t.Config.History),
+			Agent:                 agentToProto(t.Config.Agent),
+			Tools:                 toolsToProto(t.Config.Tools),
+			DedupChunks:           &t.Config.DedupChunks,
+			NearDuplicates:        nearDuplicatesToProto(t.Config.NearDuplicates),
+			Headless:              headlessToProto(t.Config.Headless),
+			Rerank:                rerankToProto(t.Config.Rerank),
+			DuplicateResults:      duplicateResultsToProto(t.Config.DuplicateResults),
+			KnowledgeGraph:        knowledgeGraphToProto(t.Config.KnowledgeGraph),
+			SummaryIndex:          summaryIndexToProto(t.Config.SummaryIndex),
+			TextAnalysis:          textAnalysisToProto(t.Config.TextAnalysis),
 		},
 		Usage: &ragv1.TenantUsage{
 			DocumentCount:   int32(t.Usage.DocumentCount),
@@ -438,7 +839,14 @@ func (s *TenantService) tenantToProto(t *repository.Tenant) *ragv1.Tenant {
 		},
 		CreatedAt: timestamppb.New(t.CreatedAt),
 		UpdatedAt: timestamppb.New(t.UpdatedAt),
+
+		Status:       convertTenantStatus(t.Status),
+		StatusReason: t.StatusReason,
+	}
+	if t.StatusChangedAt != nil {
+		pb.StatusChangedAt = timestamppb.New(*t.StatusChangedAt)
 	}
+	return pb
 }
 
 const defaultSystemPrompt = `You are a concise knowledge assistant. Answer questions using ONLY the provided documents.
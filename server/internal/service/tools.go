@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ragv1 "github.com/knoguchi/rag/gen/rag/v1"
+	"github.com/knoguchi/rag/internal/llm"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// maxTools caps the tools a tenant may register
+const maxTools = 16
+
+// toolNamePattern matches valid tool names
+var toolNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// toolReply is the LLM's reply when the tenant has tools: an answer, or
+// arguments keyed by the name of the tool called
+type toolReply struct {
+	Answer string                     `json:"answer"`
+	Tool   map[string]json.RawMessage `json:"tool"`
+}
+
+// validateTools checks tool names and parameter schemas
+func validateTools(tools []repository.ToolDefinition) error {
+	if len(tools) > maxTools {
+		return fmt.Errorf("at most %d tools are allowed", maxTools)
+	}
+	seen := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		if !toolNamePattern.MatchString(tool.Name) {
+			return fmt.Errorf("invalid tool name %q: use up to 64 letters, digits, _ and -", tool.Name)
+		}
+		if seen[tool.Name] {
+			return fmt.Errorf("duplicate tool %q", tool.Name)
+		}
+		seen[tool.Name] = true
+		if _, err := toolParameters(tool); err != nil {
+			return fmt.Errorf("tool %q: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
+
+// toolParameters returns a tool's arguments schema, which must describe an object
+func toolParameters(tool repository.ToolDefinition) (map[string]any, error) {
+	if strings.TrimSpace(tool.Parameters) == "" {
+		return map[string]any{"type": "object"}, nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(tool.Parameters), &schema); err != nil {
+		return nil, fmt.Errorf("parameters must be a JSON schema object: %v", err)
+	}
+	if t, ok := schema["type"]; ok && t != "object" {
+		return nil, fmt.Errorf("parameters must describe an object, not %v", t)
+	}
+	schema["type"] = "object"
+	return schema, nil
+}
+
+// toolInstructions tells the LLM what the tenant's tools do and when to call them
+func toolInstructions(tools []repository.ToolDefinition) string {
+	var b strings.Builder
+	b.WriteString("Instead of answering, you can ask the application to take an action by calling one of these tools. ")
+	b.WriteString("Call a tool only when the user wants what it does; otherwise answer.\n")
+	for _, tool := range tools {
+		fmt.Fprintf(&b, "- %s: %s\n", tool.Name, tool.Description)
+	}
+	b.WriteString(`Reply {"answer": "..."} to answer, or {"tool": {"<tool name>": {<arguments>}}} to call a tool.`)
+	return b.String()
+}
+
+// toolReplySchema is the JSON schema of a toolReply for the tenant's tools,
+// so each tool's arguments are validated against its parameters
+func toolReplySchema(tools []repository.ToolDefinition) (json.RawMessage, error) {
+	calls := make(map[string]any, len(tools))
+	for _, tool := range tools {
+		params, err := toolParameters(tool)
+		if err != nil {
+			return nil, err
+		}
+		calls[tool.Name] = params
+	}
+	return json.Marshal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"answer": map[string]any{"type": "string"},
+			"tool": map[string]any{
+				"type":                 "object",
+				"properties":           calls,
+				"additionalProperties": false,
+			},
+		},
+	})
+}
+
+// generateWithTools asks the LLM to answer or call one of the tools. A reply
+// calling several tools keeps the first in the tenant's order.
+func (s *RAGService) generateWithTools(ctx context.Context, prompt string, opts llm.GenerateOptions, tools []repository.ToolDefinition) (string, *ragv1.ToolCall, error) {
+	schema, err := toolReplySchema(tools)
+	if err != nil {
+		return "", nil, err
+	}
+	raw, err := s.llmClient.GenerateStructured(ctx, prompt, schema, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	var reply toolReply
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return "", nil, fmt.Errorf("invalid tool reply: %w", err)
+	}
+	for _, tool := range tools {
+		if args, ok := reply.Tool[tool.Name]; ok {
+			return "", &ragv1.ToolCall{Name: tool.Name, Arguments: string(args)}, nil
+		}
+	}
+	return reply.Answer, nil, nil
+}
+
+// completionText is what the LLM generated: the answer, or the tool call
+func completionText(answer string, call *ragv1.ToolCall) string {
+	if call != nil {
+		return call.Name + call.Arguments
+	}
+	return answer
+}
+
+// toolCallText records a tool call in the session history, so later turns
+// know the action was requested
+func toolCallText(call *ragv1.ToolCall) string {
+	return fmt.Sprintf("[called %s with %s]", call.Name, call.Arguments)
+}
+
+// toolsFromProto converts proto tool definitions to repository ToolDefinitions
+func toolsFromProto(p []*ragv1.ToolDefinition) []repository.ToolDefinition {
+	tools := make([]repository.ToolDefinition, len(p))
+	for i, t := range p {
+		tools[i] = repository.ToolDefinition{
+			Name:        t.Name,
+			Description: t.Description,
+			Parameters:  t.Parameters,
+		}
+	}
+	return tools
+}
+
+// toolsToProto converts repository ToolDefinitions to proto tool definitions
+func toolsToProto(tools []repository.ToolDefinition) []*ragv1.ToolDefinition {
+	p := make([]*ragv1.ToolDefinition, len(tools))
+	for i, t := range tools {
+		p[i] = &ragv1.ToolDefinition{
+			Name:        t.Name,
+			Description: t.Description,
+			Parameters:  t.Parameters,
+		}
+	}
+	return p
+}
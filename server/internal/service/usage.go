@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/knoguchi/rag/internal/repository"
+)
+
+// queryCountKey identifies a tenant's pending count in a month
+type queryCountKey struct {
+	tenantID uuid.UUID
+	month    time.Time
+}
+
+// QueryCounter counts tenants' queries in memory and adds them to their
+// monthly counts in batches, so busy tenants' queries don't contend on their
+// usage row. Counts are kept per calendar month, so a tenant's
+// query_count_month starts again from zero each month.
+type QueryCounter struct {
+	repo      repository.QueryUsageRepository
+	retention int // months of counts kept, including the current one; 0 keeps all
+
+	mu      sync.Mutex
+	pending map[queryCountKey]int64
+	now     func() time.Time
+}
+
+// NewQueryCounter creates a QueryCounter keeping retention months of counts
+func NewQueryCounter(repo repository.QueryUsageRepository, retention int) *QueryCounter {
+	return &QueryCounter{
+		repo:      repo,
+		retention: retention,
+		pending:   make(map[queryCountKey]int64),
+		now:       time.Now,
+	}
+}
+
+// Add counts a query of a tenant. It is a no-op on a nil QueryCounter.
+func (c *QueryCounter) Add(tenantID uuid.UUID) {
+	if c == nil {
+		return
+	}
+	key := queryCountKey{tenantID: tenantID, month: repository.QueryMonth(c.now())}
+
+	c.mu.Lock()
+	c.pending[key]++
+	c.mu.Unlock()
+}
+
+// Flush adds the pending counts to the repository. Counts it fails to add
+// stay pending for the next flush.
+func (c *QueryCounter) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[queryCountKey]int64, len(pending))
+	c.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	usage := make([]repository.QueryUsage, 0, len(pending))
+	for key, count := range pending {
+		usage = append(usage, repository.QueryUsage{TenantID: key.tenantID, Month: key.month, QueryCount: count})
+	}
+	if err := c.repo.Add(ctx, usage); err != nil {
+		c.mu.Lock()
+		for key, count := range pending {
+			c.pending[key] += count
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run flushes pending counts every flushInterval until ctx is cancelled. At
+// startup and when a month ends it deletes the months past retention.
+func (c *QueryCounter) Run(ctx context.Context, flushInterval time.Duration) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var month time.Time
+	for {
+		if err := c.Flush(ctx); err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to flush query counts", "error", err)
+		}
+		if current := repository.QueryMonth(c.now()); !current.Equal(month) {
+			month = current
+			c.prune(ctx, month)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune deletes the counts of months past retention, counting back from month
+func (c *QueryCounter) prune(ctx context.Context, month time.Time) {
+	if c.retention <= 0 {
+		return
+	}
+	deleted, err := c.repo.DeleteBefore(ctx, month.AddDate(0, 1-c.retention, 0))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to delete expired query counts", "error", err)
+		return
+	}
+	if deleted > 0 {
+		slog.InfoContext(ctx, "deleted expired query counts", "months_kept", c.retention, "deleted", deleted)
+	}
+}
+
+// Monthly returns tenants' counts, pending ones included, of the last months
+// months up to the current one
+func (c *QueryCounter) Monthly(ctx context.Context, months int) ([]*repository.QueryUsage, error) {
+	since := repository.QueryMonth(c.now()).AddDate(0, 1-months, 0)
+	stored, err := c.repo.ListSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[queryCountKey]*repository.QueryUsage, len(stored))
+	for _, u := range stored {
+		byKey[queryCountKey{tenantID: u.TenantID, month: u.Month}] = u
+	}
+	c.mu.Lock()
+	for key, count := range c.pending {
+		if key.month.Before(since) {
+			continue
+		}
+		if u, ok := byKey[key]; ok {
+			u.QueryCount += count
+			continue
+		}
+		u := &repository.QueryUsage{TenantID: key.tenantID, Month: key.month, QueryCount: count}
+		byKey[key] = u
+		stored = append(stored, u)
+	}
+	c.mu.Unlock()
+	return stored, nil
+}
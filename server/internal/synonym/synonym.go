@@ -0,0 +1,218 @@
+// Package synonym expands queries with a tenant's vocabulary, so a search
+// for "k8s" also matches chunks that only say "Kubernetes". A dictionary
+// maps terms of up to MaxTermWords words to the terms they also match;
+// entries are one-way, so "k8s" -> "kubernetes" does not expand
+// "kubernetes".
+package synonym
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxTermWords is the longest term, in words, a dictionary matches.
+const MaxTermWords = 4
+
+// Entry maps a term to the terms a query containing it also matches.
+type Entry struct {
+	Term     string
+	Synonyms []string
+}
+
+// Dictionary matches the terms of a tenant's entries in queries. It is
+// immutable and safe for concurrent use.
+type Dictionary struct {
+	synonyms map[string][]string // by normalized term
+	maxWords int
+}
+
+// NewDictionary builds a dictionary of entries. Terms are matched
+// case-insensitively, ignoring punctuation; entries with the same term are
+// merged.
+func NewDictionary(entries []Entry) *Dictionary {
+	d := &Dictionary{synonyms: make(map[string][]string, len(entries))}
+	for _, e := range entries {
+		key := Normalize(e.Term)
+		if key == "" {
+			continue
+		}
+		words := strings.Count(key, " ") + 1
+		if words > MaxTermWords {
+			continue
+		}
+		for _, syn := range e.Synonyms {
+			syn = strings.TrimSpace(syn)
+			if syn == "" || Normalize(syn) == key || containsFold(d.synonyms[key], syn) {
+				continue
+			}
+			d.synonyms[key] = append(d.synonyms[key], syn)
+		}
+		if len(d.synonyms[key]) > 0 {
+			d.maxWords = max(d.maxWords, words)
+		}
+	}
+	return d
+}
+
+// Len returns the number of terms in the dictionary.
+func (d *Dictionary) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.synonyms)
+}
+
+// Normalize returns the form terms are matched in: lowercase words of
+// letters and digits separated by single spaces.
+func Normalize(term string) string {
+	words := make([]string, 0, 4)
+	for _, w := range tokenize(term) {
+		words = append(words, w.text)
+	}
+	return strings.Join(words, " ")
+}
+
+// Match is a dictionary term found in a query.
+type Match struct {
+	Term     string   // normalized
+	Synonyms []string // as entered
+	start    int      // byte offsets of the term in the query
+	end      int
+}
+
+// Matches returns the dictionary terms in a query, left to right, preferring
+// the longest term at each word and never overlapping.
+func (d *Dictionary) Matches(query string) []Match {
+	if d.Len() == 0 {
+		return nil
+	}
+	words := tokenize(query)
+	var matches []Match
+	for i := 0; i < len(words); {
+		n := min(d.maxWords, len(words)-i)
+		for ; n >= 1; n-- {
+			parts := make([]string, n)
+			for j := range parts {
+				parts[j] = words[i+j].text
+			}
+			term := strings.Join(parts, " ")
+			if syns, ok := d.synonyms[term]; ok {
+				matches = append(matches, Match{
+					Term:     term,
+					Synonyms: syns,
+					start:    words[i].start,
+					end:      words[i+n-1].end,
+				})
+				break
+			}
+		}
+		if n == 0 {
+			n = 1
+		}
+		i += n
+	}
+	return matches
+}
+
+// Expand returns the query followed by the synonyms of the terms it
+// contains, for bag-of-words scoring such as sparse vectors. A query without
+// dictionary terms is returned as it is.
+func (d *Dictionary) Expand(query string) string {
+	matches := d.Matches(query)
+	if len(matches) == 0 {
+		return query
+	}
+	var b strings.Builder
+	b.WriteString(query)
+	for _, m := range matches {
+		for _, syn := range m.Synonyms {
+			b.WriteString(" ")
+			b.WriteString(syn)
+		}
+	}
+	return b.String()
+}
+
+// KeywordQuery rewrites a web search syntax query to also match it with
+// dictionary terms replaced by their synonyms: "k8s pods" becomes
+// "k8s pods OR kubernetes pods". At most maxVariants alternatives are
+// joined, the query itself first; quotes and exclusions are kept since
+// only the terms' words are replaced.
+func (d *Dictionary) KeywordQuery(query string, maxVariants int) string {
+	matches := d.Matches(query)
+	if len(matches) == 0 || maxVariants < 2 {
+		return query
+	}
+
+	// Count through the combinations of each match's term and synonyms, the
+	// last match varying fastest
+	choices := make([]int, len(matches))
+	variants := []string{query}
+	for len(variants) < maxVariants {
+		i := len(choices) - 1
+		for ; i >= 0; i-- {
+			choices[i]++
+			if choices[i] <= len(matches[i].Synonyms) {
+				break
+			}
+			choices[i] = 0
+		}
+		if i < 0 {
+			break
+		}
+		variants = append(variants, substitute(query, matches, choices))
+	}
+	return strings.Join(variants, " OR ")
+}
+
+// substitute replaces each match with the synonym chosen for it; choice 0
+// keeps the term
+func substitute(query string, matches []Match, choices []int) string {
+	var b strings.Builder
+	last := 0
+	for i, m := range matches {
+		if choices[i] == 0 {
+			continue
+		}
+		b.WriteString(query[last:m.start])
+		b.WriteString(m.Synonyms[choices[i]-1])
+		last = m.end
+	}
+	b.WriteString(query[last:])
+	return b.String()
+}
+
+// word is a lowercased run of letters and digits and its byte offsets
+type word struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into words of letters and digits
+func tokenize(text string) []word {
+	var words []word
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if inWord && start < 0 {
+			start = i
+		} else if !inWord && start >= 0 {
+			words = append(words, word{text: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{text: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return words
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package synonym
+
+import (
+	"testing"
+)
+
+func testDictionary() *Dictionary {
+	return NewDictionary([]Entry{
+		{Term: "K8s", Synonyms: []string{"Kubernetes"}},
+		{Term: "ML", Synonyms: []string{"machine learning", "ml"}},
+		{Term: "machine learning ops", Synonyms: []string{"MLOps"}},
+		{Term: "db", Synonyms: []string{"database", "DATABASE", "datastore"}},
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"K8s":                 "k8s",
+		"  Machine-Learning ": "machine learning",
+		"C++, Go!":            "c go",
+		"...":                 "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDictionary_Expand(t *testing.T) {
+	d := testDictionary()
+	tests := []struct {
+		query, want string
+	}{
+		{"deploy k8s", "deploy k8s Kubernetes"},
+		{"K8S and ML", "K8S and ML Kubernetes machine learning"},
+		{"no terms here", "no terms here"},
+		// The longest term wins, so "machine learning" is not also expanded
+		{"Machine learning ops tools", "Machine learning ops tools MLOps"},
+		{"db", "db database datastore"},
+	}
+	for _, tt := range tests {
+		if got := d.Expand(tt.query); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDictionary_KeywordQuery(t *testing.T) {
+	d := testDictionary()
+	tests := []struct {
+		query       string
+		maxVariants int
+		want        string
+	}{
+		{"k8s pods", 8, "k8s pods OR Kubernetes pods"},
+		{`"k8s cluster" -docker`, 8, `"k8s cluster" -docker OR "Kubernetes cluster" -docker`},
+		{"k8s db", 8, "k8s db OR k8s database OR k8s datastore OR Kubernetes db OR Kubernetes database OR Kubernetes datastore"},
+		{"k8s db", 3, "k8s db OR k8s database OR k8s datastore"},
+		{"k8s pods", 1, "k8s pods"},
+		{"plain query", 8, "plain query"},
+	}
+	for _, tt := range tests {
+		if got := d.KeywordQuery(tt.query, tt.maxVariants); got != tt.want {
+			t.Errorf("KeywordQuery(%q, %d) = %q, want %q", tt.query, tt.maxVariants, got, tt.want)
+		}
+	}
+}
+
+func TestDictionary_Empty(t *testing.T) {
+	var d *Dictionary
+	if got := d.Expand("k8s"); got != "k8s" {
+		t.Errorf("nil dictionary Expand = %q", got)
+	}
+	if got := NewDictionary(nil).KeywordQuery("k8s", 8); got != "k8s" {
+		t.Errorf("empty dictionary KeywordQuery = %q", got)
+	}
+}
@@ -0,0 +1,114 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// MigrateToShared copies a tenant's per-tenant collection into the shared
+// collection, tagging each point with the tenant ID, and returns the number of
+// points copied. The copy is idempotent since point IDs are kept, so an
+// interrupted migration can be rerun. The source collection is deleted
+// afterwards if deleteSource is set; a tenant without one is skipped.
+func (s *QdrantStore) MigrateToShared(ctx context.Context, tenantID string, deleteSource bool) (uint64, error) {
+	if s.shared == "" {
+		return 0, errors.New("no shared collection configured")
+	}
+
+	source := s.collectionName(tenantID)
+	exists, err := s.tenantCollectionExists(ctx, source)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	info, err := s.client.GetCollectionInfo(ctx, source)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get collection info: %w", err)
+	}
+	vectors := info.GetConfig().GetParams().GetVectorsConfig()
+	dimension := vectors.GetParams().GetSize()
+	if dimension == 0 {
+		// Hybrid collections keep the dense vector under a name
+		dimension = vectors.GetParamsMap().GetMap()[denseVectorName].GetSize()
+	}
+	if err := s.ensureShared(ctx, int(dimension)); err != nil {
+		return 0, err
+	}
+
+	var copied uint64
+	var offset *qdrant.PointId
+	for {
+		page, next, err := s.client.ScrollAndOffset(ctx, &qdrant.ScrollPoints{
+			CollectionName: source,
+			Offset:         offset,
+			Limit:          qdrant.PtrOf(uint32(migrateBatchSize)),
+			WithPayload:    qdrant.NewWithPayload(true),
+			WithVectors:    qdrant.NewWithVectors(true),
+		})
+		if err != nil {
+			return copied, fmt.Errorf("failed to scroll %s: %w", source, err)
+		}
+
+		points := make([]*qdrant.PointStruct, 0, len(page))
+		for _, p := range page {
+			vector := denseVector(p.GetVectors())
+			if len(vector) == 0 {
+				continue
+			}
+			payload := make(map[string]*qdrant.Value, len(p.GetPayload())+1)
+			for k, v := range p.GetPayload() {
+				payload[k] = v
+			}
+			payload[tenantField] = qdrant.NewValueString(tenantID)
+			points = append(points, &qdrant.PointStruct{
+				Id:      p.GetId(),
+				Vectors: qdrant.NewVectorsDense(vector),
+				Payload: payload,
+			})
+		}
+
+		if len(points) > 0 {
+			_, err = s.client.Upsert(ctx, &qdrant.UpsertPoints{
+				CollectionName: s.shared,
+				Wait:           qdrant.PtrOf(true),
+				Points:         points,
+			})
+			if err != nil {
+				return copied, fmt.Errorf("failed to upsert points: %w", err)
+			}
+			copied += uint64(len(points))
+		}
+
+		if next == nil {
+			break
+		}
+		offset = next
+	}
+
+	if deleteSource {
+		if err := s.deleteTenantCollection(ctx, source); err != nil {
+			return copied, err
+		}
+	}
+
+	return copied, nil
+}
+
+// denseVector returns a point's dense vector, unnamed or stored under the
+// hybrid collection's dense name. Sparse vectors are not carried over.
+func denseVector(v *qdrant.VectorsOutput) []float32 {
+	out := v.GetVector()
+	if out == nil {
+		out = v.GetVectors().GetVectors()[denseVectorName]
+	}
+	if dense := out.GetDense(); dense != nil {
+		return dense.GetData()
+	}
+	return out.GetData()
+}
@@ -2,10 +2,14 @@ package vectorstore
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/qdrant/go-client/qdrant"
 )
 
@@ -13,16 +17,67 @@ const (
 	// Vector field names for hybrid search
 	denseVectorName  = "dense"
 	sparseVectorName = "sparse"
+
+	// Payload fields used by shared collection mode, hidden from search results
+	tenantField  = "tenant_id"
+	versionField = "collection_version"
+
+	// chunkIDField holds the chunk ID of a point staged for a collection
+	// version in shared mode, whose point ID is derived from it
+	chunkIDField = "chunk_id"
+
+	// tagsField and collectionsField hold a chunk's document tags and
+	// collection IDs as lists, for filtering
+	tagsField        = "tags"
+	collectionsField = "collections"
+
+	// languageField is the chunk metadata key holding its ISO 639-1 language
+	languageField = "language"
+
+	// projectField holds the project of a chunk's document, when it has one
+	projectField = "project_id"
+
+	// initialVersion is the collection version a per-tenant collection starts at
+	initialVersion = "initial"
+
+	// migrateBatchSize is the number of points copied per scroll page during migration
+	migrateBatchSize = 256
 )
 
 // QdrantStore implements VectorStore using Qdrant
 type QdrantStore struct {
 	client *qdrant.Client
+
+	// shared is the collection holding every tenant's points, partitioned by
+	// the tenant_id payload. Empty means one collection per tenant.
+	shared        string
+	sharedStorage StorageConfig
+
+	retry  RetryConfig
+	conn   ConnectionConfig
+	tls    *TLSConfig
+	apiKey string
+}
+
+// QdrantOption configures a QdrantStore
+type QdrantOption func(*QdrantStore)
+
+// WithSharedCollection stores all tenants in one collection partitioned by a
+// tenant_id payload index instead of a collection per tenant. Every tenant must
+// then use the same embedding dimension, and per-tenant storage options are
+// replaced by storage, which applies when the shared collection is created.
+func WithSharedCollection(name string, storage StorageConfig) QdrantOption {
+	return func(s *QdrantStore) {
+		s.shared = name
+		s.sharedStorage = storage
+	}
 }
 
 // NewQdrantStore creates a new Qdrant vector store client
-// url should be in format "host:port" (e.g., "localhost:6334")
-func NewQdrantStore(ctx context.Context, url string) (*QdrantStore, error) {
+// url should be in format "host:port" (e.g., "localhost:6334"); an https://
+// prefix enables TLS with the system roots
+func NewQdrantStore(ctx context.Context, url string, opts ...QdrantOption) (*QdrantStore, error) {
+	url, useTLS := splitScheme(url)
 	host, portStr, err := net.SplitHostPort(url)
 	if err != nil {
 		// If no port specified, assume default
@@ -35,15 +90,42 @@ func NewQdrantStore(ctx context.Context, url string) (*QdrantStore, error) {
 		return nil, fmt.Errorf("invalid port in qdrant url: %w", err)
 	}
 
+	s := &QdrantStore{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if useTLS && s.tls == nil {
+		s.tls = &TLSConfig{}
+	}
+
+	var tlsConfig *tls.Config
+	if s.tls != nil {
+		if tlsConfig, err = s.tls.build(); err != nil {
+			return nil, err
+		}
+	}
+
+	keepAlive := seconds(s.conn.KeepAliveTime)
+	if s.conn.KeepAliveTime < 0 {
+		keepAlive = -1
+	}
 	client, err := qdrant.NewClient(&qdrant.Config{
-		Host: host,
-		Port: port,
+		Host:             host,
+		Port:             port,
+		APIKey:           s.apiKey,
+		UseTLS:           tlsConfig != nil,
+		TLSConfig:        tlsConfig,
+		PoolSize:         uint(max(s.conn.PoolSize, 0)),
+		KeepAliveTime:    keepAlive,
+		KeepAliveTimeout: uint(seconds(s.conn.KeepAliveTimeout)),
+		GrpcOptions:      s.dialOptions(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create qdrant client: %w", err)
 	}
+	s.client = client
 
-	return &QdrantStore{client: client}, nil
+	return s, nil
 }
 
 // Close closes the Qdrant client connection
@@ -51,22 +133,103 @@ func (s *QdrantStore) Close() error {
 	return s.client.Close()
 }
 
-// collectionName returns the collection name for a tenant
+// collectionName returns the collection name for a tenant.
+// This name is an alias pointing at the current collection version, except for
+// collections created before tenants started with one that have not been reindexed.
 func (s *QdrantStore) collectionName(tenantID string) string {
 	return fmt.Sprintf("tenant_%s", tenantID)
 }
 
+// versionName returns the physical collection name for a tenant collection version
+func (s *QdrantStore) versionName(tenantID, version string) string {
+	return fmt.Sprintf("tenant_%s_%s", tenantID, version)
+}
+
+// stagingKey is the tenant_id payload of a collection version's points in shared mode
+func (s *QdrantStore) stagingKey(tenantID, version string) string {
+	return tenantID + "@" + version
+}
+
+// stagedPointID is the point ID of a chunk staged for a collection version in
+// shared mode. It differs from the chunk's live point, which stays searchable
+// until the switch and survives an aborted rebuild.
+func stagedPointID(version, chunkID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(version+"/"+chunkID)).String()
+}
+
+// scope returns the collection holding a tenant's points and the filter that
+// selects them, combined with any extra conditions. In per-tenant mode the
+// filter is nil unless conditions are given.
+func (s *QdrantStore) scope(tenantKey string, conditions ...*qdrant.Condition) (string, *qdrant.Filter) {
+	if s.shared != "" {
+		must := append([]*qdrant.Condition{qdrant.NewMatchKeyword(tenantField, tenantKey)}, conditions...)
+		return s.shared, &qdrant.Filter{Must: must}
+	}
+	if len(conditions) == 0 {
+		return s.collectionName(tenantKey), nil
+	}
+	return s.collectionName(tenantKey), &qdrant.Filter{Must: conditions}
+}
+
+// conditions converts a search filter to Qdrant conditions
+func (f Filter) conditions() []*qdrant.Condition {
+	var conditions []*qdrant.Condition
+	if len(f.Tags) > 0 {
+		conditions = append(conditions, qdrant.NewMatchKeywords(tagsField, f.Tags...))
+	}
+	if len(f.CollectionIDs) > 0 {
+		conditions = append(conditions, qdrant.NewMatchKeywords(collectionsField, f.CollectionIDs...))
+	}
+	if len(f.Languages) > 0 {
+		conditions = append(conditions, qdrant.NewMatchKeywords(languageField, f.Languages...))
+	}
+	if f.ProjectID != "" {
+		conditions = append(conditions, qdrant.NewMatchKeyword(projectField, f.ProjectID))
+	}
+	return conditions
+}
+
+// listValue stores strings as a list so a keyword match tests membership
+func listValue(items []string) *qdrant.Value {
+	values := make([]*qdrant.Value, len(items))
+	for i, item := range items {
+		values[i] = qdrant.NewValueString(item)
+	}
+	return qdrant.NewValueFromList(values...)
+}
+
+// isReservedField reports whether a payload key is stored by the vector store
+// itself rather than taken from chunk metadata
+func isReservedField(key string) bool {
+	switch key {
+	case "document_id", "content", tenantField, versionField, chunkIDField, tagsField, collectionsField, projectField:
+		return true
+	}
+	return false
+}
+
+// resolveAlias returns the collection an alias points to, if the name is an alias
+func (s *QdrantStore) resolveAlias(ctx context.Context, name string) (string, bool, error) {
+	aliases, err := s.client.ListAliases(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	for _, alias := range aliases {
+		if alias.GetAliasName() == name {
+			return alias.GetCollectionName(), true, nil
+		}
+	}
+	return "", false, nil
+}
+
 // CreateCollection creates a new collection for a tenant (dense vectors only)
-func (s *QdrantStore) CreateCollection(ctx context.Context, tenantID string, dimension int) error {
-	name := s.collectionName(tenantID)
+// In shared mode this only ensures the shared collection exists with a matching dimension.
+func (s *QdrantStore) CreateCollection(ctx context.Context, tenantID string, dimension int, storage StorageConfig) error {
+	if s.shared != "" {
+		return s.ensureShared(ctx, dimension)
+	}
 
-	err := s.client.CreateCollection(ctx, &qdrant.CreateCollection{
-		CollectionName: name,
-		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
-			Size:     uint64(dimension),
-			Distance: qdrant.Distance_Cosine,
-		}),
-	})
+	err := s.createAliased(ctx, tenantID, denseCollection("", dimension, storage))
 	if err != nil {
 		return fmt.Errorf("failed to create collection: %w", err)
 	}
@@ -75,21 +238,26 @@ func (s *QdrantStore) CreateCollection(ctx context.Context, tenantID string, dim
 }
 
 // CreateHybridCollection creates a collection with both dense and sparse vector support
-func (s *QdrantStore) CreateHybridCollection(ctx context.Context, tenantID string, dimension int) error {
-	name := s.collectionName(tenantID)
+func (s *QdrantStore) CreateHybridCollection(ctx context.Context, tenantID string, dimension int, storage StorageConfig) error {
+	if s.shared != "" {
+		return errors.New("hybrid collections are not supported in shared collection mode")
+	}
 
-	err := s.client.CreateCollection(ctx, &qdrant.CreateCollection{
-		CollectionName: name,
+	req := &qdrant.CreateCollection{
 		VectorsConfig: qdrant.NewVectorsConfigMap(map[string]*qdrant.VectorParams{
 			denseVectorName: {
 				Size:     uint64(dimension),
 				Distance: qdrant.Distance_Cosine,
+				OnDisk:   optionalTrue(storage.OnDiskVectors),
 			},
 		}),
 		SparseVectorsConfig: qdrant.NewSparseVectorsConfig(map[string]*qdrant.SparseVectorParams{
 			sparseVectorName: {}, // Use default sparse vector config
 		}),
-	})
+	}
+	applyStorage(req, storage)
+
+	err := s.createAliased(ctx, tenantID, req)
 	if err != nil {
 		return fmt.Errorf("failed to create hybrid collection: %w", err)
 	}
@@ -97,11 +265,237 @@ func (s *QdrantStore) CreateHybridCollection(ctx context.Context, tenantID strin
 	return nil
 }
 
-// DeleteCollection deletes a tenant's collection
+// createAliased creates a tenant's first collection version and points the
+// tenant's alias at it, so reindexes can switch versions atomically
+func (s *QdrantStore) createAliased(ctx context.Context, tenantID string, req *qdrant.CreateCollection) error {
+	req.CollectionName = s.versionName(tenantID, initialVersion)
+	if err := s.client.CreateCollection(ctx, req); err != nil {
+		return err
+	}
+	if err := s.createTagsIndex(ctx, req.CollectionName); err != nil {
+		_ = s.client.DeleteCollection(ctx, req.CollectionName)
+		return err
+	}
+	if err := s.client.CreateAlias(ctx, s.collectionName(tenantID), req.CollectionName); err != nil {
+		_ = s.client.DeleteCollection(ctx, req.CollectionName)
+		return err
+	}
+	return nil
+}
+
+// createTagsIndex indexes a tenant collection's tags, which tag-scoped
+// retrieval filters on
+func (s *QdrantStore) createTagsIndex(ctx context.Context, name string) error {
+	_, err := s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: name,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      tagsField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tags index: %w", err)
+	}
+	return nil
+}
+
+// ensureShared creates the shared collection on first use, with a tenant
+// payload index so Qdrant co-locates and indexes each tenant's points
+func (s *QdrantStore) ensureShared(ctx context.Context, dimension int) error {
+	exists, err := s.client.CollectionExists(ctx, s.shared)
+	if err != nil {
+		return fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if exists {
+		return s.checkSharedDimension(ctx, dimension)
+	}
+
+	req := denseCollection(s.shared, dimension, s.sharedStorage)
+	// Build HNSW graphs per tenant rather than one global graph; every
+	// search is filtered to a single tenant
+	req.HnswConfig = &qdrant.HnswConfigDiff{
+		M:        qdrant.PtrOf(uint64(0)),
+		PayloadM: qdrant.PtrOf(uint64(16)),
+	}
+	if err := s.client.CreateCollection(ctx, req); err != nil {
+		// Another replica may have created it concurrently
+		if exists, _ := s.client.CollectionExists(ctx, s.shared); exists {
+			return s.checkSharedDimension(ctx, dimension)
+		}
+		return fmt.Errorf("failed to create shared collection: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      tenantField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+		FieldIndexParams: qdrant.NewPayloadIndexParamsKeyword(&qdrant.KeywordIndexParams{
+			IsTenant: qdrant.PtrOf(true),
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tenant index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      "document_id",
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create document index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      chunkIDField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create chunk index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      tagsField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tags index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      collectionsField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create collections index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      languageField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create language index: %w", err)
+	}
+
+	_, err = s.client.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		FieldName:      projectField,
+		FieldType:      qdrant.FieldType_FieldTypeKeyword.Enum(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create project index: %w", err)
+	}
+
+	return nil
+}
+
+// checkSharedDimension returns ErrDimensionMismatch if the shared collection
+// was created for a different vector size
+func (s *QdrantStore) checkSharedDimension(ctx context.Context, dimension int) error {
+	info, err := s.client.GetCollectionInfo(ctx, s.shared)
+	if err != nil {
+		return fmt.Errorf("failed to get collection info: %w", err)
+	}
+	size := info.GetConfig().GetParams().GetVectorsConfig().GetParams().GetSize()
+	if size != uint64(dimension) {
+		return fmt.Errorf("%w: shared collection %s has dimension %d, got %d", ErrDimensionMismatch, s.shared, size, dimension)
+	}
+	return nil
+}
+
+// denseCollection builds the request for a single unnamed dense vector collection
+func denseCollection(name string, dimension int, storage StorageConfig) *qdrant.CreateCollection {
+	req := &qdrant.CreateCollection{
+		CollectionName: name,
+		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
+			Size:     uint64(dimension),
+			Distance: qdrant.Distance_Cosine,
+			OnDisk:   optionalTrue(storage.OnDiskVectors),
+		}),
+	}
+	applyStorage(req, storage)
+	return req
+}
+
+// applyStorage sets the collection-level quantization and payload storage options
+func applyStorage(req *qdrant.CreateCollection, storage StorageConfig) {
+	req.OnDiskPayload = optionalTrue(storage.OnDiskPayload)
+
+	// Quantized vectors are what search scans, so keep them in RAM even when
+	// the originals (used for rescoring) are on disk
+	switch storage.Quantization {
+	case QuantizationScalar:
+		req.QuantizationConfig = qdrant.NewQuantizationScalar(&qdrant.ScalarQuantization{
+			Type:      qdrant.QuantizationType_Int8,
+			AlwaysRam: qdrant.PtrOf(true),
+		})
+	case QuantizationBinary:
+		req.QuantizationConfig = qdrant.NewQuantizationBinary(&qdrant.BinaryQuantization{
+			AlwaysRam: qdrant.PtrOf(true),
+		})
+	}
+}
+
+// optionalTrue returns a pointer for true and nil otherwise, leaving Qdrant's default in place
+func optionalTrue(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return qdrant.PtrOf(true)
+}
+
+// DeleteCollection deletes a tenant's collection, or its points in shared mode
 func (s *QdrantStore) DeleteCollection(ctx context.Context, tenantID string) error {
-	name := s.collectionName(tenantID)
+	if s.shared != "" {
+		return s.deletePoints(ctx, s.shared, s.tenantOnly(tenantID), "failed to delete tenant points")
+	}
+	return s.deleteTenantCollection(ctx, s.collectionName(tenantID))
+}
+
+// tenantOnly returns the filter selecting a tenant's points in the shared collection
+func (s *QdrantStore) tenantOnly(tenantKey string) *qdrant.Filter {
+	_, filter := s.scope(tenantKey)
+	return filter
+}
+
+// deletePoints deletes the points matching filter
+func (s *QdrantStore) deletePoints(ctx context.Context, name string, filter *qdrant.Filter, msg string) error {
+	_, err := s.client.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: name,
+		Wait:           qdrant.PtrOf(true),
+		Points:         qdrant.NewPointsSelectorFilter(filter),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// deleteTenantCollection deletes a per-tenant collection and its alias, if any
+func (s *QdrantStore) deleteTenantCollection(ctx context.Context, name string) error {
+	target, isAlias, err := s.resolveAlias(ctx, name)
+	if err != nil {
+		return err
+	}
+	if isAlias {
+		if err := s.client.DeleteAlias(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete alias: %w", err)
+		}
+		name = target
+	}
 
-	err := s.client.DeleteCollection(ctx, name)
+	err = s.client.DeleteCollection(ctx, name)
 	if err != nil {
 		return fmt.Errorf("failed to delete collection: %w", err)
 	}
@@ -110,26 +504,191 @@ func (s *QdrantStore) DeleteCollection(ctx context.Context, tenantID string) err
 }
 
 // CollectionExists checks if a collection exists
+// In shared mode it reports whether the shared collection exists.
 func (s *QdrantStore) CollectionExists(ctx context.Context, tenantID string) (bool, error) {
-	name := s.collectionName(tenantID)
+	if s.shared != "" {
+		exists, err := s.client.CollectionExists(ctx, s.shared)
+		if err != nil {
+			return false, fmt.Errorf("failed to check collection existence: %w", err)
+		}
+		return exists, nil
+	}
+	return s.tenantCollectionExists(ctx, s.collectionName(tenantID))
+}
 
+// tenantCollectionExists checks for a per-tenant collection or alias
+func (s *QdrantStore) tenantCollectionExists(ctx context.Context, name string) (bool, error) {
 	exists, err := s.client.CollectionExists(ctx, name)
 	if err != nil {
 		return false, fmt.Errorf("failed to check collection existence: %w", err)
 	}
+	if exists {
+		return true, nil
+	}
+
+	_, isAlias, err := s.resolveAlias(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return isAlias, nil
+}
+
+// CountVectors returns the exact number of points in a tenant's collection
+func (s *QdrantStore) CountVectors(ctx context.Context, tenantID string) (uint64, error) {
+	name, filter := s.scope(tenantID)
+	count, err := s.client.Count(ctx, &qdrant.CountPoints{
+		CollectionName: name,
+		Filter:         filter,
+		Exact:          qdrant.PtrOf(true),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count points: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateCollectionVersion creates a dense collection for rebuilding a tenant's vectors
+// In shared mode versions are staged in the shared collection under a separate tenant key.
+func (s *QdrantStore) CreateCollectionVersion(ctx context.Context, tenantID, version string, dimension int, storage StorageConfig) error {
+	if s.shared != "" {
+		return s.ensureShared(ctx, dimension)
+	}
+
+	name := s.versionName(tenantID, version)
+
+	err := s.client.CreateCollection(ctx, denseCollection(name, dimension, storage))
+	if err != nil {
+		return fmt.Errorf("failed to create collection version: %w", err)
+	}
+	if err := s.createTagsIndex(ctx, name); err != nil {
+		_ = s.client.DeleteCollection(ctx, name)
+		return err
+	}
+
+	return nil
+}
+
+// SwitchCollectionVersion points the tenant alias at the given version and drops the previous collection.
+// Tenants' collections start behind an alias, so the switch is atomic. Collections created before
+// that, still under the tenant's name, are dropped first, since an alias cannot shadow a collection name.
+func (s *QdrantStore) SwitchCollectionVersion(ctx context.Context, tenantID, version string) error {
+	if s.shared != "" {
+		return s.switchSharedVersion(ctx, tenantID, version)
+	}
+
+	alias := s.collectionName(tenantID)
+	target := s.versionName(tenantID, version)
+
+	previous, isAlias, err := s.resolveAlias(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	if isAlias {
+		err := s.client.UpdateAliases(ctx, []*qdrant.AliasOperations{
+			qdrant.NewAliasDelete(alias),
+			qdrant.NewAliasCreate(alias, target),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to switch alias: %w", err)
+		}
+		if previous != target {
+			if err := s.client.DeleteCollection(ctx, previous); err != nil {
+				return fmt.Errorf("failed to delete previous collection: %w", err)
+			}
+		}
+		return nil
+	}
+
+	exists, err := s.client.CollectionExists(ctx, alias)
+	if err != nil {
+		return fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if exists {
+		if err := s.client.DeleteCollection(ctx, alias); err != nil {
+			return fmt.Errorf("failed to delete previous collection: %w", err)
+		}
+	}
+
+	if err := s.client.CreateAlias(ctx, alias, target); err != nil {
+		return fmt.Errorf("failed to create alias: %w", err)
+	}
 
-	return exists, nil
+	return nil
+}
+
+// switchSharedVersion moves staged points to the tenant and then drops the
+// tenant's older points. Searches briefly see both sets rather than neither.
+func (s *QdrantStore) switchSharedVersion(ctx context.Context, tenantID, version string) error {
+	_, err := s.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: s.shared,
+		Wait:           qdrant.PtrOf(true),
+		Payload:        qdrant.NewValueMap(map[string]any{tenantField: tenantID}),
+		PointsSelector: qdrant.NewPointsSelectorFilter(s.tenantOnly(s.stagingKey(tenantID, version))),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to promote collection version: %w", err)
+	}
+
+	previous := s.tenantOnly(tenantID)
+	previous.MustNot = []*qdrant.Condition{qdrant.NewMatchKeyword(versionField, version)}
+	return s.deletePoints(ctx, s.shared, previous, "failed to delete previous points")
+}
+
+// DeleteCollectionVersion deletes a collection version
+func (s *QdrantStore) DeleteCollectionVersion(ctx context.Context, tenantID, version string) error {
+	if s.shared != "" {
+		return s.deletePoints(ctx, s.shared, s.tenantOnly(s.stagingKey(tenantID, version)), "failed to delete collection version")
+	}
+
+	err := s.client.DeleteCollection(ctx, s.versionName(tenantID, version))
+	if err != nil {
+		return fmt.Errorf("failed to delete collection version: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteVersionDocument removes a document's chunks from a collection version
+func (s *QdrantStore) DeleteVersionDocument(ctx context.Context, tenantID, version, documentID string) error {
+	match := qdrant.NewMatch("document_id", documentID)
+	if s.shared != "" {
+		filter := s.tenantOnly(s.stagingKey(tenantID, version))
+		filter.Must = append(filter.Must, match)
+		return s.deletePoints(ctx, s.shared, filter, "failed to delete from collection version")
+	}
+	return s.deletePoints(ctx, s.versionName(tenantID, version), &qdrant.Filter{Must: []*qdrant.Condition{match}}, "failed to delete from collection version")
 }
 
 // Upsert inserts or updates chunks in the vector store
 // Supports both dense-only and hybrid (dense + sparse) collections
 func (s *QdrantStore) Upsert(ctx context.Context, tenantID string, chunks []Chunk) error {
+	if s.shared != "" {
+		return s.upsert(ctx, s.shared, map[string]string{tenantField: tenantID}, chunks)
+	}
+	return s.upsert(ctx, s.collectionName(tenantID), nil, chunks)
+}
+
+// UpsertVersion inserts or updates chunks in a collection version. In shared
+// mode staged points get their own IDs, leaving the tenant's live points alone.
+func (s *QdrantStore) UpsertVersion(ctx context.Context, tenantID, version string, chunks []Chunk) error {
+	if s.shared != "" {
+		return s.upsert(ctx, s.shared, map[string]string{
+			tenantField:  s.stagingKey(tenantID, version),
+			versionField: version,
+		}, chunks)
+	}
+	return s.upsert(ctx, s.versionName(tenantID, version), nil, chunks)
+}
+
+// upsert writes chunks to a collection, adding the reserved payload fields to every point
+func (s *QdrantStore) upsert(ctx context.Context, name string, reserved map[string]string, chunks []Chunk) error {
 	if len(chunks) == 0 {
 		return nil
 	}
 
-	name := s.collectionName(tenantID)
-
 	points := make([]*qdrant.PointStruct, len(chunks))
 	for i, chunk := range chunks {
 		payload := map[string]*qdrant.Value{
@@ -139,9 +698,22 @@ func (s *QdrantStore) Upsert(ctx context.Context, tenantID string, chunks []Chun
 		for k, v := range chunk.Metadata {
 			payload[k] = qdrant.NewValueString(v)
 		}
+		payload[tagsField] = listValue(chunk.Tags)
+		payload[collectionsField] = listValue(chunk.CollectionIDs)
+		if chunk.ProjectID != "" {
+			payload[projectField] = qdrant.NewValueString(chunk.ProjectID)
+		}
+		for k, v := range reserved {
+			payload[k] = qdrant.NewValueString(v)
+		}
 
+		id := chunk.ID
+		if version, ok := reserved[versionField]; ok {
+			id = stagedPointID(version, chunk.ID)
+			payload[chunkIDField] = qdrant.NewValueString(chunk.ID)
+		}
 		point := &qdrant.PointStruct{
-			Id:      qdrant.NewIDUUID(chunk.ID),
+			Id:      qdrant.NewIDUUID(id),
 			Payload: payload,
 		}
 
@@ -183,14 +755,17 @@ func (s *QdrantStore) Upsert(ctx context.Context, tenantID string, chunks []Chun
 }
 
 // Search performs similarity search
-func (s *QdrantStore) Search(ctx context.Context, tenantID string, vector []float32, topK int, minScore float32) ([]SearchResult, error) {
-	name := s.collectionName(tenantID)
+func (s *QdrantStore) Search(ctx context.Context, tenantID string, vector []float32, topK int, minScore float32, opts SearchOptions) ([]SearchResult, error) {
+	name, filter := s.scope(tenantID, opts.Filter.conditions()...)
 
 	response, err := s.client.Query(ctx, &qdrant.QueryPoints{
 		CollectionName: name,
+		Filter:         filter,
 		Query:          qdrant.NewQuery(vector...),
 		Limit:          qdrant.PtrOf(uint64(topK)),
-		WithPayload:    qdrant.NewWithPayload(true),
+		Offset:         qdrant.PtrOf(uint64(max(opts.Offset, 0))),
+		WithPayload:    payloadSelector(opts),
+		WithVectors:    qdrant.NewWithVectors(opts.WithVectors),
 		ScoreThreshold: qdrant.PtrOf(float32(minScore)),
 	})
 	if err != nil {
@@ -199,47 +774,92 @@ func (s *QdrantStore) Search(ctx context.Context, tenantID string, vector []floa
 
 	results := make([]SearchResult, 0, len(response))
 	for _, point := range response {
-		result := SearchResult{
-			ID:       point.Id.GetUuid(),
-			Score:    point.Score,
-			Metadata: make(map[string]string),
-		}
+		results = append(results, pointResult(point))
+	}
 
-		if payload := point.Payload; payload != nil {
-			if docID, ok := payload["document_id"]; ok {
-				result.DocumentID = docID.GetStringValue()
-			}
-			if content, ok := payload["content"]; ok {
-				result.Content = content.GetStringValue()
-			}
-			for k, v := range payload {
-				if k != "document_id" && k != "content" {
-					result.Metadata[k] = v.GetStringValue()
-				}
+	return results, nil
+}
+
+// payloadSelector returns the payload fields a search should fetch. The
+// document ID and content are always fetched.
+func payloadSelector(opts SearchOptions) *qdrant.WithPayloadSelector {
+	if len(opts.IncludeFields) > 0 {
+		fields := []string{"document_id", "content", chunkIDField}
+		for _, f := range opts.IncludeFields {
+			if !isReservedField(f) && !slices.Contains(opts.ExcludeFields, f) {
+				fields = append(fields, f)
 			}
 		}
+		return qdrant.NewWithPayloadInclude(fields...)
+	}
 
-		results = append(results, result)
+	var excluded []string
+	for _, f := range opts.ExcludeFields {
+		if !isReservedField(f) {
+			excluded = append(excluded, f)
+		}
+	}
+	if len(excluded) > 0 {
+		return qdrant.NewWithPayloadExclude(excluded...)
 	}
+	return qdrant.NewWithPayload(true)
+}
 
-	return results, nil
+// pointResult converts a scored point to a SearchResult
+func pointResult(point *qdrant.ScoredPoint) SearchResult {
+	result := SearchResult{
+		ID:       point.Id.GetUuid(),
+		Score:    point.Score,
+		Metadata: make(map[string]string),
+		Vector:   pointVector(point.Vectors),
+	}
+
+	if payload := point.Payload; payload != nil {
+		if docID, ok := payload["document_id"]; ok {
+			result.DocumentID = docID.GetStringValue()
+		}
+		if content, ok := payload["content"]; ok {
+			result.Content = content.GetStringValue()
+		}
+		if chunkID, ok := payload[chunkIDField]; ok {
+			result.ID = chunkID.GetStringValue()
+		}
+		for k, v := range payload {
+			if !isReservedField(k) {
+				result.Metadata[k] = v.GetStringValue()
+			}
+		}
+	}
+
+	return result
+}
+
+// pointVector returns a point's dense vector, whether the collection names
+// its vectors (hybrid) or not (dense only)
+func pointVector(vectors *qdrant.VectorsOutput) []float32 {
+	if vectors == nil {
+		return nil
+	}
+	vector := vectors.GetVector()
+	if named := vectors.GetVectors(); named != nil {
+		vector = named.GetVectors()[denseVectorName]
+	}
+	if vector == nil {
+		return nil
+	}
+	if dense := vector.GetDense(); dense != nil {
+		return dense.GetData()
+	}
+	return vector.GetData() // older servers send dense vectors here
 }
 
 // Delete removes chunks by document ID
 func (s *QdrantStore) Delete(ctx context.Context, tenantID string, documentID string) error {
-	name := s.collectionName(tenantID)
+	name, filter := s.scope(tenantID, qdrant.NewMatch("document_id", documentID))
 
 	_, err := s.client.Delete(ctx, &qdrant.DeletePoints{
 		CollectionName: name,
-		Points: &qdrant.PointsSelector{
-			PointsSelectorOneOf: &qdrant.PointsSelector_Filter{
-				Filter: &qdrant.Filter{
-					Must: []*qdrant.Condition{
-						qdrant.NewMatch("document_id", documentID),
-					},
-				},
-			},
-		},
+		Points:         qdrant.NewPointsSelectorFilter(filter),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete by document ID: %w", err)
@@ -248,28 +868,64 @@ func (s *QdrantStore) Delete(ctx context.Context, tenantID string, documentID st
 	return nil
 }
 
-// DeleteByIDs removes specific chunks by their IDs
-func (s *QdrantStore) DeleteByIDs(ctx context.Context, tenantID string, ids []string) error {
-	if len(ids) == 0 {
+// SetDocumentTags replaces the tags stored with a document's chunks
+func (s *QdrantStore) SetDocumentTags(ctx context.Context, tenantID, documentID string, tags []string) error {
+	return s.setDocumentList(ctx, tenantID, documentID, tagsField, tags)
+}
+
+// SetDocumentCollections replaces the collection IDs stored with a document's chunks
+func (s *QdrantStore) SetDocumentCollections(ctx context.Context, tenantID, documentID string, collectionIDs []string) error {
+	return s.setDocumentList(ctx, tenantID, documentID, collectionsField, collectionIDs)
+}
+
+// setDocumentList overwrites a list payload field on every chunk of a document
+func (s *QdrantStore) setDocumentList(ctx context.Context, tenantID, documentID, field string, items []string) error {
+	name, filter := s.scope(tenantID, qdrant.NewMatch("document_id", documentID))
+
+	_, err := s.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: name,
+		Payload:        map[string]*qdrant.Value{field: listValue(items)},
+		PointsSelector: qdrant.NewPointsSelectorFilter(filter),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set document %s: %w", field, err)
+	}
+
+	return nil
+}
+
+// SetChunkLists replaces the tags and collection IDs stored with specific chunks
+func (s *QdrantStore) SetChunkLists(ctx context.Context, tenantID string, chunkIDs, tags, collectionIDs []string) error {
+	if len(chunkIDs) == 0 {
 		return nil
 	}
 
-	name := s.collectionName(tenantID)
+	name, points := s.chunkPoints(tenantID, chunkIDs)
+	_, err := s.client.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: name,
+		Payload: map[string]*qdrant.Value{
+			tagsField:        listValue(tags),
+			collectionsField: listValue(collectionIDs),
+		},
+		PointsSelector: points,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set chunk lists: %w", err)
+	}
 
-	pointIDs := make([]*qdrant.PointId, len(ids))
-	for i, id := range ids {
-		pointIDs[i] = qdrant.NewIDUUID(id)
+	return nil
+}
+
+// DeleteByIDs removes specific chunks by their IDs
+func (s *QdrantStore) DeleteByIDs(ctx context.Context, tenantID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
+	name, points := s.chunkPoints(tenantID, ids)
 	_, err := s.client.Delete(ctx, &qdrant.DeletePoints{
 		CollectionName: name,
-		Points: &qdrant.PointsSelector{
-			PointsSelectorOneOf: &qdrant.PointsSelector_Points{
-				Points: &qdrant.PointsIdsList{
-					Ids: pointIDs,
-				},
-			},
-		},
+		Points:         points,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete by IDs: %w", err)
@@ -278,27 +934,55 @@ func (s *QdrantStore) DeleteByIDs(ctx context.Context, tenantID string, ids []st
 	return nil
 }
 
-// HybridSearch performs hybrid search combining dense and sparse vectors with RRF fusion
-func (s *QdrantStore) HybridSearch(ctx context.Context, tenantID string, denseVector []float32, sparseVector *SparseVector, topK int, minScore float32) ([]SearchResult, error) {
+// chunkPoints returns the collection holding a tenant's chunks and a
+// selector of the points of the chunks with the given IDs
+func (s *QdrantStore) chunkPoints(tenantID string, ids []string) (string, *qdrant.PointsSelector) {
+	pointIDs := make([]*qdrant.PointId, len(ids))
+	for i, id := range ids {
+		pointIDs[i] = qdrant.NewIDUUID(id)
+	}
+
+	// In shared mode the IDs must also belong to the tenant, and chunks
+	// promoted from a collection version are stored under derived point IDs
+	points := qdrant.NewPointsSelectorIDs(pointIDs)
 	name := s.collectionName(tenantID)
+	if s.shared != "" {
+		var filter *qdrant.Filter
+		name, filter = s.scope(tenantID, qdrant.NewFilterAsCondition(&qdrant.Filter{
+			Should: []*qdrant.Condition{
+				qdrant.NewHasID(pointIDs...),
+				qdrant.NewMatchKeywords(chunkIDField, ids...),
+			},
+		}))
+		points = qdrant.NewPointsSelectorFilter(filter)
+	}
+	return name, points
+}
+
+// HybridSearch performs hybrid search combining dense and sparse vectors with RRF fusion
+func (s *QdrantStore) HybridSearch(ctx context.Context, tenantID string, denseVector []float32, sparseVector *SparseVector, topK int, minScore float32, opts SearchOptions) ([]SearchResult, error) {
+	name, filter := s.scope(tenantID, opts.Filter.conditions()...)
+	offset := max(opts.Offset, 0)
 
 	// Build prefetch queries for both dense and sparse
-	prefetchLimit := uint64(topK * 2) // Get more candidates for fusion
+	prefetchLimit := uint64((topK + offset) * 2) // Get more candidates for fusion
 
 	prefetch := []*qdrant.PrefetchQuery{
 		{
-			Query: qdrant.NewQueryDense(denseVector),
-			Using: qdrant.PtrOf(denseVectorName),
-			Limit: qdrant.PtrOf(prefetchLimit),
+			Query:  qdrant.NewQueryDense(denseVector),
+			Using:  qdrant.PtrOf(denseVectorName),
+			Limit:  qdrant.PtrOf(prefetchLimit),
+			Filter: filter,
 		},
 	}
 
 	// Add sparse prefetch if sparse vector is provided
 	if sparseVector != nil && len(sparseVector.Indices) > 0 {
 		prefetch = append(prefetch, &qdrant.PrefetchQuery{
-			Query: qdrant.NewQuerySparse(sparseVector.Indices, sparseVector.Values),
-			Using: qdrant.PtrOf(sparseVectorName),
-			Limit: qdrant.PtrOf(prefetchLimit),
+			Query:  qdrant.NewQuerySparse(sparseVector.Indices, sparseVector.Values),
+			Using:  qdrant.PtrOf(sparseVectorName),
+			Limit:  qdrant.PtrOf(prefetchLimit),
+			Filter: filter,
 		})
 	}
 
@@ -306,9 +990,12 @@ func (s *QdrantStore) HybridSearch(ctx context.Context, tenantID string, denseVe
 	response, err := s.client.Query(ctx, &qdrant.QueryPoints{
 		CollectionName: name,
 		Prefetch:       prefetch,
+		Filter:         filter,
 		Query:          qdrant.NewQueryFusion(qdrant.Fusion_RRF),
 		Limit:          qdrant.PtrOf(uint64(topK)),
-		WithPayload:    qdrant.NewWithPayload(true),
+		Offset:         qdrant.PtrOf(uint64(offset)),
+		WithPayload:    payloadSelector(opts),
+		WithVectors:    withDenseVector(opts.WithVectors),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to hybrid search: %w", err)
@@ -316,28 +1003,8 @@ func (s *QdrantStore) HybridSearch(ctx context.Context, tenantID string, denseVe
 
 	results := make([]SearchResult, 0, len(response))
 	for _, point := range response {
-		result := SearchResult{
-			ID:       point.Id.GetUuid(),
-			Score:    point.Score,
-			Metadata: make(map[string]string),
-		}
-
-		if payload := point.Payload; payload != nil {
-			if docID, ok := payload["document_id"]; ok {
-				result.DocumentID = docID.GetStringValue()
-			}
-			if content, ok := payload["content"]; ok {
-				result.Content = content.GetStringValue()
-			}
-			for k, v := range payload {
-				if k != "document_id" && k != "content" {
-					result.Metadata[k] = v.GetStringValue()
-				}
-			}
-		}
-
 		// Skip results below minScore threshold
-		if result.Score >= minScore {
+		if result := pointResult(point); result.Score >= minScore {
 			results = append(results, result)
 		}
 	}
@@ -345,5 +1012,14 @@ func (s *QdrantStore) HybridSearch(ctx context.Context, tenantID string, denseVe
 	return results, nil
 }
 
+// withDenseVector selects the dense vector of a hybrid collection's points,
+// leaving out the sparse one
+func withDenseVector(enabled bool) *qdrant.WithVectorsSelector {
+	if !enabled {
+		return qdrant.NewWithVectors(false)
+	}
+	return qdrant.NewWithVectorsInclude(denseVectorName)
+}
+
 // Ensure QdrantStore implements VectorStore
 var _ VectorStore = (*QdrantStore)(nil)
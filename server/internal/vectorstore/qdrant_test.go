@@ -0,0 +1,35 @@
+package vectorstore
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPayloadSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		include []string
+		exclude []string
+	}{
+		{"everything by default", SearchOptions{}, nil, nil},
+		{"include keeps id and content", SearchOptions{IncludeFields: []string{"title"}}, []string{"document_id", "content", "chunk_id", "title"}, nil},
+		{"exclude narrows include", SearchOptions{IncludeFields: []string{"title", "url"}, ExcludeFields: []string{"url"}}, []string{"document_id", "content", "chunk_id", "title"}, nil},
+		{"exclude cannot drop reserved fields", SearchOptions{ExcludeFields: []string{"content", "url"}}, nil, []string{"url"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := payloadSelector(tt.opts)
+			if tt.include == nil && tt.exclude == nil && !sel.GetEnable() {
+				t.Fatalf("expected full payload, got %v", sel)
+			}
+			if got := sel.GetInclude().GetFields(); !slices.Equal(got, tt.include) {
+				t.Errorf("include = %v, want %v", got, tt.include)
+			}
+			if got := sel.GetExclude().GetFields(); !slices.Equal(got, tt.exclude) {
+				t.Errorf("exclude = %v, want %v", got, tt.exclude)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of attempts per Qdrant call
+	DefaultMaxAttempts = 3
+
+	// DefaultInitialBackoff is the default delay before the first retry
+	DefaultInitialBackoff = 200 * time.Millisecond
+
+	// DefaultMaxBackoff caps the delay between retries
+	DefaultMaxBackoff = 5 * time.Second
+
+	// DefaultReconnectMaxDelay caps the delay between reconnection attempts
+	// after the connection to Qdrant drops
+	DefaultReconnectMaxDelay = 5 * time.Second
+)
+
+// RetryConfig controls how transient Qdrant failures are retried.
+// Zero values are replaced with the defaults above.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first (1 disables retries)
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry; it doubles with each attempt
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = DefaultInitialBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+	return c
+}
+
+// backoff returns the delay before the given retry (1 for the first retry),
+// randomized by ±20% so concurrent calls do not retry in lockstep
+func (c RetryConfig) backoff(retry int) time.Duration {
+	delay := c.InitialBackoff << (retry - 1)
+	if delay <= 0 || delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	spread := float64(delay) * 0.2
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
+
+// ConnectionConfig tunes the gRPC connections to Qdrant. Zero values keep the
+// client defaults.
+type ConnectionConfig struct {
+	// PoolSize is the number of connections; calls are spread round-robin (default 3)
+	PoolSize int
+
+	// KeepAliveTime is the idle time after which the connection is pinged
+	// (default 10s); negative disables keepalive
+	KeepAliveTime time.Duration
+
+	// KeepAliveTimeout is how long to wait for a ping reply before the
+	// connection is considered dead and re-established (default 2s)
+	KeepAliveTimeout time.Duration
+
+	// ReconnectMaxDelay caps the delay between reconnection attempts
+	ReconnectMaxDelay time.Duration
+}
+
+// WithRetry sets how transient failures (unavailable, overloaded or aborted
+// calls) are retried
+func WithRetry(cfg RetryConfig) QdrantOption {
+	return func(s *QdrantStore) {
+		s.retry = cfg
+	}
+}
+
+// WithConnection tunes connection pooling, keepalive and reconnection
+func WithConnection(cfg ConnectionConfig) QdrantOption {
+	return func(s *QdrantStore) {
+		s.conn = cfg
+	}
+}
+
+// seconds converts a duration to whole seconds, rounding up so short
+// durations are not mistaken for "use the default"
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// dialOptions returns the gRPC options for reconnection and retries
+func (s *QdrantStore) dialOptions() []grpc.DialOption {
+	reconnect := backoff.DefaultConfig
+	reconnect.MaxDelay = s.conn.ReconnectMaxDelay
+	if reconnect.MaxDelay <= 0 {
+		reconnect.MaxDelay = DefaultReconnectMaxDelay
+	}
+	if reconnect.BaseDelay > reconnect.MaxDelay {
+		reconnect.BaseDelay = reconnect.MaxDelay
+	}
+
+	return []grpc.DialOption{
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: reconnect, MinConnectTimeout: 5 * time.Second}),
+		grpc.WithChainUnaryInterceptor(retryInterceptor(s.retry.withDefaults())),
+	}
+}
+
+// retryInterceptor retries calls that failed with a transient status and
+// classifies the final error as ErrUnavailable or ErrCollectionNotFound. A
+// dropped connection surfaces as Unavailable while gRPC reconnects, so the
+// retries also ride out Qdrant restarts.
+func retryInterceptor(cfg RetryConfig) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !isTransient(err) || attempt >= cfg.MaxAttempts {
+				return classify(err)
+			}
+
+			timer := time.NewTimer(cfg.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return classify(err)
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// isTransient reports whether a gRPC error is worth retrying
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// classify wraps gRPC errors in the package's typed errors, keeping the
+// original status reachable through errors.As
+func classify(err error) error {
+	switch status.Code(err) {
+	case codes.OK:
+		return err
+	case codes.NotFound:
+		return fmt.Errorf("%w: %w", ErrCollectionNotFound, err)
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
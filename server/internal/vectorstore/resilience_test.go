@@ -0,0 +1,54 @@
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryInterceptor(t *testing.T) {
+	cfg := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	tests := []struct {
+		name     string
+		codes    []codes.Code // returned by successive attempts; OK ends the sequence
+		attempts int
+		wantErr  error
+	}{
+		{"recovers after reconnect", []codes.Code{codes.Unavailable, codes.OK}, 2, nil},
+		{"gives up when unavailable", []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable}, 3, ErrUnavailable},
+		{"does not retry not found", []codes.Code{codes.NotFound}, 1, ErrCollectionNotFound},
+		{"does not retry invalid argument", []codes.Code{codes.InvalidArgument}, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				code := tt.codes[attempts]
+				attempts++
+				if code == codes.OK {
+					return nil
+				}
+				return status.Error(code, code.String())
+			}
+
+			err := retryInterceptor(cfg)(context.Background(), "/qdrant.Points/Search", nil, nil, nil, invoker)
+			if attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			last := tt.codes[len(tt.codes)-1]
+			if got := status.Code(err); got != last {
+				t.Errorf("status code = %v, want %v", got, last)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package vectorstore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSConfig enables TLS to Qdrant, e.g. for Qdrant Cloud or a TLS-terminated cluster
+type TLSConfig struct {
+	// CAFile is a PEM bundle of CAs to trust instead of the system roots
+	CAFile string
+
+	// ServerName overrides the host name used to verify the server certificate
+	ServerName string
+
+	// InsecureSkipVerify disables certificate verification; for testing only
+	InsecureSkipVerify bool
+}
+
+// WithTLS connects to Qdrant over TLS
+func WithTLS(cfg TLSConfig) QdrantOption {
+	return func(s *QdrantStore) {
+		s.tls = &cfg
+	}
+}
+
+// WithAPIKey authenticates every request with a Qdrant API key. Use it with
+// TLS; the key is otherwise sent in plaintext.
+func WithAPIKey(key string) QdrantOption {
+	return func(s *QdrantStore) {
+		s.apiKey = key
+	}
+}
+
+// build returns the crypto/tls configuration, loading the CA bundle if set
+func (c TLSConfig) build() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read qdrant CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in qdrant CA bundle %s", c.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// splitScheme strips an http:// or https:// prefix from a Qdrant address and
+// reports whether it asked for TLS
+func splitScheme(url string) (string, bool) {
+	if rest, ok := strings.CutPrefix(url, "https://"); ok {
+		return strings.TrimSuffix(rest, "/"), true
+	}
+	if rest, ok := strings.CutPrefix(url, "http://"); ok {
+		return strings.TrimSuffix(rest, "/"), false
+	}
+	return url, false
+}
@@ -0,0 +1,21 @@
+package vectorstore
+
+import "testing"
+
+func TestSplitScheme(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantAddr string
+		wantTLS  bool
+	}{
+		{"localhost:6334", "localhost:6334", false},
+		{"http://qdrant:6334", "qdrant:6334", false},
+		{"https://xyz.cloud.qdrant.io:6334/", "xyz.cloud.qdrant.io:6334", true},
+	}
+	for _, tt := range tests {
+		addr, useTLS := splitScheme(tt.url)
+		if addr != tt.wantAddr || useTLS != tt.wantTLS {
+			t.Errorf("splitScheme(%q) = %q, %v; want %q, %v", tt.url, addr, useTLS, tt.wantAddr, tt.wantTLS)
+		}
+	}
+}
@@ -3,8 +3,20 @@ package vectorstore
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrDimensionMismatch is returned when a tenant's embedding dimension does not
+// match the shared collection's vector size
+var ErrDimensionMismatch = errors.New("embedding dimension does not match collection")
+
+// ErrCollectionNotFound is returned when a tenant's collection does not exist
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// ErrUnavailable is returned when the vector store cannot be reached or is
+// overloaded after retries; callers may degrade or ask clients to retry
+var ErrUnavailable = errors.New("vector store unavailable")
+
 // SparseVector represents a sparse vector with indices and values
 type SparseVector struct {
 	Indices []uint32
@@ -13,13 +25,36 @@ type SparseVector struct {
 
 // Chunk represents a document chunk with its embedding
 type Chunk struct {
-	ID           string
-	DocumentID   string
-	TenantID     string
-	Content      string
-	Vector       []float32     // Dense vector from embedding model
-	SparseVector *SparseVector // Optional sparse vector for keyword search
-	Metadata     map[string]string
+	ID            string
+	DocumentID    string
+	TenantID      string
+	Content       string
+	Vector        []float32     // Dense vector from embedding model
+	SparseVector  *SparseVector // Optional sparse vector for keyword search
+	Metadata      map[string]string
+	Tags          []string // The document's tags, for filtered search
+	CollectionIDs []string // The collections the document is in, for filtered search
+	ProjectID     string   // The document's project, for filtered search; empty for none
+}
+
+// Filter restricts a search to some of a tenant's points. The zero value
+// matches everything.
+type Filter struct {
+	Tags          []string // points whose document has at least one of these tags
+	CollectionIDs []string // points whose document is in at least one of these collections
+	Languages     []string // points whose "language" metadata is one of these
+	ProjectID     string   // points of this project's documents; empty for any
+}
+
+// SearchOptions controls which points a search matches and what it returns
+// for each. The zero value matches everything and returns all metadata
+// without vectors.
+type SearchOptions struct {
+	Filter        Filter
+	Offset        int      // Skip this many of the best results, for pagination
+	WithVectors   bool     // Return each result's dense vector
+	IncludeFields []string // Return only these metadata fields; empty returns all
+	ExcludeFields []string // Leave these metadata fields out
 }
 
 // SearchResult represents a search result from the vector store
@@ -29,15 +64,30 @@ type SearchResult struct {
 	Content    string
 	Score      float32
 	Metadata   map[string]string
+	Vector     []float32 // Dense vector, set only when SearchOptions.WithVectors is
+}
+
+// Quantization modes for StorageConfig
+const (
+	QuantizationNone   = ""
+	QuantizationScalar = "scalar" // int8 per dimension; ~4x less memory, negligible recall loss
+	QuantizationBinary = "binary" // 1 bit per dimension; ~32x less memory, best for 1024+ dimensions
+)
+
+// StorageConfig controls how a collection's vectors and payloads are stored
+type StorageConfig struct {
+	Quantization  string // QuantizationNone, QuantizationScalar or QuantizationBinary
+	OnDiskVectors bool   // Keep original vectors on disk (mmap); quantized vectors stay in RAM
+	OnDiskPayload bool   // Keep payloads (chunk text and metadata) on disk
 }
 
 // VectorStore defines the interface for vector storage operations
 type VectorStore interface {
 	// CreateCollection creates a new collection for a tenant (dense vectors only)
-	CreateCollection(ctx context.Context, tenantID string, dimension int) error
+	CreateCollection(ctx context.Context, tenantID string, dimension int, storage StorageConfig) error
 
 	// CreateHybridCollection creates a collection with both dense and sparse vector support
-	CreateHybridCollection(ctx context.Context, tenantID string, dimension int) error
+	CreateHybridCollection(ctx context.Context, tenantID string, dimension int, storage StorageConfig) error
 
 	// DeleteCollection deletes a tenant's collection
 	DeleteCollection(ctx context.Context, tenantID string) error
@@ -45,18 +95,47 @@ type VectorStore interface {
 	// CollectionExists checks if a collection exists
 	CollectionExists(ctx context.Context, tenantID string) (bool, error)
 
+	// CountVectors returns the number of points in a tenant's collection
+	CountVectors(ctx context.Context, tenantID string) (uint64, error)
+
 	// Upsert inserts or updates chunks in the vector store
 	Upsert(ctx context.Context, tenantID string, chunks []Chunk) error
 
 	// Search performs similarity search using dense vectors only
-	Search(ctx context.Context, tenantID string, vector []float32, topK int, minScore float32) ([]SearchResult, error)
+	Search(ctx context.Context, tenantID string, vector []float32, topK int, minScore float32, opts SearchOptions) ([]SearchResult, error)
 
 	// HybridSearch performs hybrid search combining dense and sparse vectors with RRF fusion
-	HybridSearch(ctx context.Context, tenantID string, denseVector []float32, sparseVector *SparseVector, topK int, minScore float32) ([]SearchResult, error)
+	HybridSearch(ctx context.Context, tenantID string, denseVector []float32, sparseVector *SparseVector, topK int, minScore float32, opts SearchOptions) ([]SearchResult, error)
+
+	// SetDocumentTags replaces the tags stored with a document's chunks
+	SetDocumentTags(ctx context.Context, tenantID, documentID string, tags []string) error
+
+	// SetDocumentCollections replaces the collection IDs stored with a document's chunks
+	SetDocumentCollections(ctx context.Context, tenantID, documentID string, collectionIDs []string) error
+
+	// SetChunkLists replaces the tags and collection IDs stored with specific
+	// chunks, such as those whose vectors several documents share
+	SetChunkLists(ctx context.Context, tenantID string, chunkIDs, tags, collectionIDs []string) error
 
 	// Delete removes chunks by document ID
 	Delete(ctx context.Context, tenantID string, documentID string) error
 
 	// DeleteByIDs removes specific chunks by their IDs
 	DeleteByIDs(ctx context.Context, tenantID string, ids []string) error
+
+	// CreateCollectionVersion creates a dense collection for rebuilding a tenant's vectors.
+	// The version is not searched until SwitchCollectionVersion is called.
+	CreateCollectionVersion(ctx context.Context, tenantID, version string, dimension int, storage StorageConfig) error
+
+	// UpsertVersion inserts or updates chunks in a collection version
+	UpsertVersion(ctx context.Context, tenantID, version string, chunks []Chunk) error
+
+	// DeleteVersionDocument removes a document's chunks from a collection version
+	DeleteVersionDocument(ctx context.Context, tenantID, version, documentID string) error
+
+	// SwitchCollectionVersion points the tenant at the given version and drops the previous collection
+	SwitchCollectionVersion(ctx context.Context, tenantID, version string) error
+
+	// DeleteCollectionVersion deletes a collection version (e.g., after a failed rebuild)
+	DeleteCollectionVersion(ctx context.Context, tenantID, version string) error
 }